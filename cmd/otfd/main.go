@@ -13,6 +13,8 @@ import (
 	"github.com/leg100/otf/internal/github"
 	"github.com/leg100/otf/internal/gitlab"
 	"github.com/leg100/otf/internal/logr"
+	"github.com/leg100/otf/internal/provider"
+	"github.com/leg100/otf/internal/pubsub"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
@@ -77,6 +79,12 @@ func parseFlags(ctx context.Context, args []string, out io.Writer) error {
 	cmd.Flags().IntVar(&cfg.CacheConfig.Size, "cache-size", 0, "Maximum cache size in MB. 0 means unlimited size.")
 	cmd.Flags().DurationVar(&cfg.CacheConfig.TTL, "cache-expiry", internal.DefaultCacheTTL, "Cache entry TTL.")
 
+	cmd.Flags().DurationVar(&cfg.EventRetention, "event-retention", pubsub.DefaultEventRetention, "How long events are retained for replay to reconnecting clients. 0 retains events indefinitely.")
+	cmd.Flags().DurationVar(&cfg.EventCompactionInterval, "event-compaction-interval", pubsub.DefaultEventCompactionInterval, "Interval between deleting events older than the retention period.")
+
+	cmd.Flags().StringVar((*string)(&cfg.PubSubTransport), "pubsub-transport", string(pubsub.DefaultTransport), "Transport for relaying events between nodes: notify or poll.")
+	cmd.Flags().DurationVar(&cfg.PubSubPollInterval, "pubsub-poll-interval", pubsub.DefaultPollInterval, "Interval between polling for events when using the poll transport.")
+
 	cmd.Flags().BoolVar(&cfg.SSL, "ssl", false, "Toggle SSL")
 	cmd.Flags().StringVar(&cfg.CertFile, "cert-file", "", "Path to SSL certificate (required if enabling SSL)")
 	cmd.Flags().StringVar(&cfg.KeyFile, "key-file", "", "Path to SSL key (required if enabling SSL)")
@@ -98,6 +106,15 @@ func parseFlags(ctx context.Context, args []string, out io.Writer) error {
 	cmd.Flags().StringSliceVar(&cfg.OIDC.Scopes, "oidc-scopes", authenticator.DefaultOIDCScopes, "OIDC scopes")
 	cmd.Flags().StringVar(&cfg.OIDC.UsernameClaim, "oidc-username-claim", string(authenticator.DefaultUsernameClaim), "OIDC claim to be used for username (name, email, or sub)")
 
+	cmd.Flags().StringVar(&cfg.SAML.Name, "saml-name", "saml", "User friendly SAML identity provider name")
+	cmd.Flags().StringVar(&cfg.SAML.IDPMetadataURL, "saml-idp-metadata-url", "", "SAML identity provider metadata URL")
+	cmd.Flags().StringVar(&cfg.SAML.UsernameAttribute, "saml-username-attribute", "", "SAML assertion attribute to be used for username (defaults to NameID)")
+	cmd.Flags().StringVar(&cfg.SAML.TeamsAttribute, "saml-teams-attribute", "", "SAML assertion attribute listing groups to be mapped to teams by their SSO team ID")
+
+	cmd.Flags().StringVar(&cfg.ProviderMirror.Dir, "provider-mirror-dir", provider.DefaultMirrorDir, "Directory in which the provider network mirror stores provider packages. Can be pre-seeded using terraform providers mirror.")
+	cmd.Flags().BoolVar(&cfg.ProviderMirror.Offline, "provider-mirror-offline", false, "Only serve provider packages already in the provider network mirror directory, without retrieving them from upstream registries.")
+	cmd.Flags().StringSliceVar(&cfg.ProviderMirror.UpstreamHosts, "provider-mirror-upstream-hosts", provider.DefaultMirrorUpstreamHosts, "Hostnames of upstream registries from which the provider network mirror retrieves provider packages.")
+
 	cmd.Flags().BoolVar(&cfg.RestrictOrganizationCreation, "restrict-org-creation", false, "Restrict organization creation capability to site admin role")
 
 	cmd.Flags().StringVar(&cfg.GoogleIAPConfig.Audience, "google-jwt-audience", "", "The Google JWT audience claim for validation. If unspecified then validation is skipped")
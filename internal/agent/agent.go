@@ -7,13 +7,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"os"
 	"os/exec"
-	"path/filepath"
+	"slices"
 
 	"github.com/go-logr/logr"
 	"github.com/leg100/otf/internal"
 	"github.com/leg100/otf/internal/releases"
+	"github.com/leg100/otf/internal/secrets"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -22,13 +22,10 @@ const (
 	DefaultConcurrency = 5
 )
 
-var (
-	PluginCacheDir = filepath.Join(os.TempDir(), "plugin-cache")
-	DefaultEnvs    = []string{
-		"TF_IN_AUTOMATION=true",
-		"CHECKPOINT_DISABLE=true",
-	}
-)
+var DefaultEnvs = []string{
+	"TF_IN_AUTOMATION=true",
+	"CHECKPOINT_DISABLE=true",
+}
 
 // agent processes runs.
 type agent struct {
@@ -40,7 +37,10 @@ type agent struct {
 	spooler     // spools new run events
 	*terminator // terminates runs
 
-	envs []string // terraform environment variables
+	moduleTester *moduleTester // tests module versions; nil if not supported
+
+	envs    []string          // terraform environment variables
+	secrets *secrets.Resolver // resolves secret references in variables
 }
 
 // NewAgent is the constructor for an agent
@@ -61,22 +61,37 @@ func NewAgent(logger logr.Logger, app client, cfg Config) (*agent, error) {
 		logger.V(0).Info("enabled debug mode")
 	}
 
+	if !cfg.External {
+		// the file and env providers would expose the filesystem and
+		// environment of otfd, including its own secrets, to anyone who can
+		// set a variable.
+		for _, scheme := range []string{secrets.FileScheme, secrets.EnvScheme} {
+			if slices.Contains(cfg.Secrets.Enabled, scheme) {
+				return nil, fmt.Errorf("%s secret provider can only be enabled on an external agent", scheme)
+			}
+		}
+	}
+	resolver, err := secrets.NewResolver(cfg.Secrets)
+	if err != nil {
+		return nil, err
+	}
+	if len(cfg.Secrets.Enabled) > 0 {
+		logger.V(0).Info("enabled secret providers", "providers", cfg.Secrets.Enabled)
+	}
+
 	agent := &agent{
 		client:     app,
 		Config:     cfg,
 		Logger:     logger,
 		Downloader: releases.NewDownloader(cfg.TerraformBinDir),
 		envs:       DefaultEnvs,
+		secrets:    resolver,
 		spooler:    newSpooler(app, logger, cfg),
 		terminator: newTerminator(),
 	}
-
-	if cfg.PluginCache {
-		if err := os.MkdirAll(PluginCacheDir, 0o755); err != nil {
-			return nil, fmt.Errorf("creating plugin cache directory: %w", err)
-		}
-		agent.envs = append(agent.envs, "TF_PLUGIN_CACHE_DIR="+PluginCacheDir)
-		logger.V(0).Info("enabled plugin cache", "path", PluginCacheDir)
+	// Only the client of an internal agent permits testing module versions.
+	if client, ok := app.(moduleTestClient); ok {
+		agent.moduleTester = &moduleTester{agent: agent, client: client}
 	}
 
 	return agent, nil
@@ -120,6 +135,12 @@ func (a *agent) Start(ctx context.Context) error {
 		return nil
 	})
 
+	if a.moduleTester != nil {
+		g.Go(func() error {
+			return a.moduleTester.start(ctx)
+		})
+	}
+
 	g.Go(func() error {
 		for i := 0; i < a.Concurrency; i++ {
 			w := &worker{a}
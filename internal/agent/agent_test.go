@@ -1,9 +1,13 @@
 package agent
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/go-logr/logr"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/secrets"
+	"github.com/spf13/pflag"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -13,3 +17,29 @@ func TestNewAgent_SetDefaultConcurrency(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, 5, agent.Concurrency)
 }
+
+func TestNewAgent_LocalSecretProviders(t *testing.T) {
+	for _, scheme := range []string{secrets.FileScheme, secrets.EnvScheme} {
+		t.Run(scheme, func(t *testing.T) {
+			cfg := Config{Secrets: secrets.Config{Enabled: []string{scheme}}}
+			_, err := NewAgent(logr.Discard(), nil, cfg)
+			assert.Error(t, err)
+
+			cfg.External = true
+			cfg.Organization = internal.String("acme-corp")
+			_, err = NewAgent(logr.Discard(), nil, cfg)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestNewConfigFromFlags_DeprecatedPluginCache(t *testing.T) {
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	var stderr bytes.Buffer
+	flags.SetOutput(&stderr)
+	NewConfigFromFlags(flags)
+
+	require.NoError(t, flags.Parse([]string{"--plugin-cache"}))
+	assert.Contains(t, stderr.String(), "--plugin-cache has been deprecated")
+	assert.True(t, flags.Lookup("plugin-cache").Hidden)
+}
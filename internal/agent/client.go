@@ -7,6 +7,7 @@ import (
 	otfapi "github.com/leg100/otf/internal/api"
 	"github.com/leg100/otf/internal/configversion"
 	"github.com/leg100/otf/internal/logs"
+	"github.com/leg100/otf/internal/module"
 	"github.com/leg100/otf/internal/pubsub"
 	"github.com/leg100/otf/internal/resource"
 	"github.com/leg100/otf/internal/run"
@@ -30,6 +31,7 @@ type (
 		UploadPlanFile(ctx context.Context, id string, plan []byte, format run.PlanFormat) error
 		GetLockFile(ctx context.Context, id string) ([]byte, error)
 		UploadLockFile(ctx context.Context, id string, lockFile []byte) error
+		UploadTestResults(ctx context.Context, id string, results []byte) error
 		ListRuns(ctx context.Context, opts run.ListOptions) (*resource.Page[*run.Run], error)
 		StartPhase(ctx context.Context, id string, phase internal.PhaseType, opts run.PhaseStartOptions) (*run.Run, error)
 		FinishPhase(ctx context.Context, id string, phase internal.PhaseType, opts run.PhaseFinishOptions) (*run.Run, error)
@@ -53,6 +55,7 @@ type (
 		configversion.ConfigurationVersionService
 		run.RunService
 		logs.LogsService
+		module.ModuleService
 	}
 
 	// remoteClient is the client for an external agent.
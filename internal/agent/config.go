@@ -2,19 +2,23 @@ package agent
 
 import (
 	"github.com/leg100/otf/internal/api"
+	"github.com/leg100/otf/internal/secrets"
 	"github.com/spf13/pflag"
 )
 
 type (
 	// Config is configuration for an agent.
 	Config struct {
-		Organization    *string // only process runs belonging to org
-		External        bool    // dedicated agent (true) or integrated into otfd (false)
-		Concurrency     int     // number of workers
-		Sandbox         bool    // isolate privileged ops within sandbox
-		Debug           bool    // toggle debug mode
-		PluginCache     bool    // toggle use of terraform's shared plugin cache
-		TerraformBinDir string  // destination directory for terraform binaries
+		Organization    *string        // only process runs belonging to org
+		External        bool           // dedicated agent (true) or integrated into otfd (false)
+		Concurrency     int            // number of workers
+		Sandbox         bool           // isolate privileged ops within sandbox
+		Debug           bool           // toggle debug mode
+		TerraformBinDir string         // destination directory for terraform binaries
+		Secrets         secrets.Config // resolution of secret references in variables
+		// install providers from these registries via otfd's provider network
+		// mirror; if empty then the mirror is not used.
+		ProviderMirrorHosts []string
 	}
 	// ExternalConfig is configuration for an external agent
 	ExternalConfig struct {
@@ -28,8 +32,17 @@ func NewConfigFromFlags(flags *pflag.FlagSet) *Config {
 	cfg := Config{}
 	flags.BoolVar(&cfg.Sandbox, "sandbox", false, "Isolate terraform apply within sandbox for additional security")
 	flags.BoolVar(&cfg.Debug, "debug", false, "Enable agent debug mode which dumps additional info to terraform runs.")
-	flags.BoolVar(&cfg.PluginCache, "plugin-cache", false, "Enable shared plugin cache for terraform providers.")
 	flags.IntVar(&cfg.Concurrency, "concurrency", DefaultConcurrency, "Number of runs that can be processed concurrently")
+	flags.StringSliceVar(&cfg.Secrets.Enabled, "secret-providers", nil, "Enable resolution of secret references in variables using these providers: vault, file, env")
+	flags.StringVar(&cfg.Secrets.Vault.Address, "vault-address", "", "Address of vault server for resolving vault:// secret references. Defaults to $VAULT_ADDR.")
+	flags.StringVar(&cfg.Secrets.Vault.Token, "vault-token", "", "Token for authenticating with vault. Defaults to $VAULT_TOKEN.")
+	flags.StringVar(&cfg.Secrets.Vault.Namespace, "vault-namespace", "", "Vault enterprise namespace. Defaults to $VAULT_NAMESPACE.")
+	flags.StringSliceVar(&cfg.ProviderMirrorHosts, "provider-mirror-hosts", nil, "Install providers from these registries via the provider network mirror on otfd. Disabled by default.")
+
+	// the plugin cache has been superseded by the provider network mirror;
+	// the flag is retained so that existing invocations continue to work.
+	flags.Bool("plugin-cache", false, "Enable shared plugin cache for terraform providers.")
+	flags.MarkDeprecated("plugin-cache", "providers are now installed via the provider network mirror on otfd; the flag has no effect and will be removed in a future release")
 	return &cfg
 }
 
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/go-logr/logr"
 	"github.com/hashicorp/go-multierror"
@@ -12,6 +14,8 @@ import (
 	"github.com/leg100/otf/internal/logs"
 	"github.com/leg100/otf/internal/releases"
 	"github.com/leg100/otf/internal/run"
+	"github.com/leg100/otf/internal/secrets"
+	"github.com/leg100/otf/internal/tfeapi"
 	"github.com/leg100/otf/internal/tokens"
 	"github.com/leg100/otf/internal/variable"
 	"github.com/pkg/errors"
@@ -30,8 +34,10 @@ type environment struct {
 	steps []step // sequence of steps to execute
 
 	ctx       context.Context      // contains subject for authenticating to services
+	cliConfig string               // path to terraform CLI config file
 	out       io.WriteCloser       // captures CLI process output
-	variables []*variable.Variable // terraform workspace variables
+	variables []*variable.Variable // workspace variables
+	secrets   *secrets.Resolver    // resolves secret references in variables
 
 	*executor // executes processes
 	*runner   // execute sequence of steps
@@ -68,17 +74,22 @@ func newEnvironment(
 	}
 	envs := internal.SafeAppend(agent.envs, internal.CredentialEnv(agent.Hostname(), token))
 
-	// retrieve variables and add them to the environment
+	// Configure terraform to install providers from the configured registries
+	// via otfd's provider network mirror.
+	cliConfig, err := writeCLIConfig(agent.Hostname(), agent.ProviderMirrorHosts)
+	if err != nil {
+		return nil, fmt.Errorf("writing terraform CLI config: %w", err)
+	}
+	if cliConfig != "" {
+		envs = append(envs, "TF_CLI_CONFIG_FILE="+cliConfig)
+	}
+
+	// retrieve variables, which are added to the environment once any secret
+	// references have been resolved.
 	variables, err := agent.ListEffectiveVariables(ctx, run.ID)
 	if err != nil {
 		return nil, fmt.Errorf("retrieving workspace variables: %w", err)
 	}
-	for _, v := range variables {
-		if v.Category == variable.CategoryEnv {
-			ev := fmt.Sprintf("%s=%s", v.Key, v.Value)
-			envs = append(envs, ev)
-		}
-	}
 
 	writer := logs.NewPhaseWriter(ctx, logs.PhaseWriterOptions{
 		RunID:  run.ID,
@@ -92,15 +103,18 @@ func newEnvironment(
 		client:     agent,
 		out:        writer,
 		workdir:    wd,
+		cliConfig:  cliConfig,
 		variables:  variables,
+		secrets:    agent.secrets,
 		ctx:        ctx,
 		runner:     &runner{out: writer},
 		executor: &executor{
-			Config:  agent.Config,
-			version: run.TerraformVersion,
-			out:     writer,
-			envs:    envs,
-			workdir: wd,
+			Config:    agent.Config,
+			version:   run.TerraformVersion,
+			out:       writer,
+			envs:      envs,
+			workdir:   wd,
+			cliConfig: cliConfig,
 		},
 	}
 
@@ -109,6 +123,52 @@ func newEnvironment(
 	return env, nil
 }
 
+// writeCLIConfig writes a terraform CLI config file to a temporary path,
+// configuring terraform to install providers from the given registry hosts via
+// otfd's provider network mirror, and all other providers directly. The path
+// to the file is returned, or an empty string if there are no hosts, in which
+// case no file is written and terraform's own CLI config is left untouched.
+func writeCLIConfig(hostname string, mirrorHosts []string) (string, error) {
+	if len(mirrorHosts) == 0 {
+		return "", nil
+	}
+	patterns := make([]string, len(mirrorHosts))
+	for i, host := range mirrorHosts {
+		patterns[i] = strconv.Quote(host + "/*/*")
+	}
+	f, err := os.CreateTemp("", "otf-terraformrc-")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	_, err = fmt.Fprintf(f, `provider_installation {
+  network_mirror {
+    url     = "https://%[1]s%[2]s"
+    include = [%[3]s]
+  }
+  direct {
+    exclude = [%[3]s]
+  }
+}
+`, hostname, tfeapi.ProviderMirrorPrefix, strings.Join(patterns, ", "))
+	if err != nil {
+		return "", err
+	}
+	return f.Name(), f.Close()
+}
+
+// close removes the working directory and the terraform CLI config file, if
+// any.
+func (e *environment) close() error {
+	if e.cliConfig != "" {
+		if err := os.Remove(e.cliConfig); err != nil {
+			return err
+		}
+	}
+	return e.workdir.close()
+}
+
 // execute executes a phase and regardless of whether it fails, it'll close its
 // logs.
 func (e *environment) execute() (err error) {
@@ -0,0 +1,37 @@
+package agent
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteCLIConfig(t *testing.T) {
+	t.Run("mirror disabled", func(t *testing.T) {
+		path, err := writeCLIConfig("otf.dev", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "", path)
+	})
+
+	t.Run("mirror hosts", func(t *testing.T) {
+		path, err := writeCLIConfig("otf.dev", []string{"registry.terraform.io", "registry.opentofu.org"})
+		require.NoError(t, err)
+		t.Cleanup(func() { os.Remove(path) })
+
+		got, err := os.ReadFile(path)
+		require.NoError(t, err)
+		want := `provider_installation {
+  network_mirror {
+    url     = "https://otf.dev/v1/mirror/providers/"
+    include = ["registry.terraform.io/*/*", "registry.opentofu.org/*/*"]
+  }
+  direct {
+    exclude = ["registry.terraform.io/*/*", "registry.opentofu.org/*/*"]
+  }
+}
+`
+		assert.Equal(t, want, string(got))
+	})
+}
@@ -20,10 +20,11 @@ type (
 	executor struct {
 		Config
 
-		version string // terraform cli version
-		out     io.Writer
-		envs    []string
-		workdir *workdir
+		version   string // terraform cli version
+		out       io.Writer
+		envs      []string
+		workdir   *workdir
+		cliConfig string // path to terraform CLI config file
 
 		*execution // current or last execution of a process
 	}
@@ -32,13 +33,15 @@ type (
 	execution struct {
 		Config
 
-		out     io.Writer
-		envs    []string
-		workdir *workdir
-		proc    *os.Process // current or last process
+		out       io.Writer
+		envs      []string
+		workdir   *workdir
+		cliConfig string      // path to terraform CLI config file
+		proc      *os.Process // current or last process
 
 		// options
 		redirectStdout   *string
+		stdout           io.Writer
 		sandboxIfEnabled bool
 	}
 
@@ -60,13 +63,21 @@ func redirectStdout(dst string) executionOption {
 	}
 }
 
+// stdoutWriter sends stdout to the writer rather than to the output.
+func stdoutWriter(w io.Writer) executionOption {
+	return func(e *execution) {
+		e.stdout = w
+	}
+}
+
 // execute executes a process.
 func (e *executor) execute(args []string, opts ...executionOption) error {
 	exe := execution{
-		Config:  e.Config,
-		out:     e.out,
-		envs:    e.envs,
-		workdir: e.workdir,
+		Config:    e.Config,
+		out:       e.out,
+		envs:      e.envs,
+		workdir:   e.workdir,
+		cliConfig: e.cliConfig,
 	}
 	for _, fn := range opts {
 		fn(&exe)
@@ -78,6 +89,13 @@ func (e *executor) execute(args []string, opts ...executionOption) error {
 	return nil
 }
 
+// test executes `terraform test` with the given flags. Tests may create real
+// infrastructure, so they are sandboxed like an apply.
+func (e *executor) test(terraformPath string, flags []string, opts ...executionOption) error {
+	args := append([]string{terraformPath, "test"}, flags...)
+	return e.execute(args, append(opts, sandboxIfEnabled())...)
+}
+
 func (e *execution) execute(args []string) error {
 	if len(args) == 0 {
 		return fmt.Errorf("missing command name")
@@ -96,6 +114,8 @@ func (e *execution) execute(args []string) error {
 		}
 		defer dst.Close()
 		cmd.Stdout = dst
+	} else if e.stdout != nil {
+		cmd.Stdout = e.stdout
 	} else {
 		cmd.Stdout = e.out
 	}
@@ -143,8 +163,8 @@ func (e *execution) addSandboxWrapper(args []string) []string {
 		// avoids provider error "failed to read schema..."
 		"--tmpfs", "/tmp",
 	}
-	if e.PluginCache {
-		bargs = append(bargs, "--ro-bind", PluginCacheDir, PluginCacheDir)
+	if e.cliConfig != "" {
+		bargs = append(bargs, "--ro-bind", e.cliConfig, e.cliConfig)
 	}
 	bargs = append(bargs, path.Join("/bin", path.Base(args[0])))
 	return append(bargs, args[1:]...)
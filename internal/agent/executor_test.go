@@ -86,7 +86,7 @@ func TestExecutor_execute(t *testing.T) {
 }
 
 func TestExecutor_addSandboxWrapper(t *testing.T) {
-	t.Run("without plugin cache", func(t *testing.T) {
+	t.Run("without cli config", func(t *testing.T) {
 		env := execution{
 			workdir: &workdir{root: "/root"},
 		}
@@ -105,12 +105,10 @@ func TestExecutor_addSandboxWrapper(t *testing.T) {
 		assert.Equal(t, want, env.addSandboxWrapper([]string{"/tmp/tf-bins/1.1.1/terraform", "apply", "-input=false", "-no-color"}))
 	})
 
-	t.Run("with plugin cache", func(t *testing.T) {
+	t.Run("with cli config", func(t *testing.T) {
 		env := execution{
-			Config: Config{
-				PluginCache: true,
-			},
-			workdir: &workdir{root: "/root"},
+			workdir:   &workdir{root: "/root"},
+			cliConfig: "/tmp/otf-terraformrc-123",
 		}
 		want := []string{
 			"bwrap",
@@ -121,7 +119,7 @@ func TestExecutor_addSandboxWrapper(t *testing.T) {
 			"--chdir", "/config",
 			"--proc", "/proc",
 			"--tmpfs", "/tmp",
-			"--ro-bind", PluginCacheDir, PluginCacheDir,
+			"--ro-bind", "/tmp/otf-terraformrc-123", "/tmp/otf-terraformrc-123",
 			"/bin/terraform", "apply",
 			"-input=false", "-no-color",
 		}
@@ -130,10 +128,8 @@ func TestExecutor_addSandboxWrapper(t *testing.T) {
 
 	t.Run("with relative working directory", func(t *testing.T) {
 		env := execution{
-			Config: Config{
-				PluginCache: true,
-			},
-			workdir: &workdir{root: "/root", relative: "/relative"},
+			workdir:   &workdir{root: "/root", relative: "/relative"},
+			cliConfig: "/tmp/otf-terraformrc-123",
 		}
 		want := []string{
 			"bwrap",
@@ -144,7 +140,7 @@ func TestExecutor_addSandboxWrapper(t *testing.T) {
 			"--chdir", "/config/relative",
 			"--proc", "/proc",
 			"--tmpfs", "/tmp",
-			"--ro-bind", PluginCacheDir, PluginCacheDir,
+			"--ro-bind", "/tmp/otf-terraformrc-123", "/tmp/otf-terraformrc-123",
 			"/bin/terraform", "apply",
 			"-input=false", "-no-color",
 		}
@@ -0,0 +1,160 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/module"
+	"github.com/leg100/otf/internal/releases"
+)
+
+// moduleTestInterval is the interval between checks for module versions
+// awaiting tests.
+const moduleTestInterval = 10 * time.Second
+
+type (
+	// moduleTestClient allows an agent to test module versions.
+	moduleTestClient interface {
+		ListPendingModuleTests(ctx context.Context) ([]*module.PendingModuleTest, error)
+		StartModuleTest(ctx context.Context, versionID string) ([]byte, error)
+		PutModuleTestOutput(ctx context.Context, versionID string, output []byte) error
+		FinishModuleTest(ctx context.Context, versionID string, passed bool) (*module.ModuleVersion, error)
+	}
+
+	// moduleTester runs `terraform test` on module versions awaiting tests
+	// before they are made available in the registry.
+	moduleTester struct {
+		*agent
+
+		client moduleTestClient
+	}
+
+	// moduleTestWriter uploads the output of a module version test.
+	moduleTestWriter struct {
+		ctx       context.Context
+		versionID string
+
+		moduleTestClient
+	}
+)
+
+// start periodically checks for module versions awaiting tests, and tests
+// them one at a time.
+func (t *moduleTester) start(ctx context.Context) error {
+	ticker := time.NewTicker(moduleTestInterval)
+	defer ticker.Stop()
+
+	for {
+		pending, err := t.client.ListPendingModuleTests(ctx)
+		if err != nil {
+			t.Error(err, "listing pending module tests")
+		}
+		for _, p := range pending {
+			t.test(ctx, p)
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// test claims and tests a module version, reporting whether the test passed.
+func (t *moduleTester) test(ctx context.Context, pending *module.PendingModuleTest) {
+	log := t.Logger.WithValues(
+		"module_version_id", pending.ModuleVersionID,
+		"module", fmt.Sprintf("%s/%s/%s", pending.Organization, pending.Name, pending.Provider),
+		"version", pending.Version,
+	)
+
+	tarball, err := t.client.StartModuleTest(ctx, pending.ModuleVersionID)
+	if errors.Is(err, module.ErrTestAlreadyStarted) {
+		// another agent has already claimed it
+		return
+	} else if err != nil {
+		log.Error(err, "starting module test")
+		return
+	}
+
+	log.Info("testing module version")
+
+	out := &moduleTestWriter{
+		ctx:              ctx,
+		versionID:        pending.ModuleVersionID,
+		moduleTestClient: t.client,
+	}
+	err = t.run(ctx, out, tarball)
+	if err != nil {
+		// write error message to output
+		fmt.Fprintf(out, "\nError: %s\n", err.Error())
+		log.Error(err, "testing module version")
+	}
+
+	if _, err := t.client.FinishModuleTest(ctx, pending.ModuleVersionID, err == nil); err != nil {
+		log.Error(err, "finishing module test")
+		return
+	}
+	log.Info("finished testing module version", "passed", err == nil)
+}
+
+// run unpacks the module tarball into a working directory and runs `terraform
+// test`, writing output to out.
+func (t *moduleTester) run(ctx context.Context, out io.Writer, tarball []byte) error {
+	wd, err := newWorkdir("")
+	if err != nil {
+		return err
+	}
+	defer wd.close()
+
+	if err := internal.Unpack(bytes.NewReader(tarball), wd.root); err != nil {
+		return fmt.Errorf("unable to unpack module: %w", err)
+	}
+
+	// terraform test requires terraform >= 1.6.0
+	version := releases.DefaultTerraformVersion
+	terraformPath, err := t.Download(ctx, version, out)
+	if err != nil {
+		return err
+	}
+
+	cliConfig, err := writeCLIConfig(t.Hostname(), t.ProviderMirrorHosts)
+	if err != nil {
+		return fmt.Errorf("writing terraform CLI config: %w", err)
+	}
+	envs := t.envs
+	if cliConfig != "" {
+		defer os.Remove(cliConfig)
+		envs = internal.SafeAppend(envs, "TF_CLI_CONFIG_FILE="+cliConfig)
+	}
+
+	exe := &executor{
+		Config:    t.Config,
+		version:   version,
+		out:       out,
+		envs:      envs,
+		workdir:   wd,
+		cliConfig: cliConfig,
+	}
+	if err := exe.execute([]string{terraformPath, "init", "-no-color"}); err != nil {
+		return err
+	}
+	return exe.test(terraformPath, []string{"-no-color"})
+}
+
+// Write uploads output from a test to the server.
+func (w *moduleTestWriter) Write(p []byte) (int, error) {
+	data := make([]byte, len(p))
+	copy(data, p)
+
+	if err := w.PutModuleTestOutput(w.ctx, w.versionID, data); err != nil {
+		return 0, fmt.Errorf("writing module test output: %w", err)
+	}
+	return len(p), nil
+}
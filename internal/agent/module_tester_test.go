@@ -0,0 +1,61 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/leg100/otf/internal/module"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModuleTester_test(t *testing.T) {
+	pending := &module.PendingModuleTest{ModuleVersionID: "modver-123"}
+
+	t.Run("already started", func(t *testing.T) {
+		client := &fakeModuleTestClient{startErr: module.ErrTestAlreadyStarted}
+		tester := &moduleTester{agent: &agent{Logger: logr.Discard()}, client: client}
+
+		tester.test(context.Background(), pending)
+
+		assert.Nil(t, client.passed)
+	})
+
+	t.Run("invalid tarball", func(t *testing.T) {
+		client := &fakeModuleTestClient{tarball: []byte("not-a-tarball")}
+		tester := &moduleTester{agent: &agent{Logger: logr.Discard()}, client: client}
+
+		tester.test(context.Background(), pending)
+
+		if assert.NotNil(t, client.passed) {
+			assert.False(t, *client.passed)
+		}
+		assert.Contains(t, client.output.String(), "Error: unable to unpack module")
+	})
+}
+
+type fakeModuleTestClient struct {
+	tarball  []byte
+	startErr error
+	output   bytes.Buffer
+	passed   *bool
+}
+
+func (f *fakeModuleTestClient) ListPendingModuleTests(context.Context) ([]*module.PendingModuleTest, error) {
+	return nil, nil
+}
+
+func (f *fakeModuleTestClient) StartModuleTest(context.Context, string) ([]byte, error) {
+	return f.tarball, f.startErr
+}
+
+func (f *fakeModuleTestClient) PutModuleTestOutput(_ context.Context, _ string, output []byte) error {
+	f.output.Write(output)
+	return nil
+}
+
+func (f *fakeModuleTestClient) FinishModuleTest(_ context.Context, _ string, passed bool) (*module.ModuleVersion, error) {
+	f.passed = &passed
+	return nil, nil
+}
@@ -37,6 +37,10 @@ type (
 		client                        // Application for retrieving queued runs
 		logr.Logger
 		Config
+
+		// ID of the last event received, for resuming the stream of events
+		// upon reinitializing.
+		lastEventID int64
 	}
 
 	cancelation struct {
@@ -78,8 +82,11 @@ func (s *spoolerDaemon) getCancelation() <-chan cancelation {
 }
 
 func (s *spoolerDaemon) reinitialize(ctx context.Context) error {
+	// resume the stream of events, to ensure events are not missed while
+	// reinitializing, e.g. cancelations
 	sub, err := s.Watch(ctx, otfrun.WatchOptions{
 		Organization: s.Organization,
+		LastEventID:  s.lastEventID,
 	})
 	if err != nil {
 		return err
@@ -89,7 +96,7 @@ func (s *spoolerDaemon) reinitialize(ctx context.Context) error {
 	existing, err := resource.ListAll(func(opts resource.PageOptions) (*resource.Page[*otfrun.Run], error) {
 		return s.ListRuns(ctx, otfrun.ListOptions{
 			PageOptions:  opts,
-			Statuses:     []otfrun.Status{otfrun.RunPlanQueued, otfrun.RunApplyQueued},
+			Statuses:     []otfrun.Status{otfrun.RunPlanQueued, otfrun.RunApplyQueued, otfrun.RunTestQueued},
 			Organization: s.Organization,
 		})
 	})
@@ -117,6 +124,9 @@ func (s *spoolerDaemon) reinitialize(ctx context.Context) error {
 }
 
 func (s *spoolerDaemon) handleEvent(ev pubsub.Event) error {
+	if ev.ID != 0 {
+		s.lastEventID = ev.ID
+	}
 	switch payload := ev.Payload.(type) {
 	case *otfrun.Run:
 		s.handleRun(ev.Type, payload)
@@ -14,17 +14,19 @@ import (
 func TestSpooler(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 
-	// run[1-2] are in the DB; run[3-5] are events
+	// run[1-3] and a run that is not queued are in the DB; run[4-6] are events
 	run1 := &run.Run{ExecutionMode: workspace.RemoteExecutionMode, Status: run.RunPlanQueued}
-	run2 := &run.Run{ExecutionMode: workspace.RemoteExecutionMode, Status: run.RunPlanQueued}
-	run3 := &run.Run{ExecutionMode: workspace.RemoteExecutionMode, Status: run.RunPlanQueued}
-	run4 := &run.Run{ExecutionMode: workspace.RemoteExecutionMode, Status: run.RunCanceled}
-	run5 := &run.Run{ExecutionMode: workspace.RemoteExecutionMode, Status: run.RunForceCanceled}
-	db := []*run.Run{run1, run2}
+	run2 := &run.Run{ExecutionMode: workspace.RemoteExecutionMode, Status: run.RunApplyQueued}
+	run3 := &run.Run{ExecutionMode: workspace.RemoteExecutionMode, Status: run.RunTestQueued}
+	planned := &run.Run{ExecutionMode: workspace.RemoteExecutionMode, Status: run.RunPlanned}
+	run4 := &run.Run{ExecutionMode: workspace.RemoteExecutionMode, Status: run.RunPlanQueued}
+	run5 := &run.Run{ExecutionMode: workspace.RemoteExecutionMode, Status: run.RunCanceled}
+	run6 := &run.Run{ExecutionMode: workspace.RemoteExecutionMode, Status: run.RunForceCanceled}
+	db := []*run.Run{run1, run2, planned, run3}
 	events := make(chan pubsub.Event, 3)
-	events <- pubsub.Event{Payload: run3}
 	events <- pubsub.Event{Payload: run4}
 	events <- pubsub.Event{Payload: run5}
+	events <- pubsub.Event{Payload: run6}
 
 	spooler := newSpooler(
 		&fakeSpoolerApp{runs: db, events: events},
@@ -34,14 +36,15 @@ func TestSpooler(t *testing.T) {
 	errch := make(chan error)
 	go func() { errch <- spooler.reinitialize(ctx) }()
 
-	// expect to receive runs from DB in reverse order
+	// expect to receive queued runs from DB in reverse order
+	assert.Equal(t, run3, <-spooler.getRun())
 	assert.Equal(t, run2, <-spooler.getRun())
 	assert.Equal(t, run1, <-spooler.getRun())
 
 	// expect afterwards to receive runs from events
-	assert.Equal(t, run3, <-spooler.getRun())
-	assert.Equal(t, cancelation{Run: run4, Forceful: false}, <-spooler.getCancelation())
-	assert.Equal(t, cancelation{Run: run5, Forceful: true}, <-spooler.getCancelation())
+	assert.Equal(t, run4, <-spooler.getRun())
+	assert.Equal(t, cancelation{Run: run5, Forceful: false}, <-spooler.getCancelation())
+	assert.Equal(t, cancelation{Run: run6, Forceful: true}, <-spooler.getCancelation())
 	cancel()
 	assert.Equal(t, pubsub.ErrSubscriptionTerminated, <-errch)
 }
@@ -2,6 +2,7 @@ package agent
 
 import (
 	"context"
+	"slices"
 
 	"github.com/leg100/otf/internal/pubsub"
 	"github.com/leg100/otf/internal/resource"
@@ -16,7 +17,13 @@ type fakeSpoolerApp struct {
 }
 
 func (a *fakeSpoolerApp) ListRuns(ctx context.Context, opts run.ListOptions) (*resource.Page[*run.Run], error) {
-	return resource.NewPage(a.runs, opts.PageOptions, nil), nil
+	var runs []*run.Run
+	for _, r := range a.runs {
+		if opts.Statuses == nil || slices.Contains(opts.Statuses, r.Status) {
+			runs = append(runs, r)
+		}
+	}
+	return resource.NewPage(runs, opts.PageOptions, nil), nil
 }
 
 func (a *fakeSpoolerApp) Watch(ctx context.Context, opts run.WatchOptions) (<-chan pubsub.Event, error) {
@@ -49,6 +49,7 @@ func buildSteps(env *environment, run *run.Run) (steps []step) {
 	// default setup steps
 	steps = append(steps, bldr.downloadTerraform)
 	steps = append(steps, bldr.downloadConfig)
+	steps = append(steps, bldr.resolveVariables)
 	steps = append(steps, bldr.writeTerraformVars)
 	steps = append(steps, bldr.deleteBackendConfig)
 	steps = append(steps, bldr.downloadState)
@@ -68,6 +69,9 @@ func buildSteps(env *environment, run *run.Run) (steps []step) {
 		steps = append(steps, bldr.downloadPlanFile)
 		steps = append(steps, bldr.terraformInit)
 		steps = append(steps, bldr.terraformApply)
+	case internal.TestPhase:
+		steps = append(steps, bldr.terraformInit)
+		steps = append(steps, bldr.terraformTest)
 	}
 
 	return
@@ -159,6 +163,35 @@ func (b *stepsBuilder) downloadLockFile(ctx context.Context) error {
 	return b.writeFile(lockFilename, lockFile)
 }
 
+// resolveVariables resolves variables flagged as secret references, adding
+// environment variables to the environment and retaining terraform variables
+// for writing to disk. The resolved values are held only in memory and the
+// variables retrieved from the server are left untouched.
+func (b *stepsBuilder) resolveVariables(ctx context.Context) error {
+	var tfvars []*variable.Variable
+	for _, v := range b.variables {
+		value := v.Value
+		if v.SecretReference {
+			var err error
+			value, err = b.secrets.Resolve(ctx, v.Value)
+			if err != nil {
+				// error only ever mentions the reference and not the secret
+				return fmt.Errorf("variable %s: %w", v.Key, err)
+			}
+		}
+		switch v.Category {
+		case variable.CategoryEnv:
+			b.executor.envs = append(b.executor.envs, fmt.Sprintf("%s=%s", v.Key, value))
+		case variable.CategoryTerraform:
+			resolved := *v
+			resolved.Value = value
+			tfvars = append(tfvars, &resolved)
+		}
+	}
+	b.variables = tfvars
+	return nil
+}
+
 func (b *stepsBuilder) writeTerraformVars(ctx context.Context) error {
 	if err := variable.WriteTerraformVars(b.workdir.String(), b.variables); err != nil {
 		return fmt.Errorf("writing terraform.fvars: %w", err)
@@ -215,6 +248,19 @@ func (b *stepsBuilder) terraformApply(ctx context.Context) (err error) {
 	return b.executor.execute(append([]string{b.terraformPath}, args...))
 }
 
+// terraformTest runs the configuration's tests, streaming human-readable
+// messages to the output and uploading the machine-readable results, even if
+// tests fail, so that a report can be compiled.
+func (b *stepsBuilder) terraformTest(ctx context.Context) error {
+	results := &testResultsWriter{out: b.out}
+	testErr := b.executor.test(b.terraformPath, []string{"-json"}, stdoutWriter(results))
+	results.flush()
+	if err := b.UploadTestResults(ctx, b.ID, results.results.Bytes()); err != nil {
+		return errors.Join(testErr, fmt.Errorf("unable to upload test results: %w", err))
+	}
+	return testErr
+}
+
 func (b *stepsBuilder) convertPlanToJSON(ctx context.Context) error {
 	args := []string{"show", "-json", planFilename}
 	return b.executor.execute(
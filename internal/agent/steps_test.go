@@ -0,0 +1,49 @@
+package agent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/leg100/otf/internal/secrets"
+	"github.com/leg100/otf/internal/variable"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStepsBuilder_resolveVariables(t *testing.T) {
+	t.Setenv("OTF_TEST_DB_PASSWORD", "s3cr3t")
+
+	resolver, err := secrets.NewResolver(secrets.Config{Enabled: []string{secrets.EnvScheme}})
+	require.NoError(t, err)
+
+	ref := &variable.Variable{Key: "password", Value: "env://OTF_TEST_DB_PASSWORD", Category: variable.CategoryTerraform, SecretReference: true}
+	bldr := &stepsBuilder{environment: &environment{
+		secrets:  resolver,
+		executor: &executor{},
+		variables: []*variable.Variable{
+			ref,
+			{Key: "region", Value: "eu-west-2", Category: variable.CategoryTerraform},
+			{Key: "PGPASSWORD", Value: "env://OTF_TEST_DB_PASSWORD", Category: variable.CategoryEnv, SecretReference: true},
+			{Key: "endpoint", Value: "file:///etc/endpoint", Category: variable.CategoryTerraform},
+		},
+	}}
+	require.NoError(t, bldr.resolveVariables(context.Background()))
+
+	require.Equal(t, 3, len(bldr.variables))
+	assert.Equal(t, "s3cr3t", bldr.variables[0].Value)
+	assert.Equal(t, "eu-west-2", bldr.variables[1].Value)
+	// value resembling a reference is left as-is unless flagged as one
+	assert.Equal(t, "file:///etc/endpoint", bldr.variables[2].Value)
+	assert.Equal(t, []string{"PGPASSWORD=s3cr3t"}, bldr.executor.envs)
+	// original variable is untouched
+	assert.Equal(t, "env://OTF_TEST_DB_PASSWORD", ref.Value)
+
+	t.Run("provider not enabled", func(t *testing.T) {
+		bldr.variables = []*variable.Variable{
+			{Key: "token", Value: "vault://secret/data/app#token", Category: variable.CategoryTerraform, SecretReference: true},
+		}
+		err := bldr.resolveVariables(context.Background())
+		assert.ErrorIs(t, err, secrets.ErrProviderNotEnabled)
+		assert.EqualError(t, err, "variable token: resolving vault://secret/data/app#token: secret provider not enabled")
+	})
+}
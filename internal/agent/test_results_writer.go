@@ -0,0 +1,57 @@
+package agent
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+)
+
+// testResultsWriter receives the machine-readable output of `terraform test
+// -json`, writing the human-readable message from each line to the output and
+// retaining the machine-readable results. Lines that are not JSON, e.g.
+// diagnostics written outside of the JSON stream, are relayed to the output
+// but not retained.
+type testResultsWriter struct {
+	out     io.Writer
+	results bytes.Buffer
+	partial []byte // incomplete line awaiting a newline
+}
+
+func (w *testResultsWriter) Write(p []byte) (int, error) {
+	w.partial = append(w.partial, p...)
+	for {
+		i := bytes.IndexByte(w.partial, '\n')
+		if i < 0 {
+			break
+		}
+		if err := w.writeLine(w.partial[:i+1]); err != nil {
+			return 0, err
+		}
+		w.partial = w.partial[i+1:]
+	}
+	return len(p), nil
+}
+
+// flush writes any remaining line that lacks a trailing newline.
+func (w *testResultsWriter) flush() error {
+	if len(w.partial) == 0 {
+		return nil
+	}
+	err := w.writeLine(append(w.partial, '\n'))
+	w.partial = nil
+	return err
+}
+
+func (w *testResultsWriter) writeLine(line []byte) error {
+	var msg struct {
+		Message string `json:"@message"`
+	}
+	if err := json.Unmarshal(line, &msg); err != nil {
+		// not JSON, so relay it as-is
+		_, err := w.out.Write(line)
+		return err
+	}
+	w.results.Write(line)
+	_, err := io.WriteString(w.out, msg.Message+"\n")
+	return err
+}
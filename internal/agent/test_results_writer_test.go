@@ -0,0 +1,40 @@
+package agent
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTestResultsWriter(t *testing.T) {
+	var out bytes.Buffer
+	w := &testResultsWriter{out: &out}
+
+	input := `{"@level":"info","@message":"main.tftest.hcl... in progress","type":"test_file"}
+{"@level":"info","@message":"  run \"setup\"... pass","type":"test_run"}
+{"@level":"info","@message":"Success! 1 passed, 0 failed.","type":"test_summary"}`
+
+	// write in chunks that split lines
+	_, err := w.Write([]byte(input[:10]))
+	require.NoError(t, err)
+	_, err = w.Write([]byte(input[10:]))
+	require.NoError(t, err)
+	require.NoError(t, w.flush())
+
+	assert.Equal(t, "main.tftest.hcl... in progress\n  run \"setup\"... pass\nSuccess! 1 passed, 0 failed.\n", out.String())
+	assert.Equal(t, input+"\n", w.results.String())
+
+	t.Run("non-JSON lines are not retained", func(t *testing.T) {
+		var out bytes.Buffer
+		w := &testResultsWriter{out: &out}
+
+		_, err := w.Write([]byte("Error: Invalid test file\n" + `{"@level":"info","@message":"Failure! 0 passed, 1 failed.","type":"test_summary"}` + "\n"))
+		require.NoError(t, err)
+		require.NoError(t, w.flush())
+
+		assert.Equal(t, "Error: Invalid test file\nFailure! 0 passed, 1 failed.\n", out.String())
+		assert.Equal(t, `{"@level":"info","@message":"Failure! 0 passed, 1 failed.","type":"test_summary"}`+"\n", w.results.String())
+	})
+}
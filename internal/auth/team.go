@@ -17,10 +17,12 @@ type (
 
 		Access OrganizationAccess
 
+		// SSOTeamID maps the team to a group in a SAML identity provider.
+		SSOTeamID *string
+
 		// TFE fields that OTF does not support but persists merely to pass the
 		// go-tfe integration tests
 		Visibility string
-		SSOTeamID  *string
 	}
 
 	CreateTeamOptions struct {
@@ -29,9 +31,11 @@ type (
 
 		OrganizationAccessOptions
 
+		// SSOTeamID maps the team to a group in a SAML identity provider.
+		SSOTeamID *string `schema:"sso_team_id"`
+
 		// TFE fields that OTF does not support but persists merely to pass the
 		// go-tfe integration tests
-		SSOTeamID  *string
 		Visibility *string
 	}
 
@@ -40,9 +44,11 @@ type (
 
 		OrganizationAccessOptions
 
+		// SSOTeamID maps the team to a group in a SAML identity provider.
+		SSOTeamID *string `schema:"sso_team_id"`
+
 		// TFE fields that OTF does not support but persists merely to pass the
 		// go-tfe integration tests
-		SSOTeamID  *string
 		Visibility *string
 	}
 
@@ -155,9 +161,14 @@ func (t *Team) CanAccessOrganization(action rbac.Action, org string) bool {
 			}
 		}
 		if t.Access.ManageModules {
+			// registry managers retain VCS permissions, which they need in
+			// order to connect modules to repositories.
 			if rbac.VCSManagerRole.IsAllowed(action) {
 				return true
 			}
+			if rbac.RegistryManagerRole.IsAllowed(action) {
+				return true
+			}
 		}
 	}
 	return false
@@ -172,7 +183,15 @@ func (t *Team) CanAccessWorkspace(action rbac.Action, policy internal.WorkspaceP
 	if t.Organization != policy.Organization {
 		return false
 	}
-	for _, perm := range policy.Permissions {
+	if !t.canAccess(action, policy.Permissions) {
+		// fallback to checking permissions inherited from the project
+		return t.canAccess(action, policy.ProjectPermissions)
+	}
+	return true
+}
+
+func (t *Team) canAccess(action rbac.Action, perms []internal.WorkspacePermission) bool {
+	for _, perm := range perms {
 		if t.Name == perm.Team {
 			return perm.Role.IsAllowed(action)
 		}
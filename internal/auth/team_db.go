@@ -107,6 +107,21 @@ func (db *pgdb) listTeams(ctx context.Context, organization string) ([]*Team, er
 	return items, nil
 }
 
+// listTeamsWithSSOTeamID lists teams with an SSO team ID belonging to the given
+// organizations.
+func (db *pgdb) listTeamsWithSSOTeamID(ctx context.Context, organizations []string) ([]*Team, error) {
+	result, err := db.Conn(ctx).FindTeamsWithSSOTeamID(ctx, organizations)
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+
+	items := make([]*Team, len(result))
+	for i, r := range result {
+		items[i] = teamRow(r).toTeam()
+	}
+	return items, nil
+}
+
 func (db *pgdb) deleteTeam(ctx context.Context, teamID string) error {
 	_, err := db.Conn(ctx).DeleteTeamByID(ctx, sql.String(teamID))
 	if err != nil {
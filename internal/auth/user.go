@@ -3,6 +3,8 @@ package auth
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"log/slog"
@@ -52,6 +54,13 @@ type (
 		UserID                *string
 		Username              *string
 		AuthenticationTokenID *string
+		VCSIdentity           *VCSIdentity
+	}
+
+	// VCSIdentity identifies a user's account on a VCS provider.
+	VCSIdentity struct {
+		Hostname string // hostname of the VCS provider, e.g. github.com
+		Username string // username on the VCS provider
 	}
 )
 
@@ -86,6 +95,44 @@ func (u *User) IsTeamMember(teamID string) bool {
 	return false
 }
 
+// ssoTeamChanges determines which of the given teams with an SSO team ID the
+// user should be added to and removed from, according to the given groups,
+// which are typically the user's group memberships in an identity provider.
+// Groups are namespaced by organization, i.e. <organization>/<sso-team-id>, so
+// that a team is only ever mapped to a group intended for its own
+// organization.
+func ssoTeamChanges(user *User, mapped []*Team, groups []string) (add, remove []*Team) {
+	for _, team := range mapped {
+		if team.SSOTeamID == nil {
+			continue
+		}
+		want := slices.Contains(groups, team.Organization+"/"+*team.SSOTeamID)
+		have := user.IsTeamMember(team.ID)
+		if want && !have {
+			add = append(add, team)
+		} else if !want && have {
+			remove = append(remove, team)
+		}
+	}
+	return
+}
+
+// ssoOrganizations returns the organizations whose teams are affected by
+// synchronising the user's memberships with the given groups: the
+// organizations named in the groups along with those of which the user is
+// already a member.
+func ssoOrganizations(user *User, groups []string) []string {
+	organizations := user.Organizations()
+	for _, group := range groups {
+		org, _, ok := strings.Cut(group, "/")
+		if !ok || slices.Contains(organizations, org) {
+			continue
+		}
+		organizations = append(organizations, org)
+	}
+	return organizations
+}
+
 // Organizations returns the user's membership of organizations (indirectly via
 // their membership of teams).
 //
@@ -206,7 +253,7 @@ func UserFromContext(ctx context.Context) (*User, error) {
 	if err != nil {
 		return nil, err
 	}
-	user, ok := subj.(*User)
+	user, ok := internal.UnwrapSubject(subj).(*User)
 	if !ok {
 		return nil, fmt.Errorf("no user in context")
 	}
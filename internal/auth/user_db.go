@@ -88,11 +88,31 @@ func (db *pgdb) getUser(ctx context.Context, spec UserSpec) (*User, error) {
 			return nil, sql.Error(err)
 		}
 		return userRow(result).toUser(), nil
+	} else if spec.VCSIdentity != nil {
+		result, err := db.Conn(ctx).FindUserByVCSIdentity(ctx, sql.String(spec.VCSIdentity.Hostname), sql.String(spec.VCSIdentity.Username))
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+		return userRow(result).toUser(), nil
 	} else {
 		return nil, fmt.Errorf("unsupported user spec for retrieving user")
 	}
 }
 
+// linkVCSIdentity links an identity on a VCS provider to a user, replacing any
+// existing link for the identity.
+func (db *pgdb) linkVCSIdentity(ctx context.Context, userID string, identity VCSIdentity) error {
+	_, err := db.Conn(ctx).UpsertVCSIdentity(ctx, pggen.UpsertVCSIdentityParams{
+		Hostname:    sql.String(identity.Hostname),
+		VCSUsername: sql.String(identity.Username),
+		UserID:      sql.String(userID),
+	})
+	if err != nil {
+		return sql.Error(err)
+	}
+	return nil
+}
+
 func (db *pgdb) addTeamMembership(ctx context.Context, teamID string, usernames ...string) error {
 	_, err := db.Conn(ctx).InsertTeamMembership(ctx, usernames, sql.String(teamID))
 	if err != nil {
@@ -20,6 +20,8 @@ type (
 		DeleteUser(ctx context.Context, username string) error
 		AddTeamMembership(ctx context.Context, teamID string, usernames []string) error
 		RemoveTeamMembership(ctx context.Context, teamID string, usernames []string) error
+		SyncSSOTeamMemberships(ctx context.Context, username string, ssoTeamIDs []string) error
+		LinkVCSIdentity(ctx context.Context, username string, identity VCSIdentity) error
 		SetSiteAdmins(ctx context.Context, usernames ...string) error
 	}
 )
@@ -170,6 +172,98 @@ func (a *service) RemoveTeamMembership(ctx context.Context, teamID string, usern
 	return nil
 }
 
+// SyncSSOTeamMemberships authoritatively sets a user's membership of teams
+// that are mapped to groups in an identity provider, i.e. teams with an SSO
+// team ID. Each of ssoTeamIDs takes the form <organization>/<sso-team-id>. The
+// user is added to each team with a matching SSO team ID in the named
+// organization, and removed from each other team with an SSO team ID. Teams
+// without an SSO team ID are left untouched. If the user does not exist then
+// it is created.
+func (a *service) SyncSSOTeamMemberships(ctx context.Context, username string, ssoTeamIDs []string) error {
+	subject, err := a.site.CanAccess(ctx, rbac.SyncSSOTeamMembershipsAction, "")
+	if err != nil {
+		return err
+	}
+
+	var added, removed []string
+	err = a.db.Tx(ctx, func(ctx context.Context, _ pggen.Querier) error {
+		user, err := a.db.getUser(ctx, UserSpec{Username: &username})
+		if errors.Is(err, internal.ErrResourceNotFound) {
+			user, err = a.CreateUser(ctx, username)
+		}
+		if err != nil {
+			return err
+		}
+		mapped, err := a.db.listTeamsWithSSOTeamID(ctx, ssoOrganizations(user, ssoTeamIDs))
+		if err != nil {
+			return err
+		}
+		add, remove := ssoTeamChanges(user, mapped, ssoTeamIDs)
+		for _, team := range add {
+			if err := a.db.addTeamMembership(ctx, team.ID, username); err != nil {
+				return err
+			}
+			added = append(added, team.ID)
+		}
+		for _, team := range remove {
+			if team.Name == "owners" {
+				// never remove the last owner
+				owners, err := a.db.listTeamMembers(ctx, team.ID)
+				if err != nil {
+					return err
+				}
+				if len(owners) <= 1 {
+					continue
+				}
+			}
+			if err := a.db.removeTeamMembership(ctx, team.ID, username); err != nil {
+				return err
+			}
+			removed = append(removed, team.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		a.Error(err, "synchronising sso team memberships", "user", username, "subject", subject)
+		return err
+	}
+
+	if len(added) > 0 || len(removed) > 0 {
+		a.V(0).Info("synchronised sso team memberships", "user", username, "added", added, "removed", removed, "subject", subject)
+	}
+
+	return nil
+}
+
+// LinkVCSIdentity links a user to their identity on a VCS provider, verified by
+// the user having logged in via the VCS provider. Any existing link for the
+// identity is replaced. If the user does not exist then it is created.
+func (a *service) LinkVCSIdentity(ctx context.Context, username string, identity VCSIdentity) error {
+	subject, err := a.site.CanAccess(ctx, rbac.LinkVCSIdentityAction, "")
+	if err != nil {
+		return err
+	}
+
+	err = a.db.Tx(ctx, func(ctx context.Context, _ pggen.Querier) error {
+		user, err := a.db.getUser(ctx, UserSpec{Username: &username})
+		if errors.Is(err, internal.ErrResourceNotFound) {
+			user, err = a.CreateUser(ctx, username)
+		}
+		if err != nil {
+			return err
+		}
+		return a.db.linkVCSIdentity(ctx, user.ID, identity)
+	})
+	if err != nil {
+		a.Error(err, "linking vcs identity", "user", username, "hostname", identity.Hostname, "vcs_username", identity.Username, "subject", subject)
+		return err
+	}
+
+	a.V(1).Info("linked vcs identity", "user", username, "hostname", identity.Hostname, "vcs_username", identity.Username, "subject", subject)
+
+	return nil
+}
+
 // SetSiteAdmins authoritatively promotes users with the given usernames to site
 // admins. If no such users exist then they are created. Any unspecified users
 // that are currently site admins are demoted.
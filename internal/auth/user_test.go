@@ -3,6 +3,7 @@ package auth
 import (
 	"testing"
 
+	"github.com/leg100/otf/internal"
 	"github.com/leg100/otf/internal/rbac"
 	"github.com/stretchr/testify/assert"
 )
@@ -26,6 +27,33 @@ func TestOwnerCanAccessOrganization(t *testing.T) {
 	assert.True(t, u.CanAccessOrganization(rbac.ListRunsAction, "acme-corp"))
 }
 
+func TestUser_CanAccessWorkspace_ProjectPermissions(t *testing.T) {
+	u := User{
+		Teams: []*Team{
+			{
+				Name:         "engineers",
+				Organization: "acme-corp",
+			},
+		},
+	}
+	policy := internal.WorkspacePolicy{
+		Organization: "acme-corp",
+		WorkspaceID:  "ws-123",
+		Permissions: []internal.WorkspacePermission{
+			{Team: "engineers", Role: rbac.WorkspaceReadRole},
+		},
+		ProjectPermissions: []internal.WorkspacePermission{
+			{Team: "engineers", Role: rbac.WorkspaceWriteRole},
+		},
+	}
+	// read permitted by workspace permission
+	assert.True(t, u.CanAccessWorkspace(rbac.GetWorkspaceAction, policy))
+	// apply permitted by project permission
+	assert.True(t, u.CanAccessWorkspace(rbac.ApplyRunAction, policy))
+	// admin not permitted by either
+	assert.False(t, u.CanAccessWorkspace(rbac.SetWorkspacePermissionAction, policy))
+}
+
 func TestUser_Organizations(t *testing.T) {
 	u := User{
 		Teams: []*Team{
@@ -53,3 +81,55 @@ func TestUser_Organizations(t *testing.T) {
 	assert.Contains(t, want, "big-tobacco")
 	assert.Contains(t, want, "big-pharma")
 }
+
+func TestUser_ssoTeamChanges(t *testing.T) {
+	devs := &Team{ID: "team-devs", Organization: "acme", SSOTeamID: internal.String("devs-group")}
+	ops := &Team{ID: "team-ops", Organization: "acme", SSOTeamID: internal.String("ops-group")}
+	admins := &Team{ID: "team-admins", Organization: "acme", SSOTeamID: internal.String("admins-group")}
+	unmapped := &Team{ID: "team-unmapped", Organization: "acme"}
+
+	user := NewUser("bobby", WithTeams(ops, admins, unmapped))
+
+	add, remove := ssoTeamChanges(user, []*Team{devs, ops, admins}, []string{"acme/devs-group", "acme/ops-group"})
+	assert.Equal(t, []*Team{devs}, add)
+	assert.Equal(t, []*Team{admins}, remove)
+}
+
+func TestUser_ssoTeamChanges_MultipleOrganizations(t *testing.T) {
+	// both organizations map a team to the same SSO team ID
+	acmeOwners := &Team{ID: "team-acme-owners", Name: "owners", Organization: "acme", SSOTeamID: internal.String("admins")}
+	evilOwners := &Team{ID: "team-evil-owners", Name: "owners", Organization: "evil", SSOTeamID: internal.String("admins")}
+
+	user := NewUser("bobby")
+
+	add, remove := ssoTeamChanges(user, []*Team{acmeOwners, evilOwners}, []string{"acme/admins"})
+	assert.Equal(t, []*Team{acmeOwners}, add)
+	assert.Empty(t, remove)
+
+	// unqualified groups match no team
+	add, remove = ssoTeamChanges(user, []*Team{acmeOwners, evilOwners}, []string{"admins"})
+	assert.Empty(t, add)
+	assert.Empty(t, remove)
+}
+
+func TestUser_ssoOrganizations(t *testing.T) {
+	user := NewUser("bobby", WithTeams(&Team{ID: "team-1", Organization: "acme"}))
+
+	got := ssoOrganizations(user, []string{"acme/devs", "initech/ops", "unqualified"})
+	assert.Equal(t, []string{"acme", "initech"}, got)
+}
+
+func TestRegistryManagerCanAccessOrganization(t *testing.T) {
+	u := User{
+		Teams: []*Team{
+			{
+				Organization: "acme-corp",
+				Access:       OrganizationAccess{ManageModules: true},
+			},
+		},
+	}
+	assert.True(t, u.CanAccessOrganization(rbac.CreateModuleAction, "acme-corp"))
+	assert.True(t, u.CanAccessOrganization(rbac.CreateRegistryProviderAction, "acme-corp"))
+	assert.True(t, u.CanAccessOrganization(rbac.CreateVCSProviderAction, "acme-corp"))
+	assert.False(t, u.CanAccessOrganization(rbac.CreateWorkspaceAction, "acme-corp"))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/leg100/otf/internal/auth"
 	"github.com/leg100/otf/internal/tokens"
 	"golang.org/x/oauth2"
 )
@@ -16,6 +17,11 @@ type (
 	fakeTokenHandler struct {
 		username string
 	}
+
+	fakeVCSIdentityLinker struct {
+		username string
+		identity auth.VCSIdentity
+	}
 )
 
 func (f fakeTokenHandler) getUsername(ctx context.Context, token *oauth2.Token) (string, error) {
@@ -26,3 +32,9 @@ func (fakeTokensService) StartSession(w http.ResponseWriter, r *http.Request, op
 	w.Header().Set("username", *opts.Username)
 	return nil
 }
+
+func (f *fakeVCSIdentityLinker) LinkVCSIdentity(ctx context.Context, username string, identity auth.VCSIdentity) error {
+	f.username = username
+	f.identity = identity
+	return nil
+}
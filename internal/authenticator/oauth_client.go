@@ -10,6 +10,7 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/auth"
 	"github.com/leg100/otf/internal/http/decode"
 	"github.com/leg100/otf/internal/http/html"
 	"github.com/leg100/otf/internal/http/html/paths"
@@ -28,6 +29,11 @@ type (
 		getUsername(context.Context, *oauth2.Token) (string, error)
 	}
 
+	// vcsIdentityLinker links a user to their identity on a VCS provider.
+	vcsIdentityLinker interface {
+		LinkVCSIdentity(ctx context.Context, username string, identity auth.VCSIdentity) error
+	}
+
 	// OAuthClient performs the client role in an oauth handshake, requesting
 	// authorization from the user to access their account details on a particular
 	// cloud.
@@ -38,6 +44,9 @@ type (
 		tokens.TokensService
 		// for retrieving OTF system hostname to construct redirect URLs
 		internal.HostnameService
+		// for linking users to their VCS provider identity; nil if the
+		// identity provider is not a VCS provider.
+		identities vcsIdentityLinker
 
 		OAuthConfig
 	}
@@ -158,6 +167,20 @@ func (a *OAuthClient) callbackHandler(w http.ResponseWriter, r *http.Request) {
 		html.Error(w, err.Error(), http.StatusInternalServerError, false)
 		return
 	}
+	if a.identities != nil {
+		// the user has proven they own the account on the VCS provider, so
+		// record the link, permitting them to be identified by actions they
+		// carry out on the VCS provider, e.g. commenting on a pull request.
+		ctx := internal.AddSubjectToContext(r.Context(), &internal.Superuser{Username: "oauth-client"})
+		err := a.identities.LinkVCSIdentity(ctx, username, auth.VCSIdentity{
+			Hostname: a.OAuthConfig.Hostname,
+			Username: username,
+		})
+		if err != nil {
+			html.Error(w, err.Error(), http.StatusInternalServerError, false)
+			return
+		}
+	}
 	err = a.StartSession(w, r, tokens.StartSessionOptions{Username: &username})
 	if err != nil {
 		html.Error(w, err.Error(), http.StatusInternalServerError, false)
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/auth"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/oauth2"
@@ -41,6 +42,20 @@ func TestOAuthClient_callbackHandler(t *testing.T) {
 	assert.Equal(t, w.Header().Get("username"), "bobby")
 }
 
+func TestOAuthClient_callbackHandler_LinkVCSIdentity(t *testing.T) {
+	client := newTestOAuthServerClient(t, "bobby")
+	linker := &fakeVCSIdentityLinker{}
+	client.identities = linker
+	r := httptest.NewRequest("GET", "/auth?state=state", nil)
+	r.AddCookie(&http.Cookie{Name: oauthCookieName, Value: "state"})
+	w := httptest.NewRecorder()
+
+	client.callbackHandler(w, r)
+	assert.Equal(t, w.Header().Get("username"), "bobby")
+	assert.Equal(t, "bobby", linker.username)
+	assert.Equal(t, auth.VCSIdentity{Hostname: client.OAuthConfig.Hostname, Username: "bobby"}, linker.identity)
+}
+
 // newTestOAuthServerClient creates an OAuth server for testing purposes and
 // returns a client configured to access the server.
 func newTestOAuthServerClient(t *testing.T, username string) *OAuthClient {
@@ -0,0 +1,491 @@
+package authenticator
+
+import (
+	"bytes"
+	"compress/flate"
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/beevik/etree"
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/auth"
+	otfhttp "github.com/leg100/otf/internal/http"
+	"github.com/leg100/otf/internal/http/html"
+	"github.com/leg100/otf/internal/http/html/paths"
+	"github.com/leg100/otf/internal/tokens"
+	dsig "github.com/russellhaering/goxmldsig"
+	"golang.org/x/oauth2"
+)
+
+const (
+	samlCookieName = "saml-request-id"
+
+	samlBindingHTTPRedirect = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect"
+	samlBindingHTTPPost     = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"
+	samlStatusSuccess       = "urn:oasis:names:tc:SAML:2.0:status:Success"
+
+	// samlClockSkew is the tolerance permitted when comparing timestamps in
+	// an assertion against the current time.
+	samlClockSkew = 90 * time.Second
+)
+
+var (
+	ErrMissingSAMLMetadataURL = errors.New("missing saml-idp-metadata-url")
+	ErrSAMLNoSSOService       = errors.New("identity provider metadata contains no HTTP-Redirect single sign-on service")
+	ErrSAMLNoSigningCerts     = errors.New("identity provider metadata contains no signing certificates")
+)
+
+type (
+	// SAMLConfig is the configuration for a SAML 2.0 identity provider.
+	SAMLConfig struct {
+		// Name is the user-friendly identifier of the identity provider.
+		Name string
+		// IDPMetadataURL is the URL of the identity provider's metadata.
+		IDPMetadataURL string
+		// UsernameAttribute is the assertion attribute that provides the
+		// username. If empty then the subject's NameID is used.
+		UsernameAttribute string
+		// TeamsAttribute is the assertion attribute that lists the user's
+		// groups. Each group is matched against the SSO team ID of teams.
+		// If empty then team memberships are not synchronised.
+		TeamsAttribute string
+		// Skip TLS Verification when retrieving metadata.
+		SkipTLSVerification bool
+	}
+
+	// samlClient performs the service provider role in a SAML 2.0 web browser
+	// SSO flow, using the HTTP-Redirect binding to send authentication requests
+	// and the HTTP-POST binding to receive responses.
+	samlClient struct {
+		// for creating session
+		tokens.TokensService
+		// for synchronising team memberships
+		auth.UserService
+		// for retrieving OTF system hostname to construct URLs
+		internal.HostnameService
+
+		SAMLConfig
+
+		idp *samlIDP
+		// returns the current time; overridden in tests
+		now func() time.Time
+	}
+
+	// samlIDP is the identity provider configuration extracted from its
+	// metadata.
+	samlIDP struct {
+		entityID string
+		ssoURL   string
+		certs    []*x509.Certificate
+	}
+
+	samlEntityDescriptor struct {
+		EntityID         string `xml:"entityID,attr"`
+		IDPSSODescriptor struct {
+			KeyDescriptors []struct {
+				Use          string   `xml:"use,attr"`
+				Certificates []string `xml:"KeyInfo>X509Data>X509Certificate"`
+			} `xml:"KeyDescriptor"`
+			SingleSignOnServices []struct {
+				Binding  string `xml:"Binding,attr"`
+				Location string `xml:"Location,attr"`
+			} `xml:"SingleSignOnService"`
+		} `xml:"IDPSSODescriptor"`
+	}
+
+	samlAssertion struct {
+		Issuer  string `xml:"Issuer"`
+		Subject struct {
+			NameID       string `xml:"NameID"`
+			Confirmation struct {
+				Data struct {
+					InResponseTo string    `xml:"InResponseTo,attr"`
+					NotOnOrAfter time.Time `xml:"NotOnOrAfter,attr"`
+					Recipient    string    `xml:"Recipient,attr"`
+				} `xml:"SubjectConfirmationData"`
+			} `xml:"SubjectConfirmation"`
+		} `xml:"Subject"`
+		Conditions struct {
+			NotBefore    time.Time `xml:"NotBefore,attr"`
+			NotOnOrAfter time.Time `xml:"NotOnOrAfter,attr"`
+			Audiences    []string  `xml:"AudienceRestriction>Audience"`
+		} `xml:"Conditions"`
+		Attributes []struct {
+			Name   string   `xml:"Name,attr"`
+			Values []string `xml:"AttributeValue"`
+		} `xml:"AttributeStatement>Attribute"`
+	}
+)
+
+func newSAMLClient(
+	ctx context.Context,
+	hostnameService internal.HostnameService,
+	tokensService tokens.TokensService,
+	userService auth.UserService,
+	cfg SAMLConfig,
+) (*samlClient, error) {
+	if cfg.IDPMetadataURL == "" {
+		return nil, ErrMissingSAMLMetadataURL
+	}
+	idp, err := fetchSAMLMetadata(contextWithClient(ctx, cfg.SkipTLSVerification), cfg.IDPMetadataURL)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving saml identity provider metadata: %w", err)
+	}
+	return &samlClient{
+		TokensService:   tokensService,
+		UserService:     userService,
+		HostnameService: hostnameService,
+		SAMLConfig:      cfg,
+		idp:             idp,
+		now:             time.Now,
+	}, nil
+}
+
+// fetchSAMLMetadata retrieves and parses identity provider metadata.
+func fetchSAMLMetadata(ctx context.Context, metadataURL string) (*samlIDP, error) {
+	client := http.DefaultClient
+	if c, ok := ctx.Value(oauth2.HTTPClient).(*http.Client); ok {
+		client = c
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", metadataURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %s", resp.Status)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return parseSAMLMetadata(body)
+}
+
+func parseSAMLMetadata(data []byte) (*samlIDP, error) {
+	var md samlEntityDescriptor
+	if err := xml.Unmarshal(data, &md); err != nil {
+		return nil, err
+	}
+	idp := samlIDP{entityID: md.EntityID}
+	for _, svc := range md.IDPSSODescriptor.SingleSignOnServices {
+		if svc.Binding == samlBindingHTTPRedirect {
+			idp.ssoURL = svc.Location
+			break
+		}
+	}
+	if idp.ssoURL == "" {
+		return nil, ErrSAMLNoSSOService
+	}
+	for _, kd := range md.IDPSSODescriptor.KeyDescriptors {
+		if kd.Use != "" && kd.Use != "signing" {
+			continue
+		}
+		for _, encoded := range kd.Certificates {
+			der, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(encoded), ""))
+			if err != nil {
+				return nil, fmt.Errorf("decoding certificate: %w", err)
+			}
+			cert, err := x509.ParseCertificate(der)
+			if err != nil {
+				return nil, fmt.Errorf("parsing certificate: %w", err)
+			}
+			idp.certs = append(idp.certs, cert)
+		}
+	}
+	if len(idp.certs) == 0 {
+		return nil, ErrSAMLNoSigningCerts
+	}
+	return &idp, nil
+}
+
+// String provides a human-readable identifier for the identity provider.
+func (a *samlClient) String() string { return a.Name }
+
+func (a *samlClient) RequestPath() string { return "/saml/login" }
+
+func (a *samlClient) acsPath() string { return "/saml/acs" }
+
+func (a *samlClient) metadataPath() string { return "/saml/metadata" }
+
+// entityID uniquely identifies OTF to the identity provider; by convention it
+// is the URL of the service provider metadata.
+func (a *samlClient) entityID() string { return a.URL(a.metadataPath()) }
+
+func (a *samlClient) addHandlers(r *mux.Router) {
+	r.HandleFunc(a.RequestPath(), a.requestHandler).Methods("GET")
+	r.HandleFunc(a.acsPath(), a.acsHandler).Methods("POST")
+	r.HandleFunc(a.metadataPath(), a.metadataHandler).Methods("GET")
+}
+
+// requestHandler initiates the SAML flow, redirecting the user to the identity
+// provider with an authentication request.
+func (a *samlClient) requestHandler(w http.ResponseWriter, r *http.Request) {
+	id := "id-" + internal.GenerateRandomString(32)
+	redirectURL, err := a.authnRequestURL(id)
+	if err != nil {
+		http.Error(w, "unable to construct authentication request: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	cookie := &http.Cookie{
+		Name:     samlCookieName,
+		Value:    id,
+		Path:     "/",
+		MaxAge:   300, // 5 minutes
+		HttpOnly: true,
+	}
+	// The identity provider POSTs its response back to OTF, so the cookie
+	// must be sent on a cross-site request, which browsers only permit for
+	// secure cookies. Without HTTPS the browser's default policy is left to
+	// apply.
+	if otfhttp.IsSecure(r) {
+		cookie.Secure = true
+		cookie.SameSite = http.SameSiteNoneMode
+	}
+	http.SetCookie(w, cookie)
+	http.Redirect(w, r, redirectURL, http.StatusFound)
+}
+
+// authnRequestURL constructs the identity provider URL for an authentication
+// request, encoded according to the HTTP-Redirect binding.
+func (a *samlClient) authnRequestURL(id string) (string, error) {
+	doc := etree.NewDocument()
+	req := doc.CreateElement("samlp:AuthnRequest")
+	req.CreateAttr("xmlns:samlp", "urn:oasis:names:tc:SAML:2.0:protocol")
+	req.CreateAttr("xmlns:saml", "urn:oasis:names:tc:SAML:2.0:assertion")
+	req.CreateAttr("ID", id)
+	req.CreateAttr("Version", "2.0")
+	req.CreateAttr("IssueInstant", a.now().UTC().Format(time.RFC3339))
+	req.CreateAttr("Destination", a.idp.ssoURL)
+	req.CreateAttr("AssertionConsumerServiceURL", a.URL(a.acsPath()))
+	req.CreateAttr("ProtocolBinding", samlBindingHTTPPost)
+	req.CreateElement("saml:Issuer").SetText(a.entityID())
+	req.CreateElement("samlp:NameIDPolicy").CreateAttr("AllowCreate", "true")
+
+	var buf bytes.Buffer
+	fw, err := flate.NewWriter(&buf, flate.DefaultCompression)
+	if err != nil {
+		return "", err
+	}
+	if _, err := doc.WriteTo(fw); err != nil {
+		return "", err
+	}
+	if err := fw.Close(); err != nil {
+		return "", err
+	}
+
+	u, err := url.Parse(a.idp.ssoURL)
+	if err != nil {
+		return "", err
+	}
+	q := u.Query()
+	q.Set("SAMLRequest", base64.StdEncoding.EncodeToString(buf.Bytes()))
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// acsHandler is the assertion consumer service, handling the response from
+// the identity provider. The response's assertion is verified, the user's
+// team memberships are synchronised, and a new OTF user session is started.
+func (a *samlClient) acsHandler(w http.ResponseWriter, r *http.Request) {
+	assertion, err := a.parseResponse(r)
+	if err != nil {
+		html.FlashError(w, "saml: "+err.Error())
+		http.Redirect(w, r, paths.Login(), http.StatusFound)
+		return
+	}
+	username, err := a.username(assertion)
+	if err != nil {
+		html.FlashError(w, "saml: "+err.Error())
+		http.Redirect(w, r, paths.Login(), http.StatusFound)
+		return
+	}
+	if a.TeamsAttribute != "" {
+		ctx := internal.AddSubjectToContext(r.Context(), &internal.Superuser{Username: "saml-authenticator"})
+		err := a.SyncSSOTeamMemberships(ctx, username, assertion.attribute(a.TeamsAttribute))
+		if err != nil {
+			html.Error(w, err.Error(), http.StatusInternalServerError, false)
+			return
+		}
+	}
+	err = a.StartSession(w, r, tokens.StartSessionOptions{Username: &username})
+	if err != nil {
+		html.Error(w, err.Error(), http.StatusInternalServerError, false)
+		return
+	}
+}
+
+// parseResponse decodes and verifies the SAML response in the request,
+// returning its assertion.
+func (a *samlClient) parseResponse(r *http.Request) (*samlAssertion, error) {
+	cookie, err := r.Cookie(samlCookieName)
+	if err != nil {
+		return nil, fmt.Errorf("missing request ID cookie (the cookie expires after 5 minutes)")
+	}
+	encoded := r.PostFormValue("SAMLResponse")
+	if encoded == "" {
+		return nil, fmt.Errorf("missing SAMLResponse")
+	}
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+	doc := etree.NewDocument()
+	if err := doc.ReadFromBytes(raw); err != nil {
+		return nil, fmt.Errorf("parsing response: %w", err)
+	}
+	response := doc.Root()
+	if response == nil || response.Tag != "Response" {
+		return nil, fmt.Errorf("response is not a SAML response")
+	}
+	if status := response.FindElement("./Status/StatusCode"); status == nil || status.SelectAttrValue("Value", "") != samlStatusSuccess {
+		return nil, fmt.Errorf("authentication failed")
+	}
+	if got := response.SelectAttrValue("InResponseTo", ""); got != cookie.Value {
+		return nil, fmt.Errorf("response does not match authentication request")
+	}
+	if dest := response.SelectAttrValue("Destination", ""); dest != "" && dest != a.URL(a.acsPath()) {
+		return nil, fmt.Errorf("response destination mismatch: %s", dest)
+	}
+
+	// Either the response or its assertion must be signed. Only the signed
+	// element returned by the validator is trusted; anything outside of it is
+	// ignored, to guard against signature wrapping attacks.
+	validator := dsig.NewDefaultValidationContext(&dsig.MemoryX509CertificateStore{Roots: a.idp.certs})
+	validator.Clock = dsig.NewFakeClockAt(a.now())
+	if hasSignature(response) {
+		response, err = validator.Validate(response)
+		if err != nil {
+			return nil, fmt.Errorf("verifying response signature: %w", err)
+		}
+	}
+	el := response.FindElement("./Assertion")
+	if el == nil {
+		if response.FindElement("./EncryptedAssertion") != nil {
+			return nil, fmt.Errorf("encrypted assertions are not supported")
+		}
+		return nil, fmt.Errorf("missing assertion")
+	}
+	if hasSignature(el) {
+		el, err = validator.Validate(el)
+		if err != nil {
+			return nil, fmt.Errorf("verifying assertion signature: %w", err)
+		}
+	} else if !hasSignature(doc.Root()) {
+		return nil, fmt.Errorf("neither response nor assertion is signed")
+	}
+
+	var assertion samlAssertion
+	assertionDoc := etree.NewDocument()
+	assertionDoc.SetRoot(el.Copy())
+	data, err := assertionDoc.WriteToBytes()
+	if err != nil {
+		return nil, err
+	}
+	if err := xml.Unmarshal(data, &assertion); err != nil {
+		return nil, fmt.Errorf("parsing assertion: %w", err)
+	}
+	if err := a.validateAssertion(&assertion, cookie.Value); err != nil {
+		return nil, err
+	}
+	return &assertion, nil
+}
+
+func (a *samlClient) validateAssertion(assertion *samlAssertion, requestID string) error {
+	now := a.now()
+	if assertion.Issuer != a.idp.entityID {
+		return fmt.Errorf("unexpected assertion issuer: %s", assertion.Issuer)
+	}
+	if c := assertion.Conditions; !c.NotBefore.IsZero() && now.Add(samlClockSkew).Before(c.NotBefore) {
+		return fmt.Errorf("assertion is not yet valid")
+	}
+	if c := assertion.Conditions; !c.NotOnOrAfter.IsZero() && !now.Add(-samlClockSkew).Before(c.NotOnOrAfter) {
+		return fmt.Errorf("assertion has expired")
+	}
+	if audiences := assertion.Conditions.Audiences; len(audiences) > 0 && !slices.Contains(audiences, a.entityID()) {
+		return fmt.Errorf("assertion is not intended for this service provider")
+	}
+	data := assertion.Subject.Confirmation.Data
+	if data.InResponseTo != "" && data.InResponseTo != requestID {
+		return fmt.Errorf("assertion does not match authentication request")
+	}
+	if data.Recipient != "" && data.Recipient != a.URL(a.acsPath()) {
+		return fmt.Errorf("assertion recipient mismatch: %s", data.Recipient)
+	}
+	if !data.NotOnOrAfter.IsZero() && !now.Add(-samlClockSkew).Before(data.NotOnOrAfter) {
+		return fmt.Errorf("assertion subject confirmation has expired")
+	}
+	return nil
+}
+
+// username retrieves the username from the assertion.
+func (a *samlClient) username(assertion *samlAssertion) (string, error) {
+	if a.UsernameAttribute == "" {
+		if assertion.Subject.NameID == "" {
+			return "", fmt.Errorf("assertion is missing NameID")
+		}
+		return assertion.Subject.NameID, nil
+	}
+	values := assertion.attribute(a.UsernameAttribute)
+	if len(values) == 0 || values[0] == "" {
+		return "", fmt.Errorf("assertion is missing attribute: %s", a.UsernameAttribute)
+	}
+	return values[0], nil
+}
+
+// metadataHandler serves the service provider metadata, for configuring the
+// identity provider.
+func (a *samlClient) metadataHandler(w http.ResponseWriter, r *http.Request) {
+	doc := etree.NewDocument()
+	doc.CreateProcInst("xml", `version="1.0" encoding="UTF-8"`)
+	ed := doc.CreateElement("md:EntityDescriptor")
+	ed.CreateAttr("xmlns:md", "urn:oasis:names:tc:SAML:2.0:metadata")
+	ed.CreateAttr("entityID", a.entityID())
+	sp := ed.CreateElement("md:SPSSODescriptor")
+	sp.CreateAttr("AuthnRequestsSigned", "false")
+	sp.CreateAttr("WantAssertionsSigned", "true")
+	sp.CreateAttr("protocolSupportEnumeration", "urn:oasis:names:tc:SAML:2.0:protocol")
+	acs := sp.CreateElement("md:AssertionConsumerService")
+	acs.CreateAttr("Binding", samlBindingHTTPPost)
+	acs.CreateAttr("Location", a.URL(a.acsPath()))
+	acs.CreateAttr("index", "0")
+	doc.Indent(2)
+
+	w.Header().Set("Content-Type", "application/samlmetadata+xml")
+	doc.WriteTo(w)
+}
+
+// attribute returns the values of the attribute with the given name.
+func (a *samlAssertion) attribute(name string) []string {
+	for _, attr := range a.Attributes {
+		if attr.Name == name {
+			return attr.Values
+		}
+	}
+	return nil
+}
+
+// hasSignature determines whether the element has an enveloped signature.
+func hasSignature(el *etree.Element) bool {
+	for _, child := range el.ChildElements() {
+		if child.Tag == "Signature" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,202 @@
+package authenticator
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beevik/etree"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/auth"
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSAMLClient(t *testing.T) {
+	idp := newTestIDP(t)
+	users := &fakeSAMLUserService{}
+	client, err := newSAMLClient(
+		context.Background(),
+		internal.NewHostnameService("otf.example.com"),
+		fakeTokensService{},
+		users,
+		SAMLConfig{
+			Name:                "saml",
+			IDPMetadataURL:      idp.URL + "/metadata",
+			TeamsAttribute:      "groups",
+			SkipTLSVerification: true,
+		},
+	)
+	require.NoError(t, err)
+
+	t.Run("request", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "https://otf.example.com/saml/login", nil)
+		w := httptest.NewRecorder()
+		client.requestHandler(w, r)
+
+		assert.Equal(t, http.StatusFound, w.Code)
+		loc, err := url.Parse(w.Header().Get("Location"))
+		require.NoError(t, err)
+		assert.Equal(t, idp.URL+"/sso", loc.Scheme+"://"+loc.Host+loc.Path)
+		assert.NotEmpty(t, loc.Query().Get("SAMLRequest"))
+		if assert.Equal(t, 1, len(w.Result().Cookies())) {
+			cookie := w.Result().Cookies()[0]
+			assert.Equal(t, samlCookieName, cookie.Name)
+			assert.True(t, cookie.Secure)
+			assert.Equal(t, http.SameSiteNoneMode, cookie.SameSite)
+		}
+	})
+
+	t.Run("request without https", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "http://otf.example.com/saml/login", nil)
+		w := httptest.NewRecorder()
+		client.requestHandler(w, r)
+
+		if assert.Equal(t, 1, len(w.Result().Cookies())) {
+			cookie := w.Result().Cookies()[0]
+			assert.False(t, cookie.Secure)
+			assert.Zero(t, cookie.SameSite)
+		}
+	})
+
+	t.Run("signed assertion", func(t *testing.T) {
+		resp := idp.response(t, client, "req-1", func(assertion *etree.Element) {})
+
+		w := httptest.NewRecorder()
+		client.acsHandler(w, newACSRequest("req-1", resp))
+
+		assert.Equal(t, "bobby", w.Header().Get("username"))
+		assert.Equal(t, "bobby", users.username)
+		assert.Equal(t, []string{"devs", "ops"}, users.ssoTeamIDs)
+	})
+
+	t.Run("tampered assertion", func(t *testing.T) {
+		resp := idp.response(t, client, "req-1", func(assertion *etree.Element) {
+			assertion.FindElement("./Subject/NameID").SetText("mallory")
+		})
+
+		w := httptest.NewRecorder()
+		client.acsHandler(w, newACSRequest("req-1", resp))
+
+		assert.Equal(t, http.StatusFound, w.Code)
+		assert.Equal(t, "/login", w.Header().Get("Location"))
+		assert.Empty(t, w.Header().Get("username"))
+	})
+
+	t.Run("mismatched request ID", func(t *testing.T) {
+		resp := idp.response(t, client, "req-1", func(assertion *etree.Element) {})
+
+		w := httptest.NewRecorder()
+		client.acsHandler(w, newACSRequest("req-2", resp))
+
+		assert.Equal(t, http.StatusFound, w.Code)
+		assert.Equal(t, "/login", w.Header().Get("Location"))
+	})
+}
+
+type (
+	testIDP struct {
+		*httptest.Server
+
+		signer *dsig.SigningContext
+	}
+
+	fakeSAMLUserService struct {
+		auth.UserService
+
+		username   string
+		ssoTeamIDs []string
+	}
+)
+
+// newTestIDP starts a SAML identity provider that serves its metadata.
+func newTestIDP(t *testing.T) *testIDP {
+	ks := dsig.RandomKeyStoreForTest()
+	_, cert, err := ks.GetKeyPair()
+	require.NoError(t, err)
+
+	idp := &testIDP{signer: dsig.NewDefaultSigningContext(ks)}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metadata", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `<EntityDescriptor xmlns="urn:oasis:names:tc:SAML:2.0:metadata" entityID="%s/metadata">
+  <IDPSSODescriptor protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol">
+    <KeyDescriptor use="signing">
+      <KeyInfo xmlns="http://www.w3.org/2000/09/xmldsig#">
+        <X509Data><X509Certificate>%s</X509Certificate></X509Data>
+      </KeyInfo>
+    </KeyDescriptor>
+    <SingleSignOnService Binding="%s" Location="%s/sso"/>
+  </IDPSSODescriptor>
+</EntityDescriptor>`, idp.URL, base64.StdEncoding.EncodeToString(cert), samlBindingHTTPRedirect, idp.URL)
+	})
+	idp.Server = httptest.NewTLSServer(mux)
+	t.Cleanup(idp.Close)
+	return idp
+}
+
+// response constructs a base64-encoded SAML response containing a signed
+// assertion, invoking fn to modify the assertion after it has been signed.
+func (idp *testIDP) response(t *testing.T, client *samlClient, requestID string, fn func(assertion *etree.Element)) string {
+	now := time.Now().UTC()
+	acs := client.URL(client.acsPath())
+
+	assertion := etree.NewElement("saml:Assertion")
+	assertion.CreateAttr("xmlns:saml", "urn:oasis:names:tc:SAML:2.0:assertion")
+	assertion.CreateAttr("ID", "assertion-1")
+	assertion.CreateAttr("Version", "2.0")
+	assertion.CreateAttr("IssueInstant", now.Format(time.RFC3339))
+	assertion.CreateElement("saml:Issuer").SetText(idp.URL + "/metadata")
+	subject := assertion.CreateElement("saml:Subject")
+	subject.CreateElement("saml:NameID").SetText("bobby")
+	data := subject.CreateElement("saml:SubjectConfirmation").CreateElement("saml:SubjectConfirmationData")
+	data.CreateAttr("InResponseTo", requestID)
+	data.CreateAttr("Recipient", acs)
+	data.CreateAttr("NotOnOrAfter", now.Add(time.Minute).Format(time.RFC3339))
+	conditions := assertion.CreateElement("saml:Conditions")
+	conditions.CreateAttr("NotBefore", now.Add(-time.Minute).Format(time.RFC3339))
+	conditions.CreateAttr("NotOnOrAfter", now.Add(time.Minute).Format(time.RFC3339))
+	conditions.CreateElement("saml:AudienceRestriction").CreateElement("saml:Audience").SetText(client.entityID())
+	attr := assertion.CreateElement("saml:AttributeStatement").CreateElement("saml:Attribute")
+	attr.CreateAttr("Name", "groups")
+	attr.CreateElement("saml:AttributeValue").SetText("devs")
+	attr.CreateElement("saml:AttributeValue").SetText("ops")
+
+	signed, err := idp.signer.SignEnveloped(assertion)
+	require.NoError(t, err)
+	fn(signed)
+
+	doc := etree.NewDocument()
+	response := doc.CreateElement("samlp:Response")
+	response.CreateAttr("xmlns:samlp", "urn:oasis:names:tc:SAML:2.0:protocol")
+	response.CreateAttr("ID", "response-1")
+	response.CreateAttr("Version", "2.0")
+	response.CreateAttr("InResponseTo", requestID)
+	response.CreateAttr("Destination", acs)
+	response.CreateElement("samlp:Status").CreateElement("samlp:StatusCode").CreateAttr("Value", samlStatusSuccess)
+	response.AddChild(signed)
+
+	raw, err := doc.WriteToBytes()
+	require.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(raw)
+}
+
+func newACSRequest(requestID, response string) *http.Request {
+	form := url.Values{"SAMLResponse": {response}}
+	r := httptest.NewRequest("POST", "/saml/acs", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.AddCookie(&http.Cookie{Name: samlCookieName, Value: requestID})
+	return r
+}
+
+func (f *fakeSAMLUserService) SyncSSOTeamMemberships(ctx context.Context, username string, ssoTeamIDs []string) error {
+	f.username = username
+	f.ssoTeamIDs = ssoTeamIDs
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/auth"
 	"github.com/leg100/otf/internal/http/html"
 	"github.com/leg100/otf/internal/logr"
 	"github.com/leg100/otf/internal/tokens"
@@ -18,9 +19,11 @@ type (
 
 		internal.HostnameService
 		tokens.TokensService
+		auth.UserService
 
 		OpaqueHandlerConfigs []OpaqueHandlerConfig
 		IDTokenHandlerConfig OIDCConfig
+		SAMLConfig           SAMLConfig
 
 		SkipTLSVerification bool
 	}
@@ -28,13 +31,26 @@ type (
 	service struct {
 		html.Renderer
 
-		clients []*OAuthClient
+		clients []loginClient
+	}
+
+	// loginClient is a client that logs users onto the system via a third
+	// party identity provider.
+	loginClient interface {
+		// String provides a human-readable identifier for the identity
+		// provider.
+		String() string
+		// RequestPath is the path that initiates the login flow.
+		RequestPath() string
+
+		addHandlers(r *mux.Router)
 	}
 )
 
 // NewAuthenticatorService constructs a service for logging users onto
 // the system. Supports multiple clients: zero or more clients that support an
-// opaque token, and one client that supports IDToken/OIDC.
+// opaque token, one client that supports IDToken/OIDC, and one client that
+// supports SAML.
 func NewAuthenticatorService(ctx context.Context, opts Options) (*service, error) {
 	svc := service{Renderer: opts.Renderer}
 	// Construct clients with opaque token handlers
@@ -53,9 +69,27 @@ func NewAuthenticatorService(ctx context.Context, opts Options) (*service, error
 		if err != nil {
 			return nil, err
 		}
+		// opaque handlers authenticate users with their VCS provider account
+		client.identities = opts.UserService
 		svc.clients = append(svc.clients, client)
 		opts.V(0).Info("activated OAuth client", "name", cfg.Name, "hostname", cfg.Hostname)
 	}
+	// Construct SAML client
+	if opts.SAMLConfig.IDPMetadataURL != "" {
+		opts.SAMLConfig.SkipTLSVerification = opts.SkipTLSVerification
+		client, err := newSAMLClient(
+			ctx,
+			opts.HostnameService,
+			opts.TokensService,
+			opts.UserService,
+			opts.SAMLConfig,
+		)
+		if err != nil {
+			return nil, err
+		}
+		svc.clients = append(svc.clients, client)
+		opts.V(0).Info("activated SAML client", "name", opts.SAMLConfig.Name)
+	}
 	// Construct client with OIDC IDToken handler
 	if opts.IDTokenHandlerConfig.ClientID == "" && opts.IDTokenHandlerConfig.ClientSecret == "" {
 		// skip creating OIDC authenticator when creds are unspecified
@@ -97,7 +131,7 @@ func (a *service) AddHandlers(r *mux.Router) {
 func (a *service) loginHandler(w http.ResponseWriter, r *http.Request) {
 	a.Render("login.tmpl", w, struct {
 		html.SitePage
-		Clients []*OAuthClient
+		Clients []loginClient
 	}{
 		SitePage: html.NewSitePage(r, "login"),
 		Clients:  a.clients,
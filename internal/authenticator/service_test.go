@@ -47,9 +47,9 @@ func TestLoginHandler(t *testing.T) {
 	require.NoError(t, err)
 	svc := &service{Renderer: renderer}
 
-	svc.clients = []*OAuthClient{
-		{OAuthConfig: OAuthConfig{Name: "cloud1"}},
-		{OAuthConfig: OAuthConfig{Name: "cloud2"}},
+	svc.clients = []loginClient{
+		&OAuthClient{OAuthConfig: OAuthConfig{Name: "cloud1"}},
+		&OAuthClient{OAuthConfig: OAuthConfig{Name: "cloud2"}},
 	}
 
 	r := httptest.NewRequest("GET", "/?", nil)
@@ -34,6 +34,10 @@ type WorkspacePolicy struct {
 	WorkspaceID  string
 	Permissions  []WorkspacePermission
 
+	// Permissions granted on the workspace's project, which cascade to the
+	// workspace.
+	ProjectPermissions []WorkspacePermission
+
 	// Whether workspace permits its state to be consumed by all workspaces in
 	// the organization.
 	GlobalRemoteState bool
@@ -60,6 +64,29 @@ func SubjectFromContext(ctx context.Context) (Subject, error) {
 	return subj, nil
 }
 
+// UnwrapSubject returns the subject underlying a subject that restricts the
+// privileges of another subject, e.g. a scoped token. Otherwise the subject
+// itself is returned.
+func UnwrapSubject(subj Subject) Subject {
+	for {
+		wrapper, ok := subj.(interface{ Unwrap() Subject })
+		if !ok {
+			return subj
+		}
+		subj = wrapper.Unwrap()
+	}
+}
+
+// RestrictedWorkspaceIDs returns the IDs of the workspaces to which a subject
+// is restricted, e.g. by the scope of a token, or nil if the subject is not
+// restricted to particular workspaces.
+func RestrictedWorkspaceIDs(subj Subject) []string {
+	if restricted, ok := subj.(interface{ RestrictedWorkspaceIDs() []string }); ok {
+		return restricted.RestrictedWorkspaceIDs()
+	}
+	return nil
+}
+
 // Superuser is a subject with unlimited privileges.
 type Superuser struct {
 	Username string
@@ -70,6 +70,10 @@ func TestCommandTree(t *testing.T) {
 			name: "agent token create",
 			args: []string{"agents", "tokens", "new", "-h"},
 		},
+		{
+			name: "login",
+			args: []string{"login", "-h"},
+		},
 		{
 			name: "invalid",
 			args: []string{"invalid", "-h"},
@@ -0,0 +1,165 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/leg100/otf/internal/api"
+	otfhttp "github.com/leg100/otf/internal/http"
+	"github.com/leg100/otf/internal/loginserver"
+	"github.com/spf13/cobra"
+)
+
+// slowDownIncrement is the amount by which the polling interval is increased
+// upon the server requesting the client to slow down.
+//
+// https://datatracker.ietf.org/doc/html/rfc8628#section-3.5
+const slowDownIncrement = 5 * time.Second
+
+type (
+	// deviceLogin obtains a user token via the OAuth2 device authorization
+	// grant.
+	deviceLogin struct {
+		address string
+		client  *http.Client
+		out     io.Writer
+	}
+
+	deviceAuthorization struct {
+		DeviceCode              string `json:"device_code"`
+		UserCode                string `json:"user_code"`
+		VerificationURI         string `json:"verification_uri"`
+		VerificationURIComplete string `json:"verification_uri_complete"`
+		ExpiresIn               int    `json:"expires_in"`
+		Interval                int    `json:"interval"`
+	}
+
+	tokenResponse struct {
+		AccessToken      string `json:"access_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+)
+
+func (a *CLI) loginCommand(cfg *api.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "login",
+		Short: "Obtain and store an API token",
+		Long: `Obtain an API token by authorizing this device in a browser, possibly on
+another machine, and store the token in the terraform credentials file for use
+by both otf and terraform.`,
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		// Override the parent's pre-run, which requires an existing token.
+		PersistentPreRunE: func(*cobra.Command, []string) error { return nil },
+		RunE: func(cmd *cobra.Command, args []string) error {
+			login := &deviceLogin{
+				address: cfg.Address,
+				client:  http.DefaultClient,
+				out:     cmd.OutOrStdout(),
+			}
+			token, err := login.login(cmd.Context())
+			if err != nil {
+				return err
+			}
+			if err := a.creds.Save(cfg.Address, token); err != nil {
+				return fmt.Errorf("saving token: %w", err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Successfully logged in and stored token in %s\n", a.creds)
+			return nil
+		},
+	}
+}
+
+// login requests authorization for the device, prompts the user to approve it
+// in a browser, and polls the server until the user has done so, returning a
+// user token.
+func (l *deviceLogin) login(ctx context.Context) (string, error) {
+	addr, err := otfhttp.SanitizeAddress(l.address)
+	if err != nil {
+		return "", err
+	}
+
+	var da deviceAuthorization
+	err = l.post(ctx, addr+loginserver.DeviceAuthorizationRoute, url.Values{
+		"client_id": {loginserver.CLIClientID},
+	}, &da)
+	if err != nil {
+		return "", fmt.Errorf("requesting device authorization: %w", err)
+	}
+
+	fmt.Fprintf(l.out, "Open the following URL in a browser:\n\n\t%s\n\n", da.VerificationURI)
+	fmt.Fprintf(l.out, "and enter the code: %s\n\n", da.UserCode)
+	fmt.Fprintf(l.out, "Alternatively, open the following URL:\n\n\t%s\n\n", da.VerificationURIComplete)
+	fmt.Fprintln(l.out, "Waiting for authorization...")
+
+	interval := time.Duration(da.Interval) * time.Second
+	expiry := time.Now().Add(time.Duration(da.ExpiresIn) * time.Second)
+	for {
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(interval):
+		}
+		if time.Now().After(expiry) {
+			return "", errors.New("device authorization expired")
+		}
+
+		var resp tokenResponse
+		err := l.post(ctx, addr+loginserver.TokenRoute, url.Values{
+			"client_id":   {loginserver.CLIClientID},
+			"grant_type":  {loginserver.DeviceCodeGrantType},
+			"device_code": {da.DeviceCode},
+		}, &resp)
+		if err != nil {
+			return "", fmt.Errorf("requesting token: %w", err)
+		}
+		switch resp.Error {
+		case "":
+			return resp.AccessToken, nil
+		case loginserver.ErrAuthorizationPending:
+			continue
+		case loginserver.ErrSlowDown:
+			interval += slowDownIncrement
+			continue
+		case loginserver.ErrAccessDenied:
+			return "", errors.New("authorization denied")
+		case loginserver.ErrExpiredToken:
+			return "", errors.New("device authorization expired")
+		default:
+			if resp.ErrorDescription != "" {
+				return "", fmt.Errorf("%s: %s", resp.Error, resp.ErrorDescription)
+			}
+			return "", errors.New(resp.Error)
+		}
+	}
+}
+
+// post sends a form to the server and decodes the JSON response into dst. An
+// error response from the server that conforms to RFC6749 is decoded into dst
+// too, and only other error responses are returned as an error.
+func (l *deviceLogin) post(ctx context.Context, u string, form url.Values, dst any) error {
+	req, err := http.NewRequestWithContext(ctx, "POST", u, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if !strings.HasPrefix(resp.Header.Get("Content-Type"), "application/json") {
+		return fmt.Errorf("unexpected response: %s", resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(dst)
+}
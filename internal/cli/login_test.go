@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/leg100/otf/internal/loginserver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeviceLogin(t *testing.T) {
+	tests := []struct {
+		name string
+		// errors returned by the token endpoint before returning a token
+		errors  []string
+		want    string
+		wantErr string
+	}{
+		{
+			name:   "approved",
+			errors: []string{loginserver.ErrAuthorizationPending, loginserver.ErrAuthorizationPending},
+			want:   "my-token",
+		},
+		{
+			name:    "denied",
+			errors:  []string{loginserver.ErrAuthorizationPending, loginserver.ErrAccessDenied},
+			wantErr: "authorization denied",
+		},
+		{
+			name:    "expired",
+			errors:  []string{loginserver.ErrExpiredToken},
+			wantErr: "device authorization expired",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errors := tt.errors
+			mux := http.NewServeMux()
+			mux.HandleFunc(loginserver.DeviceAuthorizationRoute, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, loginserver.CLIClientID, r.FormValue("client_id"))
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(deviceAuthorization{
+					DeviceCode:      "device-code",
+					UserCode:        "BCDF-GHJK",
+					VerificationURI: "https://otf.dev/app/oauth2/device",
+					ExpiresIn:       60,
+				})
+			})
+			mux.HandleFunc(loginserver.TokenRoute, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, loginserver.DeviceCodeGrantType, r.FormValue("grant_type"))
+				assert.Equal(t, "device-code", r.FormValue("device_code"))
+				w.Header().Set("Content-Type", "application/json")
+				if len(errors) > 0 {
+					w.WriteHeader(http.StatusBadRequest)
+					json.NewEncoder(w).Encode(tokenResponse{Error: errors[0]})
+					errors = errors[1:]
+					return
+				}
+				json.NewEncoder(w).Encode(tokenResponse{AccessToken: "my-token"})
+			})
+			srv := httptest.NewTLSServer(mux)
+			t.Cleanup(srv.Close)
+
+			out := new(bytes.Buffer)
+			login := &deviceLogin{
+				address: srv.Listener.Addr().String(),
+				client:  srv.Client(),
+				out:     out,
+			}
+			got, err := login.login(context.Background())
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Contains(t, out.String(), "BCDF-GHJK")
+		})
+	}
+}
@@ -1,9 +1,15 @@
 package configversion
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 
 	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
 	otfapi "github.com/leg100/otf/internal/api"
 	"github.com/leg100/otf/internal/http/decode"
 	"github.com/leg100/otf/internal/tfeapi"
@@ -12,13 +18,65 @@ import (
 type api struct {
 	Service
 	*tfeapi.Responder
+
+	maxConfigSize int64
 }
 
 func (a *api) addHandlers(r *mux.Router) {
 	r = r.PathPrefix(otfapi.DefaultBasePath).Subrouter()
+	r.HandleFunc("/workspaces/{workspace_id}/configuration-versions", a.create).Methods("POST")
+	r.HandleFunc("/configuration-versions/{id}/upload", a.upload()).Methods("PUT")
 	r.HandleFunc("/configuration-versions/{id}/download", a.download).Methods("GET")
 }
 
+func (a *api) create(w http.ResponseWriter, r *http.Request) {
+	workspaceID, err := decode.Param("workspace_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var opts ConfigurationVersionCreateOptions
+	if err := json.NewDecoder(r.Body).Decode(&opts); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	cv, err := a.CreateConfigurationVersion(r.Context(), workspaceID, opts)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.Respond(w, r, cv, http.StatusCreated)
+}
+
+func (a *api) upload() http.HandlerFunc {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := decode.Param("id", r)
+		if err != nil {
+			tfeapi.Error(w, err)
+			return
+		}
+		buf := new(bytes.Buffer)
+		if _, err := io.Copy(buf, r.Body); err != nil {
+			maxBytesError := &http.MaxBytesError{}
+			if errors.As(err, &maxBytesError) {
+				tfeapi.Error(w, &internal.HTTPError{
+					Code:    422,
+					Message: fmt.Sprintf("config exceeds maximum size (%d bytes)", a.maxConfigSize),
+				})
+			} else {
+				tfeapi.Error(w, err)
+			}
+			return
+		}
+		if err := a.uploadConfig(r.Context(), id, buf.Bytes()); err != nil {
+			tfeapi.Error(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	})
+	return http.MaxBytesHandler(h, a.maxConfigSize).ServeHTTP
+}
+
 func (a *api) download(w http.ResponseWriter, r *http.Request) {
 	id, err := decode.Param("id", r)
 	if err != nil {
@@ -31,3 +31,26 @@ func (c *Client) DownloadConfig(ctx context.Context, cvID string) ([]byte, error
 
 	return buf.Bytes(), nil
 }
+
+func (c *Client) CreateConfigurationVersion(ctx context.Context, workspaceID string, opts ConfigurationVersionCreateOptions) (*ConfigurationVersion, error) {
+	u := fmt.Sprintf("workspaces/%s/configuration-versions", url.QueryEscape(workspaceID))
+	req, err := c.NewRequest("POST", u, &opts)
+	if err != nil {
+		return nil, err
+	}
+	var cv ConfigurationVersion
+	if err := c.Do(ctx, req, &cv); err != nil {
+		return nil, err
+	}
+	return &cv, nil
+}
+
+// UploadConfig uploads a configuration tarball for a configuration version.
+func (c *Client) UploadConfig(ctx context.Context, cvID string, config []byte) error {
+	u := fmt.Sprintf("configuration-versions/%s/upload", url.QueryEscape(cvID))
+	req, err := c.NewRequest("PUT", u, config)
+	if err != nil {
+		return err
+	}
+	return c.Do(ctx, req, nil)
+}
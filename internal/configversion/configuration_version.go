@@ -25,15 +25,24 @@ type (
 	// ConfigurationVersion is a representation of an uploaded or ingressed
 	// Terraform configuration.
 	ConfigurationVersion struct {
-		ID                string
-		CreatedAt         time.Time
-		AutoQueueRuns     bool
-		Source            Source
-		Speculative       bool
-		Status            ConfigurationStatus
-		StatusTimestamps  []ConfigurationVersionStatusTimestamp
-		WorkspaceID       string
-		IngressAttributes *IngressAttributes
+		ID                string                                `jsonapi:"primary,configuration-versions"`
+		CreatedAt         time.Time                             `jsonapi:"attribute" json:"created_at"`
+		AutoQueueRuns     bool                                  `jsonapi:"attribute" json:"auto_queue_runs"`
+		Source            Source                                `jsonapi:"attribute" json:"source"`
+		Speculative       bool                                  `jsonapi:"attribute" json:"speculative"`
+		Status            ConfigurationStatus                   `jsonapi:"attribute" json:"status"`
+		StatusTimestamps  []ConfigurationVersionStatusTimestamp `jsonapi:"attribute" json:"status_timestamps"`
+		WorkspaceID       string                                `jsonapi:"attribute" json:"workspace_id"`
+		IngressAttributes *IngressAttributes                    `jsonapi:"attribute" json:"ingress_attributes"`
+	}
+
+	// UploadedConfig is a configuration tarball that has been uploaded for a
+	// configuration version.
+	UploadedConfig struct {
+		ConfigurationVersionID string
+		WorkspaceID            string
+		Speculative            bool
+		Config                 []byte
 	}
 
 	// ConfigurationVersionCreateOptions represents the options for creating a
@@ -50,8 +59,8 @@ type (
 	ConfigurationStatus string
 
 	ConfigurationVersionStatusTimestamp struct {
-		Status    ConfigurationStatus
-		Timestamp time.Time
+		Status    ConfigurationStatus `json:"status"`
+		Timestamp time.Time           `json:"timestamp"`
 	}
 
 	// ConfigUploader uploads a config
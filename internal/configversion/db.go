@@ -141,6 +141,52 @@ func (db *pgdb) DeleteConfigurationVersion(ctx context.Context, id string) error
 	return nil
 }
 
+func (db *pgdb) createDeclaredVariables(ctx context.Context, id string, declared []*DeclaredVariable) error {
+	err := db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		for _, v := range declared {
+			def := pgtype.Text{Status: pgtype.Null}
+			if v.Default != nil {
+				def = sql.String(*v.Default)
+			}
+			_, err := q.InsertConfigurationVersionVariable(ctx, pggen.InsertConfigurationVersionVariableParams{
+				ConfigurationVersionID: sql.String(id),
+				Directory:              sql.String(v.Directory),
+				Name:                   sql.String(v.Name),
+				Type:                   sql.String(v.Type),
+				DefaultValue:           def,
+				Description:            sql.String(v.Description),
+				Sensitive:              v.Sensitive,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return sql.Error(err)
+}
+
+func (db *pgdb) listDeclaredVariables(ctx context.Context, id string) ([]*DeclaredVariable, error) {
+	rows, err := db.Conn(ctx).FindConfigurationVersionVariables(ctx, sql.String(id))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	declared := make([]*DeclaredVariable, len(rows))
+	for i, row := range rows {
+		declared[i] = &DeclaredVariable{
+			Name:        row.Name.String,
+			Directory:   row.Directory.String,
+			Type:        row.Type.String,
+			Description: row.Description.String,
+			Sensitive:   row.Sensitive,
+		}
+		if row.DefaultValue.Status == pgtype.Present {
+			declared[i].Default = &row.DefaultValue.String
+		}
+	}
+	return declared, nil
+}
+
 func (db *pgdb) insertCVStatusTimestamp(ctx context.Context, cv *ConfigurationVersion) error {
 	sts, err := cv.StatusTimestamp(cv.Status)
 	if err != nil {
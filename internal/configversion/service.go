@@ -6,6 +6,7 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/gorilla/mux"
 	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/hooks"
 	"github.com/leg100/otf/internal/rbac"
 	"github.com/leg100/otf/internal/resource"
 	"github.com/leg100/otf/internal/sql"
@@ -31,6 +32,16 @@ type (
 		DownloadConfig(ctx context.Context, id string) ([]byte, error)
 
 		DeleteConfigurationVersion(ctx context.Context, cvID string) error
+
+		// ListDeclaredVariables lists the variables declared in the config
+		// uploaded for the given config version ID.
+		ListDeclaredVariables(ctx context.Context, cvID string) ([]*DeclaredVariable, error)
+
+		// AfterUploadConfig registers a listener that is called after a
+		// configuration tarball is uploaded.
+		AfterUploadConfig(l hooks.Listener[*UploadedConfig])
+
+		uploadConfig(ctx context.Context, id string, config []byte) error
 	}
 
 	service struct {
@@ -42,6 +53,8 @@ type (
 		cache  internal.Cache
 		tfeapi *tfe
 		api    *api
+
+		uploadHook *hooks.Hook[*UploadedConfig]
 	}
 
 	Options struct {
@@ -66,6 +79,7 @@ func NewService(opts Options) *service {
 
 	svc.db = &pgdb{opts.DB}
 	svc.cache = opts.Cache
+	svc.uploadHook = hooks.NewHook[*UploadedConfig](opts.DB)
 	svc.tfeapi = &tfe{
 		Service:       &svc,
 		Signer:        opts.Signer,
@@ -73,8 +87,9 @@ func NewService(opts Options) *service {
 		maxConfigSize: opts.MaxConfigSize,
 	}
 	svc.api = &api{
-		Service:   &svc,
-		Responder: opts.Responder,
+		Service:       &svc,
+		Responder:     opts.Responder,
+		maxConfigSize: opts.MaxConfigSize,
 	}
 
 	// Fetch config version when API requests config version be included in the
@@ -92,6 +107,10 @@ func (s *service) AddHandlers(r *mux.Router) {
 	s.api.addHandlers(r)
 }
 
+func (s *service) AfterUploadConfig(l hooks.Listener[*UploadedConfig]) {
+	s.uploadHook.After(l)
+}
+
 func (s *service) CreateConfigurationVersion(ctx context.Context, workspaceID string, opts ConfigurationVersionCreateOptions) (*ConfigurationVersion, error) {
 	subject, err := s.workspace.CanAccess(ctx, rbac.CreateConfigurationVersionAction, workspaceID)
 	if err != nil {
@@ -172,6 +191,21 @@ func (s *service) DeleteConfigurationVersion(ctx context.Context, cvID string) e
 	return nil
 }
 
+func (s *service) ListDeclaredVariables(ctx context.Context, cvID string) ([]*DeclaredVariable, error) {
+	subject, err := s.canAccess(ctx, rbac.GetConfigurationVersionAction, cvID)
+	if err != nil {
+		return nil, err
+	}
+
+	declared, err := s.db.listDeclaredVariables(ctx, cvID)
+	if err != nil {
+		s.Error(err, "listing declared variables", "id", cvID, "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("listed declared variables", "id", cvID, "subject", subject)
+	return declared, nil
+}
+
 func (s *service) canAccess(ctx context.Context, action rbac.Action, cvID string) (internal.Subject, error) {
 	cv, err := s.db.GetConfigurationVersion(ctx, ConfigurationVersionGetOptions{ID: &cvID})
 	if err != nil {
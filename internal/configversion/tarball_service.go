@@ -16,8 +16,21 @@ func cacheKey(cvID string) string {
 //
 // NOTE: unauthenticated - access granted only via signed URL
 func (s *service) UploadConfig(ctx context.Context, cvID string, config []byte) error {
-	err := s.db.UploadConfigurationVersion(ctx, cvID, func(cv *ConfigurationVersion, uploader ConfigUploader) error {
-		return cv.Upload(ctx, config, uploader)
+	cv, err := s.db.GetConfigurationVersion(ctx, ConfigurationVersionGetOptions{ID: &cvID})
+	if err != nil {
+		s.Error(err, "uploading configuration")
+		return err
+	}
+	uploaded := &UploadedConfig{
+		ConfigurationVersionID: cv.ID,
+		WorkspaceID:            cv.WorkspaceID,
+		Speculative:            cv.Speculative,
+		Config:                 config,
+	}
+	err = s.uploadHook.Dispatch(ctx, uploaded, func(ctx context.Context) error {
+		return s.db.UploadConfigurationVersion(ctx, cvID, func(cv *ConfigurationVersion, uploader ConfigUploader) error {
+			return cv.Upload(ctx, config, uploader)
+		})
 	})
 	if err != nil {
 		s.Error(err, "uploading configuration")
@@ -27,9 +40,28 @@ func (s *service) UploadConfig(ctx context.Context, cvID string, config []byte)
 		s.Error(err, "caching configuration version tarball")
 	}
 	s.V(2).Info("uploaded configuration", "id", cvID, "bytes", len(config))
+
+	// Record the variables declared in the config. Invalid terraform files are
+	// only logged, because they are reported to the user when a run fails.
+	declared, err := parseDeclaredVariables(config)
+	if err != nil {
+		s.Error(err, "parsing declared variables", "id", cvID)
+	}
+	if err := s.db.createDeclaredVariables(ctx, cvID, declared); err != nil {
+		s.Error(err, "recording declared variables", "id", cvID)
+	}
 	return nil
 }
 
+// uploadConfig saves a configuration tarball on behalf of an authenticated
+// subject, who must be permitted to create configuration versions.
+func (s *service) uploadConfig(ctx context.Context, cvID string, config []byte) error {
+	if _, err := s.canAccess(ctx, rbac.CreateConfigurationVersionAction, cvID); err != nil {
+		return err
+	}
+	return s.UploadConfig(ctx, cvID, config)
+}
+
 // download retrieves a tarball from the db
 func (s *service) DownloadConfig(ctx context.Context, cvID string) ([]byte, error) {
 	subject, err := s.canAccess(ctx, rbac.DownloadConfigurationVersionAction, cvID)
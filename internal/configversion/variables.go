@@ -0,0 +1,146 @@
+package configversion
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// DeclaredVariable is a variable declared in a configuration with a variable
+// block.
+type DeclaredVariable struct {
+	Name string
+	// Directory containing the module in which the variable is declared,
+	// relative to the root of the configuration. An empty string is the root
+	// of the configuration.
+	Directory string
+	// Type is the source of the type constraint, or an empty string if there
+	// is no type constraint.
+	Type string
+	// Default is the source of the default value, or nil if there is no
+	// default.
+	Default     *string
+	Description string
+	Sensitive   bool
+}
+
+// Required is true if a value must be provided for the variable.
+func (v *DeclaredVariable) Required() bool {
+	return v.Default == nil
+}
+
+// parseDeclaredVariables parses the variable blocks in the terraform files in
+// a configuration tarball. Files that fail to parse are skipped and their
+// errors returned alongside the variables from the remaining files.
+func parseDeclaredVariables(tarball []byte) ([]*DeclaredVariable, error) {
+	gr, err := gzip.NewReader(bytes.NewReader(tarball))
+	if err != nil {
+		return nil, err
+	}
+	defer gr.Close()
+
+	var (
+		declared []*DeclaredVariable
+		errs     []error
+	)
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		name := path.Clean(hdr.Name)
+		if path.Ext(name) != ".tf" || strings.Contains(name, ".terraform/") {
+			continue
+		}
+		src, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		vars, err := parseVariableBlocks(name, src)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		declared = append(declared, vars...)
+	}
+	slices.SortFunc(declared, func(a, b *DeclaredVariable) int {
+		if c := strings.Compare(a.Directory, b.Directory); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return declared, errors.Join(errs...)
+}
+
+func parseVariableBlocks(filename string, src []byte) ([]*DeclaredVariable, error) {
+	file, diags := hclsyntax.ParseConfig(src, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		return nil, fmt.Errorf("%s: unexpected body type", filename)
+	}
+	dir := path.Dir(filename)
+	if dir == "." {
+		dir = ""
+	}
+	var declared []*DeclaredVariable
+	for _, block := range body.Blocks {
+		if block.Type != "variable" || len(block.Labels) != 1 {
+			continue
+		}
+		v := DeclaredVariable{Name: block.Labels[0], Directory: dir}
+		if attr, ok := block.Body.Attributes["type"]; ok {
+			v.Type = string(attr.Expr.Range().SliceBytes(src))
+		}
+		if attr, ok := block.Body.Attributes["default"]; ok {
+			def := string(attr.Expr.Range().SliceBytes(src))
+			v.Default = &def
+		}
+		if attr, ok := block.Body.Attributes["description"]; ok {
+			if val, diags := attr.Expr.Value(nil); !diags.HasErrors() && val.Type() == cty.String && val.IsKnown() && !val.IsNull() {
+				v.Description = val.AsString()
+			}
+		}
+		if attr, ok := block.Body.Attributes["sensitive"]; ok {
+			if val, diags := attr.Expr.Value(nil); !diags.HasErrors() && val.Type() == cty.Bool && val.IsKnown() && !val.IsNull() {
+				v.Sensitive = val.True()
+			}
+		}
+		declared = append(declared, &v)
+	}
+	return declared, nil
+}
+
+// FilterDeclaredVariables returns those variables declared in the module in
+// the given directory, which is relative to the root of the configuration.
+func FilterDeclaredVariables(declared []*DeclaredVariable, directory string) []*DeclaredVariable {
+	directory = path.Clean(strings.TrimPrefix(directory, "/"))
+	if directory == "." {
+		directory = ""
+	}
+	var filtered []*DeclaredVariable
+	for _, v := range declared {
+		if v.Directory == directory {
+			filtered = append(filtered, v)
+		}
+	}
+	return filtered
+}
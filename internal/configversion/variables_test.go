@@ -0,0 +1,75 @@
+package configversion
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDeclaredVariables(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name, contents string) {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(contents), 0o644))
+	}
+	writeFile("variables.tf", `
+variable "region" {
+  type        = string
+  default     = "eu-west-2"
+  description = "AWS region"
+}
+
+variable "instances" {
+  type = map(object({
+    size = string
+  }))
+}
+
+variable "password" {
+  sensitive = true
+}
+
+resource "null_resource" "foo" {}
+`)
+	writeFile("modules/vpc/main.tf", `variable "cidr" {}`)
+	writeFile("invalid.tf", `variable "foo" {`)
+	writeFile("README.md", `variable "bar" {}`)
+
+	tarball, err := internal.Pack(dir)
+	require.NoError(t, err)
+
+	got, err := parseDeclaredVariables(tarball)
+	// invalid file is reported but the remaining files are still parsed
+	assert.ErrorContains(t, err, "invalid.tf")
+	require.Equal(t, 4, len(got))
+
+	assert.Equal(t, &DeclaredVariable{
+		Name: "instances",
+		Type: "map(object({\n    size = string\n  }))",
+	}, got[0])
+	assert.True(t, got[0].Required())
+
+	assert.Equal(t, &DeclaredVariable{Name: "password", Sensitive: true}, got[1])
+
+	assert.Equal(t, &DeclaredVariable{
+		Name:        "region",
+		Type:        "string",
+		Default:     internal.String(`"eu-west-2"`),
+		Description: "AWS region",
+	}, got[2])
+	assert.False(t, got[2].Required())
+
+	assert.Equal(t, &DeclaredVariable{Name: "cidr", Directory: "modules/vpc"}, got[3])
+
+	t.Run("filter", func(t *testing.T) {
+		assert.Equal(t, 3, len(FilterDeclaredVariables(got, "")))
+		assert.Equal(t, 3, len(FilterDeclaredVariables(got, "/")))
+		assert.Equal(t, []*DeclaredVariable{got[3]}, FilterDeclaredVariables(got, "modules/vpc/"))
+		assert.Empty(t, FilterDeclaredVariables(got, "modules"))
+	})
+}
@@ -2,12 +2,15 @@ package daemon
 
 import (
 	"errors"
+	"time"
 
 	"github.com/leg100/otf/internal"
 	"github.com/leg100/otf/internal/agent"
 	"github.com/leg100/otf/internal/authenticator"
 	"github.com/leg100/otf/internal/configversion"
 	"github.com/leg100/otf/internal/inmem"
+	"github.com/leg100/otf/internal/provider"
+	"github.com/leg100/otf/internal/pubsub"
 	"github.com/leg100/otf/internal/tokens"
 )
 
@@ -25,6 +28,7 @@ type Config struct {
 	GitlabClientID               string
 	GitlabClientSecret           string
 	OIDC                         authenticator.OIDCConfig
+	SAML                         authenticator.SAMLConfig
 	Secret                       []byte // 16-byte secret for signing URLs and encrypting payloads
 	SiteToken                    string
 	Host                         string
@@ -39,6 +43,11 @@ type Config struct {
 	RestrictOrganizationCreation bool
 	SiteAdmins                   []string
 	SkipTLSVerification          bool
+	EventRetention               time.Duration
+	EventCompactionInterval      time.Duration
+	PubSubTransport              pubsub.TransportKind
+	PubSubPollInterval           time.Duration
+	ProviderMirror               provider.MirrorConfig
 	// skip checks for latest terraform version
 	DisableLatestChecker *bool
 
@@ -57,6 +66,15 @@ func ApplyDefaults(cfg *Config) {
 	if cfg.MaxConfigSize == 0 {
 		cfg.MaxConfigSize = configversion.DefaultConfigMaxSize
 	}
+	if cfg.ProviderMirror.Dir == "" {
+		cfg.ProviderMirror.Dir = provider.DefaultMirrorDir
+	}
+	if cfg.ProviderMirror.UpstreamHosts == nil {
+		cfg.ProviderMirror.UpstreamHosts = provider.DefaultMirrorUpstreamHosts
+	}
+	if cfg.EventCompactionInterval == 0 {
+		cfg.EventCompactionInterval = pubsub.DefaultEventCompactionInterval
+	}
 }
 
 func (cfg *Config) Valid() error {
@@ -17,6 +17,7 @@ import (
 	"github.com/leg100/otf/internal/configversion"
 	"github.com/leg100/otf/internal/connections"
 	"github.com/leg100/otf/internal/disco"
+	"github.com/leg100/otf/internal/explorer"
 	"github.com/leg100/otf/internal/ghapphandler"
 	"github.com/leg100/otf/internal/github"
 	"github.com/leg100/otf/internal/gitlab"
@@ -28,6 +29,8 @@ import (
 	"github.com/leg100/otf/internal/module"
 	"github.com/leg100/otf/internal/notifications"
 	"github.com/leg100/otf/internal/organization"
+	"github.com/leg100/otf/internal/project"
+	"github.com/leg100/otf/internal/provider"
 	"github.com/leg100/otf/internal/pubsub"
 	"github.com/leg100/otf/internal/releases"
 	"github.com/leg100/otf/internal/repohooks"
@@ -35,6 +38,7 @@ import (
 	"github.com/leg100/otf/internal/scheduler"
 	"github.com/leg100/otf/internal/sql"
 	"github.com/leg100/otf/internal/state"
+	"github.com/leg100/otf/internal/stream"
 	"github.com/leg100/otf/internal/tfeapi"
 	"github.com/leg100/otf/internal/tokens"
 	"github.com/leg100/otf/internal/variable"
@@ -59,7 +63,9 @@ type (
 		vcsprovider.VCSProviderService
 		state.StateService
 		workspace.WorkspaceService
+		project.ProjectService
 		module.ModuleService
+		provider.ProviderService
 		internal.HostnameService
 		configversion.ConfigurationVersionService
 		run.RunService
@@ -112,7 +118,13 @@ func New(ctx context.Context, logger logr.Logger, cfg Config) (*Daemon, error) {
 
 	responder := tfeapi.NewResponder()
 
-	broker := pubsub.NewBroker(logger, db)
+	broker, err := pubsub.NewBroker(logger, db, pubsub.BrokerOptions{
+		Transport:    cfg.PubSubTransport,
+		PollInterval: cfg.PubSubPollInterval,
+	})
+	if err != nil {
+		return nil, err
+	}
 
 	// Setup url signer
 	signer := internal.NewSigner(cfg.Secret)
@@ -201,6 +213,13 @@ func New(ctx context.Context, logger logr.Logger, cfg Config) (*Daemon, error) {
 	if cfg.DisableLatestChecker == nil || !*cfg.DisableLatestChecker {
 		releasesService.StartLatestChecker(ctx)
 	}
+	projectService := project.NewService(project.Options{
+		Logger:      logger,
+		DB:          db,
+		Renderer:    renderer,
+		Responder:   responder,
+		TeamService: authService,
+	})
 	workspaceService := workspace.NewService(workspace.Options{
 		Logger:              logger,
 		DB:                  db,
@@ -211,6 +230,7 @@ func New(ctx context.Context, logger logr.Logger, cfg Config) (*Daemon, error) {
 		TeamService:         authService,
 		OrganizationService: orgService,
 		VCSProviderService:  vcsProviderService,
+		ProjectService:      projectService,
 	})
 	configService := configversion.NewService(configversion.Options{
 		Logger:              logger,
@@ -237,6 +257,8 @@ func New(ctx context.Context, logger logr.Logger, cfg Config) (*Daemon, error) {
 		VCSEventSubscriber:          vcsEventBroker,
 		Signer:                      signer,
 		ReleasesService:             releasesService,
+		UserService:                 authService,
+		HostnameService:             hostnameService,
 	})
 	logsService := logs.NewService(logs.Options{
 		Logger:        logger,
@@ -250,12 +272,23 @@ func New(ctx context.Context, logger logr.Logger, cfg Config) (*Daemon, error) {
 		Logger:             logger,
 		DB:                 db,
 		Renderer:           renderer,
+		Responder:          responder,
 		HostnameService:    hostnameService,
 		VCSProviderService: vcsProviderService,
 		Signer:             signer,
 		ConnectionService:  connectionService,
 		RepohookService:    repoService,
 		VCSEventSubscriber: vcsEventBroker,
+
+		ConfigurationVersionService: configService,
+	})
+	providerService := provider.NewService(provider.Options{
+		Logger:          logger,
+		DB:              db,
+		Renderer:        renderer,
+		HostnameService: hostnameService,
+		Signer:          signer,
+		Mirror:          cfg.ProviderMirror,
 	})
 	stateService := state.NewService(state.Options{
 		Logger:              logger,
@@ -266,6 +299,7 @@ func New(ctx context.Context, logger logr.Logger, cfg Config) (*Daemon, error) {
 		Renderer:            renderer,
 		Responder:           responder,
 		Signer:              signer,
+		Broker:              broker,
 	})
 	variableService := variable.NewService(variable.Options{
 		Logger:              logger,
@@ -274,7 +308,21 @@ func New(ctx context.Context, logger logr.Logger, cfg Config) (*Daemon, error) {
 		Responder:           responder,
 		WorkspaceAuthorizer: workspaceService,
 		WorkspaceService:    workspaceService,
+		ProjectService:      projectService,
 		RunService:          runService,
+		Broker:              broker,
+
+		ConfigurationVersionService: configService,
+	})
+
+	explorerService := explorer.NewService(explorer.Options{
+		Logger:                      logger,
+		Renderer:                    renderer,
+		Responder:                   responder,
+		DB:                          db,
+		WorkspaceService:            workspaceService,
+		ConfigurationVersionService: configService,
+		StateIndexer:                stateService,
 	})
 
 	agent, err := agent.NewAgent(
@@ -288,6 +336,7 @@ func New(ctx context.Context, logger logr.Logger, cfg Config) (*Daemon, error) {
 			ConfigurationVersionService: configService,
 			RunService:                  runService,
 			LogsService:                 logsService,
+			ModuleService:               moduleService,
 		},
 		*cfg.AgentConfig,
 	)
@@ -300,6 +349,7 @@ func New(ctx context.Context, logger logr.Logger, cfg Config) (*Daemon, error) {
 		Renderer:        renderer,
 		HostnameService: hostnameService,
 		TokensService:   tokensService,
+		UserService:     authService,
 		OpaqueHandlerConfigs: []authenticator.OpaqueHandlerConfig{
 			{
 				ClientConstructor: github.NewOAuthClient,
@@ -325,6 +375,7 @@ func New(ctx context.Context, logger logr.Logger, cfg Config) (*Daemon, error) {
 			},
 		},
 		IDTokenHandlerConfig: cfg.OIDC,
+		SAMLConfig:           cfg.SAML,
 		SkipTLSVerification:  cfg.SkipTLSVerification,
 	})
 	if err != nil {
@@ -341,8 +392,15 @@ func New(ctx context.Context, logger logr.Logger, cfg Config) (*Daemon, error) {
 		HostnameService:     hostnameService,
 	})
 
+	streamService := stream.NewService(stream.Options{
+		Logger:           logger,
+		Broker:           broker,
+		WorkspaceService: workspaceService,
+	})
+
 	loginServer, err := loginserver.NewServer(loginserver.Options{
 		Secret:        cfg.Secret,
+		DB:            db,
 		Renderer:      renderer,
 		TokensService: tokensService,
 	})
@@ -354,11 +412,13 @@ func New(ctx context.Context, logger logr.Logger, cfg Config) (*Daemon, error) {
 		authService,
 		tokensService,
 		workspaceService,
+		projectService,
 		stateService,
 		orgService,
 		variableService,
 		vcsProviderService,
 		moduleService,
+		providerService,
 		runService,
 		logsService,
 		repoService,
@@ -367,6 +427,8 @@ func New(ctx context.Context, logger logr.Logger, cfg Config) (*Daemon, error) {
 		configService,
 		notificationService,
 		githubAppService,
+		explorerService,
+		streamService,
 		disco.Service{},
 		&ghapphandler.Handler{
 			Logger:             logger,
@@ -385,11 +447,13 @@ func New(ctx context.Context, logger logr.Logger, cfg Config) (*Daemon, error) {
 		AuthService:                 authService,
 		TokensService:               tokensService,
 		WorkspaceService:            workspaceService,
+		ProjectService:              projectService,
 		OrganizationService:         orgService,
 		VariableService:             variableService,
 		VCSProviderService:          vcsProviderService,
 		StateService:                stateService,
 		ModuleService:               moduleService,
+		ProviderService:             providerService,
 		HostnameService:             hostnameService,
 		ConfigurationVersionService: configService,
 		RunService:                  runService,
@@ -464,8 +528,22 @@ func (d *Daemon) Start(ctx context.Context, started chan struct{}) error {
 				HostnameService:             d.HostnameService,
 				ConfigurationVersionService: d.ConfigurationVersionService,
 				WorkspaceService:            d.WorkspaceService,
+				RunService:                  d.RunService,
 			},
 		},
+		{
+			Name:      "compactor",
+			Logger:    d.Logger,
+			Exclusive: true,
+			DB:        d.DB,
+			LockID:    internal.Int64(pubsub.CompactorLockID),
+			System: pubsub.NewCompactor(pubsub.CompactorOptions{
+				Logger:    d.Logger,
+				DB:        d.DB,
+				Retention: d.EventRetention,
+				Interval:  d.EventCompactionInterval,
+			}),
+		},
 		{
 			Name:      "notifier",
 			Logger:    d.Logger,
@@ -480,6 +558,17 @@ func (d *Daemon) Start(ctx context.Context, started chan struct{}) error {
 				DB:               d.DB,
 			}),
 		},
+		{
+			Name:      "module-test-reaper",
+			Logger:    d.Logger,
+			Exclusive: true,
+			DB:        d.DB,
+			LockID:    internal.Int64(module.TestReaperLockID),
+			System: module.NewTestReaper(module.TestReaperOptions{
+				Logger: d.Logger,
+				DB:     d.DB,
+			}),
+		},
 	}
 	if !d.DisableScheduler {
 		subsystems = append(subsystems, &Subsystem{
@@ -0,0 +1,90 @@
+package explorer
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	otfapi "github.com/leg100/otf/internal/api"
+	"github.com/leg100/otf/internal/http/decode"
+	"github.com/leg100/otf/internal/tfeapi"
+)
+
+type api struct {
+	*tfeapi.Responder
+
+	svc Service
+}
+
+func (a *api) addHandlers(r *mux.Router) {
+	r = r.PathPrefix(otfapi.DefaultBasePath).Subrouter()
+
+	r.HandleFunc("/organizations/{organization_name}/explorer", a.list).Methods("GET")
+	r.HandleFunc("/organizations/{organization_name}/explorer/export", a.export).Methods("GET")
+	r.HandleFunc("/organizations/{organization_name}/explorer/terraform-versions", a.listTerraformVersions).Methods("GET")
+	r.HandleFunc("/organizations/{organization_name}/explorer/provider-versions", a.listProviderVersions).Methods("GET")
+}
+
+func (a *api) list(w http.ResponseWriter, r *http.Request) {
+	var opts ListOptions
+	if err := decode.All(&opts, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	summaries, err := a.svc.ListWorkspaceSummaries(r.Context(), opts)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.Respond(w, r, summaries, http.StatusOK)
+}
+
+func (a *api) listTerraformVersions(w http.ResponseWriter, r *http.Request) {
+	var opts ListOptions
+	if err := decode.All(&opts, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	versions, err := a.svc.ListTerraformVersions(r.Context(), opts)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.Respond(w, r, versions, http.StatusOK)
+}
+
+func (a *api) listProviderVersions(w http.ResponseWriter, r *http.Request) {
+	var opts ListOptions
+	if err := decode.All(&opts, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	versions, err := a.svc.ListProviderVersions(r.Context(), opts)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.Respond(w, r, versions, http.StatusOK)
+}
+
+func (a *api) export(w http.ResponseWriter, r *http.Request) {
+	var opts ListOptions
+	if err := decode.All(&opts, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	summaries, err := a.svc.ListWorkspaceSummaries(r.Context(), opts)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	respondWithCSV(w, opts.Organization, summaries)
+}
+
+// respondWithCSV writes summaries to the response as a CSV file attachment.
+func respondWithCSV(w http.ResponseWriter, organization string, summaries []*WorkspaceSummary) {
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+organization+`-workspaces.csv"`)
+	if err := writeCSV(w, summaries); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
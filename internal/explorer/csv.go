@@ -0,0 +1,60 @@
+package explorer
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+)
+
+var csvHeader = []string{
+	"workspace_id",
+	"workspace_name",
+	"terraform_version",
+	"latest_run_id",
+	"latest_run_status",
+	"drifted",
+	"providers",
+	"modules",
+	"resource_count",
+}
+
+// writeCSV writes summaries in CSV format to w, one row per workspace. Multiple
+// providers and modules are separated by a space, with each provider written
+// as <source>@<version>, and each module written as <name>=<source>, followed
+// by @<version> if the module call has a version constraint.
+func writeCSV(w io.Writer, summaries []*WorkspaceSummary) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, s := range summaries {
+		providers := make([]string, len(s.Providers))
+		for i, p := range s.Providers {
+			providers[i] = p.Source + "@" + p.Version
+		}
+		modules := make([]string, len(s.Modules))
+		for i, m := range s.Modules {
+			modules[i] = m.Name + "=" + m.Source
+			if m.Version != "" {
+				modules[i] += "@" + m.Version
+			}
+		}
+		record := []string{
+			s.ID,
+			s.Name,
+			s.TerraformVersion,
+			s.LatestRunID,
+			s.LatestRunStatus,
+			strconv.FormatBool(s.Drifted),
+			strings.Join(providers, " "),
+			strings.Join(modules, " "),
+			strconv.Itoa(s.ResourceCount),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,79 @@
+package explorer
+
+import (
+	"context"
+
+	"github.com/leg100/otf/internal/run"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/sql/pggen"
+)
+
+type (
+	// pgdb is the explorer database on postgres
+	pgdb struct {
+		*sql.DB // provides access to generated SQL queries
+	}
+
+	// summaryRow is a workspace summary retrieved from the database, along
+	// with the lock file of its latest run.
+	summaryRow struct {
+		*WorkspaceSummary
+
+		LockFile []byte
+	}
+)
+
+// createModules records the modules called by a configuration version. The
+// inserts are performed in their own (nested) transaction so that a failure
+// does not abort any enclosing transaction.
+func (db *pgdb) createModules(ctx context.Context, configurationVersionID string, modules []Module) error {
+	return db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		for _, mod := range modules {
+			_, err := q.InsertConfigurationVersionModule(ctx, pggen.InsertConfigurationVersionModuleParams{
+				ConfigurationVersionID: sql.String(configurationVersionID),
+				Name:                   sql.String(mod.Name),
+				Source:                 sql.String(mod.Source),
+				Version:                sql.String(mod.Version),
+			})
+			if err != nil {
+				return sql.Error(err)
+			}
+		}
+		return nil
+	})
+}
+
+// listSummaries lists summaries of the workspaces in an organization with the
+// given IDs.
+func (db *pgdb) listSummaries(ctx context.Context, organization string, workspaceIDs []string) ([]summaryRow, error) {
+	rows, err := db.Conn(ctx).FindWorkspaceSummaries(ctx, sql.String(organization), workspaceIDs)
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	summaries := make([]summaryRow, len(rows))
+	for i, r := range rows {
+		summary := &WorkspaceSummary{
+			ID:               r.WorkspaceID.String,
+			Name:             r.Name.String,
+			Organization:     organization,
+			TerraformVersion: r.TerraformVersion.String,
+			LatestRunID:      r.LatestRunID.String,
+			LatestRunStatus:  r.LatestRunStatus.String,
+			ResourceCount:    int(r.ResourceCount.Int),
+		}
+		summary.Drifted = drifted(run.Status(r.LatestRunStatus.String), r.LatestRunPlanOnly.Bool, run.Report{
+			Additions:    int(r.PlanAdditions.Int),
+			Changes:      int(r.PlanChanges.Int),
+			Destructions: int(r.PlanDestructions.Int),
+		})
+		for j, name := range r.ModuleNames {
+			summary.Modules = append(summary.Modules, Module{
+				Name:    name,
+				Source:  r.ModuleSources[j],
+				Version: r.ModuleVersions[j],
+			})
+		}
+		summaries[i] = summaryRow{WorkspaceSummary: summary, LockFile: r.LockFile}
+	}
+	return summaries, nil
+}
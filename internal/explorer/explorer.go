@@ -0,0 +1,151 @@
+// Package explorer provides an inventory of the workspaces in an
+// organization, aggregating their terraform versions, providers, modules, runs
+// and resources.
+package explorer
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/leg100/otf/internal/run"
+)
+
+type (
+	// WorkspaceSummary summarises the make-up of a workspace.
+	WorkspaceSummary struct {
+		ID               string `jsonapi:"primary,explorer-workspaces"`
+		Name             string `jsonapi:"attribute" json:"name"`
+		Organization     string `jsonapi:"attribute" json:"organization"`
+		TerraformVersion string `jsonapi:"attribute" json:"terraform-version"`
+		// LatestRunID and LatestRunStatus are empty if the workspace has no
+		// runs.
+		LatestRunID     string `jsonapi:"attribute" json:"latest-run-id"`
+		LatestRunStatus string `jsonapi:"attribute" json:"latest-run-status"`
+		// Drifted is true if the plan of the latest run found changes that
+		// have not been applied, i.e. the real infrastructure no longer
+		// matches the configuration. Plan-only runs, e.g. those triggered by
+		// pull requests, are disregarded.
+		Drifted bool `jsonapi:"attribute" json:"drifted"`
+		// Providers are those recorded in the lock file of the latest run.
+		Providers []Provider `jsonapi:"attribute" json:"providers"`
+		// Modules are the modules called by the configuration of the latest
+		// run.
+		Modules []Module `jsonapi:"attribute" json:"modules"`
+		// ResourceCount is the number of managed resource instances in the
+		// current state.
+		ResourceCount int `jsonapi:"attribute" json:"resource-count"`
+	}
+
+	// Provider is a provider and the version selected for a workspace.
+	Provider struct {
+		Source  string `json:"source"`
+		Version string `json:"version"`
+	}
+
+	// Module is a call to a module in terraform configuration.
+	Module struct {
+		Name   string `json:"name"`
+		Source string `json:"source"`
+		// Version is the version constraint, or an empty string if there is
+		// no constraint.
+		Version string `json:"version"`
+	}
+
+	// TerraformVersionSummary is a terraform version and the number of
+	// workspaces using it.
+	TerraformVersionSummary struct {
+		Version    string `jsonapi:"primary,explorer-terraform-versions"`
+		Workspaces int    `jsonapi:"attribute" json:"workspaces"`
+	}
+
+	// ProviderVersionSummary is a provider version and the number of
+	// workspaces using it.
+	ProviderVersionSummary struct {
+		ID         string `jsonapi:"primary,explorer-provider-versions"`
+		Source     string `jsonapi:"attribute" json:"source"`
+		Version    string `jsonapi:"attribute" json:"version"`
+		Workspaces int    `jsonapi:"attribute" json:"workspaces"`
+	}
+
+	// ListOptions are options for listing workspace summaries.
+	ListOptions struct {
+		Organization string `schema:"organization_name,required"`
+		// Filter by terraform version
+		TerraformVersion string `schema:"terraform_version"`
+		// Filter by workspaces using providers whose source contains the
+		// given string, e.g. hashicorp/aws
+		Provider string `schema:"provider"`
+	}
+)
+
+// drifted determines whether a run found changes that have not been applied.
+func drifted(status run.Status, planOnly bool, report run.Report) bool {
+	if planOnly || !report.HasChanges() {
+		return false
+	}
+	switch status {
+	case run.RunPlanned, run.RunDiscarded, run.RunCanceled, run.RunForceCanceled:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *WorkspaceSummary) match(opts ListOptions) bool {
+	if opts.TerraformVersion != "" && opts.TerraformVersion != s.TerraformVersion {
+		return false
+	}
+	if opts.Provider != "" {
+		for _, p := range s.Providers {
+			if strings.Contains(p.Source, opts.Provider) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// summarizeTerraformVersions aggregates the terraform versions used by
+// workspaces, sorted by version.
+func summarizeTerraformVersions(summaries []*WorkspaceSummary) []*TerraformVersionSummary {
+	counts := make(map[string]int)
+	for _, s := range summaries {
+		counts[s.TerraformVersion]++
+	}
+	versions := make([]*TerraformVersionSummary, 0, len(counts))
+	for v, n := range counts {
+		versions = append(versions, &TerraformVersionSummary{Version: v, Workspaces: n})
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].Version < versions[j].Version
+	})
+	return versions
+}
+
+// summarizeProviderVersions aggregates the provider versions used by
+// workspaces, sorted by source and version.
+func summarizeProviderVersions(summaries []*WorkspaceSummary) []*ProviderVersionSummary {
+	counts := make(map[Provider]int)
+	for _, s := range summaries {
+		for _, p := range s.Providers {
+			counts[p]++
+		}
+	}
+	versions := make([]*ProviderVersionSummary, 0, len(counts))
+	for p, n := range counts {
+		versions = append(versions, &ProviderVersionSummary{
+			ID:         p.Source + "@" + p.Version,
+			Source:     p.Source,
+			Version:    p.Version,
+			Workspaces: n,
+		})
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		if versions[i].Source != versions[j].Source {
+			return versions[i].Source < versions[j].Source
+		}
+		return versions[i].Version < versions[j].Version
+	})
+	return versions
+}
@@ -0,0 +1,31 @@
+package explorer
+
+import (
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsimple"
+)
+
+// lockFile is the dependency lock file, .terraform.lock.hcl, that terraform
+// generates upon initialization. Only those fields of interest are decoded.
+type lockFile struct {
+	Providers []struct {
+		Source  string   `hcl:"source,label"`
+		Version string   `hcl:"version"`
+		Remain  hcl.Body `hcl:",remain"`
+	} `hcl:"provider,block"`
+	Remain hcl.Body `hcl:",remain"`
+}
+
+// parseLockFile returns the providers and their selected versions from a
+// lock file.
+func parseLockFile(src []byte) ([]Provider, error) {
+	var lock lockFile
+	if err := hclsimple.Decode(".terraform.lock.hcl", src, nil, &lock); err != nil {
+		return nil, err
+	}
+	providers := make([]Provider, len(lock.Providers))
+	for i, p := range lock.Providers {
+		providers[i] = Provider{Source: p.Source, Version: p.Version}
+	}
+	return providers, nil
+}
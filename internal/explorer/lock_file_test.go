@@ -0,0 +1,23 @@
+package explorer
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLockFile(t *testing.T) {
+	src, err := os.ReadFile("./testdata/terraform.lock.hcl")
+	require.NoError(t, err)
+
+	got, err := parseLockFile(src)
+	require.NoError(t, err)
+
+	want := []Provider{
+		{Source: "registry.terraform.io/hashicorp/aws", Version: "4.67.0"},
+		{Source: "registry.terraform.io/hashicorp/null", Version: "3.2.1"},
+	}
+	assert.Equal(t, want, got)
+}
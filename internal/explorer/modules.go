@@ -0,0 +1,87 @@
+package explorer
+
+import (
+	"bytes"
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/hashicorp/terraform-config-inspect/tfconfig"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/configversion"
+)
+
+// recordModules records the modules called by configuration uploaded to a
+// workspace. Failing to record modules should not fail the upload, so errors
+// are only logged.
+func (s *service) recordModules(ctx context.Context, uploaded *configversion.UploadedConfig) error {
+	modules, err := parseModuleCalls(uploaded.Config)
+	if err != nil {
+		s.Error(err, "parsing module calls", "configuration_version_id", uploaded.ConfigurationVersionID)
+		return nil
+	}
+	if err := s.db.createModules(ctx, uploaded.ConfigurationVersionID, modules); err != nil {
+		s.Error(err, "recording module calls", "configuration_version_id", uploaded.ConfigurationVersionID)
+		return nil
+	}
+	return nil
+}
+
+// parseModuleCalls parses the module calls in a configuration tarball. Calls
+// from every module in the configuration are parsed, not only the root module,
+// because local modules may in turn call other modules. The calls are sorted
+// by name, source and version.
+func parseModuleCalls(tarball []byte) ([]Module, error) {
+	dir, err := os.MkdirTemp("", "")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	if err := internal.Unpack(bytes.NewReader(tarball), dir); err != nil {
+		return nil, err
+	}
+
+	var modules []Module
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		// skip modules installed by terraform
+		if d.Name() == ".terraform" {
+			return filepath.SkipDir
+		}
+		if !tfconfig.IsModuleDir(path) {
+			return nil
+		}
+		// invalid configuration is reported to the user when a run fails, so
+		// parse what can be parsed.
+		mod, _ := tfconfig.LoadModule(path)
+		for _, call := range mod.ModuleCalls {
+			modules = append(modules, Module{
+				Name:    call.Name,
+				Source:  call.Source,
+				Version: call.Version,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(modules, func(i, j int) bool {
+		if modules[i].Name != modules[j].Name {
+			return modules[i].Name < modules[j].Name
+		}
+		if modules[i].Source != modules[j].Source {
+			return modules[i].Source < modules[j].Source
+		}
+		return modules[i].Version < modules[j].Version
+	})
+	return modules, nil
+}
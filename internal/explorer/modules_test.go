@@ -0,0 +1,24 @@
+package explorer
+
+import (
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseModuleCalls(t *testing.T) {
+	tarball, err := internal.Pack("./testdata/config")
+	require.NoError(t, err)
+
+	got, err := parseModuleCalls(tarball)
+	require.NoError(t, err)
+
+	want := []Module{
+		{Name: "network", Source: "./modules/network"},
+		{Name: "subnets", Source: "otf.ninja/acme/subnets/aws", Version: "1.2.0"},
+		{Name: "vpc", Source: "terraform-aws-modules/vpc/aws", Version: "~> 5.0"},
+	}
+	assert.Equal(t, want, got)
+}
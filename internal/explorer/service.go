@@ -0,0 +1,152 @@
+package explorer
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal/configversion"
+	"github.com/leg100/otf/internal/http/html"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/tfeapi"
+	"github.com/leg100/otf/internal/workspace"
+)
+
+type (
+	ExplorerService = Service
+
+	Service interface {
+		// ListWorkspaceSummaries lists a summary of each workspace in an
+		// organization that the caller has access to.
+		ListWorkspaceSummaries(ctx context.Context, opts ListOptions) ([]*WorkspaceSummary, error)
+		// ListTerraformVersions lists the terraform versions used by the
+		// workspaces in an organization that the caller has access to.
+		ListTerraformVersions(ctx context.Context, opts ListOptions) ([]*TerraformVersionSummary, error)
+		// ListProviderVersions lists the provider versions used by the
+		// workspaces in an organization that the caller has access to.
+		ListProviderVersions(ctx context.Context, opts ListOptions) ([]*ProviderVersionSummary, error)
+	}
+
+	service struct {
+		logr.Logger
+
+		workspace.WorkspaceService
+
+		db      explorerDB
+		indexer StateIndexer
+		web     *webHandlers
+		api     *api
+	}
+
+	// StateIndexer indexes the resources of the current state of workspaces.
+	StateIndexer interface {
+		IndexResources(ctx context.Context, organization string) error
+	}
+
+	explorerDB interface {
+		createModules(ctx context.Context, configurationVersionID string, modules []Module) error
+		listSummaries(ctx context.Context, organization string, workspaceIDs []string) ([]summaryRow, error)
+	}
+
+	Options struct {
+		logr.Logger
+		html.Renderer
+		*tfeapi.Responder
+		*sql.DB
+
+		workspace.WorkspaceService
+		configversion.ConfigurationVersionService
+		StateIndexer
+	}
+)
+
+func NewService(opts Options) *service {
+	svc := service{
+		Logger:           opts.Logger,
+		WorkspaceService: opts.WorkspaceService,
+		db:               &pgdb{opts.DB},
+		indexer:          opts.StateIndexer,
+	}
+	svc.web = &webHandlers{
+		Renderer: opts.Renderer,
+		svc:      &svc,
+	}
+	svc.api = &api{
+		svc:       &svc,
+		Responder: opts.Responder,
+	}
+	// Record the modules called by workspaces from their uploaded
+	// configuration
+	opts.ConfigurationVersionService.AfterUploadConfig(svc.recordModules)
+	return &svc
+}
+
+func (s *service) AddHandlers(r *mux.Router) {
+	s.web.addHandlers(r)
+	s.api.addHandlers(r)
+}
+
+func (s *service) ListWorkspaceSummaries(ctx context.Context, opts ListOptions) ([]*WorkspaceSummary, error) {
+	// listing workspaces only returns those workspaces the caller has access
+	// to.
+	workspaces, err := resource.ListAll(func(pageOpts resource.PageOptions) (*resource.Page[*workspace.Workspace], error) {
+		return s.ListWorkspaces(ctx, workspace.ListOptions{
+			Organization: &opts.Organization,
+			PageOptions:  pageOpts,
+		})
+	})
+	if err != nil {
+		s.Error(err, "listing workspace summaries", "organization", opts.Organization)
+		return nil, err
+	}
+	workspaceIDs := make([]string, len(workspaces))
+	for i, ws := range workspaces {
+		workspaceIDs[i] = ws.ID
+	}
+	// resource counts are retrieved from the index of state resources, so
+	// ensure the index is up-to-date.
+	if err := s.indexer.IndexResources(ctx, opts.Organization); err != nil {
+		s.Error(err, "indexing state resources", "organization", opts.Organization)
+		return nil, err
+	}
+	rows, err := s.db.listSummaries(ctx, opts.Organization, workspaceIDs)
+	if err != nil {
+		s.Error(err, "listing workspace summaries", "organization", opts.Organization)
+		return nil, err
+	}
+
+	summaries := make([]*WorkspaceSummary, 0, len(rows))
+	for _, row := range rows {
+		if len(row.LockFile) > 0 {
+			providers, err := parseLockFile(row.LockFile)
+			if err != nil {
+				// don't let a malformed lock file prevent the summary from
+				// being listed.
+				s.Error(err, "parsing lock file", "run", row.LatestRunID)
+			}
+			row.Providers = providers
+		}
+		if row.match(opts) {
+			summaries = append(summaries, row.WorkspaceSummary)
+		}
+	}
+	s.V(9).Info("listed workspace summaries", "organization", opts.Organization, "count", len(summaries))
+	return summaries, nil
+}
+
+func (s *service) ListTerraformVersions(ctx context.Context, opts ListOptions) ([]*TerraformVersionSummary, error) {
+	summaries, err := s.ListWorkspaceSummaries(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return summarizeTerraformVersions(summaries), nil
+}
+
+func (s *service) ListProviderVersions(ctx context.Context, opts ListOptions) ([]*ProviderVersionSummary, error) {
+	summaries, err := s.ListWorkspaceSummaries(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return summarizeProviderVersions(summaries), nil
+}
@@ -0,0 +1,229 @@
+package explorer
+
+import (
+	"context"
+	"net/http/httptest"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/run"
+	"github.com/leg100/otf/internal/testutils"
+	"github.com/leg100/otf/internal/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListWorkspaceSummaries(t *testing.T) {
+	ctx := context.Background()
+	lockFile, err := os.ReadFile("./testdata/terraform.lock.hcl")
+	require.NoError(t, err)
+
+	svc := newTestService(t, lockFile)
+
+	t.Run("all", func(t *testing.T) {
+		got, err := svc.ListWorkspaceSummaries(ctx, ListOptions{Organization: "acme"})
+		require.NoError(t, err)
+
+		want := []*WorkspaceSummary{
+			{
+				ID:               "ws-dev",
+				Name:             "dev",
+				Organization:     "acme",
+				TerraformVersion: "1.5.7",
+				LatestRunID:      "run-123",
+				LatestRunStatus:  "planned",
+				Drifted:          true,
+				Providers: []Provider{
+					{Source: "registry.terraform.io/hashicorp/aws", Version: "4.67.0"},
+					{Source: "registry.terraform.io/hashicorp/null", Version: "3.2.1"},
+				},
+				Modules: []Module{
+					{Name: "vpc", Source: "terraform-aws-modules/vpc/aws", Version: "~> 5.0"},
+				},
+				ResourceCount: 3,
+			},
+			{
+				ID:               "ws-prod",
+				Name:             "prod",
+				Organization:     "acme",
+				TerraformVersion: "1.2.0",
+			},
+		}
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("filter by terraform version", func(t *testing.T) {
+		got, err := svc.ListWorkspaceSummaries(ctx, ListOptions{Organization: "acme", TerraformVersion: "1.2.0"})
+		require.NoError(t, err)
+		require.Equal(t, 1, len(got))
+		assert.Equal(t, "prod", got[0].Name)
+	})
+
+	t.Run("filter by provider", func(t *testing.T) {
+		got, err := svc.ListWorkspaceSummaries(ctx, ListOptions{Organization: "acme", Provider: "hashicorp/null"})
+		require.NoError(t, err)
+		require.Equal(t, 1, len(got))
+		assert.Equal(t, "dev", got[0].Name)
+	})
+
+	t.Run("terraform versions", func(t *testing.T) {
+		got, err := svc.ListTerraformVersions(ctx, ListOptions{Organization: "acme"})
+		require.NoError(t, err)
+
+		want := []*TerraformVersionSummary{
+			{Version: "1.2.0", Workspaces: 1},
+			{Version: "1.5.7", Workspaces: 1},
+		}
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("provider versions", func(t *testing.T) {
+		got, err := svc.ListProviderVersions(ctx, ListOptions{Organization: "acme"})
+		require.NoError(t, err)
+
+		want := []*ProviderVersionSummary{
+			{ID: "registry.terraform.io/hashicorp/aws@4.67.0", Source: "registry.terraform.io/hashicorp/aws", Version: "4.67.0", Workspaces: 1},
+			{ID: "registry.terraform.io/hashicorp/null@3.2.1", Source: "registry.terraform.io/hashicorp/null", Version: "3.2.1", Workspaces: 1},
+		}
+		assert.Equal(t, want, got)
+	})
+}
+
+func TestDrifted(t *testing.T) {
+	changes := run.Report{Additions: 1}
+
+	tests := []struct {
+		name     string
+		status   run.Status
+		planOnly bool
+		report   run.Report
+		want     bool
+	}{
+		{"planned with changes", run.RunPlanned, false, changes, true},
+		{"discarded with changes", run.RunDiscarded, false, changes, true},
+		{"applied", run.RunApplied, false, changes, false},
+		{"no changes", run.RunPlannedAndFinished, false, run.Report{}, false},
+		{"plan-only run", run.RunPlannedAndFinished, true, changes, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, drifted(tt.status, tt.planOnly, tt.report))
+		})
+	}
+}
+
+func TestWeb(t *testing.T) {
+	lockFile, err := os.ReadFile("./testdata/terraform.lock.hcl")
+	require.NoError(t, err)
+
+	h := &webHandlers{
+		Renderer: testutils.NewRenderer(t),
+		svc:      newTestService(t, lockFile),
+	}
+
+	t.Run("list", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/?organization_name=acme", nil)
+		w := httptest.NewRecorder()
+		h.list(w, r)
+		assert.Equal(t, 200, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), "registry.terraform.io/hashicorp/aws 4.67.0")
+	})
+
+	t.Run("export", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/?organization_name=acme", nil)
+		w := httptest.NewRecorder()
+		h.export(w, r)
+		assert.Equal(t, 200, w.Code, w.Body.String())
+		assert.Equal(t, "text/csv", w.Header().Get("Content-Type"))
+
+		want := `workspace_id,workspace_name,terraform_version,latest_run_id,latest_run_status,drifted,providers,modules,resource_count
+ws-dev,dev,1.5.7,run-123,planned,true,registry.terraform.io/hashicorp/aws@4.67.0 registry.terraform.io/hashicorp/null@3.2.1,vpc=terraform-aws-modules/vpc/aws@~> 5.0,3
+ws-prod,prod,1.2.0,,,false,,,0
+`
+		assert.Equal(t, want, w.Body.String())
+	})
+}
+
+func newTestService(t *testing.T, lockFile []byte) *service {
+	return &service{
+		Logger: logr.Discard(),
+		WorkspaceService: &fakeWorkspaceService{
+			// the caller only has access to these workspaces
+			workspaces: []*workspace.Workspace{{ID: "ws-dev"}, {ID: "ws-prod"}},
+		},
+		indexer: &fakeStateIndexer{},
+		db: &fakeDB{
+			rows: []summaryRow{
+				{
+					WorkspaceSummary: &WorkspaceSummary{
+						ID:               "ws-dev",
+						Name:             "dev",
+						Organization:     "acme",
+						TerraformVersion: "1.5.7",
+						LatestRunID:      "run-123",
+						LatestRunStatus:  "planned",
+						Drifted:          true,
+						Modules: []Module{
+							{Name: "vpc", Source: "terraform-aws-modules/vpc/aws", Version: "~> 5.0"},
+						},
+						ResourceCount: 3,
+					},
+					LockFile: lockFile,
+				},
+				{
+					WorkspaceSummary: &WorkspaceSummary{
+						ID:               "ws-prod",
+						Name:             "prod",
+						Organization:     "acme",
+						TerraformVersion: "1.2.0",
+					},
+				},
+				{
+					WorkspaceSummary: &WorkspaceSummary{
+						ID:               "ws-secret",
+						Name:             "secret",
+						Organization:     "acme",
+						TerraformVersion: "1.6.0",
+					},
+				},
+			},
+		},
+	}
+}
+
+type fakeWorkspaceService struct {
+	workspaces []*workspace.Workspace
+
+	workspace.Service
+}
+
+func (f *fakeWorkspaceService) ListWorkspaces(ctx context.Context, opts workspace.ListOptions) (*resource.Page[*workspace.Workspace], error) {
+	return resource.NewPage(f.workspaces, opts.PageOptions, nil), nil
+}
+
+type fakeStateIndexer struct{}
+
+func (f *fakeStateIndexer) IndexResources(context.Context, string) error {
+	return nil
+}
+
+type fakeDB struct {
+	rows []summaryRow
+
+	explorerDB
+}
+
+func (f *fakeDB) listSummaries(ctx context.Context, organization string, workspaceIDs []string) ([]summaryRow, error) {
+	var rows []summaryRow
+	for _, row := range f.rows {
+		if slices.Contains(workspaceIDs, row.ID) {
+			// return a copy to mimic retrieval from a database
+			summary := *row.WorkspaceSummary
+			rows = append(rows, summaryRow{WorkspaceSummary: &summary, LockFile: row.LockFile})
+		}
+	}
+	return rows, nil
+}
@@ -0,0 +1,8 @@
+module "vpc" {
+  source  = "terraform-aws-modules/vpc/aws"
+  version = "~> 5.0"
+}
+
+module "network" {
+  source = "./modules/network"
+}
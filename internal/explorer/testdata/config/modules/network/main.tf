@@ -0,0 +1,4 @@
+module "subnets" {
+  source  = "otf.ninja/acme/subnets/aws"
+  version = "1.2.0"
+}
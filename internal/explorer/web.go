@@ -0,0 +1,67 @@
+package explorer
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal/http/decode"
+	"github.com/leg100/otf/internal/http/html"
+	"github.com/leg100/otf/internal/organization"
+)
+
+type webHandlers struct {
+	html.Renderer
+
+	svc Service
+}
+
+func (h *webHandlers) addHandlers(r *mux.Router) {
+	r = html.UIRouter(r)
+
+	r.HandleFunc("/organizations/{organization_name}/explorer", h.list).Methods("GET")
+	r.HandleFunc("/organizations/{organization_name}/export-explorer", h.export).Methods("GET")
+}
+
+func (h *webHandlers) list(w http.ResponseWriter, r *http.Request) {
+	var opts ListOptions
+	if err := decode.All(&opts, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	summaries, err := h.svc.ListWorkspaceSummaries(r.Context(), opts)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.Render("explorer.tmpl", w, struct {
+		organization.OrganizationPage
+		Filter            ListOptions
+		Summaries         []*WorkspaceSummary
+		TerraformVersions []*TerraformVersionSummary
+		ProviderVersions  []*ProviderVersionSummary
+	}{
+		OrganizationPage:  organization.NewPage(r, "explorer", opts.Organization),
+		Filter:            opts,
+		Summaries:         summaries,
+		TerraformVersions: summarizeTerraformVersions(summaries),
+		ProviderVersions:  summarizeProviderVersions(summaries),
+	})
+}
+
+func (h *webHandlers) export(w http.ResponseWriter, r *http.Request) {
+	var opts ListOptions
+	if err := decode.All(&opts, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	summaries, err := h.svc.ListWorkspaceSummaries(r.Context(), opts)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	respondWithCSV(w, opts.Organization, summaries)
+}
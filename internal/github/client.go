@@ -30,6 +30,8 @@ type (
 
 		// whether authenticated using an installation access token
 		iat bool
+		// ID of the app when authenticated using an installation access token
+		appID int64
 	}
 
 	ClientOptions struct {
@@ -77,7 +79,8 @@ func NewClient(cfg ClientOptions) (*Client, error) {
 		tripper = http.DefaultTransport
 		err     error
 
-		iat bool
+		iat   bool
+		appID int64
 	)
 	if cfg.SkipTLSVerification {
 		tripper = otfhttp.InsecureTransport
@@ -91,6 +94,7 @@ func NewClient(cfg ClientOptions) (*Client, error) {
 	case cfg.InstallCredentials != nil:
 		iat = true
 		creds := cfg.InstallCredentials
+		appID = creds.AppCredentials.ID
 		installTransport, err := ghinstallation.New(tripper, creds.AppCredentials.ID, creds.ID, []byte(creds.AppCredentials.PrivateKey))
 		if err != nil {
 			return nil, err
@@ -124,7 +128,7 @@ func NewClient(cfg ClientOptions) (*Client, error) {
 			return nil, err
 		}
 	}
-	return &Client{client: client, iat: iat}, nil
+	return &Client{client: client, iat: iat, appID: appID}, nil
 }
 
 func NewTokenClient(opts vcs.NewTokenClientOptions) (vcs.Client, error) {
@@ -327,6 +331,8 @@ func (g *Client) CreateWebhook(ctx context.Context, opts vcs.CreateWebhookOption
 			events = append(events, "push")
 		case vcs.EventTypePull:
 			events = append(events, "pull_request")
+		case vcs.EventTypePullComment:
+			events = append(events, "issue_comment")
 		}
 	}
 
@@ -363,6 +369,8 @@ func (g *Client) UpdateWebhook(ctx context.Context, id string, opts vcs.UpdateWe
 			events = append(events, "push")
 		case vcs.EventTypePull:
 			events = append(events, "pull_request")
+		case vcs.EventTypePullComment:
+			events = append(events, "issue_comment")
 		}
 	}
 
@@ -407,6 +415,8 @@ func (g *Client) GetWebhook(ctx context.Context, opts vcs.GetWebhookOptions) (vc
 			events = append(events, vcs.EventTypePush)
 		case "pull_request":
 			events = append(events, vcs.EventTypePull)
+		case "issue_comment":
+			events = append(events, vcs.EventTypePullComment)
 		}
 	}
 
@@ -528,6 +538,113 @@ listloop:
 	return files, nil
 }
 
+func (g *Client) GetPullRequest(ctx context.Context, repo string, pull int) (vcs.PullRequest, error) {
+	owner, name, found := strings.Cut(repo, "/")
+	if !found {
+		return vcs.PullRequest{}, fmt.Errorf("malformed identifier: %s", repo)
+	}
+
+	pr, _, err := g.client.PullRequests.Get(ctx, owner, name, pull)
+	if err != nil {
+		return vcs.PullRequest{}, err
+	}
+	return vcs.PullRequest{
+		Number:    pr.GetNumber(),
+		URL:       pr.GetHTMLURL(),
+		Title:     pr.GetTitle(),
+		Branch:    pr.GetHead().GetRef(),
+		CommitSHA: pr.GetHead().GetSHA(),
+	}, nil
+}
+
+// issueComment is a comment on an issue or pull request, along with the app
+// that made the comment, which go-github does not yet support.
+type issueComment struct {
+	github.IssueComment
+
+	PerformedViaGithubApp *github.App `json:"performed_via_github_app,omitempty"`
+}
+
+func (g *Client) ListPullRequestComments(ctx context.Context, repo string, pull int) ([]vcs.Comment, error) {
+	owner, name, found := strings.Cut(repo, "/")
+	if !found {
+		return nil, fmt.Errorf("malformed identifier: %s", repo)
+	}
+
+	// determine whether a comment was made by the authenticated identity: an
+	// app makes comments as its bot user, so those comments are identified by
+	// the app's ID; otherwise comments are made by the authenticated user.
+	var own func(c *issueComment) bool
+	if g.iat {
+		own = func(c *issueComment) bool {
+			return c.PerformedViaGithubApp.GetID() == g.appID
+		}
+	} else {
+		user, _, err := g.client.Users.Get(ctx, "")
+		if err != nil {
+			return nil, err
+		}
+		own = func(c *issueComment) bool {
+			return c.GetUser().GetID() == user.GetID()
+		}
+	}
+
+	var comments []vcs.Comment
+	for page := 1; page != 0; {
+		// pull requests are issues as far as comments are concerned
+		u := fmt.Sprintf("repos/%s/%s/issues/%d/comments?per_page=100&page=%d", owner, name, pull, page)
+		req, err := g.client.NewRequest("GET", u, nil)
+		if err != nil {
+			return nil, err
+		}
+		var results []*issueComment
+		resp, err := g.client.Do(ctx, req, &results)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range results {
+			comments = append(comments, vcs.Comment{
+				ID:   strconv.FormatInt(c.GetID(), 10),
+				Body: c.GetBody(),
+				Own:  own(c),
+			})
+		}
+		page = resp.NextPage
+	}
+	return comments, nil
+}
+
+func (g *Client) CreatePullRequestComment(ctx context.Context, opts vcs.CreatePullRequestCommentOptions) (string, error) {
+	owner, name, found := strings.Cut(opts.Repo, "/")
+	if !found {
+		return "", fmt.Errorf("malformed identifier: %s", opts.Repo)
+	}
+
+	comment, _, err := g.client.Issues.CreateComment(ctx, owner, name, opts.PullRequestNumber, &github.IssueComment{
+		Body: internal.String(opts.Body),
+	})
+	if err != nil {
+		return "", err
+	}
+	return strconv.FormatInt(comment.GetID(), 10), nil
+}
+
+func (g *Client) UpdatePullRequestComment(ctx context.Context, opts vcs.UpdatePullRequestCommentOptions) error {
+	owner, name, found := strings.Cut(opts.Repo, "/")
+	if !found {
+		return fmt.Errorf("malformed identifier: %s", opts.Repo)
+	}
+	id, err := strconv.ParseInt(opts.ID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid comment ID: %s", opts.ID)
+	}
+
+	_, _, err = g.client.Issues.EditComment(ctx, owner, name, id, &github.IssueComment{
+		Body: internal.String(opts.Body),
+	})
+	return err
+}
+
 func (g *Client) GetCommit(ctx context.Context, repo, ref string) (vcs.Commit, error) {
 	owner, name, found := strings.Cut(repo, "/")
 	if !found {
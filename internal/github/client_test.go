@@ -77,6 +77,52 @@ func TestCreateWebhook(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestGetPullRequest(t *testing.T) {
+	ctx := context.Background()
+
+	client := newTestServerClient(t,
+		WithRepo("acme/terraform"),
+		WithPullRequest("7"),
+		WithCommit("abc123"),
+	)
+
+	got, err := client.GetPullRequest(ctx, "acme/terraform", 7)
+	require.NoError(t, err)
+
+	assert.Equal(t, 7, got.Number)
+	assert.Equal(t, "pr-7", got.Branch)
+	assert.Equal(t, "abc123", got.CommitSHA)
+}
+
+func TestPullRequestComments(t *testing.T) {
+	ctx := context.Background()
+
+	client := newTestServerClient(t,
+		WithUser(internal.String("bot")),
+		WithRepo("acme/terraform"),
+		WithPullRequest("7"),
+	)
+
+	id, err := client.CreatePullRequestComment(ctx, vcs.CreatePullRequestCommentOptions{
+		Repo:              "acme/terraform",
+		PullRequestNumber: 7,
+		Body:              "planned",
+	})
+	require.NoError(t, err)
+
+	err = client.UpdatePullRequestComment(ctx, vcs.UpdatePullRequestCommentOptions{
+		Repo:              "acme/terraform",
+		PullRequestNumber: 7,
+		ID:                id,
+		Body:              "applied",
+	})
+	require.NoError(t, err)
+
+	got, err := client.ListPullRequestComments(ctx, "acme/terraform", 7)
+	require.NoError(t, err)
+	assert.Equal(t, []vcs.Comment{{ID: id, Body: "applied", Own: true}}, got)
+}
+
 // newTestServerClient creates a github server for testing purposes and
 // returns a client configured to access the server.
 func newTestServerClient(t *testing.T, opts ...TestServerOption) *Client {
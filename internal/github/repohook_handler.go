@@ -123,6 +123,28 @@ func handleEventWithError(r *http.Request, secret string) (*vcs.EventPayload, er
 		// commit-url isn't provided in a pull-request event so one is
 		// constructed instead
 		to.CommitURL = event.GetRepo().GetHTMLURL() + "/commit/" + to.CommitSHA
+	case *github.IssueCommentEvent:
+		// ignore comments on issues other than pull requests, and ignore
+		// comments that have been edited or deleted.
+		if !event.GetIssue().IsPullRequest() || event.GetAction() != "created" {
+			return nil, nil
+		}
+		to.Type = vcs.EventTypePullComment
+		to.Action = vcs.ActionCreated
+		to.RepoPath = event.GetRepo().GetFullName()
+		to.DefaultBranch = event.GetRepo().GetDefaultBranch()
+		to.PullRequestNumber = event.GetIssue().GetNumber()
+		to.PullRequestURL = event.GetIssue().GetHTMLURL()
+		to.PullRequestTitle = event.GetIssue().GetTitle()
+		to.Comment = event.GetComment().GetBody()
+
+		to.SenderUsername = event.GetSender().GetLogin()
+		to.SenderAvatarURL = event.GetSender().GetAvatarURL()
+		to.SenderHTMLURL = event.GetSender().GetHTMLURL()
+
+		if install := event.GetInstallation(); install != nil {
+			to.GithubAppInstallID = install.ID
+		}
 	case *github.InstallationEvent:
 		// ignore events other than uninstallation events
 		if event.GetAction() != "deleted" {
@@ -100,6 +100,25 @@ func TestEventHandler(t *testing.T) {
 				SenderHTMLURL:     "https://github.com/leg100",
 			},
 		},
+		{
+			"pull request comment",
+			"issue_comment",
+			"./testdata/github_pull_comment.json",
+			&vcs.EventPayload{
+				VCSKind:           vcs.GithubKind,
+				Type:              vcs.EventTypePullComment,
+				RepoPath:          "leg100/otf-workspaces",
+				DefaultBranch:     "master",
+				PullRequestNumber: 2,
+				PullRequestURL:    "https://github.com/leg100/otf-workspaces/pull/2",
+				PullRequestTitle:  "pr-2",
+				Comment:           "otf apply",
+				Action:            vcs.ActionCreated,
+				SenderUsername:    "leg100",
+				SenderAvatarURL:   "https://avatars.githubusercontent.com/u/75728?v=4",
+				SenderHTMLURL:     "https://github.com/leg100",
+			},
+		},
 		{
 			"tag pushed",
 			"push",
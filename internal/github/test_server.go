@@ -11,6 +11,8 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"path"
+	"strconv"
 	"testing"
 	"time"
 
@@ -30,6 +32,9 @@ const (
 	WebhookDeleted
 )
 
+// testUserID is the ID of the user authenticated with the test server.
+const testUserID = 1
+
 type (
 	TestServer struct {
 		// status updates received from otfd
@@ -57,6 +62,8 @@ type (
 		// pull request stub
 		pullNumber string
 		pullFiles  []string
+		// comments on the pull request stub
+		comments []*github.IssueComment
 
 		// url of server, only populated once server starts
 		url *string
@@ -114,7 +121,7 @@ func NewTestServer(t *testing.T, opts ...TestServerOption) (*TestServer, *url.UR
 	})
 	if srv.username != nil {
 		srv.mux.HandleFunc("/api/v3/user", func(w http.ResponseWriter, r *http.Request) {
-			out, err := json.Marshal(&github.User{Login: srv.username})
+			out, err := json.Marshal(&github.User{ID: internal.Int64(testUserID), Login: srv.username})
 			require.NoError(t, err)
 			w.Header().Add("Content-Type", "application/json")
 			w.Write(out)
@@ -266,6 +273,79 @@ func NewTestServer(t *testing.T, opts ...TestServerOption) (*TestServer, *url.UR
 			w.Header().Add("Content-Type", "application/json")
 			w.Write(out)
 		})
+		if srv.pullNumber != "" {
+			// https://docs.github.com/en/rest/pulls/pulls?apiVersion=2022-11-28#get-a-pull-request
+			srv.mux.HandleFunc("/api/v3/repos/"+*srv.repo+"/pulls/"+srv.pullNumber, func(w http.ResponseWriter, r *http.Request) {
+				number, _ := strconv.Atoi(srv.pullNumber)
+				pull := github.PullRequest{
+					Number:  internal.Int(number),
+					HTMLURL: internal.String(*srv.url + "/" + *srv.repo + "/pull/" + srv.pullNumber),
+					Title:   internal.String("pr-" + srv.pullNumber),
+					Head: &github.PullRequestBranch{
+						Ref: internal.String("pr-" + srv.pullNumber),
+					},
+				}
+				if srv.commit != nil {
+					pull.Head.SHA = srv.commit
+				}
+				out, err := json.Marshal(pull)
+				require.NoError(t, err)
+				w.Header().Add("Content-Type", "application/json")
+				w.Write(out)
+			})
+		}
+		// https://docs.github.com/en/rest/issues/comments?apiVersion=2022-11-28#list-issue-comments
+		// https://docs.github.com/en/rest/issues/comments?apiVersion=2022-11-28#create-an-issue-comment
+		srv.mux.HandleFunc("/api/v3/repos/"+*srv.repo+"/issues/"+srv.pullNumber+"/comments", func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case "GET":
+				out, err := json.Marshal(srv.comments)
+				require.NoError(t, err)
+				w.Header().Add("Content-Type", "application/json")
+				w.Write(out)
+			case "POST":
+				var comment github.IssueComment
+				if err := json.NewDecoder(r.Body).Decode(&comment); err != nil {
+					http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+					return
+				}
+				comment.ID = internal.Int64(int64(len(srv.comments) + 1))
+				comment.User = &github.User{ID: internal.Int64(testUserID), Login: srv.username}
+				srv.comments = append(srv.comments, &comment)
+
+				out, err := json.Marshal(comment)
+				require.NoError(t, err)
+				w.Header().Add("Content-Type", "application/json")
+				w.WriteHeader(http.StatusCreated)
+				w.Write(out)
+			default:
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			}
+		})
+		// https://docs.github.com/en/rest/issues/comments?apiVersion=2022-11-28#update-an-issue-comment
+		srv.mux.HandleFunc("/api/v3/repos/"+*srv.repo+"/issues/comments/", func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != "PATCH" {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			var update github.IssueComment
+			if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+				http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+				return
+			}
+			id := path.Base(r.URL.Path)
+			for _, comment := range srv.comments {
+				if strconv.FormatInt(comment.GetID(), 10) == id {
+					comment.Body = update.Body
+					out, err := json.Marshal(comment)
+					require.NoError(t, err)
+					w.Header().Add("Content-Type", "application/json")
+					w.Write(out)
+					return
+				}
+			}
+			w.WriteHeader(http.StatusNotFound)
+		})
 		if srv.commit != nil {
 			// https://docs.github.com/en/rest/commits/commits?apiVersion=2022-11-28#get-a-commit
 			srv.mux.HandleFunc("/api/v3/repos/"+*srv.repo+"/commits/"+*srv.commit, func(w http.ResponseWriter, r *http.Request) {
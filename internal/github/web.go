@@ -66,7 +66,7 @@ func (h *webHandlers) new(w http.ResponseWriter, r *http.Request) {
 		HookAttrs:   hookAttrs{URL: h.URL(AppEventsPath)},
 		Redirect:    h.URL(paths.ExchangeCodeGithubApp()),
 		Description: "Trigger terraform runs in OTF from GitHub",
-		Events:      []string{"push", "pull_request"},
+		Events:      []string{"push", "pull_request", "issue_comment"},
 		Public:      false,
 		Permissions: map[string]string{
 			"checks":        "write",
@@ -189,6 +189,8 @@ func (g *Client) CreateWebhook(ctx context.Context, opts vcs.CreateWebhookOption
 			addOpts.PushEvents = internal.Bool(true)
 		case vcs.EventTypePull:
 			addOpts.MergeRequestsEvents = internal.Bool(true)
+		case vcs.EventTypePullComment:
+			addOpts.NoteEvents = internal.Bool(true)
 		}
 	}
 
@@ -216,6 +218,8 @@ func (g *Client) UpdateWebhook(ctx context.Context, id string, opts vcs.UpdateWe
 			editOpts.PushEvents = internal.Bool(true)
 		case vcs.EventTypePull:
 			editOpts.MergeRequestsEvents = internal.Bool(true)
+		case vcs.EventTypePullComment:
+			editOpts.NoteEvents = internal.Bool(true)
 		}
 	}
 
@@ -247,6 +251,9 @@ func (g *Client) GetWebhook(ctx context.Context, opts vcs.GetWebhookOptions) (vc
 	if hook.MergeRequestsEvents {
 		events = append(events, vcs.EventTypePull)
 	}
+	if hook.NoteEvents {
+		events = append(events, vcs.EventTypePullComment)
+	}
 
 	return vcs.Webhook{
 		ID:       strconv.Itoa(id),
@@ -274,6 +281,74 @@ func (g *Client) ListPullRequestFiles(ctx context.Context, repo string, pull int
 	return nil, nil
 }
 
+func (g *Client) GetPullRequest(ctx context.Context, repo string, pull int) (vcs.PullRequest, error) {
+	mr, _, err := g.client.MergeRequests.GetMergeRequest(repo, pull, nil, gitlab.WithContext(ctx))
+	if err != nil {
+		return vcs.PullRequest{}, err
+	}
+	return vcs.PullRequest{
+		Number:    mr.IID,
+		URL:       mr.WebURL,
+		Title:     mr.Title,
+		Branch:    mr.SourceBranch,
+		CommitSHA: mr.SHA,
+	}, nil
+}
+
+func (g *Client) ListPullRequestComments(ctx context.Context, repo string, pull int) ([]vcs.Comment, error) {
+	user, _, err := g.client.Users.CurrentUser(gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	var comments []vcs.Comment
+	opts := &gitlab.ListMergeRequestNotesOptions{
+		ListOptions: gitlab.ListOptions{PerPage: 100},
+	}
+	for {
+		notes, resp, err := g.client.Notes.ListMergeRequestNotes(repo, pull, opts, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		for _, n := range notes {
+			if n.System {
+				// skip notes generated by gitlab itself
+				continue
+			}
+			comments = append(comments, vcs.Comment{
+				ID:   strconv.Itoa(n.ID),
+				Body: n.Body,
+				Own:  n.Author.ID == user.ID,
+			})
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return comments, nil
+}
+
+func (g *Client) CreatePullRequestComment(ctx context.Context, opts vcs.CreatePullRequestCommentOptions) (string, error) {
+	note, _, err := g.client.Notes.CreateMergeRequestNote(opts.Repo, opts.PullRequestNumber, &gitlab.CreateMergeRequestNoteOptions{
+		Body: internal.String(opts.Body),
+	}, gitlab.WithContext(ctx))
+	if err != nil {
+		return "", err
+	}
+	return strconv.Itoa(note.ID), nil
+}
+
+func (g *Client) UpdatePullRequestComment(ctx context.Context, opts vcs.UpdatePullRequestCommentOptions) error {
+	id, err := strconv.Atoi(opts.ID)
+	if err != nil {
+		return fmt.Errorf("invalid comment ID: %s", opts.ID)
+	}
+	_, _, err = g.client.Notes.UpdateMergeRequestNote(opts.Repo, opts.PullRequestNumber, id, &gitlab.UpdateMergeRequestNoteOptions{
+		Body: internal.String(opts.Body),
+	}, gitlab.WithContext(ctx))
+	return err
+}
+
 func (g *Client) GetCommit(ctx context.Context, repo, ref string) (vcs.Commit, error) {
 	return vcs.Commit{}, nil
 }
@@ -59,6 +59,27 @@ func handle(r *http.Request, secret string) (*vcs.EventPayload, error) {
 			DefaultBranch: event.Project.DefaultBranch,
 		}, nil
 	case *gitlab.MergeEvent:
+	case *gitlab.MergeCommentEvent:
+		// ignore notes generated by gitlab itself
+		if event.ObjectAttributes.System {
+			return nil, nil
+		}
+		return &vcs.EventPayload{
+			VCSKind:           vcs.GitlabKind,
+			Type:              vcs.EventTypePullComment,
+			Action:            vcs.ActionCreated,
+			RepoPath:          event.Project.PathWithNamespace,
+			Branch:            event.MergeRequest.SourceBranch,
+			CommitSHA:         event.MergeRequest.LastCommit.ID,
+			CommitURL:         event.MergeRequest.LastCommit.URL,
+			DefaultBranch:     event.Project.DefaultBranch,
+			PullRequestNumber: event.MergeRequest.IID,
+			PullRequestURL:    fmt.Sprintf("%s/-/merge_requests/%d", event.Project.WebURL, event.MergeRequest.IID),
+			PullRequestTitle:  event.MergeRequest.Title,
+			Comment:           event.ObjectAttributes.Note,
+			SenderUsername:    event.User.Username,
+			SenderAvatarURL:   event.User.AvatarURL,
+		}, nil
 	}
 
 	return nil, nil
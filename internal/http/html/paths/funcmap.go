@@ -45,6 +45,9 @@ func init() {
 	funcmap["editOrganizationPath"] = EditOrganization
 	funcmap["updateOrganizationPath"] = UpdateOrganization
 	funcmap["deleteOrganizationPath"] = DeleteOrganization
+	funcmap["resourcesOrganizationPath"] = ResourcesOrganization
+	funcmap["explorerOrganizationPath"] = ExplorerOrganization
+	funcmap["exportExplorerOrganizationPath"] = ExportExplorerOrganization
 
 	funcmap["workspacesPath"] = Workspaces
 	funcmap["createWorkspacePath"] = CreateWorkspace
@@ -89,6 +92,18 @@ func init() {
 	funcmap["editVariablePath"] = EditVariable
 	funcmap["updateVariablePath"] = UpdateVariable
 	funcmap["deleteVariablePath"] = DeleteVariable
+	funcmap["importVariablePath"] = ImportVariable
+	funcmap["exportVariablePath"] = ExportVariable
+
+	funcmap["projectsPath"] = Projects
+	funcmap["createProjectPath"] = CreateProject
+	funcmap["newProjectPath"] = NewProject
+	funcmap["projectPath"] = Project
+	funcmap["editProjectPath"] = EditProject
+	funcmap["updateProjectPath"] = UpdateProject
+	funcmap["deleteProjectPath"] = DeleteProject
+	funcmap["setPermissionProjectPath"] = SetPermissionProject
+	funcmap["unsetPermissionProjectPath"] = UnsetPermissionProject
 
 	funcmap["agentTokensPath"] = AgentTokens
 	funcmap["createAgentTokenPath"] = CreateAgentToken
@@ -113,6 +128,8 @@ func init() {
 	funcmap["editVariableSetVariablePath"] = EditVariableSetVariable
 	funcmap["updateVariableSetVariablePath"] = UpdateVariableSetVariable
 	funcmap["deleteVariableSetVariablePath"] = DeleteVariableSetVariable
+	funcmap["importVariableSetVariablePath"] = ImportVariableSetVariable
+	funcmap["exportVariableSetVariablePath"] = ExportVariableSetVariable
 
 	funcmap["organizationTokenPath"] = OrganizationToken
 	funcmap["createOrganizationTokenPath"] = CreateOrganizationToken
@@ -152,6 +169,31 @@ func init() {
 	funcmap["editModulePath"] = EditModule
 	funcmap["updateModulePath"] = UpdateModule
 	funcmap["deleteModulePath"] = DeleteModule
+
+	funcmap["deprecateModuleVersionPath"] = DeprecateModuleVersion
+	funcmap["undeprecateModuleVersionPath"] = UndeprecateModuleVersion
+	funcmap["yankModuleVersionPath"] = YankModuleVersion
+	funcmap["unyankModuleVersionPath"] = UnyankModuleVersion
+
+	funcmap["registryProvidersPath"] = RegistryProviders
+	funcmap["createRegistryProviderPath"] = CreateRegistryProvider
+	funcmap["newRegistryProviderPath"] = NewRegistryProvider
+	funcmap["registryProviderPath"] = RegistryProvider
+	funcmap["editRegistryProviderPath"] = EditRegistryProvider
+	funcmap["updateRegistryProviderPath"] = UpdateRegistryProvider
+	funcmap["deleteRegistryProviderPath"] = DeleteRegistryProvider
+
+	funcmap["newRegistryProviderVersionPath"] = NewRegistryProviderVersion
+	funcmap["createRegistryProviderVersionPath"] = CreateRegistryProviderVersion
+	funcmap["deleteRegistryProviderVersionPath"] = DeleteRegistryProviderVersion
+	funcmap["uploadPlatformRegistryProviderVersionPath"] = UploadPlatformRegistryProviderVersion
+
+	funcmap["deleteRegistryProviderPlatformPath"] = DeleteRegistryProviderPlatform
+
+	funcmap["gpgKeysPath"] = GPGKeys
+	funcmap["newGPGKeyPath"] = NewGPGKey
+	funcmap["createGPGKeyPath"] = CreateGPGKey
+	funcmap["deleteGPGKeyPath"] = DeleteGPGKey
 }
 
 func FuncMap() template.FuncMap { return funcmap }
@@ -162,6 +162,17 @@ var specs = []controllerSpec{
 	{
 		Name:           "organization",
 		controllerType: resourcePath,
+		actions: []action{
+			{
+				name: "resources",
+			},
+			{
+				name: "explorer",
+			},
+			{
+				name: "export-explorer",
+			},
+		},
 		nested: []controllerSpec{
 			{
 				Name:           "workspace",
@@ -238,6 +249,28 @@ var specs = []controllerSpec{
 					{
 						Name:           "variable",
 						controllerType: resourcePath,
+						actions: []action{
+							{
+								name:       "import",
+								collection: true,
+							},
+							{
+								name:       "export",
+								collection: true,
+							},
+						},
+					},
+				},
+			},
+			{
+				Name:           "project",
+				controllerType: resourcePath,
+				actions: []action{
+					{
+						name: "set-permission",
+					},
+					{
+						name: "unset-permission",
 					},
 				},
 			},
@@ -252,6 +285,16 @@ var specs = []controllerSpec{
 					{
 						Name:           "variable_set_variable",
 						controllerType: resourcePath,
+						actions: []action{
+							{
+								name:       "import",
+								collection: true,
+							},
+							{
+								name:       "export",
+								collection: true,
+							},
+						},
 					},
 				},
 			},
@@ -306,6 +349,90 @@ var specs = []controllerSpec{
 			{
 				Name:           "module",
 				controllerType: resourcePath,
+				nested: []controllerSpec{
+					{
+						Name:               "module_version",
+						controllerType:     resourcePath,
+						skipDefaultActions: true,
+						actions: []action{
+							{
+								name: "deprecate",
+							},
+							{
+								name: "undeprecate",
+							},
+							{
+								name: "yank",
+							},
+							{
+								name: "unyank",
+							},
+						},
+					},
+				},
+			},
+			{
+				Name:           "registry_provider",
+				controllerType: resourcePath,
+				nested: []controllerSpec{
+					{
+						Name:               "registry_provider_version",
+						controllerType:     resourcePath,
+						skipDefaultActions: true,
+						actions: []action{
+							{
+								name:       "new",
+								collection: true,
+							},
+							{
+								name:       "create",
+								collection: true,
+							},
+							{
+								name: "delete",
+							},
+							{
+								name: "upload-platform",
+							},
+						},
+						nested: []controllerSpec{
+							{
+								Name:               "registry_provider_platform",
+								controllerType:     resourcePath,
+								skipDefaultActions: true,
+								actions: []action{
+									{
+										name: "delete",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				Name:               "gpg_key",
+				controllerType:     resourcePath,
+				skipDefaultActions: true,
+				camel:              "GPGKey",
+				lowerCamel:         "gpgKey",
+				actions: []action{
+					{
+						name:       "list",
+						collection: true,
+					},
+					{
+						name:       "new",
+						collection: true,
+					},
+					{
+						name:       "create",
+						collection: true,
+					},
+					{
+						name: "delete",
+					},
+				},
 			},
 		},
 	},
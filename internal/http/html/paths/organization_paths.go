@@ -31,3 +31,15 @@ func UpdateOrganization(organization string) string {
 func DeleteOrganization(organization string) string {
 	return fmt.Sprintf("/app/organizations/%s/delete", organization)
 }
+
+func ResourcesOrganization(organization string) string {
+	return fmt.Sprintf("/app/organizations/%s/resources", organization)
+}
+
+func ExplorerOrganization(organization string) string {
+	return fmt.Sprintf("/app/organizations/%s/explorer", organization)
+}
+
+func ExportExplorerOrganization(organization string) string {
+	return fmt.Sprintf("/app/organizations/%s/export-explorer", organization)
+}
@@ -31,3 +31,11 @@ func UpdateVariable(variable string) string {
 func DeleteVariable(variable string) string {
 	return fmt.Sprintf("/app/variables/%s/delete", variable)
 }
+
+func ImportVariable(workspace string) string {
+	return fmt.Sprintf("/app/workspaces/%s/variables/import", workspace)
+}
+
+func ExportVariable(workspace string) string {
+	return fmt.Sprintf("/app/workspaces/%s/variables/export", workspace)
+}
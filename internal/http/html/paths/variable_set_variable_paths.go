@@ -31,3 +31,11 @@ func UpdateVariableSetVariable(variableSetVariable string) string {
 func DeleteVariableSetVariable(variableSetVariable string) string {
 	return fmt.Sprintf("/app/variable-set-variables/%s/delete", variableSetVariable)
 }
+
+func ImportVariableSetVariable(variableSet string) string {
+	return fmt.Sprintf("/app/variable-sets/%s/variable-set-variables/import", variableSet)
+}
+
+func ExportVariableSetVariable(variableSet string) string {
+	return fmt.Sprintf("/app/variable-sets/%s/variable-set-variables/export", variableSet)
+}
@@ -37,6 +37,15 @@ func Absolute(r *http.Request, path string) string {
 	return u.String()
 }
 
+// IsSecure determines whether the request was made over HTTPS, either directly
+// or via a reverse proxy that terminates TLS.
+func IsSecure(r *http.Request) bool {
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto != "" {
+		return proto == "https"
+	}
+	return r.TLS != nil
+}
+
 // ExternalHost uses the incoming HTTP request to determine the host:port on
 // which this server can be reached externally by clients and the internet.
 func ExternalHost(r *http.Request) string {
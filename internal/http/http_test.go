@@ -102,3 +102,26 @@ func TestGetClientIP(t *testing.T) {
 		})
 	}
 }
+
+func TestIsSecure(t *testing.T) {
+	tests := []struct {
+		name      string
+		url       string
+		forwarded string
+		want      bool
+	}{
+		{"http", "http://otf.example.com/", "", false},
+		{"https", "https://otf.example.com/", "", true},
+		{"http behind https proxy", "http://otf.example.com/", "https", true},
+		{"https behind http proxy", "https://otf.example.com/", "http", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", tt.url, nil)
+			if tt.forwarded != "" {
+				r.Header.Add("X-Forwarded-Proto", tt.forwarded)
+			}
+			assert.Equal(t, tt.want, IsSecure(r))
+		})
+	}
+}
@@ -9,25 +9,33 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-// TestBroker demonstrates publishing and subscribing of events via postgres.
+// TestBroker demonstrates publishing and subscribing of events via postgres,
+// using each transport.
 func TestBroker(t *testing.T) {
 	integrationTest(t)
 
-	// simulate a cluster of two otfd nodes sharing a database
-	cfg := config{
-		Config: daemon.Config{Database: sql.NewTestDB(t)},
-		// skip creating orgs which would otherwise send creation events
-		skipDefaultOrganization: true,
-	}
-	local, _, ctx := setup(t, &cfg)
-	remote, _, _ := setup(t, &cfg)
+	for _, transport := range []pubsub.TransportKind{pubsub.NotifyTransport, pubsub.PollTransport} {
+		t.Run(string(transport), func(t *testing.T) {
+			// simulate a cluster of two otfd nodes sharing a database
+			cfg := config{
+				Config: daemon.Config{
+					Database:        sql.NewTestDB(t),
+					PubSubTransport: transport,
+				},
+				// skip creating orgs which would otherwise send creation events
+				skipDefaultOrganization: true,
+			}
+			local, _, ctx := setup(t, &cfg)
+			remote, _, _ := setup(t, &cfg)
 
-	// create an org which should trigger an event
-	org := local.createOrganization(t, ctx)
-	want := pubsub.NewCreatedEvent(org)
+			// create an org which should trigger an event
+			org := local.createOrganization(t, ctx)
+			want := pubsub.NewCreatedEvent(org)
 
-	// receive event on local broker
-	assert.Equal(t, want, <-local.sub)
-	// receive event on remote broker (via postgres)
-	assert.Equal(t, want, <-remote.sub)
+			// receive event on local broker
+			assert.Equal(t, want, <-local.sub)
+			// receive event on remote broker (via postgres)
+			assert.Equal(t, want, <-remote.sub)
+		})
+	}
 }
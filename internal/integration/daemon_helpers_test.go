@@ -21,6 +21,7 @@ import (
 	"github.com/leg100/otf/internal/module"
 	"github.com/leg100/otf/internal/notifications"
 	"github.com/leg100/otf/internal/organization"
+	"github.com/leg100/otf/internal/project"
 	"github.com/leg100/otf/internal/pubsub"
 	"github.com/leg100/otf/internal/releases"
 	"github.com/leg100/otf/internal/run"
@@ -131,8 +132,18 @@ func setup(t *testing.T, cfg *config, gopts ...github.TestServerOption) (*testDa
 		<-done   // don't exit test until daemon is fully terminated
 	})
 
-	sub, err := d.Broker.Subscribe(ctx, "")
+	events, err := d.Broker.Subscribe(ctx, "")
 	require.NoError(t, err)
+	// tests compare events without regard to their position in the event
+	// log, so strip their IDs.
+	sub := make(chan pubsub.Event)
+	go func() {
+		defer close(sub)
+		for event := range events {
+			event.ID = 0
+			sub <- event
+		}
+	}()
 
 	releasesService := releases.NewService(releases.Options{
 		Logger:          logger,
@@ -189,6 +200,20 @@ func (s *testDaemon) createWorkspace(t *testing.T, ctx context.Context, org *org
 	return ws
 }
 
+func (s *testDaemon) createProject(t *testing.T, ctx context.Context, org *organization.Organization) *project.Project {
+	t.Helper()
+
+	if org == nil {
+		org = s.createOrganization(t, ctx)
+	}
+
+	prj, err := s.CreateProject(ctx, org.Name, project.CreateOptions{
+		Name: internal.String("project-" + internal.GenerateRandomString(6)),
+	})
+	require.NoError(t, err)
+	return prj
+}
+
 func (s *testDaemon) getWorkspace(t *testing.T, ctx context.Context, workspaceID string) *workspace.Workspace {
 	t.Helper()
 
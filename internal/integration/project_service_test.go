@@ -0,0 +1,182 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/auth"
+	"github.com/leg100/otf/internal/project"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegration_ProjectService(t *testing.T) {
+	integrationTest(t)
+
+	t.Run("create", func(t *testing.T) {
+		svc, org, ctx := setup(t, nil)
+
+		prj, err := svc.CreateProject(ctx, org.Name, project.CreateOptions{
+			Name: internal.String("networking"),
+		})
+		require.NoError(t, err)
+
+		t.Run("duplicate error", func(t *testing.T) {
+			_, err := svc.CreateProject(ctx, org.Name, project.CreateOptions{
+				Name: internal.String(prj.Name),
+			})
+			require.Equal(t, internal.ErrResourceAlreadyExists, err)
+		})
+	})
+
+	t.Run("update name", func(t *testing.T) {
+		svc, org, ctx := setup(t, nil)
+		prj := svc.createProject(t, ctx, org)
+
+		got, err := svc.UpdateProject(ctx, prj.ID, project.UpdateOptions{
+			Name: internal.String("new-name"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "new-name", got.Name)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		svc, org, ctx := setup(t, nil)
+		prj1 := svc.createProject(t, ctx, org)
+		prj2 := svc.createProject(t, ctx, org)
+
+		got, err := svc.ListProjects(ctx, project.ListOptions{Organization: org.Name})
+		require.NoError(t, err)
+		assert.Equal(t, 2, len(got.Items))
+		assert.Contains(t, got.Items, prj1)
+		assert.Contains(t, got.Items, prj2)
+	})
+
+	t.Run("delete removes workspace from project", func(t *testing.T) {
+		svc, org, ctx := setup(t, nil)
+		prj := svc.createProject(t, ctx, org)
+		ws, err := svc.CreateWorkspace(ctx, workspace.CreateOptions{
+			Name:         internal.String("dev"),
+			Organization: &org.Name,
+			ProjectID:    &prj.ID,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, prj.ID, ws.ProjectID)
+
+		err = svc.DeleteProject(ctx, prj.ID)
+		require.NoError(t, err)
+
+		got := svc.getWorkspace(t, ctx, ws.ID)
+		assert.Equal(t, "", got.ProjectID)
+	})
+
+	t.Run("list workspaces by project", func(t *testing.T) {
+		svc, org, ctx := setup(t, nil)
+		prj := svc.createProject(t, ctx, org)
+		ws, err := svc.CreateWorkspace(ctx, workspace.CreateOptions{
+			Name:         internal.String("dev"),
+			Organization: &org.Name,
+			ProjectID:    &prj.ID,
+		})
+		require.NoError(t, err)
+		_ = svc.createWorkspace(t, ctx, org)
+
+		got, err := svc.ListWorkspaces(ctx, workspace.ListOptions{
+			Organization: &org.Name,
+			ProjectID:    &prj.ID,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []*workspace.Workspace{ws}, got.Items)
+	})
+
+	t.Run("reject project from another organization", func(t *testing.T) {
+		svc, org, ctx := setup(t, nil)
+		otherOrg := svc.createOrganization(t, ctx)
+		prj := svc.createProject(t, ctx, otherOrg)
+
+		_, err := svc.CreateWorkspace(ctx, workspace.CreateOptions{
+			Name:         internal.String("dev"),
+			Organization: &org.Name,
+			ProjectID:    &prj.ID,
+		})
+		assert.Equal(t, project.ErrOrganizationMismatch, err)
+
+		ws := svc.createWorkspace(t, ctx, org)
+		_, err = svc.UpdateWorkspace(ctx, ws.ID, workspace.UpdateOptions{
+			ProjectID: &prj.ID,
+		})
+		assert.Equal(t, project.ErrOrganizationMismatch, err)
+	})
+
+	t.Run("list workspaces by project without organization permissions", func(t *testing.T) {
+		svc, org, ctx := setup(t, nil)
+		prj := svc.createProject(t, ctx, org)
+		team := svc.createTeam(t, ctx, org)
+		ws, err := svc.CreateWorkspace(ctx, workspace.CreateOptions{
+			Name:         internal.String("dev"),
+			Organization: &org.Name,
+			ProjectID:    &prj.ID,
+		})
+		require.NoError(t, err)
+		other := svc.createWorkspace(t, ctx, org)
+		err = svc.SetPermission(ctx, other.ID, team.Name, rbac.WorkspaceReadRole)
+		require.NoError(t, err)
+		err = svc.SetProjectPermission(ctx, prj.ID, team.Name, rbac.WorkspaceReadRole)
+		require.NoError(t, err)
+		_, userCtx := svc.createUserCtx(t, auth.WithTeams(team))
+
+		got, err := svc.ListWorkspaces(userCtx, workspace.ListOptions{
+			Organization: &org.Name,
+			ProjectID:    &prj.ID,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, len(got.Items))
+		assert.Equal(t, ws.ID, got.Items[0].ID)
+	})
+
+	t.Run("project permissions cascade to workspaces", func(t *testing.T) {
+		svc, org, ctx := setup(t, nil)
+		prj := svc.createProject(t, ctx, org)
+		team := svc.createTeam(t, ctx, org)
+		ws, err := svc.CreateWorkspace(ctx, workspace.CreateOptions{
+			Name:         internal.String("dev"),
+			Organization: &org.Name,
+			ProjectID:    &prj.ID,
+		})
+		require.NoError(t, err)
+
+		err = svc.SetProjectPermission(ctx, prj.ID, team.Name, rbac.WorkspaceWriteRole)
+		require.NoError(t, err)
+
+		policy, err := svc.GetPolicy(ctx, ws.ID)
+		require.NoError(t, err)
+		assert.Empty(t, policy.Permissions)
+		assert.Equal(t, []internal.WorkspacePermission{
+			{Team: team.Name, TeamID: team.ID, Role: rbac.WorkspaceWriteRole},
+		}, policy.ProjectPermissions)
+
+		err = svc.UnsetProjectPermission(ctx, prj.ID, team.Name)
+		require.NoError(t, err)
+
+		policy, err = svc.GetPolicy(ctx, ws.ID)
+		require.NoError(t, err)
+		assert.Empty(t, policy.ProjectPermissions)
+	})
+
+	t.Run("custom role not supported", func(t *testing.T) {
+		svc, org, ctx := setup(t, nil)
+		prj := svc.createProject(t, ctx, org)
+		team := svc.createTeam(t, ctx, org)
+		role, err := rbac.NewCustomRole(rbac.CustomPermissions{
+			Runs:          rbac.RunsRead,
+			Variables:     rbac.VariablesNone,
+			StateVersions: rbac.StateVersionsNone,
+		})
+		require.NoError(t, err)
+
+		err = svc.SetProjectPermission(ctx, prj.ID, team.Name, role)
+		assert.Equal(t, project.ErrCustomRoleNotSupported, err)
+	})
+}
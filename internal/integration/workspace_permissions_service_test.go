@@ -71,6 +71,26 @@ func TestIntegration_WorkspacePermissionsService(t *testing.T) {
 		})
 	})
 
+	t.Run("set custom permission", func(t *testing.T) {
+		ws := svc.createWorkspace(t, ctx, org)
+		team := svc.createTeam(t, ctx, org)
+		role, err := rbac.NewCustomRole(rbac.CustomPermissions{
+			Runs:             rbac.RunsPlan,
+			Variables:        rbac.VariablesRead,
+			StateVersions:    rbac.StateVersionsReadOutputs,
+			WorkspaceLocking: true,
+		})
+		require.NoError(t, err)
+		err = svc.SetPermission(ctx, ws.ID, team.Name, role)
+		require.NoError(t, err)
+
+		got, err := svc.GetPolicy(ctx, ws.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []internal.WorkspacePermission{
+			{Team: team.Name, TeamID: team.ID, Role: role},
+		}, got.Permissions)
+	})
+
 	t.Run("workspace not found", func(t *testing.T) {
 		_, err := svc.GetPolicy(ctx, "non-existent")
 		require.True(t, errors.Is(err, internal.ErrResourceNotFound))
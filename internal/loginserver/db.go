@@ -0,0 +1,109 @@
+package loginserver
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgtype"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/sql/pggen"
+)
+
+type (
+	// pgdb stores device authorizations in a postgres database
+	pgdb struct {
+		*sql.DB // provides access to generated SQL queries
+	}
+
+	deviceAuthorizationRow struct {
+		DeviceCodeHash pgtype.Text        `json:"device_code_hash"`
+		UserCode       pgtype.Text        `json:"user_code"`
+		ClientID       pgtype.Text        `json:"client_id"`
+		CreatedAt      pgtype.Timestamptz `json:"created_at"`
+		Expiry         pgtype.Timestamptz `json:"expiry"`
+		Status         pgtype.Text        `json:"status"`
+		Username       pgtype.Text        `json:"username"`
+		LastPolledAt   pgtype.Timestamptz `json:"last_polled_at"`
+	}
+)
+
+func (db *pgdb) createDeviceAuthorization(ctx context.Context, da *deviceAuthorization) error {
+	return db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		// opportunistically purge expired device authorizations
+		if _, err := q.DeleteExpiredDeviceAuthorizations(ctx, sql.Timestamptz(internal.CurrentTimestamp(nil))); err != nil {
+			return sql.Error(err)
+		}
+		_, err := q.InsertDeviceAuthorization(ctx, pggen.InsertDeviceAuthorizationParams{
+			DeviceCodeHash: sql.String(da.DeviceCodeHash),
+			UserCode:       sql.String(da.UserCode),
+			ClientID:       sql.String(da.ClientID),
+			CreatedAt:      sql.Timestamptz(da.CreatedAt),
+			Expiry:         sql.Timestamptz(da.Expiry),
+			Status:         sql.String(string(da.Status)),
+		})
+		return sql.Error(err)
+	})
+}
+
+func (db *pgdb) getDeviceAuthorizationByUserCode(ctx context.Context, userCode string) (*deviceAuthorization, error) {
+	row, err := db.Conn(ctx).FindDeviceAuthorizationByUserCode(ctx, sql.String(userCode))
+	if err != nil {
+		return nil, deviceError(err)
+	}
+	return deviceAuthorizationRow(row).toDeviceAuthorization(), nil
+}
+
+func (db *pgdb) updateDeviceAuthorizationStatus(ctx context.Context, userCode string, status deviceStatus, username string) error {
+	_, err := db.Conn(ctx).UpdateDeviceAuthorizationStatus(ctx, pggen.UpdateDeviceAuthorizationStatusParams{
+		UserCode: sql.String(userCode),
+		Status:   sql.String(string(status)),
+		Username: sql.String(username),
+	})
+	return deviceError(err)
+}
+
+func (db *pgdb) pollDeviceAuthorization(ctx context.Context, deviceCodeHash string, fn func(*deviceAuthorization) (bool, error)) error {
+	return db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		row, err := q.FindDeviceAuthorizationForUpdate(ctx, sql.String(deviceCodeHash))
+		if err != nil {
+			return deviceError(err)
+		}
+		da := deviceAuthorizationRow(row).toDeviceAuthorization()
+		remove, err := fn(da)
+		if err != nil {
+			return err
+		}
+		if remove {
+			_, err = q.DeleteDeviceAuthorization(ctx, sql.String(deviceCodeHash))
+		} else {
+			_, err = q.UpdateDeviceAuthorizationLastPolled(ctx, sql.TimestamptzPtr(da.LastPolledAt), sql.String(deviceCodeHash))
+		}
+		return sql.Error(err)
+	})
+}
+
+func (row deviceAuthorizationRow) toDeviceAuthorization() *deviceAuthorization {
+	da := &deviceAuthorization{
+		DeviceCodeHash: row.DeviceCodeHash.String,
+		UserCode:       row.UserCode.String,
+		ClientID:       row.ClientID.String,
+		CreatedAt:      row.CreatedAt.Time.UTC(),
+		Expiry:         row.Expiry.Time.UTC(),
+		Status:         deviceStatus(row.Status.String),
+		Username:       row.Username.String,
+	}
+	if row.LastPolledAt.Status == pgtype.Present {
+		da.LastPolledAt = internal.Time(row.LastPolledAt.Time.UTC())
+	}
+	return da
+}
+
+// deviceError maps a not found error to errDeviceNotFound
+func deviceError(err error) error {
+	err = sql.Error(err)
+	if errors.Is(err, internal.ErrResourceNotFound) {
+		return errDeviceNotFound
+	}
+	return err
+}
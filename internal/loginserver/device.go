@@ -0,0 +1,350 @@
+package loginserver
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/auth"
+	otfhttp "github.com/leg100/otf/internal/http"
+	"github.com/leg100/otf/internal/http/decode"
+	"github.com/leg100/otf/internal/http/html"
+	"github.com/leg100/otf/internal/tokens"
+)
+
+// Implementation of the OAuth2 device authorization grant:
+//
+// https://datatracker.ietf.org/doc/html/rfc8628
+
+const (
+	// DeviceCodeGrantType is the grant type a client specifies when polling
+	// the token endpoint with a device code.
+	DeviceCodeGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+	// deviceExpiry is how long a device authorization is valid for before
+	// the user must approve it.
+	deviceExpiry = 10 * time.Minute
+	// devicePollInterval is the minimum interval a client must wait between
+	// polling the token endpoint.
+	devicePollInterval = 5 * time.Second
+
+	// user codes are composed of these characters, which excludes vowels to
+	// avoid spelling words, and are formatted as XXXX-XXXX.
+	userCodeCharset = "BCDFGHJKLMNPQRSTVWXZ"
+	userCodeLength  = 8
+)
+
+const (
+	devicePending  deviceStatus = "pending"
+	deviceApproved deviceStatus = "approved"
+	deviceDenied   deviceStatus = "denied"
+)
+
+var errDeviceNotFound = errors.New("device authorization not found")
+
+type (
+	deviceStatus string
+
+	// deviceAuthorization is a request by a client on a device to be
+	// authorized by a user.
+	deviceAuthorization struct {
+		// SHA256 hash of the device code; the device code itself is only known
+		// to the client.
+		DeviceCodeHash string
+		UserCode       string
+		ClientID       string
+		CreatedAt      time.Time
+		Expiry         time.Time
+		Status         deviceStatus
+		// Username of the user that approved or denied the authorization
+		Username string
+		// Last time the client polled the token endpoint
+		LastPolledAt *time.Time
+	}
+
+	// deviceAuthorizationResponse is the response to a device authorization
+	// request.
+	deviceAuthorizationResponse struct {
+		DeviceCode              string `json:"device_code"`
+		UserCode                string `json:"user_code"`
+		VerificationURI         string `json:"verification_uri"`
+		VerificationURIComplete string `json:"verification_uri_complete"`
+		ExpiresIn               int    `json:"expires_in"`
+		Interval                int    `json:"interval"`
+	}
+
+	// deviceStore persists device authorizations
+	deviceStore interface {
+		createDeviceAuthorization(ctx context.Context, da *deviceAuthorization) error
+		getDeviceAuthorizationByUserCode(ctx context.Context, userCode string) (*deviceAuthorization, error)
+		// updateDeviceAuthorizationStatus approves or denies a pending
+		// device authorization.
+		updateDeviceAuthorizationStatus(ctx context.Context, userCode string, status deviceStatus, username string) error
+		// pollDeviceAuthorization retrieves a device authorization and
+		// passes it to fn, persisting any changes fn makes to the time it was
+		// last polled, and deleting it if fn returns true.
+		pollDeviceAuthorization(ctx context.Context, deviceCodeHash string, fn func(*deviceAuthorization) (bool, error)) error
+	}
+)
+
+// deviceAuthorizationHandler handles a device authorization request from a
+// client, responding with a device code for the client and a user code for the
+// user to enter in their browser.
+func (s *server) deviceAuthorizationHandler(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		ClientID string `schema:"client_id"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		tokenError(w, ErrInvalidRequest, err.Error())
+		return
+	}
+	if !isValidClient(params.ClientID) {
+		tokenError(w, ErrInvalidClient, "")
+		return
+	}
+
+	deviceCode, err := generateDeviceCode()
+	if err != nil {
+		tokenError(w, ErrServerError, err.Error())
+		return
+	}
+	userCode, err := generateUserCode()
+	if err != nil {
+		tokenError(w, ErrServerError, err.Error())
+		return
+	}
+	now := internal.CurrentTimestamp(nil)
+	err = s.devices.createDeviceAuthorization(r.Context(), &deviceAuthorization{
+		DeviceCodeHash: hashDeviceCode(deviceCode),
+		UserCode:       userCode,
+		ClientID:       params.ClientID,
+		CreatedAt:      now,
+		Expiry:         now.Add(deviceExpiry),
+		Status:         devicePending,
+	})
+	if err != nil {
+		tokenError(w, ErrServerError, err.Error())
+		return
+	}
+
+	verificationURI := otfhttp.Absolute(r, DeviceRoute)
+	writeJSON(w, http.StatusOK, &deviceAuthorizationResponse{
+		DeviceCode:              deviceCode,
+		UserCode:                userCode,
+		VerificationURI:         verificationURI,
+		VerificationURIComplete: verificationURI + "?user_code=" + userCode,
+		ExpiresIn:               int(deviceExpiry.Seconds()),
+		Interval:                int(devicePollInterval.Seconds()),
+	})
+}
+
+// deviceHandler renders a page for the user to enter a user code, and
+// processes the user's approval or denial of the device authorization.
+func (s *server) deviceHandler(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		UserCode  string `schema:"user_code"`
+		Consented *bool  `schema:"consented"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	params.UserCode = normalizeUserCode(params.UserCode)
+
+	render := func() {
+		s.Render("device.tmpl", w, struct {
+			html.SitePage
+			UserCode string
+		}{
+			SitePage: html.NewSitePage(r, "authorize device"),
+			UserCode: formatUserCode(params.UserCode),
+		})
+	}
+	if r.Method == "GET" || params.Consented == nil {
+		render()
+		return
+	}
+
+	da, err := s.devices.getDeviceAuthorizationByUserCode(r.Context(), params.UserCode)
+	if errors.Is(err, errDeviceNotFound) || (err == nil && (da.Status != devicePending || da.expired())) {
+		html.FlashError(w, "invalid or expired code")
+		http.Redirect(w, r, DeviceRoute, http.StatusFound)
+		return
+	} else if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	user, err := auth.UserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	status := deviceDenied
+	if *params.Consented {
+		status = deviceApproved
+	}
+	err = s.devices.updateDeviceAuthorizationStatus(r.Context(), params.UserCode, status, user.Username)
+	if errors.Is(err, errDeviceNotFound) {
+		// authorization has since been approved or denied
+		html.FlashError(w, "invalid or expired code")
+		http.Redirect(w, r, DeviceRoute, http.StatusFound)
+		return
+	} else if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if status == deviceApproved {
+		html.FlashSuccess(w, "Device authorized. You can now return to your device.")
+	} else {
+		html.FlashSuccess(w, "Device denied access.")
+	}
+	http.Redirect(w, r, DeviceRoute, http.StatusFound)
+}
+
+// deviceTokenHandler handles a client polling the token endpoint with a
+// device code, responding with an API token once the user has approved the
+// device authorization.
+func (s *server) deviceTokenHandler(w http.ResponseWriter, r *http.Request, clientID, deviceCode string) {
+	if !isValidClient(clientID) {
+		tokenError(w, ErrInvalidClient, "")
+		return
+	}
+	if deviceCode == "" {
+		tokenError(w, ErrInvalidRequest, "missing device code")
+		return
+	}
+
+	var (
+		errCode  string
+		username string
+	)
+	err := s.devices.pollDeviceAuthorization(r.Context(), hashDeviceCode(deviceCode), func(da *deviceAuthorization) (bool, error) {
+		now := internal.CurrentTimestamp(nil)
+		lastPolledAt := da.LastPolledAt
+		da.LastPolledAt = &now
+
+		switch {
+		case da.ClientID != clientID:
+			errCode = ErrInvalidGrant
+			return false, nil
+		case da.expired():
+			errCode = ErrExpiredToken
+			return true, nil
+		case da.Status == deviceDenied:
+			errCode = ErrAccessDenied
+			return true, nil
+		case da.Status == deviceApproved:
+			username = da.Username
+			return true, nil
+		case lastPolledAt != nil && now.Sub(*lastPolledAt) < devicePollInterval:
+			errCode = ErrSlowDown
+			return false, nil
+		default:
+			errCode = ErrAuthorizationPending
+			return false, nil
+		}
+	})
+	if errors.Is(err, errDeviceNotFound) {
+		tokenError(w, ErrInvalidGrant, "unknown device code")
+		return
+	} else if err != nil {
+		tokenError(w, ErrServerError, err.Error())
+		return
+	}
+	if errCode != "" {
+		tokenError(w, errCode, "")
+		return
+	}
+
+	// Create API token for user and include in response
+	userCtx := internal.AddSubjectToContext(r.Context(), &auth.User{Username: username})
+	_, token, err := s.CreateUserToken(userCtx, tokens.CreateUserTokenOptions{
+		Description: clientID + " login",
+	})
+	if err != nil {
+		tokenError(w, ErrServerError, err.Error())
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, struct {
+		AccessToken string `json:"access_token"`
+		TokenType   string `json:"token_type"`
+	}{
+		AccessToken: string(token),
+		TokenType:   "bearer",
+	})
+}
+
+func (da *deviceAuthorization) expired() bool {
+	return time.Now().After(da.Expiry)
+}
+
+func isValidClient(clientID string) bool {
+	return clientID == ClientID || clientID == CLIClientID
+}
+
+// generateDeviceCode generates a random device code.
+func generateDeviceCode() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hashDeviceCode(deviceCode string) string {
+	hash := sha256.Sum256([]byte(deviceCode))
+	return hex.EncodeToString(hash[:])
+}
+
+// generateUserCode generates a random user code, formatted as XXXX-XXXX.
+func generateUserCode() (string, error) {
+	code := make([]byte, userCodeLength)
+	max := big.NewInt(int64(len(userCodeCharset)))
+	for i := range code {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		code[i] = userCodeCharset[n.Int64()]
+	}
+	return formatUserCode(string(code)), nil
+}
+
+// normalizeUserCode normalizes a user code entered by a user, ignoring case
+// and any hyphens or whitespace, and formats it as XXXX-XXXX.
+func normalizeUserCode(code string) string {
+	code = strings.ToUpper(code)
+	code = strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, code)
+	return formatUserCode(code)
+}
+
+func formatUserCode(code string) string {
+	if len(code) != userCodeLength {
+		return code
+	}
+	return code[:userCodeLength/2] + "-" + code[userCodeLength/2:]
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
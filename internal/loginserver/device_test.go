@@ -0,0 +1,100 @@
+package loginserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/auth"
+	"github.com/leg100/otf/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeviceAuthorizationGrant(t *testing.T) {
+	srv := fakeServer(t, testutils.NewSecret(t))
+
+	// client requests device authorization
+	w := httptest.NewRecorder()
+	srv.deviceAuthorizationHandler(w, newFormRequest(t, "/oauth2/device_authorization", url.Values{
+		"client_id": {CLIClientID},
+	}))
+	require.Equal(t, 200, w.Code, w.Body.String())
+	var da deviceAuthorizationResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &da))
+	assert.Regexp(t, `^[A-Z]{4}-[A-Z]{4}$`, da.UserCode)
+	assert.Equal(t, "http://example.com/app/oauth2/device", da.VerificationURI)
+
+	poll := func(t *testing.T) (int, map[string]string) {
+		w := httptest.NewRecorder()
+		srv.tokenHandler(w, newFormRequest(t, "/oauth2/token", url.Values{
+			"client_id":   {CLIClientID},
+			"grant_type":  {DeviceCodeGrantType},
+			"device_code": {da.DeviceCode},
+		}))
+		var resp map[string]string
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		return w.Code, resp
+	}
+
+	// client polls before user has approved authorization
+	code, resp := poll(t)
+	assert.Equal(t, 400, code)
+	assert.Equal(t, ErrAuthorizationPending, resp["error"])
+
+	// client polls too quickly
+	code, resp = poll(t)
+	assert.Equal(t, 400, code)
+	assert.Equal(t, ErrSlowDown, resp["error"])
+
+	// user enters code, in lower case and without the hyphen, and approves
+	r := newFormRequest(t, "/app/oauth2/device", url.Values{
+		"user_code": {strings.ToLower(strings.ReplaceAll(da.UserCode, "-", ""))},
+		"consented": {"true"},
+	})
+	r = r.WithContext(internal.AddSubjectToContext(r.Context(), &auth.User{Username: "bobby"}))
+	w = httptest.NewRecorder()
+	srv.deviceHandler(w, r)
+	require.Equal(t, 302, w.Code, w.Body.String())
+
+	// client polls after user has approved authorization, having waited
+	// the requisite interval
+	for _, da := range srv.devices.(*fakeDeviceStore).authorizations {
+		da.LastPolledAt = internal.Time(time.Now().Add(-devicePollInterval))
+	}
+	code, resp = poll(t)
+	assert.Equal(t, 200, code, resp)
+	assert.Equal(t, "bearer", resp["token_type"])
+
+	// device code cannot be used again
+	code, resp = poll(t)
+	assert.Equal(t, 400, code)
+	assert.Equal(t, ErrInvalidGrant, resp["error"])
+}
+
+func TestDeviceAuthorizationGrant_InvalidClient(t *testing.T) {
+	srv := fakeServer(t, testutils.NewSecret(t))
+
+	w := httptest.NewRecorder()
+	srv.deviceAuthorizationHandler(w, newFormRequest(t, "/oauth2/device_authorization", url.Values{
+		"client_id": {"unknown"},
+	}))
+	assert.Equal(t, 401, w.Code)
+}
+
+func TestNormalizeUserCode(t *testing.T) {
+	assert.Equal(t, "BCDF-GHJK", normalizeUserCode("bcdf ghjk"))
+	assert.Equal(t, "BCDF-GHJK", normalizeUserCode("BCDF-GHJK"))
+	assert.Equal(t, "", normalizeUserCode(""))
+}
+
+func newFormRequest(t *testing.T, path string, form url.Values) *http.Request {
+	r := httptest.NewRequest("POST", path, strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return r
+}
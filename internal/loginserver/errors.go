@@ -1,6 +1,7 @@
 package loginserver
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/url"
 )
@@ -16,6 +17,13 @@ const (
 	ErrServerError             string = "server_error"
 )
 
+// https://datatracker.ietf.org/doc/html/rfc8628#section-3.5
+const (
+	ErrAuthorizationPending string = "authorization_pending"
+	ErrSlowDown             string = "slow_down"
+	ErrExpiredToken         string = "expired_token"
+)
+
 type redirectError struct {
 	redirect *url.URL
 	state    string
@@ -34,3 +42,27 @@ func (e redirectError) error(w http.ResponseWriter, r *http.Request, error, desc
 
 	http.Redirect(w, r, e.redirect.String(), http.StatusFound)
 }
+
+// tokenError responds to a client with an error from the token endpoint as per
+// RFC6749.
+//
+// https://datatracker.ietf.org/doc/html/rfc6749#section-5.2
+func tokenError(w http.ResponseWriter, error, description string) {
+	status := http.StatusBadRequest
+	switch error {
+	case ErrInvalidClient:
+		status = http.StatusUnauthorized
+	case ErrServerError:
+		status = http.StatusInternalServerError
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(struct {
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description,omitempty"`
+	}{
+		Error:            error,
+		ErrorDescription: description,
+	})
+}
@@ -19,13 +19,59 @@ func fakeServer(t *testing.T, secret []byte) *server {
 		TokensService: &fakeTokenService{},
 	})
 	require.NoError(t, err)
+	srv.devices = &fakeDeviceStore{authorizations: make(map[string]*deviceAuthorization)}
 	return srv
 }
 
-type fakeTokenService struct {
-	tokens.TokensService
-}
+type (
+	fakeTokenService struct {
+		tokens.TokensService
+	}
+
+	fakeDeviceStore struct {
+		authorizations map[string]*deviceAuthorization // keyed by device code hash
+	}
+)
 
 func (a *fakeTokenService) CreateUserToken(ctx context.Context, opts tokens.CreateUserTokenOptions) (*tokens.UserToken, []byte, error) {
 	return nil, nil, nil
 }
+
+func (f *fakeDeviceStore) createDeviceAuthorization(ctx context.Context, da *deviceAuthorization) error {
+	f.authorizations[da.DeviceCodeHash] = da
+	return nil
+}
+
+func (f *fakeDeviceStore) getDeviceAuthorizationByUserCode(ctx context.Context, userCode string) (*deviceAuthorization, error) {
+	for _, da := range f.authorizations {
+		if da.UserCode == userCode {
+			return da, nil
+		}
+	}
+	return nil, errDeviceNotFound
+}
+
+func (f *fakeDeviceStore) updateDeviceAuthorizationStatus(ctx context.Context, userCode string, status deviceStatus, username string) error {
+	da, err := f.getDeviceAuthorizationByUserCode(ctx, userCode)
+	if err != nil {
+		return err
+	}
+	da.Status = status
+	da.Username = username
+	return nil
+}
+
+func (f *fakeDeviceStore) pollDeviceAuthorization(ctx context.Context, deviceCodeHash string, fn func(*deviceAuthorization) (bool, error)) error {
+	da, ok := f.authorizations[deviceCodeHash]
+	if !ok {
+		return errDeviceNotFound
+	}
+	remove, err := fn(da)
+	if err != nil {
+		return err
+	}
+	if remove {
+		delete(f.authorizations, deviceCodeHash)
+	}
+	return nil
+}
@@ -1,11 +1,15 @@
 // Package loginserver implements a "terraform login protocol" server:
 //
 // https://developer.hashicorp.com/terraform/internals/v1.3.x/login-protocol#client
+//
+// It also implements the OAuth2 device authorization grant, for clients such
+// as the otf CLI running on hosts without a browser.
 package loginserver
 
 import (
 	"github.com/gorilla/mux"
 	"github.com/leg100/otf/internal/http/html"
+	"github.com/leg100/otf/internal/sql"
 	"github.com/leg100/otf/internal/tokens"
 )
 
@@ -13,9 +17,13 @@ const (
 	// OAuth2 client ID - purely advisory according to:
 	// https://developer.hashicorp.com/terraform/internals/v1.3.x/login-protocol#client
 	ClientID = "terraform"
+	// OAuth2 client ID of the otf CLI
+	CLIClientID = "otf"
 
-	AuthRoute  = "/app/oauth2/auth"
-	TokenRoute = "/oauth2/token"
+	AuthRoute                = "/app/oauth2/auth"
+	TokenRoute               = "/oauth2/token"
+	DeviceAuthorizationRoute = "/oauth2/device_authorization"
+	DeviceRoute              = "/app/oauth2/device"
 )
 
 var Discovery = DiscoverySpec{
@@ -28,7 +36,8 @@ var Discovery = DiscoverySpec{
 
 type (
 	server struct {
-		secret []byte // for encrypting auth code
+		secret  []byte      // for encrypting auth code
+		devices deviceStore // for persisting device authorizations
 
 		html.Renderer        // render consent UI
 		tokens.TokensService // for creating user API token
@@ -38,6 +47,7 @@ type (
 	Options struct {
 		Secret []byte // for encrypting auth code
 
+		*sql.DB
 		html.Renderer
 		tokens.TokensService
 	}
@@ -60,6 +70,7 @@ type (
 func NewServer(opts Options) (*server, error) {
 	return &server{
 		secret:        opts.Secret,
+		devices:       &pgdb{opts.DB},
 		Renderer:      opts.Renderer,
 		TokensService: opts.TokensService,
 	}, nil
@@ -68,6 +79,8 @@ func NewServer(opts Options) (*server, error) {
 func (s *server) AddHandlers(r *mux.Router) {
 	// authenticated
 	r.HandleFunc(AuthRoute, s.authHandler).Methods("GET", "POST")
+	r.HandleFunc(DeviceRoute, s.deviceHandler).Methods("GET", "POST")
 	// unauthenticated
 	r.HandleFunc(TokenRoute, s.tokenHandler).Methods("POST")
+	r.HandleFunc(DeviceAuthorizationRoute, s.deviceAuthorizationHandler).Methods("POST")
 }
@@ -18,6 +18,7 @@ func (s *server) tokenHandler(w http.ResponseWriter, r *http.Request) {
 		ClientID     string `schema:"client_id"`
 		Code         string `schema:"code"`
 		CodeVerifier string `schema:"code_verifier"`
+		DeviceCode   string `schema:"device_code"`
 		GrantType    string `schema:"grant_type"`
 		RedirectURI  string `schema:"redirect_uri"`
 	}
@@ -26,6 +27,11 @@ func (s *server) tokenHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if params.GrantType == DeviceCodeGrantType {
+		s.deviceTokenHandler(w, r, params.ClientID, params.DeviceCode)
+		return
+	}
+
 	redirect, err := url.Parse(params.RedirectURI)
 	if err != nil {
 		http.Error(w, "invalid redirect_uri", http.StatusBadRequest)
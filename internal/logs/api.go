@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	otfapi "github.com/leg100/otf/internal/api"
+	"github.com/leg100/otf/internal/tfeapi"
 
 	"github.com/gorilla/mux"
 	"github.com/leg100/otf/internal"
@@ -27,6 +28,7 @@ func (a *api) addHandlers(r *mux.Router) {
 	// client is typically an external agent
 	r = r.PathPrefix(otfapi.DefaultBasePath).Subrouter()
 	r.HandleFunc("/runs/{run_id}/logs/{phase}", a.putLogs).Methods("PUT")
+	r.HandleFunc("/runs/{run_id}/logs/{phase}/tail", a.tailLogs).Methods("GET")
 }
 
 func (a *api) getLogs(w http.ResponseWriter, r *http.Request) {
@@ -63,3 +65,39 @@ func (a *api) putLogs(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+// tailLogs streams the logs for a phase until the end of the logs is reached,
+// or the client disconnects.
+func (a *api) tailLogs(w http.ResponseWriter, r *http.Request) {
+	var opts internal.GetChunkOptions
+	if err := decode.All(&opts, r); err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	ch, err := a.svc.Tail(r.Context(), opts)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	rc := http.NewResponseController(w)
+	rc.Flush()
+
+	for {
+		select {
+		case chunk, ok := <-ch:
+			if !ok {
+				return
+			}
+			if _, err := w.Write(chunk.Data); err != nil {
+				return
+			}
+			rc.Flush()
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
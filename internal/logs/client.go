@@ -3,6 +3,7 @@ package logs
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/url"
 	"strconv"
 
@@ -33,3 +34,18 @@ func (c *Client) PutChunk(ctx context.Context, opts internal.PutChunkOptions) er
 
 	return nil
 }
+
+// TailLogs writes the logs for a phase to w, starting at the given offset, and
+// returns once the end of the logs has been written.
+func (c *Client) TailLogs(ctx context.Context, opts internal.GetChunkOptions, w io.Writer) error {
+	u := fmt.Sprintf("runs/%s/logs/%s/tail", url.QueryEscape(opts.RunID), url.QueryEscape(string(opts.Phase)))
+	req, err := c.NewRequest("GET", u, nil)
+	if err != nil {
+		return err
+	}
+	q := url.Values{}
+	q.Add("offset", strconv.Itoa(opts.Offset))
+	req.URL.RawQuery = q.Encode()
+
+	return c.Do(ctx, req, w)
+}
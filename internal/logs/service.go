@@ -132,6 +132,11 @@ func (s *service) Tail(ctx context.Context, opts internal.GetChunkOptions) (<-ch
 		if len(chunk.Data) > 0 {
 			relay <- chunk
 		}
+		if chunk.IsEnd() {
+			// logs have already finished
+			close(relay)
+			return
+		}
 
 		// relay chunks from subscription
 		for ev := range sub {
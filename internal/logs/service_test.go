@@ -50,6 +50,26 @@ func TestTail(t *testing.T) {
 		require.Equal(t, want, <-stream)
 	})
 
+	t.Run("receive existing finished chunk", func(t *testing.T) {
+		want := internal.Chunk{
+			RunID: "run-123",
+			Phase: internal.PlanPhase,
+			Data:  []byte("\x02hello world\x03"),
+		}
+		svc := fakeService(want)
+
+		stream, err := svc.Tail(ctx, internal.GetChunkOptions{
+			RunID: "run-123",
+			Phase: internal.PlanPhase,
+		})
+		require.NoError(t, err)
+
+		require.Equal(t, want, <-stream)
+		// stream is closed because there are no more logs to tail
+		_, ok := <-stream
+		assert.False(t, ok)
+	})
+
 	t.Run("receive existing chunk and overlapping published chunk", func(t *testing.T) {
 		// send first chunk
 		want := internal.Chunk{
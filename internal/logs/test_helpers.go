@@ -73,6 +73,10 @@ func (f *fakePubSubService) Subscribe(ctx context.Context, id string) (<-chan pu
 	return f.stream, nil
 }
 
+func (f *fakePubSubService) SubscribeFrom(ctx context.Context, id string, _ int64) (<-chan pubsub.Event, error) {
+	return f.Subscribe(ctx, id)
+}
+
 func (f *fakePubSubService) Publish(event pubsub.Event) {
 	f.stream <- event
 }
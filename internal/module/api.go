@@ -1,14 +1,23 @@
 package module
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/leg100/otf/internal"
+	otfapi "github.com/leg100/otf/internal/api"
+	otfhttp "github.com/leg100/otf/internal/http"
 	"github.com/leg100/otf/internal/http/decode"
+	"github.com/leg100/otf/internal/http/html/paths"
 	"github.com/leg100/otf/internal/tfeapi"
 	"github.com/leg100/surl"
 )
@@ -25,6 +34,18 @@ func (h *api) addHandlers(r *mux.Router) {
 	signed.Use(internal.VerifySignedURL(h.Signer))
 	signed.HandleFunc("/modules/download/{module_version_id}.tar.gz", h.downloadModuleVersion).Methods("GET")
 
+	// otf api routes for publishing modules without a VCS repository
+	otf := r.PathPrefix(otfapi.DefaultBasePath).Subrouter()
+	otf.HandleFunc("/organizations/{organization}/modules", h.createModule).Methods("POST")
+	otf.HandleFunc("/organizations/{organization}/modules/{name}/{provider}", h.getModule).Methods("GET")
+	otf.HandleFunc("/modules/{module_id}/versions", h.createModuleVersion).Methods("POST")
+	otf.HandleFunc("/module-versions/{module_version_id}/upload", h.uploadModuleVersion()).Methods("PUT")
+	otf.HandleFunc("/modules/{module_id}/usages", h.listModuleUsages).Methods("GET")
+	otf.HandleFunc("/module-versions/{module_version_id}/actions/deprecate", h.deprecateModuleVersion).Methods("POST")
+	otf.HandleFunc("/module-versions/{module_version_id}/actions/undeprecate", h.updateModuleVersion(Service.UndeprecateVersion)).Methods("POST")
+	otf.HandleFunc("/module-versions/{module_version_id}/actions/yank", h.updateModuleVersion(Service.YankVersion)).Methods("POST")
+	otf.HandleFunc("/module-versions/{module_version_id}/actions/unyank", h.updateModuleVersion(Service.UnyankVersion)).Methods("POST")
+
 	// authenticated module api routes
 	//
 	// Implements the Module Registry Protocol:
@@ -45,7 +66,12 @@ type (
 		Versions []listAvailableVersionsVersion
 	}
 	listAvailableVersionsVersion struct {
-		Version string
+		Version     string
+		Deprecation *listAvailableVersionsDeprecation `json:",omitempty"`
+	}
+	listAvailableVersionsDeprecation struct {
+		Reason string
+		Link   string
 	}
 )
 
@@ -82,10 +108,18 @@ func (h *api) listAvailableVersions(w http.ResponseWriter, r *http.Request) {
 			},
 		},
 	}
-	for _, ver := range mod.AvailableVersions() {
-		response.Modules[0].Versions = append(response.Modules[0].Versions, listAvailableVersionsVersion{
-			Version: ver.Version,
-		})
+	// yanked versions are not listed
+	for _, ver := range mod.InstallableVersions() {
+		v := listAvailableVersionsVersion{Version: ver.Version}
+		if ver.Deprecation != nil {
+			v.Deprecation = &listAvailableVersionsDeprecation{
+				Reason: ver.Deprecation.String(),
+				// the query must be appended to the absolute URL, otherwise
+				// it is escaped as part of the path.
+				Link: otfhttp.Absolute(r, paths.Module(mod.ID)) + "?version=" + url.QueryEscape(ver.Version),
+			}
+		}
+		response.Modules[0].Versions = append(response.Modules[0].Versions, v)
 	}
 	if err := json.NewEncoder(w).Encode(response); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -127,6 +161,9 @@ func (h *api) getModuleVersionDownloadLink(w http.ResponseWriter, r *http.Reques
 	}
 
 	w.Header().Add("X-Terraform-Get", signed)
+	if version.Deprecation != nil {
+		w.Header().Add("Warning", fmt.Sprintf(`299 - "module version %s is deprecated: %s"`, version.Version, version.Deprecation))
+	}
 	w.WriteHeader(http.StatusNoContent)
 }
 
@@ -145,3 +182,161 @@ func (h *api) downloadModuleVersion(w http.ResponseWriter, r *http.Request) {
 
 	w.Write(tarball)
 }
+
+func (h *api) createModule(w http.ResponseWriter, r *http.Request) {
+	organization, err := decode.Param("organization", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var opts CreateOptions
+	if err := json.NewDecoder(r.Body).Decode(&opts); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	opts.Organization = organization
+
+	mod, err := h.svc.CreateModule(r.Context(), opts)
+	if err != nil {
+		tfeapi.Error(w, apiError(err))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(mod)
+}
+
+func (h *api) getModule(w http.ResponseWriter, r *http.Request) {
+	var opts GetModuleOptions
+	if err := decode.Route(&opts, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	mod, err := h.svc.GetModule(r.Context(), opts)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(mod)
+}
+
+func (h *api) createModuleVersion(w http.ResponseWriter, r *http.Request) {
+	moduleID, err := decode.Param("module_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var opts CreateModuleVersionOptions
+	if err := json.NewDecoder(r.Body).Decode(&opts); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	opts.ModuleID = moduleID
+
+	mod, err := h.svc.GetModuleByID(r.Context(), moduleID)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	if mod.Connection != nil {
+		tfeapi.Error(w, apiError(ErrModuleConnected))
+		return
+	}
+	modver, err := h.svc.CreateVersion(r.Context(), opts)
+	if err != nil {
+		tfeapi.Error(w, apiError(err))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(modver)
+}
+
+func (h *api) uploadModuleVersion() http.HandlerFunc {
+	fn := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := decode.Param("module_version_id", r)
+		if err != nil {
+			tfeapi.Error(w, err)
+			return
+		}
+		buf := new(bytes.Buffer)
+		if _, err := io.Copy(buf, r.Body); err != nil {
+			maxBytesError := &http.MaxBytesError{}
+			if errors.As(err, &maxBytesError) {
+				tfeapi.Error(w, &internal.HTTPError{
+					Code:    422,
+					Message: fmt.Sprintf("module tarball exceeds maximum size (%d bytes)", MaxTarballSize),
+				})
+			} else {
+				tfeapi.Error(w, err)
+			}
+			return
+		}
+		if err := h.svc.UploadVersion(r.Context(), id, buf.Bytes()); err != nil {
+			tfeapi.Error(w, apiError(err))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	return http.MaxBytesHandler(fn, MaxTarballSize).ServeHTTP
+}
+
+func (h *api) listModuleUsages(w http.ResponseWriter, r *http.Request) {
+	moduleID, err := decode.Param("module_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	usages, err := h.svc.ListModuleUsages(r.Context(), moduleID)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(usages)
+}
+
+func (h *api) deprecateModuleVersion(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("module_version_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var opts DeprecateModuleVersionOptions
+	if err := json.NewDecoder(r.Body).Decode(&opts); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	opts.ID = id
+
+	modver, err := h.svc.DeprecateVersion(r.Context(), opts)
+	if err != nil {
+		tfeapi.Error(w, apiError(err))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(modver)
+}
+
+// updateModuleVersion returns a handler that updates a module version with
+// the given func.
+func (h *api) updateModuleVersion(fn func(Service, context.Context, string) (*ModuleVersion, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := decode.Param("module_version_id", r)
+		if err != nil {
+			tfeapi.Error(w, err)
+			return
+		}
+
+		modver, err := fn(h.svc, r.Context(), id)
+		if err != nil {
+			tfeapi.Error(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(modver)
+	}
+}
@@ -0,0 +1,119 @@
+package module
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/hashicorp/go-version"
+	"github.com/leg100/otf/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPI_DeprecatedAndYankedVersions(t *testing.T) {
+	svc := &fakeCLIService{mod: &Module{
+		ID:           "mod-123",
+		Organization: "acme",
+		Name:         "vpc",
+		Provider:     "aws",
+		Versions: []ModuleVersion{
+			{ID: "modver-3", ModuleID: "mod-123", Version: "1.2.0", Status: ModuleVersionStatusOK, Yanked: true},
+			{ID: "modver-2", ModuleID: "mod-123", Version: "1.1.0", Status: ModuleVersionStatusOK},
+			{
+				ID:          "modver-1",
+				ModuleID:    "mod-123",
+				Version:     "1.0.0",
+				Status:      ModuleVersionStatusOK,
+				Deprecation: &ModuleVersionDeprecation{Reason: "security issue", Replacement: "1.1.0"},
+			},
+		},
+	}}
+	r := mux.NewRouter()
+	(&api{Signer: internal.NewSigner([]byte("secret")), svc: svc}).addHandlers(r)
+
+	t.Run("list available versions", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", "/v1/modules/acme/vpc/aws/versions", nil))
+		require.Equal(t, 200, w.Code, w.Body.String())
+
+		var got listAvailableVersionsResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&got))
+		require.Equal(t, 1, len(got.Modules))
+		assert.Equal(t, []listAvailableVersionsVersion{
+			{Version: "1.1.0"},
+			{
+				Version: "1.0.0",
+				Deprecation: &listAvailableVersionsDeprecation{
+					Reason: "security issue (use version 1.1.0 instead)",
+					Link:   "http://example.com/app/modules/mod-123?version=1.0.0",
+				},
+			},
+		}, got.Modules[0].Versions)
+	})
+
+	// terraform resolves a version constraint by selecting the greatest
+	// listed version that satisfies the constraint, so a yanked version
+	// cannot be installed, not even by requiring that exact version. The
+	// versions recorded as in use by workspaces are resolved the same way.
+	t.Run("resolve version constraints", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", "/v1/modules/acme/vpc/aws/versions", nil))
+		require.Equal(t, 200, w.Code, w.Body.String())
+
+		var got listAvailableVersionsResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&got))
+
+		for constraint, want := range map[string]string{
+			"":        "1.1.0",
+			"~> 1.0":  "1.1.0",
+			"1.0.0":   "1.0.0",
+			"1.2.0":   "",
+			">= 1.2":  "",
+			"< 1.1.0": "1.0.0",
+		} {
+			t.Run(constraint, func(t *testing.T) {
+				constraints, err := version.NewConstraint(constraint)
+				if constraint == "" {
+					constraints, err = nil, nil
+				}
+				require.NoError(t, err)
+
+				var resolved *version.Version
+				for _, listed := range got.Modules[0].Versions {
+					v := version.Must(version.NewVersion(listed.Version))
+					if constraints.Check(v) && (resolved == nil || v.GreaterThan(resolved)) {
+						resolved = v
+					}
+				}
+				if want == "" {
+					assert.Nil(t, resolved)
+					assert.Nil(t, resolveVersion(svc.mod, constraint))
+					return
+				}
+				if assert.NotNil(t, resolved) {
+					assert.Equal(t, want, resolved.String())
+				}
+				if modver := resolveVersion(svc.mod, constraint); assert.NotNil(t, modver) {
+					assert.Equal(t, want, modver.Version)
+				}
+			})
+		}
+	})
+
+	t.Run("download deprecated version", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", "/v1/modules/acme/vpc/aws/1.0.0/download", nil))
+		require.Equal(t, 204, w.Code, w.Body.String())
+		assert.Equal(t, `299 - "module version 1.0.0 is deprecated: security issue (use version 1.1.0 instead)"`, w.Header().Get("Warning"))
+	})
+
+	t.Run("download yanked version", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", "/v1/modules/acme/vpc/aws/1.2.0/download", nil))
+		require.Equal(t, 204, w.Code, w.Body.String())
+		assert.NotEmpty(t, w.Header().Get("X-Terraform-Get"))
+		assert.Empty(t, w.Header().Get("Warning"))
+	})
+}
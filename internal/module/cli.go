@@ -0,0 +1,105 @@
+package module
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/leg100/otf/internal"
+	otfapi "github.com/leg100/otf/internal/api"
+	"github.com/spf13/cobra"
+)
+
+type CLI struct {
+	Service
+}
+
+func NewCommand(api *otfapi.Client) *cobra.Command {
+	cli := &CLI{}
+	cmd := &cobra.Command{
+		Use:   "modules",
+		Short: "Registry module management",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := cmd.Parent().PersistentPreRunE(cmd.Parent(), args); err != nil {
+				return err
+			}
+			cli.Service = &Client{Client: api}
+			return nil
+		},
+	}
+
+	cmd.AddCommand(cli.modulePublishCommand())
+
+	return cmd
+}
+
+func (a *CLI) modulePublishCommand() *cobra.Command {
+	var (
+		opts    CreateOptions
+		version string
+	)
+	cmd := &cobra.Command{
+		Use:           "publish [path]",
+		Short:         "Publish a module version from a local directory",
+		Long:          "Publish a module version from a local directory, which defaults to the current directory. The module is created if it does not exist. Modules connected to a VCS repository cannot be published this way.",
+		Args:          cobra.MaximumNArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			path := "."
+			if len(args) > 0 {
+				path = args[0]
+			}
+
+			tarball, err := internal.Pack(path)
+			if err != nil {
+				return fmt.Errorf("packing module directory: %w", err)
+			}
+
+			mod, err := a.GetModule(ctx, GetModuleOptions{
+				Organization: opts.Organization,
+				Name:         opts.Name,
+				Provider:     opts.Provider,
+			})
+			if errors.Is(err, internal.ErrResourceNotFound) {
+				mod, err = a.CreateModule(ctx, opts)
+				if err != nil {
+					return fmt.Errorf("creating module: %w", err)
+				}
+			} else if err != nil {
+				return fmt.Errorf("retrieving module: %w", err)
+			}
+			if mod.Connection != nil {
+				return ErrModuleConnected
+			}
+
+			modver, err := a.CreateVersion(ctx, CreateModuleVersionOptions{
+				ModuleID: mod.ID,
+				Version:  version,
+			})
+			if err != nil {
+				return fmt.Errorf("creating module version: %w", err)
+			}
+			if err := a.UploadVersion(ctx, modver.ID, tarball); err != nil {
+				return fmt.Errorf("uploading module version: %w", err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Published module %s/%s/%s version %s\n", mod.Organization, mod.Name, mod.Provider, modver.Version)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Organization, "organization", "", "Name of the organization the module belongs to")
+	cmd.MarkFlagRequired("organization")
+
+	cmd.Flags().StringVar(&opts.Name, "name", "", "Name of the module")
+	cmd.MarkFlagRequired("name")
+
+	cmd.Flags().StringVar(&opts.Provider, "provider", "", "Name of the provider the module is for, e.g. aws")
+	cmd.MarkFlagRequired("provider")
+
+	cmd.Flags().StringVar(&version, "version", "", "Semantic version of the module version to publish, e.g. 1.0.0")
+	cmd.MarkFlagRequired("version")
+
+	return cmd
+}
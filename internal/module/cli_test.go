@@ -0,0 +1,80 @@
+package module
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/connections"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCLI_Publish(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.tf"), []byte(`variable "foo" {}`), 0o644))
+
+	t.Run("create module", func(t *testing.T) {
+		svc := &fakeCLIService{}
+		cmd := (&CLI{Service: svc}).modulePublishCommand()
+		cmd.SetArgs([]string{dir, "--organization", "acme", "--name", "vpc", "--provider", "aws", "--version", "1.0.0"})
+		got := bytes.Buffer{}
+		cmd.SetOut(&got)
+		require.NoError(t, cmd.Execute())
+
+		assert.Equal(t, "Published module acme/vpc/aws version 1.0.0\n", got.String())
+		assert.NotNil(t, svc.created)
+		assert.NotEmpty(t, svc.uploaded)
+	})
+
+	t.Run("existing module", func(t *testing.T) {
+		svc := &fakeCLIService{mod: &Module{ID: "mod-123", Organization: "acme", Name: "vpc", Provider: "aws"}}
+		cmd := (&CLI{Service: svc}).modulePublishCommand()
+		cmd.SetArgs([]string{dir, "--organization", "acme", "--name", "vpc", "--provider", "aws", "--version", "1.0.1"})
+		cmd.SetOut(&bytes.Buffer{})
+		require.NoError(t, cmd.Execute())
+
+		assert.Nil(t, svc.created)
+		assert.NotEmpty(t, svc.uploaded)
+	})
+
+	t.Run("connected module", func(t *testing.T) {
+		svc := &fakeCLIService{mod: &Module{ID: "mod-123", Connection: &connections.Connection{}}}
+		cmd := (&CLI{Service: svc}).modulePublishCommand()
+		cmd.SetArgs([]string{dir, "--organization", "acme", "--name", "vpc", "--provider", "aws", "--version", "1.0.1"})
+		cmd.SetOut(&bytes.Buffer{})
+		assert.ErrorIs(t, cmd.Execute(), ErrModuleConnected)
+	})
+}
+
+type fakeCLIService struct {
+	mod      *Module
+	created  *Module
+	uploaded []byte
+
+	Service
+}
+
+func (f *fakeCLIService) GetModule(context.Context, GetModuleOptions) (*Module, error) {
+	if f.mod == nil {
+		return nil, internal.ErrResourceNotFound
+	}
+	return f.mod, nil
+}
+
+func (f *fakeCLIService) CreateModule(_ context.Context, opts CreateOptions) (*Module, error) {
+	f.created = newModule(opts)
+	return f.created, nil
+}
+
+func (f *fakeCLIService) CreateVersion(_ context.Context, opts CreateModuleVersionOptions) (*ModuleVersion, error) {
+	return newModuleVersion(opts), nil
+}
+
+func (f *fakeCLIService) UploadVersion(_ context.Context, _ string, tarball []byte) error {
+	f.uploaded = tarball
+	return nil
+}
@@ -0,0 +1,131 @@
+package module
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/go-retryablehttp"
+	otfapi "github.com/leg100/otf/internal/api"
+)
+
+type Client struct {
+	*otfapi.Client
+
+	// Client does not implement all of service yet
+	Service
+}
+
+func (c *Client) CreateModule(ctx context.Context, opts CreateOptions) (*Module, error) {
+	u := fmt.Sprintf("organizations/%s/modules", url.QueryEscape(opts.Organization))
+	req, err := c.NewRequest("POST", u, &opts)
+	if err != nil {
+		return nil, err
+	}
+	var mod Module
+	if err := c.doJSON(ctx, req, &mod); err != nil {
+		return nil, err
+	}
+	return &mod, nil
+}
+
+func (c *Client) GetModule(ctx context.Context, opts GetModuleOptions) (*Module, error) {
+	u := fmt.Sprintf("organizations/%s/modules/%s/%s",
+		url.QueryEscape(opts.Organization),
+		url.QueryEscape(opts.Name),
+		url.QueryEscape(opts.Provider),
+	)
+	req, err := c.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	var mod Module
+	if err := c.doJSON(ctx, req, &mod); err != nil {
+		return nil, err
+	}
+	return &mod, nil
+}
+
+func (c *Client) CreateVersion(ctx context.Context, opts CreateModuleVersionOptions) (*ModuleVersion, error) {
+	u := fmt.Sprintf("modules/%s/versions", url.QueryEscape(opts.ModuleID))
+	req, err := c.NewRequest("POST", u, &opts)
+	if err != nil {
+		return nil, err
+	}
+	var modver ModuleVersion
+	if err := c.doJSON(ctx, req, &modver); err != nil {
+		return nil, err
+	}
+	return &modver, nil
+}
+
+func (c *Client) UploadVersion(ctx context.Context, versionID string, tarball []byte) error {
+	u := fmt.Sprintf("module-versions/%s/upload", url.QueryEscape(versionID))
+	req, err := c.NewRequest("PUT", u, tarball)
+	if err != nil {
+		return err
+	}
+	return c.Do(ctx, req, nil)
+}
+
+func (c *Client) ListModuleUsages(ctx context.Context, moduleID string) ([]*ModuleUsage, error) {
+	u := fmt.Sprintf("modules/%s/usages", url.QueryEscape(moduleID))
+	req, err := c.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	var usages []*ModuleUsage
+	if err := c.doJSON(ctx, req, &usages); err != nil {
+		return nil, err
+	}
+	return usages, nil
+}
+
+func (c *Client) DeprecateVersion(ctx context.Context, opts DeprecateModuleVersionOptions) (*ModuleVersion, error) {
+	u := fmt.Sprintf("module-versions/%s/actions/deprecate", url.QueryEscape(opts.ID))
+	req, err := c.NewRequest("POST", u, &opts)
+	if err != nil {
+		return nil, err
+	}
+	var modver ModuleVersion
+	if err := c.doJSON(ctx, req, &modver); err != nil {
+		return nil, err
+	}
+	return &modver, nil
+}
+
+func (c *Client) UndeprecateVersion(ctx context.Context, versionID string) (*ModuleVersion, error) {
+	return c.versionAction(ctx, versionID, "undeprecate")
+}
+
+func (c *Client) YankVersion(ctx context.Context, versionID string) (*ModuleVersion, error) {
+	return c.versionAction(ctx, versionID, "yank")
+}
+
+func (c *Client) UnyankVersion(ctx context.Context, versionID string) (*ModuleVersion, error) {
+	return c.versionAction(ctx, versionID, "unyank")
+}
+
+func (c *Client) versionAction(ctx context.Context, versionID, action string) (*ModuleVersion, error) {
+	u := fmt.Sprintf("module-versions/%s/actions/%s", url.QueryEscape(versionID), action)
+	req, err := c.NewRequest("POST", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	var modver ModuleVersion
+	if err := c.doJSON(ctx, req, &modver); err != nil {
+		return nil, err
+	}
+	return &modver, nil
+}
+
+// doJSON sends the request and decodes the JSON-encoded response into v.
+func (c *Client) doJSON(ctx context.Context, req *retryablehttp.Request, v any) error {
+	var buf bytes.Buffer
+	if err := c.Do(ctx, req, &buf); err != nil {
+		return err
+	}
+	return json.Unmarshal(buf.Bytes(), v)
+}
@@ -2,9 +2,12 @@ package module
 
 import (
 	"context"
+	"errors"
 	"sort"
+	"time"
 
 	"github.com/jackc/pgtype"
+	"github.com/leg100/otf/internal"
 	"github.com/leg100/otf/internal/connections"
 	"github.com/leg100/otf/internal/semver"
 	"github.com/leg100/otf/internal/sql"
@@ -26,6 +29,9 @@ type (
 		Provider         pgtype.Text            `json:"provider"`
 		Status           pgtype.Text            `json:"status"`
 		OrganizationName pgtype.Text            `json:"organization_name"`
+		Path             pgtype.Text            `json:"path"`
+		TagPrefix        pgtype.Text            `json:"tag_prefix"`
+		RunTests         bool                   `json:"run_tests"`
 		ModuleConnection *pggen.RepoConnections `json:"module_connection"`
 		Versions         []pggen.ModuleVersions `json:"versions"`
 	}
@@ -40,6 +46,9 @@ func (db *pgdb) createModule(ctx context.Context, mod *Module) error {
 		Provider:         sql.String(mod.Provider),
 		Status:           sql.String(string(mod.Status)),
 		OrganizationName: sql.String(mod.Organization),
+		Path:             sql.String(mod.Path),
+		TagPrefix:        sql.String(mod.TagPrefix),
+		RunTests:         mod.RunTests,
 	})
 	return sql.Error(err)
 }
@@ -87,13 +96,17 @@ func (db *pgdb) getModuleByID(ctx context.Context, id string) (*Module, error) {
 	return moduleRow(row).toModule(), nil
 }
 
-func (db *pgdb) getModuleByConnection(ctx context.Context, vcsProviderID, repoPath string) (*Module, error) {
-	row, err := db.Conn(ctx).FindModuleByConnection(ctx, sql.String(vcsProviderID), sql.String(repoPath))
+func (db *pgdb) listModulesByConnection(ctx context.Context, vcsProviderID, repoPath string) ([]*Module, error) {
+	rows, err := db.Conn(ctx).FindModulesByConnection(ctx, sql.String(vcsProviderID), sql.String(repoPath))
 	if err != nil {
 		return nil, sql.Error(err)
 	}
 
-	return moduleRow(row).toModule(), nil
+	modules := make([]*Module, len(rows))
+	for i, r := range rows {
+		modules[i] = moduleRow(r).toModule()
+	}
+	return modules, nil
 }
 
 func (db *pgdb) delete(ctx context.Context, id string) error {
@@ -151,6 +164,168 @@ func (db *pgdb) getTarball(ctx context.Context, versionID string) ([]byte, error
 	return tarball, nil
 }
 
+func (db *pgdb) updateDeprecation(ctx context.Context, versionID string, deprecation *ModuleVersionDeprecation) error {
+	params := pggen.UpdateModuleVersionDeprecationByIDParams{
+		ModuleVersionID:    sql.String(versionID),
+		DeprecationReason:  sql.String(""),
+		ReplacementVersion: sql.String(""),
+	}
+	if deprecation != nil {
+		params.Deprecated = true
+		params.DeprecationReason = sql.String(deprecation.Reason)
+		params.ReplacementVersion = sql.String(deprecation.Replacement)
+	}
+	_, err := db.Conn(ctx).UpdateModuleVersionDeprecationByID(ctx, params)
+	return sql.Error(err)
+}
+
+func (db *pgdb) updateYanked(ctx context.Context, versionID string, yanked bool) error {
+	_, err := db.Conn(ctx).UpdateModuleVersionYankedByID(ctx, yanked, sql.String(versionID))
+	return sql.Error(err)
+}
+
+func (db *pgdb) incrementDownloads(ctx context.Context, versionID string) error {
+	_, err := db.Conn(ctx).IncrementModuleVersionDownloads(ctx, sql.String(versionID))
+	return sql.Error(err)
+}
+
+// getWorkspaceOrganization retrieves the name of the organization to which a
+// workspace belongs.
+func (db *pgdb) getWorkspaceOrganization(ctx context.Context, workspaceID string) (string, error) {
+	row, err := db.Conn(ctx).FindWorkspaceByID(ctx, sql.String(workspaceID))
+	if err != nil {
+		return "", sql.Error(err)
+	}
+	return row.OrganizationName.String, nil
+}
+
+// replaceUsages replaces the module versions used by a workspace.
+func (db *pgdb) replaceUsages(ctx context.Context, workspaceID string, versionIDs []string) error {
+	return db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		if _, err := q.DeleteModuleVersionUsagesByWorkspaceID(ctx, sql.String(workspaceID)); err != nil {
+			return sql.Error(err)
+		}
+		for _, id := range versionIDs {
+			_, err := q.InsertModuleVersionUsage(ctx, pggen.InsertModuleVersionUsageParams{
+				ModuleVersionID: sql.String(id),
+				WorkspaceID:     sql.String(workspaceID),
+				UpdatedAt:       sql.Timestamptz(internal.CurrentTimestamp(nil)),
+			})
+			if err != nil {
+				return sql.Error(err)
+			}
+		}
+		return nil
+	})
+}
+
+func (db *pgdb) listUsages(ctx context.Context, moduleID string) ([]*ModuleUsage, error) {
+	rows, err := db.Conn(ctx).FindModuleVersionUsagesByModuleID(ctx, sql.String(moduleID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	usages := make([]*ModuleUsage, len(rows))
+	for i, r := range rows {
+		usages[i] = &ModuleUsage{
+			ModuleVersionID: r.ModuleVersionID.String,
+			Version:         r.Version.String,
+			WorkspaceID:     r.WorkspaceID.String,
+			WorkspaceName:   r.WorkspaceName.String,
+			UpdatedAt:       r.UpdatedAt.Time.UTC(),
+		}
+	}
+	return usages, nil
+}
+
+func (db *pgdb) listPendingTests(ctx context.Context) ([]*PendingModuleTest, error) {
+	rows, err := db.Conn(ctx).FindPendingModuleVersionTests(ctx)
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	pending := make([]*PendingModuleTest, len(rows))
+	for i, r := range rows {
+		pending[i] = &PendingModuleTest{
+			ModuleVersionID: r.ModuleVersionID.String,
+			Organization:    r.OrganizationName.String,
+			Name:            r.Name.String,
+			Provider:        r.Provider.String,
+			Version:         r.Version.String,
+		}
+	}
+	return pending, nil
+}
+
+// startTest marks the test of a module version as started, returning
+// ErrTestAlreadyStarted if it is no longer pending.
+func (db *pgdb) startTest(ctx context.Context, versionID string) error {
+	return db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.UpdateModuleVersionTestStarted(ctx, sql.String(versionID))
+		if errors.Is(sql.Error(err), internal.ErrResourceNotFound) {
+			return ErrTestAlreadyStarted
+		} else if err != nil {
+			return sql.Error(err)
+		}
+		_, err = q.InsertModuleVersionTest(ctx, sql.String(versionID), sql.Timestamptz(internal.CurrentTimestamp(nil)))
+		return sql.Error(err)
+	})
+}
+
+// requeueStaleTests returns tests started before the given time that have yet
+// to finish to the queue of tests awaiting an agent, returning the IDs of
+// their module versions.
+func (db *pgdb) requeueStaleTests(ctx context.Context, startedBefore time.Time) ([]string, error) {
+	rows, err := db.Conn(ctx).UpdateStaleModuleVersionTests(ctx, sql.Timestamptz(startedBefore))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	ids := make([]string, len(rows))
+	for i, r := range rows {
+		ids[i] = r.String
+	}
+	return ids, nil
+}
+
+func (db *pgdb) appendTestOutput(ctx context.Context, versionID string, output []byte) error {
+	_, err := db.Conn(ctx).UpdateModuleVersionTestOutput(ctx, output, sql.String(versionID))
+	return sql.Error(err)
+}
+
+// finishTest marks the test of a module version as finished, updating the
+// status of the version.
+func (db *pgdb) finishTest(ctx context.Context, versionID string, status ModuleVersionStatus) error {
+	return db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.UpdateModuleVersionTestFinished(ctx, sql.Timestamptz(internal.CurrentTimestamp(nil)), sql.String(versionID))
+		if err != nil {
+			return sql.Error(err)
+		}
+		return db.updateModuleVersionStatus(ctx, UpdateModuleVersionStatusOptions{
+			ID:     versionID,
+			Status: status,
+		})
+	})
+}
+
+func (db *pgdb) listTests(ctx context.Context, moduleID string) ([]*ModuleVersionTest, error) {
+	rows, err := db.Conn(ctx).FindModuleVersionTestsByModuleID(ctx, sql.String(moduleID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	tests := make([]*ModuleVersionTest, len(rows))
+	for i, r := range rows {
+		tests[i] = &ModuleVersionTest{
+			ModuleVersionID: r.ModuleVersionID.String,
+			Version:         r.Version.String,
+			Status:          ModuleVersionStatus(r.Status.String),
+			Output:          r.Output,
+			StartedAt:       r.StartedAt.Time.UTC(),
+		}
+		if r.FinishedAt.Status == pgtype.Present {
+			tests[i].FinishedAt = internal.Time(r.FinishedAt.Time.UTC())
+		}
+	}
+	return tests, nil
+}
+
 // toModule converts a database row into a module
 func (row moduleRow) toModule() *Module {
 	module := &Module{
@@ -161,6 +336,9 @@ func (row moduleRow) toModule() *Module {
 		Provider:     row.Provider.String,
 		Status:       ModuleStatus(row.Status.String),
 		Organization: row.OrganizationName.String,
+		Path:         row.Path.String,
+		TagPrefix:    row.TagPrefix.String,
+		RunTests:     row.RunTests,
 	}
 	if row.ModuleConnection != nil {
 		module.Connection = &connections.Connection{
@@ -171,7 +349,7 @@ func (row moduleRow) toModule() *Module {
 	// versions are always maintained in descending order
 	sort.Sort(byVersion(row.Versions))
 	for i := len(row.Versions) - 1; i >= 0; i-- {
-		module.Versions = append(module.Versions, ModuleVersion{
+		modver := ModuleVersion{
 			ID:          row.Versions[i].ModuleVersionID.String,
 			Version:     row.Versions[i].Version.String,
 			CreatedAt:   row.Versions[i].CreatedAt.Time.UTC(),
@@ -179,7 +357,16 @@ func (row moduleRow) toModule() *Module {
 			ModuleID:    row.Versions[i].ModuleID.String,
 			Status:      ModuleVersionStatus(row.Versions[i].Status.String),
 			StatusError: row.Versions[i].StatusError.String,
-		})
+			Downloads:   int(row.Versions[i].Downloads.Int),
+			Yanked:      row.Versions[i].Yanked,
+		}
+		if row.Versions[i].Deprecated {
+			modver.Deprecation = &ModuleVersionDeprecation{
+				Reason:      row.Versions[i].DeprecationReason.String,
+				Replacement: row.Versions[i].ReplacementVersion.String,
+			}
+		}
+		module.Versions = append(module.Versions, modver)
 	}
 	return module
 }
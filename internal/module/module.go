@@ -3,6 +3,10 @@ package module
 
 import (
 	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
 	"time"
 
 	"log/slog"
@@ -10,6 +14,7 @@ import (
 	"github.com/leg100/otf/internal"
 	"github.com/leg100/otf/internal/connections"
 	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/semver"
 	"github.com/leg100/otf/internal/vcs"
 )
 
@@ -26,9 +31,26 @@ const (
 	ModuleVersionStatusRegIngressing       ModuleVersionStatus = "reg_ingressing"
 	ModuleVersionStatusRegIngressFailed    ModuleVersionStatus = "reg_ingress_failed"
 	ModuleVersionStatusOK                  ModuleVersionStatus = "ok"
+	ModuleVersionStatusTestPending         ModuleVersionStatus = "test_pending"
+	ModuleVersionStatusTesting             ModuleVersionStatus = "testing"
+	ModuleVersionStatusTestFailed          ModuleVersionStatus = "test_failed"
 )
 
-var ErrInvalidModuleRepo = errors.New("invalid repository name for module")
+var (
+	ErrInvalidModuleRepo    = errors.New("invalid repository name for module")
+	ErrInvalidModuleVersion = errors.New("module version must be a semantic version")
+	ErrInvalidModuleTarball = errors.New("module tarball is invalid")
+	ErrModuleConnected      = errors.New("module is connected to a VCS repository and its versions can only be published from tags")
+	ErrInvalidModulePath    = errors.New("module path must be a relative path within the repository")
+	ErrDeprecationReason    = errors.New("a reason must be given for deprecating a module version")
+	ErrInvalidReplacement   = errors.New("replacement must be another available version of the module")
+	ErrTestAlreadyStarted   = errors.New("module version test has already started")
+
+	// module names may contain alphanumerics, hyphens and underscores
+	reModuleName = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+	// module providers may only contain lowercase alphanumerics
+	reModuleProvider = regexp.MustCompile(`^[a-z0-9]+$`)
+)
 
 type (
 	Module struct {
@@ -41,6 +63,15 @@ type (
 		Status       ModuleStatus
 		Versions     []ModuleVersion         // versions sorted in descending order
 		Connection   *connections.Connection // optional vcs repo connection
+		// Path is the subdirectory of the connected repository containing the
+		// module. Empty if the module is in the root of the repository.
+		Path string
+		// TagPrefix is the prefix of the repository's tags from which versions
+		// of the module are published, e.g. 'vpc/' for the tag 'vpc/v1.2.0'.
+		TagPrefix string
+		// RunTests is true if a new version must pass `terraform test` before
+		// it is made available.
+		RunTests bool
 	}
 
 	ModuleStatus string
@@ -53,31 +84,98 @@ type (
 		UpdatedAt   time.Time
 		Status      ModuleVersionStatus
 		StatusError string
-		// TODO: download counters
+		// Downloads is the number of times the version has been downloaded
+		// from the registry.
+		Downloads int
+		// Deprecation is non-nil if the version is deprecated.
+		Deprecation *ModuleVersionDeprecation
+		// Yanked versions are not listed by the registry. Terraform resolves
+		// version constraints, including exact versions, against the listed
+		// versions, so it no longer installs a yanked version.
+		Yanked bool
+	}
+
+	// ModuleVersionDeprecation describes why a module version is deprecated.
+	ModuleVersionDeprecation struct {
+		Reason string
+		// Replacement is an optional version to use instead.
+		Replacement string
 	}
 
 	ModuleVersionStatus string
 
+	// ModuleVersionTest is the result of running `terraform test` on a module
+	// version.
+	ModuleVersionTest struct {
+		ModuleVersionID string
+		Version         string
+		// Status is the status of the version, which reports whether the test
+		// is running, or whether it has passed or failed.
+		Status     ModuleVersionStatus
+		Output     []byte
+		StartedAt  time.Time
+		FinishedAt *time.Time
+	}
+
+	// PendingModuleTest is a module version awaiting an agent to test it.
+	PendingModuleTest struct {
+		ModuleVersionID string
+		Organization    string
+		Name            string
+		Provider        string
+		Version         string
+	}
+
+	// ModuleUsage records a workspace using a module version, as determined
+	// from the latest configuration uploaded to the workspace.
+	ModuleUsage struct {
+		ModuleVersionID string
+		Version         string
+		WorkspaceID     string
+		WorkspaceName   string
+		UpdatedAt       time.Time
+	}
+
 	PublishOptions struct {
 		Repo          Repo
 		VCSProviderID string
+		// Name and Provider of the module. If either is empty then it is
+		// derived from the repository name.
+		Name     string
+		Provider string
+		// Path is the subdirectory of the repository containing the module.
+		Path string
+		// TagPrefix is the prefix of tags from which to publish versions.
+		TagPrefix string
+		// RunTests requires versions to pass `terraform test` before they are
+		// made available.
+		RunTests bool
 	}
 	PublishVersionOptions struct {
 		ModuleID string
 		Version  string
 		Ref      string
 		Repo     Repo
+		Path     string // subdirectory of repo containing module
 		Client   vcs.Client
 	}
 	CreateOptions struct {
 		Name         string
 		Provider     string
 		Organization string
+		Path         string
+		TagPrefix    string
+		RunTests     bool
 	}
 	CreateModuleVersionOptions struct {
 		ModuleID string
 		Version  string
 	}
+	DeprecateModuleVersionOptions struct {
+		ID          string
+		Reason      string
+		Replacement string
+	}
 	UpdateModuleVersionStatusOptions struct {
 		ID     string
 		Status ModuleVersionStatus
@@ -106,6 +204,9 @@ func newModule(opts CreateOptions) *Module {
 		Provider:     opts.Provider,
 		Status:       ModuleStatusPending,
 		Organization: opts.Organization,
+		Path:         opts.Path,
+		TagPrefix:    opts.TagPrefix,
+		RunTests:     opts.RunTests,
 	}
 }
 
@@ -130,12 +231,35 @@ func (m *Module) LogValue() slog.Value {
 		slog.String("provider", m.Provider),
 		slog.String("status", string(m.Status)),
 	}
+	if m.Path != "" {
+		attrs = append(attrs, slog.String("path", m.Path))
+	}
+	if m.TagPrefix != "" {
+		attrs = append(attrs, slog.String("tag_prefix", m.TagPrefix))
+	}
+	if m.RunTests {
+		attrs = append(attrs, slog.Bool("run_tests", true))
+	}
 	if m.Latest() != nil {
 		attrs = append(attrs, slog.String("latest_version", m.Latest().Version))
 	}
 	return slog.GroupValue(attrs...)
 }
 
+// versionFromTag returns the module version for a git tag, stripping off the
+// module's tag prefix and any 'v' prefix. False is returned if the tag does not
+// begin with the tag prefix or is not otherwise a semantic version.
+func (m *Module) versionFromTag(tag string) (string, bool) {
+	version, found := strings.CutPrefix(tag, m.TagPrefix)
+	if !found {
+		return "", false
+	}
+	if !semver.IsValid(version) {
+		return "", false
+	}
+	return strings.TrimPrefix(version, "v"), true
+}
+
 func (m *Module) AvailableVersions() (avail []ModuleVersion) {
 	for _, modver := range m.Versions {
 		if modver.Status == ModuleVersionStatusOK {
@@ -145,6 +269,25 @@ func (m *Module) AvailableVersions() (avail []ModuleVersion) {
 	return
 }
 
+// Downloads is the total number of downloads of all versions of the module.
+func (m *Module) Downloads() (total int) {
+	for _, modver := range m.Versions {
+		total += modver.Downloads
+	}
+	return
+}
+
+// InstallableVersions returns the available versions that are not yanked,
+// which are those listed by the registry.
+func (m *Module) InstallableVersions() (installable []ModuleVersion) {
+	for _, modver := range m.AvailableVersions() {
+		if !modver.Yanked {
+			installable = append(installable, modver)
+		}
+	}
+	return
+}
+
 func (m *Module) Version(v string) *ModuleVersion {
 	for _, modver := range m.Versions {
 		if modver.Version == v {
@@ -154,6 +297,15 @@ func (m *Module) Version(v string) *ModuleVersion {
 	return nil
 }
 
+func (m *Module) versionByID(id string) *ModuleVersion {
+	for _, modver := range m.Versions {
+		if modver.ID == id {
+			return &modver
+		}
+	}
+	return nil
+}
+
 // Latest retrieves the latest version, which is the greatest version with an
 // ok status. If there is no such version, nil is returned.
 func (m *Module) Latest() *ModuleVersion {
@@ -165,6 +317,33 @@ func (m *Module) Latest() *ModuleVersion {
 	return nil
 }
 
+// cleanModulePath cleans a module's path within a repository, returning an
+// error if the path is absolute or refers to a path outside of the
+// repository.
+func cleanModulePath(p string) (string, error) {
+	if p == "" {
+		return "", nil
+	}
+	if path.IsAbs(p) {
+		return "", ErrInvalidModulePath
+	}
+	p = path.Clean(p)
+	if p == "." {
+		return "", nil
+	}
+	if p == ".." || strings.HasPrefix(p, "../") {
+		return "", ErrInvalidModulePath
+	}
+	return p, nil
+}
+
+func (d *ModuleVersionDeprecation) String() string {
+	if d.Replacement != "" {
+		return fmt.Sprintf("%s (use version %s instead)", d.Reason, d.Replacement)
+	}
+	return d.Reason
+}
+
 func (v *ModuleVersion) LogValue() slog.Value {
 	attrs := []slog.Attr{
 		slog.String("id", v.ID),
@@ -172,5 +351,11 @@ func (v *ModuleVersion) LogValue() slog.Value {
 		slog.String("version", v.Version),
 		slog.String("status", string(v.Status)),
 	}
+	if v.Deprecation != nil {
+		attrs = append(attrs, slog.Bool("deprecated", true))
+	}
+	if v.Yanked {
+		attrs = append(attrs, slog.Bool("yanked", true))
+	}
 	return slog.GroupValue(attrs...)
 }
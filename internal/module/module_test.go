@@ -24,3 +24,67 @@ func TestModule(t *testing.T) {
 		assert.Equal(t, &modver2, mod.Version("v2"))
 	})
 }
+
+func TestModule_versionFromTag(t *testing.T) {
+	tests := []struct {
+		name      string
+		tagPrefix string
+		tag       string
+		want      string
+		wantOK    bool
+	}{
+		{"semver", "", "v1.2.0", "1.2.0", true},
+		{"semver without v prefix", "", "1.2.0", "1.2.0", true},
+		{"not semver", "", "latest", "", false},
+		{"prefixed tag without tag prefix", "", "vpc/v1.2.0", "", false},
+		{"tag prefix", "vpc/", "vpc/v1.2.0", "1.2.0", true},
+		{"different tag prefix", "vpc/", "s3/v1.2.0", "", false},
+		{"tag without tag prefix", "vpc/", "v1.2.0", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mod := &Module{TagPrefix: tt.tagPrefix}
+			got, ok := mod.versionFromTag(tt.tag)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCleanModulePath(t *testing.T) {
+	tests := []struct {
+		path    string
+		want    string
+		wantErr error
+	}{
+		{"", "", nil},
+		{".", "", nil},
+		{"modules/vpc", "modules/vpc", nil},
+		{"modules/vpc/", "modules/vpc", nil},
+		{"./modules/../vpc", "vpc", nil},
+		{"/modules/vpc", "", ErrInvalidModulePath},
+		{"../vpc", "", ErrInvalidModulePath},
+		{"..", "", ErrInvalidModulePath},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, err := cleanModulePath(tt.path)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestModule_Downloads(t *testing.T) {
+	mod := &Module{Versions: []ModuleVersion{{Downloads: 3}, {Downloads: 7}}}
+	assert.Equal(t, 10, mod.Downloads())
+}
+
+func TestModule_InstallableVersions(t *testing.T) {
+	mod := &Module{Versions: []ModuleVersion{
+		{Version: "1.2.0", Status: ModuleVersionStatusOK, Yanked: true},
+		{Version: "1.1.0", Status: ModuleVersionStatusPending},
+		{Version: "1.0.0", Status: ModuleVersionStatusOK, Deprecation: &ModuleVersionDeprecation{Reason: "bug"}},
+	}}
+	assert.Equal(t, []ModuleVersion{mod.Versions[2]}, mod.InstallableVersions())
+}
@@ -2,13 +2,12 @@ package module
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"strings"
 
 	"github.com/go-logr/logr"
 	"github.com/leg100/otf/internal"
 	"github.com/leg100/otf/internal/pubsub"
-	"github.com/leg100/otf/internal/semver"
 	"github.com/leg100/otf/internal/vcs"
 	"github.com/leg100/otf/internal/vcsprovider"
 )
@@ -51,29 +50,39 @@ func (p *publisher) handleWithError(logger logr.Logger, event vcs.Event) error {
 	if event.Action != vcs.ActionCreated {
 		return nil
 	}
-	// only interested in tags that look like semantic versions
-	if !semver.IsValid(event.Tag) {
-		return nil
-	}
-	// TODO: we're only retrieving *one* module, but can not *multiple* modules
-	// be connected to a repo?
-	module, err := p.GetModuleByConnection(ctx, event.VCSProviderID, event.RepoPath)
+	modules, err := p.ListModulesByConnection(ctx, event.VCSProviderID, event.RepoPath)
 	if err != nil {
 		return err
 	}
-	if module.Connection == nil {
-		return fmt.Errorf("module is not connected to a repo: %s", module.ID)
-	}
-	client, err := p.GetVCSClient(ctx, module.Connection.VCSProviderID)
-	if err != nil {
-		return err
+	// a repository may contain several modules, each published from tags with
+	// its own prefix. A failure to publish one module does not prevent
+	// publishing the others.
+	var errs []error
+	for _, module := range modules {
+		version, ok := module.versionFromTag(event.Tag)
+		if !ok {
+			continue
+		}
+		if module.Connection == nil {
+			errs = append(errs, fmt.Errorf("module is not connected to a repo: %s", module.ID))
+			continue
+		}
+		client, err := p.GetVCSClient(ctx, module.Connection.VCSProviderID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("publishing module %s: %w", module.ID, err))
+			continue
+		}
+		err = p.PublishVersion(ctx, PublishVersionOptions{
+			ModuleID: module.ID,
+			Version:  version,
+			Ref:      event.CommitSHA,
+			Repo:     Repo(module.Connection.Repo),
+			Path:     module.Path,
+			Client:   client,
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("publishing module %s: %w", module.ID, err))
+		}
 	}
-	return p.PublishVersion(ctx, PublishVersionOptions{
-		ModuleID: module.ID,
-		// strip off v prefix if it has one
-		Version: strings.TrimPrefix(event.Tag, "v"),
-		Ref:     event.CommitSHA,
-		Repo:    Repo(module.Connection.Repo),
-		Client:  client,
-	})
+	return errors.Join(errs...)
 }
@@ -0,0 +1,68 @@
+package module
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/leg100/otf/internal/connections"
+	"github.com/leg100/otf/internal/vcs"
+	"github.com/leg100/otf/internal/vcsprovider"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublisher_ContinueAfterError(t *testing.T) {
+	svc := &fakePublisherService{
+		modules: []*Module{
+			{ID: "mod-unconnected"},
+			{ID: "mod-broken", Connection: &connections.Connection{}},
+			{ID: "mod-ok", Connection: &connections.Connection{}},
+		},
+		fail: "mod-broken",
+	}
+	p := &publisher{
+		Logger:             logr.Discard(),
+		ModuleService:      svc,
+		VCSProviderService: &fakePublisherVCSProviderService{},
+	}
+	err := p.handleWithError(logr.Discard(), vcs.Event{
+		EventHeader: vcs.EventHeader{VCSProviderID: "vcs-123"},
+		EventPayload: vcs.EventPayload{
+			Type:   vcs.EventTypeTag,
+			Action: vcs.ActionCreated,
+			Tag:    "v1.0.0",
+		},
+	})
+	assert.ErrorContains(t, err, "mod-unconnected")
+	assert.ErrorContains(t, err, "mod-broken")
+	assert.Equal(t, []string{"mod-broken", "mod-ok"}, svc.published)
+}
+
+type fakePublisherService struct {
+	modules   []*Module
+	fail      string
+	published []string
+
+	Service
+}
+
+func (f *fakePublisherService) ListModulesByConnection(context.Context, string, string) ([]*Module, error) {
+	return f.modules, nil
+}
+
+func (f *fakePublisherService) PublishVersion(_ context.Context, opts PublishVersionOptions) error {
+	f.published = append(f.published, opts.ModuleID)
+	if opts.ModuleID == f.fail {
+		return errors.New("publishing failed")
+	}
+	return nil
+}
+
+type fakePublisherVCSProviderService struct {
+	vcsprovider.Service
+}
+
+func (f *fakePublisherVCSProviderService) GetVCSClient(context.Context, string) (vcs.Client, error) {
+	return nil, nil
+}
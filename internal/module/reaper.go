@@ -0,0 +1,83 @@
+package module
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/sql"
+)
+
+const (
+	// TestReaperLockID guarantees only one test reaper on a cluster is running
+	// at any time.
+	TestReaperLockID int64 = 5577006791947779413
+
+	// DefaultTestTimeout is how long a module version test can run before it
+	// is deemed to have been abandoned by its agent.
+	DefaultTestTimeout = time.Hour
+
+	testReaperInterval = time.Minute
+)
+
+type (
+	// TestReaper periodically requeues module version tests that have been
+	// claimed by an agent but have not finished within the timeout, e.g.
+	// because the agent was terminated, so that another agent can test the
+	// version. Otherwise the version would remain unpublished indefinitely.
+	TestReaper struct {
+		logr.Logger
+
+		db      *pgdb
+		timeout time.Duration
+	}
+
+	TestReaperOptions struct {
+		logr.Logger
+		*sql.DB
+
+		// Timeout is how long a test can run before it is requeued.
+		Timeout time.Duration
+	}
+)
+
+func NewTestReaper(opts TestReaperOptions) *TestReaper {
+	reaper := &TestReaper{
+		Logger:  opts.Logger.WithValues("component", "module-test-reaper"),
+		db:      &pgdb{opts.DB},
+		timeout: opts.Timeout,
+	}
+	if reaper.timeout == 0 {
+		reaper.timeout = DefaultTestTimeout
+	}
+	return reaper
+}
+
+// Start the reaper, requeuing stale tests upon starting and then periodically.
+// Should be started in a go-routine.
+func (r *TestReaper) Start(ctx context.Context) error {
+	ticker := time.NewTicker(testReaperInterval)
+	defer ticker.Stop()
+
+	for {
+		r.reap(ctx)
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (r *TestReaper) reap(ctx context.Context) {
+	before := internal.CurrentTimestamp(nil).Add(-r.timeout)
+	ids, err := r.db.requeueStaleTests(ctx, before)
+	if err != nil {
+		r.Error(err, "requeuing stale module tests")
+		return
+	}
+	for _, id := range ids {
+		r.V(0).Info("requeued stale module test", "module_version_id", id)
+	}
+}
@@ -2,12 +2,14 @@ package module
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/go-logr/logr"
 	"github.com/gorilla/mux"
 	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/configversion"
 	"github.com/leg100/otf/internal/connections"
 	"github.com/leg100/otf/internal/http/html"
 	"github.com/leg100/otf/internal/organization"
@@ -16,6 +18,7 @@ import (
 	"github.com/leg100/otf/internal/semver"
 	"github.com/leg100/otf/internal/sql"
 	"github.com/leg100/otf/internal/sql/pggen"
+	"github.com/leg100/otf/internal/tfeapi"
 	"github.com/leg100/otf/internal/vcs"
 	"github.com/leg100/otf/internal/vcsprovider"
 	"github.com/leg100/surl"
@@ -33,13 +36,45 @@ type (
 		ListModules(context.Context, ListModulesOptions) ([]*Module, error)
 		GetModule(ctx context.Context, opts GetModuleOptions) (*Module, error)
 		GetModuleByID(ctx context.Context, id string) (*Module, error)
-		GetModuleByConnection(ctx context.Context, vcsProviderID, repoPath string) (*Module, error)
+		// ListModulesByConnection lists the modules connected to a repository.
+		ListModulesByConnection(ctx context.Context, vcsProviderID, repoPath string) ([]*Module, error)
 		DeleteModule(ctx context.Context, id string) (*Module, error)
 		GetModuleInfo(ctx context.Context, versionID string) (*TerraformModule, error)
+		// ListModuleUsages lists the workspaces using versions of a module.
+		ListModuleUsages(ctx context.Context, moduleID string) ([]*ModuleUsage, error)
 
 		CreateVersion(context.Context, CreateModuleVersionOptions) (*ModuleVersion, error)
+		// UploadVersion uploads the tarball for a version of a module that is
+		// not connected to a VCS repository.
+		UploadVersion(ctx context.Context, versionID string, tarball []byte) error
+
+		// DeprecateVersion deprecates a module version, warning users of the
+		// version that they should use another version.
+		DeprecateVersion(ctx context.Context, opts DeprecateModuleVersionOptions) (*ModuleVersion, error)
+		UndeprecateVersion(ctx context.Context, versionID string) (*ModuleVersion, error)
+		// YankVersion hides a module version from the list of versions
+		// available in the registry, which prevents terraform from installing
+		// the version.
+		YankVersion(ctx context.Context, versionID string) (*ModuleVersion, error)
+		UnyankVersion(ctx context.Context, versionID string) (*ModuleVersion, error)
+
+		// ListModuleTests lists the results of testing a module's versions.
+		ListModuleTests(ctx context.Context, moduleID string) ([]*ModuleVersionTest, error)
+		// ListPendingModuleTests lists the module versions, across all
+		// organizations, that are awaiting an agent to test them.
+		ListPendingModuleTests(ctx context.Context) ([]*PendingModuleTest, error)
+		// StartModuleTest claims the test of a module version for an agent,
+		// returning the version's tarball. ErrTestAlreadyStarted is returned
+		// if the test has already been claimed.
+		StartModuleTest(ctx context.Context, versionID string) ([]byte, error)
+		// PutModuleTestOutput appends output to the test of a module version.
+		PutModuleTestOutput(ctx context.Context, versionID string, output []byte) error
+		// FinishModuleTest finishes the test of a module version, making the
+		// version available only if the test passed.
+		FinishModuleTest(ctx context.Context, versionID string, passed bool) (*ModuleVersion, error)
 
 		uploadVersion(ctx context.Context, versionID string, tarball []byte) error
+		uploadTarball(ctx context.Context, versionID string, tarball []byte) error
 		downloadVersion(ctx context.Context, versionID string) ([]byte, error)
 
 		updateModuleStatus(ctx context.Context, module *Module, status ModuleStatus) (*Module, error)
@@ -48,14 +83,17 @@ type (
 	service struct {
 		vcsprovider.VCSProviderService
 		connections.ConnectionService
+		internal.HostnameService
 		logr.Logger
 		*publisher
 
 		db *pgdb
 
 		organization internal.Authorizer
+		site         internal.Authorizer
 
 		api *api
+		tfe *tfe
 		web *webHandlers
 	}
 
@@ -67,8 +105,10 @@ type (
 		vcsprovider.VCSProviderService
 		*surl.Signer
 		html.Renderer
+		*tfeapi.Responder
 		connections.ConnectionService
 		repohooks.RepohookService
+		configversion.ConfigurationVersionService
 
 		VCSEventSubscriber vcs.Subscriber
 	}
@@ -79,13 +119,20 @@ func NewService(opts Options) *service {
 		Logger:             opts.Logger,
 		VCSProviderService: opts.VCSProviderService,
 		ConnectionService:  opts.ConnectionService,
+		HostnameService:    opts.HostnameService,
 		organization:       &organization.Authorizer{Logger: opts.Logger},
+		site:               &internal.SiteAuthorizer{Logger: opts.Logger},
 		db:                 &pgdb{opts.DB},
 	}
 	svc.api = &api{
 		svc:    &svc,
 		Signer: opts.Signer,
 	}
+	svc.tfe = &tfe{
+		svc:       &svc,
+		Signer:    opts.Signer,
+		Responder: opts.Responder,
+	}
 	svc.web = &webHandlers{
 		HostnameService:    opts.HostnameService,
 		Renderer:           opts.Renderer,
@@ -99,12 +146,16 @@ func NewService(opts Options) *service {
 	}
 	// Subscribe module publisher to incoming vcs events
 	opts.VCSEventSubscriber.Subscribe(publisher.handle)
+	// Record the module versions used by workspaces from their uploaded
+	// configuration
+	opts.ConfigurationVersionService.AfterUploadConfig(svc.recordUsage)
 
 	return &svc
 }
 
 func (s *service) AddHandlers(r *mux.Router) {
 	s.api.addHandlers(r)
+	s.tfe.addHandlers(r)
 	s.web.addHandlers(r)
 }
 
@@ -132,7 +183,24 @@ func (s *service) PublishModule(ctx context.Context, opts PublishOptions) (*Modu
 }
 
 func (s *service) publishModule(ctx context.Context, organization string, opts PublishOptions) (*Module, error) {
-	name, provider, err := opts.Repo.Split()
+	name, provider := opts.Name, opts.Provider
+	if name == "" || provider == "" {
+		// derive name and/or provider from repo name
+		repoName, repoProvider, err := opts.Repo.Split()
+		if err != nil {
+			return nil, err
+		}
+		if name == "" {
+			name = repoName
+		}
+		if provider == "" {
+			provider = repoProvider
+		}
+	}
+	if !reModuleName.MatchString(name) || !reModuleProvider.MatchString(provider) {
+		return nil, internal.ErrInvalidName
+	}
+	modulePath, err := cleanModulePath(opts.Path)
 	if err != nil {
 		return nil, err
 	}
@@ -141,6 +209,9 @@ func (s *service) publishModule(ctx context.Context, organization string, opts P
 		Name:         name,
 		Provider:     provider,
 		Organization: organization,
+		Path:         modulePath,
+		TagPrefix:    opts.TagPrefix,
+		RunTests:     opts.RunTests,
 	})
 
 	// persist module to db and connect to repository
@@ -167,7 +238,8 @@ func (s *service) publishModule(ctx context.Context, organization string, opts P
 			return err
 		}
 		tags, err = client.ListTags(ctx, vcs.ListTagsOptions{
-			Repo: string(opts.Repo),
+			Repo:   string(opts.Repo),
+			Prefix: mod.TagPrefix,
 		})
 		if err != nil {
 			return err
@@ -181,22 +253,23 @@ func (s *service) publishModule(ctx context.Context, organization string, opts P
 		return s.updateModuleStatus(ctx, mod, ModuleStatusNoVersionTags)
 	}
 	for _, tag := range tags {
-		// tags/<version> -> <version>
-		_, version, found := strings.Cut(tag, "/")
+		// tags/<tag> -> <tag>
+		_, name, found := strings.Cut(tag, "/")
 		if !found {
 			return nil, fmt.Errorf("malformed git ref: %s", tag)
 		}
-		// skip tags that are not semantic versions
-		if !semver.IsValid(version) {
+		// skip tags that are not semantic versions of this module
+		version, ok := mod.versionFromTag(name)
+		if !ok {
 			continue
 		}
 		err := s.PublishVersion(ctx, PublishVersionOptions{
 			ModuleID: mod.ID,
-			// strip off v prefix if it has one
-			Version: strings.TrimPrefix(version, "v"),
-			Ref:     tag,
-			Repo:    opts.Repo,
-			Client:  client,
+			Version:  version,
+			Ref:      tag,
+			Repo:     opts.Repo,
+			Path:     mod.Path,
+			Client:   client,
 		})
 		if err != nil {
 			return nil, err
@@ -231,6 +304,17 @@ func (s *service) PublishVersion(ctx context.Context, opts PublishVersionOptions
 			Error:  err.Error(),
 		})
 	}
+	if opts.Path != "" {
+		// only publish the subdirectory containing the module
+		tarball, err = extractSubdirectory(tarball, opts.Path)
+		if err != nil {
+			return s.db.updateModuleVersionStatus(ctx, UpdateModuleVersionStatusOptions{
+				ID:     modver.ID,
+				Status: ModuleVersionStatusRegIngressFailed,
+				Error:  err.Error(),
+			})
+		}
+	}
 
 	return s.uploadVersion(ctx, modver.ID, tarball)
 }
@@ -241,6 +325,9 @@ func (s *service) CreateModule(ctx context.Context, opts CreateOptions) (*Module
 		return nil, err
 	}
 
+	if !reModuleName.MatchString(opts.Name) || !reModuleProvider.MatchString(opts.Provider) {
+		return nil, internal.ErrInvalidName
+	}
 	module := newModule(opts)
 
 	if err := s.db.createModule(ctx, module); err != nil {
@@ -298,8 +385,8 @@ func (s *service) GetModuleByID(ctx context.Context, id string) (*Module, error)
 	return module, nil
 }
 
-func (s *service) GetModuleByConnection(ctx context.Context, vcsProviderID, repoPath string) (*Module, error) {
-	return s.db.getModuleByConnection(ctx, vcsProviderID, repoPath)
+func (s *service) ListModulesByConnection(ctx context.Context, vcsProviderID, repoPath string) ([]*Module, error) {
+	return s.db.listModulesByConnection(ctx, vcsProviderID, repoPath)
 }
 
 func (s *service) DeleteModule(ctx context.Context, id string) (*Module, error) {
@@ -346,6 +433,9 @@ func (s *service) CreateVersion(ctx context.Context, opts CreateModuleVersionOpt
 		return nil, err
 	}
 
+	if !semver.IsValid(opts.Version) {
+		return nil, ErrInvalidModuleVersion
+	}
 	modver := newModuleVersion(opts)
 
 	if err := s.db.createModuleVersion(ctx, modver); err != nil {
@@ -356,6 +446,77 @@ func (s *service) CreateVersion(ctx context.Context, opts CreateModuleVersionOpt
 	return modver, nil
 }
 
+func (s *service) DeprecateVersion(ctx context.Context, opts DeprecateModuleVersionOptions) (*ModuleVersion, error) {
+	if opts.Reason == "" {
+		return nil, ErrDeprecationReason
+	}
+	return s.updateVersion(ctx, opts.ID, "deprecated", func(ctx context.Context, module *Module, modver *ModuleVersion) error {
+		if opts.Replacement != "" {
+			replacement := module.Version(opts.Replacement)
+			if replacement == nil || replacement.ID == modver.ID || replacement.Status != ModuleVersionStatusOK || replacement.Yanked {
+				return ErrInvalidReplacement
+			}
+		}
+		return s.db.updateDeprecation(ctx, modver.ID, &ModuleVersionDeprecation{
+			Reason:      opts.Reason,
+			Replacement: opts.Replacement,
+		})
+	})
+}
+
+func (s *service) UndeprecateVersion(ctx context.Context, versionID string) (*ModuleVersion, error) {
+	return s.updateVersion(ctx, versionID, "undeprecated", func(ctx context.Context, _ *Module, modver *ModuleVersion) error {
+		return s.db.updateDeprecation(ctx, modver.ID, nil)
+	})
+}
+
+func (s *service) YankVersion(ctx context.Context, versionID string) (*ModuleVersion, error) {
+	return s.updateVersion(ctx, versionID, "yanked", func(ctx context.Context, _ *Module, modver *ModuleVersion) error {
+		return s.db.updateYanked(ctx, modver.ID, true)
+	})
+}
+
+func (s *service) UnyankVersion(ctx context.Context, versionID string) (*ModuleVersion, error) {
+	return s.updateVersion(ctx, versionID, "unyanked", func(ctx context.Context, _ *Module, modver *ModuleVersion) error {
+		return s.db.updateYanked(ctx, modver.ID, false)
+	})
+}
+
+// updateVersion updates a module version using the given func, returning the
+// updated version.
+func (s *service) updateVersion(ctx context.Context, versionID, action string, fn func(context.Context, *Module, *ModuleVersion) error) (*ModuleVersion, error) {
+	module, err := s.db.getModuleByVersionID(ctx, versionID)
+	if err != nil {
+		s.Error(err, "retrieving module", "module_version_id", versionID)
+		return nil, err
+	}
+
+	subject, err := s.organization.CanAccess(ctx, rbac.UpdateModuleAction, module.Organization)
+	if err != nil {
+		return nil, err
+	}
+
+	modver := module.versionByID(versionID)
+	if modver == nil {
+		return nil, internal.ErrResourceNotFound
+	}
+	if err := fn(ctx, module, modver); err != nil {
+		s.Error(err, "updating module version", "subject", subject, "module_version", modver)
+		return nil, err
+	}
+	// re-retrieve module to get updated version
+	module, err = s.db.getModuleByID(ctx, module.ID)
+	if err != nil {
+		return nil, err
+	}
+	modver = module.versionByID(versionID)
+	if modver == nil {
+		return nil, internal.ErrResourceNotFound
+	}
+	s.V(0).Info(action+" module version", "subject", subject, "module_version", modver)
+	return modver, nil
+}
+
 func (s *service) GetModuleInfo(ctx context.Context, versionID string) (*TerraformModule, error) {
 	tarball, err := s.db.getTarball(ctx, versionID)
 	if err != nil {
@@ -364,6 +525,135 @@ func (s *service) GetModuleInfo(ctx context.Context, versionID string) (*Terrafo
 	return unmarshalTerraformModule(tarball)
 }
 
+func (s *service) ListModuleUsages(ctx context.Context, moduleID string) ([]*ModuleUsage, error) {
+	module, err := s.db.getModuleByID(ctx, moduleID)
+	if err != nil {
+		s.Error(err, "retrieving module", "id", moduleID)
+		return nil, err
+	}
+
+	subject, err := s.organization.CanAccess(ctx, rbac.GetModuleAction, module.Organization)
+	if err != nil {
+		return nil, err
+	}
+
+	usages, err := s.db.listUsages(ctx, moduleID)
+	if err != nil {
+		s.Error(err, "listing module usages", "subject", subject, "module", module)
+		return nil, err
+	}
+	s.V(9).Info("listed module usages", "subject", subject, "module", module)
+	return usages, nil
+}
+
+func (s *service) ListModuleTests(ctx context.Context, moduleID string) ([]*ModuleVersionTest, error) {
+	module, err := s.db.getModuleByID(ctx, moduleID)
+	if err != nil {
+		s.Error(err, "retrieving module", "id", moduleID)
+		return nil, err
+	}
+
+	subject, err := s.organization.CanAccess(ctx, rbac.GetModuleAction, module.Organization)
+	if err != nil {
+		return nil, err
+	}
+
+	tests, err := s.db.listTests(ctx, moduleID)
+	if err != nil {
+		s.Error(err, "listing module tests", "subject", subject, "module", module)
+		return nil, err
+	}
+	s.V(9).Info("listed module tests", "subject", subject, "module", module)
+	return tests, nil
+}
+
+func (s *service) ListPendingModuleTests(ctx context.Context) ([]*PendingModuleTest, error) {
+	subject, err := s.site.CanAccess(ctx, rbac.TestModuleVersionAction, "")
+	if err != nil {
+		return nil, err
+	}
+
+	pending, err := s.db.listPendingTests(ctx)
+	if err != nil {
+		s.Error(err, "listing pending module tests", "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("listed pending module tests", "subject", subject, "total", len(pending))
+	return pending, nil
+}
+
+func (s *service) StartModuleTest(ctx context.Context, versionID string) ([]byte, error) {
+	subject, err := s.canTestVersion(ctx, versionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.db.startTest(ctx, versionID); err != nil {
+		if !errors.Is(err, ErrTestAlreadyStarted) {
+			s.Error(err, "starting module test", "subject", subject, "module_version_id", versionID)
+		}
+		return nil, err
+	}
+	tarball, err := s.db.getTarball(ctx, versionID)
+	if err != nil {
+		s.Error(err, "retrieving module tarball", "subject", subject, "module_version_id", versionID)
+		return nil, err
+	}
+	s.V(0).Info("started module test", "subject", subject, "module_version_id", versionID)
+	return tarball, nil
+}
+
+func (s *service) PutModuleTestOutput(ctx context.Context, versionID string, output []byte) error {
+	subject, err := s.canTestVersion(ctx, versionID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.db.appendTestOutput(ctx, versionID, output); err != nil {
+		s.Error(err, "writing module test output", "subject", subject, "module_version_id", versionID)
+		return err
+	}
+	s.V(9).Info("written module test output", "subject", subject, "module_version_id", versionID, "bytes", len(output))
+	return nil
+}
+
+func (s *service) FinishModuleTest(ctx context.Context, versionID string, passed bool) (*ModuleVersion, error) {
+	subject, err := s.canTestVersion(ctx, versionID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := ModuleVersionStatusTestFailed
+	if passed {
+		status = ModuleVersionStatusOK
+	}
+	if err := s.db.finishTest(ctx, versionID, status); err != nil {
+		s.Error(err, "finishing module test", "subject", subject, "module_version_id", versionID)
+		return nil, err
+	}
+	module, err := s.db.getModuleByVersionID(ctx, versionID)
+	if err != nil {
+		return nil, err
+	}
+	modver := module.versionByID(versionID)
+	if modver == nil {
+		return nil, internal.ErrResourceNotFound
+	}
+	s.V(0).Info("finished module test", "subject", subject, "module_version", modver)
+	return modver, nil
+}
+
+// canTestVersion authorizes the subject in the context to test a module
+// version.
+func (s *service) canTestVersion(ctx context.Context, versionID string) (internal.Subject, error) {
+	module, err := s.db.getModuleByVersionID(ctx, versionID)
+	if err != nil {
+		s.Error(err, "retrieving module", "module_version_id", versionID)
+		return nil, err
+	}
+	return s.organization.CanAccess(ctx, rbac.TestModuleVersionAction, module.Organization)
+}
+
 func (s *service) updateModuleStatus(ctx context.Context, mod *Module, status ModuleStatus) (*Module, error) {
 	mod.Status = status
 
@@ -391,6 +681,13 @@ func (s *service) uploadVersion(ctx context.Context, versionID string, tarball [
 		})
 	}
 
+	// a module with tests enabled only makes a version available once an
+	// agent has successfully tested it.
+	status := ModuleVersionStatusOK
+	if module.RunTests {
+		status = ModuleVersionStatusTestPending
+	}
+
 	// save tarball, set status, and make it the latest version
 	err = s.db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
 		if err := s.db.saveTarball(ctx, versionID, tarball); err != nil {
@@ -398,7 +695,7 @@ func (s *service) uploadVersion(ctx context.Context, versionID string, tarball [
 		}
 		err := s.db.updateModuleVersionStatus(ctx, UpdateModuleVersionStatusOptions{
 			ID:     versionID,
-			Status: ModuleVersionStatusOK,
+			Status: status,
 		})
 		if err != nil {
 			return err
@@ -416,7 +713,50 @@ func (s *service) uploadVersion(ctx context.Context, versionID string, tarball [
 		return err
 	}
 
-	s.V(0).Info("uploaded module version", "module_version", versionID)
+	s.V(0).Info("uploaded module version", "module_version", versionID, "status", status)
+	return nil
+}
+
+func (s *service) UploadVersion(ctx context.Context, versionID string, tarball []byte) error {
+	module, err := s.db.getModuleByVersionID(ctx, versionID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.organization.CanAccess(ctx, rbac.CreateModuleVersionAction, module.Organization); err != nil {
+		return err
+	}
+
+	return s.uploadTarball(ctx, versionID, tarball)
+}
+
+// uploadTarball uploads the tarball for a version of a module that is not
+// connected to a VCS repository. Unlike uploadVersion, an invalid tarball is
+// reported back to the caller as well as being recorded on the version.
+//
+// uploadTarball should be accessed via signed URL or after authorization.
+func (s *service) uploadTarball(ctx context.Context, versionID string, tarball []byte) error {
+	module, err := s.db.getModuleByVersionID(ctx, versionID)
+	if err != nil {
+		return err
+	}
+	if module.Connection != nil {
+		return ErrModuleConnected
+	}
+	if err := s.uploadVersion(ctx, versionID, tarball); err != nil {
+		return err
+	}
+	// uploadVersion records an invalid tarball on the version rather than
+	// returning an error, so check the version's status.
+	module, err = s.db.getModuleByID(ctx, module.ID)
+	if err != nil {
+		return err
+	}
+	for _, ver := range module.Versions {
+		if ver.ID == versionID && ver.Status == ModuleVersionStatusRegIngressFailed {
+			return fmt.Errorf("%w: %s", ErrInvalidModuleTarball, ver.StatusError)
+		}
+	}
 	return nil
 }
 
@@ -427,6 +767,10 @@ func (s *service) downloadVersion(ctx context.Context, versionID string) ([]byte
 		s.Error(err, "downloading module", "module_version_id", versionID)
 		return nil, err
 	}
+	if err := s.db.incrementDownloads(ctx, versionID); err != nil {
+		// a failure to count the download should not fail the download
+		s.Error(err, "recording module download", "module_version_id", versionID)
+	}
 	s.V(9).Info("downloaded module", "module_version_id", versionID)
 	return tarball, nil
 }
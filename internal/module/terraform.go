@@ -5,24 +5,48 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"path/filepath"
 
 	"github.com/hashicorp/terraform-config-inspect/tfconfig"
 	"github.com/leg100/otf/internal"
 	"github.com/pkg/errors"
 )
 
+const (
+	// submodulesDir is the directory in a module containing its submodules
+	submodulesDir = "modules"
+	// examplesDir is the directory in a module containing its examples
+	examplesDir = "examples"
+)
+
 // TerraformModule is a module of terraform configuration
 type TerraformModule struct {
 	*tfconfig.Module
 
+	// Submodules and Examples are the modules found in the submodules and
+	// examples directories respectively.
+	Submodules []*TerraformSubmodule
+	Examples   []*TerraformSubmodule
+
 	readme []byte
 }
 
+// TerraformSubmodule is a module of terraform configuration nested within a
+// module.
+type TerraformSubmodule struct {
+	*tfconfig.Module
+
+	// Name is the name of the directory containing the submodule
+	Name string
+}
+
 func unmarshalTerraformModule(tarball []byte) (*TerraformModule, error) {
 	dir, err := os.MkdirTemp("", "")
 	if err != nil {
 		return nil, errors.Wrap(err, "creating temporary directory")
 	}
+	defer os.RemoveAll(dir)
+
 	if err := internal.Unpack(bytes.NewReader(tarball), dir); err != nil {
 		return nil, errors.Wrap(err, "extracting tarball")
 	}
@@ -40,6 +64,59 @@ func unmarshalTerraformModule(tarball []byte) (*TerraformModule, error) {
 		tfmod.readme = readme
 	}
 
+	if tfmod.Submodules, err = loadSubmodules(filepath.Join(dir, submodulesDir)); err != nil {
+		return nil, err
+	}
+	if tfmod.Examples, err = loadSubmodules(filepath.Join(dir, examplesDir)); err != nil {
+		return nil, err
+	}
+
 	// valid module
 	return tfmod, nil
 }
+
+// loadSubmodules loads each module found in a subdirectory of parent.
+// Subdirectories without valid terraform configuration are skipped.
+func loadSubmodules(parent string) ([]*TerraformSubmodule, error) {
+	entries, err := os.ReadDir(parent)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var submodules []*TerraformSubmodule
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		dir := filepath.Join(parent, entry.Name())
+		if !tfconfig.IsModuleDir(dir) {
+			continue
+		}
+		mod, diags := tfconfig.LoadModule(dir)
+		if diags.HasErrors() {
+			continue
+		}
+		submodules = append(submodules, &TerraformSubmodule{Module: mod, Name: entry.Name()})
+	}
+	return submodules, nil
+}
+
+// extractSubdirectory extracts a subdirectory from a tarball into a new
+// tarball.
+func extractSubdirectory(tarball []byte, subdir string) ([]byte, error) {
+	dir, err := os.MkdirTemp("", "")
+	if err != nil {
+		return nil, errors.Wrap(err, "creating temporary directory")
+	}
+	defer os.RemoveAll(dir)
+
+	if err := internal.Unpack(bytes.NewReader(tarball), dir); err != nil {
+		return nil, errors.Wrap(err, "extracting tarball")
+	}
+	src := filepath.Join(dir, filepath.FromSlash(subdir))
+	if info, err := os.Stat(src); err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("module path not found in repository: %s", subdir)
+	}
+	return internal.Pack(src)
+}
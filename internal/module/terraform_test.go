@@ -0,0 +1,65 @@
+package module
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmarshalTerraformModule_Submodules(t *testing.T) {
+	tarball := newTestMonorepoTarball(t)
+
+	// the root of the repo is itself a module
+	tfmod, err := unmarshalTerraformModule(tarball)
+	require.NoError(t, err)
+	assert.Empty(t, tfmod.Submodules)
+	assert.Empty(t, tfmod.Examples)
+
+	vpc, err := extractSubdirectory(tarball, "vpc")
+	require.NoError(t, err)
+	tfmod, err = unmarshalTerraformModule(vpc)
+	require.NoError(t, err)
+	assert.Contains(t, tfmod.Variables, "cidr")
+
+	if assert.Len(t, tfmod.Submodules, 1) {
+		assert.Equal(t, "subnet", tfmod.Submodules[0].Name)
+		assert.Contains(t, tfmod.Submodules[0].Variables, "subnet_cidr")
+	}
+	if assert.Len(t, tfmod.Examples, 1) {
+		assert.Equal(t, "basic", tfmod.Examples[0].Name)
+		assert.Contains(t, tfmod.Examples[0].Outputs, "vpc_id")
+	}
+}
+
+func TestExtractSubdirectory_NotFound(t *testing.T) {
+	_, err := extractSubdirectory(newTestMonorepoTarball(t), "s3")
+	assert.Error(t, err)
+}
+
+// newTestMonorepoTarball creates a tarball of a repository containing a vpc
+// module, along with its submodules and examples.
+func newTestMonorepoTarball(t *testing.T) []byte {
+	t.Helper()
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"main.tf":                          `resource "null_resource" "root" {}`,
+		"vpc/main.tf":                      `variable "cidr" {}`,
+		"vpc/modules/subnet/main.tf":       `variable "subnet_cidr" {}`,
+		"vpc/modules/README.md":            `not a module`,
+		"vpc/examples/basic/main.tf":       `output "vpc_id" { value = "vpc-123" }`,
+		"vpc/examples/not-a-module/foo.md": `not a module`,
+	}
+	for path, content := range files {
+		path = filepath.Join(dir, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	tarball, err := internal.Pack(dir)
+	require.NoError(t, err)
+	return tarball
+}
@@ -0,0 +1,253 @@
+package module
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/DataDog/jsonapi"
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	otfhttp "github.com/leg100/otf/internal/http"
+	"github.com/leg100/otf/internal/http/decode"
+	"github.com/leg100/otf/internal/tfeapi"
+	"github.com/leg100/otf/internal/tfeapi/types"
+	"github.com/leg100/surl"
+)
+
+const (
+	// registryName is the name TFE gives to an organization's private
+	// registry.
+	registryName = "private"
+	// MaxTarballSize is the maximum permitted size in bytes of an uploaded
+	// module version tarball.
+	MaxTarballSize = 100 * 1024 * 1024
+)
+
+// tfe implements the TFE API for publishing modules without a VCS repository:
+//
+// https://developer.hashicorp.com/terraform/cloud-docs/api-docs/private-registry/modules
+type tfe struct {
+	*surl.Signer
+	*tfeapi.Responder
+
+	svc Service
+}
+
+func (a *tfe) addHandlers(r *mux.Router) {
+	signed := r.PathPrefix("/signed/{signature.expiry}").Subrouter()
+	signed.Use(internal.VerifySignedURL(a.Signer))
+	signed.HandleFunc("/modules/upload/{module_version_id}", a.uploadModuleVersion()).Methods("PUT", "POST")
+
+	r = r.PathPrefix(tfeapi.APIPrefixV2).Subrouter()
+	r.HandleFunc("/organizations/{organization_name}/registry-modules", a.createModule).Methods("POST")
+	r.HandleFunc("/organizations/{organization_name}/registry-modules/private/{namespace}/{name}/{provider}", a.getModule).Methods("GET")
+	r.HandleFunc("/organizations/{organization_name}/registry-modules/private/{namespace}/{name}/{provider}/versions", a.createModuleVersion).Methods("POST")
+}
+
+func (a *tfe) createModule(w http.ResponseWriter, r *http.Request) {
+	organization, err := decode.Param("organization_name", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var params types.RegistryModuleCreateOptions
+	if err := tfeapi.Unmarshal(r.Body, &params); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	if params.Name == nil {
+		tfeapi.Error(w, &internal.MissingParameterError{Parameter: "name"})
+		return
+	}
+	if params.Provider == nil {
+		tfeapi.Error(w, &internal.MissingParameterError{Parameter: "provider"})
+		return
+	}
+
+	mod, err := a.svc.CreateModule(r.Context(), CreateOptions{
+		Name:         *params.Name,
+		Provider:     *params.Provider,
+		Organization: organization,
+	})
+	if err != nil {
+		tfeapi.Error(w, apiError(err))
+		return
+	}
+	a.Respond(w, r, a.convert(mod), http.StatusCreated)
+}
+
+func (a *tfe) getModule(w http.ResponseWriter, r *http.Request) {
+	params, err := decodeModuleParams(r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	mod, err := a.svc.GetModule(r.Context(), params)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.Respond(w, r, a.convert(mod), http.StatusOK)
+}
+
+func (a *tfe) createModuleVersion(w http.ResponseWriter, r *http.Request) {
+	params, err := decodeModuleParams(r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var opts types.RegistryModuleVersionCreateOptions
+	if err := tfeapi.Unmarshal(r.Body, &opts); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	if opts.Version == nil {
+		tfeapi.Error(w, &internal.MissingParameterError{Parameter: "version"})
+		return
+	}
+
+	mod, err := a.svc.GetModule(r.Context(), params)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	if mod.Connection != nil {
+		tfeapi.Error(w, apiError(ErrModuleConnected))
+		return
+	}
+	modver, err := a.svc.CreateVersion(r.Context(), CreateModuleVersionOptions{
+		ModuleID: mod.ID,
+		Version:  *opts.Version,
+	})
+	if err != nil {
+		tfeapi.Error(w, apiError(err))
+		return
+	}
+
+	uploadURL, err := a.Sign("/modules/upload/"+modver.ID, time.Hour)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	// clients expect an absolute URL
+	uploadURL = otfhttp.Absolute(r, uploadURL)
+
+	// The upload URL is provided as a link on the resource object, which the
+	// jsonapi marshaler does not support, so add it to the marshaled document.
+	b, err := jsonapi.Marshal(&types.RegistryModuleVersion{
+		ID:             modver.ID,
+		Source:         "tfe-api",
+		Status:         string(modver.Status),
+		Version:        modver.Version,
+		CreatedAt:      modver.CreatedAt,
+		UpdatedAt:      modver.UpdatedAt,
+		RegistryModule: &types.RegistryModule{ID: mod.ID},
+	})
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(b, &doc); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	doc["data"].(map[string]any)["links"] = map[string]string{"upload": uploadURL}
+
+	w.Header().Set("Content-type", "application/vnd.api+json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(doc)
+}
+
+func (a *tfe) uploadModuleVersion() http.HandlerFunc {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := decode.Param("module_version_id", r)
+		if err != nil {
+			tfeapi.Error(w, err)
+			return
+		}
+		buf := new(bytes.Buffer)
+		if _, err := io.Copy(buf, r.Body); err != nil {
+			maxBytesError := &http.MaxBytesError{}
+			if errors.As(err, &maxBytesError) {
+				tfeapi.Error(w, &internal.HTTPError{
+					Code:    422,
+					Message: fmt.Sprintf("module tarball exceeds maximum size (%d bytes)", MaxTarballSize),
+				})
+			} else {
+				tfeapi.Error(w, err)
+			}
+			return
+		}
+		if err := a.svc.uploadTarball(r.Context(), id, buf.Bytes()); err != nil {
+			tfeapi.Error(w, apiError(err))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	return http.MaxBytesHandler(h, MaxTarballSize).ServeHTTP
+}
+
+func (a *tfe) convert(from *Module) *types.RegistryModule {
+	to := &types.RegistryModule{
+		ID:              from.ID,
+		Name:            from.Name,
+		Provider:        from.Provider,
+		RegistryName:    registryName,
+		Namespace:       from.Organization,
+		Status:          string(from.Status),
+		VersionStatuses: []types.RegistryModuleVersionStatuses{},
+		CreatedAt:       from.CreatedAt,
+		UpdatedAt:       from.UpdatedAt,
+		Organization:    &types.Organization{Name: from.Organization},
+	}
+	for _, ver := range from.Versions {
+		to.VersionStatuses = append(to.VersionStatuses, types.RegistryModuleVersionStatuses{
+			Version: ver.Version,
+			Status:  string(ver.Status),
+			Error:   ver.StatusError,
+		})
+	}
+	return to
+}
+
+func decodeModuleParams(r *http.Request) (GetModuleOptions, error) {
+	var params struct {
+		Organization string `schema:"organization_name,required"`
+		Namespace    string `schema:"namespace,required"`
+		Name         string `schema:"name,required"`
+		Provider     string `schema:"provider,required"`
+	}
+	if err := decode.Route(&params, r); err != nil {
+		return GetModuleOptions{}, err
+	}
+	// the namespace of a module in a private registry is always its
+	// organization
+	if params.Namespace != params.Organization {
+		return GetModuleOptions{}, internal.ErrResourceNotFound
+	}
+	return GetModuleOptions{
+		Organization: params.Organization,
+		Name:         params.Name,
+		Provider:     params.Provider,
+	}, nil
+}
+
+// apiError converts validation errors into an error reported to API clients
+// with a 422 status code.
+func apiError(err error) error {
+	if errors.Is(err, internal.ErrInvalidName) ||
+		errors.Is(err, ErrInvalidModuleVersion) ||
+		errors.Is(err, ErrInvalidModuleTarball) ||
+		errors.Is(err, ErrModuleConnected) ||
+		errors.Is(err, ErrDeprecationReason) ||
+		errors.Is(err, ErrInvalidReplacement) {
+		return &internal.HTTPError{Code: http.StatusUnprocessableEntity, Message: err.Error()}
+	}
+	return err
+}
@@ -0,0 +1,59 @@
+package module
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/connections"
+	"github.com/leg100/otf/internal/tfeapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTFE_CreateModuleVersion(t *testing.T) {
+	svc := &fakeCLIService{mod: &Module{ID: "mod-123", Organization: "acme", Name: "vpc", Provider: "aws"}}
+	r := mux.NewRouter()
+	(&tfe{
+		Signer:    internal.NewSigner([]byte("secret")),
+		Responder: tfeapi.NewResponder(),
+		svc:       svc,
+	}).addHandlers(r)
+
+	body := `{"data":{"type":"registry-module-versions","attributes":{"version":"1.0.0"}}}`
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("POST", "/api/v2/organizations/acme/registry-modules/private/acme/vpc/aws/versions", bytes.NewBufferString(body)))
+	require.Equal(t, 201, w.Code, w.Body.String())
+
+	var got struct {
+		Data struct {
+			Attributes struct {
+				Version string `json:"version"`
+			} `json:"attributes"`
+			Links struct {
+				Upload string `json:"upload"`
+			} `json:"links"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&got))
+	assert.Equal(t, "1.0.0", got.Data.Attributes.Version)
+	assert.Regexp(t, `^http://.*/signed/.*/modules/upload/modver-`, got.Data.Links.Upload)
+}
+
+func TestTFE_CreateModuleVersion_Connected(t *testing.T) {
+	svc := &fakeCLIService{mod: &Module{ID: "mod-123", Connection: &connections.Connection{}}}
+	r := mux.NewRouter()
+	(&tfe{
+		Signer:    internal.NewSigner([]byte("secret")),
+		Responder: tfeapi.NewResponder(),
+		svc:       svc,
+	}).addHandlers(r)
+
+	body := `{"data":{"type":"registry-module-versions","attributes":{"version":"1.0.0"}}}`
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("POST", "/api/v2/organizations/acme/registry-modules/private/acme/vpc/aws/versions", bytes.NewBufferString(body)))
+	assert.Equal(t, 422, w.Code, w.Body.String())
+}
@@ -0,0 +1,177 @@
+package module
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-config-inspect/tfconfig"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/configversion"
+	"github.com/leg100/otf/internal/sql/pggen"
+)
+
+// registryModuleCall is a call in terraform configuration to a module in the
+// registry.
+type registryModuleCall struct {
+	GetModuleOptions
+
+	// Constraint is the version constraint, or an empty string if there is
+	// no constraint.
+	Constraint string
+}
+
+// recordUsage records the module versions used by a workspace from the
+// configuration uploaded to the workspace. Speculative configurations, e.g.
+// those uploaded for pull requests, are ignored. Only modules belonging to the
+// workspace's organization are recorded.
+//
+// Failing to record usage should not fail the upload, so errors are only
+// logged. The upload is carried out within a transaction, and so usage is
+// recorded within a nested transaction, ensuring a failed query does not abort
+// the upload's transaction.
+func (s *service) recordUsage(ctx context.Context, uploaded *configversion.UploadedConfig) error {
+	if uploaded.Speculative {
+		return nil
+	}
+	calls, err := parseRegistryModuleCalls(uploaded.Config, s.Hostname())
+	if err != nil {
+		s.Error(err, "parsing module calls", "configuration_version_id", uploaded.ConfigurationVersionID)
+		return nil
+	}
+	var versionIDs []string
+	err = s.db.Tx(ctx, func(ctx context.Context, _ pggen.Querier) error {
+		organization, err := s.db.getWorkspaceOrganization(ctx, uploaded.WorkspaceID)
+		if err != nil {
+			return err
+		}
+		seen := make(map[string]bool)
+		for _, call := range calls {
+			if call.Organization != organization {
+				continue
+			}
+			mod, err := s.db.getModule(ctx, call.GetModuleOptions)
+			if errors.Is(err, internal.ErrResourceNotFound) {
+				continue
+			} else if err != nil {
+				return err
+			}
+			// the same version may be called more than once
+			if modver := resolveVersion(mod, call.Constraint); modver != nil && !seen[modver.ID] {
+				versionIDs = append(versionIDs, modver.ID)
+				seen[modver.ID] = true
+			}
+		}
+		return s.db.replaceUsages(ctx, uploaded.WorkspaceID, versionIDs)
+	})
+	if err != nil {
+		s.Error(err, "recording module usage", "workspace_id", uploaded.WorkspaceID)
+		return nil
+	}
+	s.V(9).Info("recorded module usage", "workspace_id", uploaded.WorkspaceID, "module_versions", versionIDs)
+	return nil
+}
+
+// resolveVersion returns the greatest installable version of a module that
+// satisfies a version constraint, which is the version terraform would
+// install. Nil is returned if there is no such version.
+func resolveVersion(mod *Module, constraint string) *ModuleVersion {
+	var constraints version.Constraints
+	if constraint != "" {
+		var err error
+		constraints, err = version.NewConstraint(constraint)
+		if err != nil {
+			return nil
+		}
+	}
+	// installable versions are sorted in descending order
+	for _, modver := range mod.InstallableVersions() {
+		v, err := version.NewVersion(modver.Version)
+		if err != nil {
+			continue
+		}
+		if constraints.Check(v) {
+			return &modver
+		}
+	}
+	return nil
+}
+
+// parseRegistryModuleCalls parses the calls in a configuration tarball to
+// modules in the registry with the given hostname. Calls from every module in
+// the configuration are parsed, not only the root module, because local
+// modules may in turn call registry modules.
+func parseRegistryModuleCalls(tarball []byte, hostname string) ([]registryModuleCall, error) {
+	dir, err := os.MkdirTemp("", "")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	if err := internal.Unpack(bytes.NewReader(tarball), dir); err != nil {
+		return nil, err
+	}
+
+	var calls []registryModuleCall
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		// skip modules installed by terraform
+		if d.Name() == ".terraform" {
+			return filepath.SkipDir
+		}
+		if !tfconfig.IsModuleDir(path) {
+			return nil
+		}
+		// invalid configuration is reported to the user when a run fails, so
+		// parse what can be parsed.
+		mod, _ := tfconfig.LoadModule(path)
+		for _, call := range mod.ModuleCalls {
+			opts, ok := parseRegistrySource(call.Source, hostname)
+			if !ok {
+				continue
+			}
+			calls = append(calls, registryModuleCall{
+				GetModuleOptions: opts,
+				Constraint:       call.Version,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return calls, nil
+}
+
+// parseRegistrySource parses a module source address of the form
+// <hostname>/<organization>/<name>/<provider>, optionally followed by
+// //<subdirectory>. False is returned if the source is not a module in the
+// registry with the given hostname.
+func parseRegistrySource(source, hostname string) (GetModuleOptions, bool) {
+	// strip off the path of a submodule
+	if i := strings.Index(source, "//"); i >= 0 {
+		source = source[:i]
+	}
+	parts := strings.Split(source, "/")
+	if len(parts) != 4 {
+		return GetModuleOptions{}, false
+	}
+	if !strings.EqualFold(parts[0], hostname) {
+		return GetModuleOptions{}, false
+	}
+	return GetModuleOptions{
+		Organization: parts[1],
+		Name:         parts[2],
+		Provider:     parts[3],
+	}, true
+}
@@ -0,0 +1,88 @@
+package module
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRegistryModuleCalls(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"main.tf": `
+module "vpc" {
+  source  = "otf.dev/acme/vpc/aws"
+  version = "~> 1.0"
+}
+module "subnet" {
+  source = "otf.dev/acme/vpc/aws//modules/subnet"
+}
+module "public" {
+  source = "hashicorp/consul/aws"
+}
+module "local" {
+  source = "./modules/app"
+}
+`,
+		"modules/app/main.tf": `
+module "s3" {
+  source  = "OTF.DEV/acme/s3/aws"
+  version = "2.0.0"
+}
+`,
+		".terraform/modules/vpc/main.tf": `
+module "ignored" {
+  source = "otf.dev/acme/ignored/aws"
+}
+`,
+	}
+	for path, content := range files {
+		path = filepath.Join(dir, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	tarball, err := internal.Pack(dir)
+	require.NoError(t, err)
+
+	got, err := parseRegistryModuleCalls(tarball, "otf.dev")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []registryModuleCall{
+		{GetModuleOptions: GetModuleOptions{Organization: "acme", Name: "vpc", Provider: "aws"}, Constraint: "~> 1.0"},
+		{GetModuleOptions: GetModuleOptions{Organization: "acme", Name: "vpc", Provider: "aws"}},
+		{GetModuleOptions: GetModuleOptions{Organization: "acme", Name: "s3", Provider: "aws"}, Constraint: "2.0.0"},
+	}, got)
+}
+
+func TestResolveVersion(t *testing.T) {
+	mod := &Module{Versions: []ModuleVersion{
+		{ID: "modver-3", Version: "2.0.0", Status: ModuleVersionStatusPending},
+		{ID: "modver-2", Version: "1.1.0", Status: ModuleVersionStatusOK},
+		{ID: "modver-1", Version: "1.0.0", Status: ModuleVersionStatusOK},
+	}}
+
+	tests := []struct {
+		constraint string
+		want       string
+	}{
+		{"", "modver-2"},
+		{"1.0.0", "modver-1"},
+		{"~> 1.0", "modver-2"},
+		{"< 1.1.0", "modver-1"},
+		{">= 2.0.0", ""},
+		{"invalid", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			got := resolveVersion(mod, tt.constraint)
+			if tt.want == "" {
+				assert.Nil(t, got)
+			} else if assert.NotNil(t, got) {
+				assert.Equal(t, tt.want, got.ID)
+			}
+		})
+	}
+}
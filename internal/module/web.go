@@ -1,9 +1,11 @@
 package module
 
 import (
+	"context"
 	"errors"
 	"html/template"
 	"net/http"
+	"net/url"
 
 	"github.com/gorilla/mux"
 	"github.com/leg100/otf/internal"
@@ -46,6 +48,10 @@ func (h *webHandlers) addHandlers(r *mux.Router) {
 	r.HandleFunc("/organizations/{organization_name}/modules/create", h.publish).Methods("POST")
 	r.HandleFunc("/modules/{module_id}", h.get).Methods("GET")
 	r.HandleFunc("/modules/{module_id}/delete", h.delete).Methods("POST")
+	r.HandleFunc("/module-versions/{module_version_id}/deprecate", h.deprecateVersion).Methods("POST")
+	r.HandleFunc("/module-versions/{module_version_id}/undeprecate", h.updateVersion(Service.UndeprecateVersion, "undeprecated")).Methods("POST")
+	r.HandleFunc("/module-versions/{module_version_id}/yank", h.updateVersion(Service.YankVersion, "yanked")).Methods("POST")
+	r.HandleFunc("/module-versions/{module_version_id}/unyank", h.updateVersion(Service.UnyankVersion, "unyanked")).Methods("POST")
 }
 
 func (h *webHandlers) list(w http.ResponseWriter, r *http.Request) {
@@ -118,30 +124,52 @@ func (h *webHandlers) get(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	usages, err := h.svc.ListModuleUsages(r.Context(), module.ID)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	tests, err := h.svc.ListModuleTests(r.Context(), module.ID)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
 	h.Render("module_get.tmpl", w, struct {
 		organization.OrganizationPage
-		Module                    *Module
-		TerraformModule           *TerraformModule
-		Readme                    template.HTML
-		CurrentVersion            *ModuleVersion
-		Hostname                  string
-		ModuleStatusPending       ModuleStatus
-		ModuleStatusNoVersionTags ModuleStatus
-		ModuleStatusSetupFailed   ModuleStatus
-		ModuleStatusSetupComplete ModuleStatus
-		ModuleVersionStatusOK     ModuleVersionStatus
+		Module                         *Module
+		TerraformModule                *TerraformModule
+		Readme                         template.HTML
+		CurrentVersion                 *ModuleVersion
+		Usages                         []*ModuleUsage
+		Tests                          []*ModuleVersionTest
+		Hostname                       string
+		ModuleStatusPending            ModuleStatus
+		ModuleStatusNoVersionTags      ModuleStatus
+		ModuleStatusSetupFailed        ModuleStatus
+		ModuleStatusSetupComplete      ModuleStatus
+		ModuleVersionStatusOK          ModuleVersionStatus
+		ModuleVersionStatusTestPending ModuleVersionStatus
+		ModuleVersionStatusTesting     ModuleVersionStatus
+		ModuleVersionStatusTestFailed  ModuleVersionStatus
 	}{
-		OrganizationPage:          organization.NewPage(r, module.ID, module.Organization),
-		Module:                    module,
-		TerraformModule:           tfmod,
-		Readme:                    readme,
-		CurrentVersion:            modver,
-		Hostname:                  h.Hostname(),
-		ModuleStatusPending:       ModuleStatusPending,
-		ModuleStatusNoVersionTags: ModuleStatusNoVersionTags,
-		ModuleStatusSetupFailed:   ModuleStatusSetupFailed,
-		ModuleStatusSetupComplete: ModuleStatusSetupComplete,
-		ModuleVersionStatusOK:     ModuleVersionStatusOK,
+		OrganizationPage:               organization.NewPage(r, module.ID, module.Organization),
+		Module:                         module,
+		TerraformModule:                tfmod,
+		Readme:                         readme,
+		CurrentVersion:                 modver,
+		Usages:                         usages,
+		Tests:                          tests,
+		Hostname:                       h.Hostname(),
+		ModuleStatusPending:            ModuleStatusPending,
+		ModuleStatusNoVersionTags:      ModuleStatusNoVersionTags,
+		ModuleStatusSetupFailed:        ModuleStatusSetupFailed,
+		ModuleStatusSetupComplete:      ModuleStatusSetupComplete,
+		ModuleVersionStatusOK:          ModuleVersionStatusOK,
+		ModuleVersionStatusTestPending: ModuleVersionStatusTestPending,
+		ModuleVersionStatusTesting:     ModuleVersionStatusTesting,
+		ModuleVersionStatusTestFailed:  ModuleVersionStatusTestFailed,
 	})
 }
 
@@ -256,16 +284,25 @@ func (h *webHandlers) newModuleConfirm(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Pre-populate name and provider if the repo follows the naming format.
+	// Otherwise, e.g. the repo is a monorepo, they must be entered by the
+	// user.
+	name, provider, _ := Repo(params.Repo).Split()
+
 	h.Render("module_new.tmpl", w, struct {
 		organization.OrganizationPage
 		Step        newModuleStep
 		Repo        string
 		VCSProvider *vcsprovider.VCSProvider
+		Name        string
+		Provider    string
 	}{
 		OrganizationPage: organization.NewPage(r, "new module", params.Organization),
 		Step:             newModuleConfirmStep,
 		Repo:             params.Repo,
 		VCSProvider:      vcsprov,
+		Name:             name,
+		Provider:         provider,
 	})
 }
 
@@ -273,6 +310,11 @@ func (h *webHandlers) publish(w http.ResponseWriter, r *http.Request) {
 	var params struct {
 		VCSProviderID string `schema:"vcs_provider_id,required"`
 		Repo          Repo   `schema:"identifier,required"`
+		Name          string `schema:"name"`
+		Provider      string `schema:"provider"`
+		Path          string `schema:"path"`
+		TagPrefix     string `schema:"tag_prefix"`
+		RunTests      bool   `schema:"run_tests"`
 	}
 	if err := decode.All(&params, r); err != nil {
 		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
@@ -282,8 +324,16 @@ func (h *webHandlers) publish(w http.ResponseWriter, r *http.Request) {
 	module, err := h.svc.PublishModule(r.Context(), PublishOptions{
 		Repo:          params.Repo,
 		VCSProviderID: params.VCSProviderID,
+		Name:          params.Name,
+		Provider:      params.Provider,
+		Path:          params.Path,
+		TagPrefix:     params.TagPrefix,
+		RunTests:      params.RunTests,
 	})
-	if err != nil && errors.Is(err, internal.ErrInvalidRepo) || errors.Is(err, ErrInvalidModuleRepo) {
+	if err != nil && errors.Is(err, internal.ErrInvalidRepo) ||
+		errors.Is(err, ErrInvalidModuleRepo) ||
+		errors.Is(err, internal.ErrInvalidName) ||
+		errors.Is(err, ErrInvalidModulePath) {
 		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
 		return
 	} else if err != nil {
@@ -311,3 +361,57 @@ func (h *webHandlers) delete(w http.ResponseWriter, r *http.Request) {
 	html.FlashSuccess(w, "deleted module: "+deleted.Name)
 	http.Redirect(w, r, paths.Modules(deleted.Organization), http.StatusFound)
 }
+
+func (h *webHandlers) deprecateVersion(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		ID          string `schema:"module_version_id,required"`
+		Reason      string `schema:"reason"`
+		Replacement string `schema:"replacement"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	modver, err := h.svc.DeprecateVersion(r.Context(), DeprecateModuleVersionOptions{
+		ID:          params.ID,
+		Reason:      params.Reason,
+		Replacement: params.Replacement,
+	})
+	if errors.Is(err, ErrDeprecationReason) || errors.Is(err, ErrInvalidReplacement) {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	} else if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	html.FlashSuccess(w, "deprecated module version: "+modver.Version)
+	http.Redirect(w, r, moduleVersionPath(modver), http.StatusFound)
+}
+
+// updateVersion returns a handler that updates a module version with the
+// given func, reporting the action to the user.
+func (h *webHandlers) updateVersion(fn func(Service, context.Context, string) (*ModuleVersion, error), action string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := decode.Param("module_version_id", r)
+		if err != nil {
+			h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+
+		modver, err := fn(h.svc, r.Context(), id)
+		if err != nil {
+			h.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		html.FlashSuccess(w, action+" module version: "+modver.Version)
+		http.Redirect(w, r, moduleVersionPath(modver), http.StatusFound)
+	}
+}
+
+// moduleVersionPath is the path to the web page for a module version.
+func moduleVersionPath(modver *ModuleVersion) string {
+	return paths.Module(modver.ModuleID) + "?version=" + url.QueryEscape(modver.Version)
+}
@@ -80,6 +80,158 @@ func TestGetModule(t *testing.T) {
 	}
 }
 
+func TestGetModule_Submodules(t *testing.T) {
+	tarball, err := extractSubdirectory(newTestMonorepoTarball(t), "vpc")
+	require.NoError(t, err)
+
+	mod := Module{
+		Name:       "vpc",
+		Provider:   "aws",
+		Connection: &connections.Connection{Repo: "acme/terraform-modules"},
+		Path:       "vpc",
+		TagPrefix:  "vpc/",
+		Status:     ModuleStatusSetupComplete,
+		Versions:   []ModuleVersion{{Version: "1.0.0", Status: ModuleVersionStatusOK}},
+	}
+	h := newTestWebHandlers(t, withMod(&mod), withTarball(tarball), withHostname("fake-host.org"))
+
+	r := httptest.NewRequest("GET", "/?module_id=mod-123", nil)
+	w := httptest.NewRecorder()
+	h.get(w, r)
+	if assert.Equal(t, 200, w.Code) {
+		assert.Contains(t, w.Body.String(), `id="submodules"`)
+		assert.Contains(t, w.Body.String(), "//modules/subnet")
+		assert.Contains(t, w.Body.String(), `id="examples"`)
+		assert.Contains(t, w.Body.String(), "basic")
+	}
+}
+
+func TestGetModule_Usage(t *testing.T) {
+	tarball, err := os.ReadFile("./testdata/module.tar.gz")
+	require.NoError(t, err)
+
+	mod := Module{
+		ID:     "mod-123",
+		Status: ModuleStatusSetupComplete,
+		Versions: []ModuleVersion{
+			{Version: "1.1.0", Status: ModuleVersionStatusOK, Downloads: 3},
+			{Version: "1.0.0", Status: ModuleVersionStatusOK, Downloads: 7},
+		},
+	}
+	h := newTestWebHandlers(t,
+		withMod(&mod),
+		withTarball(tarball),
+		withUsages(&ModuleUsage{Version: "1.0.0", WorkspaceID: "ws-123", WorkspaceName: "dev"}),
+	)
+
+	r := httptest.NewRequest("GET", "/?module_id=mod-123", nil)
+	w := httptest.NewRecorder()
+	h.get(w, r)
+	if assert.Equal(t, 200, w.Code) {
+		assert.Contains(t, w.Body.String(), `<span class="bg-gray-200" id="module-downloads">3</span>`)
+		assert.Contains(t, w.Body.String(), `<span id="module-total-downloads">10</span>`)
+		assert.Contains(t, w.Body.String(), `href="/app/workspaces/ws-123">dev</a> uses version <span class="bg-gray-200">1.0.0</span>`)
+	}
+}
+
+func TestGetModule_Deprecated(t *testing.T) {
+	tarball, err := os.ReadFile("./testdata/module.tar.gz")
+	require.NoError(t, err)
+
+	mod := Module{
+		ID:     "mod-123",
+		Status: ModuleStatusSetupComplete,
+		Versions: []ModuleVersion{
+			{Version: "1.2.0", Status: ModuleVersionStatusOK, Yanked: true},
+			{Version: "1.1.0", Status: ModuleVersionStatusOK},
+			{
+				Version:     "1.0.0",
+				Status:      ModuleVersionStatusOK,
+				Deprecation: &ModuleVersionDeprecation{Reason: "security issue", Replacement: "1.1.0"},
+			},
+		},
+	}
+	h := newTestWebHandlers(t, withMod(&mod), withTarball(tarball))
+
+	t.Run("deprecated", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/?module_id=mod-123&version=1.0.0", nil)
+		w := httptest.NewRecorder()
+		h.get(w, r)
+		if assert.Equal(t, 200, w.Code) {
+			assert.Contains(t, w.Body.String(), `id="module-version-deprecation"`)
+			assert.Contains(t, w.Body.String(), "This version is deprecated: security issue")
+			assert.Contains(t, w.Body.String(), `id="undeprecate-button"`)
+			assert.Contains(t, w.Body.String(), ">1.2.0 (yanked)</option>")
+		}
+	})
+
+	t.Run("yanked", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/?module_id=mod-123&version=1.2.0", nil)
+		w := httptest.NewRecorder()
+		h.get(w, r)
+		if assert.Equal(t, 200, w.Code) {
+			assert.Contains(t, w.Body.String(), `id="module-version-yanked"`)
+			assert.Contains(t, w.Body.String(), `id="unyank-button"`)
+			assert.Contains(t, w.Body.String(), ">1.0.0 (deprecated)</option>")
+		}
+	})
+}
+
+func TestGetModule_Tests(t *testing.T) {
+	tarball, err := os.ReadFile("./testdata/module.tar.gz")
+	require.NoError(t, err)
+
+	mod := Module{
+		ID:       "mod-123",
+		Status:   ModuleStatusSetupComplete,
+		RunTests: true,
+		Versions: []ModuleVersion{
+			{Version: "1.2.0", Status: ModuleVersionStatusTestPending},
+			{Version: "1.1.0", Status: ModuleVersionStatusTestFailed},
+			{Version: "1.0.0", Status: ModuleVersionStatusOK},
+		},
+	}
+	h := newTestWebHandlers(t,
+		withMod(&mod),
+		withTarball(tarball),
+		withTests(
+			&ModuleVersionTest{Version: "1.1.0", Status: ModuleVersionStatusTestFailed, Output: []byte("1 failed")},
+			&ModuleVersionTest{Version: "1.0.0", Status: ModuleVersionStatusOK, Output: []byte("1 passed")},
+		),
+	)
+
+	r := httptest.NewRequest("GET", "/?module_id=mod-123", nil)
+	w := httptest.NewRecorder()
+	h.get(w, r)
+	if assert.Equal(t, 200, w.Code) {
+		assert.Contains(t, w.Body.String(), `id="module-tests"`)
+		assert.Contains(t, w.Body.String(), "waiting for an agent")
+		assert.Contains(t, w.Body.String(), `<span class="text-red-700">failed</span>`)
+		assert.Contains(t, w.Body.String(), "1 failed")
+		assert.Contains(t, w.Body.String(), "1 passed")
+		// the failed version is not available for selection
+		assert.NotContains(t, w.Body.String(), `<option value="1.1.0"`)
+	}
+}
+
+func TestGetModule_NoAvailableVersions(t *testing.T) {
+	mod := Module{
+		ID:       "mod-123",
+		Status:   ModuleStatusSetupComplete,
+		RunTests: true,
+		Versions: []ModuleVersion{{Version: "1.0.0", Status: ModuleVersionStatusTestPending}},
+	}
+	h := newTestWebHandlers(t, withMod(&mod))
+
+	r := httptest.NewRequest("GET", "/?module_id=mod-123", nil)
+	w := httptest.NewRecorder()
+	h.get(w, r)
+	if assert.Equal(t, 200, w.Code, w.Body.String()) {
+		assert.Contains(t, w.Body.String(), `id="no-available-versions"`)
+		assert.Contains(t, w.Body.String(), "waiting for an agent")
+	}
+}
+
 func TestNewModule_Connect(t *testing.T) {
 	h := newTestWebHandlers(t, withVCSProviders(
 		&vcsprovider.VCSProvider{},
@@ -125,6 +277,21 @@ func TestNewModule_Confirm(t *testing.T) {
 	}
 }
 
+func TestNewModule_ConfirmMonorepo(t *testing.T) {
+	h := newTestWebHandlers(t, withVCSProviders(&vcsprovider.VCSProvider{}))
+
+	// repo name does not follow the module naming format so the module name
+	// and provider are left for the user to fill in
+	q := "/?organization_name=acme-corp&vcs_provider_id=vcs-123&identifier=acme/modules"
+	r := httptest.NewRequest("GET", q, nil)
+	w := httptest.NewRecorder()
+	h.newModuleConfirm(w, r)
+	if assert.Equal(t, 200, w.Code) {
+		assert.Contains(t, w.Body.String(), `name="tag_prefix"`)
+		assert.Contains(t, w.Body.String(), `name="name" id="name" value=""`)
+	}
+}
+
 func TestWeb_Publish(t *testing.T) {
 	mod := Module{}
 	h := newTestWebHandlers(t, withMod(&mod))
@@ -155,6 +322,44 @@ func TestNewModule_Delete(t *testing.T) {
 	}
 }
 
+func TestWeb_DeprecateVersion(t *testing.T) {
+	mod := Module{
+		ID:       "mod-123",
+		Versions: []ModuleVersion{{ID: "modver-123", ModuleID: "mod-123", Version: "1.0.0"}},
+	}
+	h := newTestWebHandlers(t, withMod(&mod))
+
+	q := "/?module_version_id=modver-123&reason=security+issue"
+	r := httptest.NewRequest("POST", q, nil)
+	w := httptest.NewRecorder()
+	h.deprecateVersion(w, r)
+	if assert.Equal(t, 302, w.Code) {
+		redirect, err := w.Result().Location()
+		require.NoError(t, err)
+		assert.Equal(t, paths.Module("mod-123"), redirect.Path)
+		assert.Equal(t, "1.0.0", redirect.Query().Get("version"))
+	}
+}
+
+func TestWeb_YankVersion(t *testing.T) {
+	mod := Module{
+		ID:       "mod-123",
+		Versions: []ModuleVersion{{ID: "modver-123", ModuleID: "mod-123", Version: "1.0.0"}},
+	}
+	h := newTestWebHandlers(t, withMod(&mod))
+
+	q := "/?module_version_id=modver-123"
+	r := httptest.NewRequest("POST", q, nil)
+	w := httptest.NewRecorder()
+	h.updateVersion(Service.YankVersion, "yanked")(w, r)
+	if assert.Equal(t, 302, w.Code) {
+		redirect, err := w.Result().Location()
+		require.NoError(t, err)
+		assert.Equal(t, paths.Module("mod-123"), redirect.Path)
+		assert.Equal(t, "1.0.0", redirect.Query().Get("version"))
+	}
+}
+
 func newTestWebHandlers(t *testing.T, opts ...testWebOption) *webHandlers {
 	renderer, err := html.NewRenderer(false)
 	require.NoError(t, err)
@@ -180,6 +385,18 @@ func withMod(mod *Module) testWebOption {
 	}
 }
 
+func withUsages(usages ...*ModuleUsage) testWebOption {
+	return func(svc *fakeWebServices) {
+		svc.usages = usages
+	}
+}
+
+func withTests(tests ...*ModuleVersionTest) testWebOption {
+	return func(svc *fakeWebServices) {
+		svc.tests = tests
+	}
+}
+
 func withTarball(tarball []byte) testWebOption {
 	return func(svc *fakeWebServices) {
 		svc.tarball = tarball
@@ -206,6 +423,8 @@ func withHostname(hostname string) testWebOption {
 
 type fakeWebServices struct {
 	mod      *Module
+	usages   []*ModuleUsage
+	tests    []*ModuleVersionTest
 	tarball  []byte
 	vcsprovs []*vcsprovider.VCSProvider
 	repos    []string
@@ -225,10 +444,26 @@ func (f *fakeWebServices) GetModuleByID(context.Context, string) (*Module, error
 	return f.mod, nil
 }
 
+func (f *fakeWebServices) ListModuleUsages(context.Context, string) ([]*ModuleUsage, error) {
+	return f.usages, nil
+}
+
+func (f *fakeWebServices) ListModuleTests(context.Context, string) ([]*ModuleVersionTest, error) {
+	return f.tests, nil
+}
+
 func (f *fakeWebServices) DeleteModule(context.Context, string) (*Module, error) {
 	return f.mod, nil
 }
 
+func (f *fakeWebServices) DeprecateVersion(_ context.Context, opts DeprecateModuleVersionOptions) (*ModuleVersion, error) {
+	return f.mod.versionByID(opts.ID), nil
+}
+
+func (f *fakeWebServices) YankVersion(_ context.Context, versionID string) (*ModuleVersion, error) {
+	return f.mod.versionByID(versionID), nil
+}
+
 func (f *fakeWebServices) ListModules(context.Context, ListModulesOptions) ([]*Module, error) {
 	return []*Module{f.mod}, nil
 }
@@ -6,6 +6,7 @@ const (
 	PendingPhase PhaseType = "pending"
 	PlanPhase    PhaseType = "plan"
 	ApplyPhase   PhaseType = "apply"
+	TestPhase    PhaseType = "test"
 	FinalPhase   PhaseType = "final"
 	UnknownPhase PhaseType = "unknown"
 )
@@ -0,0 +1,149 @@
+package project
+
+import (
+	"context"
+
+	"github.com/jackc/pgtype"
+	"github.com/jackc/pgx/v4"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/sql/pggen"
+)
+
+type (
+	// pgdb is a project database on postgres
+	pgdb struct {
+		*sql.DB // provides access to generated SQL queries
+	}
+
+	// pgresult represents the result of a database query for a project.
+	pgresult struct {
+		ProjectID        pgtype.Text        `json:"project_id"`
+		CreatedAt        pgtype.Timestamptz `json:"created_at"`
+		Name             pgtype.Text        `json:"name"`
+		OrganizationName pgtype.Text        `json:"organization_name"`
+	}
+)
+
+func (r pgresult) toProject() *Project {
+	return &Project{
+		ID:           r.ProjectID.String,
+		CreatedAt:    r.CreatedAt.Time.UTC(),
+		Name:         r.Name.String,
+		Organization: r.OrganizationName.String,
+	}
+}
+
+func (db *pgdb) create(ctx context.Context, project *Project) error {
+	_, err := db.Conn(ctx).InsertProject(ctx, pggen.InsertProjectParams{
+		ID:               sql.String(project.ID),
+		CreatedAt:        sql.Timestamptz(project.CreatedAt),
+		Name:             sql.String(project.Name),
+		OrganizationName: sql.String(project.Organization),
+	})
+	return sql.Error(err)
+}
+
+func (db *pgdb) update(ctx context.Context, projectID string, fn func(*Project) error) (*Project, error) {
+	var project *Project
+	err := db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		result, err := q.FindProjectByIDForUpdate(ctx, sql.String(projectID))
+		if err != nil {
+			return sql.Error(err)
+		}
+		project = pgresult(result).toProject()
+		if err := fn(project); err != nil {
+			return err
+		}
+		_, err = q.UpdateProjectByID(ctx, sql.String(project.Name), sql.String(project.ID))
+		return sql.Error(err)
+	})
+	return project, err
+}
+
+func (db *pgdb) list(ctx context.Context, opts ListOptions) (*resource.Page[*Project], error) {
+	q := db.Conn(ctx)
+	batch := &pgx.Batch{}
+
+	q.FindProjectsBatch(batch, pggen.FindProjectsParams{
+		OrganizationName: sql.String(opts.Organization),
+		Limit:            opts.GetLimit(),
+		Offset:           opts.GetOffset(),
+	})
+	q.CountProjectsBatch(batch, sql.String(opts.Organization))
+	results := db.SendBatch(ctx, batch)
+	defer results.Close()
+
+	rows, err := q.FindProjectsScan(results)
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	count, err := q.CountProjectsScan(results)
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+
+	items := make([]*Project, len(rows))
+	for i, r := range rows {
+		items[i] = pgresult(r).toProject()
+	}
+	return resource.NewPage(items, opts.PageOptions, internal.Int64(count.Int)), nil
+}
+
+func (db *pgdb) get(ctx context.Context, projectID string) (*Project, error) {
+	result, err := db.Conn(ctx).FindProjectByID(ctx, sql.String(projectID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return pgresult(result).toProject(), nil
+}
+
+func (db *pgdb) delete(ctx context.Context, projectID string) error {
+	_, err := db.Conn(ctx).DeleteProjectByID(ctx, sql.String(projectID))
+	if err != nil {
+		return sql.Error(err)
+	}
+	return nil
+}
+
+func (db *pgdb) setPermission(ctx context.Context, projectID, team string, role rbac.Role) error {
+	_, err := db.Conn(ctx).UpsertProjectPermission(ctx, pggen.UpsertProjectPermissionParams{
+		ProjectID: sql.String(projectID),
+		TeamName:  sql.String(team),
+		Role:      sql.String(role.String()),
+	})
+	if err != nil {
+		return sql.Error(err)
+	}
+	return nil
+}
+
+func (db *pgdb) listPermissions(ctx context.Context, projectID string) ([]internal.WorkspacePermission, error) {
+	rows, err := db.Conn(ctx).FindProjectPermissionsByProjectID(ctx, sql.String(projectID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	perms := make([]internal.WorkspacePermission, len(rows))
+	for i, r := range rows {
+		role, err := rbac.WorkspaceRoleFromString(r.Role.String)
+		if err != nil {
+			return nil, err
+		}
+		perms[i] = internal.WorkspacePermission{
+			Team:   r.Team.Name.String,
+			TeamID: r.Team.TeamID.String,
+			Role:   role,
+		}
+	}
+	return perms, nil
+}
+
+func (db *pgdb) unsetPermission(ctx context.Context, projectID, team string) error {
+	_, err := db.Conn(ctx).DeleteProjectPermissionByID(ctx, sql.String(projectID), sql.String(team))
+	if err != nil {
+		return sql.Error(err)
+	}
+	return nil
+}
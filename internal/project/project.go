@@ -0,0 +1,75 @@
+// Package project provides projects, which group workspaces within an
+// organization.
+package project
+
+import (
+	"errors"
+	"time"
+
+	"log/slog"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/resource"
+)
+
+var (
+	ErrCustomRoleNotSupported = errors.New("custom roles cannot be assigned to projects")
+	ErrOrganizationMismatch   = errors.New("project belongs to a different organization")
+)
+
+type (
+	// Project groups workspaces within an organization. Permissions granted to
+	// teams on a project cascade to the workspaces that belong to the project.
+	Project struct {
+		ID           string    `jsonapi:"primary,projects"`
+		CreatedAt    time.Time `jsonapi:"attribute" json:"created_at"`
+		Name         string    `jsonapi:"attribute" json:"name"`
+		Organization string    `jsonapi:"attribute" json:"organization"`
+	}
+
+	CreateOptions struct {
+		Name *string
+	}
+
+	UpdateOptions struct {
+		Name *string
+	}
+
+	// ListOptions are options for paginating a list of projects
+	ListOptions struct {
+		Organization string `schema:"organization_name,required"`
+
+		resource.PageOptions
+	}
+)
+
+func newProject(organization string, opts CreateOptions) (*Project, error) {
+	if err := resource.ValidateName(opts.Name); err != nil {
+		return nil, err
+	}
+	return &Project{
+		ID:           internal.NewID("prj"),
+		CreatedAt:    internal.CurrentTimestamp(nil),
+		Name:         *opts.Name,
+		Organization: organization,
+	}, nil
+}
+
+func (p *Project) update(opts UpdateOptions) error {
+	if opts.Name != nil {
+		if err := resource.ValidateName(opts.Name); err != nil {
+			return err
+		}
+		p.Name = *opts.Name
+	}
+	return nil
+}
+
+// LogValue implements slog.LogValuer.
+func (p *Project) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("id", p.ID),
+		slog.String("organization", p.Organization),
+		slog.String("name", p.Name),
+	)
+}
@@ -0,0 +1,210 @@
+package project
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/auth"
+	"github.com/leg100/otf/internal/http/html"
+	"github.com/leg100/otf/internal/organization"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/tfeapi"
+)
+
+type (
+	ProjectService = Service
+
+	Service interface {
+		CreateProject(ctx context.Context, organization string, opts CreateOptions) (*Project, error)
+		UpdateProject(ctx context.Context, projectID string, opts UpdateOptions) (*Project, error)
+		GetProject(ctx context.Context, projectID string) (*Project, error)
+		ListProjects(ctx context.Context, opts ListOptions) (*resource.Page[*Project], error)
+		DeleteProject(ctx context.Context, projectID string) error
+
+		// SetProjectPermission assigns a role to a team on a project. The role
+		// cascades to all workspaces in the project.
+		SetProjectPermission(ctx context.Context, projectID, team string, role rbac.Role) error
+		UnsetProjectPermission(ctx context.Context, projectID, team string) error
+		ListProjectPermissions(ctx context.Context, projectID string) ([]internal.WorkspacePermission, error)
+	}
+
+	service struct {
+		logr.Logger
+
+		organization internal.Authorizer
+
+		db     *pgdb
+		web    *webHandlers
+		tfeapi *tfe
+	}
+
+	Options struct {
+		*sql.DB
+		*tfeapi.Responder
+		html.Renderer
+		auth.TeamService
+		logr.Logger
+	}
+)
+
+func NewService(opts Options) *service {
+	svc := service{
+		Logger:       opts.Logger,
+		organization: &organization.Authorizer{Logger: opts.Logger},
+		db:           &pgdb{opts.DB},
+	}
+	svc.web = &webHandlers{
+		Renderer:    opts.Renderer,
+		TeamService: opts.TeamService,
+		svc:         &svc,
+	}
+	svc.tfeapi = &tfe{
+		Service:   &svc,
+		Responder: opts.Responder,
+	}
+	return &svc
+}
+
+func (s *service) AddHandlers(r *mux.Router) {
+	s.web.addHandlers(r)
+	s.tfeapi.addHandlers(r)
+}
+
+func (s *service) CreateProject(ctx context.Context, organization string, opts CreateOptions) (*Project, error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.CreateProjectAction, organization)
+	if err != nil {
+		return nil, err
+	}
+	project, err := newProject(organization, opts)
+	if err != nil {
+		s.Error(err, "constructing project", "subject", subject)
+		return nil, err
+	}
+	if err := s.db.create(ctx, project); err != nil {
+		s.Error(err, "creating project", "project", project, "subject", subject)
+		return nil, err
+	}
+	s.V(0).Info("created project", "project", project, "subject", subject)
+	return project, nil
+}
+
+func (s *service) UpdateProject(ctx context.Context, projectID string, opts UpdateOptions) (*Project, error) {
+	var subject internal.Subject
+	updated, err := s.db.update(ctx, projectID, func(project *Project) (err error) {
+		subject, err = s.organization.CanAccess(ctx, rbac.UpdateProjectAction, project.Organization)
+		if err != nil {
+			return err
+		}
+		return project.update(opts)
+	})
+	if err != nil {
+		s.Error(err, "updating project", "id", projectID, "subject", subject)
+		return nil, err
+	}
+	s.V(0).Info("updated project", "project", updated, "subject", subject)
+	return updated, nil
+}
+
+func (s *service) GetProject(ctx context.Context, projectID string) (*Project, error) {
+	project, err := s.db.get(ctx, projectID)
+	if err != nil {
+		s.Error(err, "retrieving project", "id", projectID)
+		return nil, err
+	}
+	subject, err := s.organization.CanAccess(ctx, rbac.GetProjectAction, project.Organization)
+	if err != nil {
+		return nil, err
+	}
+	s.V(9).Info("retrieved project", "project", project, "subject", subject)
+	return project, nil
+}
+
+func (s *service) ListProjects(ctx context.Context, opts ListOptions) (*resource.Page[*Project], error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.ListProjectsAction, opts.Organization)
+	if err != nil {
+		return nil, err
+	}
+	page, err := s.db.list(ctx, opts)
+	if err != nil {
+		s.Error(err, "listing projects", "organization", opts.Organization, "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("listed projects", "organization", opts.Organization, "subject", subject)
+	return page, nil
+}
+
+func (s *service) DeleteProject(ctx context.Context, projectID string) error {
+	project, err := s.db.get(ctx, projectID)
+	if err != nil {
+		s.Error(err, "retrieving project", "id", projectID)
+		return err
+	}
+	subject, err := s.organization.CanAccess(ctx, rbac.DeleteProjectAction, project.Organization)
+	if err != nil {
+		return err
+	}
+	if err := s.db.delete(ctx, projectID); err != nil {
+		s.Error(err, "deleting project", "project", project, "subject", subject)
+		return err
+	}
+	s.V(0).Info("deleted project", "project", project, "subject", subject)
+	return nil
+}
+
+func (s *service) SetProjectPermission(ctx context.Context, projectID, team string, role rbac.Role) error {
+	project, err := s.db.get(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	subject, err := s.organization.CanAccess(ctx, rbac.SetProjectPermissionAction, project.Organization)
+	if err != nil {
+		return err
+	}
+	if role.Custom() != nil {
+		return ErrCustomRoleNotSupported
+	}
+	if err := s.db.setPermission(ctx, projectID, team, role); err != nil {
+		s.Error(err, "setting project permission", "project", project, "team", team, "role", role, "subject", subject)
+		return err
+	}
+	s.V(0).Info("set project permission", "project", project, "team", team, "role", role, "subject", subject)
+	return nil
+}
+
+func (s *service) UnsetProjectPermission(ctx context.Context, projectID, team string) error {
+	project, err := s.db.get(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	subject, err := s.organization.CanAccess(ctx, rbac.UnsetProjectPermissionAction, project.Organization)
+	if err != nil {
+		return err
+	}
+	if err := s.db.unsetPermission(ctx, projectID, team); err != nil {
+		s.Error(err, "unsetting project permission", "project", project, "team", team, "subject", subject)
+		return err
+	}
+	s.V(0).Info("unset project permission", "project", project, "team", team, "subject", subject)
+	return nil
+}
+
+func (s *service) ListProjectPermissions(ctx context.Context, projectID string) ([]internal.WorkspacePermission, error) {
+	project, err := s.db.get(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	subject, err := s.organization.CanAccess(ctx, rbac.GetProjectAction, project.Organization)
+	if err != nil {
+		return nil, err
+	}
+	perms, err := s.db.listPermissions(ctx, projectID)
+	if err != nil {
+		s.Error(err, "listing project permissions", "project", project, "subject", subject)
+		return nil, err
+	}
+	return perms, nil
+}
@@ -0,0 +1,60 @@
+package project
+
+import (
+	"context"
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/auth"
+	"github.com/leg100/otf/internal/http/html"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	fakeService struct {
+		project *Project
+		teams   []*auth.Team
+		perms   []internal.WorkspacePermission
+		role    rbac.Role // role set via SetProjectPermission
+
+		Service
+		auth.TeamService
+	}
+)
+
+func newFakeWeb(t *testing.T, svc *fakeService) *webHandlers {
+	renderer, err := html.NewRenderer(false)
+	require.NoError(t, err)
+	return &webHandlers{
+		Renderer:    renderer,
+		TeamService: svc,
+		svc:         svc,
+	}
+}
+
+func (f *fakeService) CreateProject(ctx context.Context, organization string, opts CreateOptions) (*Project, error) {
+	return newProject(organization, opts)
+}
+
+func (f *fakeService) GetProject(context.Context, string) (*Project, error) {
+	return f.project, nil
+}
+
+func (f *fakeService) ListProjects(ctx context.Context, opts ListOptions) (*resource.Page[*Project], error) {
+	return resource.NewPage([]*Project{f.project}, opts.PageOptions, nil), nil
+}
+
+func (f *fakeService) ListProjectPermissions(context.Context, string) ([]internal.WorkspacePermission, error) {
+	return f.perms, nil
+}
+
+func (f *fakeService) SetProjectPermission(ctx context.Context, projectID, team string, role rbac.Role) error {
+	f.role = role
+	return nil
+}
+
+func (f *fakeService) ListTeams(context.Context, string) ([]*auth.Team, error) {
+	return f.teams, nil
+}
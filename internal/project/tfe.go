@@ -0,0 +1,140 @@
+package project
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal/http/decode"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/tfeapi"
+	"github.com/leg100/otf/internal/tfeapi/types"
+)
+
+type tfe struct {
+	Service
+	*tfeapi.Responder
+}
+
+func (a *tfe) addHandlers(r *mux.Router) {
+	r = r.PathPrefix(tfeapi.APIPrefixV2).Subrouter()
+
+	r.HandleFunc("/organizations/{organization_name}/projects", a.createProject).Methods("POST")
+	r.HandleFunc("/organizations/{organization_name}/projects", a.listProjects).Methods("GET")
+	r.HandleFunc("/projects/{project_id}", a.getProject).Methods("GET")
+	r.HandleFunc("/projects/{project_id}", a.updateProject).Methods("PATCH")
+	r.HandleFunc("/projects/{project_id}", a.deleteProject).Methods("DELETE")
+}
+
+func (a *tfe) createProject(w http.ResponseWriter, r *http.Request) {
+	organization, err := decode.Param("organization_name", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var params types.ProjectCreateOptions
+	if err := tfeapi.Unmarshal(r.Body, &params); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	project, err := a.CreateProject(r.Context(), organization, CreateOptions{
+		Name: &params.Name,
+	})
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	a.Respond(w, r, a.convert(project), http.StatusCreated)
+}
+
+func (a *tfe) listProjects(w http.ResponseWriter, r *http.Request) {
+	organization, err := decode.Param("organization_name", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var params types.ProjectListOptions
+	if err := decode.All(&params, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	page, err := a.ListProjects(r.Context(), ListOptions{
+		Organization: organization,
+		PageOptions:  resource.PageOptions(params.ListOptions),
+	})
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	// convert items
+	items := make([]*types.Project, len(page.Items))
+	for i, from := range page.Items {
+		items[i] = a.convert(from)
+	}
+	a.RespondWithPage(w, r, items, page.Pagination)
+}
+
+func (a *tfe) getProject(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("project_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	project, err := a.GetProject(r.Context(), id)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	a.Respond(w, r, a.convert(project), http.StatusOK)
+}
+
+func (a *tfe) updateProject(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("project_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var params types.ProjectUpdateOptions
+	if err := tfeapi.Unmarshal(r.Body, &params); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	project, err := a.UpdateProject(r.Context(), id, UpdateOptions{
+		Name: params.Name,
+	})
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	a.Respond(w, r, a.convert(project), http.StatusOK)
+}
+
+func (a *tfe) deleteProject(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("project_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	if err := a.DeleteProject(r.Context(), id); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *tfe) convert(from *Project) *types.Project {
+	return &types.Project{
+		ID:           from.ID,
+		Name:         from.Name,
+		Organization: &types.Organization{Name: from.Organization},
+	}
+}
@@ -0,0 +1,278 @@
+package project
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/auth"
+	"github.com/leg100/otf/internal/http/decode"
+	"github.com/leg100/otf/internal/http/html"
+	"github.com/leg100/otf/internal/http/html/paths"
+	"github.com/leg100/otf/internal/organization"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/resource"
+)
+
+type webHandlers struct {
+	html.Renderer
+	auth.TeamService
+
+	svc Service
+}
+
+func (h *webHandlers) addHandlers(r *mux.Router) {
+	r = html.UIRouter(r)
+
+	r.HandleFunc("/organizations/{organization_name}/projects", h.listProjects).Methods("GET")
+	r.HandleFunc("/organizations/{organization_name}/projects/new", h.newProject).Methods("GET")
+	r.HandleFunc("/organizations/{organization_name}/projects/create", h.createProject).Methods("POST")
+	r.HandleFunc("/projects/{project_id}", h.getProject).Methods("GET")
+	r.HandleFunc("/projects/{project_id}/update", h.updateProject).Methods("POST")
+	r.HandleFunc("/projects/{project_id}/delete", h.deleteProject).Methods("POST")
+	r.HandleFunc("/projects/{project_id}/set-permission", h.setProjectPermission).Methods("POST")
+	r.HandleFunc("/projects/{project_id}/unset-permission", h.unsetProjectPermission).Methods("POST")
+}
+
+func (h *webHandlers) listProjects(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Organization string `schema:"organization_name,required"`
+		PageNumber   int    `schema:"page[number]"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	page, err := h.svc.ListProjects(r.Context(), ListOptions{
+		Organization: params.Organization,
+		PageOptions: resource.PageOptions{
+			PageNumber: params.PageNumber,
+			PageSize:   html.PageSize,
+		},
+	})
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	user, err := auth.UserFromContext(r.Context())
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.Render("project_list.tmpl", w, struct {
+		organization.OrganizationPage
+		*resource.Page[*Project]
+		CanCreateProject bool
+	}{
+		OrganizationPage: organization.NewPage(r, "projects", params.Organization),
+		Page:             page,
+		CanCreateProject: user.CanAccessOrganization(rbac.CreateProjectAction, params.Organization),
+	})
+}
+
+func (h *webHandlers) newProject(w http.ResponseWriter, r *http.Request) {
+	org, err := decode.Param("organization_name", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	h.Render("project_new.tmpl", w, struct {
+		organization.OrganizationPage
+	}{
+		OrganizationPage: organization.NewPage(r, "new project", org),
+	})
+}
+
+func (h *webHandlers) createProject(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Name         *string
+		Organization string `schema:"organization_name,required"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	project, err := h.svc.CreateProject(r.Context(), params.Organization, CreateOptions{
+		Name: params.Name,
+	})
+	if err == internal.ErrResourceAlreadyExists {
+		html.FlashError(w, "project already exists")
+		http.Redirect(w, r, paths.NewProject(params.Organization), http.StatusFound)
+		return
+	}
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	html.FlashSuccess(w, "created project: "+project.Name)
+	http.Redirect(w, r, paths.Project(project.ID), http.StatusFound)
+}
+
+func (h *webHandlers) getProject(w http.ResponseWriter, r *http.Request) {
+	projectID, err := decode.Param("project_id", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	project, err := h.svc.GetProject(r.Context(), projectID)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	perms, err := h.svc.ListProjectPermissions(r.Context(), projectID)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	teams, err := h.ListTeams(r.Context(), project.Organization)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	user, err := auth.UserFromContext(r.Context())
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.Render("project_get.tmpl", w, struct {
+		organization.OrganizationPage
+		Project          *Project
+		Permissions      []internal.WorkspacePermission
+		Unassigned       []*auth.Team
+		Roles            []rbac.Role
+		CanUpdateProject bool
+		CanDeleteProject bool
+		CanSetPermission bool
+	}{
+		OrganizationPage: organization.NewPage(r, project.Name, project.Organization),
+		Project:          project,
+		Permissions:      perms,
+		Unassigned:       filterUnassigned(perms, teams),
+		Roles: []rbac.Role{
+			rbac.WorkspaceReadRole,
+			rbac.WorkspacePlanRole,
+			rbac.WorkspaceWriteRole,
+			rbac.WorkspaceAdminRole,
+		},
+		CanUpdateProject: user.CanAccessOrganization(rbac.UpdateProjectAction, project.Organization),
+		CanDeleteProject: user.CanAccessOrganization(rbac.DeleteProjectAction, project.Organization),
+		CanSetPermission: user.CanAccessOrganization(rbac.SetProjectPermissionAction, project.Organization),
+	})
+}
+
+func (h *webHandlers) updateProject(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		ProjectID string  `schema:"project_id,required"`
+		Name      *string `schema:"name"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	project, err := h.svc.UpdateProject(r.Context(), params.ProjectID, UpdateOptions{
+		Name: params.Name,
+	})
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	html.FlashSuccess(w, "updated project")
+	http.Redirect(w, r, paths.Project(project.ID), http.StatusFound)
+}
+
+func (h *webHandlers) deleteProject(w http.ResponseWriter, r *http.Request) {
+	projectID, err := decode.Param("project_id", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	project, err := h.svc.GetProject(r.Context(), projectID)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if err := h.svc.DeleteProject(r.Context(), projectID); err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	html.FlashSuccess(w, "deleted project: "+project.Name)
+	http.Redirect(w, r, paths.Projects(project.Organization), http.StatusFound)
+}
+
+func (h *webHandlers) setProjectPermission(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		ProjectID string `schema:"project_id,required"`
+		TeamName  string `schema:"team_name,required"`
+		Role      string `schema:"role,required"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	role, err := rbac.WorkspaceRoleFromString(params.Role)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	err = h.svc.SetProjectPermission(r.Context(), params.ProjectID, params.TeamName, role)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	html.FlashSuccess(w, "updated project permissions")
+	http.Redirect(w, r, paths.Project(params.ProjectID), http.StatusFound)
+}
+
+func (h *webHandlers) unsetProjectPermission(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		ProjectID string `schema:"project_id,required"`
+		TeamName  string `schema:"team_name,required"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	err := h.svc.UnsetProjectPermission(r.Context(), params.ProjectID, params.TeamName)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	html.FlashSuccess(w, "deleted project permission")
+	http.Redirect(w, r, paths.Project(params.ProjectID), http.StatusFound)
+}
+
+// filterUnassigned removes from the list of teams those that have been
+// assigned a permission on the project.
+//
+// NOTE: the owners team is always removed because it already possesses
+// admin privileges on all workspaces.
+func filterUnassigned(perms []internal.WorkspacePermission, teams []*auth.Team) (unassigned []*auth.Team) {
+	assigned := make(map[string]struct{}, len(perms))
+	for _, p := range perms {
+		assigned[p.Team] = struct{}{}
+	}
+	for _, t := range teams {
+		if t.Name == "owners" {
+			continue
+		}
+		if _, ok := assigned[t.Name]; !ok {
+			unassigned = append(unassigned, t)
+		}
+	}
+	return
+}
@@ -0,0 +1,81 @@
+package project
+
+import (
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/antchfx/htmlquery"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/auth"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWeb_CreateProject(t *testing.T) {
+	h := newFakeWeb(t, &fakeService{})
+
+	form := strings.NewReader(url.Values{
+		"organization_name": {"acme-corp"},
+		"name":              {"networking"},
+	}.Encode())
+	r := httptest.NewRequest("POST", "/?", form)
+	r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	h.createProject(w, r)
+
+	if assert.Equal(t, 302, w.Code) {
+		redirect, _ := w.Result().Location()
+		assert.True(t, strings.HasPrefix(redirect.Path, "/app/projects/prj-"))
+	}
+}
+
+func TestWeb_GetProject(t *testing.T) {
+	owners := &auth.Team{Name: "owners", Organization: "acme-corp"}
+	devs := &auth.Team{Name: "devs", Organization: "acme-corp"}
+	ops := &auth.Team{Name: "ops", Organization: "acme-corp"}
+	h := newFakeWeb(t, &fakeService{
+		project: &Project{ID: "prj-123", Name: "networking", Organization: "acme-corp"},
+		teams:   []*auth.Team{owners, devs, ops},
+		perms: []internal.WorkspacePermission{
+			{Team: "devs", Role: rbac.WorkspaceWriteRole},
+		},
+	})
+
+	r := httptest.NewRequest("GET", "/?project_id=prj-123", nil)
+	r = r.WithContext(internal.AddSubjectToContext(r.Context(), &auth.SiteAdmin))
+	w := httptest.NewRecorder()
+	h.getProject(w, r)
+	assert.Equal(t, 200, w.Code, w.Body.String())
+
+	doc, err := htmlquery.Parse(w.Body)
+	require.NoError(t, err)
+
+	// devs team is already assigned a permission and the owners team
+	// implicitly has all permissions, so only the ops team should be available
+	// in the dropdown
+	assert.NotNil(t, htmlquery.FindOne(doc, `//select[@id='permissions-add-select-team']/option[@value='ops']`))
+	assert.Nil(t, htmlquery.FindOne(doc, `//select[@id='permissions-add-select-team']/option[@value='devs']`))
+	assert.Nil(t, htmlquery.FindOne(doc, `//select[@id='permissions-add-select-team']/option[@value='owners']`))
+}
+
+func TestWeb_SetProjectPermission(t *testing.T) {
+	svc := &fakeService{}
+	h := newFakeWeb(t, svc)
+
+	form := strings.NewReader(url.Values{
+		"project_id": {"prj-123"},
+		"team_name":  {"devs"},
+		"role":       {"plan"},
+	}.Encode())
+	r := httptest.NewRequest("POST", "/?", form)
+	r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	h.setProjectPermission(w, r)
+
+	testutils.AssertRedirect(t, w, "/app/projects/prj-123")
+	assert.Equal(t, rbac.WorkspacePlanRole, svc.role)
+}
@@ -0,0 +1,239 @@
+package provider
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/http/decode"
+	"github.com/leg100/otf/internal/tfeapi"
+	"github.com/leg100/surl"
+)
+
+type api struct {
+	*surl.Signer
+
+	svc Service
+}
+
+func (h *api) addHandlers(r *mux.Router) {
+	// signed routes
+	signed := r.PathPrefix("/signed/{signature.expiry}").Subrouter()
+	signed.Use(internal.VerifySignedURL(h.Signer))
+	signed.HandleFunc("/providers/platforms/{platform_id}.zip", h.downloadPlatform).Methods("GET")
+	signed.HandleFunc("/providers/versions/{version_id}/SHA256SUMS", h.downloadSHASums).Methods("GET")
+	signed.HandleFunc("/providers/versions/{version_id}/SHA256SUMS.sig", h.downloadSHASumsSignature).Methods("GET")
+
+	// authenticated provider api routes
+	//
+	// Implements the Provider Registry Protocol:
+	//
+	// https://developer.hashicorp.com/terraform/internals/provider-registry-protocol
+	r = r.PathPrefix(tfeapi.ProviderV1Prefix).Subrouter()
+
+	r.HandleFunc("/{namespace}/{type}/versions", h.listAvailableVersions).Methods("GET")
+	r.HandleFunc("/{namespace}/{type}/{version}/download/{os}/{arch}", h.findPackage).Methods("GET")
+}
+
+type (
+	listAvailableVersionsResponse struct {
+		Versions []listAvailableVersionsVersion `json:"versions"`
+	}
+	listAvailableVersionsVersion struct {
+		Version   string                          `json:"version"`
+		Protocols []string                        `json:"protocols"`
+		Platforms []listAvailableVersionsPlatform `json:"platforms"`
+	}
+	listAvailableVersionsPlatform struct {
+		OS   string `json:"os"`
+		Arch string `json:"arch"`
+	}
+
+	findPackageResponse struct {
+		Protocols           []string           `json:"protocols"`
+		OS                  string             `json:"os"`
+		Arch                string             `json:"arch"`
+		Filename            string             `json:"filename"`
+		DownloadURL         string             `json:"download_url"`
+		SHASumsURL          string             `json:"shasums_url"`
+		SHASumsSignatureURL string             `json:"shasums_signature_url"`
+		SHASum              string             `json:"shasum"`
+		SigningKeys         findPackageSigning `json:"signing_keys"`
+	}
+	findPackageSigning struct {
+		GPGPublicKeys []findPackageGPGKey `json:"gpg_public_keys"`
+	}
+	findPackageGPGKey struct {
+		KeyID      string `json:"key_id"`
+		ASCIIArmor string `json:"ascii_armor"`
+	}
+)
+
+// List Available Versions for a Specific Provider.
+//
+// https://developer.hashicorp.com/terraform/internals/provider-registry-protocol#list-available-versions
+func (h *api) listAvailableVersions(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Namespace string `schema:"namespace,required"`
+		Type      string `schema:"type,required"`
+	}
+	if err := decode.Route(&params, r); err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	prov, err := h.svc.GetProvider(r.Context(), GetOptions{
+		Organization: params.Namespace,
+		Name:         params.Type,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	response := listAvailableVersionsResponse{
+		Versions: []listAvailableVersionsVersion{},
+	}
+	for _, ver := range prov.AvailableVersions() {
+		v := listAvailableVersionsVersion{
+			Version:   ver.Version,
+			Protocols: ver.Protocols,
+		}
+		for _, platform := range ver.Platforms {
+			v.Platforms = append(v.Platforms, listAvailableVersionsPlatform{
+				OS:   platform.OS,
+				Arch: platform.Arch,
+			})
+		}
+		response.Versions = append(response.Versions, v)
+	}
+
+	w.Header().Set("Content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// Find a Provider Package.
+//
+// https://developer.hashicorp.com/terraform/internals/provider-registry-protocol#find-a-provider-package
+func (h *api) findPackage(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Namespace string `schema:"namespace,required"`
+		Type      string `schema:"type,required"`
+		Version   string `schema:"version,required"`
+		OS        string `schema:"os,required"`
+		Arch      string `schema:"arch,required"`
+	}
+	if err := decode.Route(&params, r); err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	prov, err := h.svc.GetProvider(r.Context(), GetOptions{
+		Organization: params.Namespace,
+		Name:         params.Type,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	ver := prov.Version(params.Version)
+	if ver == nil {
+		http.Error(w, "version not found", http.StatusNotFound)
+		return
+	}
+	platform := ver.Platform(params.OS, params.Arch)
+	if platform == nil {
+		http.Error(w, "platform not found", http.StatusNotFound)
+		return
+	}
+	key, err := h.svc.getGPGKey(r.Context(), ver.GPGKeyID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var urls [3]string
+	for i, path := range []string{
+		fmt.Sprintf("/providers/platforms/%s.zip", platform.ID),
+		fmt.Sprintf("/providers/versions/%s/SHA256SUMS", ver.ID),
+		fmt.Sprintf("/providers/versions/%s/SHA256SUMS.sig", ver.ID),
+	} {
+		urls[i], err = h.Sign(path, time.Hour)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	response := findPackageResponse{
+		Protocols:           ver.Protocols,
+		OS:                  platform.OS,
+		Arch:                platform.Arch,
+		Filename:            platform.Filename,
+		DownloadURL:         urls[0],
+		SHASumsURL:          urls[1],
+		SHASumsSignatureURL: urls[2],
+		SHASum:              platform.SHASum,
+		SigningKeys: findPackageSigning{
+			GPGPublicKeys: []findPackageGPGKey{
+				{KeyID: key.KeyID, ASCIIArmor: key.ASCIIArmor},
+			},
+		},
+	}
+
+	w.Header().Set("Content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (h *api) downloadPlatform(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("platform_id", r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	binary, err := h.svc.downloadPlatform(r.Context(), id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-type", "application/zip")
+	w.Write(binary)
+}
+
+func (h *api) downloadSHASums(w http.ResponseWriter, r *http.Request) {
+	h.writeSHASums(w, r, func(ver *Version) []byte { return ver.SHASums })
+}
+
+func (h *api) downloadSHASumsSignature(w http.ResponseWriter, r *http.Request) {
+	h.writeSHASums(w, r, func(ver *Version) []byte { return ver.SHASumsSignature })
+}
+
+func (h *api) writeSHASums(w http.ResponseWriter, r *http.Request, contents func(*Version) []byte) {
+	id, err := decode.Param("version_id", r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	ver, err := h.svc.downloadSHASums(r.Context(), id)
+	if errors.Is(err, internal.ErrResourceNotFound) || ver == nil {
+		http.Error(w, "version not found", http.StatusNotFound)
+		return
+	} else if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-type", "application/octet-stream")
+	w.Write(contents(ver))
+}
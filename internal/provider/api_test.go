@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPI(t *testing.T) {
+	prov := &Provider{
+		ID:           "prov-123",
+		Organization: "acme",
+		Name:         "null",
+		Versions: []*Version{
+			{
+				ID:               "provver-2",
+				Version:          "2.0.0",
+				Protocols:        []string{"5.0"},
+				GPGKeyID:         "gpg-123",
+				SHASums:          []byte("sums"),
+				SHASumsSignature: []byte("signature"),
+				Platforms: []*Platform{
+					{ID: "provplat-1", OS: "linux", Arch: "amd64", Filename: "terraform-provider-null_2.0.0_linux_amd64.zip", SHASum: "abc"},
+				},
+			},
+			// version without any binaries is not available
+			{ID: "provver-1", Version: "1.0.0", Protocols: []string{"5.0"}},
+		},
+	}
+	svc := &fakeService{
+		prov:   prov,
+		key:    &GPGKey{ID: "gpg-123", KeyID: "ABCDEF", ASCIIArmor: "armor"},
+		binary: []byte("binary"),
+	}
+	r := mux.NewRouter()
+	(&api{Signer: internal.NewSigner([]byte("secret")), svc: svc}).addHandlers(r)
+
+	t.Run("list available versions", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", "/v1/providers/acme/null/versions", nil))
+		require.Equal(t, 200, w.Code, w.Body.String())
+
+		var got listAvailableVersionsResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&got))
+		assert.Equal(t, listAvailableVersionsResponse{
+			Versions: []listAvailableVersionsVersion{
+				{
+					Version:   "2.0.0",
+					Protocols: []string{"5.0"},
+					Platforms: []listAvailableVersionsPlatform{{OS: "linux", Arch: "amd64"}},
+				},
+			},
+		}, got)
+	})
+
+	t.Run("find package and download", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", "/v1/providers/acme/null/2.0.0/download/linux/amd64", nil))
+		require.Equal(t, 200, w.Code, w.Body.String())
+
+		var got findPackageResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&got))
+		assert.Equal(t, "terraform-provider-null_2.0.0_linux_amd64.zip", got.Filename)
+		assert.Equal(t, "abc", got.SHASum)
+		assert.Equal(t, []findPackageGPGKey{{KeyID: "ABCDEF", ASCIIArmor: "armor"}}, got.SigningKeys.GPGPublicKeys)
+
+		for url, want := range map[string]string{
+			got.DownloadURL:         "binary",
+			got.SHASumsURL:          "sums",
+			got.SHASumsSignatureURL: "signature",
+		} {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest("GET", url, nil))
+			assert.Equal(t, 200, w.Code, url)
+			assert.Equal(t, want, w.Body.String())
+		}
+	})
+
+	t.Run("unsigned download", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", "/signed/abc/providers/platforms/provplat-1.zip", nil))
+		assert.Equal(t, 401, w.Code)
+	})
+
+	t.Run("missing platform", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", "/v1/providers/acme/null/2.0.0/download/darwin/arm64", nil))
+		assert.Equal(t, 404, w.Code)
+	})
+}
+
+type fakeService struct {
+	prov   *Provider
+	key    *GPGKey
+	binary []byte
+
+	Service
+}
+
+func (f *fakeService) GetProvider(context.Context, GetOptions) (*Provider, error) {
+	return f.prov, nil
+}
+
+func (f *fakeService) GetProviderByID(context.Context, string) (*Provider, error) {
+	return f.prov, nil
+}
+
+func (f *fakeService) ListGPGKeys(context.Context, string) ([]*GPGKey, error) {
+	return []*GPGKey{f.key}, nil
+}
+
+func (f *fakeService) getGPGKey(context.Context, string) (*GPGKey, error) {
+	return f.key, nil
+}
+
+func (f *fakeService) downloadPlatform(context.Context, string) ([]byte, error) {
+	return f.binary, nil
+}
+
+func (f *fakeService) downloadSHASums(_ context.Context, versionID string) (*Version, error) {
+	return f.prov.VersionByID(versionID), nil
+}
@@ -0,0 +1,237 @@
+package provider
+
+import (
+	"context"
+	"sort"
+
+	"github.com/leg100/otf/internal/semver"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/sql/pggen"
+)
+
+type (
+	// pgdb is the provider registry database on postgres
+	pgdb struct {
+		*sql.DB // provides access to generated SQL queries
+	}
+
+	providerRow    pggen.FindRegistryProviderByIDRow
+	versionRow     pggen.FindRegistryProviderVersionsByProviderIDRow
+	platformRow    pggen.FindRegistryProviderPlatformByIDRow
+	gpgKeyRow      pggen.FindRegistryGPGKeyByIDRow
+	providerGetter func(ctx context.Context) (pggen.FindRegistryProviderByIDRow, error)
+)
+
+func (db *pgdb) createGPGKey(ctx context.Context, key *GPGKey) error {
+	_, err := db.Conn(ctx).InsertRegistryGPGKey(ctx, pggen.InsertRegistryGPGKeyParams{
+		GpgKeyID:         sql.String(key.ID),
+		OrganizationName: sql.String(key.Organization),
+		KeyID:            sql.String(key.KeyID),
+		AsciiArmor:       sql.String(key.ASCIIArmor),
+		CreatedAt:        sql.Timestamptz(key.CreatedAt),
+	})
+	return sql.Error(err)
+}
+
+func (db *pgdb) listGPGKeys(ctx context.Context, organization string) ([]*GPGKey, error) {
+	rows, err := db.Conn(ctx).FindRegistryGPGKeysByOrganization(ctx, sql.String(organization))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	keys := make([]*GPGKey, len(rows))
+	for i, r := range rows {
+		keys[i] = gpgKeyRow(r).toGPGKey()
+	}
+	return keys, nil
+}
+
+func (db *pgdb) getGPGKey(ctx context.Context, id string) (*GPGKey, error) {
+	row, err := db.Conn(ctx).FindRegistryGPGKeyByID(ctx, sql.String(id))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return gpgKeyRow(row).toGPGKey(), nil
+}
+
+func (db *pgdb) deleteGPGKey(ctx context.Context, id string) error {
+	_, err := db.Conn(ctx).DeleteRegistryGPGKeyByID(ctx, sql.String(id))
+	return sql.Error(err)
+}
+
+func (db *pgdb) createProvider(ctx context.Context, prov *Provider) error {
+	_, err := db.Conn(ctx).InsertRegistryProvider(ctx, pggen.InsertRegistryProviderParams{
+		RegistryProviderID: sql.String(prov.ID),
+		OrganizationName:   sql.String(prov.Organization),
+		Name:               sql.String(prov.Name),
+		CreatedAt:          sql.Timestamptz(prov.CreatedAt),
+	})
+	return sql.Error(err)
+}
+
+func (db *pgdb) listProviders(ctx context.Context, organization string) ([]*Provider, error) {
+	rows, err := db.Conn(ctx).FindRegistryProvidersByOrganization(ctx, sql.String(organization))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	providers := make([]*Provider, len(rows))
+	for i, r := range rows {
+		providers[i] = providerRow(r).toProvider()
+	}
+	return providers, nil
+}
+
+func (db *pgdb) getProvider(ctx context.Context, opts GetOptions) (*Provider, error) {
+	return db.getProviderWithVersions(ctx, func(ctx context.Context) (pggen.FindRegistryProviderByIDRow, error) {
+		row, err := db.Conn(ctx).FindRegistryProviderByName(ctx, sql.String(opts.Organization), sql.String(opts.Name))
+		return pggen.FindRegistryProviderByIDRow(row), err
+	})
+}
+
+func (db *pgdb) getProviderByID(ctx context.Context, id string) (*Provider, error) {
+	return db.getProviderWithVersions(ctx, func(ctx context.Context) (pggen.FindRegistryProviderByIDRow, error) {
+		return db.Conn(ctx).FindRegistryProviderByID(ctx, sql.String(id))
+	})
+}
+
+func (db *pgdb) getProviderByVersionID(ctx context.Context, versionID string) (*Provider, error) {
+	return db.getProviderWithVersions(ctx, func(ctx context.Context) (pggen.FindRegistryProviderByIDRow, error) {
+		row, err := db.Conn(ctx).FindRegistryProviderByVersionID(ctx, sql.String(versionID))
+		return pggen.FindRegistryProviderByIDRow(row), err
+	})
+}
+
+// getProviderWithVersions retrieves a provider along with its versions and
+// their platforms.
+func (db *pgdb) getProviderWithVersions(ctx context.Context, getter providerGetter) (*Provider, error) {
+	row, err := getter(ctx)
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	prov := providerRow(row).toProvider()
+
+	versions, err := db.Conn(ctx).FindRegistryProviderVersionsByProviderID(ctx, sql.String(prov.ID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	platforms, err := db.Conn(ctx).FindRegistryProviderPlatformsByProviderID(ctx, sql.String(prov.ID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	for _, r := range versions {
+		prov.Versions = append(prov.Versions, versionRow(r).toVersion())
+	}
+	for _, r := range platforms {
+		platform := platformRow(r).toPlatform()
+		if ver := prov.VersionByID(platform.VersionID); ver != nil {
+			ver.Platforms = append(ver.Platforms, platform)
+		}
+	}
+	// sort versions in descending order
+	sort.Slice(prov.Versions, func(i, j int) bool {
+		return semver.Compare(prov.Versions[i].Version, prov.Versions[j].Version) > 0
+	})
+	return prov, nil
+}
+
+func (db *pgdb) deleteProvider(ctx context.Context, id string) error {
+	_, err := db.Conn(ctx).DeleteRegistryProviderByID(ctx, sql.String(id))
+	return sql.Error(err)
+}
+
+func (db *pgdb) createVersion(ctx context.Context, ver *Version) error {
+	_, err := db.Conn(ctx).InsertRegistryProviderVersion(ctx, pggen.InsertRegistryProviderVersionParams{
+		RegistryProviderVersionID: sql.String(ver.ID),
+		RegistryProviderID:        sql.String(ver.ProviderID),
+		Version:                   sql.String(ver.Version),
+		Protocols:                 ver.Protocols,
+		GpgKeyID:                  sql.String(ver.GPGKeyID),
+		Shasums:                   ver.SHASums,
+		ShasumsSignature:          ver.SHASumsSignature,
+		CreatedAt:                 sql.Timestamptz(ver.CreatedAt),
+	})
+	return sql.Error(err)
+}
+
+func (db *pgdb) deleteVersion(ctx context.Context, id string) error {
+	_, err := db.Conn(ctx).DeleteRegistryProviderVersionByID(ctx, sql.String(id))
+	return sql.Error(err)
+}
+
+func (db *pgdb) createPlatform(ctx context.Context, platform *Platform, binary []byte) error {
+	_, err := db.Conn(ctx).InsertRegistryProviderPlatform(ctx, pggen.InsertRegistryProviderPlatformParams{
+		RegistryProviderPlatformID: sql.String(platform.ID),
+		RegistryProviderVersionID:  sql.String(platform.VersionID),
+		Os:                         sql.String(platform.OS),
+		Arch:                       sql.String(platform.Arch),
+		Filename:                   sql.String(platform.Filename),
+		Shasum:                     sql.String(platform.SHASum),
+		Binary:                     binary,
+		CreatedAt:                  sql.Timestamptz(platform.CreatedAt),
+	})
+	return sql.Error(err)
+}
+
+func (db *pgdb) getPlatform(ctx context.Context, id string) (*Platform, error) {
+	row, err := db.Conn(ctx).FindRegistryProviderPlatformByID(ctx, sql.String(id))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return platformRow(row).toPlatform(), nil
+}
+
+func (db *pgdb) getPlatformBinary(ctx context.Context, id string) ([]byte, error) {
+	binary, err := db.Conn(ctx).FindRegistryProviderPlatformBinary(ctx, sql.String(id))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return binary, nil
+}
+
+func (db *pgdb) deletePlatform(ctx context.Context, id string) error {
+	_, err := db.Conn(ctx).DeleteRegistryProviderPlatformByID(ctx, sql.String(id))
+	return sql.Error(err)
+}
+
+func (row gpgKeyRow) toGPGKey() *GPGKey {
+	return &GPGKey{
+		ID:           row.GpgKeyID.String,
+		Organization: row.OrganizationName.String,
+		KeyID:        row.KeyID.String,
+		ASCIIArmor:   row.AsciiArmor.String,
+		CreatedAt:    row.CreatedAt.Time.UTC(),
+	}
+}
+
+func (row providerRow) toProvider() *Provider {
+	return &Provider{
+		ID:           row.RegistryProviderID.String,
+		Organization: row.OrganizationName.String,
+		Name:         row.Name.String,
+		CreatedAt:    row.CreatedAt.Time.UTC(),
+	}
+}
+
+func (row versionRow) toVersion() *Version {
+	return &Version{
+		ID:               row.RegistryProviderVersionID.String,
+		ProviderID:       row.RegistryProviderID.String,
+		Version:          row.Version.String,
+		Protocols:        row.Protocols,
+		GPGKeyID:         row.GpgKeyID.String,
+		SHASums:          row.Shasums,
+		SHASumsSignature: row.ShasumsSignature,
+		CreatedAt:        row.CreatedAt.Time.UTC(),
+	}
+}
+
+func (row platformRow) toPlatform() *Platform {
+	return &Platform{
+		ID:        row.RegistryProviderPlatformID.String,
+		VersionID: row.RegistryProviderVersionID.String,
+		OS:        row.Os.String,
+		Arch:      row.Arch.String,
+		Filename:  row.Filename.String,
+		SHASum:    row.Shasum.String,
+		CreatedAt: row.CreatedAt.Time.UTC(),
+	}
+}
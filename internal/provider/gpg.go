@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/leg100/otf/internal"
+)
+
+var (
+	ErrInvalidGPGKey    = errors.New("ascii armor must contain exactly one public key")
+	ErrInvalidSignature = errors.New("SHA256SUMS signature was not made by the signing key")
+	ErrInvalidSHASums   = errors.New("SHA256SUMS must contain lines of a hex-encoded sha256 checksum followed by a filename")
+)
+
+type (
+	// GPGKey is the public key of a GPG key pair, with which an organization
+	// signs the checksums of its providers' binaries.
+	GPGKey struct {
+		ID           string
+		Organization string
+		KeyID        string // hex-encoded ID of the primary key
+		ASCIIArmor   string
+		CreatedAt    time.Time
+	}
+
+	CreateGPGKeyOptions struct {
+		Organization string `schema:"organization_name,required"`
+		ASCIIArmor   string `schema:"ascii_armor,required"`
+	}
+)
+
+func newGPGKey(opts CreateGPGKeyOptions) (*GPGKey, error) {
+	entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(opts.ASCIIArmor))
+	if err != nil {
+		return nil, fmt.Errorf("reading ascii armor: %w", err)
+	}
+	if len(entities) != 1 {
+		return nil, ErrInvalidGPGKey
+	}
+	return &GPGKey{
+		ID:           internal.NewID("gpg"),
+		Organization: opts.Organization,
+		KeyID:        entities[0].PrimaryKey.KeyIdString(),
+		ASCIIArmor:   opts.ASCIIArmor,
+		CreatedAt:    internal.CurrentTimestamp(nil),
+	}, nil
+}
+
+// verify checks the binary detached signature of the signed content was made
+// by the key.
+func (k *GPGKey) verify(signed, signature []byte) error {
+	keyring, err := openpgp.ReadArmoredKeyRing(strings.NewReader(k.ASCIIArmor))
+	if err != nil {
+		return err
+	}
+	if _, err := openpgp.CheckDetachedSignature(keyring, bytes.NewReader(signed), bytes.NewReader(signature), nil); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+	return nil
+}
+
+func (k *GPGKey) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("id", k.ID),
+		slog.String("organization", k.Organization),
+		slog.String("key_id", k.KeyID),
+	)
+}
+
+// parseSHASums parses the contents of a SHA256SUMS file, returning a map of
+// filename to hex-encoded checksum.
+func parseSHASums(contents []byte) (map[string]string, error) {
+	sums := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, ErrInvalidSHASums
+		}
+		if sum, err := hex.DecodeString(fields[0]); err != nil || len(sum) != 32 {
+			return nil, ErrInvalidSHASums
+		}
+		sums[fields[1]] = strings.ToLower(fields[0])
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(sums) == 0 {
+		return nil, ErrInvalidSHASums
+	}
+	return sums, nil
+}
@@ -0,0 +1,511 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal/http/decode"
+	"github.com/leg100/otf/internal/semver"
+	"github.com/leg100/otf/internal/tfeapi"
+)
+
+// DefaultMirrorDir is the default directory in which the provider network
+// mirror stores provider packages.
+var DefaultMirrorDir = filepath.Join(os.TempDir(), "otf-provider-mirror")
+
+// DefaultMirrorUpstreamHosts are the default hostnames of upstream registries
+// from which the provider network mirror retrieves provider packages.
+var DefaultMirrorUpstreamHosts = []string{"registry.terraform.io"}
+
+var (
+	// hostnames of upstream registries, e.g. registry.terraform.io
+	reMirrorHostname = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]*(:[0-9]+)?$`)
+	// provider namespaces, e.g. hashicorp
+	reMirrorNamespace = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9-]*$`)
+
+	errMirrorNotFound = errors.New("provider package not found in mirror")
+)
+
+type (
+	// MirrorConfig configures the provider network mirror.
+	MirrorConfig struct {
+		// Dir is the directory in which provider packages are stored, using
+		// the same layout as `terraform providers mirror`, i.e.
+		// HOSTNAME/NAMESPACE/TYPE/terraform-provider-TYPE_VERSION_OS_ARCH.zip.
+		// It can be pre-seeded with packages.
+		Dir string
+		// Offline disables retrieving packages from upstream registries, only
+		// serving those packages already in Dir.
+		Offline bool
+		// UpstreamHosts are the hostnames of the upstream registries from
+		// which packages are retrieved. Packages for providers with any other
+		// hostname are only served if they are already in Dir.
+		UpstreamHosts []string
+	}
+
+	// mirror implements the provider network mirror protocol, serving
+	// provider packages from a local directory, and caching packages from
+	// upstream registries on first request.
+	//
+	// https://developer.hashicorp.com/terraform/internals/provider-network-mirror-protocol
+	mirror struct {
+		logr.Logger
+		MirrorConfig
+
+		client *http.Client // for retrieving packages from upstream registries
+	}
+
+	// mirrorProvider identifies a provider in the mirror.
+	mirrorProvider struct {
+		Hostname  string `schema:"hostname,required"`
+		Namespace string `schema:"namespace,required"`
+		Type      string `schema:"type,required"`
+	}
+
+	mirrorIndexResponse struct {
+		Versions map[string]struct{} `json:"versions"`
+	}
+	mirrorVersionResponse struct {
+		Archives map[string]mirrorArchive `json:"archives"`
+	}
+	mirrorArchive struct {
+		URL    string   `json:"url"`
+		Hashes []string `json:"hashes,omitempty"`
+	}
+)
+
+func (m *mirror) addHandlers(r *mux.Router) {
+	r = r.PathPrefix(tfeapi.ProviderMirrorPrefix).Subrouter()
+
+	r.HandleFunc("/{hostname}/{namespace}/{type}/index.json", m.listVersions).Methods("GET")
+	r.HandleFunc("/{hostname}/{namespace}/{type}/{version}.json", m.listInstallationPackages).Methods("GET")
+	r.HandleFunc("/{hostname}/{namespace}/{type}/{filename:terraform-provider-.+\\.zip}", m.download).Methods("GET")
+}
+
+// List Available Versions
+//
+// https://developer.hashicorp.com/terraform/internals/provider-network-mirror-protocol#list-available-versions
+func (m *mirror) listVersions(w http.ResponseWriter, r *http.Request) {
+	prov, err := decodeMirrorProvider(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	local, err := m.localPackages(prov)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	response := mirrorIndexResponse{Versions: make(map[string]struct{})}
+	for version := range local {
+		response.Versions[version] = struct{}{}
+	}
+	if m.upstream(prov.Hostname) {
+		upstream, err := m.upstreamVersions(r.Context(), prov)
+		if err != nil {
+			// fallback to serving only those versions in the mirror
+			m.Error(err, "listing upstream provider versions", "provider", prov)
+		}
+		for _, ver := range upstream {
+			response.Versions[ver.Version] = struct{}{}
+		}
+	}
+	if len(response.Versions) == 0 {
+		http.Error(w, errMirrorNotFound.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// List Available Installation Packages
+//
+// https://developer.hashicorp.com/terraform/internals/provider-network-mirror-protocol#list-available-installation-packages
+func (m *mirror) listInstallationPackages(w http.ResponseWriter, r *http.Request) {
+	prov, err := decodeMirrorProvider(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	version, err := decode.Param("version", r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	local, err := m.localPackages(prov)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	response := mirrorVersionResponse{Archives: make(map[string]mirrorArchive)}
+	for platform, path := range local[version] {
+		sum, err := sha256File(path)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		response.Archives[platform] = mirrorArchive{
+			URL:    filepath.Base(path),
+			Hashes: []string{"zh:" + sum},
+		}
+	}
+	if m.upstream(prov.Hostname) {
+		upstream, err := m.upstreamVersions(r.Context(), prov)
+		if err != nil {
+			// fallback to serving only those packages in the mirror
+			m.Error(err, "listing upstream provider versions", "provider", prov)
+		}
+		for _, ver := range upstream {
+			if ver.Version != version {
+				continue
+			}
+			for _, p := range ver.Platforms {
+				platform := p.OS + "_" + p.Arch
+				if _, ok := response.Archives[platform]; ok {
+					continue
+				}
+				// package is retrieved from upstream upon download, at which
+				// point its checksum is verified.
+				response.Archives[platform] = mirrorArchive{
+					URL: prov.filename(version, p.OS, p.Arch),
+				}
+			}
+		}
+	}
+	if len(response.Archives) == 0 {
+		http.Error(w, errMirrorNotFound.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (m *mirror) download(w http.ResponseWriter, r *http.Request) {
+	prov, err := decodeMirrorProvider(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	filename, err := decode.Param("filename", r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	version, goos, goarch, ok := prov.parseFilename(filename)
+	if !ok {
+		http.Error(w, "invalid provider package filename", http.StatusUnprocessableEntity)
+		return
+	}
+
+	path := filepath.Join(prov.dir(m.Dir), filename)
+	if _, err := os.Stat(path); err != nil {
+		if !m.upstream(prov.Hostname) {
+			http.Error(w, errMirrorNotFound.Error(), http.StatusNotFound)
+			return
+		}
+		if err := m.fetch(r.Context(), prov, version, goos, goarch); err != nil {
+			m.Error(err, "retrieving provider package from upstream", "provider", prov, "filename", filename)
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		m.V(1).Info("cached provider package from upstream", "provider", prov, "filename", filename)
+	}
+
+	w.Header().Set("Content-type", "application/zip")
+	http.ServeFile(w, r, path)
+}
+
+// upstream determines whether packages for providers with the hostname can be
+// retrieved from their upstream registry. Only permitted hostnames are
+// contacted, otherwise anyone able to reach the mirror could have otfd send
+// requests to arbitrary hosts.
+func (m *mirror) upstream(hostname string) bool {
+	return !m.Offline && slices.Contains(m.UpstreamHosts, hostname)
+}
+
+// localPackages returns the paths of the provider's packages in the mirror
+// directory, keyed by version and then by platform, e.g. linux_amd64.
+func (m *mirror) localPackages(prov mirrorProvider) (map[string]map[string]string, error) {
+	entries, err := os.ReadDir(prov.dir(m.Dir))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	packages := make(map[string]map[string]string)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		version, goos, goarch, ok := prov.parseFilename(entry.Name())
+		if !ok {
+			// skip index.json etc written by `terraform providers mirror`
+			continue
+		}
+		if packages[version] == nil {
+			packages[version] = make(map[string]string)
+		}
+		packages[version][goos+"_"+goarch] = filepath.Join(prov.dir(m.Dir), entry.Name())
+	}
+	return packages, nil
+}
+
+// upstreamVersions lists the provider's versions in its upstream registry.
+func (m *mirror) upstreamVersions(ctx context.Context, prov mirrorProvider) ([]listAvailableVersionsVersion, error) {
+	endpoint, err := m.discover(ctx, prov.Hostname)
+	if err != nil {
+		return nil, err
+	}
+	u, err := endpoint.Parse(fmt.Sprintf("%s/%s/versions", prov.Namespace, prov.Type))
+	if err != nil {
+		return nil, err
+	}
+	var response listAvailableVersionsResponse
+	if err := m.getJSON(ctx, u, &response); err != nil {
+		return nil, err
+	}
+	return response.Versions, nil
+}
+
+// fetch retrieves a provider package from its upstream registry and writes it
+// to the mirror directory, verifying its checksum and that its checksum has
+// been signed by one of the provider's signing keys.
+func (m *mirror) fetch(ctx context.Context, prov mirrorProvider, version, goos, goarch string) error {
+	endpoint, err := m.discover(ctx, prov.Hostname)
+	if err != nil {
+		return err
+	}
+	u, err := endpoint.Parse(fmt.Sprintf("%s/%s/%s/download/%s/%s", prov.Namespace, prov.Type, version, goos, goarch))
+	if err != nil {
+		return err
+	}
+	var pkg findPackageResponse
+	if err := m.getJSON(ctx, u, &pkg); err != nil {
+		return err
+	}
+	if pkg.Filename != prov.filename(version, goos, goarch) {
+		return fmt.Errorf("upstream registry returned unexpected filename: %s", pkg.Filename)
+	}
+
+	// verify checksum in SHA256SUMS matches that reported by registry.
+	shasums, err := m.getBytes(ctx, u, pkg.SHASumsURL)
+	if err != nil {
+		return fmt.Errorf("retrieving SHA256SUMS: %w", err)
+	}
+	signature, err := m.getBytes(ctx, u, pkg.SHASumsSignatureURL)
+	if err != nil {
+		return fmt.Errorf("retrieving SHA256SUMS signature: %w", err)
+	}
+	if err := verifyWithAny(pkg.SigningKeys.GPGPublicKeys, shasums, signature); err != nil {
+		return err
+	}
+	sums, err := parseSHASums(shasums)
+	if err != nil {
+		return err
+	}
+	if want, ok := sums[pkg.Filename]; !ok || want != strings.ToLower(pkg.SHASum) {
+		return fmt.Errorf("checksum for %s does not match entry in SHA256SUMS", pkg.Filename)
+	}
+
+	// download package to temporary file and only move it into place once its
+	// checksum has been verified.
+	dst := filepath.Join(prov.dir(m.Dir), pkg.Filename)
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(dst), pkg.Filename+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	body, err := m.get(ctx, u, pkg.DownloadURL)
+	if err != nil {
+		return fmt.Errorf("downloading provider package: %w", err)
+	}
+	defer body.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(tmp, hash), body); err != nil {
+		return fmt.Errorf("downloading provider package: %w", err)
+	}
+	if hex.EncodeToString(hash.Sum(nil)) != strings.ToLower(pkg.SHASum) {
+		return fmt.Errorf("checksum of downloaded %s does not match entry in SHA256SUMS", pkg.Filename)
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), dst)
+}
+
+// discover returns the URL of the providers.v1 service of the registry at the
+// hostname.
+//
+// https://developer.hashicorp.com/terraform/internals/remote-service-discovery
+func (m *mirror) discover(ctx context.Context, hostname string) (*url.URL, error) {
+	if !m.upstream(hostname) {
+		return nil, fmt.Errorf("retrieving packages from %s is not permitted", hostname)
+	}
+	u := &url.URL{Scheme: "https", Host: hostname, Path: "/.well-known/terraform.json"}
+	var services struct {
+		Providers string `json:"providers.v1"`
+	}
+	if err := m.getJSON(ctx, u, &services); err != nil {
+		return nil, err
+	}
+	if services.Providers == "" {
+		return nil, fmt.Errorf("%s does not provide a provider registry", hostname)
+	}
+	endpoint, err := u.Parse(services.Providers)
+	if err != nil {
+		return nil, err
+	}
+	// ensure relative paths are resolved beneath the endpoint
+	if !strings.HasSuffix(endpoint.Path, "/") {
+		endpoint.Path += "/"
+	}
+	return endpoint, nil
+}
+
+func (m *mirror) getJSON(ctx context.Context, u *url.URL, v any) error {
+	body, err := m.get(ctx, u, "")
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+	return json.NewDecoder(body).Decode(v)
+}
+
+func (m *mirror) getBytes(ctx context.Context, base *url.URL, ref string) ([]byte, error) {
+	body, err := m.get(ctx, base, ref)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	return io.ReadAll(body)
+}
+
+// get sends a GET request to the URL reference, which is resolved relative to
+// the base URL, returning the response body.
+func (m *mirror) get(ctx context.Context, base *url.URL, ref string) (io.ReadCloser, error) {
+	u, err := base.Parse(ref)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("GET %s: %s", u.Redacted(), resp.Status)
+	}
+	return resp.Body, nil
+}
+
+// verifyWithAny verifies the signature was made by one of the keys.
+func verifyWithAny(keys []findPackageGPGKey, signed, signature []byte) error {
+	if len(keys) == 0 {
+		return errors.New("upstream registry provided no signing keys")
+	}
+	var err error
+	for _, k := range keys {
+		key := &GPGKey{KeyID: k.KeyID, ASCIIArmor: k.ASCIIArmor}
+		if err = key.verify(signed, signature); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+func sha256File(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func decodeMirrorProvider(r *http.Request) (mirrorProvider, error) {
+	var prov mirrorProvider
+	if err := decode.Route(&prov, r); err != nil {
+		return mirrorProvider{}, err
+	}
+	// terraform normalizes hostnames to lowercase
+	prov.Hostname = strings.ToLower(prov.Hostname)
+	if !reMirrorHostname.MatchString(prov.Hostname) ||
+		!reMirrorNamespace.MatchString(prov.Namespace) ||
+		!reProviderName.MatchString(prov.Type) {
+		return mirrorProvider{}, fmt.Errorf("invalid provider address: %s", prov)
+	}
+	return prov, nil
+}
+
+func (p mirrorProvider) String() string {
+	return fmt.Sprintf("%s/%s/%s", p.Hostname, p.Namespace, p.Type)
+}
+
+// dir returns the provider's directory within the mirror directory.
+func (p mirrorProvider) dir(root string) string {
+	return filepath.Join(root, p.Hostname, p.Namespace, p.Type)
+}
+
+// filename returns the filename of the provider's package for the given
+// version and platform.
+func (p mirrorProvider) filename(version, os, arch string) string {
+	return fmt.Sprintf("terraform-provider-%s_%s_%s_%s.zip", p.Type, version, os, arch)
+}
+
+// parseFilename parses the version and platform from the filename of one of
+// the provider's packages.
+func (p mirrorProvider) parseFilename(filename string) (version, os, arch string, ok bool) {
+	trimmed, found := strings.CutPrefix(filename, fmt.Sprintf("terraform-provider-%s_", p.Type))
+	if !found {
+		return "", "", "", false
+	}
+	trimmed, found = strings.CutSuffix(trimmed, ".zip")
+	if !found {
+		return "", "", "", false
+	}
+	parts := strings.Split(trimmed, "_")
+	if len(parts) != 3 {
+		return "", "", "", false
+	}
+	version, os, arch = parts[0], parts[1], parts[2]
+	if !semver.IsValid(version) || !rePlatformPart.MatchString(os) || !rePlatformPart.MatchString(arch) {
+		return "", "", "", false
+	}
+	return version, os, arch, true
+}
@@ -0,0 +1,224 @@
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMirror(t *testing.T) {
+	upstream, hostname := newTestUpstreamRegistry(t, []byte("upstream-binary"), []byte("upstream-binary"))
+
+	// pre-seed mirror with a different version
+	dir := t.TempDir()
+	seeded := filepath.Join(dir, hostname, "hashicorp", "null", "terraform-provider-null_1.0.0_linux_amd64.zip")
+	require.NoError(t, os.MkdirAll(filepath.Dir(seeded), 0o755))
+	require.NoError(t, os.WriteFile(seeded, []byte("seeded-binary"), 0o644))
+
+	newRouter := func(offline bool) *mux.Router {
+		r := mux.NewRouter()
+		m := &mirror{
+			Logger:       logr.Discard(),
+			MirrorConfig: MirrorConfig{Dir: dir, Offline: offline, UpstreamHosts: []string{hostname}},
+			client:       upstream.Client(),
+		}
+		m.addHandlers(r)
+		return r
+	}
+	base := fmt.Sprintf("/v1/mirror/providers/%s/hashicorp/null/", url.PathEscape(hostname))
+
+	t.Run("list versions", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		newRouter(false).ServeHTTP(w, httptest.NewRequest("GET", base+"index.json", nil))
+		require.Equal(t, 200, w.Code, w.Body.String())
+
+		var got mirrorIndexResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&got))
+		assert.Equal(t, map[string]struct{}{"1.0.0": {}, "2.0.0": {}}, got.Versions)
+	})
+
+	t.Run("list versions offline", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		newRouter(true).ServeHTTP(w, httptest.NewRequest("GET", base+"index.json", nil))
+		require.Equal(t, 200, w.Code, w.Body.String())
+
+		var got mirrorIndexResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&got))
+		assert.Equal(t, map[string]struct{}{"1.0.0": {}}, got.Versions)
+	})
+
+	t.Run("list seeded installation packages", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		newRouter(false).ServeHTTP(w, httptest.NewRequest("GET", base+"1.0.0.json", nil))
+		require.Equal(t, 200, w.Code, w.Body.String())
+
+		var got mirrorVersionResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&got))
+		assert.Equal(t, map[string]mirrorArchive{
+			"linux_amd64": {
+				URL:    "terraform-provider-null_1.0.0_linux_amd64.zip",
+				Hashes: []string{fmt.Sprintf("zh:%x", sha256.Sum256([]byte("seeded-binary")))},
+			},
+		}, got.Archives)
+	})
+
+	t.Run("list upstream installation packages", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		newRouter(false).ServeHTTP(w, httptest.NewRequest("GET", base+"2.0.0.json", nil))
+		require.Equal(t, 200, w.Code, w.Body.String())
+
+		var got mirrorVersionResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&got))
+		assert.Equal(t, map[string]mirrorArchive{
+			"linux_amd64": {URL: "terraform-provider-null_2.0.0_linux_amd64.zip"},
+		}, got.Archives)
+	})
+
+	t.Run("download seeded package", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		newRouter(true).ServeHTTP(w, httptest.NewRequest("GET", base+"terraform-provider-null_1.0.0_linux_amd64.zip", nil))
+		require.Equal(t, 200, w.Code, w.Body.String())
+		assert.Equal(t, "seeded-binary", w.Body.String())
+	})
+
+	t.Run("download upstream package offline", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		newRouter(true).ServeHTTP(w, httptest.NewRequest("GET", base+"terraform-provider-null_2.0.0_linux_amd64.zip", nil))
+		assert.Equal(t, 404, w.Code)
+	})
+
+	t.Run("download and cache upstream package", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		newRouter(false).ServeHTTP(w, httptest.NewRequest("GET", base+"terraform-provider-null_2.0.0_linux_amd64.zip", nil))
+		require.Equal(t, 200, w.Code, w.Body.String())
+		assert.Equal(t, "upstream-binary", w.Body.String())
+
+		// package should now be cached in the mirror
+		w = httptest.NewRecorder()
+		newRouter(true).ServeHTTP(w, httptest.NewRequest("GET", base+"terraform-provider-null_2.0.0_linux_amd64.zip", nil))
+		require.Equal(t, 200, w.Code, w.Body.String())
+		assert.Equal(t, "upstream-binary", w.Body.String())
+	})
+}
+
+func TestMirror_TamperedUpstreamPackage(t *testing.T) {
+	// serve a binary that differs from that listed in SHA256SUMS
+	upstream, hostname := newTestUpstreamRegistry(t, []byte("upstream-binary"), []byte("tampered"))
+
+	dir := t.TempDir()
+	r := mux.NewRouter()
+	(&mirror{
+		Logger:       logr.Discard(),
+		MirrorConfig: MirrorConfig{Dir: dir, UpstreamHosts: []string{hostname}},
+		client:       upstream.Client(),
+	}).addHandlers(r)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", fmt.Sprintf("/v1/mirror/providers/%s/hashicorp/null/terraform-provider-null_2.0.0_linux_amd64.zip", hostname), nil))
+	assert.Equal(t, http.StatusBadGateway, w.Code)
+	assert.NoFileExists(t, filepath.Join(dir, hostname, "hashicorp", "null", "terraform-provider-null_2.0.0_linux_amd64.zip"))
+}
+
+func TestMirror_UpstreamHostNotPermitted(t *testing.T) {
+	var requested bool
+	upstream, hostname := newTestUpstreamRegistry(t, []byte("upstream-binary"), []byte("upstream-binary"))
+	upstream.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = true
+	})
+
+	dir := t.TempDir()
+	r := mux.NewRouter()
+	(&mirror{
+		Logger:       logr.Discard(),
+		MirrorConfig: MirrorConfig{Dir: dir, UpstreamHosts: DefaultMirrorUpstreamHosts},
+		client:       upstream.Client(),
+	}).addHandlers(r)
+	base := fmt.Sprintf("/v1/mirror/providers/%s/hashicorp/null/", url.PathEscape(hostname))
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", base+"index.json", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", base+"terraform-provider-null_2.0.0_linux_amd64.zip", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	assert.False(t, requested, "upstream registry should not have been contacted")
+}
+
+// newTestUpstreamRegistry starts a registry serving version 2.0.0 of the
+// hashicorp/null provider for linux_amd64, returning the server and its
+// hostname. The checksum of the listed binary is signed, whereas the served
+// binary is that which is downloaded.
+func newTestUpstreamRegistry(t *testing.T, listed, served []byte) (*httptest.Server, string) {
+	t.Helper()
+
+	entity, armored := newTestKeyPair(t)
+	filename := "terraform-provider-null_2.0.0_linux_amd64.zip"
+	sum := fmt.Sprintf("%x", sha256.Sum256(listed))
+	shasums := []byte(fmt.Sprintf("%s  %s\n", sum, filename))
+	signature := sign(t, entity, shasums)
+
+	writeJSON := func(w http.ResponseWriter, v any) {
+		w.Header().Set("Content-type", "application/json")
+		json.NewEncoder(w).Encode(v)
+	}
+
+	r := mux.NewRouter()
+	r.HandleFunc("/.well-known/terraform.json", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]string{"providers.v1": "/v1/providers/"})
+	})
+	r.HandleFunc("/v1/providers/hashicorp/null/versions", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, listAvailableVersionsResponse{
+			Versions: []listAvailableVersionsVersion{
+				{
+					Version:   "2.0.0",
+					Protocols: []string{"5.0"},
+					Platforms: []listAvailableVersionsPlatform{{OS: "linux", Arch: "amd64"}},
+				},
+			},
+		})
+	})
+	r.HandleFunc("/v1/providers/hashicorp/null/2.0.0/download/linux/amd64", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, findPackageResponse{
+			Protocols:           []string{"5.0"},
+			OS:                  "linux",
+			Arch:                "amd64",
+			Filename:            filename,
+			DownloadURL:         "/files/" + filename,
+			SHASumsURL:          "/files/SHA256SUMS",
+			SHASumsSignatureURL: "/files/SHA256SUMS.sig",
+			SHASum:              sum,
+			SigningKeys: findPackageSigning{
+				GPGPublicKeys: []findPackageGPGKey{{ASCIIArmor: armored}},
+			},
+		})
+	})
+	r.HandleFunc("/files/SHA256SUMS", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(shasums)
+	})
+	r.HandleFunc("/files/SHA256SUMS.sig", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(signature)
+	})
+	r.HandleFunc("/files/"+filename, func(w http.ResponseWriter, r *http.Request) {
+		w.Write(served)
+	})
+
+	srv := httptest.NewTLSServer(r)
+	t.Cleanup(srv.Close)
+
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+	return srv, u.Host
+}
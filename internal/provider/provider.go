@@ -0,0 +1,241 @@
+// Package provider is responsible for the registry of private providers
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"time"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/semver"
+)
+
+// DefaultProtocols are the plugin protocol versions a provider version is
+// assumed to support if none are specified.
+var DefaultProtocols = []string{"5.0"}
+
+var (
+	// a provider type must only contain lowercase alphanumerics and hyphens
+	// (this is the name after the terraform-provider- prefix in a binary's
+	// filename).
+	reProviderName = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+	// os and arch names, e.g. linux, amd64.
+	rePlatformPart = regexp.MustCompile(`^[a-z0-9]+$`)
+	// plugin protocol versions, e.g. 5.0
+	reProtocol = regexp.MustCompile(`^[0-9]+\.[0-9]+$`)
+
+	ErrInvalidVersion  = errors.New("version must be a semantic version")
+	ErrInvalidProtocol = errors.New("protocol must be a major and minor version number, e.g. 5.0")
+	ErrInvalidPlatform = errors.New("os and arch must only contain lowercase letters and numbers")
+)
+
+type (
+	// Provider is a provider in an organization's private registry. The
+	// organization is the provider's namespace, and its name is the provider
+	// type.
+	Provider struct {
+		ID           string
+		Organization string
+		Name         string
+		CreatedAt    time.Time
+		Versions     []*Version // versions sorted in descending order
+	}
+
+	// Version is a version of a provider, along with the SHA256SUMS file
+	// listing the checksums of its platform binaries and the detached
+	// signature of that file.
+	Version struct {
+		ID               string
+		ProviderID       string
+		Version          string
+		Protocols        []string
+		GPGKeyID         string // ID of the GPG key that signed the SHA256SUMS
+		SHASums          []byte
+		SHASumsSignature []byte
+		CreatedAt        time.Time
+		Platforms        []*Platform
+	}
+
+	// Platform is a provider binary built for a specific os and arch.
+	Platform struct {
+		ID        string
+		VersionID string
+		OS        string
+		Arch      string
+		Filename  string
+		SHASum    string // hex-encoded sha256 checksum of binary
+		CreatedAt time.Time
+	}
+
+	CreateOptions struct {
+		Organization string `schema:"organization_name,required"`
+		Name         string `schema:"name,required"`
+	}
+	GetOptions struct {
+		Organization string
+		Name         string
+	}
+	CreateVersionOptions struct {
+		ProviderID       string
+		Version          string
+		Protocols        []string // defaults to DefaultProtocols
+		GPGKeyID         string
+		SHASums          []byte
+		SHASumsSignature []byte
+	}
+	UploadPlatformOptions struct {
+		VersionID string
+		OS        string
+		Arch      string
+		Binary    []byte
+	}
+)
+
+func newProvider(opts CreateOptions) (*Provider, error) {
+	if !reProviderName.MatchString(opts.Name) {
+		return nil, internal.ErrInvalidName
+	}
+	return &Provider{
+		ID:           internal.NewID("prov"),
+		Organization: opts.Organization,
+		Name:         opts.Name,
+		CreatedAt:    internal.CurrentTimestamp(nil),
+	}, nil
+}
+
+// newVersion constructs a provider version, checking the SHA256SUMS file has
+// been signed by the given key.
+func newVersion(opts CreateVersionOptions, key *GPGKey) (*Version, error) {
+	if !semver.IsValid(opts.Version) {
+		return nil, ErrInvalidVersion
+	}
+	protocols := opts.Protocols
+	if len(protocols) == 0 {
+		protocols = DefaultProtocols
+	}
+	for _, p := range protocols {
+		if !reProtocol.MatchString(p) {
+			return nil, ErrInvalidProtocol
+		}
+	}
+	if _, err := parseSHASums(opts.SHASums); err != nil {
+		return nil, err
+	}
+	if err := key.verify(opts.SHASums, opts.SHASumsSignature); err != nil {
+		return nil, err
+	}
+	return &Version{
+		ID:               internal.NewID("provver"),
+		ProviderID:       opts.ProviderID,
+		Version:          opts.Version,
+		Protocols:        protocols,
+		GPGKeyID:         key.ID,
+		SHASums:          opts.SHASums,
+		SHASumsSignature: opts.SHASumsSignature,
+		CreatedAt:        internal.CurrentTimestamp(nil),
+	}, nil
+}
+
+// newPlatform constructs a platform binary for the provider version, checking
+// the binary's checksum matches the entry in the version's SHA256SUMS file.
+func (v *Version) newPlatform(providerName string, opts UploadPlatformOptions) (*Platform, error) {
+	if !rePlatformPart.MatchString(opts.OS) || !rePlatformPart.MatchString(opts.Arch) {
+		return nil, ErrInvalidPlatform
+	}
+	if v.Platform(opts.OS, opts.Arch) != nil {
+		return nil, fmt.Errorf("version %s already has a binary for %s_%s", v.Version, opts.OS, opts.Arch)
+	}
+	filename := fmt.Sprintf("terraform-provider-%s_%s_%s_%s.zip", providerName, v.Version, opts.OS, opts.Arch)
+	sums, err := parseSHASums(v.SHASums)
+	if err != nil {
+		return nil, err
+	}
+	want, ok := sums[filename]
+	if !ok {
+		return nil, fmt.Errorf("SHA256SUMS has no entry for %s", filename)
+	}
+	got := sha256.Sum256(opts.Binary)
+	if hex.EncodeToString(got[:]) != want {
+		return nil, fmt.Errorf("checksum of binary does not match entry for %s in SHA256SUMS", filename)
+	}
+	return &Platform{
+		ID:        internal.NewID("provplat"),
+		VersionID: v.ID,
+		OS:        opts.OS,
+		Arch:      opts.Arch,
+		Filename:  filename,
+		SHASum:    want,
+		CreatedAt: internal.CurrentTimestamp(nil),
+	}, nil
+}
+
+// Version retrieves the provider version with the given version number. If no
+// such version exists then nil is returned.
+func (p *Provider) Version(v string) *Version {
+	for _, ver := range p.Versions {
+		if ver.Version == v {
+			return ver
+		}
+	}
+	return nil
+}
+
+// VersionByID retrieves the provider version with the given ID. If no such
+// version exists then nil is returned.
+func (p *Provider) VersionByID(id string) *Version {
+	for _, ver := range p.Versions {
+		if ver.ID == id {
+			return ver
+		}
+	}
+	return nil
+}
+
+// AvailableVersions returns those versions with at least one platform binary.
+func (p *Provider) AvailableVersions() (avail []*Version) {
+	for _, ver := range p.Versions {
+		if len(ver.Platforms) > 0 {
+			avail = append(avail, ver)
+		}
+	}
+	return
+}
+
+func (p *Provider) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("id", p.ID),
+		slog.String("organization", p.Organization),
+		slog.String("name", p.Name),
+	)
+}
+
+// Platform retrieves the platform binary for the given os and arch. If no such
+// binary exists then nil is returned.
+func (v *Version) Platform(os, arch string) *Platform {
+	for _, p := range v.Platforms {
+		if p.OS == os && p.Arch == arch {
+			return p
+		}
+	}
+	return nil
+}
+
+func (v *Version) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("id", v.ID),
+		slog.String("provider_id", v.ProviderID),
+		slog.String("version", v.Version),
+	)
+}
+
+func (p *Platform) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("id", p.ID),
+		slog.String("version_id", p.VersionID),
+		slog.String("filename", p.Filename),
+	)
+}
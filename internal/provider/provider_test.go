@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGPGKey(t *testing.T) {
+	entity, armored := newTestKeyPair(t)
+
+	key, err := newGPGKey(CreateGPGKeyOptions{Organization: "acme", ASCIIArmor: armored})
+	require.NoError(t, err)
+	assert.Equal(t, "acme", key.Organization)
+	assert.Equal(t, entity.PrimaryKey.KeyIdString(), key.KeyID)
+
+	_, err = newGPGKey(CreateGPGKeyOptions{Organization: "acme", ASCIIArmor: "not a key"})
+	assert.Error(t, err)
+}
+
+func TestNewProvider(t *testing.T) {
+	_, err := newProvider(CreateOptions{Organization: "acme", Name: "null"})
+	assert.NoError(t, err)
+
+	_, err = newProvider(CreateOptions{Organization: "acme", Name: "Null_Provider"})
+	assert.Error(t, err)
+}
+
+func TestNewVersion(t *testing.T) {
+	entity, armored := newTestKeyPair(t)
+	key, err := newGPGKey(CreateGPGKeyOptions{Organization: "acme", ASCIIArmor: armored})
+	require.NoError(t, err)
+
+	shasums := []byte(fmt.Sprintf("%x  terraform-provider-null_1.0.0_linux_amd64.zip\n", sha256.Sum256([]byte("binary"))))
+	signature := sign(t, entity, shasums)
+
+	tests := []struct {
+		name string
+		opts CreateVersionOptions
+		want error
+	}{
+		{
+			name: "valid",
+			opts: CreateVersionOptions{Version: "1.0.0", SHASums: shasums, SHASumsSignature: signature},
+		},
+		{
+			name: "invalid version",
+			opts: CreateVersionOptions{Version: "latest", SHASums: shasums, SHASumsSignature: signature},
+			want: ErrInvalidVersion,
+		},
+		{
+			name: "invalid protocol",
+			opts: CreateVersionOptions{Version: "1.0.0", Protocols: []string{"5"}, SHASums: shasums, SHASumsSignature: signature},
+			want: ErrInvalidProtocol,
+		},
+		{
+			name: "malformed shasums",
+			opts: CreateVersionOptions{Version: "1.0.0", SHASums: []byte("foo"), SHASumsSignature: sign(t, entity, []byte("foo"))},
+			want: ErrInvalidSHASums,
+		},
+		{
+			name: "signature of different content",
+			opts: CreateVersionOptions{Version: "1.0.0", SHASums: shasums, SHASumsSignature: sign(t, entity, []byte("foo"))},
+			want: ErrInvalidSignature,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newVersion(tt.opts, key)
+			if tt.want != nil {
+				assert.ErrorIs(t, err, tt.want)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, DefaultProtocols, got.Protocols)
+			assert.Equal(t, key.ID, got.GPGKeyID)
+		})
+	}
+}
+
+func TestNewPlatform(t *testing.T) {
+	binary := []byte("binary")
+	ver := &Version{
+		ID:      "provver-123",
+		Version: "1.0.0",
+		SHASums: []byte(fmt.Sprintf("%x  terraform-provider-null_1.0.0_linux_amd64.zip\n", sha256.Sum256(binary))),
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		got, err := ver.newPlatform("null", UploadPlatformOptions{OS: "linux", Arch: "amd64", Binary: binary})
+		require.NoError(t, err)
+		assert.Equal(t, "terraform-provider-null_1.0.0_linux_amd64.zip", got.Filename)
+		sum := sha256.Sum256(binary)
+		assert.Equal(t, hex.EncodeToString(sum[:]), got.SHASum)
+	})
+
+	t.Run("no entry in shasums", func(t *testing.T) {
+		_, err := ver.newPlatform("null", UploadPlatformOptions{OS: "darwin", Arch: "arm64", Binary: binary})
+		assert.ErrorContains(t, err, "SHA256SUMS has no entry")
+	})
+
+	t.Run("checksum mismatch", func(t *testing.T) {
+		_, err := ver.newPlatform("null", UploadPlatformOptions{OS: "linux", Arch: "amd64", Binary: []byte("tampered")})
+		assert.ErrorContains(t, err, "does not match")
+	})
+
+	t.Run("invalid platform", func(t *testing.T) {
+		_, err := ver.newPlatform("null", UploadPlatformOptions{OS: "../linux", Arch: "amd64", Binary: binary})
+		assert.ErrorIs(t, err, ErrInvalidPlatform)
+	})
+}
+
+// newTestKeyPair generates a GPG key pair, returning the private key entity and
+// the ascii-armored public key.
+func newTestKeyPair(t *testing.T) (*openpgp.Entity, string) {
+	t.Helper()
+
+	entity, err := openpgp.NewEntity("acme", "", "acme@example.com", &packet.Config{Algorithm: packet.PubKeyAlgoEdDSA})
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	w, err := armor.Encode(&buf, openpgp.PublicKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, entity.Serialize(w))
+	require.NoError(t, w.Close())
+	return entity, buf.String()
+}
+
+// sign returns a binary detached signature of the content.
+func sign(t *testing.T, entity *openpgp.Entity, content []byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	require.NoError(t, openpgp.DetachSign(&buf, entity, bytes.NewReader(content), nil))
+	return buf.Bytes()
+}
@@ -0,0 +1,360 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/http/html"
+	"github.com/leg100/otf/internal/organization"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/surl"
+)
+
+type (
+	ProviderService = Service
+
+	Service interface {
+		CreateProvider(ctx context.Context, opts CreateOptions) (*Provider, error)
+		ListProviders(ctx context.Context, organization string) ([]*Provider, error)
+		GetProvider(ctx context.Context, opts GetOptions) (*Provider, error)
+		GetProviderByID(ctx context.Context, id string) (*Provider, error)
+		DeleteProvider(ctx context.Context, id string) (*Provider, error)
+
+		// CreateProviderVersion creates a provider version, verifying its SHA256SUMS
+		// file has been signed by one of the organization's GPG keys.
+		CreateProviderVersion(ctx context.Context, opts CreateVersionOptions) (*Version, error)
+		DeleteProviderVersion(ctx context.Context, id string) (*Version, error)
+		// UploadProviderPlatform uploads a provider binary for a specific os and arch,
+		// verifying its checksum against the version's SHA256SUMS file.
+		UploadProviderPlatform(ctx context.Context, opts UploadPlatformOptions) (*Platform, error)
+		DeleteProviderPlatform(ctx context.Context, id string) (*Platform, error)
+
+		CreateGPGKey(ctx context.Context, opts CreateGPGKeyOptions) (*GPGKey, error)
+		ListGPGKeys(ctx context.Context, organization string) ([]*GPGKey, error)
+		DeleteGPGKey(ctx context.Context, id string) (*GPGKey, error)
+
+		getGPGKey(ctx context.Context, id string) (*GPGKey, error)
+		downloadPlatform(ctx context.Context, id string) ([]byte, error)
+		downloadSHASums(ctx context.Context, versionID string) (*Version, error)
+	}
+
+	service struct {
+		logr.Logger
+
+		db *pgdb
+
+		organization internal.Authorizer
+
+		api    *api
+		web    *webHandlers
+		mirror *mirror
+	}
+
+	Options struct {
+		logr.Logger
+
+		*sql.DB
+		*surl.Signer
+		html.Renderer
+		internal.HostnameService
+
+		Mirror MirrorConfig
+	}
+)
+
+func NewService(opts Options) *service {
+	svc := service{
+		Logger:       opts.Logger,
+		organization: &organization.Authorizer{Logger: opts.Logger},
+		db:           &pgdb{opts.DB},
+	}
+	svc.api = &api{
+		svc:    &svc,
+		Signer: opts.Signer,
+	}
+	svc.web = &webHandlers{
+		Renderer:        opts.Renderer,
+		HostnameService: opts.HostnameService,
+		svc:             &svc,
+	}
+	svc.mirror = &mirror{
+		Logger:       opts.Logger,
+		MirrorConfig: opts.Mirror,
+		client:       http.DefaultClient,
+	}
+	return &svc
+}
+
+func (s *service) AddHandlers(r *mux.Router) {
+	s.api.addHandlers(r)
+	s.web.addHandlers(r)
+	s.mirror.addHandlers(r)
+}
+
+func (s *service) CreateProvider(ctx context.Context, opts CreateOptions) (*Provider, error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.CreateRegistryProviderAction, opts.Organization)
+	if err != nil {
+		return nil, err
+	}
+
+	prov, err := newProvider(opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.db.createProvider(ctx, prov); err != nil {
+		s.Error(err, "creating registry provider", "subject", subject, "provider", prov)
+		return nil, err
+	}
+	s.V(0).Info("created registry provider", "subject", subject, "provider", prov)
+	return prov, nil
+}
+
+func (s *service) ListProviders(ctx context.Context, organization string) ([]*Provider, error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.ListRegistryProvidersAction, organization)
+	if err != nil {
+		return nil, err
+	}
+
+	providers, err := s.db.listProviders(ctx, organization)
+	if err != nil {
+		s.Error(err, "listing registry providers", "organization", organization, "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("listed registry providers", "organization", organization, "count", len(providers), "subject", subject)
+	return providers, nil
+}
+
+func (s *service) GetProvider(ctx context.Context, opts GetOptions) (*Provider, error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.GetRegistryProviderAction, opts.Organization)
+	if err != nil {
+		return nil, err
+	}
+
+	prov, err := s.db.getProvider(ctx, opts)
+	if err != nil {
+		s.Error(err, "retrieving registry provider", "organization", opts.Organization, "name", opts.Name, "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("retrieved registry provider", "provider", prov, "subject", subject)
+	return prov, nil
+}
+
+func (s *service) GetProviderByID(ctx context.Context, id string) (*Provider, error) {
+	prov, err := s.db.getProviderByID(ctx, id)
+	if err != nil {
+		s.Error(err, "retrieving registry provider", "id", id)
+		return nil, err
+	}
+
+	subject, err := s.organization.CanAccess(ctx, rbac.GetRegistryProviderAction, prov.Organization)
+	if err != nil {
+		return nil, err
+	}
+	s.V(9).Info("retrieved registry provider", "provider", prov, "subject", subject)
+	return prov, nil
+}
+
+func (s *service) DeleteProvider(ctx context.Context, id string) (*Provider, error) {
+	prov, err := s.db.getProviderByID(ctx, id)
+	if err != nil {
+		s.Error(err, "retrieving registry provider", "id", id)
+		return nil, err
+	}
+
+	subject, err := s.organization.CanAccess(ctx, rbac.DeleteRegistryProviderAction, prov.Organization)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.db.deleteProvider(ctx, id); err != nil {
+		s.Error(err, "deleting registry provider", "provider", prov, "subject", subject)
+		return nil, err
+	}
+	s.V(0).Info("deleted registry provider", "provider", prov, "subject", subject)
+	return prov, nil
+}
+
+func (s *service) CreateProviderVersion(ctx context.Context, opts CreateVersionOptions) (*Version, error) {
+	prov, err := s.db.getProviderByID(ctx, opts.ProviderID)
+	if err != nil {
+		return nil, err
+	}
+
+	subject, err := s.organization.CanAccess(ctx, rbac.CreateRegistryProviderVersionAction, prov.Organization)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := s.db.getGPGKey(ctx, opts.GPGKeyID)
+	if err != nil {
+		return nil, err
+	}
+	if key.Organization != prov.Organization {
+		return nil, internal.ErrResourceNotFound
+	}
+	if prov.Version(opts.Version) != nil {
+		return nil, internal.ErrResourceAlreadyExists
+	}
+	ver, err := newVersion(opts, key)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.db.createVersion(ctx, ver); err != nil {
+		s.Error(err, "creating registry provider version", "version", ver, "subject", subject)
+		return nil, err
+	}
+	s.V(0).Info("created registry provider version", "version", ver, "subject", subject)
+	return ver, nil
+}
+
+func (s *service) DeleteProviderVersion(ctx context.Context, id string) (*Version, error) {
+	prov, err := s.db.getProviderByVersionID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	subject, err := s.organization.CanAccess(ctx, rbac.DeleteRegistryProviderVersionAction, prov.Organization)
+	if err != nil {
+		return nil, err
+	}
+
+	ver := prov.VersionByID(id)
+	if err := s.db.deleteVersion(ctx, id); err != nil {
+		s.Error(err, "deleting registry provider version", "version", ver, "subject", subject)
+		return nil, err
+	}
+	s.V(0).Info("deleted registry provider version", "version", ver, "subject", subject)
+	return ver, nil
+}
+
+func (s *service) UploadProviderPlatform(ctx context.Context, opts UploadPlatformOptions) (*Platform, error) {
+	prov, err := s.db.getProviderByVersionID(ctx, opts.VersionID)
+	if err != nil {
+		return nil, err
+	}
+
+	subject, err := s.organization.CanAccess(ctx, rbac.CreateRegistryProviderVersionAction, prov.Organization)
+	if err != nil {
+		return nil, err
+	}
+
+	platform, err := prov.VersionByID(opts.VersionID).newPlatform(prov.Name, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.db.createPlatform(ctx, platform, opts.Binary); err != nil {
+		s.Error(err, "uploading registry provider binary", "platform", platform, "subject", subject)
+		return nil, err
+	}
+	s.V(0).Info("uploaded registry provider binary", "platform", platform, "bytes", len(opts.Binary), "subject", subject)
+	return platform, nil
+}
+
+func (s *service) DeleteProviderPlatform(ctx context.Context, id string) (*Platform, error) {
+	platform, err := s.db.getPlatform(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	prov, err := s.db.getProviderByVersionID(ctx, platform.VersionID)
+	if err != nil {
+		return nil, err
+	}
+
+	subject, err := s.organization.CanAccess(ctx, rbac.DeleteRegistryProviderVersionAction, prov.Organization)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.db.deletePlatform(ctx, id); err != nil {
+		s.Error(err, "deleting registry provider binary", "platform", platform, "subject", subject)
+		return nil, err
+	}
+	s.V(0).Info("deleted registry provider binary", "platform", platform, "subject", subject)
+	return platform, nil
+}
+
+func (s *service) CreateGPGKey(ctx context.Context, opts CreateGPGKeyOptions) (*GPGKey, error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.CreateGPGKeyAction, opts.Organization)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := newGPGKey(opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.db.createGPGKey(ctx, key); err != nil {
+		s.Error(err, "creating gpg key", "key", key, "subject", subject)
+		return nil, err
+	}
+	s.V(0).Info("created gpg key", "key", key, "subject", subject)
+	return key, nil
+}
+
+func (s *service) ListGPGKeys(ctx context.Context, organization string) ([]*GPGKey, error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.ListGPGKeysAction, organization)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := s.db.listGPGKeys(ctx, organization)
+	if err != nil {
+		s.Error(err, "listing gpg keys", "organization", organization, "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("listed gpg keys", "organization", organization, "count", len(keys), "subject", subject)
+	return keys, nil
+}
+
+func (s *service) DeleteGPGKey(ctx context.Context, id string) (*GPGKey, error) {
+	key, err := s.db.getGPGKey(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	subject, err := s.organization.CanAccess(ctx, rbac.DeleteGPGKeyAction, key.Organization)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.db.deleteGPGKey(ctx, id); err != nil {
+		var fkerr *internal.ForeignKeyError
+		if errors.As(err, &fkerr) {
+			err = errors.New("cannot delete a key that has signed provider versions")
+		}
+		s.Error(err, "deleting gpg key", "key", key, "subject", subject)
+		return nil, err
+	}
+	s.V(0).Info("deleted gpg key", "key", key, "subject", subject)
+	return key, nil
+}
+
+// getGPGKey retrieves a public key. No authorization is performed, because
+// public keys are served to anyone permitted to download a provider.
+func (s *service) getGPGKey(ctx context.Context, id string) (*GPGKey, error) {
+	return s.db.getGPGKey(ctx, id)
+}
+
+// downloadPlatform retrieves a provider binary.
+//
+// NOTE: unauthenticated - access granted only via signed URL
+func (s *service) downloadPlatform(ctx context.Context, id string) ([]byte, error) {
+	return s.db.getPlatformBinary(ctx, id)
+}
+
+// downloadSHASums retrieves a provider version, along with its SHA256SUMS file
+// and signature.
+//
+// NOTE: unauthenticated - access granted only via signed URL
+func (s *service) downloadSHASums(ctx context.Context, versionID string) (*Version, error) {
+	prov, err := s.db.getProviderByVersionID(ctx, versionID)
+	if err != nil {
+		return nil, err
+	}
+	return prov.VersionByID(versionID), nil
+}
@@ -0,0 +1,415 @@
+package provider
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/auth"
+	"github.com/leg100/otf/internal/http/decode"
+	"github.com/leg100/otf/internal/http/html"
+	"github.com/leg100/otf/internal/http/html/paths"
+	"github.com/leg100/otf/internal/organization"
+	"github.com/leg100/otf/internal/rbac"
+)
+
+// MaxUploadSize is the maximum size of a file uploaded via the web UI, be it
+// a provider binary or a SHA256SUMS file.
+const MaxUploadSize = 512 << 20 // 512 MiB
+
+// webHandlers provides handlers for the webUI
+type webHandlers struct {
+	html.Renderer
+	internal.HostnameService
+
+	svc Service
+}
+
+func (h *webHandlers) addHandlers(r *mux.Router) {
+	r = html.UIRouter(r)
+
+	r.HandleFunc("/organizations/{organization_name}/registry-providers", h.list).Methods("GET")
+	r.HandleFunc("/organizations/{organization_name}/registry-providers/new", h.new).Methods("GET")
+	r.HandleFunc("/organizations/{organization_name}/registry-providers/create", h.create).Methods("POST")
+	r.HandleFunc("/registry-providers/{registry_provider_id}", h.get).Methods("GET")
+	r.HandleFunc("/registry-providers/{registry_provider_id}/delete", h.delete).Methods("POST")
+
+	r.HandleFunc("/registry-providers/{registry_provider_id}/registry-provider-versions/new", h.newVersion).Methods("GET")
+	r.HandleFunc("/registry-providers/{registry_provider_id}/registry-provider-versions/create", h.createVersion).Methods("POST")
+	r.HandleFunc("/registry-provider-versions/{registry_provider_version_id}/delete", h.deleteVersion).Methods("POST")
+	r.HandleFunc("/registry-provider-versions/{registry_provider_version_id}/upload-platform", h.uploadPlatform).Methods("POST")
+	r.HandleFunc("/registry-provider-platforms/{registry_provider_platform_id}/delete", h.deletePlatform).Methods("POST")
+
+	r.HandleFunc("/organizations/{organization_name}/gpg-keys", h.listGPGKeys).Methods("GET")
+	r.HandleFunc("/organizations/{organization_name}/gpg-keys/new", h.newGPGKey).Methods("GET")
+	r.HandleFunc("/organizations/{organization_name}/gpg-keys/create", h.createGPGKey).Methods("POST")
+	r.HandleFunc("/gpg-keys/{gpg_key_id}/delete", h.deleteGPGKey).Methods("POST")
+}
+
+func (h *webHandlers) list(w http.ResponseWriter, r *http.Request) {
+	org, err := decode.Param("organization_name", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	providers, err := h.svc.ListProviders(r.Context(), org)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	user, err := auth.UserFromContext(r.Context())
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.Render("registry_provider_list.tmpl", w, struct {
+		organization.OrganizationPage
+		Items             []*Provider
+		CanCreateProvider bool
+	}{
+		OrganizationPage:  organization.NewPage(r, "providers", org),
+		Items:             providers,
+		CanCreateProvider: user.CanAccessOrganization(rbac.CreateRegistryProviderAction, org),
+	})
+}
+
+func (h *webHandlers) new(w http.ResponseWriter, r *http.Request) {
+	org, err := decode.Param("organization_name", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	h.Render("registry_provider_new.tmpl", w, struct {
+		organization.OrganizationPage
+	}{
+		OrganizationPage: organization.NewPage(r, "new provider", org),
+	})
+}
+
+func (h *webHandlers) create(w http.ResponseWriter, r *http.Request) {
+	var opts CreateOptions
+	if err := decode.All(&opts, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	prov, err := h.svc.CreateProvider(r.Context(), opts)
+	if err != nil {
+		html.FlashError(w, err.Error())
+		http.Redirect(w, r, paths.NewRegistryProvider(opts.Organization), http.StatusFound)
+		return
+	}
+
+	html.FlashSuccess(w, "created provider: "+prov.Name)
+	http.Redirect(w, r, paths.RegistryProvider(prov.ID), http.StatusFound)
+}
+
+func (h *webHandlers) get(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("registry_provider_id", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	prov, err := h.svc.GetProviderByID(r.Context(), id)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	user, err := auth.UserFromContext(r.Context())
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.Render("registry_provider_get.tmpl", w, struct {
+		organization.OrganizationPage
+		Provider          *Provider
+		Latest            *Version
+		Hostname          string
+		CanManage         bool
+		CanDeleteProvider bool
+	}{
+		OrganizationPage:  organization.NewPage(r, prov.Name, prov.Organization),
+		Provider:          prov,
+		Latest:            latest(prov.AvailableVersions()),
+		Hostname:          h.Hostname(),
+		CanManage:         user.CanAccessOrganization(rbac.CreateRegistryProviderVersionAction, prov.Organization),
+		CanDeleteProvider: user.CanAccessOrganization(rbac.DeleteRegistryProviderAction, prov.Organization),
+	})
+}
+
+func (h *webHandlers) delete(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("registry_provider_id", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	deleted, err := h.svc.DeleteProvider(r.Context(), id)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	html.FlashSuccess(w, "deleted provider: "+deleted.Name)
+	http.Redirect(w, r, paths.RegistryProviders(deleted.Organization), http.StatusFound)
+}
+
+func (h *webHandlers) newVersion(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("registry_provider_id", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	prov, err := h.svc.GetProviderByID(r.Context(), id)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	keys, err := h.svc.ListGPGKeys(r.Context(), prov.Organization)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.Render("registry_provider_version_new.tmpl", w, struct {
+		organization.OrganizationPage
+		Provider         *Provider
+		GPGKeys          []*GPGKey
+		DefaultProtocols string
+	}{
+		OrganizationPage: organization.NewPage(r, "new version", prov.Organization),
+		Provider:         prov,
+		GPGKeys:          keys,
+		DefaultProtocols: strings.Join(DefaultProtocols, ","),
+	})
+}
+
+func (h *webHandlers) createVersion(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, MaxUploadSize)
+	if err := r.ParseMultipartForm(MaxUploadSize); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	var params struct {
+		ProviderID string `schema:"registry_provider_id,required"`
+		Version    string `schema:"version,required"`
+		Protocols  string `schema:"protocols"`
+		GPGKeyID   string `schema:"gpg_key_id,required"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	opts := CreateVersionOptions{
+		ProviderID: params.ProviderID,
+		Version:    params.Version,
+		GPGKeyID:   params.GPGKeyID,
+	}
+	for _, p := range strings.Split(params.Protocols, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			opts.Protocols = append(opts.Protocols, p)
+		}
+	}
+	var err error
+	if opts.SHASums, err = readFormFile(r, "shasums"); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	if opts.SHASumsSignature, err = readFormFile(r, "shasums_signature"); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	ver, err := h.svc.CreateProviderVersion(r.Context(), opts)
+	if err != nil {
+		html.FlashError(w, "creating version: "+err.Error())
+		http.Redirect(w, r, paths.NewRegistryProviderVersion(params.ProviderID), http.StatusFound)
+		return
+	}
+
+	html.FlashSuccess(w, "created version: "+ver.Version)
+	http.Redirect(w, r, paths.RegistryProvider(params.ProviderID), http.StatusFound)
+}
+
+func (h *webHandlers) deleteVersion(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("registry_provider_version_id", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	deleted, err := h.svc.DeleteProviderVersion(r.Context(), id)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	html.FlashSuccess(w, "deleted version: "+deleted.Version)
+	http.Redirect(w, r, paths.RegistryProvider(deleted.ProviderID), http.StatusFound)
+}
+
+func (h *webHandlers) uploadPlatform(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, MaxUploadSize)
+	if err := r.ParseMultipartForm(MaxUploadSize); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	var params struct {
+		ProviderID string `schema:"registry_provider_id,required"`
+		VersionID  string `schema:"registry_provider_version_id,required"`
+		OS         string `schema:"os,required"`
+		Arch       string `schema:"arch,required"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	binary, err := readFormFile(r, "binary")
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	platform, err := h.svc.UploadProviderPlatform(r.Context(), UploadPlatformOptions{
+		VersionID: params.VersionID,
+		OS:        params.OS,
+		Arch:      params.Arch,
+		Binary:    binary,
+	})
+	if err != nil {
+		html.FlashError(w, "uploading binary: "+err.Error())
+		http.Redirect(w, r, paths.RegistryProvider(params.ProviderID), http.StatusFound)
+		return
+	}
+
+	html.FlashSuccess(w, "uploaded binary: "+platform.Filename)
+	http.Redirect(w, r, paths.RegistryProvider(params.ProviderID), http.StatusFound)
+}
+
+func (h *webHandlers) deletePlatform(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		ProviderID string `schema:"registry_provider_id,required"`
+		PlatformID string `schema:"registry_provider_platform_id,required"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	deleted, err := h.svc.DeleteProviderPlatform(r.Context(), params.PlatformID)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	html.FlashSuccess(w, "deleted binary: "+deleted.Filename)
+	http.Redirect(w, r, paths.RegistryProvider(params.ProviderID), http.StatusFound)
+}
+
+func (h *webHandlers) listGPGKeys(w http.ResponseWriter, r *http.Request) {
+	org, err := decode.Param("organization_name", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	keys, err := h.svc.ListGPGKeys(r.Context(), org)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	user, err := auth.UserFromContext(r.Context())
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.Render("gpg_key_list.tmpl", w, struct {
+		organization.OrganizationPage
+		Items           []*GPGKey
+		CanCreateGPGKey bool
+	}{
+		OrganizationPage: organization.NewPage(r, "gpg keys", org),
+		Items:            keys,
+		CanCreateGPGKey:  user.CanAccessOrganization(rbac.CreateGPGKeyAction, org),
+	})
+}
+
+func (h *webHandlers) newGPGKey(w http.ResponseWriter, r *http.Request) {
+	org, err := decode.Param("organization_name", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	h.Render("gpg_key_new.tmpl", w, struct {
+		organization.OrganizationPage
+	}{
+		OrganizationPage: organization.NewPage(r, "new gpg key", org),
+	})
+}
+
+func (h *webHandlers) createGPGKey(w http.ResponseWriter, r *http.Request) {
+	var opts CreateGPGKeyOptions
+	if err := decode.All(&opts, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	key, err := h.svc.CreateGPGKey(r.Context(), opts)
+	if err != nil {
+		html.FlashError(w, "adding gpg key: "+err.Error())
+		http.Redirect(w, r, paths.NewGPGKey(opts.Organization), http.StatusFound)
+		return
+	}
+
+	html.FlashSuccess(w, "added gpg key: "+key.KeyID)
+	http.Redirect(w, r, paths.GPGKeys(opts.Organization), http.StatusFound)
+}
+
+func (h *webHandlers) deleteGPGKey(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("gpg_key_id", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	deleted, err := h.svc.DeleteGPGKey(r.Context(), id)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	html.FlashSuccess(w, "deleted gpg key: "+deleted.KeyID)
+	http.Redirect(w, r, paths.GPGKeys(deleted.Organization), http.StatusFound)
+}
+
+// readFormFile reads the contents of a file uploaded in a multipart form.
+func readFormFile(r *http.Request, name string) ([]byte, error) {
+	file, _, err := r.FormFile(name)
+	if errors.Is(err, http.ErrMissingFile) {
+		return nil, errors.New("missing file: " + name)
+	} else if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return io.ReadAll(file)
+}
+
+// latest returns the first of the versions, which are sorted in descending
+// order, or nil if there are no versions.
+func latest(versions []*Version) *Version {
+	if len(versions) == 0 {
+		return nil
+	}
+	return versions[0]
+}
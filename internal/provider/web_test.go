@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/auth"
+	"github.com/leg100/otf/internal/http/html"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWeb_GetProvider(t *testing.T) {
+	h := newTestWebHandlers(t, &fakeService{prov: &Provider{
+		ID:           "prov-123",
+		Organization: "acme",
+		Name:         "null",
+		Versions: []*Version{
+			{
+				ID:        "provver-1",
+				Version:   "1.0.0",
+				Protocols: []string{"5.0"},
+				Platforms: []*Platform{{ID: "provplat-1", OS: "linux", Arch: "amd64"}},
+			},
+		},
+	}})
+
+	r := httptest.NewRequest("GET", "/?registry_provider_id=prov-123", nil)
+	r = r.WithContext(internal.AddSubjectToContext(r.Context(), &auth.User{ID: "janitor"}))
+	w := httptest.NewRecorder()
+	h.get(w, r)
+	if assert.Equal(t, 200, w.Code) {
+		assert.Contains(t, w.Body.String(), `source  = "fake-host.org/acme/null"`)
+	}
+}
+
+func TestWeb_NewVersion(t *testing.T) {
+	h := newTestWebHandlers(t, &fakeService{
+		prov: &Provider{ID: "prov-123", Organization: "acme", Name: "null"},
+		key:  &GPGKey{ID: "gpg-123", KeyID: "ABCDEF"},
+	})
+
+	r := httptest.NewRequest("GET", "/?registry_provider_id=prov-123", nil)
+	w := httptest.NewRecorder()
+	h.newVersion(w, r)
+	if assert.Equal(t, 200, w.Code) {
+		assert.Contains(t, w.Body.String(), "ABCDEF")
+	}
+}
+
+func newTestWebHandlers(t *testing.T, svc *fakeService) *webHandlers {
+	renderer, err := html.NewRenderer(false)
+	require.NoError(t, err)
+
+	return &webHandlers{
+		Renderer:        renderer,
+		HostnameService: internal.NewHostnameService("fake-host.org"),
+		svc:             svc,
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"log/slog"
 
@@ -13,6 +15,7 @@ import (
 	"github.com/jackc/pgconn"
 	"github.com/jackc/pgx/v4/pgxpool"
 	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/sql/pggen"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -21,6 +24,10 @@ const (
 
 	// subBufferSize is the buffer size of the channel for each subscription.
 	subBufferSize = 100
+
+	// replayBatchSize is the maximum number of events retrieved from the event
+	// log at a time when replaying events.
+	replayBatchSize = 100
 )
 
 // ErrSubscriptionTerminated is for use by subscribers to indicate that their
@@ -29,89 +36,97 @@ var ErrSubscriptionTerminated = errors.New("broker terminated the subscription")
 
 type (
 
-	// Broker is a pubsub Broker implemented using postgres' listen/notify
+	// Broker is a pubsub Broker, relaying events between nodes via postgres
+	// using a choice of transports.
 	Broker struct {
 		logr.Logger
 
-		channel     string        // postgres notification channel name
-		pool        pool          // pool from which to acquire a dedicated connection to postgres
+		transport   transport     // relays events from postgres
 		islistening chan struct{} // semaphore that's closed once broker is listening
+		once        sync.Once     // ensures semaphore is closed only once
 
 		subs    map[string]chan Event       // subscriptions
 		metrics map[string]prometheus.Gauge // metric for each subscription
 		mu      sync.Mutex                  // sync access to maps
 
+		log    eventLog     // durable log of events, for replaying events
+		latest atomic.Int64 // ID of the latest logged event received
+
 		*converter
 	}
 
+	BrokerOptions struct {
+		// Transport for relaying events between nodes. Defaults to
+		// NotifyTransport.
+		Transport TransportKind
+		// PollInterval is the interval between polling the event log when
+		// using PollTransport.
+		PollInterval time.Duration
+	}
+
 	// database connection pool
 	pool interface {
 		Acquire(ctx context.Context) (*pgxpool.Conn, error)
 		Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+		Conn(ctx context.Context) *pggen.DBQuerier
 	}
 
 	// pgevent is the payload of a postgres notification triggered by a database
 	// change.
 	pgevent struct {
+		EventID int64     `json:"event_id"` // position in the event log
+		Time    time.Time `json:"time"`     // time event was logged
+
 		Table  string   `json:"table"`  // pg table associated with change
 		Action DBAction `json:"action"` // INSERT/UPDATE/DELETE
 		ID     string   `json:"id"`     // id of changed row
+
+		// state of changed row at the time of the event
+		Snapshot json.RawMessage `json:"snapshot,omitempty"`
 	}
 )
 
-func NewBroker(logger logr.Logger, db pool) *Broker {
+func NewBroker(logger logr.Logger, db pool, opts BrokerOptions) (*Broker, error) {
+	logger = logger.WithValues("component", "broker")
+	transport, err := newTransport(logger, db, opts)
+	if err != nil {
+		return nil, err
+	}
 	return &Broker{
-		Logger:      logger.WithValues("component", "broker"),
-		pool:        db,
+		Logger:      logger,
+		transport:   transport,
 		islistening: make(chan struct{}),
-		channel:     defaultChannel,
 		subs:        make(map[string]chan Event),
 		metrics:     make(map[string]prometheus.Gauge),
+		log:         &pgdb{db},
 		converter:   newConverter(),
-	}
+	}, nil
 }
 
-// Start the pubsub daemon; listen to notifications from postgres and forward to
-// local pubsub broker. The listening channel is closed once the broker has
-// started listening; from this point onwards published messages will be
-// forwarded.
+// Start the pubsub daemon; relay events from the database to the local pubsub
+// broker. The listening channel is closed once the broker has started
+// listening; from this point onwards published messages will be forwarded.
 func (b *Broker) Start(ctx context.Context) error {
-	conn, err := b.pool.Acquire(ctx)
-	if err != nil {
-		return fmt.Errorf("unable to acquire postgres connection: %w", err)
+	ready := func() {
+		b.once.Do(func() {
+			close(b.islistening) // close semaphore to indicate broker is now listening
+		})
 	}
-	defer conn.Release()
+	return b.transport.start(ctx, ready, b.receive)
+}
 
-	if _, err := conn.Exec(ctx, "listen "+b.channel); err != nil {
-		return err
+// receive converts a database event into an OTF event and publishes it to
+// local subscribers.
+func (b *Broker) receive(ctx context.Context, pge pgevent) {
+	if pge.EventID > b.latest.Load() {
+		b.latest.Store(pge.EventID)
 	}
-	b.V(2).Info("listening for events")
-	close(b.islistening) // close semaphore to indicate broker is now listening
-
-	for {
-		notification, err := conn.Conn().WaitForNotification(ctx)
-		if err != nil {
-			select {
-			case <-ctx.Done():
-				// parent has decided to shutdown so exit without error
-				return nil
-			default:
-				b.Error(err, "waiting for postgres notification")
-				return err
-			}
-		}
-		var pge pgevent
-		if err := json.Unmarshal([]byte(notification.Payload), &pge); err != nil {
-			b.Error(err, "unmarshaling postgres notification")
-			continue
-		}
-		event, err := b.convert(ctx, pge)
-		if err != nil {
-			b.Error(err, "converting postgres notification into event", "notification", pge)
-			continue
-		}
-		b.localPublish(event)
+	event, err := b.convert(ctx, pge)
+	if err != nil {
+		b.Error(err, "converting database event into event", "event", pge)
+		return
 	}
+	b.localPublish(event)
 }
 
 func (b *Broker) Started() <-chan struct{} {
@@ -133,6 +148,95 @@ func (b *Broker) Publish(event Event) {
 // identifier prefixed to a random string to helpfully identify the subscriber
 // in metrics.
 func (b *Broker) Subscribe(ctx context.Context, prefix string) (<-chan Event, error) {
+	_, sub, err := b.subscribe(ctx, prefix)
+	return sub, err
+}
+
+// SubscribeFrom subscribes the caller to a stream of events, first replaying
+// logged events with an ID greater than lastEventID. If lastEventID is zero
+// then no events are replayed. Replayed events carry the current state of
+// the resource rather than its state at the time of the event, and events
+// that have been compacted from the log are not replayed.
+//
+// Event IDs are allocated before the event's transaction commits, so an event
+// with an ID lower than lastEventID may have committed after the subscriber
+// received lastEventID. To avoid missing such events, the replay also
+// includes events logged up to maxGapAge before lastEventID, some of which the
+// subscriber may have already received.
+func (b *Broker) SubscribeFrom(ctx context.Context, prefix string, lastEventID int64) (<-chan Event, error) {
+	// subscribe before replaying, to ensure events published in the interim
+	// are not missed.
+	name, sub, err := b.subscribe(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+	if lastEventID == 0 {
+		return sub, nil
+	}
+
+	relay := make(chan Event)
+	go func() {
+		defer func() {
+			subscriberLag.DeleteLabelValues(name)
+			close(relay)
+		}()
+		send := func(event Event) bool {
+			select {
+			case relay <- event:
+				if event.ID != 0 {
+					subscriberLag.WithLabelValues(name).Set(float64(b.latest.Load() - event.ID))
+				}
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+		// replay logged events, recording which have been replayed. Events
+		// are not necessarily relayed in order of ID, so it is not enough to
+		// record only the highest ID replayed.
+		replayed := make(map[int64]bool)
+		after, err := b.log.replayStartID(ctx, lastEventID, maxGapAge)
+		if err != nil {
+			b.Error(err, "retrieving start of replay", "sub", name)
+			return
+		}
+		for {
+			events, err := b.log.listEventsAfter(ctx, after, replayBatchSize)
+			if err != nil {
+				b.Error(err, "retrieving events for replay", "sub", name)
+				return
+			}
+			for _, pge := range events {
+				after = pge.EventID
+				replayed[pge.EventID] = true
+				event, err := b.convert(ctx, pge)
+				if err != nil {
+					// the resource may have since been deleted
+					b.V(2).Info("skipping replay of event", "event", pge, "reason", err.Error())
+					continue
+				}
+				if !send(event) {
+					return
+				}
+			}
+			if len(events) < replayBatchSize {
+				break
+			}
+		}
+		// relay new events, skipping those already replayed
+		for event := range sub {
+			if replayed[event.ID] {
+				continue
+			}
+			if !send(event) {
+				return
+			}
+		}
+	}()
+	return relay, nil
+}
+
+func (b *Broker) subscribe(ctx context.Context, prefix string) (string, chan Event, error) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
@@ -140,7 +244,7 @@ func (b *Broker) Subscribe(ctx context.Context, prefix string) (<-chan Event, er
 
 	sub := make(chan Event, subBufferSize)
 	if _, ok := b.subs[name]; ok {
-		return nil, fmt.Errorf("name already taken")
+		return "", nil, fmt.Errorf("name already taken")
 	}
 	b.subs[name] = sub
 
@@ -154,7 +258,7 @@ func (b *Broker) Subscribe(ctx context.Context, prefix string) (<-chan Event, er
 		ConstLabels: prometheus.Labels{"name": name},
 	})
 	if err := prometheus.Register(b.metrics[name]); err != nil {
-		return nil, fmt.Errorf("registering metric for subscriber: %s: %w", name, err)
+		return "", nil, fmt.Errorf("registering metric for subscriber: %s: %w", name, err)
 	}
 
 	// when the context is canceled remove the subscriber
@@ -163,7 +267,7 @@ func (b *Broker) Subscribe(ctx context.Context, prefix string) (<-chan Event, er
 		b.unsubscribe(name)
 	}()
 
-	return sub, nil
+	return name, sub, nil
 }
 
 func (b *Broker) unsubscribe(name string) {
@@ -214,6 +318,7 @@ func (b *Broker) localPublish(event Event) {
 
 func (v *pgevent) LogValue() slog.Value {
 	attrs := []slog.Attr{
+		slog.Int64("event_id", v.EventID),
 		slog.String("id", v.ID),
 		slog.String("action", string(v.Action)),
 		slog.String("table", v.Table),
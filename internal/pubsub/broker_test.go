@@ -10,7 +10,8 @@ import (
 )
 
 func TestBroker_Subscribe(t *testing.T) {
-	broker := NewBroker(logr.Discard(), &fakePool{})
+	broker, err := NewBroker(logr.Discard(), &fakePool{}, BrokerOptions{})
+	require.NoError(t, err)
 	ctx, cancel := context.WithCancel(context.Background())
 
 	sub, err := broker.Subscribe(ctx, "")
@@ -25,7 +26,8 @@ func TestBroker_Subscribe(t *testing.T) {
 
 func TestBroker_Publish_Local(t *testing.T) {
 	ctx := context.Background()
-	broker := NewBroker(logr.Discard(), &fakePool{})
+	broker, err := NewBroker(logr.Discard(), &fakePool{}, BrokerOptions{})
+	require.NoError(t, err)
 
 	sub, err := broker.Subscribe(ctx, "")
 	require.NoError(t, err)
@@ -41,9 +43,10 @@ func TestBroker_Publish_Local(t *testing.T) {
 
 func TestBroker_UnsubscribeFullSubscriber(t *testing.T) {
 	ctx := context.Background()
-	broker := NewBroker(logr.Discard(), &fakePool{})
+	broker, err := NewBroker(logr.Discard(), &fakePool{}, BrokerOptions{})
+	require.NoError(t, err)
 
-	_, err := broker.Subscribe(ctx, "")
+	_, err = broker.Subscribe(ctx, "")
 	require.NoError(t, err)
 	assert.Equal(t, 1, len(broker.subs))
 
@@ -56,3 +59,67 @@ func TestBroker_UnsubscribeFullSubscriber(t *testing.T) {
 	}
 	assert.Equal(t, 0, len(broker.subs))
 }
+
+func TestBroker_SubscribeFrom(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	broker, err := NewBroker(logr.Discard(), &fakePool{}, BrokerOptions{})
+	require.NoError(t, err)
+	broker.RegisterFunc("runs", func(ctx context.Context, id string, action DBAction) (any, error) {
+		return id, nil
+	})
+	broker.log = &fakeEventLog{events: []pgevent{
+		{EventID: 1, Table: "runs", Action: InsertDBAction, ID: "run-1"},
+		{EventID: 2, Table: "runs", Action: UpdateDBAction, ID: "run-1"},
+		{EventID: 3, Table: "runs", Action: InsertDBAction, ID: "run-2"},
+	}}
+
+	sub, err := broker.SubscribeFrom(ctx, "", 1)
+	require.NoError(t, err)
+
+	// replayed events
+	assert.Equal(t, Event{ID: 2, Type: UpdatedEvent, Payload: "run-1"}, <-sub)
+	assert.Equal(t, Event{ID: 3, Type: CreatedEvent, Payload: "run-2"}, <-sub)
+
+	// event already replayed is skipped, whereas new event is relayed
+	broker.localPublish(Event{ID: 3, Type: CreatedEvent, Payload: "run-2"})
+	broker.localPublish(Event{ID: 4, Type: CreatedEvent, Payload: "run-3"})
+	assert.Equal(t, Event{ID: 4, Type: CreatedEvent, Payload: "run-3"}, <-sub)
+}
+
+func TestBroker_SubscribeFrom_OutOfOrderCommit(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	broker, err := NewBroker(logr.Discard(), &fakePool{}, BrokerOptions{})
+	require.NoError(t, err)
+	broker.RegisterFunc("runs", func(ctx context.Context, id string, action DBAction) (any, error) {
+		return id, nil
+	})
+	// the subscriber last received event 3, but event 2 committed after event
+	// 3 and so was never received. Event 4 is yet to commit.
+	broker.log = &fakeEventLog{
+		events: []pgevent{
+			{EventID: 1, Table: "runs", Action: InsertDBAction, ID: "run-1"},
+			{EventID: 2, Table: "runs", Action: InsertDBAction, ID: "run-2"},
+			{EventID: 3, Table: "runs", Action: InsertDBAction, ID: "run-3"},
+			{EventID: 5, Table: "runs", Action: InsertDBAction, ID: "run-5"},
+		},
+		replayStart: 1,
+	}
+
+	sub, err := broker.SubscribeFrom(ctx, "", 3)
+	require.NoError(t, err)
+
+	// events logged within the window before event 3 are replayed
+	assert.Equal(t, Event{ID: 2, Type: CreatedEvent, Payload: "run-2"}, <-sub)
+	assert.Equal(t, Event{ID: 3, Type: CreatedEvent, Payload: "run-3"}, <-sub)
+	assert.Equal(t, Event{ID: 5, Type: CreatedEvent, Payload: "run-5"}, <-sub)
+
+	// event already replayed is skipped, whereas event committed after the
+	// replay is relayed despite having a lower ID than those replayed.
+	broker.localPublish(Event{ID: 5, Type: CreatedEvent, Payload: "run-5"})
+	broker.localPublish(Event{ID: 4, Type: CreatedEvent, Payload: "run-4"})
+	assert.Equal(t, Event{ID: 4, Type: CreatedEvent, Payload: "run-4"}, <-sub)
+}
@@ -75,5 +75,11 @@ func (r *converter) convert(ctx context.Context, event pgevent) (Event, error) {
 	if err != nil {
 		return Event{}, err
 	}
-	return Event{Type: eventType, Payload: payload}, nil
+	return Event{
+		ID:       event.EventID,
+		Type:     eventType,
+		Payload:  payload,
+		Time:     event.Time,
+		Snapshot: event.Snapshot,
+	}, nil
 }
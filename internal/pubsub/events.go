@@ -1,5 +1,10 @@
 package pubsub
 
+import (
+	"encoding/json"
+	"time"
+)
+
 const (
 	EventError       EventType = "error"
 	EventInfo        EventType = "info"
@@ -22,9 +27,19 @@ type (
 
 	// Event represents an event in the lifecycle of an otf resource
 	Event struct {
+		// ID is the position of the event in the durable event log; zero if
+		// the event is not logged.
+		ID      int64
 		Type    EventType
 		Payload any
 		Local   bool // for local node only and not to be published to rest of cluster
+		// Time the event was logged; zero if the event is not logged.
+		Time time.Time
+		// Snapshot is the state of the resource at the time of the event,
+		// which may differ from the payload if the event is replayed and the
+		// resource has since changed. Nil if the event is not logged or the
+		// resource does not record snapshots.
+		Snapshot json.RawMessage
 	}
 	Table string
 
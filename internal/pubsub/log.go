@@ -0,0 +1,142 @@
+package pubsub
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/jackc/pgtype"
+	"github.com/leg100/otf/internal/logr"
+	"github.com/leg100/otf/internal/sql"
+)
+
+const (
+	// CompactorLockID guarantees only one compactor on a cluster is running at
+	// any time.
+	CompactorLockID int64 = 5577006791947779412
+
+	DefaultEventRetention          = 24 * time.Hour
+	DefaultEventCompactionInterval = 10 * time.Minute
+)
+
+type (
+	// eventLog is the durable log of events
+	eventLog interface {
+		// listEventsAfter lists up to limit events with an ID greater than the
+		// given ID, in ascending order of ID.
+		listEventsAfter(ctx context.Context, id int64, limit int) ([]pgevent, error)
+		// replayStartID returns the ID of the event after which to replay
+		// events to a subscriber that last received the event with the given
+		// ID, taking into account events with a lower ID that were logged
+		// within the given window and may have committed after it.
+		replayStartID(ctx context.Context, id int64, window time.Duration) (int64, error)
+	}
+
+	pgdb struct {
+		pool
+	}
+
+	// Compactor periodically deletes events from the event log that are older
+	// than the retention period.
+	Compactor struct {
+		logr.Logger
+
+		db        *sql.DB
+		retention time.Duration
+		interval  time.Duration
+	}
+
+	CompactorOptions struct {
+		logr.Logger
+		*sql.DB
+
+		// Retention is how long events are retained in the log. Zero retains
+		// events indefinitely.
+		Retention time.Duration
+		// Interval between compactions.
+		Interval time.Duration
+	}
+)
+
+func (db *pgdb) listEventsAfter(ctx context.Context, id int64, limit int) ([]pgevent, error) {
+	rows, err := db.Conn(ctx).FindEventsAfter(ctx,
+		pgtype.Int8{Int: id, Status: pgtype.Present},
+		pgtype.Int8{Int: int64(limit), Status: pgtype.Present},
+	)
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	events := make([]pgevent, len(rows))
+	for i, r := range rows {
+		events[i] = pgevent{
+			EventID:  r.EventID.Int,
+			Time:     r.Time.Time.UTC(),
+			Table:    r.TableName.String,
+			Action:   DBAction(r.Action.String),
+			ID:       r.RecordID.String,
+			Snapshot: snapshot(r.Snapshot),
+		}
+	}
+	return events, nil
+}
+
+func (db *pgdb) replayStartID(ctx context.Context, id int64, window time.Duration) (int64, error) {
+	start, err := db.Conn(ctx).FindReplayStartEventID(ctx,
+		pgtype.Int8{Int: id, Status: pgtype.Present},
+		pgtype.Int8{Int: int64(window.Seconds()), Status: pgtype.Present},
+	)
+	if err != nil {
+		return 0, sql.Error(err)
+	}
+	return start.Int, nil
+}
+
+// snapshot converts an event's snapshot column, which is null for events
+// without a snapshot.
+func snapshot(col pgtype.JSONB) json.RawMessage {
+	if col.Status != pgtype.Present {
+		return nil
+	}
+	return col.Bytes
+}
+
+func NewCompactor(opts CompactorOptions) *Compactor {
+	return &Compactor{
+		Logger:    opts.Logger.WithValues("component", "compactor"),
+		db:        opts.DB,
+		retention: opts.Retention,
+		interval:  opts.Interval,
+	}
+}
+
+// Start the compactor. Should be started in a go-routine.
+func (c *Compactor) Start(ctx context.Context) error {
+	if c.retention == 0 {
+		c.V(1).Info("event retention is unlimited; compaction disabled")
+		<-ctx.Done()
+		return nil
+	}
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		c.compact(ctx)
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// compact deletes events older than the retention period.
+func (c *Compactor) compact(ctx context.Context) {
+	before := time.Now().Add(-c.retention)
+	tag, err := c.db.Conn(ctx).DeleteEventsBefore(ctx, pgtype.Timestamptz{Time: before.UTC(), Status: pgtype.Present})
+	if err != nil {
+		c.Error(err, "compacting event log")
+		return
+	}
+	compactedEvents.Add(float64(tag.RowsAffected()))
+	c.V(2).Info("compacted event log", "deleted", tag.RowsAffected(), "before", before)
+}
@@ -4,6 +4,8 @@ import "github.com/prometheus/client_golang/prometheus"
 
 func init() {
 	prometheus.MustRegister(totalSubscribers)
+	prometheus.MustRegister(subscriberLag)
+	prometheus.MustRegister(compactedEvents)
 }
 
 var totalSubscribers = prometheus.NewGauge(prometheus.GaugeOpts{
@@ -12,3 +14,17 @@ var totalSubscribers = prometheus.NewGauge(prometheus.GaugeOpts{
 	Name:      "total_subscribers",
 	Help:      "Total number of subscribers.",
 })
+
+var subscriberLag = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: "otf",
+	Subsystem: "pub_sub",
+	Name:      "subscriber_lag",
+	Help:      "Number of logged events a resumable subscriber has yet to receive.",
+}, []string{"name"})
+
+var compactedEvents = prometheus.NewCounter(prometheus.CounterOpts{
+	Namespace: "otf",
+	Subsystem: "pub_sub",
+	Name:      "compacted_events_total",
+	Help:      "Total number of events deleted from the event log.",
+})
@@ -8,6 +8,7 @@ import "context"
 type PubSubService interface {
 	Publisher
 	Subscriber
+	ResumableSubscriber
 }
 
 type Publisher interface {
@@ -21,3 +22,12 @@ type Subscriber interface {
 	// caller.
 	Subscribe(ctx context.Context, name string) (<-chan Event, error)
 }
+
+// ResumableSubscriber is capable of creating a subscription that first replays
+// events the caller has missed.
+type ResumableSubscriber interface {
+	// SubscribeFrom subscribes the caller to OTF events, replaying logged
+	// events with an ID greater than lastEventID before relaying new events.
+	// If lastEventID is zero then no events are replayed.
+	SubscribeFrom(ctx context.Context, name string, lastEventID int64) (<-chan Event, error)
+}
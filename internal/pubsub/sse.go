@@ -4,6 +4,8 @@ import (
 	"encoding/base64"
 	"fmt"
 	"io"
+	"net/http"
+	"strconv"
 	"strings"
 )
 
@@ -18,3 +20,24 @@ func WriteSSEEvent(w io.Writer, data []byte, event EventType, base64encode bool)
 	fmt.Fprintf(w, "data: %s\n", strings.ReplaceAll(string(data), "\n", "&#13;"))
 	fmt.Fprintf(w, "event: %s\n\n", event)
 }
+
+// WriteSSEEventWithID writes a server-side-event to w along with its ID, which
+// the client sends in the Last-Event-ID header upon reconnecting. An ID of
+// zero is omitted.
+func WriteSSEEventWithID(w io.Writer, id int64, data []byte, event EventType, base64encode bool) {
+	if id != 0 {
+		fmt.Fprintf(w, "id: %d\n", id)
+	}
+	WriteSSEEvent(w, data, event, base64encode)
+}
+
+// LastEventID retrieves the ID of the last event received by a reconnecting
+// client from the Last-Event-ID header. Zero is returned if the header is
+// absent or invalid.
+func LastEventID(r *http.Request) int64 {
+	id, err := strconv.ParseInt(r.Header.Get("Last-Event-ID"), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return id
+}
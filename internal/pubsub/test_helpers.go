@@ -2,12 +2,20 @@ package pubsub
 
 import (
 	"context"
+	"slices"
+	"time"
 )
 
 type (
 	fakePool struct {
 		pool
 	}
+	fakeEventLog struct {
+		events []pgevent
+		// replayStart is the ID returned by replayStartID; if zero then the
+		// given ID is returned.
+		replayStart int64
+	}
 	fakeGetter struct {
 		fake *fakeType
 	}
@@ -20,3 +28,38 @@ type (
 func (f *fakeGetter) GetByID(context.Context, string, DBAction) (any, error) {
 	return f.fake, nil
 }
+
+func (f *fakeEventLog) listEventsAfter(ctx context.Context, id int64, limit int) ([]pgevent, error) {
+	var events []pgevent
+	for _, ev := range f.events {
+		if ev.EventID > id && len(events) < limit {
+			events = append(events, ev)
+		}
+	}
+	return events, nil
+}
+
+func (f *fakeEventLog) replayStartID(ctx context.Context, id int64, window time.Duration) (int64, error) {
+	if f.replayStart != 0 {
+		return f.replayStart, nil
+	}
+	return id, nil
+}
+
+func (f *fakeEventLog) latestEventID(context.Context) (int64, error) {
+	if len(f.events) == 0 {
+		return 0, nil
+	}
+	return f.events[len(f.events)-1].EventID, nil
+}
+
+func (f *fakeEventLog) listEventsAfterOrMissing(ctx context.Context, id int64, missing []int64, limit int) ([]pgevent, error) {
+	var events []pgevent
+	for _, ev := range f.events {
+		if (ev.EventID > id || slices.Contains(missing, ev.EventID)) && len(events) < limit {
+			events = append(events, ev)
+		}
+	}
+	slices.SortFunc(events, func(a, b pgevent) int { return int(a.EventID - b.EventID) })
+	return events, nil
+}
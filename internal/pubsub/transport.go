@@ -0,0 +1,223 @@
+package pubsub
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/jackc/pgtype"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/sql/pggen"
+)
+
+const (
+	// NotifyTransport relays events using postgres' LISTEN/NOTIFY.
+	NotifyTransport TransportKind = "notify"
+	// PollTransport relays events by polling the event log.
+	PollTransport TransportKind = "poll"
+
+	DefaultTransport    = NotifyTransport
+	DefaultPollInterval = time.Second
+
+	// pollBatchSize is the maximum number of events retrieved per query when
+	// polling the event log.
+	pollBatchSize = 1000
+
+	// maxGapAge is how long the poller keeps checking for an event missing
+	// from a sequence of event IDs. Event IDs are allocated when a transaction
+	// inserts an event but the event is only visible once the transaction
+	// commits, so an event with a lower ID can appear after an event with a
+	// higher ID. Gaps also arise from rolled back transactions, in which case
+	// the missing event never appears.
+	maxGapAge = 30 * time.Second
+
+	// maxGaps is the maximum number of missing events tracked by the poller.
+	maxGaps = 1000
+)
+
+type (
+	// TransportKind identifies a transport for relaying events between nodes.
+	TransportKind string
+
+	// transport relays database events to the broker.
+	transport interface {
+		// start relaying events to fn until the context is canceled. ready is
+		// called once the transport has begun relaying events.
+		start(ctx context.Context, ready func(), fn func(context.Context, pgevent)) error
+	}
+
+	// notifyTransport relays events using postgres' LISTEN/NOTIFY, which
+	// requires a dedicated connection to postgres.
+	notifyTransport struct {
+		logr.Logger
+
+		pool    pool   // pool from which to acquire a dedicated connection to postgres
+		channel string // postgres notification channel name
+	}
+
+	// pollTransport relays events by periodically polling the event log. It
+	// does not require a dedicated connection, and so is compatible with
+	// connection poolers such as PgBouncer in transaction mode.
+	pollTransport struct {
+		logr.Logger
+
+		db       eventPoller
+		interval time.Duration
+
+		last int64               // ID of last event relayed
+		gaps map[int64]time.Time // IDs of missing events and when first found missing
+	}
+
+	eventPoller interface {
+		latestEventID(ctx context.Context) (int64, error)
+		listEventsAfterOrMissing(ctx context.Context, id int64, missing []int64, limit int) ([]pgevent, error)
+	}
+)
+
+func newTransport(logger logr.Logger, db pool, opts BrokerOptions) (transport, error) {
+	switch opts.Transport {
+	case NotifyTransport, "":
+		return &notifyTransport{Logger: logger, pool: db, channel: defaultChannel}, nil
+	case PollTransport:
+		interval := opts.PollInterval
+		if interval == 0 {
+			interval = DefaultPollInterval
+		}
+		return &pollTransport{Logger: logger, db: &pgdb{db}, interval: interval}, nil
+	default:
+		return nil, fmt.Errorf("unknown pubsub transport: %s", opts.Transport)
+	}
+}
+
+func (t *notifyTransport) start(ctx context.Context, ready func(), fn func(context.Context, pgevent)) error {
+	conn, err := t.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to acquire postgres connection: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "listen "+t.channel); err != nil {
+		return err
+	}
+	t.V(2).Info("listening for events")
+	ready()
+
+	for {
+		notification, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			select {
+			case <-ctx.Done():
+				// parent has decided to shutdown so exit without error
+				return nil
+			default:
+				t.Error(err, "waiting for postgres notification")
+				return err
+			}
+		}
+		var pge pgevent
+		if err := json.Unmarshal([]byte(notification.Payload), &pge); err != nil {
+			t.Error(err, "unmarshaling postgres notification")
+			continue
+		}
+		fn(ctx, pge)
+	}
+}
+
+func (t *pollTransport) start(ctx context.Context, ready func(), fn func(context.Context, pgevent)) error {
+	if t.gaps == nil {
+		// first time starting: relay only events that occur from now on;
+		// whereas upon restarting, resume from the last event relayed.
+		last, err := t.db.latestEventID(ctx)
+		if err != nil {
+			return fmt.Errorf("retrieving latest event ID: %w", err)
+		}
+		t.last = last
+		t.gaps = make(map[int64]time.Time)
+	}
+	t.V(2).Info("polling for events", "interval", t.interval, "after", t.last)
+	ready()
+
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := t.poll(ctx, fn); err != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+				t.Error(err, "polling for events")
+				return err
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// poll relays events that are newer than the last event relayed, along with
+// any events that were previously found missing.
+func (t *pollTransport) poll(ctx context.Context, fn func(context.Context, pgevent)) error {
+	for {
+		missing := make([]int64, 0, len(t.gaps))
+		for id, found := range t.gaps {
+			if time.Since(found) > maxGapAge {
+				delete(t.gaps, id)
+				continue
+			}
+			missing = append(missing, id)
+		}
+		events, err := t.db.listEventsAfterOrMissing(ctx, t.last, missing, pollBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, pge := range events {
+			if pge.EventID > t.last {
+				// record any IDs skipped over
+				for id := t.last + 1; id < pge.EventID && len(t.gaps) < maxGaps; id++ {
+					t.gaps[id] = time.Now()
+				}
+				t.last = pge.EventID
+			} else {
+				delete(t.gaps, pge.EventID)
+			}
+			fn(ctx, pge)
+		}
+		if len(events) < pollBatchSize {
+			return nil
+		}
+	}
+}
+
+func (db *pgdb) latestEventID(ctx context.Context) (int64, error) {
+	id, err := db.Conn(ctx).FindLatestEventID(ctx)
+	if err != nil {
+		return 0, sql.Error(err)
+	}
+	return id.Int, nil
+}
+
+func (db *pgdb) listEventsAfterOrMissing(ctx context.Context, id int64, missing []int64, limit int) ([]pgevent, error) {
+	rows, err := db.Conn(ctx).FindEventsAfterOrMissing(ctx, pggen.FindEventsAfterOrMissingParams{
+		AfterID:    pgtype.Int8{Int: id, Status: pgtype.Present},
+		MissingIds: missing,
+		Limit:      pgtype.Int8{Int: int64(limit), Status: pgtype.Present},
+	})
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	events := make([]pgevent, len(rows))
+	for i, r := range rows {
+		events[i] = pgevent{
+			EventID:  r.EventID.Int,
+			Time:     r.Time.Time.UTC(),
+			Table:    r.TableName.String,
+			Action:   DBAction(r.Action.String),
+			ID:       r.RecordID.String,
+			Snapshot: snapshot(r.Snapshot),
+		}
+	}
+	return events, nil
+}
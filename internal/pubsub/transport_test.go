@@ -0,0 +1,36 @@
+package pubsub
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/leg100/otf/internal/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPollTransport(t *testing.T) {
+	ctx := context.Background()
+	db := &fakeEventLog{events: []pgevent{
+		{EventID: 1, Table: "runs"},
+		{EventID: 2, Table: "runs"},
+		{EventID: 4, Table: "runs"},
+	}}
+	transport := &pollTransport{Logger: logr.Discard(), db: db, gaps: make(map[int64]time.Time)}
+
+	var got []int64
+	relay := func(_ context.Context, pge pgevent) { got = append(got, pge.EventID) }
+
+	require.NoError(t, transport.poll(ctx, relay))
+	assert.Equal(t, []int64{1, 2, 4}, got)
+	assert.Contains(t, transport.gaps, int64(3))
+
+	// missing event appears once its transaction commits
+	db.events = append(db.events, pgevent{EventID: 3, Table: "runs"}, pgevent{EventID: 5, Table: "runs"})
+	got = nil
+
+	require.NoError(t, transport.poll(ctx, relay))
+	assert.Equal(t, []int64{3, 5}, got)
+	assert.Empty(t, transport.gaps)
+}
@@ -1,5 +1,7 @@
 package rbac
 
+import "fmt"
+
 // Action identifies an action a subject carries out on a resource for
 // authorization purposes.
 type Action int
@@ -38,6 +40,18 @@ const (
 	GetModuleAction
 	DeleteModuleAction
 	DeleteModuleVersionAction
+	TestModuleVersionAction
+
+	CreateRegistryProviderAction
+	ListRegistryProvidersAction
+	GetRegistryProviderAction
+	DeleteRegistryProviderAction
+	CreateRegistryProviderVersionAction
+	DeleteRegistryProviderVersionAction
+
+	CreateGPGKeyAction
+	ListGPGKeysAction
+	DeleteGPGKeyAction
 
 	CreateWorkspaceVariableAction
 	UpdateWorkspaceVariableAction
@@ -81,6 +95,8 @@ const (
 	GetLockFileAction
 	UploadLockFileAction
 
+	UploadTestResultsAction
+
 	ListWorkspacesAction
 	GetWorkspaceAction
 	CreateWorkspaceAction
@@ -89,6 +105,14 @@ const (
 	UnsetWorkspacePermissionAction
 	UpdateWorkspaceAction
 
+	CreateProjectAction
+	UpdateProjectAction
+	GetProjectAction
+	ListProjectsAction
+	DeleteProjectAction
+	SetProjectPermissionAction
+	UnsetProjectPermissionAction
+
 	ListTagsAction
 	DeleteTagsAction
 	TagWorkspacesAction
@@ -108,6 +132,7 @@ const (
 	UploadStateAction
 	DownloadStateAction
 	GetStateVersionOutputAction
+	SearchStateResourcesAction
 
 	CreateConfigurationVersionAction
 	ListConfigurationVersionsAction
@@ -127,6 +152,8 @@ const (
 	DeleteTeamAction
 	AddTeamMembershipAction
 	RemoveTeamMembershipAction
+	SyncSSOTeamMembershipsAction
+	LinkVCSIdentityAction
 
 	CreateNotificationConfigurationAction
 	UpdateNotificationConfigurationAction
@@ -142,3 +169,28 @@ const (
 	CreateGithubAppInstallAction
 	DeleteGithubAppInstallAction
 )
+
+// ParseAction parses the name of an action, e.g. GetRunAction, into an action.
+func ParseAction(name string) (Action, error) {
+	for i := 0; i < len(_Action_index)-1; i++ {
+		if action := Action(i); action.String() == name {
+			return action, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown action: %s", name)
+}
+
+// MarshalText marshals an action into its name.
+func (a Action) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalText unmarshals an action from its name.
+func (a *Action) UnmarshalText(text []byte) error {
+	action, err := ParseAction(string(text))
+	if err != nil {
+		return err
+	}
+	*a = action
+	return nil
+}
@@ -35,101 +35,123 @@ func _() {
 	_ = x[GetModuleAction-24]
 	_ = x[DeleteModuleAction-25]
 	_ = x[DeleteModuleVersionAction-26]
-	_ = x[CreateWorkspaceVariableAction-27]
-	_ = x[UpdateWorkspaceVariableAction-28]
-	_ = x[ListWorkspaceVariablesAction-29]
-	_ = x[GetWorkspaceVariableAction-30]
-	_ = x[DeleteWorkspaceVariableAction-31]
-	_ = x[CreateVariableSetAction-32]
-	_ = x[UpdateVariableSetAction-33]
-	_ = x[ListVariableSetsAction-34]
-	_ = x[GetVariableSetAction-35]
-	_ = x[DeleteVariableSetAction-36]
-	_ = x[CreateVariableSetVariableAction-37]
-	_ = x[UpdateVariableSetVariableAction-38]
-	_ = x[GetVariableSetVariableAction-39]
-	_ = x[DeleteVariableSetVariableAction-40]
-	_ = x[AddVariableToSetAction-41]
-	_ = x[RemoveVariableFromSetAction-42]
-	_ = x[ApplyVariableSetToWorkspacesAction-43]
-	_ = x[DeleteVariableSetFromWorkspacesAction-44]
-	_ = x[GetRunAction-45]
-	_ = x[ListRunsAction-46]
-	_ = x[ApplyRunAction-47]
-	_ = x[CreateRunAction-48]
-	_ = x[DiscardRunAction-49]
-	_ = x[DeleteRunAction-50]
-	_ = x[CancelRunAction-51]
-	_ = x[EnqueuePlanAction-52]
-	_ = x[StartPhaseAction-53]
-	_ = x[FinishPhaseAction-54]
-	_ = x[PutChunkAction-55]
-	_ = x[TailLogsAction-56]
-	_ = x[GetPlanFileAction-57]
-	_ = x[UploadPlanFileAction-58]
-	_ = x[GetLockFileAction-59]
-	_ = x[UploadLockFileAction-60]
-	_ = x[ListWorkspacesAction-61]
-	_ = x[GetWorkspaceAction-62]
-	_ = x[CreateWorkspaceAction-63]
-	_ = x[DeleteWorkspaceAction-64]
-	_ = x[SetWorkspacePermissionAction-65]
-	_ = x[UnsetWorkspacePermissionAction-66]
-	_ = x[UpdateWorkspaceAction-67]
-	_ = x[ListTagsAction-68]
-	_ = x[DeleteTagsAction-69]
-	_ = x[TagWorkspacesAction-70]
-	_ = x[AddTagsAction-71]
-	_ = x[RemoveTagsAction-72]
-	_ = x[ListWorkspaceTags-73]
-	_ = x[LockWorkspaceAction-74]
-	_ = x[UnlockWorkspaceAction-75]
-	_ = x[ForceUnlockWorkspaceAction-76]
-	_ = x[CreateStateVersionAction-77]
-	_ = x[ListStateVersionsAction-78]
-	_ = x[GetStateVersionAction-79]
-	_ = x[DeleteStateVersionAction-80]
-	_ = x[RollbackStateVersionAction-81]
-	_ = x[UploadStateAction-82]
-	_ = x[DownloadStateAction-83]
-	_ = x[GetStateVersionOutputAction-84]
-	_ = x[CreateConfigurationVersionAction-85]
-	_ = x[ListConfigurationVersionsAction-86]
-	_ = x[GetConfigurationVersionAction-87]
-	_ = x[DownloadConfigurationVersionAction-88]
-	_ = x[DeleteConfigurationVersionAction-89]
-	_ = x[CreateUserAction-90]
-	_ = x[ListUsersAction-91]
-	_ = x[GetUserAction-92]
-	_ = x[DeleteUserAction-93]
-	_ = x[CreateTeamAction-94]
-	_ = x[UpdateTeamAction-95]
-	_ = x[GetTeamAction-96]
-	_ = x[ListTeamsAction-97]
-	_ = x[DeleteTeamAction-98]
-	_ = x[AddTeamMembershipAction-99]
-	_ = x[RemoveTeamMembershipAction-100]
-	_ = x[CreateNotificationConfigurationAction-101]
-	_ = x[UpdateNotificationConfigurationAction-102]
-	_ = x[ListNotificationConfigurationsAction-103]
-	_ = x[GetNotificationConfigurationAction-104]
-	_ = x[DeleteNotificationConfigurationAction-105]
-	_ = x[CreateGithubAppAction-106]
-	_ = x[UpdateGithubAppAction-107]
-	_ = x[GetGithubAppAction-108]
-	_ = x[ListGithubAppsAction-109]
-	_ = x[DeleteGithubAppAction-110]
-	_ = x[CreateGithubAppInstallAction-111]
-	_ = x[DeleteGithubAppInstallAction-112]
+	_ = x[TestModuleVersionAction-27]
+	_ = x[CreateRegistryProviderAction-28]
+	_ = x[ListRegistryProvidersAction-29]
+	_ = x[GetRegistryProviderAction-30]
+	_ = x[DeleteRegistryProviderAction-31]
+	_ = x[CreateRegistryProviderVersionAction-32]
+	_ = x[DeleteRegistryProviderVersionAction-33]
+	_ = x[CreateGPGKeyAction-34]
+	_ = x[ListGPGKeysAction-35]
+	_ = x[DeleteGPGKeyAction-36]
+	_ = x[CreateWorkspaceVariableAction-37]
+	_ = x[UpdateWorkspaceVariableAction-38]
+	_ = x[ListWorkspaceVariablesAction-39]
+	_ = x[GetWorkspaceVariableAction-40]
+	_ = x[DeleteWorkspaceVariableAction-41]
+	_ = x[CreateVariableSetAction-42]
+	_ = x[UpdateVariableSetAction-43]
+	_ = x[ListVariableSetsAction-44]
+	_ = x[GetVariableSetAction-45]
+	_ = x[DeleteVariableSetAction-46]
+	_ = x[CreateVariableSetVariableAction-47]
+	_ = x[UpdateVariableSetVariableAction-48]
+	_ = x[GetVariableSetVariableAction-49]
+	_ = x[DeleteVariableSetVariableAction-50]
+	_ = x[AddVariableToSetAction-51]
+	_ = x[RemoveVariableFromSetAction-52]
+	_ = x[ApplyVariableSetToWorkspacesAction-53]
+	_ = x[DeleteVariableSetFromWorkspacesAction-54]
+	_ = x[GetRunAction-55]
+	_ = x[ListRunsAction-56]
+	_ = x[ApplyRunAction-57]
+	_ = x[CreateRunAction-58]
+	_ = x[DiscardRunAction-59]
+	_ = x[DeleteRunAction-60]
+	_ = x[CancelRunAction-61]
+	_ = x[EnqueuePlanAction-62]
+	_ = x[StartPhaseAction-63]
+	_ = x[FinishPhaseAction-64]
+	_ = x[PutChunkAction-65]
+	_ = x[TailLogsAction-66]
+	_ = x[GetPlanFileAction-67]
+	_ = x[UploadPlanFileAction-68]
+	_ = x[GetLockFileAction-69]
+	_ = x[UploadLockFileAction-70]
+	_ = x[UploadTestResultsAction-71]
+	_ = x[ListWorkspacesAction-72]
+	_ = x[GetWorkspaceAction-73]
+	_ = x[CreateWorkspaceAction-74]
+	_ = x[DeleteWorkspaceAction-75]
+	_ = x[SetWorkspacePermissionAction-76]
+	_ = x[UnsetWorkspacePermissionAction-77]
+	_ = x[UpdateWorkspaceAction-78]
+	_ = x[CreateProjectAction-79]
+	_ = x[UpdateProjectAction-80]
+	_ = x[GetProjectAction-81]
+	_ = x[ListProjectsAction-82]
+	_ = x[DeleteProjectAction-83]
+	_ = x[SetProjectPermissionAction-84]
+	_ = x[UnsetProjectPermissionAction-85]
+	_ = x[ListTagsAction-86]
+	_ = x[DeleteTagsAction-87]
+	_ = x[TagWorkspacesAction-88]
+	_ = x[AddTagsAction-89]
+	_ = x[RemoveTagsAction-90]
+	_ = x[ListWorkspaceTags-91]
+	_ = x[LockWorkspaceAction-92]
+	_ = x[UnlockWorkspaceAction-93]
+	_ = x[ForceUnlockWorkspaceAction-94]
+	_ = x[CreateStateVersionAction-95]
+	_ = x[ListStateVersionsAction-96]
+	_ = x[GetStateVersionAction-97]
+	_ = x[DeleteStateVersionAction-98]
+	_ = x[RollbackStateVersionAction-99]
+	_ = x[UploadStateAction-100]
+	_ = x[DownloadStateAction-101]
+	_ = x[GetStateVersionOutputAction-102]
+	_ = x[SearchStateResourcesAction-103]
+	_ = x[CreateConfigurationVersionAction-104]
+	_ = x[ListConfigurationVersionsAction-105]
+	_ = x[GetConfigurationVersionAction-106]
+	_ = x[DownloadConfigurationVersionAction-107]
+	_ = x[DeleteConfigurationVersionAction-108]
+	_ = x[CreateUserAction-109]
+	_ = x[ListUsersAction-110]
+	_ = x[GetUserAction-111]
+	_ = x[DeleteUserAction-112]
+	_ = x[CreateTeamAction-113]
+	_ = x[UpdateTeamAction-114]
+	_ = x[GetTeamAction-115]
+	_ = x[ListTeamsAction-116]
+	_ = x[DeleteTeamAction-117]
+	_ = x[AddTeamMembershipAction-118]
+	_ = x[RemoveTeamMembershipAction-119]
+	_ = x[SyncSSOTeamMembershipsAction-120]
+	_ = x[LinkVCSIdentityAction-121]
+	_ = x[CreateNotificationConfigurationAction-122]
+	_ = x[UpdateNotificationConfigurationAction-123]
+	_ = x[ListNotificationConfigurationsAction-124]
+	_ = x[GetNotificationConfigurationAction-125]
+	_ = x[DeleteNotificationConfigurationAction-126]
+	_ = x[CreateGithubAppAction-127]
+	_ = x[UpdateGithubAppAction-128]
+	_ = x[GetGithubAppAction-129]
+	_ = x[ListGithubAppsAction-130]
+	_ = x[DeleteGithubAppAction-131]
+	_ = x[CreateGithubAppInstallAction-132]
+	_ = x[DeleteGithubAppInstallAction-133]
 }
 
-const _Action_name = "WatchActionCreateOrganizationActionUpdateOrganizationActionGetOrganizationActionListOrganizationsActionGetEntitlementsActionDeleteOrganizationActionCreateVCSProviderActionGetVCSProviderActionListVCSProvidersActionDeleteVCSProviderActionCreateAgentTokenActionListAgentTokensActionDeleteAgentTokenActionCreateOrganizationTokenActionDeleteOrganizationTokenActionCreateRunTokenActionCreateTeamTokenActionGetTeamTokenActionDeleteTeamTokenActionCreateModuleActionCreateModuleVersionActionUpdateModuleActionListModulesActionGetModuleActionDeleteModuleActionDeleteModuleVersionActionCreateWorkspaceVariableActionUpdateWorkspaceVariableActionListWorkspaceVariablesActionGetWorkspaceVariableActionDeleteWorkspaceVariableActionCreateVariableSetActionUpdateVariableSetActionListVariableSetsActionGetVariableSetActionDeleteVariableSetActionCreateVariableSetVariableActionUpdateVariableSetVariableActionGetVariableSetVariableActionDeleteVariableSetVariableActionAddVariableToSetActionRemoveVariableFromSetActionApplyVariableSetToWorkspacesActionDeleteVariableSetFromWorkspacesActionGetRunActionListRunsActionApplyRunActionCreateRunActionDiscardRunActionDeleteRunActionCancelRunActionEnqueuePlanActionStartPhaseActionFinishPhaseActionPutChunkActionTailLogsActionGetPlanFileActionUploadPlanFileActionGetLockFileActionUploadLockFileActionListWorkspacesActionGetWorkspaceActionCreateWorkspaceActionDeleteWorkspaceActionSetWorkspacePermissionActionUnsetWorkspacePermissionActionUpdateWorkspaceActionListTagsActionDeleteTagsActionTagWorkspacesActionAddTagsActionRemoveTagsActionListWorkspaceTagsLockWorkspaceActionUnlockWorkspaceActionForceUnlockWorkspaceActionCreateStateVersionActionListStateVersionsActionGetStateVersionActionDeleteStateVersionActionRollbackStateVersionActionUploadStateActionDownloadStateActionGetStateVersionOutputActionCreateConfigurationVersionActionListConfigurationVersionsActionGetConfigurationVersionActionDownloadConfigurationVersionActionDeleteConfigurationVersionActionCreateUserActionListUsersActionGetUserActionDeleteUserActionCreateTeamActionUpdateTeamActionGetTeamActionListTeamsActionDeleteTeamActionAddTeamMembershipActionRemoveTeamMembershipActionCreateNotificationConfigurationActionUpdateNotificationConfigurationActionListNotificationConfigurationsActionGetNotificationConfigurationActionDeleteNotificationConfigurationActionCreateGithubAppActionUpdateGithubAppActionGetGithubAppActionListGithubAppsActionDeleteGithubAppActionCreateGithubAppInstallActionDeleteGithubAppInstallAction"
+const _Action_name = "WatchActionCreateOrganizationActionUpdateOrganizationActionGetOrganizationActionListOrganizationsActionGetEntitlementsActionDeleteOrganizationActionCreateVCSProviderActionGetVCSProviderActionListVCSProvidersActionDeleteVCSProviderActionCreateAgentTokenActionListAgentTokensActionDeleteAgentTokenActionCreateOrganizationTokenActionDeleteOrganizationTokenActionCreateRunTokenActionCreateTeamTokenActionGetTeamTokenActionDeleteTeamTokenActionCreateModuleActionCreateModuleVersionActionUpdateModuleActionListModulesActionGetModuleActionDeleteModuleActionDeleteModuleVersionActionTestModuleVersionActionCreateRegistryProviderActionListRegistryProvidersActionGetRegistryProviderActionDeleteRegistryProviderActionCreateRegistryProviderVersionActionDeleteRegistryProviderVersionActionCreateGPGKeyActionListGPGKeysActionDeleteGPGKeyActionCreateWorkspaceVariableActionUpdateWorkspaceVariableActionListWorkspaceVariablesActionGetWorkspaceVariableActionDeleteWorkspaceVariableActionCreateVariableSetActionUpdateVariableSetActionListVariableSetsActionGetVariableSetActionDeleteVariableSetActionCreateVariableSetVariableActionUpdateVariableSetVariableActionGetVariableSetVariableActionDeleteVariableSetVariableActionAddVariableToSetActionRemoveVariableFromSetActionApplyVariableSetToWorkspacesActionDeleteVariableSetFromWorkspacesActionGetRunActionListRunsActionApplyRunActionCreateRunActionDiscardRunActionDeleteRunActionCancelRunActionEnqueuePlanActionStartPhaseActionFinishPhaseActionPutChunkActionTailLogsActionGetPlanFileActionUploadPlanFileActionGetLockFileActionUploadLockFileActionUploadTestResultsActionListWorkspacesActionGetWorkspaceActionCreateWorkspaceActionDeleteWorkspaceActionSetWorkspacePermissionActionUnsetWorkspacePermissionActionUpdateWorkspaceActionCreateProjectActionUpdateProjectActionGetProjectActionListProjectsActionDeleteProjectActionSetProjectPermissionActionUnsetProjectPermissionActionListTagsActionDeleteTagsActionTagWorkspacesActionAddTagsActionRemoveTagsActionListWorkspaceTagsLockWorkspaceActionUnlockWorkspaceActionForceUnlockWorkspaceActionCreateStateVersionActionListStateVersionsActionGetStateVersionActionDeleteStateVersionActionRollbackStateVersionActionUploadStateActionDownloadStateActionGetStateVersionOutputActionSearchStateResourcesActionCreateConfigurationVersionActionListConfigurationVersionsActionGetConfigurationVersionActionDownloadConfigurationVersionActionDeleteConfigurationVersionActionCreateUserActionListUsersActionGetUserActionDeleteUserActionCreateTeamActionUpdateTeamActionGetTeamActionListTeamsActionDeleteTeamActionAddTeamMembershipActionRemoveTeamMembershipActionSyncSSOTeamMembershipsActionLinkVCSIdentityActionCreateNotificationConfigurationActionUpdateNotificationConfigurationActionListNotificationConfigurationsActionGetNotificationConfigurationActionDeleteNotificationConfigurationActionCreateGithubAppActionUpdateGithubAppActionGetGithubAppActionListGithubAppsActionDeleteGithubAppActionCreateGithubAppInstallActionDeleteGithubAppInstallAction"
 
-var _Action_index = [...]uint16{0, 11, 35, 59, 80, 103, 124, 148, 171, 191, 213, 236, 258, 279, 301, 330, 359, 379, 400, 418, 439, 457, 482, 500, 517, 532, 550, 575, 604, 633, 661, 687, 716, 739, 762, 784, 804, 827, 858, 889, 917, 948, 970, 997, 1031, 1068, 1080, 1094, 1108, 1123, 1139, 1154, 1169, 1186, 1202, 1219, 1233, 1247, 1264, 1284, 1301, 1321, 1341, 1359, 1380, 1401, 1429, 1459, 1480, 1494, 1510, 1529, 1542, 1558, 1575, 1594, 1615, 1641, 1665, 1688, 1709, 1733, 1759, 1776, 1795, 1822, 1854, 1885, 1914, 1948, 1980, 1996, 2011, 2024, 2040, 2056, 2072, 2085, 2100, 2116, 2139, 2165, 2202, 2239, 2275, 2309, 2346, 2367, 2388, 2406, 2426, 2447, 2475, 2503}
+var _Action_index = [...]uint16{0, 11, 35, 59, 80, 103, 124, 148, 171, 191, 213, 236, 258, 279, 301, 330, 359, 379, 400, 418, 439, 457, 482, 500, 517, 532, 550, 575, 598, 626, 653, 678, 706, 741, 776, 794, 811, 829, 858, 887, 915, 941, 970, 993, 1016, 1038, 1058, 1081, 1112, 1143, 1171, 1202, 1224, 1251, 1285, 1322, 1334, 1348, 1362, 1377, 1393, 1408, 1423, 1440, 1456, 1473, 1487, 1501, 1518, 1538, 1555, 1575, 1598, 1618, 1636, 1657, 1678, 1706, 1736, 1757, 1776, 1795, 1811, 1829, 1848, 1874, 1902, 1916, 1932, 1951, 1964, 1980, 1997, 2016, 2037, 2063, 2087, 2110, 2131, 2155, 2181, 2198, 2217, 2244, 2270, 2302, 2333, 2362, 2396, 2428, 2444, 2459, 2472, 2488, 2504, 2520, 2533, 2548, 2564, 2587, 2613, 2641, 2662, 2699, 2736, 2772, 2806, 2843, 2864, 2885, 2903, 2923, 2944, 2972, 3000}
 
 func (i Action) String() string {
-	if i < 0 || i >= Action(len(_Action_index)-1) {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_Action_index)-1 {
 		return "Action(" + strconv.FormatInt(int64(i), 10) + ")"
 	}
-	return _Action_name[_Action_index[i]:_Action_index[i+1]]
+	return _Action_name[_Action_index[idx]:_Action_index[idx+1]]
 }
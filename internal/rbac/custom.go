@@ -0,0 +1,180 @@
+package rbac
+
+import (
+	"fmt"
+	"slices"
+)
+
+const (
+	// RunsRead permits reading runs
+	RunsRead RunsPermission = "read"
+	// RunsPlan permits reading runs and queuing plans
+	RunsPlan RunsPermission = "plan"
+	// RunsApply permits reading runs, queuing plans and applying, discarding
+	// and cancelling runs
+	RunsApply RunsPermission = "apply"
+
+	VariablesNone  VariablesPermission = "none"
+	VariablesRead  VariablesPermission = "read"
+	VariablesWrite VariablesPermission = "write"
+
+	StateVersionsNone StateVersionsPermission = "none"
+	// StateVersionsReadOutputs permits reading only the outputs of state
+	// versions
+	StateVersionsReadOutputs StateVersionsPermission = "read-outputs"
+	StateVersionsRead        StateVersionsPermission = "read"
+	StateVersionsWrite       StateVersionsPermission = "write"
+
+	customRoleName = "custom"
+)
+
+type (
+	RunsPermission          string
+	VariablesPermission     string
+	StateVersionsPermission string
+
+	// CustomPermissions are fine-grained workspace permissions, permitting a
+	// team's access to a workspace to be tailored rather than relying on one
+	// of the fixed workspace roles.
+	//
+	// https://developer.hashicorp.com/terraform/cloud-docs/users-teams-organizations/permissions#custom-workspace-permissions
+	CustomPermissions struct {
+		Runs             RunsPermission
+		Variables        VariablesPermission
+		StateVersions    StateVersionsPermission
+		WorkspaceLocking bool
+		// RunTasks permits managing run tasks. OTF does not yet support run
+		// tasks, and the permission is only recorded for compatibility with
+		// TFC/TFE.
+		RunTasks bool
+	}
+)
+
+var (
+	// actions permitted regardless of custom permissions
+	customBaseActions = []Action{
+		GetWorkspaceAction,
+		WatchAction,
+		ListWorkspaceTags,
+		ListNotificationConfigurationsAction,
+		GetNotificationConfigurationAction,
+	}
+
+	customRunsActions = map[RunsPermission][]Action{
+		RunsRead: {
+			ListRunsAction,
+			GetRunAction,
+			GetPlanFileAction,
+			GetLockFileAction,
+			TailLogsAction,
+			GetConfigurationVersionAction,
+			DownloadConfigurationVersionAction,
+		},
+		RunsPlan: {
+			CreateRunAction,
+			CreateConfigurationVersionAction,
+		},
+		RunsApply: {
+			ApplyRunAction,
+			DiscardRunAction,
+			CancelRunAction,
+		},
+	}
+	runsPermissionLevels = []RunsPermission{RunsRead, RunsPlan, RunsApply}
+
+	customVariablesActions = map[VariablesPermission][]Action{
+		VariablesRead: {
+			ListWorkspaceVariablesAction,
+			GetWorkspaceVariableAction,
+		},
+		VariablesWrite: {
+			CreateWorkspaceVariableAction,
+			UpdateWorkspaceVariableAction,
+			DeleteWorkspaceVariableAction,
+		},
+	}
+	variablesPermissionLevels = []VariablesPermission{VariablesNone, VariablesRead, VariablesWrite}
+
+	customStateVersionsActions = map[StateVersionsPermission][]Action{
+		StateVersionsReadOutputs: {
+			GetStateVersionOutputAction,
+		},
+		StateVersionsRead: {
+			GetStateVersionAction,
+			ListStateVersionsAction,
+			DownloadStateAction,
+		},
+		StateVersionsWrite: {
+			CreateStateVersionAction,
+			UploadStateAction,
+			RollbackStateVersionAction,
+		},
+	}
+	stateVersionsPermissionLevels = []StateVersionsPermission{StateVersionsNone, StateVersionsReadOutputs, StateVersionsRead, StateVersionsWrite}
+
+	customLockingActions = []Action{
+		LockWorkspaceAction,
+		UnlockWorkspaceAction,
+	}
+)
+
+// NewCustomRole constructs a workspace role from custom permissions.
+func NewCustomRole(perms CustomPermissions) (Role, error) {
+	if err := perms.Valid(); err != nil {
+		return Role{}, err
+	}
+	role := Role{
+		name:        customRoleName,
+		permissions: make(map[Action]bool),
+		custom:      &perms,
+	}
+	role.permit(customBaseActions...)
+	// each permission level includes the actions of the levels below it.
+	for _, level := range runsPermissionLevels {
+		role.permit(customRunsActions[level]...)
+		if level == perms.Runs {
+			break
+		}
+	}
+	for _, level := range variablesPermissionLevels {
+		role.permit(customVariablesActions[level]...)
+		if level == perms.Variables {
+			break
+		}
+	}
+	for _, level := range stateVersionsPermissionLevels {
+		role.permit(customStateVersionsActions[level]...)
+		if level == perms.StateVersions {
+			break
+		}
+	}
+	if perms.WorkspaceLocking {
+		role.permit(customLockingActions...)
+	}
+	return role, nil
+}
+
+// Valid validates the custom permissions
+func (p CustomPermissions) Valid() error {
+	if !slices.Contains(runsPermissionLevels, p.Runs) {
+		return fmt.Errorf("invalid runs permission: %q", p.Runs)
+	}
+	if !slices.Contains(variablesPermissionLevels, p.Variables) {
+		return fmt.Errorf("invalid variables permission: %q", p.Variables)
+	}
+	if !slices.Contains(stateVersionsPermissionLevels, p.StateVersions) {
+		return fmt.Errorf("invalid state versions permission: %q", p.StateVersions)
+	}
+	return nil
+}
+
+// RunsPermissions returns the valid runs permissions.
+func RunsPermissions() []RunsPermission { return runsPermissionLevels }
+
+// VariablesPermissions returns the valid variables permissions.
+func VariablesPermissions() []VariablesPermission { return variablesPermissionLevels }
+
+// StateVersionsPermissions returns the valid state versions permissions.
+func StateVersionsPermissions() []StateVersionsPermission {
+	return stateVersionsPermissionLevels
+}
@@ -0,0 +1,82 @@
+package rbac
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCustomRole(t *testing.T) {
+	t.Run("minimal", func(t *testing.T) {
+		role, err := NewCustomRole(CustomPermissions{
+			Runs:          RunsRead,
+			Variables:     VariablesNone,
+			StateVersions: StateVersionsNone,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "custom", role.String())
+		assert.True(t, role.IsAllowed(GetWorkspaceAction))
+		assert.True(t, role.IsAllowed(ListRunsAction))
+		assert.False(t, role.IsAllowed(CreateRunAction))
+		assert.False(t, role.IsAllowed(ListWorkspaceVariablesAction))
+		assert.False(t, role.IsAllowed(GetStateVersionOutputAction))
+		assert.False(t, role.IsAllowed(LockWorkspaceAction))
+	})
+
+	t.Run("intermediate", func(t *testing.T) {
+		role, err := NewCustomRole(CustomPermissions{
+			Runs:             RunsPlan,
+			Variables:        VariablesRead,
+			StateVersions:    StateVersionsReadOutputs,
+			WorkspaceLocking: true,
+		})
+		require.NoError(t, err)
+
+		assert.True(t, role.IsAllowed(ListRunsAction))
+		assert.True(t, role.IsAllowed(CreateRunAction))
+		assert.False(t, role.IsAllowed(ApplyRunAction))
+		assert.True(t, role.IsAllowed(ListWorkspaceVariablesAction))
+		assert.False(t, role.IsAllowed(CreateWorkspaceVariableAction))
+		assert.True(t, role.IsAllowed(GetStateVersionOutputAction))
+		assert.False(t, role.IsAllowed(DownloadStateAction))
+		assert.True(t, role.IsAllowed(LockWorkspaceAction))
+		assert.True(t, role.IsAllowed(UnlockWorkspaceAction))
+	})
+
+	t.Run("maximal", func(t *testing.T) {
+		perms := CustomPermissions{
+			Runs:             RunsApply,
+			Variables:        VariablesWrite,
+			StateVersions:    StateVersionsWrite,
+			WorkspaceLocking: true,
+			RunTasks:         true,
+		}
+		role, err := NewCustomRole(perms)
+		require.NoError(t, err)
+
+		assert.True(t, role.IsAllowed(ApplyRunAction))
+		assert.True(t, role.IsAllowed(CreateWorkspaceVariableAction))
+		assert.True(t, role.IsAllowed(DownloadStateAction))
+		assert.True(t, role.IsAllowed(CreateStateVersionAction))
+		// custom permissions never confer administrative privileges
+		assert.False(t, role.IsAllowed(SetWorkspacePermissionAction))
+		assert.False(t, role.IsAllowed(DeleteWorkspaceAction))
+
+		assert.Equal(t, &perms, role.Custom())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := NewCustomRole(CustomPermissions{
+			Runs:          "delete",
+			Variables:     VariablesNone,
+			StateVersions: StateVersionsNone,
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("fixed role is not custom", func(t *testing.T) {
+		assert.Nil(t, WorkspaceReadRole.Custom())
+	})
+}
@@ -9,19 +9,27 @@ var (
 	OrganizationMinPermissions = Role{
 		name: "minimum",
 		permissions: map[Action]bool{
-			GetOrganizationAction:  true,
-			GetEntitlementsAction:  true,
-			ListModulesAction:      true,
-			GetModuleAction:        true,
-			GetTeamAction:          true,
-			ListTeamsAction:        true,
-			GetUserAction:          true,
-			ListUsersAction:        true,
-			ListTagsAction:         true,
-			ListVCSProvidersAction: true,
-			GetVCSProviderAction:   true,
-			ListVariableSetsAction: true,
-			GetVariableSetAction:   true,
+			GetOrganizationAction:       true,
+			GetEntitlementsAction:       true,
+			ListModulesAction:           true,
+			GetModuleAction:             true,
+			ListRegistryProvidersAction: true,
+			GetRegistryProviderAction:   true,
+			ListGPGKeysAction:           true,
+			GetTeamAction:               true,
+			ListTeamsAction:             true,
+			GetUserAction:               true,
+			ListUsersAction:             true,
+			ListTagsAction:              true,
+			ListVCSProvidersAction:      true,
+			GetVCSProviderAction:        true,
+			ListVariableSetsAction:      true,
+			GetVariableSetAction:        true,
+			GetProjectAction:            true,
+			ListProjectsAction:          true,
+			// organization members can search state resources, but are
+			// restricted to those workspaces they have access to.
+			SearchStateResourcesAction: true,
 		},
 	}
 
@@ -32,6 +40,7 @@ var (
 		permissions: map[Action]bool{
 			ListRunsAction:                       true,
 			GetPlanFileAction:                    true,
+			GetLockFileAction:                    true,
 			GetWorkspaceAction:                   true,
 			GetStateVersionAction:                true,
 			GetStateVersionOutputAction:          true,
@@ -97,11 +106,16 @@ var (
 	WorkspaceManagerRole = Role{
 		name: "workspace-manager",
 		permissions: map[Action]bool{
-			CreateWorkspaceAction: true,
-			ListWorkspacesAction:  true,
-			UpdateWorkspaceAction: true,
-			AddTagsAction:         true,
-			RemoveTagsAction:      true,
+			CreateWorkspaceAction:        true,
+			ListWorkspacesAction:         true,
+			UpdateWorkspaceAction:        true,
+			AddTagsAction:                true,
+			RemoveTagsAction:             true,
+			CreateProjectAction:          true,
+			UpdateProjectAction:          true,
+			DeleteProjectAction:          true,
+			SetProjectPermissionAction:   true,
+			UnsetProjectPermissionAction: true,
 		},
 		inherits: &WorkspaceAdminRole,
 	}
@@ -121,10 +135,16 @@ var (
 	RegistryManagerRole = Role{
 		name: "registry-manager",
 		permissions: map[Action]bool{
-			CreateModuleAction:        true,
-			CreateModuleVersionAction: true,
-			UpdateModuleAction:        true,
-			DeleteModuleAction:        true,
+			CreateModuleAction:                  true,
+			CreateModuleVersionAction:           true,
+			UpdateModuleAction:                  true,
+			DeleteModuleAction:                  true,
+			CreateRegistryProviderAction:        true,
+			DeleteRegistryProviderAction:        true,
+			CreateRegistryProviderVersionAction: true,
+			DeleteRegistryProviderVersionAction: true,
+			CreateGPGKeyAction:                  true,
+			DeleteGPGKeyAction:                  true,
 		},
 	}
 )
@@ -133,7 +153,8 @@ var (
 type Role struct {
 	name        string
 	permissions map[Action]bool
-	inherits    *Role // inherit perms from this role too
+	inherits    *Role              // inherit perms from this role too
+	custom      *CustomPermissions // non-nil if role is a custom role
 }
 
 func (r Role) IsAllowed(action Action) bool {
@@ -152,6 +173,22 @@ func (r Role) String() string {
 	return r.name
 }
 
+// Custom returns a copy of the custom permissions from which the role was
+// constructed, or nil if the role is not a custom role.
+func (r Role) Custom() *CustomPermissions {
+	if r.custom == nil {
+		return nil
+	}
+	custom := *r.custom
+	return &custom
+}
+
+func (r Role) permit(actions ...Action) {
+	for _, action := range actions {
+		r.permissions[action] = true
+	}
+}
+
 func WorkspaceRoleFromString(role string) (Role, error) {
 	switch role {
 	case "read":
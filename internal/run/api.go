@@ -2,6 +2,7 @@ package run
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -27,13 +28,19 @@ type api struct {
 func (a *api) addHandlers(r *mux.Router) {
 	r = r.PathPrefix(otfapi.DefaultBasePath).Subrouter()
 	r.HandleFunc("/runs", a.list).Methods("GET")
-	r.HandleFunc("/runs/{run_id}", a.get).Methods("GET")
+	r.HandleFunc("/workspaces/{workspace_id}/runs", a.create).Methods("POST")
+	r.HandleFunc("/runs/{id}", a.get).Methods("GET")
+	r.HandleFunc("/runs/{id}/actions/apply", a.apply).Methods("POST")
+	r.HandleFunc("/runs/{id}/actions/discard", a.discard).Methods("POST")
+	r.HandleFunc("/runs/{id}/actions/cancel", a.cancel).Methods("POST")
+	r.HandleFunc("/runs/{id}/actions/force-cancel", a.forceCancel).Methods("POST")
 	r.HandleFunc("/runs/{id}/actions/start/{phase}", a.startPhase).Methods("POST")
 	r.HandleFunc("/runs/{id}/actions/finish/{phase}", a.finishPhase).Methods("POST")
 	r.HandleFunc("/runs/{id}/planfile", a.getPlanFile).Methods("GET")
 	r.HandleFunc("/runs/{id}/planfile", a.uploadPlanFile).Methods("PUT")
 	r.HandleFunc("/runs/{id}/lockfile", a.getLockFile).Methods("GET")
 	r.HandleFunc("/runs/{id}/lockfile", a.uploadLockFile).Methods("PUT")
+	r.HandleFunc("/runs/{id}/test-results", a.uploadTestResults).Methods("PUT")
 	r.HandleFunc("/watch", a.watch).Methods("GET")
 }
 
@@ -65,6 +72,58 @@ func (a *api) get(w http.ResponseWriter, r *http.Request) {
 	a.Respond(w, r, run, http.StatusOK)
 }
 
+func (a *api) create(w http.ResponseWriter, r *http.Request) {
+	workspaceID, err := decode.Param("workspace_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var opts CreateOptions
+	if err := json.NewDecoder(r.Body).Decode(&opts); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	run, err := a.CreateRun(r.Context(), workspaceID, opts)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.Respond(w, r, run, http.StatusCreated)
+}
+
+func (a *api) apply(w http.ResponseWriter, r *http.Request) {
+	a.action(w, r, a.Apply)
+}
+
+func (a *api) discard(w http.ResponseWriter, r *http.Request) {
+	a.action(w, r, a.DiscardRun)
+}
+
+func (a *api) cancel(w http.ResponseWriter, r *http.Request) {
+	a.action(w, r, func(ctx context.Context, runID string) error {
+		_, err := a.Cancel(ctx, runID)
+		return err
+	})
+}
+
+func (a *api) forceCancel(w http.ResponseWriter, r *http.Request) {
+	a.action(w, r, a.ForceCancelRun)
+}
+
+// action invokes an action on a run, responding with no content on success.
+func (a *api) action(w http.ResponseWriter, r *http.Request, fn func(ctx context.Context, runID string) error) {
+	id, err := decode.Param("id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	if err := fn(r.Context(), id); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
 func (a *api) startPhase(w http.ResponseWriter, r *http.Request) {
 	var params struct {
 		RunID string             `schema:"id,required"`
@@ -189,6 +248,24 @@ func (a *api) uploadLockFile(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusAccepted)
 }
 
+func (a *api) uploadTestResults(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	buf := new(bytes.Buffer)
+	if _, err := io.Copy(buf, r.Body); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	if err := a.UploadTestResults(r.Context(), id, buf.Bytes()); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
 // watch responds with a stream of run events
 func (a *api) watch(w http.ResponseWriter, r *http.Request) {
 	flusher, ok := w.(http.Flusher)
@@ -202,6 +279,8 @@ func (a *api) watch(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
 		return
 	}
+	params.LastEventID = pubsub.LastEventID(r)
+
 	events, err := a.Watch(r.Context(), params)
 	if err != nil && errors.Is(err, internal.ErrAccessNotPermitted) {
 		http.Error(w, err.Error(), http.StatusForbidden)
@@ -225,7 +304,7 @@ func (a *api) watch(w http.ResponseWriter, r *http.Request) {
 			a.Error(err, "marshalling run event", "event", event.Type)
 			continue
 		}
-		pubsub.WriteSSEEvent(w, b, event.Type, true)
+		pubsub.WriteSSEEventWithID(w, event.ID, b, event.Type, true)
 		flusher.Flush()
 	}
 }
@@ -2,25 +2,48 @@ package run
 
 import (
 	"bytes"
+	"context"
 	"fmt"
+	"io"
 	"os"
+	"text/tabwriter"
+	"time"
 
+	"github.com/leg100/otf/internal"
 	otfapi "github.com/leg100/otf/internal/api"
-
 	"github.com/leg100/otf/internal/configversion"
-
-	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/logs"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/workspace"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
-type CLI struct {
-	Service
-	configversion.ConfigurationVersionService
-}
+type (
+	CLI struct {
+		Service
+		configversion.ConfigurationVersionService
+		WorkspaceService workspace.WorkspaceService
+		Logs             logsTailer
+
+		// pollInterval is the interval between checking the status of a run
+		// that is being watched.
+		pollInterval time.Duration
+	}
+
+	logsTailer interface {
+		TailLogs(ctx context.Context, opts internal.GetChunkOptions, w io.Writer) error
+	}
+
+	// logWriter writes logs, stripping the markers that denote the start and
+	// end of the logs for a phase.
+	logWriter struct {
+		io.Writer
+	}
+)
 
 func NewCommand(api *otfapi.Client) *cobra.Command {
-	cli := &CLI{}
+	cli := &CLI{pollInterval: time.Second}
 	cmd := &cobra.Command{
 		Use:   "runs",
 		Short: "Runs management",
@@ -30,15 +53,218 @@ func NewCommand(api *otfapi.Client) *cobra.Command {
 			}
 			cli.Service = &Client{Client: api}
 			cli.ConfigurationVersionService = &configversion.Client{Client: api}
+			cli.WorkspaceService = &workspace.Client{Client: api}
+			cli.Logs = &logs.Client{Client: api}
 			return nil
 		},
 	}
 
+	cmd.AddCommand(cli.runStartCommand())
+	cmd.AddCommand(cli.runListCommand())
+	cmd.AddCommand(cli.runWatchCommand())
+	cmd.AddCommand(cli.runApplyCommand())
+	cmd.AddCommand(cli.runDiscardCommand())
+	cmd.AddCommand(cli.runCancelCommand())
+	cmd.AddCommand(cli.runForceCancelCommand())
 	cmd.AddCommand(cli.runDownloadCommand())
 
 	return cmd
 }
 
+func (a *CLI) runStartCommand() *cobra.Command {
+	var (
+		organization string
+		name         string
+		path         string
+		message      string
+		isDestroy    bool
+		planOnly     bool
+		autoApply    bool
+		watch        bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "start",
+		Short: "Upload configuration and start a run",
+		Long: `Upload the terraform configuration in a local directory to a workspace and
+start a run with it. The directory is uploaded in its entirety, including any
+.terraform directory, so upload the configuration before running terraform
+init, or remove the .terraform directory first.`,
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ws, err := a.WorkspaceService.GetWorkspaceByName(cmd.Context(), organization, name)
+			if err != nil {
+				return errors.Wrap(err, "retrieving workspace")
+			}
+			tarball, err := internal.Pack(path)
+			if err != nil {
+				return errors.Wrap(err, "packing configuration")
+			}
+			cv, err := a.CreateConfigurationVersion(cmd.Context(), ws.ID, configversion.ConfigurationVersionCreateOptions{
+				AutoQueueRuns: internal.Bool(false),
+				Speculative:   internal.Bool(planOnly),
+			})
+			if err != nil {
+				return errors.Wrap(err, "creating configuration version")
+			}
+			if err := a.UploadConfig(cmd.Context(), cv.ID, tarball); err != nil {
+				return errors.Wrap(err, "uploading configuration")
+			}
+
+			opts := CreateOptions{
+				ConfigurationVersionID: &cv.ID,
+				IsDestroy:              &isDestroy,
+				PlanOnly:               &planOnly,
+			}
+			if message != "" {
+				opts.Message = &message
+			}
+			if cmd.Flags().Changed("auto-apply") {
+				opts.AutoApply = &autoApply
+			}
+			run, err := a.CreateRun(cmd.Context(), ws.ID, opts)
+			if err != nil {
+				return errors.Wrap(err, "creating run")
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Started run %s\n", run.ID)
+
+			if watch {
+				return a.watchRun(cmd.Context(), cmd.OutOrStdout(), run.ID)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&organization, "organization", "", "Organization workspace belongs to")
+	cmd.MarkFlagRequired("organization")
+	cmd.Flags().StringVar(&name, "workspace", "", "Name of workspace")
+	cmd.MarkFlagRequired("workspace")
+	cmd.Flags().StringVar(&path, "path", ".", "Path to directory containing terraform configuration")
+	cmd.Flags().StringVar(&message, "message", "", "Message describing the run")
+	cmd.Flags().BoolVar(&isDestroy, "destroy", false, "Destroy all resources managed by the workspace")
+	cmd.Flags().BoolVar(&planOnly, "plan-only", false, "Create a speculative plan that cannot be applied")
+	cmd.Flags().BoolVar(&autoApply, "auto-apply", false, "Automatically apply the run if the plan succeeds. Defaults to the workspace's auto-apply setting.")
+	cmd.Flags().BoolVar(&watch, "watch", false, "Stream the logs of the run until it finishes")
+
+	return cmd
+}
+
+func (a *CLI) runListCommand() *cobra.Command {
+	var (
+		organization string
+		name         string
+		statuses     []string
+		limit        int
+	)
+
+	cmd := &cobra.Command{
+		Use:           "list",
+		Short:         "List runs",
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts := ListOptions{
+				PageOptions:  resource.PageOptions{PageSize: limit},
+				Organization: &organization,
+			}
+			if name != "" {
+				opts.WorkspaceName = &name
+			}
+			for _, status := range statuses {
+				opts.Statuses = append(opts.Statuses, Status(status))
+			}
+			page, err := a.ListRuns(cmd.Context(), opts)
+			if err != nil {
+				return errors.Wrap(err, "listing runs")
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "ID\tSTATUS\tSOURCE\tCREATED\tMESSAGE")
+			for _, run := range page.Items {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+					run.ID,
+					run.Status,
+					run.Source,
+					run.CreatedAt.Format(time.RFC3339),
+					run.Message,
+				)
+			}
+			return w.Flush()
+		},
+	}
+
+	cmd.Flags().StringVar(&organization, "organization", "", "List runs in this organization")
+	cmd.MarkFlagRequired("organization")
+	cmd.Flags().StringVar(&name, "workspace", "", "Only list runs for the workspace with this name")
+	cmd.Flags().StringSliceVar(&statuses, "status", nil, "Only list runs with these statuses")
+	cmd.Flags().IntVar(&limit, "limit", 20, "Maximum number of runs to list, most recent first")
+
+	return cmd
+}
+
+func (a *CLI) runWatchCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "watch [run-id]",
+		Short: "Stream the logs of a run",
+		Long: `Stream the logs of a run until it finishes, or until it is awaiting
+confirmation. Exits with an error if the run errors or is canceled.`,
+		Args:          cobra.ExactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return a.watchRun(cmd.Context(), cmd.OutOrStdout(), args[0])
+		},
+	}
+	return cmd
+}
+
+func (a *CLI) runApplyCommand() *cobra.Command {
+	return a.runActionCommand("apply", "Apply a run that is awaiting confirmation", "Enqueued apply for run %s\n", func(ctx context.Context, runID string) error {
+		return a.Apply(ctx, runID)
+	})
+}
+
+func (a *CLI) runDiscardCommand() *cobra.Command {
+	return a.runActionCommand("discard", "Discard a run that is awaiting confirmation", "Discarded run %s\n", func(ctx context.Context, runID string) error {
+		return a.DiscardRun(ctx, runID)
+	})
+}
+
+func (a *CLI) runCancelCommand() *cobra.Command {
+	return a.runActionCommand("cancel", "Cancel a run", "Canceled run %s\n", func(ctx context.Context, runID string) error {
+		_, err := a.Cancel(ctx, runID)
+		return err
+	})
+}
+
+func (a *CLI) runForceCancelCommand() *cobra.Command {
+	return a.runActionCommand("force-cancel", "Forcefully cancel a run that has failed to cancel", "Force canceled run %s\n", func(ctx context.Context, runID string) error {
+		return a.ForceCancelRun(ctx, runID)
+	})
+}
+
+// runActionCommand constructs a command that invokes an action on a run.
+func (a *CLI) runActionCommand(name, short, success string, fn func(ctx context.Context, runID string) error) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           fmt.Sprintf("%s [run-id]", name),
+		Short:         short,
+		Args:          cobra.ExactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := fn(cmd.Context(), args[0]); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), success, args[0])
+			return nil
+		},
+	}
+	return cmd
+}
+
 func (a *CLI) runDownloadCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:           "download [run-id]",
@@ -74,3 +300,96 @@ func (a *CLI) runDownloadCommand() *cobra.Command {
 
 	return cmd
 }
+
+// watchRun writes the logs of each phase of a run to out as they are
+// produced, until the run is either done or awaiting confirmation.
+func (a *CLI) watchRun(ctx context.Context, out io.Writer, runID string) error {
+	run, err := a.GetRun(ctx, runID)
+	if err != nil {
+		return errors.Wrap(err, "retrieving run")
+	}
+	phases := []internal.PhaseType{internal.PlanPhase, internal.ApplyPhase}
+	if run.TestOnly {
+		phases = []internal.PhaseType{internal.TestPhase}
+	}
+	for _, phase := range phases {
+		run, err := a.waitForRun(ctx, runID, func(run *Run) bool {
+			return phaseStarted(run, phase) || settled(run)
+		})
+		if err != nil {
+			return err
+		}
+		if !phaseStarted(run, phase) {
+			break
+		}
+		err = a.Logs.TailLogs(ctx, internal.GetChunkOptions{RunID: runID, Phase: phase}, logWriter{out})
+		if err != nil {
+			return errors.Wrapf(err, "tailing %s logs", phase)
+		}
+	}
+
+	run, err = a.waitForRun(ctx, runID, settled)
+	if err != nil {
+		return err
+	}
+	switch run.Status {
+	case RunErrored, RunCanceled, RunForceCanceled:
+		return fmt.Errorf("run %s: %s", run.ID, run.Status)
+	case RunPlanned:
+		fmt.Fprintf(out, "\nRun %s: planned and awaiting confirmation\n", run.ID)
+	default:
+		fmt.Fprintf(out, "\nRun %s: %s\n", run.ID, run.Status)
+	}
+	return nil
+}
+
+// waitForRun polls a run until the condition is true.
+func (a *CLI) waitForRun(ctx context.Context, runID string, cond func(*Run) bool) (*Run, error) {
+	for {
+		run, err := a.GetRun(ctx, runID)
+		if err != nil {
+			return nil, errors.Wrap(err, "retrieving run")
+		}
+		if cond(run) {
+			return run, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(a.pollInterval):
+		}
+	}
+}
+
+// phaseStarted determines whether a phase of a run has started, in which case
+// it has logs to tail.
+func phaseStarted(run *Run, phase internal.PhaseType) bool {
+	p := run.Plan
+	switch phase {
+	case internal.ApplyPhase:
+		p = run.Apply
+	case internal.TestPhase:
+		p = run.Test
+	}
+	_, err := p.StatusTimestamp(PhaseRunning)
+	return err == nil
+}
+
+// settled determines whether a run is no longer making progress of its own
+// accord, i.e. it is done or it is awaiting confirmation.
+func settled(run *Run) bool {
+	return run.Done() || run.Status == RunPlanned || run.Status == RunForceCanceled
+}
+
+func (w logWriter) Write(p []byte) (int, error) {
+	stripped := make([]byte, 0, len(p))
+	for _, b := range p {
+		if b != internal.STX && b != internal.ETX {
+			stripped = append(stripped, b)
+		}
+	}
+	if _, err := w.Writer.Write(stripped); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
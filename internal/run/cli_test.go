@@ -3,10 +3,18 @@ package run
 import (
 	"bytes"
 	"context"
+	"io"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/leg100/otf/internal"
 	"github.com/leg100/otf/internal/configversion"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/workspace"
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -15,7 +23,7 @@ func TestRunDownload(t *testing.T) {
 	run := &Run{}
 	tarball, err := os.ReadFile("./testdata/tarball.tar.gz")
 	require.NoError(t, err)
-	app := newFakeCLI(run, tarball)
+	app := newFakeCLI(&fakeCLIService{runs: []*Run{run}, tarball: tarball})
 
 	cmd := app.runDownloadCommand()
 	cmd.SetArgs([]string{"run-123"})
@@ -26,25 +34,244 @@ func TestRunDownload(t *testing.T) {
 	assert.Regexp(t, `Extracted tarball to: /tmp/run-123-.*`, got.String())
 }
 
+func TestRunStart(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.tf"), []byte(`resource "null_resource" "foo" {}`), 0o600))
+
+	svc := &fakeCLIService{}
+	app := newFakeCLI(svc)
+
+	cmd := app.runStartCommand()
+	cmd.SetArgs([]string{"--organization", "acme", "--workspace", "dev", "--path", dir, "--message", "ci", "--plan-only"})
+	got := bytes.Buffer{}
+	cmd.SetOut(&got)
+	require.NoError(t, cmd.Execute())
+
+	assert.Equal(t, "Started run run-123\n", got.String())
+	assert.Equal(t, "ws-dev", svc.workspaceID)
+	assert.NotEmpty(t, svc.uploaded)
+	assert.True(t, *svc.cvOptions.Speculative)
+	assert.False(t, *svc.cvOptions.AutoQueueRuns)
+	assert.Equal(t, "cv-123", *svc.runOptions.ConfigurationVersionID)
+	assert.Equal(t, "ci", *svc.runOptions.Message)
+	assert.True(t, *svc.runOptions.PlanOnly)
+	// defer to the workspace setting when the flag is not set
+	assert.Nil(t, svc.runOptions.AutoApply)
+}
+
+func TestRunList(t *testing.T) {
+	created := time.Date(2023, 11, 14, 9, 0, 0, 0, time.UTC)
+	svc := &fakeCLIService{runs: []*Run{
+		{ID: "run-2", Status: RunPlanned, Source: SourceAPI, CreatedAt: created, Message: "second"},
+		{ID: "run-1", Status: RunApplied, Source: SourceUI, CreatedAt: created, Message: "first"},
+	}}
+	app := newFakeCLI(svc)
+
+	cmd := app.runListCommand()
+	cmd.SetArgs([]string{"--organization", "acme", "--workspace", "dev", "--status", "planned,applied"})
+	got := bytes.Buffer{}
+	cmd.SetOut(&got)
+	require.NoError(t, cmd.Execute())
+
+	lines := strings.Split(strings.TrimSpace(got.String()), "\n")
+	require.Equal(t, 3, len(lines))
+	assert.Equal(t, []string{"ID", "STATUS", "SOURCE", "CREATED", "MESSAGE"}, strings.Fields(lines[0]))
+	assert.Equal(t, []string{"run-2", "planned", "tfe-api", "2023-11-14T09:00:00Z", "second"}, strings.Fields(lines[1]))
+	assert.Equal(t, []string{"run-1", "applied", "tfe-ui", "2023-11-14T09:00:00Z", "first"}, strings.Fields(lines[2]))
+
+	assert.Equal(t, "acme", *svc.listOptions.Organization)
+	assert.Equal(t, "dev", *svc.listOptions.WorkspaceName)
+	assert.Equal(t, []Status{RunPlanned, RunApplied}, svc.listOptions.Statuses)
+	assert.Equal(t, 20, svc.listOptions.PageSize)
+}
+
+func TestRunWatch(t *testing.T) {
+	// newRun constructs a run with the given status, and which has started
+	// the given phases.
+	newRun := func(status Status, started ...internal.PhaseType) *Run {
+		run := &Run{ID: "run-123", Status: status}
+		for _, phase := range started {
+			p := &run.Plan
+			if phase == internal.ApplyPhase {
+				p = &run.Apply
+			}
+			p.UpdateStatus(PhaseRunning)
+		}
+		return run
+	}
+
+	tests := []struct {
+		name    string
+		runs    []*Run
+		want    string
+		wantErr string
+	}{
+		{
+			name: "plan and apply",
+			runs: []*Run{
+				newRun(RunPlanQueued),
+				newRun(RunPlanning, internal.PlanPhase),
+				newRun(RunApplyQueued, internal.PlanPhase),
+				newRun(RunApplying, internal.PlanPhase, internal.ApplyPhase),
+				newRun(RunApplied, internal.PlanPhase, internal.ApplyPhase),
+			},
+			want: "plan logs\napply logs\n\nRun run-123: applied\n",
+		},
+		{
+			name: "awaiting confirmation",
+			runs: []*Run{
+				newRun(RunPlanning, internal.PlanPhase),
+				newRun(RunPlanned, internal.PlanPhase),
+			},
+			want: "plan logs\n\nRun run-123: planned and awaiting confirmation\n",
+		},
+		{
+			name: "errored",
+			runs: []*Run{
+				newRun(RunPlanning, internal.PlanPhase),
+				newRun(RunErrored, internal.PlanPhase),
+			},
+			want:    "plan logs\n",
+			wantErr: "run run-123: errored",
+		},
+		{
+			name: "canceled before planning",
+			runs: []*Run{
+				newRun(RunCanceled),
+			},
+			wantErr: "run run-123: canceled",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newFakeCLI(&fakeCLIService{runs: tt.runs})
+
+			cmd := app.runWatchCommand()
+			cmd.SetArgs([]string{"run-123"})
+			got := bytes.Buffer{}
+			cmd.SetOut(&got)
+			err := cmd.Execute()
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
+
+func TestRunActions(t *testing.T) {
+	svc := &fakeCLIService{}
+	app := newFakeCLI(svc)
+
+	tests := []struct {
+		cmd  *cobra.Command
+		want string
+	}{
+		{app.runApplyCommand(), "Enqueued apply for run run-123\n"},
+		{app.runDiscardCommand(), "Discarded run run-123\n"},
+		{app.runCancelCommand(), "Canceled run run-123\n"},
+		{app.runForceCancelCommand(), "Force canceled run run-123\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.cmd.Name(), func(t *testing.T) {
+			tt.cmd.SetArgs([]string{"run-123"})
+			got := bytes.Buffer{}
+			tt.cmd.SetOut(&got)
+			require.NoError(t, tt.cmd.Execute())
+
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+	assert.Equal(t, []string{"apply", "discard", "cancel", "force-cancel"}, svc.actions)
+}
+
 type fakeCLIService struct {
-	run     *Run
+	runs    []*Run
 	tarball []byte
 
+	// arguments received by fake
+	workspaceID string
+	uploaded    []byte
+	cvOptions   configversion.ConfigurationVersionCreateOptions
+	runOptions  CreateOptions
+	listOptions ListOptions
+	actions     []string
+
 	Service
 	configversion.ConfigurationVersionService
+	workspace.WorkspaceService
 }
 
-func newFakeCLI(run *Run, tarball []byte) *CLI {
+func newFakeCLI(svc *fakeCLIService) *CLI {
 	return &CLI{
-		Service:                     &fakeCLIService{run: run, tarball: tarball},
-		ConfigurationVersionService: &fakeCLIService{run: run, tarball: tarball},
+		Service:                     svc,
+		ConfigurationVersionService: svc,
+		WorkspaceService:            svc,
+		Logs:                        svc,
 	}
 }
 
+func (f *fakeCLIService) GetWorkspaceByName(ctx context.Context, organization, name string) (*workspace.Workspace, error) {
+	return &workspace.Workspace{ID: "ws-" + name, Name: name, Organization: organization}, nil
+}
+
+func (f *fakeCLIService) CreateConfigurationVersion(ctx context.Context, workspaceID string, opts configversion.ConfigurationVersionCreateOptions) (*configversion.ConfigurationVersion, error) {
+	f.workspaceID, f.cvOptions = workspaceID, opts
+	return &configversion.ConfigurationVersion{ID: "cv-123", WorkspaceID: workspaceID}, nil
+}
+
+func (f *fakeCLIService) UploadConfig(ctx context.Context, cvID string, config []byte) error {
+	f.uploaded = config
+	return nil
+}
+
+func (f *fakeCLIService) CreateRun(ctx context.Context, workspaceID string, opts CreateOptions) (*Run, error) {
+	f.runOptions = opts
+	return &Run{ID: "run-123", WorkspaceID: workspaceID}, nil
+}
+
+func (f *fakeCLIService) ListRuns(ctx context.Context, opts ListOptions) (*resource.Page[*Run], error) {
+	f.listOptions = opts
+	return resource.NewPage(f.runs, opts.PageOptions, nil), nil
+}
+
+// GetRun returns the runs in turn, each time it is called, repeating the last
+// run once the others have been returned.
 func (f *fakeCLIService) GetRun(context.Context, string) (*Run, error) {
-	return f.run, nil
+	run := f.runs[0]
+	if len(f.runs) > 1 {
+		f.runs = f.runs[1:]
+	}
+	return run, nil
 }
 
 func (f *fakeCLIService) DownloadConfig(context.Context, string) ([]byte, error) {
 	return f.tarball, nil
 }
+
+func (f *fakeCLIService) TailLogs(ctx context.Context, opts internal.GetChunkOptions, w io.Writer) error {
+	_, err := w.Write([]byte("\x02" + string(opts.Phase) + " logs\n\x03"))
+	return err
+}
+
+func (f *fakeCLIService) Apply(ctx context.Context, runID string) error {
+	f.actions = append(f.actions, "apply")
+	return nil
+}
+
+func (f *fakeCLIService) DiscardRun(ctx context.Context, runID string) error {
+	f.actions = append(f.actions, "discard")
+	return nil
+}
+
+func (f *fakeCLIService) Cancel(ctx context.Context, runID string) (*Run, error) {
+	f.actions = append(f.actions, "cancel")
+	return &Run{ID: runID}, nil
+}
+
+func (f *fakeCLIService) ForceCancelRun(ctx context.Context, runID string) error {
+	f.actions = append(f.actions, "force-cancel")
+	return nil
+}
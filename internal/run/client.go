@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/url"
 	"path"
+	"strconv"
 
 	"github.com/DataDog/jsonapi"
 	"github.com/leg100/otf/internal"
@@ -87,6 +88,18 @@ func (c *Client) UploadLockFile(ctx context.Context, runID string, lockfile []by
 	return nil
 }
 
+func (c *Client) UploadTestResults(ctx context.Context, runID string, results []byte) error {
+	u := fmt.Sprintf("runs/%s/test-results", url.QueryEscape(runID))
+	req, err := c.NewRequest("PUT", u, results)
+	if err != nil {
+		return err
+	}
+	if err := c.Do(ctx, req, nil); err != nil {
+		return err
+	}
+	return nil
+}
+
 func (c *Client) ListRuns(ctx context.Context, opts ListOptions) (*resource.Page[*Run], error) {
 	req, err := c.NewRequest("GET", "runs", &opts)
 	if err != nil {
@@ -99,6 +112,19 @@ func (c *Client) ListRuns(ctx context.Context, opts ListOptions) (*resource.Page
 	return &list, nil
 }
 
+func (c *Client) CreateRun(ctx context.Context, workspaceID string, opts CreateOptions) (*Run, error) {
+	u := fmt.Sprintf("workspaces/%s/runs", url.QueryEscape(workspaceID))
+	req, err := c.NewRequest("POST", u, &opts)
+	if err != nil {
+		return nil, err
+	}
+	var run Run
+	if err := c.Do(ctx, req, &run); err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
 func (c *Client) GetRun(ctx context.Context, runID string) (*Run, error) {
 	u := fmt.Sprintf("runs/%s", url.QueryEscape(runID))
 	req, err := c.NewRequest("GET", u, nil)
@@ -144,6 +170,35 @@ func (c *Client) FinishPhase(ctx context.Context, id string, phase internal.Phas
 	return &run, nil
 }
 
+func (c *Client) Apply(ctx context.Context, runID string) error {
+	return c.action(ctx, runID, "apply")
+}
+
+func (c *Client) DiscardRun(ctx context.Context, runID string) error {
+	return c.action(ctx, runID, "discard")
+}
+
+func (c *Client) Cancel(ctx context.Context, runID string) (*Run, error) {
+	if err := c.action(ctx, runID, "cancel"); err != nil {
+		return nil, err
+	}
+	return c.GetRun(ctx, runID)
+}
+
+func (c *Client) ForceCancelRun(ctx context.Context, runID string) error {
+	return c.action(ctx, runID, "force-cancel")
+}
+
+// action invokes an action on a run, e.g. apply.
+func (c *Client) action(ctx context.Context, runID, action string) error {
+	u := fmt.Sprintf("runs/%s/actions/%s", url.QueryEscape(runID), action)
+	req, err := c.NewRequest("POST", u, nil)
+	if err != nil {
+		return err
+	}
+	return c.Do(ctx, req, nil)
+}
+
 // Watch returns a channel subscribed to run events.
 func (c *Client) Watch(ctx context.Context, opts WatchOptions) (<-chan pubsub.Event, error) {
 	// TODO: why buffered chan of size 1?
@@ -160,7 +215,9 @@ func (c *Client) Watch(ctx context.Context, opts WatchOptions) (<-chan pubsub.Ev
 				notifications <- pubsub.Event{Type: pubsub.EventError, Payload: err}
 				return
 			}
-			notifications <- pubsub.Event{Type: pubsub.EventType(raw.Event), Payload: &run}
+			// ignore invalid IDs, which are merely not used for resumption
+			id, _ := strconv.ParseInt(string(raw.ID), 10, 64)
+			notifications <- pubsub.Event{ID: id, Type: pubsub.EventType(raw.Event), Payload: &run}
 		})
 		if err != nil {
 			notifications <- pubsub.Event{Type: pubsub.EventError, Payload: err}
@@ -197,6 +254,10 @@ func newSSEClient(config otfapi.Config, notifications chan pubsub.Event, opts Wa
 			Payload: "successfully connected",
 		}
 	})
+	if opts.LastEventID != 0 {
+		// resume stream from the last event received
+		client.EventID = strconv.FormatInt(opts.LastEventID, 10)
+	}
 	client.Headers = map[string]string{
 		"Authorization": "Bearer " + config.Token,
 	}
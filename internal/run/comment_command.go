@@ -0,0 +1,308 @@
+package run
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/auth"
+	"github.com/leg100/otf/internal/configversion"
+	"github.com/leg100/otf/internal/http/html/paths"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/vcs"
+	"github.com/leg100/otf/internal/workspace"
+)
+
+// commentCommand is a command issued via a comment on a pull request, e.g.
+// `otf apply`.
+type commentCommand struct {
+	apply      bool     // apply rather than plan
+	workspaces []string // restrict command to workspaces with these names
+}
+
+// parseCommentCommand parses a command from the first line of a comment on a
+// pull request, returning false if the comment is not a command. The syntax of
+// a command is:
+//
+//	otf <plan|apply> [workspace...]
+func parseCommentCommand(comment string) (commentCommand, bool) {
+	line, _, _ := strings.Cut(strings.TrimSpace(comment), "\n")
+	fields := strings.Fields(line)
+	if len(fields) < 2 || fields[0] != "otf" {
+		return commentCommand{}, false
+	}
+	var cmd commentCommand
+	switch fields[1] {
+	case "plan":
+	case "apply":
+		cmd.apply = true
+	default:
+		return commentCommand{}, false
+	}
+	cmd.workspaces = fields[2:]
+	return cmd, true
+}
+
+func (c commentCommand) String() string {
+	if c.apply {
+		return "apply"
+	}
+	return "plan"
+}
+
+var (
+	// errNoPlannedRun is returned when an apply command finds no run awaiting
+	// confirmation that was planned from the head of a pull request.
+	errNoPlannedRun = errors.New("no planned run found for the head of the pull request; comment `otf plan` first")
+	// errBranchNotPermitted is returned when an apply command is issued on a
+	// pull request from a branch other than the one to which the workspace is
+	// restricted.
+	errBranchNotPermitted = errors.New("workspace only applies changes from branch")
+)
+
+// handleComment handles a comment on a pull request, carrying out the command
+// on each matching workspace if the comment is a command and the commenter is
+// permitted to carry out the command. The outcome is reported back in a reply
+// to the comment.
+func (s *Spawner) handleComment(ctx context.Context, logger logr.Logger, event vcs.Event) error {
+	cmd, ok := parseCommentCommand(event.Comment)
+	if !ok {
+		return nil
+	}
+	logger = logger.WithValues("command", cmd, "pull", event.PullRequestNumber, "sender", event.SenderUsername)
+
+	provider, err := s.GetVCSProvider(ctx, event.VCSProviderID)
+	if err != nil {
+		return err
+	}
+	client, err := s.GetVCSClient(ctx, event.VCSProviderID)
+	if err != nil {
+		return err
+	}
+	reply := func(body string) error {
+		_, err := client.CreatePullRequestComment(ctx, vcs.CreatePullRequestCommentOptions{
+			Repo:              event.RepoPath,
+			PullRequestNumber: event.PullRequestNumber,
+			Body:              body,
+		})
+		return err
+	}
+
+	// identify the commenter using the link between their account on the VCS
+	// provider and their OTF user, which is recorded when they log in to OTF
+	// via the VCS provider. Without a link the commenter is not trusted.
+	user, err := s.GetUser(ctx, auth.UserSpec{
+		VCSIdentity: &auth.VCSIdentity{
+			Hostname: provider.Hostname,
+			Username: event.SenderUsername,
+		},
+	})
+	if errors.Is(err, internal.ErrResourceNotFound) {
+		logger.V(2).Info("ignoring command from user without a linked identity")
+		loginURL := (&url.URL{
+			Scheme: "https",
+			Host:   s.Hostname(),
+			Path:   paths.Login(),
+		}).String()
+		return reply(fmt.Sprintf("Unable to %s: @%s has not logged in to OTF with their %s account. Log in to OTF at %s using %s, then try again.", cmd, event.SenderUsername, provider.Hostname, loginURL, provider.Hostname))
+	} else if err != nil {
+		return err
+	}
+	// never confer site admin privileges on a commenter: commands are only
+	// permitted according to their team memberships.
+	commenter := *user
+	commenter.SiteAdmin = false
+
+	workspaces, err := s.commentWorkspaces(ctx, client, event, cmd)
+	if err != nil {
+		return err
+	}
+	if len(workspaces) == 0 {
+		return reply(fmt.Sprintf("Unable to %s: no matching workspaces found.", cmd))
+	}
+
+	pull, err := client.GetPullRequest(ctx, event.RepoPath, event.PullRequestNumber)
+	if err != nil {
+		return fmt.Errorf("retrieving pull request: %w", err)
+	}
+	// an apply command applies an existing run, so only a plan command
+	// requires the configuration.
+	var tarball []byte
+	if !cmd.apply {
+		tarball, _, err = client.GetRepoTarball(ctx, vcs.GetRepoTarballOptions{
+			Repo: event.RepoPath,
+			Ref:  &pull.CommitSHA,
+		})
+		if err != nil {
+			return fmt.Errorf("retrieving repo tarball: %w", err)
+		}
+	}
+
+	action := rbac.CreateRunAction
+	if cmd.apply {
+		action = rbac.ApplyRunAction
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "@%s `otf %s`:\n\n", event.SenderUsername, cmd)
+	for _, ws := range workspaces {
+		policy, err := s.GetPolicy(ctx, ws.ID)
+		if err != nil {
+			return err
+		}
+		if !commenter.CanAccessWorkspace(action, policy) {
+			fmt.Fprintf(&b, "- `%s`: not permitted to %s\n", ws.Name, cmd)
+			continue
+		}
+		var (
+			run  *Run
+			verb string
+		)
+		if cmd.apply {
+			run, err = s.applyCommentRun(ctx, ws, pull)
+			verb = "applying"
+		} else {
+			run, err = s.spawnCommentRun(ctx, ws, event, pull, tarball)
+			verb = "started"
+		}
+		if err != nil {
+			if !errors.Is(err, errNoPlannedRun) && !errors.Is(err, errBranchNotPermitted) {
+				logger.Error(err, "carrying out command", "workspace", ws.ID)
+			}
+			fmt.Fprintf(&b, "- `%s`: unable to %s: %s\n", ws.Name, cmd, err.Error())
+			continue
+		}
+		runURL := (&url.URL{
+			Scheme: "https",
+			Host:   s.Hostname(),
+			Path:   paths.Run(run.ID),
+		}).String()
+		fmt.Fprintf(&b, "- `%s`: %s [%s](%s)\n", ws.Name, verb, run.ID, runURL)
+	}
+	return reply(b.String())
+}
+
+// commentWorkspaces returns the workspaces to which a comment command applies:
+// either those named in the command, or, if none are named, those connected
+// to the repo whose trigger patterns match the files in the pull request.
+func (s *Spawner) commentWorkspaces(ctx context.Context, client vcs.Client, event vcs.Event, cmd commentCommand) ([]*workspace.Workspace, error) {
+	workspaces, err := s.ListConnectedWorkspaces(ctx, event.VCSProviderID, event.RepoPath)
+	if err != nil {
+		return nil, err
+	}
+	if len(cmd.workspaces) > 0 {
+		n := 0
+		for _, ws := range workspaces {
+			if slices.Contains(cmd.workspaces, ws.Name) {
+				workspaces[n] = ws
+				n++
+			}
+		}
+		return workspaces[:n], nil
+	}
+	var paths []string
+	n := 0
+	for _, ws := range workspaces {
+		if ws.TriggerPatterns != nil {
+			// only retrieve files if at least one workspace has file
+			// triggers enabled.
+			if paths == nil {
+				paths, err = client.ListPullRequestFiles(ctx, event.RepoPath, event.PullRequestNumber)
+				if err != nil {
+					return nil, fmt.Errorf("retrieving list of files in pull request from cloud provider: %w", err)
+				}
+			}
+			if !globMatch(paths, ws.TriggerPatterns) {
+				continue
+			}
+		}
+		workspaces[n] = ws
+		n++
+	}
+	return workspaces[:n], nil
+}
+
+// applyable determines whether a run for the pull request can be applied on
+// the workspace, i.e. whether the workspace is restricted to a branch other
+// than that of the pull request.
+func applyable(ws *workspace.Workspace, pull vcs.PullRequest) bool {
+	return ws.Connection.Branch == "" || ws.Connection.Branch == pull.Branch
+}
+
+// spawnCommentRun spawns a run in response to a plan command, using the
+// configuration from the head of the pull request. The run awaits confirmation
+// once planned, which is provided by an apply command, unless the workspace
+// cannot apply changes from the pull request's branch, in which case a
+// plan-only run is spawned.
+func (s *Spawner) spawnCommentRun(ctx context.Context, ws *workspace.Workspace, event vcs.Event, pull vcs.PullRequest, tarball []byte) (*Run, error) {
+	cvOpts := configversion.ConfigurationVersionCreateOptions{
+		Speculative: internal.Bool(!applyable(ws, pull)),
+		IngressAttributes: &configversion.IngressAttributes{
+			Branch:            pull.Branch,
+			CommitSHA:         pull.CommitSHA,
+			Repo:              ws.Connection.Repo,
+			IsPullRequest:     true,
+			OnDefaultBranch:   pull.Branch == event.DefaultBranch,
+			PullRequestNumber: pull.Number,
+			PullRequestTitle:  pull.Title,
+			PullRequestURL:    pull.URL,
+			SenderUsername:    event.SenderUsername,
+			SenderAvatarURL:   event.SenderAvatarURL,
+			SenderHTMLURL:     event.SenderHTMLURL,
+		},
+	}
+	runOpts := CreateOptions{
+		Message: internal.String(fmt.Sprintf("Triggered by comment from @%s", event.SenderUsername)),
+		// never apply without an apply command, regardless of the workspace's
+		// auto-apply setting
+		AutoApply: internal.Bool(false),
+	}
+	switch event.VCSKind {
+	case vcs.GithubKind:
+		cvOpts.Source = configversion.SourceGithub
+		runOpts.Source = SourceGithub
+	case vcs.GitlabKind:
+		cvOpts.Source = configversion.SourceGitlab
+		runOpts.Source = SourceGitlab
+	}
+	cv, err := s.CreateConfigurationVersion(ctx, ws.ID, cvOpts)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.UploadConfig(ctx, cv.ID, tarball); err != nil {
+		return nil, err
+	}
+	runOpts.ConfigurationVersionID = internal.String(cv.ID)
+	return s.CreateRun(ctx, ws.ID, runOpts)
+}
+
+// applyCommentRun applies the workspace's run awaiting confirmation that was
+// planned from the head of the pull request, in response to an apply command.
+// If the pull request has since moved on to another commit then the run is
+// not applied.
+func (s *Spawner) applyCommentRun(ctx context.Context, ws *workspace.Workspace, pull vcs.PullRequest) (*Run, error) {
+	if !applyable(ws, pull) {
+		return nil, fmt.Errorf("%w `%s`", errBranchNotPermitted, ws.Connection.Branch)
+	}
+	page, err := s.ListRuns(ctx, ListOptions{
+		WorkspaceID: &ws.ID,
+		CommitSHA:   &pull.CommitSHA,
+		Statuses:    []Status{RunPlanned},
+		PlanOnly:    internal.Bool(false),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(page.Items) == 0 {
+		return nil, errNoPlannedRun
+	}
+	run := page.Items[0]
+	if err := s.Apply(ctx, run.ID); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
@@ -0,0 +1,33 @@
+package run
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCommentCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		comment string
+		want    commentCommand
+		ok      bool
+	}{
+		{"plan", "otf plan", commentCommand{workspaces: []string{}}, true},
+		{"apply", "otf apply", commentCommand{apply: true, workspaces: []string{}}, true},
+		{"apply workspaces", "otf apply dev prod", commentCommand{apply: true, workspaces: []string{"dev", "prod"}}, true},
+		{"surrounding whitespace", "\n  otf plan  \n", commentCommand{workspaces: []string{}}, true},
+		{"ignore subsequent lines", "otf apply\nthanks!", commentCommand{apply: true, workspaces: []string{}}, true},
+		{"unknown subcommand", "otf destroy", commentCommand{}, false},
+		{"missing subcommand", "otf", commentCommand{}, false},
+		{"not a command", "lgtm, otf apply", commentCommand{}, false},
+		{"command not on first line", "lgtm\notf apply", commentCommand{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseCommentCommand(tt.comment)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
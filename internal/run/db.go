@@ -2,6 +2,7 @@ package run
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"sort"
 	"strconv"
@@ -35,6 +36,7 @@ type (
 		Status                 pgtype.Text                   `json:"status"`
 		PlanStatus             pgtype.Text                   `json:"plan_status"`
 		ApplyStatus            pgtype.Text                   `json:"apply_status"`
+		TestStatus             pgtype.Text                   `json:"test_status"`
 		ReplaceAddrs           []string                      `json:"replace_addrs"`
 		TargetAddrs            []string                      `json:"target_addrs"`
 		AutoApply              bool                          `json:"auto_apply"`
@@ -47,6 +49,8 @@ type (
 		CreatedBy              pgtype.Text                   `json:"created_by"`
 		TerraformVersion       pgtype.Text                   `json:"terraform_version"`
 		AllowEmptyApply        bool                          `json:"allow_empty_apply"`
+		TestOnly               bool                          `json:"test_only"`
+		TestReport             []byte                        `json:"test_report"`
 		ExecutionMode          pgtype.Text                   `json:"execution_mode"`
 		Latest                 bool                          `json:"latest"`
 		OrganizationName       pgtype.Text                   `json:"organization_name"`
@@ -55,11 +59,12 @@ type (
 		RunStatusTimestamps    []pggen.RunStatusTimestamps   `json:"run_status_timestamps"`
 		PlanStatusTimestamps   []pggen.PhaseStatusTimestamps `json:"plan_status_timestamps"`
 		ApplyStatusTimestamps  []pggen.PhaseStatusTimestamps `json:"apply_status_timestamps"`
+		TestStatusTimestamps   []pggen.PhaseStatusTimestamps `json:"test_status_timestamps"`
 		RunVariables           []pggen.RunVariables          `json:"run_variables"`
 	}
 )
 
-func (result pgresult) toRun() *Run {
+func (result pgresult) toRun() (*Run, error) {
 	run := Run{
 		ID:                     result.RunID.String,
 		CreatedAt:              result.CreatedAt.Time.UTC(),
@@ -73,6 +78,7 @@ func (result pgresult) toRun() *Run {
 		TargetAddrs:            result.TargetAddrs,
 		AutoApply:              result.AutoApply,
 		PlanOnly:               result.PlanOnly,
+		TestOnly:               result.TestOnly,
 		AllowEmptyApply:        result.AllowEmptyApply,
 		TerraformVersion:       result.TerraformVersion.String,
 		ExecutionMode:          workspace.ExecutionMode(result.ExecutionMode.String),
@@ -94,6 +100,11 @@ func (result pgresult) toRun() *Run {
 			Status:         PhaseStatus(result.ApplyStatus.String),
 			ResourceReport: reportFromDB(result.ApplyResourceReport),
 		},
+		Test: Phase{
+			RunID:     result.RunID.String,
+			PhaseType: internal.TestPhase,
+			Status:    PhaseStatus(result.TestStatus.String),
+		},
 	}
 	// convert run timestamps from db result and sort them according to
 	// timestamp (earliest first)
@@ -131,6 +142,23 @@ func (result pgresult) toRun() *Run {
 	sort.Slice(run.Apply.StatusTimestamps, func(i, j int) bool {
 		return run.Apply.StatusTimestamps[i].Timestamp.Before(run.Apply.StatusTimestamps[j].Timestamp)
 	})
+	// convert test timestamps from db result and sort them according to
+	// timestamp (earliest first)
+	run.Test.StatusTimestamps = make([]PhaseStatusTimestamp, len(result.TestStatusTimestamps))
+	for i, tst := range result.TestStatusTimestamps {
+		run.Test.StatusTimestamps[i] = PhaseStatusTimestamp{
+			Status:    PhaseStatus(tst.Status.String),
+			Timestamp: tst.Timestamp.Time.UTC(),
+		}
+	}
+	sort.Slice(run.Test.StatusTimestamps, func(i, j int) bool {
+		return run.Test.StatusTimestamps[i].Timestamp.Before(run.Test.StatusTimestamps[j].Timestamp)
+	})
+	if result.TestReport != nil {
+		if err := json.Unmarshal(result.TestReport, &run.Test.TestReport); err != nil {
+			return nil, fmt.Errorf("unmarshaling test report: %w", err)
+		}
+	}
 	if len(result.RunVariables) > 0 {
 		run.Variables = make([]Variable, len(result.RunVariables))
 		for i, v := range result.RunVariables {
@@ -146,7 +174,7 @@ func (result pgresult) toRun() *Run {
 	if result.IngressAttributes != nil {
 		run.IngressAttributes = configversion.NewIngressFromRow(result.IngressAttributes)
 	}
-	return &run
+	return &run, nil
 }
 
 // CreateRun persists a Run to the DB.
@@ -166,6 +194,7 @@ func (db *pgdb) CreateRun(ctx context.Context, run *Run) error {
 			AutoApply:              run.AutoApply,
 			PlanOnly:               run.PlanOnly,
 			AllowEmptyApply:        run.AllowEmptyApply,
+			TestOnly:               run.TestOnly,
 			TerraformVersion:       sql.String(run.TerraformVersion),
 			ConfigurationVersionID: sql.String(run.ConfigurationVersionID),
 			WorkspaceID:            sql.String(run.WorkspaceID),
@@ -192,6 +221,10 @@ func (db *pgdb) CreateRun(ctx context.Context, run *Run) error {
 		if err != nil {
 			return fmt.Errorf("inserting apply: %w", err)
 		}
+		_, err = q.InsertTest(ctx, sql.String(run.ID), sql.String(string(run.Test.Status)))
+		if err != nil {
+			return fmt.Errorf("inserting test: %w", err)
+		}
 		if err := db.insertRunStatusTimestamp(ctx, run); err != nil {
 			return fmt.Errorf("inserting run status timestamp: %w", err)
 		}
@@ -201,11 +234,14 @@ func (db *pgdb) CreateRun(ctx context.Context, run *Run) error {
 		if err := db.insertPhaseStatusTimestamp(ctx, run.Apply); err != nil {
 			return fmt.Errorf("inserting apply status timestamp: %w", err)
 		}
+		if err := db.insertPhaseStatusTimestamp(ctx, run.Test); err != nil {
+			return fmt.Errorf("inserting test status timestamp: %w", err)
+		}
 		return nil
 	})
 }
 
-// UpdateStatus updates the run status as well as its plan and/or apply.
+// UpdateStatus updates the run status as well as its plan, apply, and/or test.
 func (db *pgdb) UpdateStatus(ctx context.Context, runID string, fn func(*Run) error) (*Run, error) {
 	var run *Run
 	err := db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
@@ -214,12 +250,16 @@ func (db *pgdb) UpdateStatus(ctx context.Context, runID string, fn func(*Run) er
 		if err != nil {
 			return sql.Error(err)
 		}
-		run = pgresult(result).toRun()
+		run, err = pgresult(result).toRun()
+		if err != nil {
+			return err
+		}
 
 		// Make copies of run attributes before update
 		runStatus := run.Status
 		planStatus := run.Plan.Status
 		applyStatus := run.Apply.Status
+		testStatus := run.Test.Status
 		forceCancelAvailableAt := run.ForceCancelAvailableAt
 
 		if err := fn(run); err != nil {
@@ -259,6 +299,17 @@ func (db *pgdb) UpdateStatus(ctx context.Context, runID string, fn func(*Run) er
 			}
 		}
 
+		if run.Test.Status != testStatus {
+			_, err := q.UpdateTestStatusByID(ctx, sql.String(string(run.Test.Status)), sql.String(run.ID))
+			if err != nil {
+				return err
+			}
+
+			if err := db.insertPhaseStatusTimestamp(ctx, run.Test); err != nil {
+				return err
+			}
+		}
+
 		if run.ForceCancelAvailableAt != forceCancelAvailableAt && run.ForceCancelAvailableAt != nil {
 			_, err := q.UpdateRunForceCancelAvailableAt(ctx, sql.Timestamptz(*run.ForceCancelAvailableAt), sql.String(run.ID))
 			if err != nil {
@@ -300,6 +351,18 @@ func (db *pgdb) CreateApplyReport(ctx context.Context, runID string, report Repo
 	return err
 }
 
+func (db *pgdb) CreateTestReport(ctx context.Context, runID string, report *TestReport) error {
+	encoded, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+	_, err = db.Conn(ctx).UpdateTestReportByID(ctx, encoded, sql.String(runID))
+	if err != nil {
+		return sql.Error(err)
+	}
+	return nil
+}
+
 func (db *pgdb) ListRuns(ctx context.Context, opts ListOptions) (*resource.Page[*Run], error) {
 	q := db.Conn(ctx)
 	batch := &pgx.Batch{}
@@ -364,7 +427,10 @@ func (db *pgdb) ListRuns(ctx context.Context, opts ListOptions) (*resource.Page[
 
 	items := make([]*Run, len(rows))
 	for i, r := range rows {
-		items[i] = pgresult(r).toRun()
+		items[i], err = pgresult(r).toRun()
+		if err != nil {
+			return nil, err
+		}
 	}
 	return resource.NewPage(items, opts.PageOptions, internal.Int64(count.Int)), nil
 }
@@ -375,7 +441,7 @@ func (db *pgdb) GetRun(ctx context.Context, runID string) (*Run, error) {
 	if err != nil {
 		return nil, sql.Error(err)
 	}
-	return pgresult(result).toRun(), nil
+	return pgresult(result).toRun()
 }
 
 // SetPlanFile writes a plan file to the db
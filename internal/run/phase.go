@@ -32,6 +32,8 @@ type (
 		ResourceReport *Report `json:"resource_report"`
 		// report of planned or applied output changes
 		OutputReport *Report `json:"output_report"`
+		// report of test results
+		TestReport *TestReport `json:"test_report"`
 	}
 
 	PhaseStatus string
@@ -24,7 +24,8 @@ type (
 
 	// ResourceChange represents a proposed change to a resource in a plan file
 	ResourceChange struct {
-		Change Change
+		Address string
+		Change  Change
 	}
 
 	// Change represents the type of change being made
@@ -0,0 +1,142 @@
+package run
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/leg100/otf/internal/configversion"
+	"github.com/leg100/otf/internal/vcs"
+	"github.com/leg100/otf/internal/workspace"
+)
+
+const (
+	// collapse the list of resource changes in a comment if it exceeds this
+	// many changes.
+	maxUncollapsedResourceChanges = 10
+	// list no more than this many resource changes in a comment, to keep
+	// within the maximum size of a comment imposed by VCS providers.
+	maxResourceChanges = 500
+)
+
+// commentPullRequest posts a comment on the pull request that triggered the
+// run, summarising its plan. There is one comment per workspace per pull
+// request: if the comment already exists then it is updated rather than a new
+// comment posted.
+func (r *Reporter) commentPullRequest(ctx context.Context, client vcs.Client, run *Run, ws *workspace.Workspace, cv *configversion.ConfigurationVersion, runURL string) error {
+	var planFile *PlanFile
+	if run.Plan.Status == PhaseFinished {
+		planJSON, err := r.GetPlanFile(ctx, run.ID, PlanFormatJSON)
+		if err != nil {
+			return fmt.Errorf("retrieving plan file: %w", err)
+		}
+		planFile = &PlanFile{}
+		if err := json.Unmarshal(planJSON, planFile); err != nil {
+			return fmt.Errorf("parsing plan file: %w", err)
+		}
+	}
+	body := renderPullRequestComment(run, ws, planFile, runURL)
+
+	repo := cv.IngressAttributes.Repo
+	pull := cv.IngressAttributes.PullRequestNumber
+	comments, err := client.ListPullRequestComments(ctx, repo, pull)
+	if err != nil {
+		return fmt.Errorf("listing pull request comments: %w", err)
+	}
+	marker := pullRequestCommentMarker(ws)
+	for _, comment := range comments {
+		// only update a comment made by OTF itself, otherwise anyone could
+		// hijack the comment by posting a comment with the same marker.
+		if comment.Own && strings.HasPrefix(comment.Body, marker) {
+			return client.UpdatePullRequestComment(ctx, vcs.UpdatePullRequestCommentOptions{
+				Repo:              repo,
+				PullRequestNumber: pull,
+				ID:                comment.ID,
+				Body:              body,
+			})
+		}
+	}
+	_, err = client.CreatePullRequestComment(ctx, vcs.CreatePullRequestCommentOptions{
+		Repo:              repo,
+		PullRequestNumber: pull,
+		Body:              body,
+	})
+	return err
+}
+
+// pullRequestCommentMarker is a hidden marker at the start of a comment
+// identifying the workspace to which the comment belongs.
+func pullRequestCommentMarker(ws *workspace.Workspace) string {
+	return fmt.Sprintf("<!-- otf:workspace:%s -->", ws.ID)
+}
+
+// renderPullRequestComment renders the markdown body of a pull request comment
+// summarising a run. The plan file is nil if the plan has not finished.
+func renderPullRequestComment(run *Run, ws *workspace.Workspace, planFile *PlanFile, runURL string) string {
+	var b strings.Builder
+	b.WriteString(pullRequestCommentMarker(ws))
+	b.WriteString("\n")
+	fmt.Fprintf(&b, "### Workspace `%s`: %s\n\n", ws.Name, run.Status)
+
+	if run.Plan.ResourceReport != nil {
+		fmt.Fprintf(&b, "**Plan:** %s", run.Plan.ResourceReport)
+		if run.Plan.OutputReport != nil && run.Plan.OutputReport.HasChanges() {
+			fmt.Fprintf(&b, " (outputs: %s)", run.Plan.OutputReport)
+		}
+		b.WriteString("\n\n")
+	}
+	if run.Apply.ResourceReport != nil {
+		fmt.Fprintf(&b, "**Apply:** %s\n\n", run.Apply.ResourceReport)
+	}
+
+	if planFile != nil {
+		var rows []string
+		for _, rc := range planFile.ResourceChanges {
+			action := changeActionLabel(rc.Change.Actions)
+			if action == "" {
+				continue
+			}
+			rows = append(rows, fmt.Sprintf("| `%s` | %s |", rc.Address, action))
+		}
+		if len(rows) > 0 {
+			collapse := len(rows) > maxUncollapsedResourceChanges
+			if collapse {
+				fmt.Fprintf(&b, "<details><summary>Show %d resource changes</summary>\n\n", len(rows))
+			}
+			b.WriteString("| Resource | Action |\n")
+			b.WriteString("|----------|--------|\n")
+			for i, row := range rows {
+				if i == maxResourceChanges {
+					fmt.Fprintf(&b, "| ...and %d more | |\n", len(rows)-maxResourceChanges)
+					break
+				}
+				b.WriteString(row)
+				b.WriteString("\n")
+			}
+			if collapse {
+				b.WriteString("\n</details>\n")
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	fmt.Fprintf(&b, "[View run](%s)\n", runURL)
+	return b.String()
+}
+
+// changeActionLabel returns a label describing the actions of a resource
+// change, or an empty string if the resource is unchanged.
+func changeActionLabel(actions []ChangeAction) string {
+	switch len(actions) {
+	case 1:
+		switch actions[0] {
+		case CreateAction, UpdateAction, DeleteAction:
+			return string(actions[0])
+		}
+	case 2:
+		// either delete-then-create or create-then-delete
+		return "replace"
+	}
+	return ""
+}
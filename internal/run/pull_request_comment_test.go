@@ -0,0 +1,54 @@
+package run
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/leg100/otf/internal/workspace"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderPullRequestComment(t *testing.T) {
+	ws := &workspace.Workspace{ID: "ws-123", Name: "dev"}
+	run := &Run{
+		Status: RunPlannedAndFinished,
+		Plan: Phase{
+			ResourceReport: &Report{Additions: 1, Destructions: 1},
+		},
+	}
+
+	t.Run("few changes", func(t *testing.T) {
+		planFile := &PlanFile{
+			ResourceChanges: []ResourceChange{
+				{Address: "random_id.a", Change: Change{Actions: []ChangeAction{CreateAction}}},
+				{Address: "random_id.b", Change: Change{Actions: []ChangeAction{"no-op"}}},
+				{Address: "random_id.c", Change: Change{Actions: []ChangeAction{DeleteAction, CreateAction}}},
+			},
+		}
+		got := renderPullRequestComment(run, ws, planFile, "https://otf-host.org/app/runs/run-123")
+
+		want := "<!-- otf:workspace:ws-123 -->\n" +
+			"### Workspace `dev`: planned_and_finished\n\n" +
+			"**Plan:** +1/~0/−1\n\n" +
+			"| Resource | Action |\n" +
+			"|----------|--------|\n" +
+			"| `random_id.a` | create |\n" +
+			"| `random_id.c` | replace |\n\n" +
+			"[View run](https://otf-host.org/app/runs/run-123)\n"
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("collapse many changes", func(t *testing.T) {
+		planFile := &PlanFile{}
+		for i := 0; i < maxUncollapsedResourceChanges+1; i++ {
+			planFile.ResourceChanges = append(planFile.ResourceChanges, ResourceChange{
+				Address: fmt.Sprintf("random_id.test[%d]", i),
+				Change:  Change{Actions: []ChangeAction{CreateAction}},
+			})
+		}
+		got := renderPullRequestComment(run, ws, planFile, "https://otf-host.org/app/runs/run-123")
+
+		assert.Contains(t, got, "<details><summary>Show 11 resource changes</summary>")
+		assert.Contains(t, got, "</details>")
+	})
+}
@@ -26,6 +26,7 @@ type (
 		VCSProviderService
 		ConfigurationVersionService
 		WorkspaceService
+		RunService
 		internal.HostnameService
 	}
 
@@ -102,9 +103,9 @@ func (r *Reporter) handleRun(ctx context.Context, run *Run) error {
 		description string
 	)
 	switch run.Status {
-	case RunPending, RunPlanQueued, RunApplyQueued:
+	case RunPending, RunPlanQueued, RunApplyQueued, RunTestQueued:
 		status = vcs.PendingStatus
-	case RunPlanning, RunApplying, RunPlanned, RunConfirmed:
+	case RunPlanning, RunApplying, RunPlanned, RunConfirmed, RunTesting:
 		status = vcs.RunningStatus
 	case RunPlannedAndFinished:
 		status = vcs.SuccessStatus
@@ -116,22 +117,53 @@ func (r *Reporter) handleRun(ctx context.Context, run *Run) error {
 		if run.Apply.ResourceReport != nil {
 			description = fmt.Sprintf("applied: %s", run.Apply.ResourceReport)
 		}
+	case RunTested:
+		status = vcs.SuccessStatus
+		if run.Test.TestReport != nil {
+			description = fmt.Sprintf("tested: %s", run.Test.TestReport)
+		}
 	case RunErrored, RunCanceled, RunForceCanceled, RunDiscarded:
 		status = vcs.ErrorStatus
 		description = run.Status.String()
+		// failing tests error the run but are reported as a failure rather
+		// than an error
+		if run.Status == RunErrored && run.Test.TestReport != nil {
+			status = vcs.FailureStatus
+			description = fmt.Sprintf("tests failed: %s", run.Test.TestReport)
+		}
 	default:
 		return fmt.Errorf("unknown run status: %s", run.Status)
 	}
-	return client.SetStatus(ctx, vcs.SetStatusOptions{
-		Workspace:   ws.Name,
+	// Report test runs separately so they don't overwrite the status of
+	// plan-only runs triggered by the same commit.
+	name := ws.Name
+	if run.TestOnly {
+		name += "/test"
+	}
+	runURL := (&url.URL{
+		Scheme: "https",
+		Host:   r.Hostname(),
+		Path:   paths.Run(run.ID),
+	}).String()
+	err = client.SetStatus(ctx, vcs.SetStatusOptions{
+		Workspace:   name,
 		Ref:         cv.IngressAttributes.CommitSHA,
 		Repo:        cv.IngressAttributes.Repo,
 		Status:      status,
 		Description: description,
-		TargetURL: (&url.URL{
-			Scheme: "https",
-			Host:   r.Hostname(),
-			Path:   paths.Run(run.ID),
-		}).String(),
+		TargetURL:   runURL,
 	})
+	if err != nil {
+		return err
+	}
+
+	// Summarise the plan in a pull request comment once the plan has
+	// settled, if enabled.
+	if !ws.Connection.CommentPullRequests || !cv.IngressAttributes.IsPullRequest || run.TestOnly {
+		return nil
+	}
+	if run.Status != RunPlanned && run.Status != RunPlannedAndFinished && !run.Done() {
+		return nil
+	}
+	return r.commentPullRequest(ctx, client, run, ws, cv, runURL)
 }
@@ -44,6 +44,64 @@ func TestReporter_HandleRun(t *testing.T) {
 				TargetURL: "https://otf-host.org/app/runs/run-123",
 			},
 		},
+		{
+			name: "tested run",
+			run: &Run{
+				ID:       "run-123",
+				Status:   RunTested,
+				TestOnly: true,
+				Test: Phase{
+					TestReport: &TestReport{Status: TestPass, Passed: 2},
+				},
+			},
+			ws: &workspace.Workspace{
+				Name:       "dev",
+				Connection: &workspace.Connection{},
+			},
+			cv: &configversion.ConfigurationVersion{
+				IngressAttributes: &configversion.IngressAttributes{
+					CommitSHA: "abc123",
+					Repo:      "leg100/otf",
+				},
+			},
+			want: vcs.SetStatusOptions{
+				Workspace:   "dev/test",
+				Ref:         "abc123",
+				Repo:        "leg100/otf",
+				Status:      vcs.SuccessStatus,
+				Description: "tested: 2 passed, 0 failed",
+				TargetURL:   "https://otf-host.org/app/runs/run-123",
+			},
+		},
+		{
+			name: "run with failing tests",
+			run: &Run{
+				ID:       "run-123",
+				Status:   RunErrored,
+				TestOnly: true,
+				Test: Phase{
+					TestReport: &TestReport{Status: TestFail, Passed: 1, Failed: 1},
+				},
+			},
+			ws: &workspace.Workspace{
+				Name:       "dev",
+				Connection: &workspace.Connection{},
+			},
+			cv: &configversion.ConfigurationVersion{
+				IngressAttributes: &configversion.IngressAttributes{
+					CommitSHA: "abc123",
+					Repo:      "leg100/otf",
+				},
+			},
+			want: vcs.SetStatusOptions{
+				Workspace:   "dev/test",
+				Ref:         "abc123",
+				Repo:        "leg100/otf",
+				Status:      vcs.FailureStatus,
+				Description: "tests failed: 1 passed, 1 failed",
+				TargetURL:   "https://otf-host.org/app/runs/run-123",
+			},
+		},
 		{
 			name: "skip run with config not from a VCS repo",
 			run:  &Run{ID: "run-123"},
@@ -80,6 +138,87 @@ func TestReporter_HandleRun(t *testing.T) {
 	}
 }
 
+func TestReporter_CommentPullRequest(t *testing.T) {
+	ctx := context.Background()
+	ws := &workspace.Workspace{
+		ID:         "ws-123",
+		Name:       "dev",
+		Connection: &workspace.Connection{CommentPullRequests: true},
+	}
+	cv := &configversion.ConfigurationVersion{
+		IngressAttributes: &configversion.IngressAttributes{
+			CommitSHA:         "abc123",
+			Repo:              "leg100/otf",
+			IsPullRequest:     true,
+			PullRequestNumber: 7,
+		},
+	}
+	run := &Run{
+		ID:     "run-123",
+		Status: RunPlannedAndFinished,
+		Plan: Phase{
+			Status:         PhaseFinished,
+			ResourceReport: &Report{Additions: 1},
+		},
+	}
+	plan := []byte(`{"resource_changes":[{"address":"random_id.test","change":{"actions":["create"]}}]}`)
+
+	tests := []struct {
+		name     string
+		comments []vcs.Comment
+		// want a new comment rather than an update to an existing comment
+		wantCreated bool
+	}{
+		{
+			name:        "create new comment",
+			comments:    []vcs.Comment{{ID: "1", Body: "lgtm"}},
+			wantCreated: true,
+		},
+		{
+			name: "update existing comment",
+			comments: []vcs.Comment{
+				{ID: "1", Body: "lgtm"},
+				{ID: "2", Body: "<!-- otf:workspace:ws-123 -->\nold plan", Own: true},
+			},
+			wantCreated: false,
+		},
+		{
+			name: "ignore comment with marker made by someone else",
+			comments: []vcs.Comment{
+				{ID: "1", Body: "<!-- otf:workspace:ws-123 -->\nimpostor"},
+			},
+			wantCreated: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got vcs.SetStatusOptions
+			client := &fakeReporterCloudClient{got: &got, comments: tt.comments}
+			reporter := &Reporter{
+				WorkspaceService:            &fakeReporterWorkspaceService{ws: ws},
+				ConfigurationVersionService: &fakeReporterConfigurationVersionService{cv: cv},
+				VCSProviderService:          &fakeReporterVCSProviderService{client: client},
+				RunService:                  &fakeReporterRunService{plan: plan},
+				HostnameService:             internal.NewHostnameService("otf-host.org"),
+			}
+			err := reporter.handleRun(ctx, run)
+			require.NoError(t, err)
+
+			if tt.wantCreated {
+				require.NotNil(t, client.created)
+				assert.Nil(t, client.updated)
+				assert.Equal(t, 7, client.created.PullRequestNumber)
+				assert.Contains(t, client.created.Body, "`random_id.test` | create")
+			} else {
+				require.NotNil(t, client.updated)
+				assert.Nil(t, client.created)
+				assert.Equal(t, "2", client.updated.ID)
+				assert.Contains(t, client.updated.Body, "`random_id.test` | create")
+			}
+		})
+	}
+}
+
 type fakeReporterConfigurationVersionService struct {
 	configversion.Service
 
@@ -103,20 +242,51 @@ func (f *fakeReporterWorkspaceService) GetWorkspace(context.Context, string) (*w
 type fakeReporterVCSProviderService struct {
 	vcsprovider.VCSProviderService
 
-	got *vcs.SetStatusOptions
+	got    *vcs.SetStatusOptions
+	client *fakeReporterCloudClient
 }
 
 func (f *fakeReporterVCSProviderService) GetVCSClient(context.Context, string) (vcs.Client, error) {
+	if f.client != nil {
+		return f.client, nil
+	}
 	return &fakeReporterCloudClient{got: f.got}, nil
 }
 
+type fakeReporterRunService struct {
+	RunService
+
+	plan []byte
+}
+
+func (f *fakeReporterRunService) GetPlanFile(context.Context, string, PlanFormat) ([]byte, error) {
+	return f.plan, nil
+}
+
 type fakeReporterCloudClient struct {
 	vcs.Client
 
-	got *vcs.SetStatusOptions
+	got      *vcs.SetStatusOptions
+	comments []vcs.Comment
+	created  *vcs.CreatePullRequestCommentOptions
+	updated  *vcs.UpdatePullRequestCommentOptions
 }
 
 func (f *fakeReporterCloudClient) SetStatus(ctx context.Context, opts vcs.SetStatusOptions) error {
 	*f.got = opts
 	return nil
 }
+
+func (f *fakeReporterCloudClient) ListPullRequestComments(context.Context, string, int) ([]vcs.Comment, error) {
+	return f.comments, nil
+}
+
+func (f *fakeReporterCloudClient) CreatePullRequestComment(ctx context.Context, opts vcs.CreatePullRequestCommentOptions) (string, error) {
+	f.created = &opts
+	return "3", nil
+}
+
+func (f *fakeReporterCloudClient) UpdatePullRequestComment(ctx context.Context, opts vcs.UpdatePullRequestCommentOptions) error {
+	f.updated = &opts
+	return nil
+}
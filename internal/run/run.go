@@ -24,6 +24,7 @@ const (
 	PlanOnlyOperation     Operation = "plan-only"
 	PlanAndApplyOperation Operation = "plan-and-apply"
 	DestroyAllOperation   Operation = "destroy-all"
+	TestOperation         Operation = "test"
 
 	// defaultRefresh specifies that the state be refreshed prior to running a
 	// plan
@@ -55,6 +56,7 @@ type (
 		AllowEmptyApply        bool                    `jsonapi:"attribute" json:"allow_empty_apply"`
 		AutoApply              bool                    `jsonapi:"attribute" json:"auto_apply"`
 		PlanOnly               bool                    `jsonapi:"attribute" json:"plan_only"`
+		TestOnly               bool                    `jsonapi:"attribute" json:"test_only"`
 		Source                 Source                  `jsonapi:"attribute" json:"source"`
 		Status                 Status                  `jsonapi:"attribute" json:"status"`
 		WorkspaceID            string                  `jsonapi:"attribute" json:"workspace_id"`
@@ -63,6 +65,7 @@ type (
 		Variables              []Variable              `jsonapi:"attribute" json:"variables"`
 		Plan                   Phase                   `jsonapi:"attribute" json:"plan"`
 		Apply                  Phase                   `jsonapi:"attribute" json:"apply"`
+		Test                   Phase                   `jsonapi:"attribute" json:"test"`
 
 		// Timestamps of when a state transition occured. Ordered earliest
 		// first.
@@ -113,7 +116,11 @@ type (
 		// PlanOnly specifies if this is a speculative, plan-only run that
 		// Terraform cannot apply. Takes precedence over whether the
 		// configuration version is marked as speculative or not.
-		PlanOnly  *bool
+		PlanOnly *bool
+		// TestOnly specifies if this is a run that executes `terraform test`
+		// against the configuration rather than a plan or an apply. Takes
+		// precedence over PlanOnly.
+		TestOnly  *bool
 		Variables []Variable
 
 		// testing purposes
@@ -145,6 +152,10 @@ type (
 	WatchOptions struct {
 		Organization *string `schema:"organization_name,omitempty"` // filter by organization name
 		WorkspaceID  *string `schema:"workspace_id,omitempty"`      // filter by workspace ID; mutually exclusive with organization filter
+
+		// LastEventID is the ID of the last event received by the caller; events
+		// since this event are replayed. Sent as the Last-Event-ID header.
+		LastEventID int64 `schema:"-"`
 	}
 )
 
@@ -170,6 +181,7 @@ func newRun(ctx context.Context, org *organization.Organization, cv *configversi
 	}
 	run.Plan = newPhase(run.ID, internal.PlanPhase)
 	run.Apply = newPhase(run.ID, internal.ApplyPhase)
+	run.Test = newPhase(run.ID, internal.TestPhase)
 	run.updateStatus(RunPending, opts.now)
 
 	if run.Source == "" {
@@ -199,13 +211,24 @@ func newRun(ctx context.Context, org *organization.Organization, cv *configversi
 	if opts.PlanOnly != nil {
 		run.PlanOnly = *opts.PlanOnly
 	}
+	if opts.TestOnly != nil {
+		run.TestOnly = *opts.TestOnly
+	}
+	// A test run neither plans nor applies, whereas other runs never test.
+	if run.TestOnly {
+		run.PlanOnly = false
+		run.Plan.UpdateStatus(PhaseUnreachable)
+		run.Apply.UpdateStatus(PhaseUnreachable)
+	} else {
+		run.Test.UpdateStatus(PhaseUnreachable)
+	}
 	return &run
 }
 
 func (r *Run) String() string { return r.ID }
 
 func (r *Run) Queued() bool {
-	return r.Status == RunPlanQueued || r.Status == RunApplyQueued
+	return r.Status == RunPlanQueued || r.Status == RunApplyQueued || r.Status == RunTestQueued
 }
 
 func (r *Run) HasChanges() bool {
@@ -275,6 +298,8 @@ func (r *Run) Phase() internal.PhaseType {
 		return internal.PlanPhase
 	case RunApplyQueued, RunApplying, RunApplied:
 		return internal.ApplyPhase
+	case RunTestQueued, RunTesting, RunTested:
+		return internal.TestPhase
 	default:
 		return internal.UnknownPhase
 	}
@@ -310,11 +335,14 @@ func (r *Run) Cancel() error {
 	case RunPending:
 		r.Plan.UpdateStatus(PhaseUnreachable)
 		r.Apply.UpdateStatus(PhaseUnreachable)
+		r.Test.UpdateStatus(PhaseUnreachable)
 	case RunPlanQueued, RunPlanning:
 		r.Plan.UpdateStatus(PhaseCanceled)
 		r.Apply.UpdateStatus(PhaseUnreachable)
 	case RunApplyQueued, RunApplying:
 		r.Apply.UpdateStatus(PhaseCanceled)
+	case RunTestQueued, RunTesting:
+		r.Test.UpdateStatus(PhaseCanceled)
 	}
 
 	r.updateStatus(RunCanceled, nil)
@@ -341,7 +369,7 @@ func (r *Run) StartedAt() time.Time {
 // discarded, etc.
 func (r *Run) Done() bool {
 	switch r.Status {
-	case RunApplied, RunPlannedAndFinished, RunDiscarded, RunCanceled, RunErrored:
+	case RunApplied, RunPlannedAndFinished, RunTested, RunDiscarded, RunCanceled, RunErrored:
 		return true
 	default:
 		return false
@@ -349,11 +377,17 @@ func (r *Run) Done() bool {
 }
 
 // EnqueuePlan enqueues a plan for the run. It also sets the run as the latest
-// run for its workspace (speculative runs are ignored).
+// run for its workspace (speculative runs are ignored). If the run is a test
+// run then its test is enqueued instead.
 func (r *Run) EnqueuePlan() error {
 	if r.Status != RunPending {
 		return fmt.Errorf("cannot enqueue run with status %s", r.Status)
 	}
+	if r.TestOnly {
+		r.updateStatus(RunTestQueued, nil)
+		r.Test.UpdateStatus(PhaseQueued)
+		return nil
+	}
 	r.updateStatus(RunPlanQueued, nil)
 	r.Plan.UpdateStatus(PhaseQueued)
 
@@ -405,7 +439,10 @@ func (r *Run) Start(phase internal.PhaseType) error {
 	case RunApplyQueued:
 		r.updateStatus(RunApplying, nil)
 		r.Apply.UpdateStatus(PhaseRunning)
-	case RunPlanning, RunApplying:
+	case RunTestQueued:
+		r.updateStatus(RunTesting, nil)
+		r.Test.UpdateStatus(PhaseRunning)
+	case RunPlanning, RunApplying, RunTesting:
 		return internal.ErrPhaseAlreadyStarted
 	default:
 		return ErrInvalidRunStateTransition
@@ -413,7 +450,8 @@ func (r *Run) Start(phase internal.PhaseType) error {
 	return nil
 }
 
-// Finish updates the run to reflect its plan or apply phase having finished.
+// Finish updates the run to reflect its plan, apply, or test phase having
+// finished.
 func (r *Run) Finish(phase internal.PhaseType, opts PhaseFinishOptions) error {
 	if r.Status == RunCanceled {
 		// run was canceled before the phase finished so nothing more to do.
@@ -459,6 +497,19 @@ func (r *Run) Finish(phase internal.PhaseType, opts PhaseFinishOptions) error {
 			r.Apply.UpdateStatus(PhaseFinished)
 		}
 		return nil
+	case internal.TestPhase:
+		if r.Status != RunTesting {
+			return ErrInvalidRunStateTransition
+		}
+		// terraform test errors when any of the tests fail
+		if opts.Errored {
+			r.updateStatus(RunErrored, nil)
+			r.Test.UpdateStatus(PhaseErrored)
+		} else {
+			r.updateStatus(RunTested, nil)
+			r.Test.UpdateStatus(PhaseFinished)
+		}
+		return nil
 	default:
 		return fmt.Errorf("unknown phase")
 	}
@@ -486,7 +537,7 @@ func (r *Run) Discardable() bool {
 // Cancelable determines whether run can be cancelled.
 func (r *Run) Cancelable() bool {
 	switch r.Status {
-	case RunPending, RunPlanQueued, RunPlanning, RunApplyQueued, RunApplying:
+	case RunPending, RunPlanQueued, RunPlanning, RunApplyQueued, RunApplying, RunTestQueued, RunTesting:
 		return true
 	default:
 		return false
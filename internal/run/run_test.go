@@ -171,6 +171,56 @@ func TestRun_States(t *testing.T) {
 		require.Equal(t, PhaseErrored, run.Apply.Status)
 	})
 
+	t.Run("pending test", func(t *testing.T) {
+		run := newTestRun(ctx, CreateOptions{TestOnly: internal.Bool(true)})
+
+		require.Equal(t, RunPending, run.Status)
+		require.Equal(t, PhaseUnreachable, run.Plan.Status)
+		require.Equal(t, PhaseUnreachable, run.Apply.Status)
+		require.Equal(t, PhasePending, run.Test.Status)
+	})
+
+	t.Run("enqueue test", func(t *testing.T) {
+		run := newTestRun(ctx, CreateOptions{TestOnly: internal.Bool(true)})
+
+		require.NoError(t, run.EnqueuePlan())
+
+		require.Equal(t, RunTestQueued, run.Status)
+		require.Equal(t, PhaseQueued, run.Test.Status)
+		require.Equal(t, internal.TestPhase, run.Phase())
+	})
+
+	t.Run("start test", func(t *testing.T) {
+		run := newTestRun(ctx, CreateOptions{TestOnly: internal.Bool(true)})
+		run.Status = RunTestQueued
+
+		require.NoError(t, run.Start(internal.TestPhase))
+
+		require.Equal(t, RunTesting, run.Status)
+		require.Equal(t, PhaseRunning, run.Test.Status)
+	})
+
+	t.Run("finish test", func(t *testing.T) {
+		run := newTestRun(ctx, CreateOptions{TestOnly: internal.Bool(true)})
+		run.Status = RunTesting
+
+		require.NoError(t, run.Finish(internal.TestPhase, PhaseFinishOptions{}))
+
+		require.Equal(t, RunTested, run.Status)
+		require.Equal(t, PhaseFinished, run.Test.Status)
+		require.True(t, run.Done())
+	})
+
+	t.Run("finish test with failures", func(t *testing.T) {
+		run := newTestRun(ctx, CreateOptions{TestOnly: internal.Bool(true)})
+		run.Status = RunTesting
+
+		require.NoError(t, run.Finish(internal.TestPhase, PhaseFinishOptions{Errored: true}))
+
+		require.Equal(t, RunErrored, run.Status)
+		require.Equal(t, PhaseErrored, run.Test.Status)
+	})
+
 	t.Run("cancel run", func(t *testing.T) {
 		run := newTestRun(ctx, CreateOptions{})
 		err := run.Cancel()
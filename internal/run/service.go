@@ -8,6 +8,7 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/gorilla/mux"
 	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/auth"
 	"github.com/leg100/otf/internal/configversion"
 	"github.com/leg100/otf/internal/http/html"
 	"github.com/leg100/otf/internal/organization"
@@ -46,6 +47,9 @@ type (
 		// UploadPlanFile persists a run's plan file. The plan format should be either
 		// be binary or json.
 		UploadPlanFile(ctx context.Context, runID string, plan []byte, format PlanFormat) error
+		// UploadTestResults compiles a test report from the machine-readable
+		// output of `terraform test -json` and persists it.
+		UploadTestResults(ctx context.Context, runID string, results []byte) error
 		// Watch provides access to a stream of run events. The WatchOptions filters
 		// events. Context must be cancelled to close stream.
 		//
@@ -102,6 +106,8 @@ type (
 		ConfigurationVersionService
 		VCSProviderService
 		releases.ReleasesService
+		auth.UserService
+		internal.HostnameService
 
 		logr.Logger
 		internal.Cache
@@ -159,6 +165,8 @@ func NewService(opts Options) *service {
 		WorkspaceService:            opts.WorkspaceService,
 		VCSProviderService:          opts.VCSProviderService,
 		RunService:                  &svc,
+		UserService:                 opts.UserService,
+		HostnameService:             opts.HostnameService,
 	}
 
 	// Register with broker so that it can relay run events
@@ -379,7 +387,7 @@ func (s *service) Watch(ctx context.Context, opts WatchOptions) (<-chan pubsub.E
 		return nil, err
 	}
 
-	sub, err := s.Subscribe(ctx, "run-watch-")
+	sub, err := s.SubscribeFrom(ctx, "run-watch-", opts.LastEventID)
 	if err != nil {
 		return nil, err
 	}
@@ -539,6 +547,29 @@ func (s *service) UploadPlanFile(ctx context.Context, runID string, plan []byte,
 	return nil
 }
 
+// UploadTestResults compiles a test report from the machine-readable output
+// of `terraform test -json` and persists it.
+func (s *service) UploadTestResults(ctx context.Context, runID string, results []byte) error {
+	subject, err := s.CanAccess(ctx, rbac.UploadTestResultsAction, runID)
+	if err != nil {
+		return err
+	}
+
+	report, err := CompileTestReport(results)
+	if err != nil {
+		s.Error(err, "compiling test report", "id", runID, "subject", subject)
+		return err
+	}
+	if err := s.db.CreateTestReport(ctx, runID, report); err != nil {
+		s.Error(err, "uploading test results", "id", runID, "subject", subject)
+		return err
+	}
+
+	s.V(1).Info("uploaded test results", "id", runID, "report", report, "subject", subject)
+
+	return nil
+}
+
 // createReports creates reports of changes for the phase.
 func (s *service) createReports(ctx context.Context, runID string, phase internal.PhaseType) (resource Report, output Report, err error) {
 	switch phase {
@@ -546,6 +577,9 @@ func (s *service) createReports(ctx context.Context, runID string, phase interna
 		resource, output, err = s.createPlanReports(ctx, runID)
 	case internal.ApplyPhase:
 		resource, err = s.createApplyReport(ctx, runID)
+	case internal.TestPhase:
+		// a test makes no changes; its report is instead created when its
+		// results are uploaded.
 	default:
 		return Report{}, Report{}, fmt.Errorf("unknown supported phase for creating report: %s", phase)
 	}
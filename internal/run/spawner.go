@@ -8,6 +8,7 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/gobwas/glob"
 	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/auth"
 	"github.com/leg100/otf/internal/configversion"
 	"github.com/leg100/otf/internal/vcs"
 )
@@ -21,6 +22,8 @@ type (
 		WorkspaceService
 		VCSProviderService
 		RunService
+		auth.UserService
+		internal.HostnameService
 	}
 )
 
@@ -44,6 +47,11 @@ func (s *Spawner) handleWithError(logger logr.Logger, event vcs.Event) error {
 	// give spawner unlimited powers
 	ctx = internal.AddSubjectToContext(ctx, &internal.Superuser{Username: "run-spawner"})
 
+	// comments on pull requests may contain commands
+	if event.Type == vcs.EventTypePullComment {
+		return s.handleComment(ctx, logger, event)
+	}
+
 	// skip events other than those that create or update a ref or pull request
 	switch event.Action {
 	case vcs.ActionCreated, vcs.ActionUpdated:
@@ -202,6 +210,13 @@ func (s *Spawner) handleWithError(logger logr.Logger, event vcs.Event) error {
 		if err != nil {
 			return err
 		}
+		// additionally test the pull request's configuration if enabled
+		if event.Type == vcs.EventTypePull && ws.Connection.TestPullRequests {
+			runOpts.TestOnly = internal.Bool(true)
+			if _, err := s.CreateRun(ctx, ws.ID, runOpts); err != nil {
+				return err
+			}
+		}
 	}
 	return nil
 }
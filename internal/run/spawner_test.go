@@ -5,8 +5,13 @@ import (
 	"testing"
 
 	"github.com/go-logr/logr"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/auth"
 	"github.com/leg100/otf/internal/configversion"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/resource"
 	"github.com/leg100/otf/internal/vcs"
+	"github.com/leg100/otf/internal/vcsprovider"
 	"github.com/leg100/otf/internal/workspace"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -211,6 +216,231 @@ func TestSpawner(t *testing.T) {
 	}
 }
 
+func TestSpawner_TestPullRequests(t *testing.T) {
+	services := &fakeSpawnerServices{
+		workspaces: []*workspace.Workspace{
+			{Connection: &workspace.Connection{TestPullRequests: true}},
+		},
+	}
+	spawner := Spawner{
+		ConfigurationVersionService: services,
+		WorkspaceService:            services,
+		VCSProviderService:          services,
+		RunService:                  services,
+	}
+	err := spawner.handleWithError(logr.Discard(), vcs.Event{
+		EventPayload: vcs.EventPayload{
+			Type:   vcs.EventTypePull,
+			Action: vcs.ActionCreated,
+		},
+	})
+	require.NoError(t, err)
+
+	// want a plan-only run and a test run using the same config version
+	require.Equal(t, 2, len(services.runs))
+	assert.Nil(t, services.runs[0].TestOnly)
+	assert.True(t, *services.runs[1].TestOnly)
+	assert.Equal(t, services.runs[0].ConfigurationVersionID, services.runs[1].ConfigurationVersionID)
+}
+
+func TestSpawner_Comment(t *testing.T) {
+	policy := internal.WorkspacePolicy{
+		Organization: "acme",
+		WorkspaceID:  "ws-123",
+		Permissions: []internal.WorkspacePermission{
+			{Team: "planners", Role: rbac.WorkspacePlanRole},
+			{Team: "appliers", Role: rbac.WorkspaceWriteRole},
+		},
+	}
+	planner := &auth.User{
+		ID:       "user-planner",
+		Username: "bobby",
+		Teams:    []*auth.Team{{Name: "planners", Organization: "acme"}},
+	}
+	applier := &auth.User{
+		ID:       "user-applier",
+		Username: "bobby",
+		Teams:    []*auth.Team{{Name: "appliers", Organization: "acme"}},
+	}
+	siteAdmin := &auth.User{
+		ID:        "user-admin",
+		Username:  "bobby",
+		SiteAdmin: true,
+	}
+	// run awaiting confirmation, planned from the head of the pull request
+	planned := map[string]*Run{"abc123": {ID: "run-planned", Status: RunPlanned}}
+
+	tests := []struct {
+		name    string
+		comment string
+		// branch to which workspace is restricted
+		branch string
+		// user linked to the commenter's VCS identity
+		linked *auth.User
+		// runs awaiting confirmation, keyed by commit SHA
+		planned map[string]*Run
+		// want a spawned run
+		spawn bool
+		// want a speculative config version
+		speculative bool
+		// want run with this ID applied
+		applied string
+		// want reply to contain this string
+		reply string
+	}{
+		{
+			name:    "plan",
+			comment: "otf plan",
+			linked:  planner,
+			spawn:   true,
+			reply:   "started [run-123](https://otf-host.org/app/runs/run-123)",
+		},
+		{
+			name:        "plan on workspace restricted to another branch",
+			comment:     "otf plan",
+			branch:      "main",
+			linked:      planner,
+			spawn:       true,
+			speculative: true,
+			reply:       "started [run-123](https://otf-host.org/app/runs/run-123)",
+		},
+		{
+			name:    "apply",
+			comment: "otf apply",
+			linked:  applier,
+			planned: planned,
+			applied: "run-planned",
+			reply:   "applying [run-planned](https://otf-host.org/app/runs/run-planned)",
+		},
+		{
+			name:    "apply named workspace",
+			comment: "otf apply dev",
+			linked:  applier,
+			planned: planned,
+			applied: "run-planned",
+			reply:   "applying [run-planned](https://otf-host.org/app/runs/run-planned)",
+		},
+		{
+			name:    "apply without planned run",
+			comment: "otf apply",
+			linked:  applier,
+			reply:   errNoPlannedRun.Error(),
+		},
+		{
+			name:    "apply after head of pull request has moved on",
+			comment: "otf apply",
+			linked:  applier,
+			planned: map[string]*Run{"def456": {ID: "run-planned", Status: RunPlanned}},
+			reply:   errNoPlannedRun.Error(),
+		},
+		{
+			name:    "apply on workspace restricted to another branch",
+			comment: "otf apply",
+			branch:  "main",
+			linked:  applier,
+			planned: planned,
+			reply:   "workspace only applies changes from branch `main`",
+		},
+		{
+			name:    "apply non-matching workspace",
+			comment: "otf apply prod",
+			linked:  applier,
+			reply:   "no matching workspaces found",
+		},
+		{
+			name:    "insufficient permissions to apply",
+			comment: "otf apply",
+			linked:  planner,
+			planned: planned,
+			reply:   "not permitted to apply",
+		},
+		{
+			name:    "site admin is not permitted to apply",
+			comment: "otf apply",
+			linked:  siteAdmin,
+			planned: planned,
+			reply:   "not permitted to apply",
+		},
+		{
+			name:    "unlinked user",
+			comment: "otf plan",
+			reply:   "@bobby has not logged in to OTF with their github.com account. Log in to OTF at https://otf-host.org/login using github.com, then try again.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ws := &workspace.Workspace{
+				ID:         "ws-123",
+				Name:       "dev",
+				Connection: &workspace.Connection{Repo: "leg100/otf", Branch: tt.branch},
+			}
+			services := &fakeSpawnerServices{
+				workspaces: []*workspace.Workspace{ws},
+				linked:     tt.linked,
+				planned:    tt.planned,
+				policy:     policy,
+			}
+			spawner := Spawner{
+				ConfigurationVersionService: services,
+				WorkspaceService:            services,
+				VCSProviderService:          services,
+				RunService:                  services,
+				UserService:                 services,
+				HostnameService:             internal.NewHostnameService("otf-host.org"),
+			}
+			err := spawner.handleWithError(logr.Discard(), vcs.Event{
+				EventPayload: vcs.EventPayload{
+					Type:              vcs.EventTypePullComment,
+					Action:            vcs.ActionCreated,
+					VCSKind:           vcs.GithubKind,
+					RepoPath:          "leg100/otf",
+					PullRequestNumber: 7,
+					Comment:           tt.comment,
+					SenderUsername:    "bobby",
+				},
+			})
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.spawn, services.spawned)
+			if tt.spawn {
+				require.Equal(t, 1, len(services.created))
+				assert.Equal(t, tt.speculative, services.created[0].Speculative)
+				assert.Equal(t, "abc123", services.created[0].IngressAttributes.CommitSHA)
+				require.Equal(t, 1, len(services.runs))
+				assert.False(t, *services.runs[0].AutoApply)
+			}
+			assert.Equal(t, tt.applied, services.applied)
+			require.Equal(t, 1, len(services.client.comments))
+			assert.Equal(t, 7, services.client.comments[0].PullRequestNumber)
+			assert.Contains(t, services.client.comments[0].Body, tt.reply)
+		})
+	}
+}
+
+func TestSpawner_IgnoreComment(t *testing.T) {
+	services := &fakeSpawnerServices{
+		workspaces: []*workspace.Workspace{{Connection: &workspace.Connection{}}},
+	}
+	spawner := Spawner{
+		ConfigurationVersionService: services,
+		WorkspaceService:            services,
+		VCSProviderService:          services,
+		RunService:                  services,
+		UserService:                 services,
+	}
+	err := spawner.handleWithError(logr.Discard(), vcs.Event{
+		EventPayload: vcs.EventPayload{
+			Type:    vcs.EventTypePullComment,
+			Action:  vcs.ActionCreated,
+			Comment: "lgtm",
+		},
+	})
+	require.NoError(t, err)
+
+	assert.False(t, services.spawned)
+	assert.Nil(t, services.client)
+}
+
 type fakeSpawnerServices struct {
 	// workspaces to return from stubbed ListWorkspacesByRepoID()
 	workspaces []*workspace.Workspace
@@ -218,13 +448,26 @@ type fakeSpawnerServices struct {
 	created []*configversion.ConfigurationVersion
 	// whether a run was spawned
 	spawned bool
+	// options for each spawned run
+	runs []CreateOptions
 	// list of file paths to return from stubbed ListPullRequestFiles()
 	pullFiles []string
+	// user to return from stubbed GetUser() for a VCS identity
+	linked *auth.User
+	// runs to return from stubbed ListRuns(), keyed by commit SHA
+	planned map[string]*Run
+	// ID of run applied via stubbed Apply()
+	applied string
+	// policy to return from stubbed GetPolicy()
+	policy internal.WorkspacePolicy
+	// client returned from stubbed GetVCSClient()
+	client *fakeSpawnerCloudClient
 
 	ConfigurationVersionService
 	WorkspaceService
 	VCSProviderService
 	RunService
+	auth.UserService
 }
 
 func (f *fakeSpawnerServices) ListConnectedWorkspaces(context.Context, string, string) ([]*workspace.Workspace, error) {
@@ -244,18 +487,61 @@ func (f *fakeSpawnerServices) UploadConfig(context.Context, string, []byte) erro
 	return nil
 }
 
-func (f *fakeSpawnerServices) CreateRun(context.Context, string, CreateOptions) (*Run, error) {
+func (f *fakeSpawnerServices) CreateRun(ctx context.Context, wid string, opts CreateOptions) (*Run, error) {
 	f.spawned = true
-	return nil, nil
+	f.runs = append(f.runs, opts)
+	return &Run{ID: "run-123", WorkspaceID: wid}, nil
+}
+
+func (f *fakeSpawnerServices) ListRuns(ctx context.Context, opts ListOptions) (*resource.Page[*Run], error) {
+	var items []*Run
+	if run, ok := f.planned[*opts.CommitSHA]; ok {
+		items = append(items, run)
+	}
+	return resource.NewPage(items, resource.PageOptions{}, nil), nil
+}
+
+func (f *fakeSpawnerServices) Apply(ctx context.Context, runID string) error {
+	f.applied = runID
+	return nil
+}
+
+func (f *fakeSpawnerServices) GetVCSProvider(context.Context, string) (*vcsprovider.VCSProvider, error) {
+	return &vcsprovider.VCSProvider{Hostname: "github.com"}, nil
 }
 
 func (f *fakeSpawnerServices) GetVCSClient(context.Context, string) (vcs.Client, error) {
-	return &fakeSpawnerCloudClient{pullFiles: f.pullFiles}, nil
+	if f.client == nil {
+		f.client = &fakeSpawnerCloudClient{pullFiles: f.pullFiles}
+	}
+	return f.client, nil
+}
+
+func (f *fakeSpawnerServices) GetUser(ctx context.Context, spec auth.UserSpec) (*auth.User, error) {
+	if f.linked == nil || spec.VCSIdentity == nil || spec.VCSIdentity.Hostname != "github.com" || f.linked.Username != spec.VCSIdentity.Username {
+		return nil, internal.ErrResourceNotFound
+	}
+	return f.linked, nil
+}
+
+func (f *fakeSpawnerServices) GetPolicy(ctx context.Context, workspaceID string) (internal.WorkspacePolicy, error) {
+	return f.policy, nil
 }
 
 type fakeSpawnerCloudClient struct {
 	vcs.Client
 	pullFiles []string
+	// comments posted on pull request
+	comments []vcs.CreatePullRequestCommentOptions
+}
+
+func (f *fakeSpawnerCloudClient) GetPullRequest(ctx context.Context, repo string, pull int) (vcs.PullRequest, error) {
+	return vcs.PullRequest{Number: pull, Branch: "dev", CommitSHA: "abc123"}, nil
+}
+
+func (f *fakeSpawnerCloudClient) CreatePullRequestComment(ctx context.Context, opts vcs.CreatePullRequestCommentOptions) (string, error) {
+	f.comments = append(f.comments, opts)
+	return "1", nil
 }
 
 func (f *fakeSpawnerCloudClient) GetRepoTarball(context.Context, vcs.GetRepoTarballOptions) ([]byte, string, error) {
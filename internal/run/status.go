@@ -20,6 +20,9 @@ const (
 	RunPlanned            Status = "planned"
 	RunPlannedAndFinished Status = "planned_and_finished"
 	RunPlanning           Status = "planning"
+	RunTestQueued         Status = "test_queued"
+	RunTesting            Status = "testing"
+	RunTested             Status = "tested"
 
 	// OTF doesn't support cost estimation but go-tfe API tests expect this
 	// status so it is included expressly to pass the tests.
@@ -53,6 +56,8 @@ var (
 		RunPlanQueued,
 		RunPlanned,
 		RunPlanning,
+		RunTestQueued,
+		RunTesting,
 	}
 	IncompleteRun = append(ActiveRun, RunPending)
 )
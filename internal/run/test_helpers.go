@@ -22,6 +22,10 @@ func (f *fakeSubscriber) Subscribe(context.Context, string) (<-chan pubsub.Event
 	return f.ch, nil
 }
 
+func (f *fakeSubscriber) SubscribeFrom(context.Context, string, int64) (<-chan pubsub.Event, error) {
+	return f.ch, nil
+}
+
 type (
 	fakeWebServices struct {
 		runs []*Run
@@ -0,0 +1,129 @@
+package run
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	TestPending TestStatus = "pending"
+	TestSkip    TestStatus = "skip"
+	TestPass    TestStatus = "pass"
+	TestFail    TestStatus = "fail"
+	TestError   TestStatus = "error"
+)
+
+var ErrMissingTestSummary = errors.New("test results are missing a summary")
+
+type (
+	// TestReport reports the results of executing `terraform test`, including
+	// the status of each run block in each test file.
+	TestReport struct {
+		Status  TestStatus      `json:"status"`
+		Passed  int             `json:"passed"`
+		Failed  int             `json:"failed"`
+		Errored int             `json:"errored"`
+		Skipped int             `json:"skipped"`
+		Runs    []TestRunResult `json:"runs"`
+	}
+
+	// TestRunResult is the result of executing a run block in a test file.
+	TestRunResult struct {
+		File   string     `json:"file"`
+		Name   string     `json:"name"`
+		Status TestStatus `json:"status"`
+	}
+
+	TestStatus string
+
+	// testMessage is a message in the machine-readable output of `terraform
+	// test -json`. Only those messages that contribute to the report are
+	// decoded.
+	testMessage struct {
+		Type    string `json:"type"`
+		TestRun *struct {
+			Path     string     `json:"path"`
+			Run      string     `json:"run"`
+			Progress string     `json:"progress"`
+			Status   TestStatus `json:"status"`
+		} `json:"test_run"`
+		TestSummary *struct {
+			Status  TestStatus `json:"status"`
+			Passed  int        `json:"passed"`
+			Failed  int        `json:"failed"`
+			Errored int        `json:"errored"`
+			Skipped int        `json:"skipped"`
+		} `json:"test_summary"`
+	}
+)
+
+// CompileTestReport compiles a report from the machine-readable output of
+// `terraform test -json`.
+func CompileTestReport(results []byte) (*TestReport, error) {
+	var report TestReport
+	scanner := bufio.NewScanner(bytes.NewReader(results))
+	// diagnostic messages can exceed the default max line length
+	scanner.Buffer(nil, 1024*1024)
+	for scanner.Scan() {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var msg testMessage
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+			return nil, fmt.Errorf("parsing test results: %w", err)
+		}
+		switch {
+		case msg.Type == "test_run" && msg.TestRun != nil:
+			// skip progress updates for run blocks that have yet to complete
+			if msg.TestRun.Progress != "" && msg.TestRun.Progress != "complete" {
+				continue
+			}
+			if msg.TestRun.Status == "" {
+				continue
+			}
+			report.Runs = append(report.Runs, TestRunResult{
+				File:   msg.TestRun.Path,
+				Name:   msg.TestRun.Run,
+				Status: msg.TestRun.Status,
+			})
+		case msg.Type == "test_summary" && msg.TestSummary != nil:
+			report.Status = msg.TestSummary.Status
+			report.Passed = msg.TestSummary.Passed
+			report.Failed = msg.TestSummary.Failed
+			report.Errored = msg.TestSummary.Errored
+			report.Skipped = msg.TestSummary.Skipped
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("parsing test results: %w", err)
+	}
+	if report.Status == "" {
+		return nil, ErrMissingTestSummary
+	}
+	return &report, nil
+}
+
+// Success determines whether all the tests passed.
+func (r *TestReport) Success() bool {
+	return r.Status == TestPass
+}
+
+func (r *TestReport) String() string {
+	parts := []string{
+		fmt.Sprintf("%d passed", r.Passed),
+		fmt.Sprintf("%d failed", r.Failed),
+	}
+	if r.Errored > 0 {
+		parts = append(parts, fmt.Sprintf("%d errored", r.Errored))
+	}
+	if r.Skipped > 0 {
+		parts = append(parts, fmt.Sprintf("%d skipped", r.Skipped))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (s TestStatus) String() string { return string(s) }
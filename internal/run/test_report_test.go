@@ -0,0 +1,35 @@
+package run
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompileTestReport(t *testing.T) {
+	results, err := os.ReadFile("./testdata/test.json")
+	require.NoError(t, err)
+
+	got, err := CompileTestReport(results)
+	require.NoError(t, err)
+
+	want := &TestReport{
+		Status: TestFail,
+		Passed: 1,
+		Failed: 1,
+		Runs: []TestRunResult{
+			{File: "main.tftest.hcl", Name: "setup", Status: TestPass},
+			{File: "main.tftest.hcl", Name: "verify", Status: TestFail},
+		},
+	}
+	assert.Equal(t, want, got)
+	assert.False(t, got.Success())
+	assert.Equal(t, "1 passed, 1 failed", got.String())
+}
+
+func TestCompileTestReport_MissingSummary(t *testing.T) {
+	_, err := CompileTestReport([]byte(`{"@message":"Terraform 1.6.0","type":"version"}`))
+	assert.ErrorIs(t, err, ErrMissingTestSummary)
+}
@@ -70,6 +70,7 @@ func (a *tfe) createRun(w http.ResponseWriter, r *http.Request) {
 		TargetAddrs:      params.TargetAddrs,
 		ReplaceAddrs:     params.ReplaceAddrs,
 		PlanOnly:         params.PlanOnly,
+		TestOnly:         params.TestOnly,
 		Source:           SourceAPI,
 		AllowEmptyApply:  params.AllowEmptyApply,
 		TerraformVersion: params.TerraformVersion,
@@ -152,7 +153,7 @@ func (a *tfe) listRuns(w http.ResponseWriter, r *http.Request) {
 
 func (a *tfe) getRunQueue(w http.ResponseWriter, r *http.Request) {
 	a.listRunsWithOptions(w, r, ListOptions{
-		Statuses: []Status{RunPlanQueued, RunApplyQueued},
+		Statuses: []Status{RunPlanQueued, RunApplyQueued, RunTestQueued},
 	})
 }
 
@@ -411,6 +412,7 @@ func (a *tfe) toRun(from *Run, ctx context.Context) (*types.Run, error) {
 		Permissions:            perms,
 		PlanOnly:               from.PlanOnly,
 		PositionInQueue:        0,
+		TestOnly:               from.TestOnly,
 		Refresh:                from.Refresh,
 		RefreshOnly:            from.RefreshOnly,
 		ReplaceAddrs:           from.ReplaceAddrs,
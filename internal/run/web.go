@@ -64,6 +64,7 @@ func (h *webHandlers) createRun(w http.ResponseWriter, r *http.Request) {
 	run, err := h.svc.CreateRun(r.Context(), params.WorkspaceID, CreateOptions{
 		IsDestroy: internal.Bool(params.Operation == DestroyAllOperation),
 		PlanOnly:  internal.Bool(params.Operation == PlanOnlyOperation),
+		TestOnly:  internal.Bool(params.Operation == TestOperation),
 		Source:    SourceUI,
 	})
 	if err != nil {
@@ -161,17 +162,24 @@ func (h *webHandlers) get(w http.ResponseWriter, r *http.Request) {
 		h.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	testLogs, err := h.svc.getLogs(r.Context(), run.ID, internal.TestPhase)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
 
 	h.Render("run_get.tmpl", w, struct {
 		workspace.WorkspacePage
 		Run       *Run
 		PlanLogs  internal.Chunk
 		ApplyLogs internal.Chunk
+		TestLogs  internal.Chunk
 	}{
 		WorkspacePage: workspace.NewPage(r, run.ID, ws),
 		Run:           run,
 		PlanLogs:      internal.Chunk{Data: planLogs},
 		ApplyLogs:     internal.Chunk{Data: applyLogs},
+		TestLogs:      internal.Chunk{Data: testLogs},
 	})
 }
 
@@ -283,6 +291,7 @@ func (h *webHandlers) retry(w http.ResponseWriter, r *http.Request) {
 		ConfigurationVersionID: &run.ConfigurationVersionID,
 		IsDestroy:              &run.IsDestroy,
 		PlanOnly:               &run.PlanOnly,
+		TestOnly:               &run.TestOnly,
 		Source:                 SourceUI,
 	})
 	if err != nil {
@@ -307,6 +316,7 @@ func (h *webHandlers) watch(w http.ResponseWriter, r *http.Request) {
 
 	events, err := h.svc.Watch(r.Context(), WatchOptions{
 		WorkspaceID: internal.String(params.WorkspaceID),
+		LastEventID: pubsub.LastEventID(r),
 	})
 	if err != nil {
 		h.Error(w, err.Error(), http.StatusInternalServerError)
@@ -361,10 +371,10 @@ func (h *webHandlers) watch(w http.ResponseWriter, r *http.Request) {
 			}
 			if event.Type == pubsub.CreatedEvent {
 				// newly created run is sent with "created" event type
-				pubsub.WriteSSEEvent(w, itemHTML.Bytes(), event.Type, false)
+				pubsub.WriteSSEEventWithID(w, event.ID, itemHTML.Bytes(), event.Type, false)
 			} else {
 				// updated run events target existing run items in page
-				pubsub.WriteSSEEvent(w, itemHTML.Bytes(), pubsub.EventType("run-item-"+run.ID), false)
+				pubsub.WriteSSEEventWithID(w, event.ID, itemHTML.Bytes(), pubsub.EventType("run-item-"+run.ID), false)
 			}
 			if params.Latest {
 				// also write a 'latest-run' event if the caller has requested
@@ -66,6 +66,26 @@ func TestWeb_GetHandler(t *testing.T) {
 	assert.Equal(t, 200, w.Code, "output: %s", w.Body.String())
 }
 
+func TestWeb_GetHandler_TestRun(t *testing.T) {
+	run := (&Run{ID: "run-123", WorkspaceID: "ws-1", TestOnly: true}).updateStatus(RunTested, nil)
+	run.Test.TestReport = &TestReport{
+		Status: TestPass,
+		Passed: 1,
+		Runs:   []TestRunResult{{File: "main.tftest.hcl", Name: "setup", Status: TestPass}},
+	}
+	h := newTestWebHandlers(t,
+		withWorkspace(&workspace.Workspace{ID: "ws-123"}),
+		withRuns(run),
+	)
+
+	r := httptest.NewRequest("GET", "/?run_id=run-123", nil)
+	w := httptest.NewRecorder()
+	h.get(w, r)
+	assert.Equal(t, 200, w.Code, "output: %s", w.Body.String())
+	assert.Contains(t, w.Body.String(), "main.tftest.hcl")
+	assert.NotContains(t, w.Body.String(), `id="plan"`)
+}
+
 func TestRuns_CancelHandler(t *testing.T) {
 	h := newTestWebHandlers(t, withRuns(&Run{ID: "run-1", WorkspaceID: "ws-1"}))
 
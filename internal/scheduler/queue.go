@@ -58,7 +58,8 @@ func (q *queue) handleEvent(ctx context.Context, event pubsub.Event) error {
 			}
 		}
 	case *otfrun.Run:
-		if payload.PlanOnly {
+		if payload.PlanOnly || payload.TestOnly {
+			// plan-only and test runs don't lock the workspace
 			if payload.Status == otfrun.RunPending {
 				// immediately enqueue onto global queue
 				_, err := q.EnqueuePlan(ctx, payload.ID)
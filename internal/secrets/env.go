@@ -0,0 +1,19 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"os"
+)
+
+// envProvider retrieves a secret from an environment variable, e.g.
+// env://DB_PASSWORD
+type envProvider struct{}
+
+func (p *envProvider) Resolve(ctx context.Context, ref Reference) (string, error) {
+	value, ok := os.LookupEnv(ref.Path)
+	if !ok {
+		return "", fmt.Errorf("environment variable not found: %s", ref.Path)
+	}
+	return value, nil
+}
@@ -0,0 +1,47 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// fileProvider retrieves a secret from a file, e.g. file:///run/secrets/db.
+// If a key is specified then the file is expected to contain a JSON object
+// and the secret is the value of the key, e.g. file:///run/secrets/db#password
+type fileProvider struct{}
+
+func (p *fileProvider) Resolve(ctx context.Context, ref Reference) (string, error) {
+	contents, err := os.ReadFile(ref.Path)
+	if err != nil {
+		return "", err
+	}
+	if ref.Key == "" {
+		// strip trailing newline typically added by editors
+		return strings.TrimRight(string(contents), "\r\n"), nil
+	}
+	var values map[string]any
+	if err := json.Unmarshal(contents, &values); err != nil {
+		return "", fmt.Errorf("decoding JSON: %w", err)
+	}
+	return lookupKey(values, ref.Key)
+}
+
+// lookupKey retrieves the value of a key from a secret containing several
+// values. A value that is not a string is encoded as JSON.
+func lookupKey(values map[string]any, key string) (string, error) {
+	value, ok := values[key]
+	if !ok {
+		return "", fmt.Errorf("key not found: %s", key)
+	}
+	if s, ok := value.(string); ok {
+		return s, nil
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
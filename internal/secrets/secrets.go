@@ -0,0 +1,127 @@
+// Package secrets resolves references to secrets held in external secret
+// stores, permitting a variable to hold a reference to a secret rather than
+// the secret itself.
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	// VaultScheme references a secret in HashiCorp Vault, e.g.
+	// vault://secret/data/app#password
+	VaultScheme = "vault"
+	// FileScheme references a secret in a file on the agent's filesystem,
+	// e.g. file:///run/secrets/password
+	FileScheme = "file"
+	// EnvScheme references a secret in an environment variable of the
+	// agent, e.g. env://DB_PASSWORD
+	EnvScheme = "env"
+)
+
+// Schemes are the schemes of supported secret references.
+var Schemes = []string{VaultScheme, FileScheme, EnvScheme}
+
+var (
+	ErrProviderNotEnabled = errors.New("secret provider not enabled")
+	ErrInvalidReference   = errors.New("invalid secret reference")
+)
+
+type (
+	// Reference is a reference to a secret in an external secret store, in the
+	// form <scheme>://<path>[#<key>].
+	Reference struct {
+		Scheme string
+		// Path to secret. Its meaning depends upon the scheme.
+		Path string
+		// Key of a value within a secret containing several values. Optional
+		// for some providers.
+		Key string
+	}
+
+	// Provider retrieves secrets from a secret store.
+	Provider interface {
+		Resolve(ctx context.Context, ref Reference) (string, error)
+	}
+
+	// Config configures the resolution of secret references.
+	Config struct {
+		// Enabled is the list of schemes of providers to enable. A reference
+		// to a secret with a scheme of a provider that is not enabled fails to
+		// resolve.
+		Enabled []string
+		Vault   VaultConfig
+	}
+
+	// Resolver resolves secret references using the enabled providers.
+	Resolver struct {
+		providers map[string]Provider
+	}
+)
+
+// ParseReference parses a secret reference. If value is not a secret
+// reference, i.e. it does not begin with the scheme of a supported provider,
+// then false is returned.
+func ParseReference(value string) (Reference, bool) {
+	scheme, rest, ok := strings.Cut(value, "://")
+	if !ok || !slices.Contains(Schemes, scheme) {
+		return Reference{}, false
+	}
+	path, key, _ := strings.Cut(rest, "#")
+	return Reference{Scheme: scheme, Path: path, Key: key}, true
+}
+
+func (r Reference) String() string {
+	s := r.Scheme + "://" + r.Path
+	if r.Key != "" {
+		s += "#" + r.Key
+	}
+	return s
+}
+
+// NewResolver constructs a resolver with the providers enabled in the config.
+func NewResolver(cfg Config) (*Resolver, error) {
+	r := &Resolver{providers: make(map[string]Provider)}
+	for _, scheme := range cfg.Enabled {
+		switch scheme {
+		case VaultScheme:
+			r.providers[scheme] = newVaultProvider(cfg.Vault)
+		case FileScheme:
+			r.providers[scheme] = &fileProvider{}
+		case EnvScheme:
+			r.providers[scheme] = &envProvider{}
+		default:
+			return nil, fmt.Errorf("unknown secret provider: %s", scheme)
+		}
+	}
+	return r, nil
+}
+
+// Register registers a provider for the given scheme, overriding any existing
+// provider.
+func (r *Resolver) Register(scheme string, provider Provider) {
+	r.providers[scheme] = provider
+}
+
+// Resolve resolves the secret reference in value. An error is returned if
+// value is not a secret reference. Errors mention only the reference and never
+// the secret.
+func (r *Resolver) Resolve(ctx context.Context, value string) (string, error) {
+	ref, ok := ParseReference(value)
+	if !ok {
+		return "", ErrInvalidReference
+	}
+	provider, ok := r.providers[ref.Scheme]
+	if !ok {
+		return "", fmt.Errorf("resolving %s: %w", ref, ErrProviderNotEnabled)
+	}
+	secret, err := provider.Resolve(ctx, ref)
+	if err != nil {
+		return "", fmt.Errorf("resolving %s: %w", ref, err)
+	}
+	return secret, nil
+}
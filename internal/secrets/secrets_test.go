@@ -0,0 +1,186 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseReference(t *testing.T) {
+	tests := []struct {
+		value string
+		want  Reference
+		ok    bool
+	}{
+		{"vault://secret/data/app#password", Reference{Scheme: "vault", Path: "secret/data/app", Key: "password"}, true},
+		{"file:///run/secrets/db", Reference{Scheme: "file", Path: "/run/secrets/db"}, true},
+		{"env://DB_PASSWORD", Reference{Scheme: "env", Path: "DB_PASSWORD"}, true},
+		{"https://example.com", Reference{}, false},
+		{"plain value", Reference{}, false},
+		{"", Reference{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, ok := ParseReference(tt.value)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+			if ok {
+				assert.Equal(t, tt.value, got.String())
+			}
+		})
+	}
+}
+
+func TestResolver(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("not a reference", func(t *testing.T) {
+		r, err := NewResolver(Config{})
+		require.NoError(t, err)
+		_, err = r.Resolve(ctx, "https://example.com")
+		assert.ErrorIs(t, err, ErrInvalidReference)
+	})
+
+	t.Run("provider not enabled", func(t *testing.T) {
+		r, err := NewResolver(Config{})
+		require.NoError(t, err)
+		_, err = r.Resolve(ctx, "env://HOME")
+		assert.ErrorIs(t, err, ErrProviderNotEnabled)
+	})
+
+	t.Run("unknown provider", func(t *testing.T) {
+		_, err := NewResolver(Config{Enabled: []string{"aws"}})
+		assert.Error(t, err)
+	})
+
+	t.Run("env", func(t *testing.T) {
+		t.Setenv("OTF_TEST_SECRET", "s3cr3t")
+		r, err := NewResolver(Config{Enabled: []string{EnvScheme}})
+		require.NoError(t, err)
+
+		got, err := r.Resolve(ctx, "env://OTF_TEST_SECRET")
+		require.NoError(t, err)
+		assert.Equal(t, "s3cr3t", got)
+
+		_, err = r.Resolve(ctx, "env://OTF_TEST_MISSING")
+		assert.Error(t, err)
+	})
+
+	t.Run("file", func(t *testing.T) {
+		dir := t.TempDir()
+		plain := filepath.Join(dir, "password")
+		require.NoError(t, os.WriteFile(plain, []byte("s3cr3t\n"), 0o600))
+		structured := filepath.Join(dir, "db.json")
+		require.NoError(t, os.WriteFile(structured, []byte(`{"password":"s3cr3t","port":5432}`), 0o600))
+
+		r, err := NewResolver(Config{Enabled: []string{FileScheme}})
+		require.NoError(t, err)
+
+		got, err := r.Resolve(ctx, "file://"+plain)
+		require.NoError(t, err)
+		assert.Equal(t, "s3cr3t", got)
+
+		got, err = r.Resolve(ctx, "file://"+structured+"#password")
+		require.NoError(t, err)
+		assert.Equal(t, "s3cr3t", got)
+
+		got, err = r.Resolve(ctx, "file://"+structured+"#port")
+		require.NoError(t, err)
+		assert.Equal(t, "5432", got)
+
+		_, err = r.Resolve(ctx, "file://"+structured+"#username")
+		assert.Error(t, err)
+	})
+}
+
+func TestVault(t *testing.T) {
+	ctx := context.Background()
+	srv := newFakeVault(t, "root-token", map[string]any{
+		// kv version 1
+		"/v1/kv/app": map[string]any{
+			"password": "v1-secret",
+		},
+		// kv version 2
+		"/v1/secret/data/app": map[string]any{
+			"data":     map[string]any{"password": "v2-secret"},
+			"metadata": map[string]any{"version": 3},
+		},
+	})
+
+	r, err := NewResolver(Config{
+		Enabled: []string{VaultScheme},
+		Vault:   VaultConfig{Address: srv.URL, Token: "root-token"},
+	})
+	require.NoError(t, err)
+
+	t.Run("kv v1", func(t *testing.T) {
+		got, err := r.Resolve(ctx, "vault://kv/app#password")
+		require.NoError(t, err)
+		assert.Equal(t, "v1-secret", got)
+	})
+
+	t.Run("kv v2", func(t *testing.T) {
+		got, err := r.Resolve(ctx, "vault://secret/data/app#password")
+		require.NoError(t, err)
+		assert.Equal(t, "v2-secret", got)
+	})
+
+	t.Run("missing key", func(t *testing.T) {
+		_, err := r.Resolve(ctx, "vault://secret/data/app")
+		assert.Error(t, err)
+	})
+
+	t.Run("missing secret", func(t *testing.T) {
+		_, err := r.Resolve(ctx, "vault://secret/data/missing#password")
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		r, err := NewResolver(Config{
+			Enabled: []string{VaultScheme},
+			Vault:   VaultConfig{Address: srv.URL, Token: "wrong-token"},
+		})
+		require.NoError(t, err)
+		_, err = r.Resolve(ctx, "vault://kv/app#password")
+		assert.ErrorContains(t, err, "permission denied")
+	})
+
+	t.Run("address from environment", func(t *testing.T) {
+		t.Setenv("VAULT_ADDR", srv.URL)
+		t.Setenv("VAULT_TOKEN", "root-token")
+		r, err := NewResolver(Config{Enabled: []string{VaultScheme}})
+		require.NoError(t, err)
+		got, err := r.Resolve(ctx, "vault://kv/app#password")
+		require.NoError(t, err)
+		assert.Equal(t, "v1-secret", got)
+	})
+}
+
+// newFakeVault starts a stand-in for the read API of a vault dev server,
+// serving the given secrets keyed by API path.
+func newFakeVault(t *testing.T, token string, secrets map[string]any) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Header.Get("X-Vault-Token") != token {
+			w.WriteHeader(http.StatusForbidden)
+			json.NewEncoder(w).Encode(map[string]any{"errors": []string{"permission denied"}})
+			return
+		}
+		data, ok := secrets[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]any{"errors": []string{}})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"data": data})
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
@@ -0,0 +1,99 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+type (
+	// VaultConfig configures access to HashiCorp Vault.
+	VaultConfig struct {
+		// Address of Vault server. Defaults to the value of VAULT_ADDR.
+		Address string
+		// Token for authenticating with Vault. Defaults to the value of
+		// VAULT_TOKEN.
+		Token string
+		// Namespace is the Vault Enterprise namespace. Defaults to the value
+		// of VAULT_NAMESPACE.
+		Namespace string
+	}
+
+	// vaultProvider retrieves a secret from HashiCorp Vault, e.g.
+	// vault://secret/data/app#password
+	//
+	// The path is the API path of the secret, and the key is the key of the
+	// value within the secret. Both versions of the key-value secrets engine
+	// are supported.
+	vaultProvider struct {
+		VaultConfig
+
+		client *http.Client
+	}
+
+	vaultResponse struct {
+		Data   map[string]any `json:"data"`
+		Errors []string       `json:"errors"`
+	}
+)
+
+func newVaultProvider(cfg VaultConfig) *vaultProvider {
+	if cfg.Address == "" {
+		cfg.Address = os.Getenv("VAULT_ADDR")
+	}
+	if cfg.Token == "" {
+		cfg.Token = os.Getenv("VAULT_TOKEN")
+	}
+	if cfg.Namespace == "" {
+		cfg.Namespace = os.Getenv("VAULT_NAMESPACE")
+	}
+	return &vaultProvider{VaultConfig: cfg, client: &http.Client{}}
+}
+
+func (p *vaultProvider) Resolve(ctx context.Context, ref Reference) (string, error) {
+	if p.Address == "" {
+		return "", errors.New("vault address not configured")
+	}
+	if ref.Key == "" {
+		return "", errors.New("missing key: reference must be in the form vault://<path>#<key>")
+	}
+	u := strings.TrimRight(p.Address, "/") + "/v1/" + strings.TrimLeft(ref.Path, "/")
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", p.Token)
+	if p.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", p.Namespace)
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var body vaultResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil && resp.StatusCode == http.StatusOK {
+		return "", fmt.Errorf("decoding vault response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		if len(body.Errors) > 0 {
+			return "", fmt.Errorf("vault responded with %s: %s", resp.Status, strings.Join(body.Errors, ", "))
+		}
+		return "", fmt.Errorf("vault responded with %s", resp.Status)
+	}
+
+	values := body.Data
+	// version 2 of the KV secrets engine nests the secret's values alongside
+	// its metadata.
+	if nested, ok := values["data"].(map[string]any); ok {
+		if _, ok := values["metadata"]; ok {
+			values = nested
+		}
+	}
+	return lookupKey(values, ref.Key)
+}
@@ -0,0 +1,22 @@
+-- +goose Up
+INSERT INTO workspace_roles (role) VALUES ('custom');
+
+-- custom permissions are only populated for the custom role
+ALTER TABLE workspace_permissions
+    ADD COLUMN runs TEXT,
+    ADD COLUMN variables TEXT,
+    ADD COLUMN state_versions TEXT,
+    ADD COLUMN workspace_locking BOOL DEFAULT false NOT NULL,
+    ADD COLUMN run_tasks BOOL DEFAULT false NOT NULL;
+
+-- +goose Down
+DELETE FROM workspace_permissions WHERE role = 'custom';
+
+ALTER TABLE workspace_permissions
+    DROP COLUMN runs,
+    DROP COLUMN variables,
+    DROP COLUMN state_versions,
+    DROP COLUMN workspace_locking,
+    DROP COLUMN run_tasks;
+
+DELETE FROM workspace_roles WHERE role = 'custom';
@@ -0,0 +1,31 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS projects (
+    project_id TEXT,
+    created_at TIMESTAMPTZ NOT NULL,
+    name TEXT NOT NULL,
+    organization_name TEXT REFERENCES organizations (name) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+                      UNIQUE (organization_name, name),
+                      PRIMARY KEY (project_id)
+);
+
+ALTER TABLE workspaces
+    ADD COLUMN project_id TEXT REFERENCES projects (project_id) ON UPDATE CASCADE ON DELETE SET NULL;
+
+CREATE TABLE IF NOT EXISTS project_permissions (
+    project_id TEXT REFERENCES projects (project_id) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    team_id TEXT REFERENCES teams (team_id) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    role TEXT REFERENCES workspace_roles (role) ON UPDATE CASCADE NOT NULL,
+    UNIQUE (project_id, team_id)
+);
+
+CREATE TABLE IF NOT EXISTS variable_set_projects (
+    variable_set_id TEXT REFERENCES variable_sets (variable_set_id) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    project_id TEXT REFERENCES projects (project_id) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    UNIQUE (variable_set_id, project_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS variable_set_projects;
+DROP TABLE IF EXISTS project_permissions;
+ALTER TABLE workspaces DROP COLUMN project_id;
+DROP TABLE IF EXISTS projects;
@@ -0,0 +1,207 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- events is a durable log of database changes, permitting subscribers to
+-- replay events they have missed.
+CREATE TABLE IF NOT EXISTS events (
+    event_id BIGSERIAL,
+    time TIMESTAMPTZ NOT NULL DEFAULT now(),
+    table_name TEXT NOT NULL,
+    action TEXT NOT NULL,
+    record_id TEXT NOT NULL,
+                      PRIMARY KEY (event_id)
+);
+
+CREATE INDEX IF NOT EXISTS events_time_idx ON events (time);
+
+-- log_event appends an event to the log and notifies listeners, including the
+-- event ID in the notification.
+CREATE OR REPLACE FUNCTION log_event(tbl TEXT, action TEXT, id TEXT) RETURNS VOID AS $$
+DECLARE
+    eid BIGINT;
+    notification JSON;
+BEGIN
+    INSERT INTO events (table_name, action, record_id)
+    VALUES (tbl, action, id)
+    RETURNING event_id INTO eid;
+
+    notification = json_build_object(
+                      'event_id', eid,
+                      'table', tbl,
+                      'action', action,
+                      'id', id);
+    PERFORM pg_notify('events', notification::text);
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION organizations_notify_event() RETURNS TRIGGER AS $$
+DECLARE
+    record RECORD;
+BEGIN
+    IF (TG_OP = 'DELETE') THEN
+        record = OLD;
+    ELSE
+        record = NEW;
+    END IF;
+    PERFORM log_event(TG_TABLE_NAME, TG_OP, record.organization_id::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION workspaces_notify_event() RETURNS TRIGGER AS $$
+DECLARE
+    record RECORD;
+BEGIN
+    IF (TG_OP = 'DELETE') THEN
+        record = OLD;
+    ELSE
+        record = NEW;
+    END IF;
+    PERFORM log_event(TG_TABLE_NAME, TG_OP, record.workspace_id::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION runs_notify_event() RETURNS TRIGGER AS $$
+DECLARE
+    record RECORD;
+BEGIN
+    IF (TG_OP = 'DELETE') THEN
+        record = OLD;
+    ELSE
+        record = NEW;
+    END IF;
+    PERFORM log_event(TG_TABLE_NAME, TG_OP, record.run_id::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION logs_notify_event() RETURNS TRIGGER AS $$
+DECLARE
+    record RECORD;
+BEGIN
+    IF (TG_OP = 'DELETE') THEN
+        record = OLD;
+    ELSE
+        record = NEW;
+    END IF;
+    PERFORM log_event(TG_TABLE_NAME, TG_OP, record.chunk_id::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION notification_configurations_notify_event() RETURNS TRIGGER AS $$
+DECLARE
+    record RECORD;
+BEGIN
+    IF (TG_OP = 'DELETE') THEN
+        record = OLD;
+    ELSE
+        record = NEW;
+    END IF;
+    PERFORM log_event(TG_TABLE_NAME, TG_OP, record.notification_configuration_id::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION organizations_notify_event() RETURNS TRIGGER AS $$
+DECLARE
+    record RECORD;
+    notification JSON;
+BEGIN
+    IF (TG_OP = 'DELETE') THEN
+        record = OLD;
+    ELSE
+        record = NEW;
+    END IF;
+    notification = json_build_object(
+                      'table',TG_TABLE_NAME,
+                      'action', TG_OP,
+                      'id', record.organization_id);
+    PERFORM pg_notify('events', notification::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION workspaces_notify_event() RETURNS TRIGGER AS $$
+DECLARE
+    record RECORD;
+    notification JSON;
+BEGIN
+    IF (TG_OP = 'DELETE') THEN
+        record = OLD;
+    ELSE
+        record = NEW;
+    END IF;
+    notification = json_build_object(
+                      'table',TG_TABLE_NAME,
+                      'action', TG_OP,
+                      'id', record.workspace_id);
+    PERFORM pg_notify('events', notification::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION runs_notify_event() RETURNS TRIGGER AS $$
+DECLARE
+    record RECORD;
+    notification JSON;
+BEGIN
+    IF (TG_OP = 'DELETE') THEN
+        record = OLD;
+    ELSE
+        record = NEW;
+    END IF;
+    notification = json_build_object(
+                      'table',TG_TABLE_NAME,
+                      'action', TG_OP,
+                      'id', record.run_id);
+    PERFORM pg_notify('events', notification::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION logs_notify_event() RETURNS TRIGGER AS $$
+DECLARE
+    record RECORD;
+    notification JSON;
+BEGIN
+    IF (TG_OP = 'DELETE') THEN
+        record = OLD;
+    ELSE
+        record = NEW;
+    END IF;
+    notification = json_build_object(
+                      'table',TG_TABLE_NAME,
+                      'action', TG_OP,
+                      'id', record.chunk_id::text);
+    PERFORM pg_notify('events', notification::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION notification_configurations_notify_event() RETURNS TRIGGER AS $$
+DECLARE
+    record RECORD;
+    notification JSON;
+BEGIN
+    IF (TG_OP = 'DELETE') THEN
+        record = OLD;
+    ELSE
+        record = NEW;
+    END IF;
+    notification = json_build_object(
+                      'table',TG_TABLE_NAME,
+                      'action', TG_OP,
+                      'id', record.notification_configuration_id);
+    PERFORM pg_notify('events', notification::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP FUNCTION IF EXISTS log_event;
+DROP TABLE IF EXISTS events;
+-- +goose StatementEnd
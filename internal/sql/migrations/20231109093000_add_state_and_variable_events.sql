@@ -0,0 +1,67 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE OR REPLACE FUNCTION state_versions_notify_event() RETURNS TRIGGER AS $$
+DECLARE
+    record RECORD;
+BEGIN
+    IF (TG_OP = 'DELETE') THEN
+        record = OLD;
+    ELSE
+        record = NEW;
+    END IF;
+    PERFORM log_event(TG_TABLE_NAME, TG_OP, record.state_version_id::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- workspace variable events identify both the workspace and the variable,
+-- because the variable no longer exists once it has been deleted.
+CREATE OR REPLACE FUNCTION workspace_variables_notify_event() RETURNS TRIGGER AS $$
+DECLARE
+    record RECORD;
+BEGIN
+    IF (TG_OP = 'DELETE') THEN
+        record = OLD;
+    ELSE
+        record = NEW;
+    END IF;
+    PERFORM log_event(TG_TABLE_NAME, TG_OP, record.workspace_id::text || ':' || record.variable_id::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- an update to a variable belonging to a workspace is logged as an update to
+-- the workspace variable; updates to variables belonging to variable sets are
+-- not logged.
+CREATE OR REPLACE FUNCTION variables_notify_event() RETURNS TRIGGER AS $$
+DECLARE
+    wv RECORD;
+BEGIN
+    FOR wv IN SELECT workspace_id, variable_id FROM workspace_variables WHERE variable_id = NEW.variable_id LOOP
+        PERFORM log_event('workspace_variables', TG_OP, wv.workspace_id::text || ':' || wv.variable_id::text);
+    END LOOP;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER notify_event
+AFTER INSERT OR UPDATE OR DELETE ON state_versions
+    FOR EACH ROW EXECUTE PROCEDURE state_versions_notify_event();
+
+CREATE TRIGGER notify_event
+AFTER INSERT OR DELETE ON workspace_variables
+    FOR EACH ROW EXECUTE PROCEDURE workspace_variables_notify_event();
+
+CREATE TRIGGER notify_event
+AFTER UPDATE ON variables
+    FOR EACH ROW EXECUTE PROCEDURE variables_notify_event();
+-- +goose StatementEnd
+
+-- +goose Down
+DROP TRIGGER IF EXISTS notify_event ON variables;
+DROP TRIGGER IF EXISTS notify_event ON workspace_variables;
+DROP TRIGGER IF EXISTS notify_event ON state_versions;
+DROP FUNCTION IF EXISTS variables_notify_event;
+DROP FUNCTION IF EXISTS workspace_variables_notify_event;
+DROP FUNCTION IF EXISTS state_versions_notify_event;
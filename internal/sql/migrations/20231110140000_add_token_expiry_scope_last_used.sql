@@ -0,0 +1,39 @@
+-- +goose Up
+ALTER TABLE tokens
+    ADD COLUMN expiry TIMESTAMPTZ,
+    ADD COLUMN scope_actions TEXT[],
+    ADD COLUMN scope_workspace_ids TEXT[],
+    ADD COLUMN last_used_at TIMESTAMPTZ,
+    ADD COLUMN last_used_ip TEXT;
+
+ALTER TABLE team_tokens
+    ADD COLUMN scope_actions TEXT[],
+    ADD COLUMN scope_workspace_ids TEXT[],
+    ADD COLUMN last_used_at TIMESTAMPTZ,
+    ADD COLUMN last_used_ip TEXT;
+
+ALTER TABLE organization_tokens
+    ADD COLUMN scope_actions TEXT[],
+    ADD COLUMN scope_workspace_ids TEXT[],
+    ADD COLUMN last_used_at TIMESTAMPTZ,
+    ADD COLUMN last_used_ip TEXT;
+
+-- +goose Down
+ALTER TABLE organization_tokens
+    DROP COLUMN last_used_ip,
+    DROP COLUMN last_used_at,
+    DROP COLUMN scope_workspace_ids,
+    DROP COLUMN scope_actions;
+
+ALTER TABLE team_tokens
+    DROP COLUMN last_used_ip,
+    DROP COLUMN last_used_at,
+    DROP COLUMN scope_workspace_ids,
+    DROP COLUMN scope_actions;
+
+ALTER TABLE tokens
+    DROP COLUMN last_used_ip,
+    DROP COLUMN last_used_at,
+    DROP COLUMN scope_workspace_ids,
+    DROP COLUMN scope_actions,
+    DROP COLUMN expiry;
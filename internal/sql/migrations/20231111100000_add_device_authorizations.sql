@@ -0,0 +1,16 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS device_authorizations (
+    device_code_hash TEXT NOT NULL,
+    user_code        TEXT NOT NULL,
+    client_id        TEXT NOT NULL,
+    created_at       TIMESTAMPTZ NOT NULL,
+    expiry           TIMESTAMPTZ NOT NULL,
+    status           TEXT NOT NULL,
+    username         TEXT REFERENCES users (username) ON UPDATE CASCADE ON DELETE CASCADE,
+    last_polled_at   TIMESTAMPTZ,
+                     PRIMARY KEY (device_code_hash),
+                     UNIQUE (user_code)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS device_authorizations;
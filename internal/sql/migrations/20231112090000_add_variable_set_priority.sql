@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE variable_sets ADD COLUMN priority BOOL DEFAULT false NOT NULL;
+
+-- +goose Down
+ALTER TABLE variable_sets DROP COLUMN priority;
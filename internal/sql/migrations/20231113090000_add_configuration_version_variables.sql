@@ -0,0 +1,14 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS configuration_version_variables (
+    configuration_version_id TEXT REFERENCES configuration_versions ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    directory TEXT NOT NULL,
+    name TEXT NOT NULL,
+    type TEXT NOT NULL,
+    default_value TEXT,
+    description TEXT NOT NULL,
+    sensitive BOOL NOT NULL,
+    UNIQUE (configuration_version_id, directory, name)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS configuration_version_variables;
@@ -0,0 +1,51 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS registry_gpg_keys (
+    gpg_key_id        TEXT NOT NULL,
+    organization_name TEXT REFERENCES organizations (name) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    key_id            TEXT NOT NULL,
+    ascii_armor       TEXT NOT NULL,
+    created_at        TIMESTAMPTZ NOT NULL,
+                      PRIMARY KEY (gpg_key_id),
+                      UNIQUE (organization_name, key_id)
+);
+
+CREATE TABLE IF NOT EXISTS registry_providers (
+    registry_provider_id TEXT NOT NULL,
+    organization_name    TEXT REFERENCES organizations (name) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    name                 TEXT NOT NULL,
+    created_at           TIMESTAMPTZ NOT NULL,
+                         PRIMARY KEY (registry_provider_id),
+                         UNIQUE (organization_name, name)
+);
+
+CREATE TABLE IF NOT EXISTS registry_provider_versions (
+    registry_provider_version_id TEXT NOT NULL,
+    registry_provider_id         TEXT REFERENCES registry_providers ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    version                      TEXT NOT NULL,
+    protocols                    TEXT[] NOT NULL,
+    gpg_key_id                   TEXT REFERENCES registry_gpg_keys ON UPDATE CASCADE NOT NULL,
+    shasums                      BYTEA NOT NULL,
+    shasums_signature            BYTEA NOT NULL,
+    created_at                   TIMESTAMPTZ NOT NULL,
+                                 PRIMARY KEY (registry_provider_version_id),
+                                 UNIQUE (registry_provider_id, version)
+);
+
+CREATE TABLE IF NOT EXISTS registry_provider_platforms (
+    registry_provider_platform_id TEXT NOT NULL,
+    registry_provider_version_id  TEXT REFERENCES registry_provider_versions ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    os                            TEXT NOT NULL,
+    arch                          TEXT NOT NULL,
+    filename                      TEXT NOT NULL,
+    shasum                        TEXT NOT NULL,
+    binary                        BYTEA NOT NULL,
+    created_at                    TIMESTAMPTZ NOT NULL,
+                                  PRIMARY KEY (registry_provider_platform_id),
+                                  UNIQUE (registry_provider_version_id, os, arch)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS registry_provider_platforms;
+DROP TABLE IF EXISTS registry_provider_versions;
+DROP TABLE IF EXISTS registry_providers;
+DROP TABLE IF EXISTS registry_gpg_keys;
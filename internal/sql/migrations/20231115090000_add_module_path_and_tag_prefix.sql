@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE modules
+    ADD COLUMN path TEXT DEFAULT '' NOT NULL,
+    ADD COLUMN tag_prefix TEXT DEFAULT '' NOT NULL;
+
+-- +goose Down
+ALTER TABLE modules
+    DROP COLUMN path,
+    DROP COLUMN tag_prefix;
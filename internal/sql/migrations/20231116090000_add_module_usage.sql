@@ -0,0 +1,13 @@
+-- +goose Up
+ALTER TABLE module_versions ADD COLUMN downloads INTEGER DEFAULT 0 NOT NULL;
+
+CREATE TABLE IF NOT EXISTS module_version_usages (
+    module_version_id TEXT REFERENCES module_versions ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    workspace_id      TEXT REFERENCES workspaces ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    updated_at        TIMESTAMPTZ NOT NULL,
+                      PRIMARY KEY (module_version_id, workspace_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS module_version_usages;
+ALTER TABLE module_versions DROP COLUMN downloads;
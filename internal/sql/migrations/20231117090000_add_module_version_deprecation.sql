@@ -0,0 +1,13 @@
+-- +goose Up
+ALTER TABLE module_versions
+    ADD COLUMN deprecated BOOL DEFAULT false NOT NULL,
+    ADD COLUMN deprecation_reason TEXT DEFAULT '' NOT NULL,
+    ADD COLUMN replacement_version TEXT DEFAULT '' NOT NULL,
+    ADD COLUMN yanked BOOL DEFAULT false NOT NULL;
+
+-- +goose Down
+ALTER TABLE module_versions
+    DROP COLUMN deprecated,
+    DROP COLUMN deprecation_reason,
+    DROP COLUMN replacement_version,
+    DROP COLUMN yanked;
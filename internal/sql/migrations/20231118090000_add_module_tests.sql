@@ -0,0 +1,24 @@
+-- +goose Up
+ALTER TABLE modules ADD COLUMN run_tests BOOL DEFAULT false NOT NULL;
+
+INSERT INTO module_version_statuses (status) VALUES
+	('test_pending'),
+	('testing'),
+	('test_failed');
+
+CREATE TABLE IF NOT EXISTS module_version_tests (
+    module_version_id TEXT REFERENCES module_versions ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    output            BYTEA DEFAULT ''::bytea NOT NULL,
+    started_at        TIMESTAMPTZ NOT NULL,
+    finished_at       TIMESTAMPTZ,
+                      PRIMARY KEY (module_version_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS module_version_tests;
+UPDATE module_versions
+SET status = 'pending'
+WHERE status IN ('test_pending', 'testing', 'test_failed');
+DELETE FROM module_version_statuses
+WHERE status IN ('test_pending', 'testing', 'test_failed');
+ALTER TABLE modules DROP COLUMN run_tests;
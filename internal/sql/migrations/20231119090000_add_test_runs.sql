@@ -0,0 +1,33 @@
+-- +goose Up
+ALTER TABLE runs ADD COLUMN test_only BOOL DEFAULT false NOT NULL;
+ALTER TABLE workspaces ADD COLUMN test_pull_requests BOOL DEFAULT false NOT NULL;
+
+INSERT INTO phases (phase) VALUES ('test');
+
+INSERT INTO run_statuses (status) VALUES
+	('test_queued'),
+	('testing'),
+	('tested');
+
+CREATE TABLE IF NOT EXISTS tests (
+    run_id      TEXT REFERENCES runs ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    status      TEXT REFERENCES phase_statuses NOT NULL,
+    report      BYTEA,
+                PRIMARY KEY (run_id)
+);
+
+-- existing runs never test
+INSERT INTO tests (run_id, status)
+SELECT run_id, 'unreachable'
+FROM runs;
+
+-- +goose Down
+DELETE FROM runs WHERE test_only;
+DROP TABLE IF EXISTS tests;
+DELETE FROM logs WHERE phase = 'test';
+DELETE FROM phase_status_timestamps WHERE phase = 'test';
+DELETE FROM phases WHERE phase = 'test';
+DELETE FROM run_statuses
+WHERE status IN ('test_queued', 'testing', 'tested');
+ALTER TABLE workspaces DROP COLUMN test_pull_requests;
+ALTER TABLE runs DROP COLUMN test_only;
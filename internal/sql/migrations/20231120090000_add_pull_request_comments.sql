@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE workspaces ADD COLUMN comment_pull_requests BOOL DEFAULT false NOT NULL;
+
+-- +goose Down
+ALTER TABLE workspaces DROP COLUMN comment_pull_requests;
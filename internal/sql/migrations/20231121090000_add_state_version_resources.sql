@@ -0,0 +1,21 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS state_version_resources (
+    state_version_id TEXT REFERENCES state_versions ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    address TEXT NOT NULL,
+    mode TEXT NOT NULL,
+    type TEXT NOT NULL,
+    provider TEXT NOT NULL,
+    module TEXT NOT NULL,
+    attribute_paths TEXT[] NOT NULL,
+    attribute_values TEXT[] NOT NULL,
+    PRIMARY KEY (state_version_id, address)
+);
+CREATE INDEX IF NOT EXISTS state_version_resources_type_idx ON state_version_resources (type);
+CREATE INDEX IF NOT EXISTS state_version_resources_attribute_values_idx ON state_version_resources USING GIN (attribute_values);
+
+-- state versions created before this migration are indexed on demand.
+ALTER TABLE state_versions ADD COLUMN resources_indexed BOOL DEFAULT false NOT NULL;
+
+-- +goose Down
+ALTER TABLE state_versions DROP COLUMN resources_indexed;
+DROP TABLE IF EXISTS state_version_resources;
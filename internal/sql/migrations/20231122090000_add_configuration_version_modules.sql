@@ -0,0 +1,11 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS configuration_version_modules (
+    configuration_version_id TEXT REFERENCES configuration_versions ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    name TEXT NOT NULL,
+    source TEXT NOT NULL,
+    version TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS configuration_version_modules_configuration_version_id_idx ON configuration_version_modules (configuration_version_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS configuration_version_modules;
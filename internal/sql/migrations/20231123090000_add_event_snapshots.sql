@@ -0,0 +1,124 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- snapshot records the state of the changed record at the time of the event,
+-- along with its previous state for updates, permitting subscribers replaying
+-- events to determine what changed even though the record may have since
+-- changed again.
+ALTER TABLE events ADD COLUMN snapshot JSONB;
+
+DROP FUNCTION log_event(TEXT, TEXT, TEXT);
+
+CREATE OR REPLACE FUNCTION log_event(tbl TEXT, action TEXT, id TEXT, snapshot JSONB DEFAULT NULL) RETURNS VOID AS $$
+DECLARE
+    eid BIGINT;
+    etime TIMESTAMPTZ;
+    notification JSON;
+BEGIN
+    INSERT INTO events (table_name, action, record_id, snapshot)
+    VALUES (tbl, action, id, snapshot)
+    RETURNING event_id, time INTO eid, etime;
+
+    notification = json_build_object(
+                      'event_id', eid,
+                      'time', etime,
+                      'table', tbl,
+                      'action', action,
+                      'id', id,
+                      'snapshot', snapshot);
+    PERFORM pg_notify('events', notification::text);
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION workspaces_notify_event() RETURNS TRIGGER AS $$
+DECLARE
+    snapshot JSONB;
+BEGIN
+    IF (TG_OP = 'DELETE') THEN
+        PERFORM log_event(TG_TABLE_NAME, TG_OP, OLD.workspace_id::text);
+        RETURN NULL;
+    END IF;
+    snapshot = jsonb_build_object(
+                  'locked', NEW.lock_username IS NOT NULL OR NEW.lock_run_id IS NOT NULL);
+    IF (TG_OP = 'UPDATE') THEN
+        snapshot = snapshot || jsonb_build_object(
+                  'previous_locked', OLD.lock_username IS NOT NULL OR OLD.lock_run_id IS NOT NULL);
+    END IF;
+    PERFORM log_event(TG_TABLE_NAME, TG_OP, NEW.workspace_id::text, snapshot);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION runs_notify_event() RETURNS TRIGGER AS $$
+DECLARE
+    snapshot JSONB;
+BEGIN
+    IF (TG_OP = 'DELETE') THEN
+        PERFORM log_event(TG_TABLE_NAME, TG_OP, OLD.run_id::text);
+        RETURN NULL;
+    END IF;
+    snapshot = jsonb_build_object('status', NEW.status);
+    IF (TG_OP = 'UPDATE') THEN
+        snapshot = snapshot || jsonb_build_object('previous_status', OLD.status);
+    END IF;
+    PERFORM log_event(TG_TABLE_NAME, TG_OP, NEW.run_id::text, snapshot);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+CREATE OR REPLACE FUNCTION workspaces_notify_event() RETURNS TRIGGER AS $$
+DECLARE
+    record RECORD;
+BEGIN
+    IF (TG_OP = 'DELETE') THEN
+        record = OLD;
+    ELSE
+        record = NEW;
+    END IF;
+    PERFORM log_event(TG_TABLE_NAME, TG_OP, record.workspace_id::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION runs_notify_event() RETURNS TRIGGER AS $$
+DECLARE
+    record RECORD;
+BEGIN
+    IF (TG_OP = 'DELETE') THEN
+        record = OLD;
+    ELSE
+        record = NEW;
+    END IF;
+    PERFORM log_event(TG_TABLE_NAME, TG_OP, record.run_id::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP FUNCTION log_event(TEXT, TEXT, TEXT, JSONB);
+
+CREATE OR REPLACE FUNCTION log_event(tbl TEXT, action TEXT, id TEXT) RETURNS VOID AS $$
+DECLARE
+    eid BIGINT;
+    notification JSON;
+BEGIN
+    INSERT INTO events (table_name, action, record_id)
+    VALUES (tbl, action, id)
+    RETURNING event_id INTO eid;
+
+    notification = json_build_object(
+                      'event_id', eid,
+                      'table', tbl,
+                      'action', action,
+                      'id', id);
+    PERFORM pg_notify('events', notification::text);
+END;
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE events DROP COLUMN snapshot;
+
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- vcs_identities links a user's account on a VCS provider to their OTF user,
+-- recorded when the user logs in to OTF via that VCS provider.
+CREATE TABLE IF NOT EXISTS vcs_identities (
+    hostname TEXT NOT NULL,
+    vcs_username TEXT NOT NULL,
+    user_id TEXT REFERENCES users ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    PRIMARY KEY (hostname, vcs_username)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS vcs_identities;
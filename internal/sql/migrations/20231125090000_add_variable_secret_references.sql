@@ -0,0 +1,7 @@
+-- +goose Up
+-- secret_reference is true if the value of a variable is a reference to a
+-- secret in an external secret store, to be resolved by the agent.
+ALTER TABLE variables ADD COLUMN secret_reference BOOL DEFAULT false NOT NULL;
+
+-- +goose Down
+ALTER TABLE variables DROP COLUMN secret_reference;
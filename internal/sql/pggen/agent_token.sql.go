@@ -151,6 +151,123 @@ type Querier interface {
 	// DeleteConfigurationVersionByIDScan scans the result of an executed DeleteConfigurationVersionByIDBatch query.
 	DeleteConfigurationVersionByIDScan(results pgx.BatchResults) (pgtype.Text, error)
 
+	InsertConfigurationVersionVariable(ctx context.Context, params InsertConfigurationVersionVariableParams) (pgconn.CommandTag, error)
+	// InsertConfigurationVersionVariableBatch enqueues a InsertConfigurationVersionVariable query into batch to be executed
+	// later by the batch.
+	InsertConfigurationVersionVariableBatch(batch genericBatch, params InsertConfigurationVersionVariableParams)
+	// InsertConfigurationVersionVariableScan scans the result of an executed InsertConfigurationVersionVariableBatch query.
+	InsertConfigurationVersionVariableScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindConfigurationVersionVariables(ctx context.Context, configurationVersionID pgtype.Text) ([]FindConfigurationVersionVariablesRow, error)
+	// FindConfigurationVersionVariablesBatch enqueues a FindConfigurationVersionVariables query into batch to be executed
+	// later by the batch.
+	FindConfigurationVersionVariablesBatch(batch genericBatch, configurationVersionID pgtype.Text)
+	// FindConfigurationVersionVariablesScan scans the result of an executed FindConfigurationVersionVariablesBatch query.
+	FindConfigurationVersionVariablesScan(results pgx.BatchResults) ([]FindConfigurationVersionVariablesRow, error)
+
+	InsertDeviceAuthorization(ctx context.Context, params InsertDeviceAuthorizationParams) (pgconn.CommandTag, error)
+	// InsertDeviceAuthorizationBatch enqueues a InsertDeviceAuthorization query into batch to be executed
+	// later by the batch.
+	InsertDeviceAuthorizationBatch(batch genericBatch, params InsertDeviceAuthorizationParams)
+	// InsertDeviceAuthorizationScan scans the result of an executed InsertDeviceAuthorizationBatch query.
+	InsertDeviceAuthorizationScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindDeviceAuthorizationByUserCode(ctx context.Context, userCode pgtype.Text) (FindDeviceAuthorizationByUserCodeRow, error)
+	// FindDeviceAuthorizationByUserCodeBatch enqueues a FindDeviceAuthorizationByUserCode query into batch to be executed
+	// later by the batch.
+	FindDeviceAuthorizationByUserCodeBatch(batch genericBatch, userCode pgtype.Text)
+	// FindDeviceAuthorizationByUserCodeScan scans the result of an executed FindDeviceAuthorizationByUserCodeBatch query.
+	FindDeviceAuthorizationByUserCodeScan(results pgx.BatchResults) (FindDeviceAuthorizationByUserCodeRow, error)
+
+	FindDeviceAuthorizationForUpdate(ctx context.Context, deviceCodeHash pgtype.Text) (FindDeviceAuthorizationForUpdateRow, error)
+	// FindDeviceAuthorizationForUpdateBatch enqueues a FindDeviceAuthorizationForUpdate query into batch to be executed
+	// later by the batch.
+	FindDeviceAuthorizationForUpdateBatch(batch genericBatch, deviceCodeHash pgtype.Text)
+	// FindDeviceAuthorizationForUpdateScan scans the result of an executed FindDeviceAuthorizationForUpdateBatch query.
+	FindDeviceAuthorizationForUpdateScan(results pgx.BatchResults) (FindDeviceAuthorizationForUpdateRow, error)
+
+	UpdateDeviceAuthorizationStatus(ctx context.Context, params UpdateDeviceAuthorizationStatusParams) (pgtype.Text, error)
+	// UpdateDeviceAuthorizationStatusBatch enqueues a UpdateDeviceAuthorizationStatus query into batch to be executed
+	// later by the batch.
+	UpdateDeviceAuthorizationStatusBatch(batch genericBatch, params UpdateDeviceAuthorizationStatusParams)
+	// UpdateDeviceAuthorizationStatusScan scans the result of an executed UpdateDeviceAuthorizationStatusBatch query.
+	UpdateDeviceAuthorizationStatusScan(results pgx.BatchResults) (pgtype.Text, error)
+
+	UpdateDeviceAuthorizationLastPolled(ctx context.Context, lastPolledAt pgtype.Timestamptz, deviceCodeHash pgtype.Text) (pgconn.CommandTag, error)
+	// UpdateDeviceAuthorizationLastPolledBatch enqueues a UpdateDeviceAuthorizationLastPolled query into batch to be executed
+	// later by the batch.
+	UpdateDeviceAuthorizationLastPolledBatch(batch genericBatch, lastPolledAt pgtype.Timestamptz, deviceCodeHash pgtype.Text)
+	// UpdateDeviceAuthorizationLastPolledScan scans the result of an executed UpdateDeviceAuthorizationLastPolledBatch query.
+	UpdateDeviceAuthorizationLastPolledScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	DeleteDeviceAuthorization(ctx context.Context, deviceCodeHash pgtype.Text) (pgconn.CommandTag, error)
+	// DeleteDeviceAuthorizationBatch enqueues a DeleteDeviceAuthorization query into batch to be executed
+	// later by the batch.
+	DeleteDeviceAuthorizationBatch(batch genericBatch, deviceCodeHash pgtype.Text)
+	// DeleteDeviceAuthorizationScan scans the result of an executed DeleteDeviceAuthorizationBatch query.
+	DeleteDeviceAuthorizationScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	DeleteExpiredDeviceAuthorizations(ctx context.Context, now pgtype.Timestamptz) (pgconn.CommandTag, error)
+	// DeleteExpiredDeviceAuthorizationsBatch enqueues a DeleteExpiredDeviceAuthorizations query into batch to be executed
+	// later by the batch.
+	DeleteExpiredDeviceAuthorizationsBatch(batch genericBatch, now pgtype.Timestamptz)
+	// DeleteExpiredDeviceAuthorizationsScan scans the result of an executed DeleteExpiredDeviceAuthorizationsBatch query.
+	DeleteExpiredDeviceAuthorizationsScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindEventsAfter(ctx context.Context, afterID pgtype.Int8, limit pgtype.Int8) ([]FindEventsAfterRow, error)
+	// FindEventsAfterBatch enqueues a FindEventsAfter query into batch to be executed
+	// later by the batch.
+	FindEventsAfterBatch(batch genericBatch, afterID pgtype.Int8, limit pgtype.Int8)
+	// FindEventsAfterScan scans the result of an executed FindEventsAfterBatch query.
+	FindEventsAfterScan(results pgx.BatchResults) ([]FindEventsAfterRow, error)
+
+	// FindReplayStartEventID finds the ID of the event after which to replay
+	// events to a subscriber that last received the given event. Events with a
+	// lower ID that were logged within the given window of the given event may
+	// have committed after it, so the replay starts before the earliest of them.
+	//
+	FindReplayStartEventID(ctx context.Context, afterID pgtype.Int8, windowSeconds pgtype.Int8) (pgtype.Int8, error)
+	// FindReplayStartEventIDBatch enqueues a FindReplayStartEventID query into batch to be executed
+	// later by the batch.
+	FindReplayStartEventIDBatch(batch genericBatch, afterID pgtype.Int8, windowSeconds pgtype.Int8)
+	// FindReplayStartEventIDScan scans the result of an executed FindReplayStartEventIDBatch query.
+	FindReplayStartEventIDScan(results pgx.BatchResults) (pgtype.Int8, error)
+
+	DeleteEventsBefore(ctx context.Context, before pgtype.Timestamptz) (pgconn.CommandTag, error)
+	// DeleteEventsBeforeBatch enqueues a DeleteEventsBefore query into batch to be executed
+	// later by the batch.
+	DeleteEventsBeforeBatch(batch genericBatch, before pgtype.Timestamptz)
+	// DeleteEventsBeforeScan scans the result of an executed DeleteEventsBeforeBatch query.
+	DeleteEventsBeforeScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindEventsAfterOrMissing(ctx context.Context, params FindEventsAfterOrMissingParams) ([]FindEventsAfterOrMissingRow, error)
+	// FindEventsAfterOrMissingBatch enqueues a FindEventsAfterOrMissing query into batch to be executed
+	// later by the batch.
+	FindEventsAfterOrMissingBatch(batch genericBatch, params FindEventsAfterOrMissingParams)
+	// FindEventsAfterOrMissingScan scans the result of an executed FindEventsAfterOrMissingBatch query.
+	FindEventsAfterOrMissingScan(results pgx.BatchResults) ([]FindEventsAfterOrMissingRow, error)
+
+	FindLatestEventID(ctx context.Context) (pgtype.Int8, error)
+	// FindLatestEventIDBatch enqueues a FindLatestEventID query into batch to be executed
+	// later by the batch.
+	FindLatestEventIDBatch(batch genericBatch)
+	// FindLatestEventIDScan scans the result of an executed FindLatestEventIDBatch query.
+	FindLatestEventIDScan(results pgx.BatchResults) (pgtype.Int8, error)
+
+	InsertConfigurationVersionModule(ctx context.Context, params InsertConfigurationVersionModuleParams) (pgconn.CommandTag, error)
+	// InsertConfigurationVersionModuleBatch enqueues a InsertConfigurationVersionModule query into batch to be executed
+	// later by the batch.
+	InsertConfigurationVersionModuleBatch(batch genericBatch, params InsertConfigurationVersionModuleParams)
+	// InsertConfigurationVersionModuleScan scans the result of an executed InsertConfigurationVersionModuleBatch query.
+	InsertConfigurationVersionModuleScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindWorkspaceSummaries(ctx context.Context, organizationName pgtype.Text, workspaceIds []string) ([]FindWorkspaceSummariesRow, error)
+	// FindWorkspaceSummariesBatch enqueues a FindWorkspaceSummaries query into batch to be executed
+	// later by the batch.
+	FindWorkspaceSummariesBatch(batch genericBatch, organizationName pgtype.Text, workspaceIds []string)
+	// FindWorkspaceSummariesScan scans the result of an executed FindWorkspaceSummariesBatch query.
+	FindWorkspaceSummariesScan(results pgx.BatchResults) ([]FindWorkspaceSummariesRow, error)
+
 	InsertGithubApp(ctx context.Context, params InsertGithubAppParams) (pgconn.CommandTag, error)
 	// InsertGithubAppBatch enqueues a InsertGithubApp query into batch to be executed
 	// later by the batch.
@@ -221,12 +338,12 @@ type Querier interface {
 	// FindModuleByIDScan scans the result of an executed FindModuleByIDBatch query.
 	FindModuleByIDScan(results pgx.BatchResults) (FindModuleByIDRow, error)
 
-	FindModuleByConnection(ctx context.Context, vcsProviderID pgtype.Text, repoPath pgtype.Text) (FindModuleByConnectionRow, error)
-	// FindModuleByConnectionBatch enqueues a FindModuleByConnection query into batch to be executed
+	FindModulesByConnection(ctx context.Context, vcsProviderID pgtype.Text, repoPath pgtype.Text) ([]FindModulesByConnectionRow, error)
+	// FindModulesByConnectionBatch enqueues a FindModulesByConnection query into batch to be executed
 	// later by the batch.
-	FindModuleByConnectionBatch(batch genericBatch, vcsProviderID pgtype.Text, repoPath pgtype.Text)
-	// FindModuleByConnectionScan scans the result of an executed FindModuleByConnectionBatch query.
-	FindModuleByConnectionScan(results pgx.BatchResults) (FindModuleByConnectionRow, error)
+	FindModulesByConnectionBatch(batch genericBatch, vcsProviderID pgtype.Text, repoPath pgtype.Text)
+	// FindModulesByConnectionScan scans the result of an executed FindModulesByConnectionBatch query.
+	FindModulesByConnectionScan(results pgx.BatchResults) ([]FindModulesByConnectionRow, error)
 
 	FindModuleByModuleVersionID(ctx context.Context, moduleVersionID pgtype.Text) (FindModuleByModuleVersionIDRow, error)
 	// FindModuleByModuleVersionIDBatch enqueues a FindModuleByModuleVersionID query into batch to be executed
@@ -263,6 +380,97 @@ type Querier interface {
 	// UpdateModuleVersionStatusByIDScan scans the result of an executed UpdateModuleVersionStatusByIDBatch query.
 	UpdateModuleVersionStatusByIDScan(results pgx.BatchResults) (UpdateModuleVersionStatusByIDRow, error)
 
+	UpdateModuleVersionDeprecationByID(ctx context.Context, params UpdateModuleVersionDeprecationByIDParams) (pgtype.Text, error)
+	// UpdateModuleVersionDeprecationByIDBatch enqueues a UpdateModuleVersionDeprecationByID query into batch to be executed
+	// later by the batch.
+	UpdateModuleVersionDeprecationByIDBatch(batch genericBatch, params UpdateModuleVersionDeprecationByIDParams)
+	// UpdateModuleVersionDeprecationByIDScan scans the result of an executed UpdateModuleVersionDeprecationByIDBatch query.
+	UpdateModuleVersionDeprecationByIDScan(results pgx.BatchResults) (pgtype.Text, error)
+
+	UpdateModuleVersionYankedByID(ctx context.Context, yanked bool, moduleVersionID pgtype.Text) (pgtype.Text, error)
+	// UpdateModuleVersionYankedByIDBatch enqueues a UpdateModuleVersionYankedByID query into batch to be executed
+	// later by the batch.
+	UpdateModuleVersionYankedByIDBatch(batch genericBatch, yanked bool, moduleVersionID pgtype.Text)
+	// UpdateModuleVersionYankedByIDScan scans the result of an executed UpdateModuleVersionYankedByIDBatch query.
+	UpdateModuleVersionYankedByIDScan(results pgx.BatchResults) (pgtype.Text, error)
+
+	FindPendingModuleVersionTests(ctx context.Context) ([]FindPendingModuleVersionTestsRow, error)
+	// FindPendingModuleVersionTestsBatch enqueues a FindPendingModuleVersionTests query into batch to be executed
+	// later by the batch.
+	FindPendingModuleVersionTestsBatch(batch genericBatch)
+	// FindPendingModuleVersionTestsScan scans the result of an executed FindPendingModuleVersionTestsBatch query.
+	FindPendingModuleVersionTestsScan(results pgx.BatchResults) ([]FindPendingModuleVersionTestsRow, error)
+
+	UpdateModuleVersionTestStarted(ctx context.Context, moduleVersionID pgtype.Text) (pgtype.Text, error)
+	// UpdateModuleVersionTestStartedBatch enqueues a UpdateModuleVersionTestStarted query into batch to be executed
+	// later by the batch.
+	UpdateModuleVersionTestStartedBatch(batch genericBatch, moduleVersionID pgtype.Text)
+	// UpdateModuleVersionTestStartedScan scans the result of an executed UpdateModuleVersionTestStartedBatch query.
+	UpdateModuleVersionTestStartedScan(results pgx.BatchResults) (pgtype.Text, error)
+
+	UpdateStaleModuleVersionTests(ctx context.Context, startedBefore pgtype.Timestamptz) ([]pgtype.Text, error)
+	// UpdateStaleModuleVersionTestsBatch enqueues a UpdateStaleModuleVersionTests query into batch to be executed
+	// later by the batch.
+	UpdateStaleModuleVersionTestsBatch(batch genericBatch, startedBefore pgtype.Timestamptz)
+	// UpdateStaleModuleVersionTestsScan scans the result of an executed UpdateStaleModuleVersionTestsBatch query.
+	UpdateStaleModuleVersionTestsScan(results pgx.BatchResults) ([]pgtype.Text, error)
+
+	InsertModuleVersionTest(ctx context.Context, moduleVersionID pgtype.Text, startedAt pgtype.Timestamptz) (pgconn.CommandTag, error)
+	// InsertModuleVersionTestBatch enqueues a InsertModuleVersionTest query into batch to be executed
+	// later by the batch.
+	InsertModuleVersionTestBatch(batch genericBatch, moduleVersionID pgtype.Text, startedAt pgtype.Timestamptz)
+	// InsertModuleVersionTestScan scans the result of an executed InsertModuleVersionTestBatch query.
+	InsertModuleVersionTestScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	UpdateModuleVersionTestOutput(ctx context.Context, output []byte, moduleVersionID pgtype.Text) (pgtype.Text, error)
+	// UpdateModuleVersionTestOutputBatch enqueues a UpdateModuleVersionTestOutput query into batch to be executed
+	// later by the batch.
+	UpdateModuleVersionTestOutputBatch(batch genericBatch, output []byte, moduleVersionID pgtype.Text)
+	// UpdateModuleVersionTestOutputScan scans the result of an executed UpdateModuleVersionTestOutputBatch query.
+	UpdateModuleVersionTestOutputScan(results pgx.BatchResults) (pgtype.Text, error)
+
+	UpdateModuleVersionTestFinished(ctx context.Context, finishedAt pgtype.Timestamptz, moduleVersionID pgtype.Text) (pgtype.Text, error)
+	// UpdateModuleVersionTestFinishedBatch enqueues a UpdateModuleVersionTestFinished query into batch to be executed
+	// later by the batch.
+	UpdateModuleVersionTestFinishedBatch(batch genericBatch, finishedAt pgtype.Timestamptz, moduleVersionID pgtype.Text)
+	// UpdateModuleVersionTestFinishedScan scans the result of an executed UpdateModuleVersionTestFinishedBatch query.
+	UpdateModuleVersionTestFinishedScan(results pgx.BatchResults) (pgtype.Text, error)
+
+	FindModuleVersionTestsByModuleID(ctx context.Context, moduleID pgtype.Text) ([]FindModuleVersionTestsByModuleIDRow, error)
+	// FindModuleVersionTestsByModuleIDBatch enqueues a FindModuleVersionTestsByModuleID query into batch to be executed
+	// later by the batch.
+	FindModuleVersionTestsByModuleIDBatch(batch genericBatch, moduleID pgtype.Text)
+	// FindModuleVersionTestsByModuleIDScan scans the result of an executed FindModuleVersionTestsByModuleIDBatch query.
+	FindModuleVersionTestsByModuleIDScan(results pgx.BatchResults) ([]FindModuleVersionTestsByModuleIDRow, error)
+
+	IncrementModuleVersionDownloads(ctx context.Context, moduleVersionID pgtype.Text) (pgtype.Text, error)
+	// IncrementModuleVersionDownloadsBatch enqueues a IncrementModuleVersionDownloads query into batch to be executed
+	// later by the batch.
+	IncrementModuleVersionDownloadsBatch(batch genericBatch, moduleVersionID pgtype.Text)
+	// IncrementModuleVersionDownloadsScan scans the result of an executed IncrementModuleVersionDownloadsBatch query.
+	IncrementModuleVersionDownloadsScan(results pgx.BatchResults) (pgtype.Text, error)
+
+	DeleteModuleVersionUsagesByWorkspaceID(ctx context.Context, workspaceID pgtype.Text) (pgconn.CommandTag, error)
+	// DeleteModuleVersionUsagesByWorkspaceIDBatch enqueues a DeleteModuleVersionUsagesByWorkspaceID query into batch to be executed
+	// later by the batch.
+	DeleteModuleVersionUsagesByWorkspaceIDBatch(batch genericBatch, workspaceID pgtype.Text)
+	// DeleteModuleVersionUsagesByWorkspaceIDScan scans the result of an executed DeleteModuleVersionUsagesByWorkspaceIDBatch query.
+	DeleteModuleVersionUsagesByWorkspaceIDScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	InsertModuleVersionUsage(ctx context.Context, params InsertModuleVersionUsageParams) (pgconn.CommandTag, error)
+	// InsertModuleVersionUsageBatch enqueues a InsertModuleVersionUsage query into batch to be executed
+	// later by the batch.
+	InsertModuleVersionUsageBatch(batch genericBatch, params InsertModuleVersionUsageParams)
+	// InsertModuleVersionUsageScan scans the result of an executed InsertModuleVersionUsageBatch query.
+	InsertModuleVersionUsageScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindModuleVersionUsagesByModuleID(ctx context.Context, moduleID pgtype.Text) ([]FindModuleVersionUsagesByModuleIDRow, error)
+	// FindModuleVersionUsagesByModuleIDBatch enqueues a FindModuleVersionUsagesByModuleID query into batch to be executed
+	// later by the batch.
+	FindModuleVersionUsagesByModuleIDBatch(batch genericBatch, moduleID pgtype.Text)
+	// FindModuleVersionUsagesByModuleIDScan scans the result of an executed FindModuleVersionUsagesByModuleIDBatch query.
+	FindModuleVersionUsagesByModuleIDScan(results pgx.BatchResults) ([]FindModuleVersionUsagesByModuleIDRow, error)
+
 	DeleteModuleByID(ctx context.Context, moduleID pgtype.Text) (pgtype.Text, error)
 	// DeleteModuleByIDBatch enqueues a DeleteModuleByID query into batch to be executed
 	// later by the batch.
@@ -410,6 +618,13 @@ type Querier interface {
 	// FindOrganizationTokensByIDScan scans the result of an executed FindOrganizationTokensByIDBatch query.
 	FindOrganizationTokensByIDScan(results pgx.BatchResults) (FindOrganizationTokensByIDRow, error)
 
+	UpdateOrganizationTokenLastUsed(ctx context.Context, params UpdateOrganizationTokenLastUsedParams) (pgconn.CommandTag, error)
+	// UpdateOrganizationTokenLastUsedBatch enqueues a UpdateOrganizationTokenLastUsed query into batch to be executed
+	// later by the batch.
+	UpdateOrganizationTokenLastUsedBatch(batch genericBatch, params UpdateOrganizationTokenLastUsedParams)
+	// UpdateOrganizationTokenLastUsedScan scans the result of an executed UpdateOrganizationTokenLastUsedBatch query.
+	UpdateOrganizationTokenLastUsedScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
 	DeleteOrganiationTokenByName(ctx context.Context, organizationName pgtype.Text) (pgtype.Text, error)
 	// DeleteOrganiationTokenByNameBatch enqueues a DeleteOrganiationTokenByName query into batch to be executed
 	// later by the batch.
@@ -496,6 +711,209 @@ type Querier interface {
 	// UpdatePlanJSONByIDScan scans the result of an executed UpdatePlanJSONByIDBatch query.
 	UpdatePlanJSONByIDScan(results pgx.BatchResults) (pgtype.Text, error)
 
+	InsertProject(ctx context.Context, params InsertProjectParams) (pgconn.CommandTag, error)
+	// InsertProjectBatch enqueues a InsertProject query into batch to be executed
+	// later by the batch.
+	InsertProjectBatch(batch genericBatch, params InsertProjectParams)
+	// InsertProjectScan scans the result of an executed InsertProjectBatch query.
+	InsertProjectScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindProjects(ctx context.Context, params FindProjectsParams) ([]FindProjectsRow, error)
+	// FindProjectsBatch enqueues a FindProjects query into batch to be executed
+	// later by the batch.
+	FindProjectsBatch(batch genericBatch, params FindProjectsParams)
+	// FindProjectsScan scans the result of an executed FindProjectsBatch query.
+	FindProjectsScan(results pgx.BatchResults) ([]FindProjectsRow, error)
+
+	CountProjects(ctx context.Context, organizationName pgtype.Text) (pgtype.Int8, error)
+	// CountProjectsBatch enqueues a CountProjects query into batch to be executed
+	// later by the batch.
+	CountProjectsBatch(batch genericBatch, organizationName pgtype.Text)
+	// CountProjectsScan scans the result of an executed CountProjectsBatch query.
+	CountProjectsScan(results pgx.BatchResults) (pgtype.Int8, error)
+
+	FindProjectByID(ctx context.Context, projectID pgtype.Text) (FindProjectByIDRow, error)
+	// FindProjectByIDBatch enqueues a FindProjectByID query into batch to be executed
+	// later by the batch.
+	FindProjectByIDBatch(batch genericBatch, projectID pgtype.Text)
+	// FindProjectByIDScan scans the result of an executed FindProjectByIDBatch query.
+	FindProjectByIDScan(results pgx.BatchResults) (FindProjectByIDRow, error)
+
+	FindProjectByIDForUpdate(ctx context.Context, projectID pgtype.Text) (FindProjectByIDForUpdateRow, error)
+	// FindProjectByIDForUpdateBatch enqueues a FindProjectByIDForUpdate query into batch to be executed
+	// later by the batch.
+	FindProjectByIDForUpdateBatch(batch genericBatch, projectID pgtype.Text)
+	// FindProjectByIDForUpdateScan scans the result of an executed FindProjectByIDForUpdateBatch query.
+	FindProjectByIDForUpdateScan(results pgx.BatchResults) (FindProjectByIDForUpdateRow, error)
+
+	UpdateProjectByID(ctx context.Context, name pgtype.Text, projectID pgtype.Text) (pgtype.Text, error)
+	// UpdateProjectByIDBatch enqueues a UpdateProjectByID query into batch to be executed
+	// later by the batch.
+	UpdateProjectByIDBatch(batch genericBatch, name pgtype.Text, projectID pgtype.Text)
+	// UpdateProjectByIDScan scans the result of an executed UpdateProjectByIDBatch query.
+	UpdateProjectByIDScan(results pgx.BatchResults) (pgtype.Text, error)
+
+	DeleteProjectByID(ctx context.Context, projectID pgtype.Text) (pgtype.Text, error)
+	// DeleteProjectByIDBatch enqueues a DeleteProjectByID query into batch to be executed
+	// later by the batch.
+	DeleteProjectByIDBatch(batch genericBatch, projectID pgtype.Text)
+	// DeleteProjectByIDScan scans the result of an executed DeleteProjectByIDBatch query.
+	DeleteProjectByIDScan(results pgx.BatchResults) (pgtype.Text, error)
+
+	UpsertProjectPermission(ctx context.Context, params UpsertProjectPermissionParams) (pgconn.CommandTag, error)
+	// UpsertProjectPermissionBatch enqueues a UpsertProjectPermission query into batch to be executed
+	// later by the batch.
+	UpsertProjectPermissionBatch(batch genericBatch, params UpsertProjectPermissionParams)
+	// UpsertProjectPermissionScan scans the result of an executed UpsertProjectPermissionBatch query.
+	UpsertProjectPermissionScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindProjectPermissionsByProjectID(ctx context.Context, projectID pgtype.Text) ([]FindProjectPermissionsByProjectIDRow, error)
+	// FindProjectPermissionsByProjectIDBatch enqueues a FindProjectPermissionsByProjectID query into batch to be executed
+	// later by the batch.
+	FindProjectPermissionsByProjectIDBatch(batch genericBatch, projectID pgtype.Text)
+	// FindProjectPermissionsByProjectIDScan scans the result of an executed FindProjectPermissionsByProjectIDBatch query.
+	FindProjectPermissionsByProjectIDScan(results pgx.BatchResults) ([]FindProjectPermissionsByProjectIDRow, error)
+
+	FindProjectPermissionsByWorkspaceID(ctx context.Context, workspaceID pgtype.Text) ([]FindProjectPermissionsByWorkspaceIDRow, error)
+	// FindProjectPermissionsByWorkspaceIDBatch enqueues a FindProjectPermissionsByWorkspaceID query into batch to be executed
+	// later by the batch.
+	FindProjectPermissionsByWorkspaceIDBatch(batch genericBatch, workspaceID pgtype.Text)
+	// FindProjectPermissionsByWorkspaceIDScan scans the result of an executed FindProjectPermissionsByWorkspaceIDBatch query.
+	FindProjectPermissionsByWorkspaceIDScan(results pgx.BatchResults) ([]FindProjectPermissionsByWorkspaceIDRow, error)
+
+	DeleteProjectPermissionByID(ctx context.Context, projectID pgtype.Text, teamName pgtype.Text) (pgconn.CommandTag, error)
+	// DeleteProjectPermissionByIDBatch enqueues a DeleteProjectPermissionByID query into batch to be executed
+	// later by the batch.
+	DeleteProjectPermissionByIDBatch(batch genericBatch, projectID pgtype.Text, teamName pgtype.Text)
+	// DeleteProjectPermissionByIDScan scans the result of an executed DeleteProjectPermissionByIDBatch query.
+	DeleteProjectPermissionByIDScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	InsertRegistryGPGKey(ctx context.Context, params InsertRegistryGPGKeyParams) (pgconn.CommandTag, error)
+	// InsertRegistryGPGKeyBatch enqueues a InsertRegistryGPGKey query into batch to be executed
+	// later by the batch.
+	InsertRegistryGPGKeyBatch(batch genericBatch, params InsertRegistryGPGKeyParams)
+	// InsertRegistryGPGKeyScan scans the result of an executed InsertRegistryGPGKeyBatch query.
+	InsertRegistryGPGKeyScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindRegistryGPGKeysByOrganization(ctx context.Context, organizationName pgtype.Text) ([]FindRegistryGPGKeysByOrganizationRow, error)
+	// FindRegistryGPGKeysByOrganizationBatch enqueues a FindRegistryGPGKeysByOrganization query into batch to be executed
+	// later by the batch.
+	FindRegistryGPGKeysByOrganizationBatch(batch genericBatch, organizationName pgtype.Text)
+	// FindRegistryGPGKeysByOrganizationScan scans the result of an executed FindRegistryGPGKeysByOrganizationBatch query.
+	FindRegistryGPGKeysByOrganizationScan(results pgx.BatchResults) ([]FindRegistryGPGKeysByOrganizationRow, error)
+
+	FindRegistryGPGKeyByID(ctx context.Context, gpgKeyID pgtype.Text) (FindRegistryGPGKeyByIDRow, error)
+	// FindRegistryGPGKeyByIDBatch enqueues a FindRegistryGPGKeyByID query into batch to be executed
+	// later by the batch.
+	FindRegistryGPGKeyByIDBatch(batch genericBatch, gpgKeyID pgtype.Text)
+	// FindRegistryGPGKeyByIDScan scans the result of an executed FindRegistryGPGKeyByIDBatch query.
+	FindRegistryGPGKeyByIDScan(results pgx.BatchResults) (FindRegistryGPGKeyByIDRow, error)
+
+	DeleteRegistryGPGKeyByID(ctx context.Context, gpgKeyID pgtype.Text) (pgtype.Text, error)
+	// DeleteRegistryGPGKeyByIDBatch enqueues a DeleteRegistryGPGKeyByID query into batch to be executed
+	// later by the batch.
+	DeleteRegistryGPGKeyByIDBatch(batch genericBatch, gpgKeyID pgtype.Text)
+	// DeleteRegistryGPGKeyByIDScan scans the result of an executed DeleteRegistryGPGKeyByIDBatch query.
+	DeleteRegistryGPGKeyByIDScan(results pgx.BatchResults) (pgtype.Text, error)
+
+	InsertRegistryProvider(ctx context.Context, params InsertRegistryProviderParams) (pgconn.CommandTag, error)
+	// InsertRegistryProviderBatch enqueues a InsertRegistryProvider query into batch to be executed
+	// later by the batch.
+	InsertRegistryProviderBatch(batch genericBatch, params InsertRegistryProviderParams)
+	// InsertRegistryProviderScan scans the result of an executed InsertRegistryProviderBatch query.
+	InsertRegistryProviderScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindRegistryProvidersByOrganization(ctx context.Context, organizationName pgtype.Text) ([]FindRegistryProvidersByOrganizationRow, error)
+	// FindRegistryProvidersByOrganizationBatch enqueues a FindRegistryProvidersByOrganization query into batch to be executed
+	// later by the batch.
+	FindRegistryProvidersByOrganizationBatch(batch genericBatch, organizationName pgtype.Text)
+	// FindRegistryProvidersByOrganizationScan scans the result of an executed FindRegistryProvidersByOrganizationBatch query.
+	FindRegistryProvidersByOrganizationScan(results pgx.BatchResults) ([]FindRegistryProvidersByOrganizationRow, error)
+
+	FindRegistryProviderByName(ctx context.Context, organizationName pgtype.Text, name pgtype.Text) (FindRegistryProviderByNameRow, error)
+	// FindRegistryProviderByNameBatch enqueues a FindRegistryProviderByName query into batch to be executed
+	// later by the batch.
+	FindRegistryProviderByNameBatch(batch genericBatch, organizationName pgtype.Text, name pgtype.Text)
+	// FindRegistryProviderByNameScan scans the result of an executed FindRegistryProviderByNameBatch query.
+	FindRegistryProviderByNameScan(results pgx.BatchResults) (FindRegistryProviderByNameRow, error)
+
+	FindRegistryProviderByID(ctx context.Context, registryProviderID pgtype.Text) (FindRegistryProviderByIDRow, error)
+	// FindRegistryProviderByIDBatch enqueues a FindRegistryProviderByID query into batch to be executed
+	// later by the batch.
+	FindRegistryProviderByIDBatch(batch genericBatch, registryProviderID pgtype.Text)
+	// FindRegistryProviderByIDScan scans the result of an executed FindRegistryProviderByIDBatch query.
+	FindRegistryProviderByIDScan(results pgx.BatchResults) (FindRegistryProviderByIDRow, error)
+
+	FindRegistryProviderByVersionID(ctx context.Context, registryProviderVersionID pgtype.Text) (FindRegistryProviderByVersionIDRow, error)
+	// FindRegistryProviderByVersionIDBatch enqueues a FindRegistryProviderByVersionID query into batch to be executed
+	// later by the batch.
+	FindRegistryProviderByVersionIDBatch(batch genericBatch, registryProviderVersionID pgtype.Text)
+	// FindRegistryProviderByVersionIDScan scans the result of an executed FindRegistryProviderByVersionIDBatch query.
+	FindRegistryProviderByVersionIDScan(results pgx.BatchResults) (FindRegistryProviderByVersionIDRow, error)
+
+	DeleteRegistryProviderByID(ctx context.Context, registryProviderID pgtype.Text) (pgtype.Text, error)
+	// DeleteRegistryProviderByIDBatch enqueues a DeleteRegistryProviderByID query into batch to be executed
+	// later by the batch.
+	DeleteRegistryProviderByIDBatch(batch genericBatch, registryProviderID pgtype.Text)
+	// DeleteRegistryProviderByIDScan scans the result of an executed DeleteRegistryProviderByIDBatch query.
+	DeleteRegistryProviderByIDScan(results pgx.BatchResults) (pgtype.Text, error)
+
+	InsertRegistryProviderVersion(ctx context.Context, params InsertRegistryProviderVersionParams) (pgconn.CommandTag, error)
+	// InsertRegistryProviderVersionBatch enqueues a InsertRegistryProviderVersion query into batch to be executed
+	// later by the batch.
+	InsertRegistryProviderVersionBatch(batch genericBatch, params InsertRegistryProviderVersionParams)
+	// InsertRegistryProviderVersionScan scans the result of an executed InsertRegistryProviderVersionBatch query.
+	InsertRegistryProviderVersionScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindRegistryProviderVersionsByProviderID(ctx context.Context, registryProviderID pgtype.Text) ([]FindRegistryProviderVersionsByProviderIDRow, error)
+	// FindRegistryProviderVersionsByProviderIDBatch enqueues a FindRegistryProviderVersionsByProviderID query into batch to be executed
+	// later by the batch.
+	FindRegistryProviderVersionsByProviderIDBatch(batch genericBatch, registryProviderID pgtype.Text)
+	// FindRegistryProviderVersionsByProviderIDScan scans the result of an executed FindRegistryProviderVersionsByProviderIDBatch query.
+	FindRegistryProviderVersionsByProviderIDScan(results pgx.BatchResults) ([]FindRegistryProviderVersionsByProviderIDRow, error)
+
+	DeleteRegistryProviderVersionByID(ctx context.Context, registryProviderVersionID pgtype.Text) (pgtype.Text, error)
+	// DeleteRegistryProviderVersionByIDBatch enqueues a DeleteRegistryProviderVersionByID query into batch to be executed
+	// later by the batch.
+	DeleteRegistryProviderVersionByIDBatch(batch genericBatch, registryProviderVersionID pgtype.Text)
+	// DeleteRegistryProviderVersionByIDScan scans the result of an executed DeleteRegistryProviderVersionByIDBatch query.
+	DeleteRegistryProviderVersionByIDScan(results pgx.BatchResults) (pgtype.Text, error)
+
+	InsertRegistryProviderPlatform(ctx context.Context, params InsertRegistryProviderPlatformParams) (pgconn.CommandTag, error)
+	// InsertRegistryProviderPlatformBatch enqueues a InsertRegistryProviderPlatform query into batch to be executed
+	// later by the batch.
+	InsertRegistryProviderPlatformBatch(batch genericBatch, params InsertRegistryProviderPlatformParams)
+	// InsertRegistryProviderPlatformScan scans the result of an executed InsertRegistryProviderPlatformBatch query.
+	InsertRegistryProviderPlatformScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindRegistryProviderPlatformsByProviderID(ctx context.Context, registryProviderID pgtype.Text) ([]FindRegistryProviderPlatformsByProviderIDRow, error)
+	// FindRegistryProviderPlatformsByProviderIDBatch enqueues a FindRegistryProviderPlatformsByProviderID query into batch to be executed
+	// later by the batch.
+	FindRegistryProviderPlatformsByProviderIDBatch(batch genericBatch, registryProviderID pgtype.Text)
+	// FindRegistryProviderPlatformsByProviderIDScan scans the result of an executed FindRegistryProviderPlatformsByProviderIDBatch query.
+	FindRegistryProviderPlatformsByProviderIDScan(results pgx.BatchResults) ([]FindRegistryProviderPlatformsByProviderIDRow, error)
+
+	FindRegistryProviderPlatformByID(ctx context.Context, registryProviderPlatformID pgtype.Text) (FindRegistryProviderPlatformByIDRow, error)
+	// FindRegistryProviderPlatformByIDBatch enqueues a FindRegistryProviderPlatformByID query into batch to be executed
+	// later by the batch.
+	FindRegistryProviderPlatformByIDBatch(batch genericBatch, registryProviderPlatformID pgtype.Text)
+	// FindRegistryProviderPlatformByIDScan scans the result of an executed FindRegistryProviderPlatformByIDBatch query.
+	FindRegistryProviderPlatformByIDScan(results pgx.BatchResults) (FindRegistryProviderPlatformByIDRow, error)
+
+	FindRegistryProviderPlatformBinary(ctx context.Context, registryProviderPlatformID pgtype.Text) ([]byte, error)
+	// FindRegistryProviderPlatformBinaryBatch enqueues a FindRegistryProviderPlatformBinary query into batch to be executed
+	// later by the batch.
+	FindRegistryProviderPlatformBinaryBatch(batch genericBatch, registryProviderPlatformID pgtype.Text)
+	// FindRegistryProviderPlatformBinaryScan scans the result of an executed FindRegistryProviderPlatformBinaryBatch query.
+	FindRegistryProviderPlatformBinaryScan(results pgx.BatchResults) ([]byte, error)
+
+	DeleteRegistryProviderPlatformByID(ctx context.Context, registryProviderPlatformID pgtype.Text) (pgtype.Text, error)
+	// DeleteRegistryProviderPlatformByIDBatch enqueues a DeleteRegistryProviderPlatformByID query into batch to be executed
+	// later by the batch.
+	DeleteRegistryProviderPlatformByIDBatch(batch genericBatch, registryProviderPlatformID pgtype.Text)
+	// DeleteRegistryProviderPlatformByIDScan scans the result of an executed DeleteRegistryProviderPlatformByIDBatch query.
+	DeleteRegistryProviderPlatformByIDScan(results pgx.BatchResults) (pgtype.Text, error)
+
 	InsertLatestTerraformVersion(ctx context.Context, version pgtype.Text) (pgconn.CommandTag, error)
 	// InsertLatestTerraformVersionBatch enqueues a InsertLatestTerraformVersion query into batch to be executed
 	// later by the batch.
@@ -741,6 +1159,48 @@ type Querier interface {
 	// DeleteStateVersionByIDScan scans the result of an executed DeleteStateVersionByIDBatch query.
 	DeleteStateVersionByIDScan(results pgx.BatchResults) (pgtype.Text, error)
 
+	InsertStateVersionResource(ctx context.Context, params InsertStateVersionResourceParams) (pgconn.CommandTag, error)
+	// InsertStateVersionResourceBatch enqueues a InsertStateVersionResource query into batch to be executed
+	// later by the batch.
+	InsertStateVersionResourceBatch(batch genericBatch, params InsertStateVersionResourceParams)
+	// InsertStateVersionResourceScan scans the result of an executed InsertStateVersionResourceBatch query.
+	InsertStateVersionResourceScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	UpdateStateVersionResourcesIndexed(ctx context.Context, stateVersionID pgtype.Text) (pgconn.CommandTag, error)
+	// UpdateStateVersionResourcesIndexedBatch enqueues a UpdateStateVersionResourcesIndexed query into batch to be executed
+	// later by the batch.
+	UpdateStateVersionResourcesIndexedBatch(batch genericBatch, stateVersionID pgtype.Text)
+	// UpdateStateVersionResourcesIndexedScan scans the result of an executed UpdateStateVersionResourcesIndexedBatch query.
+	UpdateStateVersionResourcesIndexedScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindUnindexedCurrentStateVersionsByOrganization(ctx context.Context, organizationName pgtype.Text) ([]FindUnindexedCurrentStateVersionsByOrganizationRow, error)
+	// FindUnindexedCurrentStateVersionsByOrganizationBatch enqueues a FindUnindexedCurrentStateVersionsByOrganization query into batch to be executed
+	// later by the batch.
+	FindUnindexedCurrentStateVersionsByOrganizationBatch(batch genericBatch, organizationName pgtype.Text)
+	// FindUnindexedCurrentStateVersionsByOrganizationScan scans the result of an executed FindUnindexedCurrentStateVersionsByOrganizationBatch query.
+	FindUnindexedCurrentStateVersionsByOrganizationScan(results pgx.BatchResults) ([]FindUnindexedCurrentStateVersionsByOrganizationRow, error)
+
+	FindCurrentStateWorkspaceIDsByOrganization(ctx context.Context, organizationName pgtype.Text) ([]pgtype.Text, error)
+	// FindCurrentStateWorkspaceIDsByOrganizationBatch enqueues a FindCurrentStateWorkspaceIDsByOrganization query into batch to be executed
+	// later by the batch.
+	FindCurrentStateWorkspaceIDsByOrganizationBatch(batch genericBatch, organizationName pgtype.Text)
+	// FindCurrentStateWorkspaceIDsByOrganizationScan scans the result of an executed FindCurrentStateWorkspaceIDsByOrganizationBatch query.
+	FindCurrentStateWorkspaceIDsByOrganizationScan(results pgx.BatchResults) ([]pgtype.Text, error)
+
+	FindStateResources(ctx context.Context, params FindStateResourcesParams) ([]FindStateResourcesRow, error)
+	// FindStateResourcesBatch enqueues a FindStateResources query into batch to be executed
+	// later by the batch.
+	FindStateResourcesBatch(batch genericBatch, params FindStateResourcesParams)
+	// FindStateResourcesScan scans the result of an executed FindStateResourcesBatch query.
+	FindStateResourcesScan(results pgx.BatchResults) ([]FindStateResourcesRow, error)
+
+	CountStateResources(ctx context.Context, params CountStateResourcesParams) (pgtype.Int8, error)
+	// CountStateResourcesBatch enqueues a CountStateResources query into batch to be executed
+	// later by the batch.
+	CountStateResourcesBatch(batch genericBatch, params CountStateResourcesParams)
+	// CountStateResourcesScan scans the result of an executed CountStateResourcesBatch query.
+	CountStateResourcesScan(results pgx.BatchResults) (pgtype.Int8, error)
+
 	InsertStateVersionOutput(ctx context.Context, params InsertStateVersionOutputParams) (pgconn.CommandTag, error)
 	// InsertStateVersionOutputBatch enqueues a InsertStateVersionOutput query into batch to be executed
 	// later by the batch.
@@ -846,6 +1306,13 @@ type Querier interface {
 	// FindTeamsByOrgScan scans the result of an executed FindTeamsByOrgBatch query.
 	FindTeamsByOrgScan(results pgx.BatchResults) ([]FindTeamsByOrgRow, error)
 
+	FindTeamsWithSSOTeamID(ctx context.Context, organizationNames []string) ([]FindTeamsWithSSOTeamIDRow, error)
+	// FindTeamsWithSSOTeamIDBatch enqueues a FindTeamsWithSSOTeamID query into batch to be executed
+	// later by the batch.
+	FindTeamsWithSSOTeamIDBatch(batch genericBatch, organizationNames []string)
+	// FindTeamsWithSSOTeamIDScan scans the result of an executed FindTeamsWithSSOTeamIDBatch query.
+	FindTeamsWithSSOTeamIDScan(results pgx.BatchResults) ([]FindTeamsWithSSOTeamIDRow, error)
+
 	FindTeamByName(ctx context.Context, name pgtype.Text, organizationName pgtype.Text) (FindTeamByNameRow, error)
 	// FindTeamByNameBatch enqueues a FindTeamByName query into batch to be executed
 	// later by the batch.
@@ -916,6 +1383,13 @@ type Querier interface {
 	// FindTeamTokensByIDScan scans the result of an executed FindTeamTokensByIDBatch query.
 	FindTeamTokensByIDScan(results pgx.BatchResults) ([]FindTeamTokensByIDRow, error)
 
+	UpdateTeamTokenLastUsed(ctx context.Context, params UpdateTeamTokenLastUsedParams) (pgconn.CommandTag, error)
+	// UpdateTeamTokenLastUsedBatch enqueues a UpdateTeamTokenLastUsed query into batch to be executed
+	// later by the batch.
+	UpdateTeamTokenLastUsedBatch(batch genericBatch, params UpdateTeamTokenLastUsedParams)
+	// UpdateTeamTokenLastUsedScan scans the result of an executed UpdateTeamTokenLastUsedBatch query.
+	UpdateTeamTokenLastUsedScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
 	DeleteTeamTokenByID(ctx context.Context, teamID pgtype.Text) (pgtype.Text, error)
 	// DeleteTeamTokenByIDBatch enqueues a DeleteTeamTokenByID query into batch to be executed
 	// later by the batch.
@@ -923,6 +1397,27 @@ type Querier interface {
 	// DeleteTeamTokenByIDScan scans the result of an executed DeleteTeamTokenByIDBatch query.
 	DeleteTeamTokenByIDScan(results pgx.BatchResults) (pgtype.Text, error)
 
+	InsertTest(ctx context.Context, runID pgtype.Text, status pgtype.Text) (pgconn.CommandTag, error)
+	// InsertTestBatch enqueues a InsertTest query into batch to be executed
+	// later by the batch.
+	InsertTestBatch(batch genericBatch, runID pgtype.Text, status pgtype.Text)
+	// InsertTestScan scans the result of an executed InsertTestBatch query.
+	InsertTestScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	UpdateTestStatusByID(ctx context.Context, status pgtype.Text, runID pgtype.Text) (pgtype.Text, error)
+	// UpdateTestStatusByIDBatch enqueues a UpdateTestStatusByID query into batch to be executed
+	// later by the batch.
+	UpdateTestStatusByIDBatch(batch genericBatch, status pgtype.Text, runID pgtype.Text)
+	// UpdateTestStatusByIDScan scans the result of an executed UpdateTestStatusByIDBatch query.
+	UpdateTestStatusByIDScan(results pgx.BatchResults) (pgtype.Text, error)
+
+	UpdateTestReportByID(ctx context.Context, report []byte, runID pgtype.Text) (pgtype.Text, error)
+	// UpdateTestReportByIDBatch enqueues a UpdateTestReportByID query into batch to be executed
+	// later by the batch.
+	UpdateTestReportByIDBatch(batch genericBatch, report []byte, runID pgtype.Text)
+	// UpdateTestReportByIDScan scans the result of an executed UpdateTestReportByIDBatch query.
+	UpdateTestReportByIDScan(results pgx.BatchResults) (pgtype.Text, error)
+
 	InsertToken(ctx context.Context, params InsertTokenParams) (pgconn.CommandTag, error)
 	// InsertTokenBatch enqueues a InsertToken query into batch to be executed
 	// later by the batch.
@@ -944,6 +1439,13 @@ type Querier interface {
 	// FindTokenByIDScan scans the result of an executed FindTokenByIDBatch query.
 	FindTokenByIDScan(results pgx.BatchResults) (FindTokenByIDRow, error)
 
+	UpdateTokenLastUsed(ctx context.Context, params UpdateTokenLastUsedParams) (pgconn.CommandTag, error)
+	// UpdateTokenLastUsedBatch enqueues a UpdateTokenLastUsed query into batch to be executed
+	// later by the batch.
+	UpdateTokenLastUsedBatch(batch genericBatch, params UpdateTokenLastUsedParams)
+	// UpdateTokenLastUsedScan scans the result of an executed UpdateTokenLastUsedBatch query.
+	UpdateTokenLastUsedScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
 	DeleteTokenByID(ctx context.Context, tokenID pgtype.Text) (pgtype.Text, error)
 	// DeleteTokenByIDBatch enqueues a DeleteTokenByID query into batch to be executed
 	// later by the batch.
@@ -1000,6 +1502,20 @@ type Querier interface {
 	// FindUserByAuthenticationTokenIDScan scans the result of an executed FindUserByAuthenticationTokenIDBatch query.
 	FindUserByAuthenticationTokenIDScan(results pgx.BatchResults) (FindUserByAuthenticationTokenIDRow, error)
 
+	FindUserByVCSIdentity(ctx context.Context, hostname pgtype.Text, vcsUsername pgtype.Text) (FindUserByVCSIdentityRow, error)
+	// FindUserByVCSIdentityBatch enqueues a FindUserByVCSIdentity query into batch to be executed
+	// later by the batch.
+	FindUserByVCSIdentityBatch(batch genericBatch, hostname pgtype.Text, vcsUsername pgtype.Text)
+	// FindUserByVCSIdentityScan scans the result of an executed FindUserByVCSIdentityBatch query.
+	FindUserByVCSIdentityScan(results pgx.BatchResults) (FindUserByVCSIdentityRow, error)
+
+	UpsertVCSIdentity(ctx context.Context, params UpsertVCSIdentityParams) (pgconn.CommandTag, error)
+	// UpsertVCSIdentityBatch enqueues a UpsertVCSIdentity query into batch to be executed
+	// later by the batch.
+	UpsertVCSIdentityBatch(batch genericBatch, params UpsertVCSIdentityParams)
+	// UpsertVCSIdentityScan scans the result of an executed UpsertVCSIdentityBatch query.
+	UpsertVCSIdentityScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
 	UpdateUserSiteAdmins(ctx context.Context, usernames []string) ([]pgtype.Text, error)
 	// UpdateUserSiteAdminsBatch enqueues a UpdateUserSiteAdmins query into batch to be executed
 	// later by the batch.
@@ -1147,6 +1663,20 @@ type Querier interface {
 	// DeleteVariableSetWorkspacesScan scans the result of an executed DeleteVariableSetWorkspacesBatch query.
 	DeleteVariableSetWorkspacesScan(results pgx.BatchResults) (pgconn.CommandTag, error)
 
+	InsertVariableSetProject(ctx context.Context, variableSetID pgtype.Text, projectID pgtype.Text) (pgconn.CommandTag, error)
+	// InsertVariableSetProjectBatch enqueues a InsertVariableSetProject query into batch to be executed
+	// later by the batch.
+	InsertVariableSetProjectBatch(batch genericBatch, variableSetID pgtype.Text, projectID pgtype.Text)
+	// InsertVariableSetProjectScan scans the result of an executed InsertVariableSetProjectBatch query.
+	InsertVariableSetProjectScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	DeleteVariableSetProjects(ctx context.Context, variableSetID pgtype.Text) (pgconn.CommandTag, error)
+	// DeleteVariableSetProjectsBatch enqueues a DeleteVariableSetProjects query into batch to be executed
+	// later by the batch.
+	DeleteVariableSetProjectsBatch(batch genericBatch, variableSetID pgtype.Text)
+	// DeleteVariableSetProjectsScan scans the result of an executed DeleteVariableSetProjectsBatch query.
+	DeleteVariableSetProjectsScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
 	InsertVCSProvider(ctx context.Context, params InsertVCSProviderParams) (pgconn.CommandTag, error)
 	// InsertVCSProviderBatch enqueues a InsertVCSProvider query into batch to be executed
 	// later by the batch.
@@ -1231,6 +1761,9 @@ type Querier interface {
 	// FindWorkspacesByConnectionScan scans the result of an executed FindWorkspacesByConnectionBatch query.
 	FindWorkspacesByConnectionScan(results pgx.BatchResults) ([]FindWorkspacesByConnectionRow, error)
 
+	// FindWorkspacesByUsername finds workspaces for which the user has been
+	// granted permissions, either directly or via the workspace's project.
+	//
 	FindWorkspacesByUsername(ctx context.Context, params FindWorkspacesByUsernameParams) ([]FindWorkspacesByUsernameRow, error)
 	// FindWorkspacesByUsernameBatch enqueues a FindWorkspacesByUsername query into batch to be executed
 	// later by the batch.
@@ -1238,10 +1771,10 @@ type Querier interface {
 	// FindWorkspacesByUsernameScan scans the result of an executed FindWorkspacesByUsernameBatch query.
 	FindWorkspacesByUsernameScan(results pgx.BatchResults) ([]FindWorkspacesByUsernameRow, error)
 
-	CountWorkspacesByUsername(ctx context.Context, organizationName pgtype.Text, username pgtype.Text) (pgtype.Int8, error)
+	CountWorkspacesByUsername(ctx context.Context, params CountWorkspacesByUsernameParams) (pgtype.Int8, error)
 	// CountWorkspacesByUsernameBatch enqueues a CountWorkspacesByUsername query into batch to be executed
 	// later by the batch.
-	CountWorkspacesByUsernameBatch(batch genericBatch, organizationName pgtype.Text, username pgtype.Text)
+	CountWorkspacesByUsernameBatch(batch genericBatch, params CountWorkspacesByUsernameParams)
 	// CountWorkspacesByUsernameScan scans the result of an executed CountWorkspacesByUsernameBatch query.
 	CountWorkspacesByUsernameScan(results pgx.BatchResults) (pgtype.Int8, error)
 
@@ -1480,6 +2013,54 @@ func PrepareAllQueries(ctx context.Context, p preparer) error {
 	if _, err := p.Prepare(ctx, deleteConfigurationVersionByIDSQL, deleteConfigurationVersionByIDSQL); err != nil {
 		return fmt.Errorf("prepare query 'DeleteConfigurationVersionByID': %w", err)
 	}
+	if _, err := p.Prepare(ctx, insertConfigurationVersionVariableSQL, insertConfigurationVersionVariableSQL); err != nil {
+		return fmt.Errorf("prepare query 'InsertConfigurationVersionVariable': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findConfigurationVersionVariablesSQL, findConfigurationVersionVariablesSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindConfigurationVersionVariables': %w", err)
+	}
+	if _, err := p.Prepare(ctx, insertDeviceAuthorizationSQL, insertDeviceAuthorizationSQL); err != nil {
+		return fmt.Errorf("prepare query 'InsertDeviceAuthorization': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findDeviceAuthorizationByUserCodeSQL, findDeviceAuthorizationByUserCodeSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindDeviceAuthorizationByUserCode': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findDeviceAuthorizationForUpdateSQL, findDeviceAuthorizationForUpdateSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindDeviceAuthorizationForUpdate': %w", err)
+	}
+	if _, err := p.Prepare(ctx, updateDeviceAuthorizationStatusSQL, updateDeviceAuthorizationStatusSQL); err != nil {
+		return fmt.Errorf("prepare query 'UpdateDeviceAuthorizationStatus': %w", err)
+	}
+	if _, err := p.Prepare(ctx, updateDeviceAuthorizationLastPolledSQL, updateDeviceAuthorizationLastPolledSQL); err != nil {
+		return fmt.Errorf("prepare query 'UpdateDeviceAuthorizationLastPolled': %w", err)
+	}
+	if _, err := p.Prepare(ctx, deleteDeviceAuthorizationSQL, deleteDeviceAuthorizationSQL); err != nil {
+		return fmt.Errorf("prepare query 'DeleteDeviceAuthorization': %w", err)
+	}
+	if _, err := p.Prepare(ctx, deleteExpiredDeviceAuthorizationsSQL, deleteExpiredDeviceAuthorizationsSQL); err != nil {
+		return fmt.Errorf("prepare query 'DeleteExpiredDeviceAuthorizations': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findEventsAfterSQL, findEventsAfterSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindEventsAfter': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findReplayStartEventIDSQL, findReplayStartEventIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindReplayStartEventID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, deleteEventsBeforeSQL, deleteEventsBeforeSQL); err != nil {
+		return fmt.Errorf("prepare query 'DeleteEventsBefore': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findEventsAfterOrMissingSQL, findEventsAfterOrMissingSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindEventsAfterOrMissing': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findLatestEventIDSQL, findLatestEventIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindLatestEventID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, insertConfigurationVersionModuleSQL, insertConfigurationVersionModuleSQL); err != nil {
+		return fmt.Errorf("prepare query 'InsertConfigurationVersionModule': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findWorkspaceSummariesSQL, findWorkspaceSummariesSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindWorkspaceSummaries': %w", err)
+	}
 	if _, err := p.Prepare(ctx, insertGithubAppSQL, insertGithubAppSQL); err != nil {
 		return fmt.Errorf("prepare query 'InsertGithubApp': %w", err)
 	}
@@ -1510,8 +2091,8 @@ func PrepareAllQueries(ctx context.Context, p preparer) error {
 	if _, err := p.Prepare(ctx, findModuleByIDSQL, findModuleByIDSQL); err != nil {
 		return fmt.Errorf("prepare query 'FindModuleByID': %w", err)
 	}
-	if _, err := p.Prepare(ctx, findModuleByConnectionSQL, findModuleByConnectionSQL); err != nil {
-		return fmt.Errorf("prepare query 'FindModuleByConnection': %w", err)
+	if _, err := p.Prepare(ctx, findModulesByConnectionSQL, findModulesByConnectionSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindModulesByConnection': %w", err)
 	}
 	if _, err := p.Prepare(ctx, findModuleByModuleVersionIDSQL, findModuleByModuleVersionIDSQL); err != nil {
 		return fmt.Errorf("prepare query 'FindModuleByModuleVersionID': %w", err)
@@ -1528,6 +2109,45 @@ func PrepareAllQueries(ctx context.Context, p preparer) error {
 	if _, err := p.Prepare(ctx, updateModuleVersionStatusByIDSQL, updateModuleVersionStatusByIDSQL); err != nil {
 		return fmt.Errorf("prepare query 'UpdateModuleVersionStatusByID': %w", err)
 	}
+	if _, err := p.Prepare(ctx, updateModuleVersionDeprecationByIDSQL, updateModuleVersionDeprecationByIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'UpdateModuleVersionDeprecationByID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, updateModuleVersionYankedByIDSQL, updateModuleVersionYankedByIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'UpdateModuleVersionYankedByID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findPendingModuleVersionTestsSQL, findPendingModuleVersionTestsSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindPendingModuleVersionTests': %w", err)
+	}
+	if _, err := p.Prepare(ctx, updateModuleVersionTestStartedSQL, updateModuleVersionTestStartedSQL); err != nil {
+		return fmt.Errorf("prepare query 'UpdateModuleVersionTestStarted': %w", err)
+	}
+	if _, err := p.Prepare(ctx, updateStaleModuleVersionTestsSQL, updateStaleModuleVersionTestsSQL); err != nil {
+		return fmt.Errorf("prepare query 'UpdateStaleModuleVersionTests': %w", err)
+	}
+	if _, err := p.Prepare(ctx, insertModuleVersionTestSQL, insertModuleVersionTestSQL); err != nil {
+		return fmt.Errorf("prepare query 'InsertModuleVersionTest': %w", err)
+	}
+	if _, err := p.Prepare(ctx, updateModuleVersionTestOutputSQL, updateModuleVersionTestOutputSQL); err != nil {
+		return fmt.Errorf("prepare query 'UpdateModuleVersionTestOutput': %w", err)
+	}
+	if _, err := p.Prepare(ctx, updateModuleVersionTestFinishedSQL, updateModuleVersionTestFinishedSQL); err != nil {
+		return fmt.Errorf("prepare query 'UpdateModuleVersionTestFinished': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findModuleVersionTestsByModuleIDSQL, findModuleVersionTestsByModuleIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindModuleVersionTestsByModuleID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, incrementModuleVersionDownloadsSQL, incrementModuleVersionDownloadsSQL); err != nil {
+		return fmt.Errorf("prepare query 'IncrementModuleVersionDownloads': %w", err)
+	}
+	if _, err := p.Prepare(ctx, deleteModuleVersionUsagesByWorkspaceIDSQL, deleteModuleVersionUsagesByWorkspaceIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'DeleteModuleVersionUsagesByWorkspaceID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, insertModuleVersionUsageSQL, insertModuleVersionUsageSQL); err != nil {
+		return fmt.Errorf("prepare query 'InsertModuleVersionUsage': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findModuleVersionUsagesByModuleIDSQL, findModuleVersionUsagesByModuleIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindModuleVersionUsagesByModuleID': %w", err)
+	}
 	if _, err := p.Prepare(ctx, deleteModuleByIDSQL, deleteModuleByIDSQL); err != nil {
 		return fmt.Errorf("prepare query 'DeleteModuleByID': %w", err)
 	}
@@ -1591,6 +2211,9 @@ func PrepareAllQueries(ctx context.Context, p preparer) error {
 	if _, err := p.Prepare(ctx, findOrganizationTokensByIDSQL, findOrganizationTokensByIDSQL); err != nil {
 		return fmt.Errorf("prepare query 'FindOrganizationTokensByID': %w", err)
 	}
+	if _, err := p.Prepare(ctx, updateOrganizationTokenLastUsedSQL, updateOrganizationTokenLastUsedSQL); err != nil {
+		return fmt.Errorf("prepare query 'UpdateOrganizationTokenLastUsed': %w", err)
+	}
 	if _, err := p.Prepare(ctx, deleteOrganiationTokenByNameSQL, deleteOrganiationTokenByNameSQL); err != nil {
 		return fmt.Errorf("prepare query 'DeleteOrganiationTokenByName': %w", err)
 	}
@@ -1627,6 +2250,93 @@ func PrepareAllQueries(ctx context.Context, p preparer) error {
 	if _, err := p.Prepare(ctx, updatePlanJSONByIDSQL, updatePlanJSONByIDSQL); err != nil {
 		return fmt.Errorf("prepare query 'UpdatePlanJSONByID': %w", err)
 	}
+	if _, err := p.Prepare(ctx, insertProjectSQL, insertProjectSQL); err != nil {
+		return fmt.Errorf("prepare query 'InsertProject': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findProjectsSQL, findProjectsSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindProjects': %w", err)
+	}
+	if _, err := p.Prepare(ctx, countProjectsSQL, countProjectsSQL); err != nil {
+		return fmt.Errorf("prepare query 'CountProjects': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findProjectByIDSQL, findProjectByIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindProjectByID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findProjectByIDForUpdateSQL, findProjectByIDForUpdateSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindProjectByIDForUpdate': %w", err)
+	}
+	if _, err := p.Prepare(ctx, updateProjectByIDSQL, updateProjectByIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'UpdateProjectByID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, deleteProjectByIDSQL, deleteProjectByIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'DeleteProjectByID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, upsertProjectPermissionSQL, upsertProjectPermissionSQL); err != nil {
+		return fmt.Errorf("prepare query 'UpsertProjectPermission': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findProjectPermissionsByProjectIDSQL, findProjectPermissionsByProjectIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindProjectPermissionsByProjectID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findProjectPermissionsByWorkspaceIDSQL, findProjectPermissionsByWorkspaceIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindProjectPermissionsByWorkspaceID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, deleteProjectPermissionByIDSQL, deleteProjectPermissionByIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'DeleteProjectPermissionByID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, insertRegistryGPGKeySQL, insertRegistryGPGKeySQL); err != nil {
+		return fmt.Errorf("prepare query 'InsertRegistryGPGKey': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findRegistryGPGKeysByOrganizationSQL, findRegistryGPGKeysByOrganizationSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindRegistryGPGKeysByOrganization': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findRegistryGPGKeyByIDSQL, findRegistryGPGKeyByIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindRegistryGPGKeyByID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, deleteRegistryGPGKeyByIDSQL, deleteRegistryGPGKeyByIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'DeleteRegistryGPGKeyByID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, insertRegistryProviderSQL, insertRegistryProviderSQL); err != nil {
+		return fmt.Errorf("prepare query 'InsertRegistryProvider': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findRegistryProvidersByOrganizationSQL, findRegistryProvidersByOrganizationSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindRegistryProvidersByOrganization': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findRegistryProviderByNameSQL, findRegistryProviderByNameSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindRegistryProviderByName': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findRegistryProviderByIDSQL, findRegistryProviderByIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindRegistryProviderByID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findRegistryProviderByVersionIDSQL, findRegistryProviderByVersionIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindRegistryProviderByVersionID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, deleteRegistryProviderByIDSQL, deleteRegistryProviderByIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'DeleteRegistryProviderByID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, insertRegistryProviderVersionSQL, insertRegistryProviderVersionSQL); err != nil {
+		return fmt.Errorf("prepare query 'InsertRegistryProviderVersion': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findRegistryProviderVersionsByProviderIDSQL, findRegistryProviderVersionsByProviderIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindRegistryProviderVersionsByProviderID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, deleteRegistryProviderVersionByIDSQL, deleteRegistryProviderVersionByIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'DeleteRegistryProviderVersionByID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, insertRegistryProviderPlatformSQL, insertRegistryProviderPlatformSQL); err != nil {
+		return fmt.Errorf("prepare query 'InsertRegistryProviderPlatform': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findRegistryProviderPlatformsByProviderIDSQL, findRegistryProviderPlatformsByProviderIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindRegistryProviderPlatformsByProviderID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findRegistryProviderPlatformByIDSQL, findRegistryProviderPlatformByIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindRegistryProviderPlatformByID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findRegistryProviderPlatformBinarySQL, findRegistryProviderPlatformBinarySQL); err != nil {
+		return fmt.Errorf("prepare query 'FindRegistryProviderPlatformBinary': %w", err)
+	}
+	if _, err := p.Prepare(ctx, deleteRegistryProviderPlatformByIDSQL, deleteRegistryProviderPlatformByIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'DeleteRegistryProviderPlatformByID': %w", err)
+	}
 	if _, err := p.Prepare(ctx, insertLatestTerraformVersionSQL, insertLatestTerraformVersionSQL); err != nil {
 		return fmt.Errorf("prepare query 'InsertLatestTerraformVersion': %w", err)
 	}
@@ -1732,6 +2442,24 @@ func PrepareAllQueries(ctx context.Context, p preparer) error {
 	if _, err := p.Prepare(ctx, deleteStateVersionByIDSQL, deleteStateVersionByIDSQL); err != nil {
 		return fmt.Errorf("prepare query 'DeleteStateVersionByID': %w", err)
 	}
+	if _, err := p.Prepare(ctx, insertStateVersionResourceSQL, insertStateVersionResourceSQL); err != nil {
+		return fmt.Errorf("prepare query 'InsertStateVersionResource': %w", err)
+	}
+	if _, err := p.Prepare(ctx, updateStateVersionResourcesIndexedSQL, updateStateVersionResourcesIndexedSQL); err != nil {
+		return fmt.Errorf("prepare query 'UpdateStateVersionResourcesIndexed': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findUnindexedCurrentStateVersionsByOrganizationSQL, findUnindexedCurrentStateVersionsByOrganizationSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindUnindexedCurrentStateVersionsByOrganization': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findCurrentStateWorkspaceIDsByOrganizationSQL, findCurrentStateWorkspaceIDsByOrganizationSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindCurrentStateWorkspaceIDsByOrganization': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findStateResourcesSQL, findStateResourcesSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindStateResources': %w", err)
+	}
+	if _, err := p.Prepare(ctx, countStateResourcesSQL, countStateResourcesSQL); err != nil {
+		return fmt.Errorf("prepare query 'CountStateResources': %w", err)
+	}
 	if _, err := p.Prepare(ctx, insertStateVersionOutputSQL, insertStateVersionOutputSQL); err != nil {
 		return fmt.Errorf("prepare query 'InsertStateVersionOutput': %w", err)
 	}
@@ -1777,6 +2505,9 @@ func PrepareAllQueries(ctx context.Context, p preparer) error {
 	if _, err := p.Prepare(ctx, findTeamsByOrgSQL, findTeamsByOrgSQL); err != nil {
 		return fmt.Errorf("prepare query 'FindTeamsByOrg': %w", err)
 	}
+	if _, err := p.Prepare(ctx, findTeamsWithSSOTeamIDSQL, findTeamsWithSSOTeamIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindTeamsWithSSOTeamID': %w", err)
+	}
 	if _, err := p.Prepare(ctx, findTeamByNameSQL, findTeamByNameSQL); err != nil {
 		return fmt.Errorf("prepare query 'FindTeamByName': %w", err)
 	}
@@ -1807,9 +2538,21 @@ func PrepareAllQueries(ctx context.Context, p preparer) error {
 	if _, err := p.Prepare(ctx, findTeamTokensByIDSQL, findTeamTokensByIDSQL); err != nil {
 		return fmt.Errorf("prepare query 'FindTeamTokensByID': %w", err)
 	}
+	if _, err := p.Prepare(ctx, updateTeamTokenLastUsedSQL, updateTeamTokenLastUsedSQL); err != nil {
+		return fmt.Errorf("prepare query 'UpdateTeamTokenLastUsed': %w", err)
+	}
 	if _, err := p.Prepare(ctx, deleteTeamTokenByIDSQL, deleteTeamTokenByIDSQL); err != nil {
 		return fmt.Errorf("prepare query 'DeleteTeamTokenByID': %w", err)
 	}
+	if _, err := p.Prepare(ctx, insertTestSQL, insertTestSQL); err != nil {
+		return fmt.Errorf("prepare query 'InsertTest': %w", err)
+	}
+	if _, err := p.Prepare(ctx, updateTestStatusByIDSQL, updateTestStatusByIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'UpdateTestStatusByID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, updateTestReportByIDSQL, updateTestReportByIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'UpdateTestReportByID': %w", err)
+	}
 	if _, err := p.Prepare(ctx, insertTokenSQL, insertTokenSQL); err != nil {
 		return fmt.Errorf("prepare query 'InsertToken': %w", err)
 	}
@@ -1819,6 +2562,9 @@ func PrepareAllQueries(ctx context.Context, p preparer) error {
 	if _, err := p.Prepare(ctx, findTokenByIDSQL, findTokenByIDSQL); err != nil {
 		return fmt.Errorf("prepare query 'FindTokenByID': %w", err)
 	}
+	if _, err := p.Prepare(ctx, updateTokenLastUsedSQL, updateTokenLastUsedSQL); err != nil {
+		return fmt.Errorf("prepare query 'UpdateTokenLastUsed': %w", err)
+	}
 	if _, err := p.Prepare(ctx, deleteTokenByIDSQL, deleteTokenByIDSQL); err != nil {
 		return fmt.Errorf("prepare query 'DeleteTokenByID': %w", err)
 	}
@@ -1843,6 +2589,12 @@ func PrepareAllQueries(ctx context.Context, p preparer) error {
 	if _, err := p.Prepare(ctx, findUserByAuthenticationTokenIDSQL, findUserByAuthenticationTokenIDSQL); err != nil {
 		return fmt.Errorf("prepare query 'FindUserByAuthenticationTokenID': %w", err)
 	}
+	if _, err := p.Prepare(ctx, findUserByVCSIdentitySQL, findUserByVCSIdentitySQL); err != nil {
+		return fmt.Errorf("prepare query 'FindUserByVCSIdentity': %w", err)
+	}
+	if _, err := p.Prepare(ctx, upsertVCSIdentitySQL, upsertVCSIdentitySQL); err != nil {
+		return fmt.Errorf("prepare query 'UpsertVCSIdentity': %w", err)
+	}
 	if _, err := p.Prepare(ctx, updateUserSiteAdminsSQL, updateUserSiteAdminsSQL); err != nil {
 		return fmt.Errorf("prepare query 'UpdateUserSiteAdmins': %w", err)
 	}
@@ -1906,6 +2658,12 @@ func PrepareAllQueries(ctx context.Context, p preparer) error {
 	if _, err := p.Prepare(ctx, deleteVariableSetWorkspacesSQL, deleteVariableSetWorkspacesSQL); err != nil {
 		return fmt.Errorf("prepare query 'DeleteVariableSetWorkspaces': %w", err)
 	}
+	if _, err := p.Prepare(ctx, insertVariableSetProjectSQL, insertVariableSetProjectSQL); err != nil {
+		return fmt.Errorf("prepare query 'InsertVariableSetProject': %w", err)
+	}
+	if _, err := p.Prepare(ctx, deleteVariableSetProjectsSQL, deleteVariableSetProjectsSQL); err != nil {
+		return fmt.Errorf("prepare query 'DeleteVariableSetProjects': %w", err)
+	}
 	if _, err := p.Prepare(ctx, insertVCSProviderSQL, insertVCSProviderSQL); err != nil {
 		return fmt.Errorf("prepare query 'InsertVCSProvider': %w", err)
 	}
@@ -2041,13 +2799,18 @@ type IngressAttributes struct {
 
 // ModuleVersions represents the Postgres composite type "module_versions".
 type ModuleVersions struct {
-	ModuleVersionID pgtype.Text        `json:"module_version_id"`
-	Version         pgtype.Text        `json:"version"`
-	CreatedAt       pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
-	Status          pgtype.Text        `json:"status"`
-	StatusError     pgtype.Text        `json:"status_error"`
-	ModuleID        pgtype.Text        `json:"module_id"`
+	ModuleVersionID    pgtype.Text        `json:"module_version_id"`
+	Version            pgtype.Text        `json:"version"`
+	CreatedAt          pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt          pgtype.Timestamptz `json:"updated_at"`
+	Status             pgtype.Text        `json:"status"`
+	StatusError        pgtype.Text        `json:"status_error"`
+	ModuleID           pgtype.Text        `json:"module_id"`
+	Downloads          pgtype.Int4        `json:"downloads"`
+	Deprecated         bool               `json:"deprecated"`
+	DeprecationReason  pgtype.Text        `json:"deprecation_reason"`
+	ReplacementVersion pgtype.Text        `json:"replacement_version"`
+	Yanked             bool               `json:"yanked"`
 }
 
 // PhaseStatusTimestamps represents the Postgres composite type "phase_status_timestamps".
@@ -2108,6 +2871,7 @@ type Runs struct {
 	Source                 pgtype.Text        `json:"source"`
 	TerraformVersion       pgtype.Text        `json:"terraform_version"`
 	AllowEmptyApply        bool               `json:"allow_empty_apply"`
+	TestOnly               bool               `json:"test_only"`
 }
 
 // StateVersionOutputs represents the Postgres composite type "state_version_outputs".
@@ -2147,14 +2911,15 @@ type Users struct {
 
 // Variables represents the Postgres composite type "variables".
 type Variables struct {
-	VariableID  pgtype.Text `json:"variable_id"`
-	Key         pgtype.Text `json:"key"`
-	Value       pgtype.Text `json:"value"`
-	Description pgtype.Text `json:"description"`
-	Category    pgtype.Text `json:"category"`
-	Sensitive   bool        `json:"sensitive"`
-	HCL         bool        `json:"hcl"`
-	VersionID   pgtype.Text `json:"version_id"`
+	VariableID      pgtype.Text `json:"variable_id"`
+	Key             pgtype.Text `json:"key"`
+	Value           pgtype.Text `json:"value"`
+	Description     pgtype.Text `json:"description"`
+	Category        pgtype.Text `json:"category"`
+	Sensitive       bool        `json:"sensitive"`
+	HCL             bool        `json:"hcl"`
+	VersionID       pgtype.Text `json:"version_id"`
+	SecretReference bool        `json:"secret_reference"`
 }
 
 // typeResolver looks up the pgtype.ValueTranscoder by Postgres type name.
@@ -2314,6 +3079,11 @@ func (tr *typeResolver) newModuleVersions() pgtype.ValueTranscoder {
 		compositeField{"status", "text", &pgtype.Text{}},
 		compositeField{"status_error", "text", &pgtype.Text{}},
 		compositeField{"module_id", "text", &pgtype.Text{}},
+		compositeField{"downloads", "int4", &pgtype.Int4{}},
+		compositeField{"deprecated", "bool", &pgtype.Bool{}},
+		compositeField{"deprecation_reason", "text", &pgtype.Text{}},
+		compositeField{"replacement_version", "text", &pgtype.Text{}},
+		compositeField{"yanked", "bool", &pgtype.Bool{}},
 	)
 }
 
@@ -2398,6 +3168,7 @@ func (tr *typeResolver) newRuns() pgtype.ValueTranscoder {
 		compositeField{"source", "text", &pgtype.Text{}},
 		compositeField{"terraform_version", "text", &pgtype.Text{}},
 		compositeField{"allow_empty_apply", "bool", &pgtype.Bool{}},
+		compositeField{"test_only", "bool", &pgtype.Bool{}},
 	)
 }
 
@@ -2461,6 +3232,7 @@ func (tr *typeResolver) newVariables() pgtype.ValueTranscoder {
 		compositeField{"sensitive", "bool", &pgtype.Bool{}},
 		compositeField{"hcl", "bool", &pgtype.Bool{}},
 		compositeField{"version_id", "text", &pgtype.Text{}},
+		compositeField{"secret_reference", "bool", &pgtype.Bool{}},
 	)
 }
 
@@ -597,3 +597,119 @@ func (q *DBQuerier) DeleteConfigurationVersionByIDScan(results pgx.BatchResults)
 	}
 	return item, nil
 }
+
+const insertConfigurationVersionVariableSQL = `INSERT INTO configuration_version_variables (
+    configuration_version_id,
+    directory,
+    name,
+    type,
+    default_value,
+    description,
+    sensitive
+) VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    $7
+)
+ON CONFLICT DO NOTHING;`
+
+type InsertConfigurationVersionVariableParams struct {
+	ConfigurationVersionID pgtype.Text
+	Directory              pgtype.Text
+	Name                   pgtype.Text
+	Type                   pgtype.Text
+	DefaultValue           pgtype.Text
+	Description            pgtype.Text
+	Sensitive              bool
+}
+
+// InsertConfigurationVersionVariable implements Querier.InsertConfigurationVersionVariable.
+func (q *DBQuerier) InsertConfigurationVersionVariable(ctx context.Context, params InsertConfigurationVersionVariableParams) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "InsertConfigurationVersionVariable")
+	cmdTag, err := q.conn.Exec(ctx, insertConfigurationVersionVariableSQL, params.ConfigurationVersionID, params.Directory, params.Name, params.Type, params.DefaultValue, params.Description, params.Sensitive)
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec query InsertConfigurationVersionVariable: %w", err)
+	}
+	return cmdTag, err
+}
+
+// InsertConfigurationVersionVariableBatch implements Querier.InsertConfigurationVersionVariableBatch.
+func (q *DBQuerier) InsertConfigurationVersionVariableBatch(batch genericBatch, params InsertConfigurationVersionVariableParams) {
+	batch.Queue(insertConfigurationVersionVariableSQL, params.ConfigurationVersionID, params.Directory, params.Name, params.Type, params.DefaultValue, params.Description, params.Sensitive)
+}
+
+// InsertConfigurationVersionVariableScan implements Querier.InsertConfigurationVersionVariableScan.
+func (q *DBQuerier) InsertConfigurationVersionVariableScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec InsertConfigurationVersionVariableBatch: %w", err)
+	}
+	return cmdTag, err
+}
+
+const findConfigurationVersionVariablesSQL = `SELECT *
+FROM configuration_version_variables
+WHERE configuration_version_id = $1
+ORDER BY directory, name;`
+
+type FindConfigurationVersionVariablesRow struct {
+	ConfigurationVersionID pgtype.Text `json:"configuration_version_id"`
+	Directory              pgtype.Text `json:"directory"`
+	Name                   pgtype.Text `json:"name"`
+	Type                   pgtype.Text `json:"type"`
+	DefaultValue           pgtype.Text `json:"default_value"`
+	Description            pgtype.Text `json:"description"`
+	Sensitive              bool        `json:"sensitive"`
+}
+
+// FindConfigurationVersionVariables implements Querier.FindConfigurationVersionVariables.
+func (q *DBQuerier) FindConfigurationVersionVariables(ctx context.Context, configurationVersionID pgtype.Text) ([]FindConfigurationVersionVariablesRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "FindConfigurationVersionVariables")
+	rows, err := q.conn.Query(ctx, findConfigurationVersionVariablesSQL, configurationVersionID)
+	if err != nil {
+		return nil, fmt.Errorf("query FindConfigurationVersionVariables: %w", err)
+	}
+	defer rows.Close()
+	items := []FindConfigurationVersionVariablesRow{}
+	for rows.Next() {
+		var item FindConfigurationVersionVariablesRow
+		if err := rows.Scan(&item.ConfigurationVersionID, &item.Directory, &item.Name, &item.Type, &item.DefaultValue, &item.Description, &item.Sensitive); err != nil {
+			return nil, fmt.Errorf("scan FindConfigurationVersionVariables row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindConfigurationVersionVariables rows: %w", err)
+	}
+	return items, err
+}
+
+// FindConfigurationVersionVariablesBatch implements Querier.FindConfigurationVersionVariablesBatch.
+func (q *DBQuerier) FindConfigurationVersionVariablesBatch(batch genericBatch, configurationVersionID pgtype.Text) {
+	batch.Queue(findConfigurationVersionVariablesSQL, configurationVersionID)
+}
+
+// FindConfigurationVersionVariablesScan implements Querier.FindConfigurationVersionVariablesScan.
+func (q *DBQuerier) FindConfigurationVersionVariablesScan(results pgx.BatchResults) ([]FindConfigurationVersionVariablesRow, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query FindConfigurationVersionVariablesBatch: %w", err)
+	}
+	defer rows.Close()
+	items := []FindConfigurationVersionVariablesRow{}
+	for rows.Next() {
+		var item FindConfigurationVersionVariablesRow
+		if err := rows.Scan(&item.ConfigurationVersionID, &item.Directory, &item.Name, &item.Type, &item.DefaultValue, &item.Description, &item.Sensitive); err != nil {
+			return nil, fmt.Errorf("scan FindConfigurationVersionVariablesBatch row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindConfigurationVersionVariablesBatch rows: %w", err)
+	}
+	return items, err
+}
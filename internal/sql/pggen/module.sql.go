@@ -18,7 +18,10 @@ const insertModuleSQL = `INSERT INTO modules (
     name,
     provider,
     status,
-    organization_name
+    organization_name,
+    path,
+    tag_prefix,
+    run_tests
 ) VALUES (
     $1,
     $2,
@@ -26,7 +29,10 @@ const insertModuleSQL = `INSERT INTO modules (
     $4,
     $5,
     $6,
-    $7
+    $7,
+    $8,
+    $9,
+    $10
 );`
 
 type InsertModuleParams struct {
@@ -37,12 +43,15 @@ type InsertModuleParams struct {
 	Provider         pgtype.Text
 	Status           pgtype.Text
 	OrganizationName pgtype.Text
+	Path             pgtype.Text
+	TagPrefix        pgtype.Text
+	RunTests         bool
 }
 
 // InsertModule implements Querier.InsertModule.
 func (q *DBQuerier) InsertModule(ctx context.Context, params InsertModuleParams) (pgconn.CommandTag, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "InsertModule")
-	cmdTag, err := q.conn.Exec(ctx, insertModuleSQL, params.ID, params.CreatedAt, params.UpdatedAt, params.Name, params.Provider, params.Status, params.OrganizationName)
+	cmdTag, err := q.conn.Exec(ctx, insertModuleSQL, params.ID, params.CreatedAt, params.UpdatedAt, params.Name, params.Provider, params.Status, params.OrganizationName, params.Path, params.TagPrefix, params.RunTests)
 	if err != nil {
 		return cmdTag, fmt.Errorf("exec query InsertModule: %w", err)
 	}
@@ -51,7 +60,7 @@ func (q *DBQuerier) InsertModule(ctx context.Context, params InsertModuleParams)
 
 // InsertModuleBatch implements Querier.InsertModuleBatch.
 func (q *DBQuerier) InsertModuleBatch(batch genericBatch, params InsertModuleParams) {
-	batch.Queue(insertModuleSQL, params.ID, params.CreatedAt, params.UpdatedAt, params.Name, params.Provider, params.Status, params.OrganizationName)
+	batch.Queue(insertModuleSQL, params.ID, params.CreatedAt, params.UpdatedAt, params.Name, params.Provider, params.Status, params.OrganizationName, params.Path, params.TagPrefix, params.RunTests)
 }
 
 // InsertModuleScan implements Querier.InsertModuleScan.
@@ -90,13 +99,18 @@ type InsertModuleVersionParams struct {
 }
 
 type InsertModuleVersionRow struct {
-	ModuleVersionID pgtype.Text        `json:"module_version_id"`
-	Version         pgtype.Text        `json:"version"`
-	CreatedAt       pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
-	Status          pgtype.Text        `json:"status"`
-	StatusError     pgtype.Text        `json:"status_error"`
-	ModuleID        pgtype.Text        `json:"module_id"`
+	ModuleVersionID    pgtype.Text        `json:"module_version_id"`
+	Version            pgtype.Text        `json:"version"`
+	CreatedAt          pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt          pgtype.Timestamptz `json:"updated_at"`
+	Status             pgtype.Text        `json:"status"`
+	StatusError        pgtype.Text        `json:"status_error"`
+	ModuleID           pgtype.Text        `json:"module_id"`
+	Downloads          pgtype.Int4        `json:"downloads"`
+	Deprecated         bool               `json:"deprecated"`
+	DeprecationReason  pgtype.Text        `json:"deprecation_reason"`
+	ReplacementVersion pgtype.Text        `json:"replacement_version"`
+	Yanked             bool               `json:"yanked"`
 }
 
 // InsertModuleVersion implements Querier.InsertModuleVersion.
@@ -104,7 +118,7 @@ func (q *DBQuerier) InsertModuleVersion(ctx context.Context, params InsertModule
 	ctx = context.WithValue(ctx, "pggen_query_name", "InsertModuleVersion")
 	row := q.conn.QueryRow(ctx, insertModuleVersionSQL, params.ModuleVersionID, params.Version, params.CreatedAt, params.UpdatedAt, params.ModuleID, params.Status)
 	var item InsertModuleVersionRow
-	if err := row.Scan(&item.ModuleVersionID, &item.Version, &item.CreatedAt, &item.UpdatedAt, &item.Status, &item.StatusError, &item.ModuleID); err != nil {
+	if err := row.Scan(&item.ModuleVersionID, &item.Version, &item.CreatedAt, &item.UpdatedAt, &item.Status, &item.StatusError, &item.ModuleID, &item.Downloads, &item.Deprecated, &item.DeprecationReason, &item.ReplacementVersion, &item.Yanked); err != nil {
 		return item, fmt.Errorf("query InsertModuleVersion: %w", err)
 	}
 	return item, nil
@@ -119,7 +133,7 @@ func (q *DBQuerier) InsertModuleVersionBatch(batch genericBatch, params InsertMo
 func (q *DBQuerier) InsertModuleVersionScan(results pgx.BatchResults) (InsertModuleVersionRow, error) {
 	row := results.QueryRow()
 	var item InsertModuleVersionRow
-	if err := row.Scan(&item.ModuleVersionID, &item.Version, &item.CreatedAt, &item.UpdatedAt, &item.Status, &item.StatusError, &item.ModuleID); err != nil {
+	if err := row.Scan(&item.ModuleVersionID, &item.Version, &item.CreatedAt, &item.UpdatedAt, &item.Status, &item.StatusError, &item.ModuleID, &item.Downloads, &item.Deprecated, &item.DeprecationReason, &item.ReplacementVersion, &item.Yanked); err != nil {
 		return item, fmt.Errorf("scan InsertModuleVersionBatch row: %w", err)
 	}
 	return item, nil
@@ -133,6 +147,9 @@ const listModulesByOrganizationSQL = `SELECT
     m.provider,
     m.status,
     m.organization_name,
+    m.path,
+    m.tag_prefix,
+    m.run_tests,
     (r.*)::"repo_connections" AS module_connection,
     (
         SELECT array_agg(v.*) AS versions
@@ -152,6 +169,9 @@ type ListModulesByOrganizationRow struct {
 	Provider         pgtype.Text        `json:"provider"`
 	Status           pgtype.Text        `json:"status"`
 	OrganizationName pgtype.Text        `json:"organization_name"`
+	Path             pgtype.Text        `json:"path"`
+	TagPrefix        pgtype.Text        `json:"tag_prefix"`
+	RunTests         bool               `json:"run_tests"`
 	ModuleConnection *RepoConnections   `json:"module_connection"`
 	Versions         []ModuleVersions   `json:"versions"`
 }
@@ -169,7 +189,7 @@ func (q *DBQuerier) ListModulesByOrganization(ctx context.Context, organizationN
 	versionsArray := q.types.newModuleVersionsArray()
 	for rows.Next() {
 		var item ListModulesByOrganizationRow
-		if err := rows.Scan(&item.ModuleID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.Provider, &item.Status, &item.OrganizationName, moduleConnectionRow, versionsArray); err != nil {
+		if err := rows.Scan(&item.ModuleID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.Provider, &item.Status, &item.OrganizationName, &item.Path, &item.TagPrefix, &item.RunTests, moduleConnectionRow, versionsArray); err != nil {
 			return nil, fmt.Errorf("scan ListModulesByOrganization row: %w", err)
 		}
 		if err := moduleConnectionRow.AssignTo(&item.ModuleConnection); err != nil {
@@ -203,7 +223,7 @@ func (q *DBQuerier) ListModulesByOrganizationScan(results pgx.BatchResults) ([]L
 	versionsArray := q.types.newModuleVersionsArray()
 	for rows.Next() {
 		var item ListModulesByOrganizationRow
-		if err := rows.Scan(&item.ModuleID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.Provider, &item.Status, &item.OrganizationName, moduleConnectionRow, versionsArray); err != nil {
+		if err := rows.Scan(&item.ModuleID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.Provider, &item.Status, &item.OrganizationName, &item.Path, &item.TagPrefix, &item.RunTests, moduleConnectionRow, versionsArray); err != nil {
 			return nil, fmt.Errorf("scan ListModulesByOrganizationBatch row: %w", err)
 		}
 		if err := moduleConnectionRow.AssignTo(&item.ModuleConnection); err != nil {
@@ -228,6 +248,9 @@ const findModuleByNameSQL = `SELECT
     m.provider,
     m.status,
     m.organization_name,
+    m.path,
+    m.tag_prefix,
+    m.run_tests,
     (r.*)::"repo_connections" AS module_connection,
     (
         SELECT array_agg(v.*) AS versions
@@ -255,6 +278,9 @@ type FindModuleByNameRow struct {
 	Provider         pgtype.Text        `json:"provider"`
 	Status           pgtype.Text        `json:"status"`
 	OrganizationName pgtype.Text        `json:"organization_name"`
+	Path             pgtype.Text        `json:"path"`
+	TagPrefix        pgtype.Text        `json:"tag_prefix"`
+	RunTests         bool               `json:"run_tests"`
 	ModuleConnection *RepoConnections   `json:"module_connection"`
 	Versions         []ModuleVersions   `json:"versions"`
 }
@@ -266,7 +292,7 @@ func (q *DBQuerier) FindModuleByName(ctx context.Context, params FindModuleByNam
 	var item FindModuleByNameRow
 	moduleConnectionRow := q.types.newRepoConnections()
 	versionsArray := q.types.newModuleVersionsArray()
-	if err := row.Scan(&item.ModuleID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.Provider, &item.Status, &item.OrganizationName, moduleConnectionRow, versionsArray); err != nil {
+	if err := row.Scan(&item.ModuleID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.Provider, &item.Status, &item.OrganizationName, &item.Path, &item.TagPrefix, &item.RunTests, moduleConnectionRow, versionsArray); err != nil {
 		return item, fmt.Errorf("query FindModuleByName: %w", err)
 	}
 	if err := moduleConnectionRow.AssignTo(&item.ModuleConnection); err != nil {
@@ -289,7 +315,7 @@ func (q *DBQuerier) FindModuleByNameScan(results pgx.BatchResults) (FindModuleBy
 	var item FindModuleByNameRow
 	moduleConnectionRow := q.types.newRepoConnections()
 	versionsArray := q.types.newModuleVersionsArray()
-	if err := row.Scan(&item.ModuleID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.Provider, &item.Status, &item.OrganizationName, moduleConnectionRow, versionsArray); err != nil {
+	if err := row.Scan(&item.ModuleID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.Provider, &item.Status, &item.OrganizationName, &item.Path, &item.TagPrefix, &item.RunTests, moduleConnectionRow, versionsArray); err != nil {
 		return item, fmt.Errorf("scan FindModuleByNameBatch row: %w", err)
 	}
 	if err := moduleConnectionRow.AssignTo(&item.ModuleConnection); err != nil {
@@ -309,6 +335,9 @@ const findModuleByIDSQL = `SELECT
     m.provider,
     m.status,
     m.organization_name,
+    m.path,
+    m.tag_prefix,
+    m.run_tests,
     (r.*)::"repo_connections" AS module_connection,
     (
         SELECT array_agg(v.*) AS versions
@@ -328,6 +357,9 @@ type FindModuleByIDRow struct {
 	Provider         pgtype.Text        `json:"provider"`
 	Status           pgtype.Text        `json:"status"`
 	OrganizationName pgtype.Text        `json:"organization_name"`
+	Path             pgtype.Text        `json:"path"`
+	TagPrefix        pgtype.Text        `json:"tag_prefix"`
+	RunTests         bool               `json:"run_tests"`
 	ModuleConnection *RepoConnections   `json:"module_connection"`
 	Versions         []ModuleVersions   `json:"versions"`
 }
@@ -339,7 +371,7 @@ func (q *DBQuerier) FindModuleByID(ctx context.Context, id pgtype.Text) (FindMod
 	var item FindModuleByIDRow
 	moduleConnectionRow := q.types.newRepoConnections()
 	versionsArray := q.types.newModuleVersionsArray()
-	if err := row.Scan(&item.ModuleID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.Provider, &item.Status, &item.OrganizationName, moduleConnectionRow, versionsArray); err != nil {
+	if err := row.Scan(&item.ModuleID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.Provider, &item.Status, &item.OrganizationName, &item.Path, &item.TagPrefix, &item.RunTests, moduleConnectionRow, versionsArray); err != nil {
 		return item, fmt.Errorf("query FindModuleByID: %w", err)
 	}
 	if err := moduleConnectionRow.AssignTo(&item.ModuleConnection); err != nil {
@@ -362,7 +394,7 @@ func (q *DBQuerier) FindModuleByIDScan(results pgx.BatchResults) (FindModuleByID
 	var item FindModuleByIDRow
 	moduleConnectionRow := q.types.newRepoConnections()
 	versionsArray := q.types.newModuleVersionsArray()
-	if err := row.Scan(&item.ModuleID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.Provider, &item.Status, &item.OrganizationName, moduleConnectionRow, versionsArray); err != nil {
+	if err := row.Scan(&item.ModuleID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.Provider, &item.Status, &item.OrganizationName, &item.Path, &item.TagPrefix, &item.RunTests, moduleConnectionRow, versionsArray); err != nil {
 		return item, fmt.Errorf("scan FindModuleByIDBatch row: %w", err)
 	}
 	if err := moduleConnectionRow.AssignTo(&item.ModuleConnection); err != nil {
@@ -374,7 +406,7 @@ func (q *DBQuerier) FindModuleByIDScan(results pgx.BatchResults) (FindModuleByID
 	return item, nil
 }
 
-const findModuleByConnectionSQL = `SELECT
+const findModulesByConnectionSQL = `SELECT
     m.module_id,
     m.created_at,
     m.updated_at,
@@ -382,6 +414,9 @@ const findModuleByConnectionSQL = `SELECT
     m.provider,
     m.status,
     m.organization_name,
+    m.path,
+    m.tag_prefix,
+    m.run_tests,
     (r.*)::"repo_connections" AS module_connection,
     (
         SELECT array_agg(v.*) AS versions
@@ -394,7 +429,7 @@ WHERE r.vcs_provider_id = $1
 AND   r.repo_path = $2
 ;`
 
-type FindModuleByConnectionRow struct {
+type FindModulesByConnectionRow struct {
 	ModuleID         pgtype.Text        `json:"module_id"`
 	CreatedAt        pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
@@ -402,50 +437,75 @@ type FindModuleByConnectionRow struct {
 	Provider         pgtype.Text        `json:"provider"`
 	Status           pgtype.Text        `json:"status"`
 	OrganizationName pgtype.Text        `json:"organization_name"`
+	Path             pgtype.Text        `json:"path"`
+	TagPrefix        pgtype.Text        `json:"tag_prefix"`
+	RunTests         bool               `json:"run_tests"`
 	ModuleConnection *RepoConnections   `json:"module_connection"`
 	Versions         []ModuleVersions   `json:"versions"`
 }
 
-// FindModuleByConnection implements Querier.FindModuleByConnection.
-func (q *DBQuerier) FindModuleByConnection(ctx context.Context, vcsProviderID pgtype.Text, repoPath pgtype.Text) (FindModuleByConnectionRow, error) {
-	ctx = context.WithValue(ctx, "pggen_query_name", "FindModuleByConnection")
-	row := q.conn.QueryRow(ctx, findModuleByConnectionSQL, vcsProviderID, repoPath)
-	var item FindModuleByConnectionRow
+// FindModulesByConnection implements Querier.FindModulesByConnection.
+func (q *DBQuerier) FindModulesByConnection(ctx context.Context, vcsProviderID pgtype.Text, repoPath pgtype.Text) ([]FindModulesByConnectionRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "FindModulesByConnection")
+	rows, err := q.conn.Query(ctx, findModulesByConnectionSQL, vcsProviderID, repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("query FindModulesByConnection: %w", err)
+	}
+	defer rows.Close()
+	items := []FindModulesByConnectionRow{}
 	moduleConnectionRow := q.types.newRepoConnections()
 	versionsArray := q.types.newModuleVersionsArray()
-	if err := row.Scan(&item.ModuleID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.Provider, &item.Status, &item.OrganizationName, moduleConnectionRow, versionsArray); err != nil {
-		return item, fmt.Errorf("query FindModuleByConnection: %w", err)
-	}
-	if err := moduleConnectionRow.AssignTo(&item.ModuleConnection); err != nil {
-		return item, fmt.Errorf("assign FindModuleByConnection row: %w", err)
+	for rows.Next() {
+		var item FindModulesByConnectionRow
+		if err := rows.Scan(&item.ModuleID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.Provider, &item.Status, &item.OrganizationName, &item.Path, &item.TagPrefix, &item.RunTests, moduleConnectionRow, versionsArray); err != nil {
+			return nil, fmt.Errorf("scan FindModulesByConnection row: %w", err)
+		}
+		if err := moduleConnectionRow.AssignTo(&item.ModuleConnection); err != nil {
+			return nil, fmt.Errorf("assign FindModulesByConnection row: %w", err)
+		}
+		if err := versionsArray.AssignTo(&item.Versions); err != nil {
+			return nil, fmt.Errorf("assign FindModulesByConnection row: %w", err)
+		}
+		items = append(items, item)
 	}
-	if err := versionsArray.AssignTo(&item.Versions); err != nil {
-		return item, fmt.Errorf("assign FindModuleByConnection row: %w", err)
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindModulesByConnection rows: %w", err)
 	}
-	return item, nil
+	return items, err
 }
 
-// FindModuleByConnectionBatch implements Querier.FindModuleByConnectionBatch.
-func (q *DBQuerier) FindModuleByConnectionBatch(batch genericBatch, vcsProviderID pgtype.Text, repoPath pgtype.Text) {
-	batch.Queue(findModuleByConnectionSQL, vcsProviderID, repoPath)
+// FindModulesByConnectionBatch implements Querier.FindModulesByConnectionBatch.
+func (q *DBQuerier) FindModulesByConnectionBatch(batch genericBatch, vcsProviderID pgtype.Text, repoPath pgtype.Text) {
+	batch.Queue(findModulesByConnectionSQL, vcsProviderID, repoPath)
 }
 
-// FindModuleByConnectionScan implements Querier.FindModuleByConnectionScan.
-func (q *DBQuerier) FindModuleByConnectionScan(results pgx.BatchResults) (FindModuleByConnectionRow, error) {
-	row := results.QueryRow()
-	var item FindModuleByConnectionRow
+// FindModulesByConnectionScan implements Querier.FindModulesByConnectionScan.
+func (q *DBQuerier) FindModulesByConnectionScan(results pgx.BatchResults) ([]FindModulesByConnectionRow, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query FindModulesByConnectionBatch: %w", err)
+	}
+	defer rows.Close()
+	items := []FindModulesByConnectionRow{}
 	moduleConnectionRow := q.types.newRepoConnections()
 	versionsArray := q.types.newModuleVersionsArray()
-	if err := row.Scan(&item.ModuleID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.Provider, &item.Status, &item.OrganizationName, moduleConnectionRow, versionsArray); err != nil {
-		return item, fmt.Errorf("scan FindModuleByConnectionBatch row: %w", err)
-	}
-	if err := moduleConnectionRow.AssignTo(&item.ModuleConnection); err != nil {
-		return item, fmt.Errorf("assign FindModuleByConnection row: %w", err)
+	for rows.Next() {
+		var item FindModulesByConnectionRow
+		if err := rows.Scan(&item.ModuleID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.Provider, &item.Status, &item.OrganizationName, &item.Path, &item.TagPrefix, &item.RunTests, moduleConnectionRow, versionsArray); err != nil {
+			return nil, fmt.Errorf("scan FindModulesByConnectionBatch row: %w", err)
+		}
+		if err := moduleConnectionRow.AssignTo(&item.ModuleConnection); err != nil {
+			return nil, fmt.Errorf("assign FindModulesByConnection row: %w", err)
+		}
+		if err := versionsArray.AssignTo(&item.Versions); err != nil {
+			return nil, fmt.Errorf("assign FindModulesByConnection row: %w", err)
+		}
+		items = append(items, item)
 	}
-	if err := versionsArray.AssignTo(&item.Versions); err != nil {
-		return item, fmt.Errorf("assign FindModuleByConnection row: %w", err)
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindModulesByConnectionBatch rows: %w", err)
 	}
-	return item, nil
+	return items, err
 }
 
 const findModuleByModuleVersionIDSQL = `SELECT
@@ -456,6 +516,9 @@ const findModuleByModuleVersionIDSQL = `SELECT
     m.provider,
     m.status,
     m.organization_name,
+    m.path,
+    m.tag_prefix,
+    m.run_tests,
     (r.*)::"repo_connections" AS module_connection,
     (
         SELECT array_agg(v.*) AS versions
@@ -476,6 +539,9 @@ type FindModuleByModuleVersionIDRow struct {
 	Provider         pgtype.Text        `json:"provider"`
 	Status           pgtype.Text        `json:"status"`
 	OrganizationName pgtype.Text        `json:"organization_name"`
+	Path             pgtype.Text        `json:"path"`
+	TagPrefix        pgtype.Text        `json:"tag_prefix"`
+	RunTests         bool               `json:"run_tests"`
 	ModuleConnection *RepoConnections   `json:"module_connection"`
 	Versions         []ModuleVersions   `json:"versions"`
 }
@@ -487,7 +553,7 @@ func (q *DBQuerier) FindModuleByModuleVersionID(ctx context.Context, moduleVersi
 	var item FindModuleByModuleVersionIDRow
 	moduleConnectionRow := q.types.newRepoConnections()
 	versionsArray := q.types.newModuleVersionsArray()
-	if err := row.Scan(&item.ModuleID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.Provider, &item.Status, &item.OrganizationName, moduleConnectionRow, versionsArray); err != nil {
+	if err := row.Scan(&item.ModuleID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.Provider, &item.Status, &item.OrganizationName, &item.Path, &item.TagPrefix, &item.RunTests, moduleConnectionRow, versionsArray); err != nil {
 		return item, fmt.Errorf("query FindModuleByModuleVersionID: %w", err)
 	}
 	if err := moduleConnectionRow.AssignTo(&item.ModuleConnection); err != nil {
@@ -510,7 +576,7 @@ func (q *DBQuerier) FindModuleByModuleVersionIDScan(results pgx.BatchResults) (F
 	var item FindModuleByModuleVersionIDRow
 	moduleConnectionRow := q.types.newRepoConnections()
 	versionsArray := q.types.newModuleVersionsArray()
-	if err := row.Scan(&item.ModuleID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.Provider, &item.Status, &item.OrganizationName, moduleConnectionRow, versionsArray); err != nil {
+	if err := row.Scan(&item.ModuleID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.Provider, &item.Status, &item.OrganizationName, &item.Path, &item.TagPrefix, &item.RunTests, moduleConnectionRow, versionsArray); err != nil {
 		return item, fmt.Errorf("scan FindModuleByModuleVersionIDBatch row: %w", err)
 	}
 	if err := moduleConnectionRow.AssignTo(&item.ModuleConnection); err != nil {
@@ -635,13 +701,18 @@ type UpdateModuleVersionStatusByIDParams struct {
 }
 
 type UpdateModuleVersionStatusByIDRow struct {
-	ModuleVersionID pgtype.Text        `json:"module_version_id"`
-	Version         pgtype.Text        `json:"version"`
-	CreatedAt       pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
-	Status          pgtype.Text        `json:"status"`
-	StatusError     pgtype.Text        `json:"status_error"`
-	ModuleID        pgtype.Text        `json:"module_id"`
+	ModuleVersionID    pgtype.Text        `json:"module_version_id"`
+	Version            pgtype.Text        `json:"version"`
+	CreatedAt          pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt          pgtype.Timestamptz `json:"updated_at"`
+	Status             pgtype.Text        `json:"status"`
+	StatusError        pgtype.Text        `json:"status_error"`
+	ModuleID           pgtype.Text        `json:"module_id"`
+	Downloads          pgtype.Int4        `json:"downloads"`
+	Deprecated         bool               `json:"deprecated"`
+	DeprecationReason  pgtype.Text        `json:"deprecation_reason"`
+	ReplacementVersion pgtype.Text        `json:"replacement_version"`
+	Yanked             bool               `json:"yanked"`
 }
 
 // UpdateModuleVersionStatusByID implements Querier.UpdateModuleVersionStatusByID.
@@ -649,7 +720,7 @@ func (q *DBQuerier) UpdateModuleVersionStatusByID(ctx context.Context, params Up
 	ctx = context.WithValue(ctx, "pggen_query_name", "UpdateModuleVersionStatusByID")
 	row := q.conn.QueryRow(ctx, updateModuleVersionStatusByIDSQL, params.Status, params.StatusError, params.ModuleVersionID)
 	var item UpdateModuleVersionStatusByIDRow
-	if err := row.Scan(&item.ModuleVersionID, &item.Version, &item.CreatedAt, &item.UpdatedAt, &item.Status, &item.StatusError, &item.ModuleID); err != nil {
+	if err := row.Scan(&item.ModuleVersionID, &item.Version, &item.CreatedAt, &item.UpdatedAt, &item.Status, &item.StatusError, &item.ModuleID, &item.Downloads, &item.Deprecated, &item.DeprecationReason, &item.ReplacementVersion, &item.Yanked); err != nil {
 		return item, fmt.Errorf("query UpdateModuleVersionStatusByID: %w", err)
 	}
 	return item, nil
@@ -664,12 +735,585 @@ func (q *DBQuerier) UpdateModuleVersionStatusByIDBatch(batch genericBatch, param
 func (q *DBQuerier) UpdateModuleVersionStatusByIDScan(results pgx.BatchResults) (UpdateModuleVersionStatusByIDRow, error) {
 	row := results.QueryRow()
 	var item UpdateModuleVersionStatusByIDRow
-	if err := row.Scan(&item.ModuleVersionID, &item.Version, &item.CreatedAt, &item.UpdatedAt, &item.Status, &item.StatusError, &item.ModuleID); err != nil {
+	if err := row.Scan(&item.ModuleVersionID, &item.Version, &item.CreatedAt, &item.UpdatedAt, &item.Status, &item.StatusError, &item.ModuleID, &item.Downloads, &item.Deprecated, &item.DeprecationReason, &item.ReplacementVersion, &item.Yanked); err != nil {
 		return item, fmt.Errorf("scan UpdateModuleVersionStatusByIDBatch row: %w", err)
 	}
 	return item, nil
 }
 
+const updateModuleVersionDeprecationByIDSQL = `UPDATE module_versions
+SET
+    deprecated = $1,
+    deprecation_reason = $2,
+    replacement_version = $3
+WHERE module_version_id = $4
+RETURNING module_version_id
+;`
+
+type UpdateModuleVersionDeprecationByIDParams struct {
+	Deprecated         bool
+	DeprecationReason  pgtype.Text
+	ReplacementVersion pgtype.Text
+	ModuleVersionID    pgtype.Text
+}
+
+// UpdateModuleVersionDeprecationByID implements Querier.UpdateModuleVersionDeprecationByID.
+func (q *DBQuerier) UpdateModuleVersionDeprecationByID(ctx context.Context, params UpdateModuleVersionDeprecationByIDParams) (pgtype.Text, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "UpdateModuleVersionDeprecationByID")
+	row := q.conn.QueryRow(ctx, updateModuleVersionDeprecationByIDSQL, params.Deprecated, params.DeprecationReason, params.ReplacementVersion, params.ModuleVersionID)
+	var item pgtype.Text
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("query UpdateModuleVersionDeprecationByID: %w", err)
+	}
+	return item, nil
+}
+
+// UpdateModuleVersionDeprecationByIDBatch implements Querier.UpdateModuleVersionDeprecationByIDBatch.
+func (q *DBQuerier) UpdateModuleVersionDeprecationByIDBatch(batch genericBatch, params UpdateModuleVersionDeprecationByIDParams) {
+	batch.Queue(updateModuleVersionDeprecationByIDSQL, params.Deprecated, params.DeprecationReason, params.ReplacementVersion, params.ModuleVersionID)
+}
+
+// UpdateModuleVersionDeprecationByIDScan implements Querier.UpdateModuleVersionDeprecationByIDScan.
+func (q *DBQuerier) UpdateModuleVersionDeprecationByIDScan(results pgx.BatchResults) (pgtype.Text, error) {
+	row := results.QueryRow()
+	var item pgtype.Text
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("scan UpdateModuleVersionDeprecationByIDBatch row: %w", err)
+	}
+	return item, nil
+}
+
+const updateModuleVersionYankedByIDSQL = `UPDATE module_versions
+SET yanked = $1
+WHERE module_version_id = $2
+RETURNING module_version_id
+;`
+
+// UpdateModuleVersionYankedByID implements Querier.UpdateModuleVersionYankedByID.
+func (q *DBQuerier) UpdateModuleVersionYankedByID(ctx context.Context, yanked bool, moduleVersionID pgtype.Text) (pgtype.Text, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "UpdateModuleVersionYankedByID")
+	row := q.conn.QueryRow(ctx, updateModuleVersionYankedByIDSQL, yanked, moduleVersionID)
+	var item pgtype.Text
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("query UpdateModuleVersionYankedByID: %w", err)
+	}
+	return item, nil
+}
+
+// UpdateModuleVersionYankedByIDBatch implements Querier.UpdateModuleVersionYankedByIDBatch.
+func (q *DBQuerier) UpdateModuleVersionYankedByIDBatch(batch genericBatch, yanked bool, moduleVersionID pgtype.Text) {
+	batch.Queue(updateModuleVersionYankedByIDSQL, yanked, moduleVersionID)
+}
+
+// UpdateModuleVersionYankedByIDScan implements Querier.UpdateModuleVersionYankedByIDScan.
+func (q *DBQuerier) UpdateModuleVersionYankedByIDScan(results pgx.BatchResults) (pgtype.Text, error) {
+	row := results.QueryRow()
+	var item pgtype.Text
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("scan UpdateModuleVersionYankedByIDBatch row: %w", err)
+	}
+	return item, nil
+}
+
+const findPendingModuleVersionTestsSQL = `SELECT
+    m.organization_name,
+    m.name,
+    m.provider,
+    v.module_version_id,
+    v.version
+FROM module_versions v
+JOIN modules m USING (module_id)
+WHERE v.status = 'test_pending'
+ORDER BY v.created_at
+;`
+
+type FindPendingModuleVersionTestsRow struct {
+	OrganizationName pgtype.Text `json:"organization_name"`
+	Name             pgtype.Text `json:"name"`
+	Provider         pgtype.Text `json:"provider"`
+	ModuleVersionID  pgtype.Text `json:"module_version_id"`
+	Version          pgtype.Text `json:"version"`
+}
+
+// FindPendingModuleVersionTests implements Querier.FindPendingModuleVersionTests.
+func (q *DBQuerier) FindPendingModuleVersionTests(ctx context.Context) ([]FindPendingModuleVersionTestsRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "FindPendingModuleVersionTests")
+	rows, err := q.conn.Query(ctx, findPendingModuleVersionTestsSQL)
+	if err != nil {
+		return nil, fmt.Errorf("query FindPendingModuleVersionTests: %w", err)
+	}
+	defer rows.Close()
+	items := []FindPendingModuleVersionTestsRow{}
+	for rows.Next() {
+		var item FindPendingModuleVersionTestsRow
+		if err := rows.Scan(&item.OrganizationName, &item.Name, &item.Provider, &item.ModuleVersionID, &item.Version); err != nil {
+			return nil, fmt.Errorf("scan FindPendingModuleVersionTests row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindPendingModuleVersionTests rows: %w", err)
+	}
+	return items, err
+}
+
+// FindPendingModuleVersionTestsBatch implements Querier.FindPendingModuleVersionTestsBatch.
+func (q *DBQuerier) FindPendingModuleVersionTestsBatch(batch genericBatch) {
+	batch.Queue(findPendingModuleVersionTestsSQL)
+}
+
+// FindPendingModuleVersionTestsScan implements Querier.FindPendingModuleVersionTestsScan.
+func (q *DBQuerier) FindPendingModuleVersionTestsScan(results pgx.BatchResults) ([]FindPendingModuleVersionTestsRow, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query FindPendingModuleVersionTestsBatch: %w", err)
+	}
+	defer rows.Close()
+	items := []FindPendingModuleVersionTestsRow{}
+	for rows.Next() {
+		var item FindPendingModuleVersionTestsRow
+		if err := rows.Scan(&item.OrganizationName, &item.Name, &item.Provider, &item.ModuleVersionID, &item.Version); err != nil {
+			return nil, fmt.Errorf("scan FindPendingModuleVersionTestsBatch row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindPendingModuleVersionTestsBatch rows: %w", err)
+	}
+	return items, err
+}
+
+const updateModuleVersionTestStartedSQL = `UPDATE module_versions
+SET status = 'testing'
+WHERE module_version_id = $1
+AND   status = 'test_pending'
+RETURNING module_version_id
+;`
+
+// UpdateModuleVersionTestStarted implements Querier.UpdateModuleVersionTestStarted.
+func (q *DBQuerier) UpdateModuleVersionTestStarted(ctx context.Context, moduleVersionID pgtype.Text) (pgtype.Text, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "UpdateModuleVersionTestStarted")
+	row := q.conn.QueryRow(ctx, updateModuleVersionTestStartedSQL, moduleVersionID)
+	var item pgtype.Text
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("query UpdateModuleVersionTestStarted: %w", err)
+	}
+	return item, nil
+}
+
+// UpdateModuleVersionTestStartedBatch implements Querier.UpdateModuleVersionTestStartedBatch.
+func (q *DBQuerier) UpdateModuleVersionTestStartedBatch(batch genericBatch, moduleVersionID pgtype.Text) {
+	batch.Queue(updateModuleVersionTestStartedSQL, moduleVersionID)
+}
+
+// UpdateModuleVersionTestStartedScan implements Querier.UpdateModuleVersionTestStartedScan.
+func (q *DBQuerier) UpdateModuleVersionTestStartedScan(results pgx.BatchResults) (pgtype.Text, error) {
+	row := results.QueryRow()
+	var item pgtype.Text
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("scan UpdateModuleVersionTestStartedBatch row: %w", err)
+	}
+	return item, nil
+}
+
+const updateStaleModuleVersionTestsSQL = `UPDATE module_versions v
+SET status = 'test_pending'
+FROM module_version_tests t
+WHERE v.module_version_id = t.module_version_id
+AND   v.status = 'testing'
+AND   t.started_at < $1
+RETURNING v.module_version_id
+;`
+
+// UpdateStaleModuleVersionTests implements Querier.UpdateStaleModuleVersionTests.
+func (q *DBQuerier) UpdateStaleModuleVersionTests(ctx context.Context, startedBefore pgtype.Timestamptz) ([]pgtype.Text, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "UpdateStaleModuleVersionTests")
+	rows, err := q.conn.Query(ctx, updateStaleModuleVersionTestsSQL, startedBefore)
+	if err != nil {
+		return nil, fmt.Errorf("query UpdateStaleModuleVersionTests: %w", err)
+	}
+	defer rows.Close()
+	items := []pgtype.Text{}
+	for rows.Next() {
+		var item pgtype.Text
+		if err := rows.Scan(&item); err != nil {
+			return nil, fmt.Errorf("scan UpdateStaleModuleVersionTests row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close UpdateStaleModuleVersionTests rows: %w", err)
+	}
+	return items, err
+}
+
+// UpdateStaleModuleVersionTestsBatch implements Querier.UpdateStaleModuleVersionTestsBatch.
+func (q *DBQuerier) UpdateStaleModuleVersionTestsBatch(batch genericBatch, startedBefore pgtype.Timestamptz) {
+	batch.Queue(updateStaleModuleVersionTestsSQL, startedBefore)
+}
+
+// UpdateStaleModuleVersionTestsScan implements Querier.UpdateStaleModuleVersionTestsScan.
+func (q *DBQuerier) UpdateStaleModuleVersionTestsScan(results pgx.BatchResults) ([]pgtype.Text, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query UpdateStaleModuleVersionTestsBatch: %w", err)
+	}
+	defer rows.Close()
+	items := []pgtype.Text{}
+	for rows.Next() {
+		var item pgtype.Text
+		if err := rows.Scan(&item); err != nil {
+			return nil, fmt.Errorf("scan UpdateStaleModuleVersionTestsBatch row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close UpdateStaleModuleVersionTestsBatch rows: %w", err)
+	}
+	return items, err
+}
+
+const insertModuleVersionTestSQL = `INSERT INTO module_version_tests (
+    module_version_id,
+    started_at
+) VALUES (
+    $1,
+    $2
+)
+ON CONFLICT (module_version_id) DO UPDATE
+SET output = ''::bytea,
+    started_at = EXCLUDED.started_at,
+    finished_at = NULL
+;`
+
+// InsertModuleVersionTest implements Querier.InsertModuleVersionTest.
+func (q *DBQuerier) InsertModuleVersionTest(ctx context.Context, moduleVersionID pgtype.Text, startedAt pgtype.Timestamptz) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "InsertModuleVersionTest")
+	cmdTag, err := q.conn.Exec(ctx, insertModuleVersionTestSQL, moduleVersionID, startedAt)
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec query InsertModuleVersionTest: %w", err)
+	}
+	return cmdTag, err
+}
+
+// InsertModuleVersionTestBatch implements Querier.InsertModuleVersionTestBatch.
+func (q *DBQuerier) InsertModuleVersionTestBatch(batch genericBatch, moduleVersionID pgtype.Text, startedAt pgtype.Timestamptz) {
+	batch.Queue(insertModuleVersionTestSQL, moduleVersionID, startedAt)
+}
+
+// InsertModuleVersionTestScan implements Querier.InsertModuleVersionTestScan.
+func (q *DBQuerier) InsertModuleVersionTestScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec InsertModuleVersionTestBatch: %w", err)
+	}
+	return cmdTag, err
+}
+
+const updateModuleVersionTestOutputSQL = `UPDATE module_version_tests
+SET output = output || $1
+WHERE module_version_id = $2
+RETURNING module_version_id
+;`
+
+// UpdateModuleVersionTestOutput implements Querier.UpdateModuleVersionTestOutput.
+func (q *DBQuerier) UpdateModuleVersionTestOutput(ctx context.Context, output []byte, moduleVersionID pgtype.Text) (pgtype.Text, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "UpdateModuleVersionTestOutput")
+	row := q.conn.QueryRow(ctx, updateModuleVersionTestOutputSQL, output, moduleVersionID)
+	var item pgtype.Text
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("query UpdateModuleVersionTestOutput: %w", err)
+	}
+	return item, nil
+}
+
+// UpdateModuleVersionTestOutputBatch implements Querier.UpdateModuleVersionTestOutputBatch.
+func (q *DBQuerier) UpdateModuleVersionTestOutputBatch(batch genericBatch, output []byte, moduleVersionID pgtype.Text) {
+	batch.Queue(updateModuleVersionTestOutputSQL, output, moduleVersionID)
+}
+
+// UpdateModuleVersionTestOutputScan implements Querier.UpdateModuleVersionTestOutputScan.
+func (q *DBQuerier) UpdateModuleVersionTestOutputScan(results pgx.BatchResults) (pgtype.Text, error) {
+	row := results.QueryRow()
+	var item pgtype.Text
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("scan UpdateModuleVersionTestOutputBatch row: %w", err)
+	}
+	return item, nil
+}
+
+const updateModuleVersionTestFinishedSQL = `UPDATE module_version_tests
+SET finished_at = $1
+WHERE module_version_id = $2
+RETURNING module_version_id
+;`
+
+// UpdateModuleVersionTestFinished implements Querier.UpdateModuleVersionTestFinished.
+func (q *DBQuerier) UpdateModuleVersionTestFinished(ctx context.Context, finishedAt pgtype.Timestamptz, moduleVersionID pgtype.Text) (pgtype.Text, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "UpdateModuleVersionTestFinished")
+	row := q.conn.QueryRow(ctx, updateModuleVersionTestFinishedSQL, finishedAt, moduleVersionID)
+	var item pgtype.Text
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("query UpdateModuleVersionTestFinished: %w", err)
+	}
+	return item, nil
+}
+
+// UpdateModuleVersionTestFinishedBatch implements Querier.UpdateModuleVersionTestFinishedBatch.
+func (q *DBQuerier) UpdateModuleVersionTestFinishedBatch(batch genericBatch, finishedAt pgtype.Timestamptz, moduleVersionID pgtype.Text) {
+	batch.Queue(updateModuleVersionTestFinishedSQL, finishedAt, moduleVersionID)
+}
+
+// UpdateModuleVersionTestFinishedScan implements Querier.UpdateModuleVersionTestFinishedScan.
+func (q *DBQuerier) UpdateModuleVersionTestFinishedScan(results pgx.BatchResults) (pgtype.Text, error) {
+	row := results.QueryRow()
+	var item pgtype.Text
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("scan UpdateModuleVersionTestFinishedBatch row: %w", err)
+	}
+	return item, nil
+}
+
+const findModuleVersionTestsByModuleIDSQL = `SELECT
+    t.module_version_id,
+    v.version,
+    v.status,
+    t.output,
+    t.started_at,
+    t.finished_at
+FROM module_version_tests t
+JOIN module_versions v USING (module_version_id)
+WHERE v.module_id = $1
+ORDER BY t.started_at DESC
+;`
+
+type FindModuleVersionTestsByModuleIDRow struct {
+	ModuleVersionID pgtype.Text        `json:"module_version_id"`
+	Version         pgtype.Text        `json:"version"`
+	Status          pgtype.Text        `json:"status"`
+	Output          []byte             `json:"output"`
+	StartedAt       pgtype.Timestamptz `json:"started_at"`
+	FinishedAt      pgtype.Timestamptz `json:"finished_at"`
+}
+
+// FindModuleVersionTestsByModuleID implements Querier.FindModuleVersionTestsByModuleID.
+func (q *DBQuerier) FindModuleVersionTestsByModuleID(ctx context.Context, moduleID pgtype.Text) ([]FindModuleVersionTestsByModuleIDRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "FindModuleVersionTestsByModuleID")
+	rows, err := q.conn.Query(ctx, findModuleVersionTestsByModuleIDSQL, moduleID)
+	if err != nil {
+		return nil, fmt.Errorf("query FindModuleVersionTestsByModuleID: %w", err)
+	}
+	defer rows.Close()
+	items := []FindModuleVersionTestsByModuleIDRow{}
+	for rows.Next() {
+		var item FindModuleVersionTestsByModuleIDRow
+		if err := rows.Scan(&item.ModuleVersionID, &item.Version, &item.Status, &item.Output, &item.StartedAt, &item.FinishedAt); err != nil {
+			return nil, fmt.Errorf("scan FindModuleVersionTestsByModuleID row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindModuleVersionTestsByModuleID rows: %w", err)
+	}
+	return items, err
+}
+
+// FindModuleVersionTestsByModuleIDBatch implements Querier.FindModuleVersionTestsByModuleIDBatch.
+func (q *DBQuerier) FindModuleVersionTestsByModuleIDBatch(batch genericBatch, moduleID pgtype.Text) {
+	batch.Queue(findModuleVersionTestsByModuleIDSQL, moduleID)
+}
+
+// FindModuleVersionTestsByModuleIDScan implements Querier.FindModuleVersionTestsByModuleIDScan.
+func (q *DBQuerier) FindModuleVersionTestsByModuleIDScan(results pgx.BatchResults) ([]FindModuleVersionTestsByModuleIDRow, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query FindModuleVersionTestsByModuleIDBatch: %w", err)
+	}
+	defer rows.Close()
+	items := []FindModuleVersionTestsByModuleIDRow{}
+	for rows.Next() {
+		var item FindModuleVersionTestsByModuleIDRow
+		if err := rows.Scan(&item.ModuleVersionID, &item.Version, &item.Status, &item.Output, &item.StartedAt, &item.FinishedAt); err != nil {
+			return nil, fmt.Errorf("scan FindModuleVersionTestsByModuleIDBatch row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindModuleVersionTestsByModuleIDBatch rows: %w", err)
+	}
+	return items, err
+}
+
+const incrementModuleVersionDownloadsSQL = `UPDATE module_versions
+SET downloads = downloads + 1
+WHERE module_version_id = $1
+RETURNING module_version_id
+;`
+
+// IncrementModuleVersionDownloads implements Querier.IncrementModuleVersionDownloads.
+func (q *DBQuerier) IncrementModuleVersionDownloads(ctx context.Context, moduleVersionID pgtype.Text) (pgtype.Text, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "IncrementModuleVersionDownloads")
+	row := q.conn.QueryRow(ctx, incrementModuleVersionDownloadsSQL, moduleVersionID)
+	var item pgtype.Text
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("query IncrementModuleVersionDownloads: %w", err)
+	}
+	return item, nil
+}
+
+// IncrementModuleVersionDownloadsBatch implements Querier.IncrementModuleVersionDownloadsBatch.
+func (q *DBQuerier) IncrementModuleVersionDownloadsBatch(batch genericBatch, moduleVersionID pgtype.Text) {
+	batch.Queue(incrementModuleVersionDownloadsSQL, moduleVersionID)
+}
+
+// IncrementModuleVersionDownloadsScan implements Querier.IncrementModuleVersionDownloadsScan.
+func (q *DBQuerier) IncrementModuleVersionDownloadsScan(results pgx.BatchResults) (pgtype.Text, error) {
+	row := results.QueryRow()
+	var item pgtype.Text
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("scan IncrementModuleVersionDownloadsBatch row: %w", err)
+	}
+	return item, nil
+}
+
+const deleteModuleVersionUsagesByWorkspaceIDSQL = `DELETE
+FROM module_version_usages
+WHERE workspace_id = $1
+;`
+
+// DeleteModuleVersionUsagesByWorkspaceID implements Querier.DeleteModuleVersionUsagesByWorkspaceID.
+func (q *DBQuerier) DeleteModuleVersionUsagesByWorkspaceID(ctx context.Context, workspaceID pgtype.Text) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "DeleteModuleVersionUsagesByWorkspaceID")
+	cmdTag, err := q.conn.Exec(ctx, deleteModuleVersionUsagesByWorkspaceIDSQL, workspaceID)
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec query DeleteModuleVersionUsagesByWorkspaceID: %w", err)
+	}
+	return cmdTag, err
+}
+
+// DeleteModuleVersionUsagesByWorkspaceIDBatch implements Querier.DeleteModuleVersionUsagesByWorkspaceIDBatch.
+func (q *DBQuerier) DeleteModuleVersionUsagesByWorkspaceIDBatch(batch genericBatch, workspaceID pgtype.Text) {
+	batch.Queue(deleteModuleVersionUsagesByWorkspaceIDSQL, workspaceID)
+}
+
+// DeleteModuleVersionUsagesByWorkspaceIDScan implements Querier.DeleteModuleVersionUsagesByWorkspaceIDScan.
+func (q *DBQuerier) DeleteModuleVersionUsagesByWorkspaceIDScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec DeleteModuleVersionUsagesByWorkspaceIDBatch: %w", err)
+	}
+	return cmdTag, err
+}
+
+const insertModuleVersionUsageSQL = `INSERT INTO module_version_usages (
+    module_version_id,
+    workspace_id,
+    updated_at
+) VALUES (
+    $1,
+    $2,
+    $3
+);`
+
+type InsertModuleVersionUsageParams struct {
+	ModuleVersionID pgtype.Text
+	WorkspaceID     pgtype.Text
+	UpdatedAt       pgtype.Timestamptz
+}
+
+// InsertModuleVersionUsage implements Querier.InsertModuleVersionUsage.
+func (q *DBQuerier) InsertModuleVersionUsage(ctx context.Context, params InsertModuleVersionUsageParams) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "InsertModuleVersionUsage")
+	cmdTag, err := q.conn.Exec(ctx, insertModuleVersionUsageSQL, params.ModuleVersionID, params.WorkspaceID, params.UpdatedAt)
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec query InsertModuleVersionUsage: %w", err)
+	}
+	return cmdTag, err
+}
+
+// InsertModuleVersionUsageBatch implements Querier.InsertModuleVersionUsageBatch.
+func (q *DBQuerier) InsertModuleVersionUsageBatch(batch genericBatch, params InsertModuleVersionUsageParams) {
+	batch.Queue(insertModuleVersionUsageSQL, params.ModuleVersionID, params.WorkspaceID, params.UpdatedAt)
+}
+
+// InsertModuleVersionUsageScan implements Querier.InsertModuleVersionUsageScan.
+func (q *DBQuerier) InsertModuleVersionUsageScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec InsertModuleVersionUsageBatch: %w", err)
+	}
+	return cmdTag, err
+}
+
+const findModuleVersionUsagesByModuleIDSQL = `SELECT
+    u.module_version_id,
+    v.version,
+    u.workspace_id,
+    w.name AS workspace_name,
+    u.updated_at
+FROM module_version_usages u
+JOIN module_versions v USING (module_version_id)
+JOIN workspaces w USING (workspace_id)
+WHERE v.module_id = $1
+ORDER BY w.name
+;`
+
+type FindModuleVersionUsagesByModuleIDRow struct {
+	ModuleVersionID pgtype.Text        `json:"module_version_id"`
+	Version         pgtype.Text        `json:"version"`
+	WorkspaceID     pgtype.Text        `json:"workspace_id"`
+	WorkspaceName   pgtype.Text        `json:"workspace_name"`
+	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
+}
+
+// FindModuleVersionUsagesByModuleID implements Querier.FindModuleVersionUsagesByModuleID.
+func (q *DBQuerier) FindModuleVersionUsagesByModuleID(ctx context.Context, moduleID pgtype.Text) ([]FindModuleVersionUsagesByModuleIDRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "FindModuleVersionUsagesByModuleID")
+	rows, err := q.conn.Query(ctx, findModuleVersionUsagesByModuleIDSQL, moduleID)
+	if err != nil {
+		return nil, fmt.Errorf("query FindModuleVersionUsagesByModuleID: %w", err)
+	}
+	defer rows.Close()
+	items := []FindModuleVersionUsagesByModuleIDRow{}
+	for rows.Next() {
+		var item FindModuleVersionUsagesByModuleIDRow
+		if err := rows.Scan(&item.ModuleVersionID, &item.Version, &item.WorkspaceID, &item.WorkspaceName, &item.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scan FindModuleVersionUsagesByModuleID row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindModuleVersionUsagesByModuleID rows: %w", err)
+	}
+	return items, err
+}
+
+// FindModuleVersionUsagesByModuleIDBatch implements Querier.FindModuleVersionUsagesByModuleIDBatch.
+func (q *DBQuerier) FindModuleVersionUsagesByModuleIDBatch(batch genericBatch, moduleID pgtype.Text) {
+	batch.Queue(findModuleVersionUsagesByModuleIDSQL, moduleID)
+}
+
+// FindModuleVersionUsagesByModuleIDScan implements Querier.FindModuleVersionUsagesByModuleIDScan.
+func (q *DBQuerier) FindModuleVersionUsagesByModuleIDScan(results pgx.BatchResults) ([]FindModuleVersionUsagesByModuleIDRow, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query FindModuleVersionUsagesByModuleIDBatch: %w", err)
+	}
+	defer rows.Close()
+	items := []FindModuleVersionUsagesByModuleIDRow{}
+	for rows.Next() {
+		var item FindModuleVersionUsagesByModuleIDRow
+		if err := rows.Scan(&item.ModuleVersionID, &item.Version, &item.WorkspaceID, &item.WorkspaceName, &item.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scan FindModuleVersionUsagesByModuleIDBatch row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindModuleVersionUsagesByModuleIDBatch rows: %w", err)
+	}
+	return items, err
+}
+
 const deleteModuleByIDSQL = `DELETE
 FROM modules
 WHERE module_id = $1
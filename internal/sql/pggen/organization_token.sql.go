@@ -15,28 +15,38 @@ const upsertOrganizationTokenSQL = `INSERT INTO organization_tokens (
     organization_token_id,
     created_at,
     organization_name,
-    expiry
+    expiry,
+    scope_actions,
+    scope_workspace_ids
 ) VALUES (
     $1,
     $2,
     $3,
-    $4
+    $4,
+    $5,
+    $6
 ) ON CONFLICT (organization_name) DO UPDATE
   SET created_at            = $2,
       organization_token_id = $1,
-      expiry                = $4;`
+      expiry                = $4,
+      scope_actions         = $5,
+      scope_workspace_ids   = $6,
+      last_used_at          = NULL,
+      last_used_ip          = NULL;`
 
 type UpsertOrganizationTokenParams struct {
 	OrganizationTokenID pgtype.Text
 	CreatedAt           pgtype.Timestamptz
 	OrganizationName    pgtype.Text
 	Expiry              pgtype.Timestamptz
+	ScopeActions        []string
+	ScopeWorkspaceIds   []string
 }
 
 // UpsertOrganizationToken implements Querier.UpsertOrganizationToken.
 func (q *DBQuerier) UpsertOrganizationToken(ctx context.Context, params UpsertOrganizationTokenParams) (pgconn.CommandTag, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "UpsertOrganizationToken")
-	cmdTag, err := q.conn.Exec(ctx, upsertOrganizationTokenSQL, params.OrganizationTokenID, params.CreatedAt, params.OrganizationName, params.Expiry)
+	cmdTag, err := q.conn.Exec(ctx, upsertOrganizationTokenSQL, params.OrganizationTokenID, params.CreatedAt, params.OrganizationName, params.Expiry, params.ScopeActions, params.ScopeWorkspaceIds)
 	if err != nil {
 		return cmdTag, fmt.Errorf("exec query UpsertOrganizationToken: %w", err)
 	}
@@ -45,7 +55,7 @@ func (q *DBQuerier) UpsertOrganizationToken(ctx context.Context, params UpsertOr
 
 // UpsertOrganizationTokenBatch implements Querier.UpsertOrganizationTokenBatch.
 func (q *DBQuerier) UpsertOrganizationTokenBatch(batch genericBatch, params UpsertOrganizationTokenParams) {
-	batch.Queue(upsertOrganizationTokenSQL, params.OrganizationTokenID, params.CreatedAt, params.OrganizationName, params.Expiry)
+	batch.Queue(upsertOrganizationTokenSQL, params.OrganizationTokenID, params.CreatedAt, params.OrganizationName, params.Expiry, params.ScopeActions, params.ScopeWorkspaceIds)
 }
 
 // UpsertOrganizationTokenScan implements Querier.UpsertOrganizationTokenScan.
@@ -66,6 +76,10 @@ type FindOrganizationTokensByNameRow struct {
 	CreatedAt           pgtype.Timestamptz `json:"created_at"`
 	OrganizationName    pgtype.Text        `json:"organization_name"`
 	Expiry              pgtype.Timestamptz `json:"expiry"`
+	ScopeActions        []string           `json:"scope_actions"`
+	ScopeWorkspaceIds   []string           `json:"scope_workspace_ids"`
+	LastUsedAt          pgtype.Timestamptz `json:"last_used_at"`
+	LastUsedIp          pgtype.Text        `json:"last_used_ip"`
 }
 
 // FindOrganizationTokensByName implements Querier.FindOrganizationTokensByName.
@@ -79,7 +93,7 @@ func (q *DBQuerier) FindOrganizationTokensByName(ctx context.Context, organizati
 	items := []FindOrganizationTokensByNameRow{}
 	for rows.Next() {
 		var item FindOrganizationTokensByNameRow
-		if err := rows.Scan(&item.OrganizationTokenID, &item.CreatedAt, &item.OrganizationName, &item.Expiry); err != nil {
+		if err := rows.Scan(&item.OrganizationTokenID, &item.CreatedAt, &item.OrganizationName, &item.Expiry, &item.ScopeActions, &item.ScopeWorkspaceIds, &item.LastUsedAt, &item.LastUsedIp); err != nil {
 			return nil, fmt.Errorf("scan FindOrganizationTokensByName row: %w", err)
 		}
 		items = append(items, item)
@@ -105,7 +119,7 @@ func (q *DBQuerier) FindOrganizationTokensByNameScan(results pgx.BatchResults) (
 	items := []FindOrganizationTokensByNameRow{}
 	for rows.Next() {
 		var item FindOrganizationTokensByNameRow
-		if err := rows.Scan(&item.OrganizationTokenID, &item.CreatedAt, &item.OrganizationName, &item.Expiry); err != nil {
+		if err := rows.Scan(&item.OrganizationTokenID, &item.CreatedAt, &item.OrganizationName, &item.Expiry, &item.ScopeActions, &item.ScopeWorkspaceIds, &item.LastUsedAt, &item.LastUsedIp); err != nil {
 			return nil, fmt.Errorf("scan FindOrganizationTokensByNameBatch row: %w", err)
 		}
 		items = append(items, item)
@@ -125,6 +139,10 @@ type FindOrganizationTokensByIDRow struct {
 	CreatedAt           pgtype.Timestamptz `json:"created_at"`
 	OrganizationName    pgtype.Text        `json:"organization_name"`
 	Expiry              pgtype.Timestamptz `json:"expiry"`
+	ScopeActions        []string           `json:"scope_actions"`
+	ScopeWorkspaceIds   []string           `json:"scope_workspace_ids"`
+	LastUsedAt          pgtype.Timestamptz `json:"last_used_at"`
+	LastUsedIp          pgtype.Text        `json:"last_used_ip"`
 }
 
 // FindOrganizationTokensByID implements Querier.FindOrganizationTokensByID.
@@ -132,7 +150,7 @@ func (q *DBQuerier) FindOrganizationTokensByID(ctx context.Context, organization
 	ctx = context.WithValue(ctx, "pggen_query_name", "FindOrganizationTokensByID")
 	row := q.conn.QueryRow(ctx, findOrganizationTokensByIDSQL, organizationTokenID)
 	var item FindOrganizationTokensByIDRow
-	if err := row.Scan(&item.OrganizationTokenID, &item.CreatedAt, &item.OrganizationName, &item.Expiry); err != nil {
+	if err := row.Scan(&item.OrganizationTokenID, &item.CreatedAt, &item.OrganizationName, &item.Expiry, &item.ScopeActions, &item.ScopeWorkspaceIds, &item.LastUsedAt, &item.LastUsedIp); err != nil {
 		return item, fmt.Errorf("query FindOrganizationTokensByID: %w", err)
 	}
 	return item, nil
@@ -147,12 +165,47 @@ func (q *DBQuerier) FindOrganizationTokensByIDBatch(batch genericBatch, organiza
 func (q *DBQuerier) FindOrganizationTokensByIDScan(results pgx.BatchResults) (FindOrganizationTokensByIDRow, error) {
 	row := results.QueryRow()
 	var item FindOrganizationTokensByIDRow
-	if err := row.Scan(&item.OrganizationTokenID, &item.CreatedAt, &item.OrganizationName, &item.Expiry); err != nil {
+	if err := row.Scan(&item.OrganizationTokenID, &item.CreatedAt, &item.OrganizationName, &item.Expiry, &item.ScopeActions, &item.ScopeWorkspaceIds, &item.LastUsedAt, &item.LastUsedIp); err != nil {
 		return item, fmt.Errorf("scan FindOrganizationTokensByIDBatch row: %w", err)
 	}
 	return item, nil
 }
 
+const updateOrganizationTokenLastUsedSQL = `UPDATE organization_tokens
+SET last_used_at = $1,
+    last_used_ip = $2
+WHERE organization_token_id = $3;`
+
+type UpdateOrganizationTokenLastUsedParams struct {
+	LastUsedAt          pgtype.Timestamptz
+	LastUsedIp          pgtype.Text
+	OrganizationTokenID pgtype.Text
+}
+
+// UpdateOrganizationTokenLastUsed implements Querier.UpdateOrganizationTokenLastUsed.
+func (q *DBQuerier) UpdateOrganizationTokenLastUsed(ctx context.Context, params UpdateOrganizationTokenLastUsedParams) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "UpdateOrganizationTokenLastUsed")
+	cmdTag, err := q.conn.Exec(ctx, updateOrganizationTokenLastUsedSQL, params.LastUsedAt, params.LastUsedIp, params.OrganizationTokenID)
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec query UpdateOrganizationTokenLastUsed: %w", err)
+	}
+	return cmdTag, err
+}
+
+// UpdateOrganizationTokenLastUsedBatch implements Querier.UpdateOrganizationTokenLastUsedBatch.
+func (q *DBQuerier) UpdateOrganizationTokenLastUsedBatch(batch genericBatch, params UpdateOrganizationTokenLastUsedParams) {
+	batch.Queue(updateOrganizationTokenLastUsedSQL, params.LastUsedAt, params.LastUsedIp, params.OrganizationTokenID)
+}
+
+// UpdateOrganizationTokenLastUsedScan implements Querier.UpdateOrganizationTokenLastUsedScan.
+func (q *DBQuerier) UpdateOrganizationTokenLastUsedScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec UpdateOrganizationTokenLastUsedBatch: %w", err)
+	}
+	return cmdTag, err
+}
+
 const deleteOrganiationTokenByNameSQL = `DELETE
 FROM organization_tokens
 WHERE organization_name = $1
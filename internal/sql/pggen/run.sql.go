@@ -28,7 +28,8 @@ const insertRunSQL = `INSERT INTO runs (
     workspace_id,
     created_by,
     terraform_version,
-    allow_empty_apply
+    allow_empty_apply,
+    test_only
 ) VALUES (
     $1,
     $2,
@@ -46,7 +47,8 @@ const insertRunSQL = `INSERT INTO runs (
     $14,
     $15,
     $16,
-    $17
+    $17,
+    $18
 );`
 
 type InsertRunParams struct {
@@ -67,12 +69,13 @@ type InsertRunParams struct {
 	CreatedBy              pgtype.Text
 	TerraformVersion       pgtype.Text
 	AllowEmptyApply        bool
+	TestOnly               bool
 }
 
 // InsertRun implements Querier.InsertRun.
 func (q *DBQuerier) InsertRun(ctx context.Context, params InsertRunParams) (pgconn.CommandTag, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "InsertRun")
-	cmdTag, err := q.conn.Exec(ctx, insertRunSQL, params.ID, params.CreatedAt, params.IsDestroy, params.PositionInQueue, params.Refresh, params.RefreshOnly, params.Source, params.Status, params.ReplaceAddrs, params.TargetAddrs, params.AutoApply, params.PlanOnly, params.ConfigurationVersionID, params.WorkspaceID, params.CreatedBy, params.TerraformVersion, params.AllowEmptyApply)
+	cmdTag, err := q.conn.Exec(ctx, insertRunSQL, params.ID, params.CreatedAt, params.IsDestroy, params.PositionInQueue, params.Refresh, params.RefreshOnly, params.Source, params.Status, params.ReplaceAddrs, params.TargetAddrs, params.AutoApply, params.PlanOnly, params.ConfigurationVersionID, params.WorkspaceID, params.CreatedBy, params.TerraformVersion, params.AllowEmptyApply, params.TestOnly)
 	if err != nil {
 		return cmdTag, fmt.Errorf("exec query InsertRun: %w", err)
 	}
@@ -81,7 +84,7 @@ func (q *DBQuerier) InsertRun(ctx context.Context, params InsertRunParams) (pgco
 
 // InsertRunBatch implements Querier.InsertRunBatch.
 func (q *DBQuerier) InsertRunBatch(batch genericBatch, params InsertRunParams) {
-	batch.Queue(insertRunSQL, params.ID, params.CreatedAt, params.IsDestroy, params.PositionInQueue, params.Refresh, params.RefreshOnly, params.Source, params.Status, params.ReplaceAddrs, params.TargetAddrs, params.AutoApply, params.PlanOnly, params.ConfigurationVersionID, params.WorkspaceID, params.CreatedBy, params.TerraformVersion, params.AllowEmptyApply)
+	batch.Queue(insertRunSQL, params.ID, params.CreatedAt, params.IsDestroy, params.PositionInQueue, params.Refresh, params.RefreshOnly, params.Source, params.Status, params.ReplaceAddrs, params.TargetAddrs, params.AutoApply, params.PlanOnly, params.ConfigurationVersionID, params.WorkspaceID, params.CreatedBy, params.TerraformVersion, params.AllowEmptyApply, params.TestOnly)
 }
 
 // InsertRunScan implements Querier.InsertRunScan.
@@ -185,6 +188,7 @@ const findRunsSQL = `SELECT
     runs.status,
     plans.status      AS plan_status,
     applies.status      AS apply_status,
+    tests.status        AS test_status,
     runs.replace_addrs,
     runs.target_addrs,
     runs.auto_apply,
@@ -197,6 +201,8 @@ const findRunsSQL = `SELECT
     runs.created_by,
     runs.terraform_version,
     runs.allow_empty_apply,
+    runs.test_only,
+    tests.report AS test_report,
     workspaces.execution_mode AS execution_mode,
     CASE WHEN workspaces.latest_run_id = runs.run_id THEN true
          ELSE false
@@ -224,6 +230,13 @@ const findRunsSQL = `SELECT
         AND   st.phase = 'apply'
         GROUP BY run_id, phase
     ) AS apply_status_timestamps,
+    (
+        SELECT array_agg(st.*) AS phase_status_timestamps
+        FROM phase_status_timestamps st
+        WHERE st.run_id = tests.run_id
+        AND   st.phase = 'test'
+        GROUP BY run_id, phase
+    ) AS test_status_timestamps,
     (
         SELECT array_agg(v.*) AS run_variables
         FROM run_variables v
@@ -233,6 +246,7 @@ const findRunsSQL = `SELECT
 FROM runs
 JOIN plans USING (run_id)
 JOIN applies USING (run_id)
+JOIN tests USING (run_id)
 JOIN (configuration_versions LEFT JOIN ingress_attributes ia USING (configuration_version_id)) USING (configuration_version_id)
 JOIN workspaces ON runs.workspace_id = workspaces.workspace_id
 JOIN organizations ON workspaces.organization_name = organizations.name
@@ -274,6 +288,7 @@ type FindRunsRow struct {
 	Status                 pgtype.Text             `json:"status"`
 	PlanStatus             pgtype.Text             `json:"plan_status"`
 	ApplyStatus            pgtype.Text             `json:"apply_status"`
+	TestStatus             pgtype.Text             `json:"test_status"`
 	ReplaceAddrs           []string                `json:"replace_addrs"`
 	TargetAddrs            []string                `json:"target_addrs"`
 	AutoApply              bool                    `json:"auto_apply"`
@@ -286,6 +301,8 @@ type FindRunsRow struct {
 	CreatedBy              pgtype.Text             `json:"created_by"`
 	TerraformVersion       pgtype.Text             `json:"terraform_version"`
 	AllowEmptyApply        bool                    `json:"allow_empty_apply"`
+	TestOnly               bool                    `json:"test_only"`
+	TestReport             []byte                  `json:"test_report"`
 	ExecutionMode          pgtype.Text             `json:"execution_mode"`
 	Latest                 bool                    `json:"latest"`
 	OrganizationName       pgtype.Text             `json:"organization_name"`
@@ -294,6 +311,7 @@ type FindRunsRow struct {
 	RunStatusTimestamps    []RunStatusTimestamps   `json:"run_status_timestamps"`
 	PlanStatusTimestamps   []PhaseStatusTimestamps `json:"plan_status_timestamps"`
 	ApplyStatusTimestamps  []PhaseStatusTimestamps `json:"apply_status_timestamps"`
+	TestStatusTimestamps   []PhaseStatusTimestamps `json:"test_status_timestamps"`
 	RunVariables           []RunVariables          `json:"run_variables"`
 }
 
@@ -313,10 +331,11 @@ func (q *DBQuerier) FindRuns(ctx context.Context, params FindRunsParams) ([]Find
 	runStatusTimestampsArray := q.types.newRunStatusTimestampsArray()
 	planStatusTimestampsArray := q.types.newPhaseStatusTimestampsArray()
 	applyStatusTimestampsArray := q.types.newPhaseStatusTimestampsArray()
+	testStatusTimestampsArray := q.types.newPhaseStatusTimestampsArray()
 	runVariablesArray := q.types.newRunVariablesArray()
 	for rows.Next() {
 		var item FindRunsRow
-		if err := rows.Scan(&item.RunID, &item.CreatedAt, &item.ForceCancelAvailableAt, &item.IsDestroy, &item.PositionInQueue, &item.Refresh, &item.RefreshOnly, &item.Source, &item.Status, &item.PlanStatus, &item.ApplyStatus, &item.ReplaceAddrs, &item.TargetAddrs, &item.AutoApply, planResourceReportRow, planOutputReportRow, applyResourceReportRow, &item.ConfigurationVersionID, &item.WorkspaceID, &item.PlanOnly, &item.CreatedBy, &item.TerraformVersion, &item.AllowEmptyApply, &item.ExecutionMode, &item.Latest, &item.OrganizationName, &item.CostEstimationEnabled, ingressAttributesRow, runStatusTimestampsArray, planStatusTimestampsArray, applyStatusTimestampsArray, runVariablesArray); err != nil {
+		if err := rows.Scan(&item.RunID, &item.CreatedAt, &item.ForceCancelAvailableAt, &item.IsDestroy, &item.PositionInQueue, &item.Refresh, &item.RefreshOnly, &item.Source, &item.Status, &item.PlanStatus, &item.ApplyStatus, &item.TestStatus, &item.ReplaceAddrs, &item.TargetAddrs, &item.AutoApply, planResourceReportRow, planOutputReportRow, applyResourceReportRow, &item.ConfigurationVersionID, &item.WorkspaceID, &item.PlanOnly, &item.CreatedBy, &item.TerraformVersion, &item.AllowEmptyApply, &item.TestOnly, &item.TestReport, &item.ExecutionMode, &item.Latest, &item.OrganizationName, &item.CostEstimationEnabled, ingressAttributesRow, runStatusTimestampsArray, planStatusTimestampsArray, applyStatusTimestampsArray, testStatusTimestampsArray, runVariablesArray); err != nil {
 			return nil, fmt.Errorf("scan FindRuns row: %w", err)
 		}
 		if err := planResourceReportRow.AssignTo(&item.PlanResourceReport); err != nil {
@@ -340,6 +359,9 @@ func (q *DBQuerier) FindRuns(ctx context.Context, params FindRunsParams) ([]Find
 		if err := applyStatusTimestampsArray.AssignTo(&item.ApplyStatusTimestamps); err != nil {
 			return nil, fmt.Errorf("assign FindRuns row: %w", err)
 		}
+		if err := testStatusTimestampsArray.AssignTo(&item.TestStatusTimestamps); err != nil {
+			return nil, fmt.Errorf("assign FindRuns row: %w", err)
+		}
 		if err := runVariablesArray.AssignTo(&item.RunVariables); err != nil {
 			return nil, fmt.Errorf("assign FindRuns row: %w", err)
 		}
@@ -371,10 +393,11 @@ func (q *DBQuerier) FindRunsScan(results pgx.BatchResults) ([]FindRunsRow, error
 	runStatusTimestampsArray := q.types.newRunStatusTimestampsArray()
 	planStatusTimestampsArray := q.types.newPhaseStatusTimestampsArray()
 	applyStatusTimestampsArray := q.types.newPhaseStatusTimestampsArray()
+	testStatusTimestampsArray := q.types.newPhaseStatusTimestampsArray()
 	runVariablesArray := q.types.newRunVariablesArray()
 	for rows.Next() {
 		var item FindRunsRow
-		if err := rows.Scan(&item.RunID, &item.CreatedAt, &item.ForceCancelAvailableAt, &item.IsDestroy, &item.PositionInQueue, &item.Refresh, &item.RefreshOnly, &item.Source, &item.Status, &item.PlanStatus, &item.ApplyStatus, &item.ReplaceAddrs, &item.TargetAddrs, &item.AutoApply, planResourceReportRow, planOutputReportRow, applyResourceReportRow, &item.ConfigurationVersionID, &item.WorkspaceID, &item.PlanOnly, &item.CreatedBy, &item.TerraformVersion, &item.AllowEmptyApply, &item.ExecutionMode, &item.Latest, &item.OrganizationName, &item.CostEstimationEnabled, ingressAttributesRow, runStatusTimestampsArray, planStatusTimestampsArray, applyStatusTimestampsArray, runVariablesArray); err != nil {
+		if err := rows.Scan(&item.RunID, &item.CreatedAt, &item.ForceCancelAvailableAt, &item.IsDestroy, &item.PositionInQueue, &item.Refresh, &item.RefreshOnly, &item.Source, &item.Status, &item.PlanStatus, &item.ApplyStatus, &item.TestStatus, &item.ReplaceAddrs, &item.TargetAddrs, &item.AutoApply, planResourceReportRow, planOutputReportRow, applyResourceReportRow, &item.ConfigurationVersionID, &item.WorkspaceID, &item.PlanOnly, &item.CreatedBy, &item.TerraformVersion, &item.AllowEmptyApply, &item.TestOnly, &item.TestReport, &item.ExecutionMode, &item.Latest, &item.OrganizationName, &item.CostEstimationEnabled, ingressAttributesRow, runStatusTimestampsArray, planStatusTimestampsArray, applyStatusTimestampsArray, testStatusTimestampsArray, runVariablesArray); err != nil {
 			return nil, fmt.Errorf("scan FindRunsBatch row: %w", err)
 		}
 		if err := planResourceReportRow.AssignTo(&item.PlanResourceReport); err != nil {
@@ -398,6 +421,9 @@ func (q *DBQuerier) FindRunsScan(results pgx.BatchResults) ([]FindRunsRow, error
 		if err := applyStatusTimestampsArray.AssignTo(&item.ApplyStatusTimestamps); err != nil {
 			return nil, fmt.Errorf("assign FindRuns row: %w", err)
 		}
+		if err := testStatusTimestampsArray.AssignTo(&item.TestStatusTimestamps); err != nil {
+			return nil, fmt.Errorf("assign FindRuns row: %w", err)
+		}
 		if err := runVariablesArray.AssignTo(&item.RunVariables); err != nil {
 			return nil, fmt.Errorf("assign FindRuns row: %w", err)
 		}
@@ -473,6 +499,7 @@ const findRunByIDSQL = `SELECT
     runs.status,
     plans.status      AS plan_status,
     applies.status      AS apply_status,
+    tests.status        AS test_status,
     runs.replace_addrs,
     runs.target_addrs,
     runs.auto_apply,
@@ -485,6 +512,8 @@ const findRunByIDSQL = `SELECT
     runs.created_by,
     runs.terraform_version,
     runs.allow_empty_apply,
+    runs.test_only,
+    tests.report AS test_report,
     workspaces.execution_mode AS execution_mode,
     CASE WHEN workspaces.latest_run_id = runs.run_id THEN true
          ELSE false
@@ -512,6 +541,13 @@ const findRunByIDSQL = `SELECT
         AND   st.phase = 'apply'
         GROUP BY run_id, phase
     ) AS apply_status_timestamps,
+    (
+        SELECT array_agg(st.*) AS phase_status_timestamps
+        FROM phase_status_timestamps st
+        WHERE st.run_id = tests.run_id
+        AND   st.phase = 'test'
+        GROUP BY run_id, phase
+    ) AS test_status_timestamps,
     (
         SELECT array_agg(v.*) AS run_variables
         FROM run_variables v
@@ -521,6 +557,7 @@ const findRunByIDSQL = `SELECT
 FROM runs
 JOIN plans USING (run_id)
 JOIN applies USING (run_id)
+JOIN tests USING (run_id)
 JOIN (configuration_versions LEFT JOIN ingress_attributes ia USING (configuration_version_id)) USING (configuration_version_id)
 JOIN workspaces ON runs.workspace_id = workspaces.workspace_id
 JOIN organizations ON workspaces.organization_name = organizations.name
@@ -539,6 +576,7 @@ type FindRunByIDRow struct {
 	Status                 pgtype.Text             `json:"status"`
 	PlanStatus             pgtype.Text             `json:"plan_status"`
 	ApplyStatus            pgtype.Text             `json:"apply_status"`
+	TestStatus             pgtype.Text             `json:"test_status"`
 	ReplaceAddrs           []string                `json:"replace_addrs"`
 	TargetAddrs            []string                `json:"target_addrs"`
 	AutoApply              bool                    `json:"auto_apply"`
@@ -551,6 +589,8 @@ type FindRunByIDRow struct {
 	CreatedBy              pgtype.Text             `json:"created_by"`
 	TerraformVersion       pgtype.Text             `json:"terraform_version"`
 	AllowEmptyApply        bool                    `json:"allow_empty_apply"`
+	TestOnly               bool                    `json:"test_only"`
+	TestReport             []byte                  `json:"test_report"`
 	ExecutionMode          pgtype.Text             `json:"execution_mode"`
 	Latest                 bool                    `json:"latest"`
 	OrganizationName       pgtype.Text             `json:"organization_name"`
@@ -559,6 +599,7 @@ type FindRunByIDRow struct {
 	RunStatusTimestamps    []RunStatusTimestamps   `json:"run_status_timestamps"`
 	PlanStatusTimestamps   []PhaseStatusTimestamps `json:"plan_status_timestamps"`
 	ApplyStatusTimestamps  []PhaseStatusTimestamps `json:"apply_status_timestamps"`
+	TestStatusTimestamps   []PhaseStatusTimestamps `json:"test_status_timestamps"`
 	RunVariables           []RunVariables          `json:"run_variables"`
 }
 
@@ -574,8 +615,9 @@ func (q *DBQuerier) FindRunByID(ctx context.Context, runID pgtype.Text) (FindRun
 	runStatusTimestampsArray := q.types.newRunStatusTimestampsArray()
 	planStatusTimestampsArray := q.types.newPhaseStatusTimestampsArray()
 	applyStatusTimestampsArray := q.types.newPhaseStatusTimestampsArray()
+	testStatusTimestampsArray := q.types.newPhaseStatusTimestampsArray()
 	runVariablesArray := q.types.newRunVariablesArray()
-	if err := row.Scan(&item.RunID, &item.CreatedAt, &item.ForceCancelAvailableAt, &item.IsDestroy, &item.PositionInQueue, &item.Refresh, &item.RefreshOnly, &item.Source, &item.Status, &item.PlanStatus, &item.ApplyStatus, &item.ReplaceAddrs, &item.TargetAddrs, &item.AutoApply, planResourceReportRow, planOutputReportRow, applyResourceReportRow, &item.ConfigurationVersionID, &item.WorkspaceID, &item.PlanOnly, &item.CreatedBy, &item.TerraformVersion, &item.AllowEmptyApply, &item.ExecutionMode, &item.Latest, &item.OrganizationName, &item.CostEstimationEnabled, ingressAttributesRow, runStatusTimestampsArray, planStatusTimestampsArray, applyStatusTimestampsArray, runVariablesArray); err != nil {
+	if err := row.Scan(&item.RunID, &item.CreatedAt, &item.ForceCancelAvailableAt, &item.IsDestroy, &item.PositionInQueue, &item.Refresh, &item.RefreshOnly, &item.Source, &item.Status, &item.PlanStatus, &item.ApplyStatus, &item.TestStatus, &item.ReplaceAddrs, &item.TargetAddrs, &item.AutoApply, planResourceReportRow, planOutputReportRow, applyResourceReportRow, &item.ConfigurationVersionID, &item.WorkspaceID, &item.PlanOnly, &item.CreatedBy, &item.TerraformVersion, &item.AllowEmptyApply, &item.TestOnly, &item.TestReport, &item.ExecutionMode, &item.Latest, &item.OrganizationName, &item.CostEstimationEnabled, ingressAttributesRow, runStatusTimestampsArray, planStatusTimestampsArray, applyStatusTimestampsArray, testStatusTimestampsArray, runVariablesArray); err != nil {
 		return item, fmt.Errorf("query FindRunByID: %w", err)
 	}
 	if err := planResourceReportRow.AssignTo(&item.PlanResourceReport); err != nil {
@@ -599,6 +641,9 @@ func (q *DBQuerier) FindRunByID(ctx context.Context, runID pgtype.Text) (FindRun
 	if err := applyStatusTimestampsArray.AssignTo(&item.ApplyStatusTimestamps); err != nil {
 		return item, fmt.Errorf("assign FindRunByID row: %w", err)
 	}
+	if err := testStatusTimestampsArray.AssignTo(&item.TestStatusTimestamps); err != nil {
+		return item, fmt.Errorf("assign FindRunByID row: %w", err)
+	}
 	if err := runVariablesArray.AssignTo(&item.RunVariables); err != nil {
 		return item, fmt.Errorf("assign FindRunByID row: %w", err)
 	}
@@ -621,8 +666,9 @@ func (q *DBQuerier) FindRunByIDScan(results pgx.BatchResults) (FindRunByIDRow, e
 	runStatusTimestampsArray := q.types.newRunStatusTimestampsArray()
 	planStatusTimestampsArray := q.types.newPhaseStatusTimestampsArray()
 	applyStatusTimestampsArray := q.types.newPhaseStatusTimestampsArray()
+	testStatusTimestampsArray := q.types.newPhaseStatusTimestampsArray()
 	runVariablesArray := q.types.newRunVariablesArray()
-	if err := row.Scan(&item.RunID, &item.CreatedAt, &item.ForceCancelAvailableAt, &item.IsDestroy, &item.PositionInQueue, &item.Refresh, &item.RefreshOnly, &item.Source, &item.Status, &item.PlanStatus, &item.ApplyStatus, &item.ReplaceAddrs, &item.TargetAddrs, &item.AutoApply, planResourceReportRow, planOutputReportRow, applyResourceReportRow, &item.ConfigurationVersionID, &item.WorkspaceID, &item.PlanOnly, &item.CreatedBy, &item.TerraformVersion, &item.AllowEmptyApply, &item.ExecutionMode, &item.Latest, &item.OrganizationName, &item.CostEstimationEnabled, ingressAttributesRow, runStatusTimestampsArray, planStatusTimestampsArray, applyStatusTimestampsArray, runVariablesArray); err != nil {
+	if err := row.Scan(&item.RunID, &item.CreatedAt, &item.ForceCancelAvailableAt, &item.IsDestroy, &item.PositionInQueue, &item.Refresh, &item.RefreshOnly, &item.Source, &item.Status, &item.PlanStatus, &item.ApplyStatus, &item.TestStatus, &item.ReplaceAddrs, &item.TargetAddrs, &item.AutoApply, planResourceReportRow, planOutputReportRow, applyResourceReportRow, &item.ConfigurationVersionID, &item.WorkspaceID, &item.PlanOnly, &item.CreatedBy, &item.TerraformVersion, &item.AllowEmptyApply, &item.TestOnly, &item.TestReport, &item.ExecutionMode, &item.Latest, &item.OrganizationName, &item.CostEstimationEnabled, ingressAttributesRow, runStatusTimestampsArray, planStatusTimestampsArray, applyStatusTimestampsArray, testStatusTimestampsArray, runVariablesArray); err != nil {
 		return item, fmt.Errorf("scan FindRunByIDBatch row: %w", err)
 	}
 	if err := planResourceReportRow.AssignTo(&item.PlanResourceReport); err != nil {
@@ -646,6 +692,9 @@ func (q *DBQuerier) FindRunByIDScan(results pgx.BatchResults) (FindRunByIDRow, e
 	if err := applyStatusTimestampsArray.AssignTo(&item.ApplyStatusTimestamps); err != nil {
 		return item, fmt.Errorf("assign FindRunByID row: %w", err)
 	}
+	if err := testStatusTimestampsArray.AssignTo(&item.TestStatusTimestamps); err != nil {
+		return item, fmt.Errorf("assign FindRunByID row: %w", err)
+	}
 	if err := runVariablesArray.AssignTo(&item.RunVariables); err != nil {
 		return item, fmt.Errorf("assign FindRunByID row: %w", err)
 	}
@@ -664,6 +713,7 @@ const findRunByIDForUpdateSQL = `SELECT
     runs.status,
     plans.status        AS plan_status,
     applies.status      AS apply_status,
+    tests.status        AS test_status,
     runs.replace_addrs,
     runs.target_addrs,
     runs.auto_apply,
@@ -676,6 +726,8 @@ const findRunByIDForUpdateSQL = `SELECT
     runs.created_by,
     runs.terraform_version,
     runs.allow_empty_apply,
+    runs.test_only,
+    tests.report AS test_report,
     workspaces.execution_mode AS execution_mode,
     CASE WHEN workspaces.latest_run_id = runs.run_id THEN true
          ELSE false
@@ -703,6 +755,13 @@ const findRunByIDForUpdateSQL = `SELECT
         AND   st.phase = 'apply'
         GROUP BY run_id, phase
     ) AS apply_status_timestamps,
+    (
+        SELECT array_agg(st.*) AS phase_status_timestamps
+        FROM phase_status_timestamps st
+        WHERE st.run_id = tests.run_id
+        AND   st.phase = 'test'
+        GROUP BY run_id, phase
+    ) AS test_status_timestamps,
     (
         SELECT array_agg(v.*) AS run_variables
         FROM run_variables v
@@ -712,11 +771,12 @@ const findRunByIDForUpdateSQL = `SELECT
 FROM runs
 JOIN plans USING (run_id)
 JOIN applies USING (run_id)
+JOIN tests USING (run_id)
 JOIN (configuration_versions LEFT JOIN ingress_attributes ia USING (configuration_version_id)) USING (configuration_version_id)
 JOIN workspaces ON runs.workspace_id = workspaces.workspace_id
 JOIN organizations ON workspaces.organization_name = organizations.name
 WHERE runs.run_id = $1
-FOR UPDATE OF runs, plans, applies
+FOR UPDATE OF runs, plans, applies, tests
 ;`
 
 type FindRunByIDForUpdateRow struct {
@@ -731,6 +791,7 @@ type FindRunByIDForUpdateRow struct {
 	Status                 pgtype.Text             `json:"status"`
 	PlanStatus             pgtype.Text             `json:"plan_status"`
 	ApplyStatus            pgtype.Text             `json:"apply_status"`
+	TestStatus             pgtype.Text             `json:"test_status"`
 	ReplaceAddrs           []string                `json:"replace_addrs"`
 	TargetAddrs            []string                `json:"target_addrs"`
 	AutoApply              bool                    `json:"auto_apply"`
@@ -743,6 +804,8 @@ type FindRunByIDForUpdateRow struct {
 	CreatedBy              pgtype.Text             `json:"created_by"`
 	TerraformVersion       pgtype.Text             `json:"terraform_version"`
 	AllowEmptyApply        bool                    `json:"allow_empty_apply"`
+	TestOnly               bool                    `json:"test_only"`
+	TestReport             []byte                  `json:"test_report"`
 	ExecutionMode          pgtype.Text             `json:"execution_mode"`
 	Latest                 bool                    `json:"latest"`
 	OrganizationName       pgtype.Text             `json:"organization_name"`
@@ -751,6 +814,7 @@ type FindRunByIDForUpdateRow struct {
 	RunStatusTimestamps    []RunStatusTimestamps   `json:"run_status_timestamps"`
 	PlanStatusTimestamps   []PhaseStatusTimestamps `json:"plan_status_timestamps"`
 	ApplyStatusTimestamps  []PhaseStatusTimestamps `json:"apply_status_timestamps"`
+	TestStatusTimestamps   []PhaseStatusTimestamps `json:"test_status_timestamps"`
 	RunVariables           []RunVariables          `json:"run_variables"`
 }
 
@@ -766,8 +830,9 @@ func (q *DBQuerier) FindRunByIDForUpdate(ctx context.Context, runID pgtype.Text)
 	runStatusTimestampsArray := q.types.newRunStatusTimestampsArray()
 	planStatusTimestampsArray := q.types.newPhaseStatusTimestampsArray()
 	applyStatusTimestampsArray := q.types.newPhaseStatusTimestampsArray()
+	testStatusTimestampsArray := q.types.newPhaseStatusTimestampsArray()
 	runVariablesArray := q.types.newRunVariablesArray()
-	if err := row.Scan(&item.RunID, &item.CreatedAt, &item.ForceCancelAvailableAt, &item.IsDestroy, &item.PositionInQueue, &item.Refresh, &item.RefreshOnly, &item.Source, &item.Status, &item.PlanStatus, &item.ApplyStatus, &item.ReplaceAddrs, &item.TargetAddrs, &item.AutoApply, planResourceReportRow, planOutputReportRow, applyResourceReportRow, &item.ConfigurationVersionID, &item.WorkspaceID, &item.PlanOnly, &item.CreatedBy, &item.TerraformVersion, &item.AllowEmptyApply, &item.ExecutionMode, &item.Latest, &item.OrganizationName, &item.CostEstimationEnabled, ingressAttributesRow, runStatusTimestampsArray, planStatusTimestampsArray, applyStatusTimestampsArray, runVariablesArray); err != nil {
+	if err := row.Scan(&item.RunID, &item.CreatedAt, &item.ForceCancelAvailableAt, &item.IsDestroy, &item.PositionInQueue, &item.Refresh, &item.RefreshOnly, &item.Source, &item.Status, &item.PlanStatus, &item.ApplyStatus, &item.TestStatus, &item.ReplaceAddrs, &item.TargetAddrs, &item.AutoApply, planResourceReportRow, planOutputReportRow, applyResourceReportRow, &item.ConfigurationVersionID, &item.WorkspaceID, &item.PlanOnly, &item.CreatedBy, &item.TerraformVersion, &item.AllowEmptyApply, &item.TestOnly, &item.TestReport, &item.ExecutionMode, &item.Latest, &item.OrganizationName, &item.CostEstimationEnabled, ingressAttributesRow, runStatusTimestampsArray, planStatusTimestampsArray, applyStatusTimestampsArray, testStatusTimestampsArray, runVariablesArray); err != nil {
 		return item, fmt.Errorf("query FindRunByIDForUpdate: %w", err)
 	}
 	if err := planResourceReportRow.AssignTo(&item.PlanResourceReport); err != nil {
@@ -791,6 +856,9 @@ func (q *DBQuerier) FindRunByIDForUpdate(ctx context.Context, runID pgtype.Text)
 	if err := applyStatusTimestampsArray.AssignTo(&item.ApplyStatusTimestamps); err != nil {
 		return item, fmt.Errorf("assign FindRunByIDForUpdate row: %w", err)
 	}
+	if err := testStatusTimestampsArray.AssignTo(&item.TestStatusTimestamps); err != nil {
+		return item, fmt.Errorf("assign FindRunByIDForUpdate row: %w", err)
+	}
 	if err := runVariablesArray.AssignTo(&item.RunVariables); err != nil {
 		return item, fmt.Errorf("assign FindRunByIDForUpdate row: %w", err)
 	}
@@ -813,8 +881,9 @@ func (q *DBQuerier) FindRunByIDForUpdateScan(results pgx.BatchResults) (FindRunB
 	runStatusTimestampsArray := q.types.newRunStatusTimestampsArray()
 	planStatusTimestampsArray := q.types.newPhaseStatusTimestampsArray()
 	applyStatusTimestampsArray := q.types.newPhaseStatusTimestampsArray()
+	testStatusTimestampsArray := q.types.newPhaseStatusTimestampsArray()
 	runVariablesArray := q.types.newRunVariablesArray()
-	if err := row.Scan(&item.RunID, &item.CreatedAt, &item.ForceCancelAvailableAt, &item.IsDestroy, &item.PositionInQueue, &item.Refresh, &item.RefreshOnly, &item.Source, &item.Status, &item.PlanStatus, &item.ApplyStatus, &item.ReplaceAddrs, &item.TargetAddrs, &item.AutoApply, planResourceReportRow, planOutputReportRow, applyResourceReportRow, &item.ConfigurationVersionID, &item.WorkspaceID, &item.PlanOnly, &item.CreatedBy, &item.TerraformVersion, &item.AllowEmptyApply, &item.ExecutionMode, &item.Latest, &item.OrganizationName, &item.CostEstimationEnabled, ingressAttributesRow, runStatusTimestampsArray, planStatusTimestampsArray, applyStatusTimestampsArray, runVariablesArray); err != nil {
+	if err := row.Scan(&item.RunID, &item.CreatedAt, &item.ForceCancelAvailableAt, &item.IsDestroy, &item.PositionInQueue, &item.Refresh, &item.RefreshOnly, &item.Source, &item.Status, &item.PlanStatus, &item.ApplyStatus, &item.TestStatus, &item.ReplaceAddrs, &item.TargetAddrs, &item.AutoApply, planResourceReportRow, planOutputReportRow, applyResourceReportRow, &item.ConfigurationVersionID, &item.WorkspaceID, &item.PlanOnly, &item.CreatedBy, &item.TerraformVersion, &item.AllowEmptyApply, &item.TestOnly, &item.TestReport, &item.ExecutionMode, &item.Latest, &item.OrganizationName, &item.CostEstimationEnabled, ingressAttributesRow, runStatusTimestampsArray, planStatusTimestampsArray, applyStatusTimestampsArray, testStatusTimestampsArray, runVariablesArray); err != nil {
 		return item, fmt.Errorf("scan FindRunByIDForUpdateBatch row: %w", err)
 	}
 	if err := planResourceReportRow.AssignTo(&item.PlanResourceReport); err != nil {
@@ -838,6 +907,9 @@ func (q *DBQuerier) FindRunByIDForUpdateScan(results pgx.BatchResults) (FindRunB
 	if err := applyStatusTimestampsArray.AssignTo(&item.ApplyStatusTimestamps); err != nil {
 		return item, fmt.Errorf("assign FindRunByIDForUpdate row: %w", err)
 	}
+	if err := testStatusTimestampsArray.AssignTo(&item.TestStatusTimestamps); err != nil {
+		return item, fmt.Errorf("assign FindRunByIDForUpdate row: %w", err)
+	}
 	if err := runVariablesArray.AssignTo(&item.RunVariables); err != nil {
 		return item, fmt.Errorf("assign FindRunByIDForUpdate row: %w", err)
 	}
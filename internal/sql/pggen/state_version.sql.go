@@ -459,3 +459,340 @@ func (q *DBQuerier) DeleteStateVersionByIDScan(results pgx.BatchResults) (pgtype
 	}
 	return item, nil
 }
+
+const insertStateVersionResourceSQL = `INSERT INTO state_version_resources (
+    state_version_id,
+    address,
+    mode,
+    type,
+    provider,
+    module,
+    attribute_paths,
+    attribute_values
+) VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    $7,
+    $8
+)
+ON CONFLICT DO NOTHING;`
+
+type InsertStateVersionResourceParams struct {
+	StateVersionID  pgtype.Text
+	Address         pgtype.Text
+	Mode            pgtype.Text
+	Type            pgtype.Text
+	Provider        pgtype.Text
+	Module          pgtype.Text
+	AttributePaths  []string
+	AttributeValues []string
+}
+
+// InsertStateVersionResource implements Querier.InsertStateVersionResource.
+func (q *DBQuerier) InsertStateVersionResource(ctx context.Context, params InsertStateVersionResourceParams) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "InsertStateVersionResource")
+	cmdTag, err := q.conn.Exec(ctx, insertStateVersionResourceSQL, params.StateVersionID, params.Address, params.Mode, params.Type, params.Provider, params.Module, params.AttributePaths, params.AttributeValues)
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec query InsertStateVersionResource: %w", err)
+	}
+	return cmdTag, err
+}
+
+// InsertStateVersionResourceBatch implements Querier.InsertStateVersionResourceBatch.
+func (q *DBQuerier) InsertStateVersionResourceBatch(batch genericBatch, params InsertStateVersionResourceParams) {
+	batch.Queue(insertStateVersionResourceSQL, params.StateVersionID, params.Address, params.Mode, params.Type, params.Provider, params.Module, params.AttributePaths, params.AttributeValues)
+}
+
+// InsertStateVersionResourceScan implements Querier.InsertStateVersionResourceScan.
+func (q *DBQuerier) InsertStateVersionResourceScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec InsertStateVersionResourceBatch: %w", err)
+	}
+	return cmdTag, err
+}
+
+const updateStateVersionResourcesIndexedSQL = `UPDATE state_versions
+SET resources_indexed = true
+WHERE state_version_id = $1
+;`
+
+// UpdateStateVersionResourcesIndexed implements Querier.UpdateStateVersionResourcesIndexed.
+func (q *DBQuerier) UpdateStateVersionResourcesIndexed(ctx context.Context, stateVersionID pgtype.Text) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "UpdateStateVersionResourcesIndexed")
+	cmdTag, err := q.conn.Exec(ctx, updateStateVersionResourcesIndexedSQL, stateVersionID)
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec query UpdateStateVersionResourcesIndexed: %w", err)
+	}
+	return cmdTag, err
+}
+
+// UpdateStateVersionResourcesIndexedBatch implements Querier.UpdateStateVersionResourcesIndexedBatch.
+func (q *DBQuerier) UpdateStateVersionResourcesIndexedBatch(batch genericBatch, stateVersionID pgtype.Text) {
+	batch.Queue(updateStateVersionResourcesIndexedSQL, stateVersionID)
+}
+
+// UpdateStateVersionResourcesIndexedScan implements Querier.UpdateStateVersionResourcesIndexedScan.
+func (q *DBQuerier) UpdateStateVersionResourcesIndexedScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec UpdateStateVersionResourcesIndexedBatch: %w", err)
+	}
+	return cmdTag, err
+}
+
+const findUnindexedCurrentStateVersionsByOrganizationSQL = `SELECT
+    sv.state_version_id,
+    sv.state
+FROM workspaces w
+JOIN state_versions sv ON w.current_state_version_id = sv.state_version_id
+WHERE w.organization_name = $1
+AND   NOT sv.resources_indexed
+;`
+
+type FindUnindexedCurrentStateVersionsByOrganizationRow struct {
+	StateVersionID pgtype.Text `json:"state_version_id"`
+	State          []byte      `json:"state"`
+}
+
+// FindUnindexedCurrentStateVersionsByOrganization implements Querier.FindUnindexedCurrentStateVersionsByOrganization.
+func (q *DBQuerier) FindUnindexedCurrentStateVersionsByOrganization(ctx context.Context, organizationName pgtype.Text) ([]FindUnindexedCurrentStateVersionsByOrganizationRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "FindUnindexedCurrentStateVersionsByOrganization")
+	rows, err := q.conn.Query(ctx, findUnindexedCurrentStateVersionsByOrganizationSQL, organizationName)
+	if err != nil {
+		return nil, fmt.Errorf("query FindUnindexedCurrentStateVersionsByOrganization: %w", err)
+	}
+	defer rows.Close()
+	items := []FindUnindexedCurrentStateVersionsByOrganizationRow{}
+	for rows.Next() {
+		var item FindUnindexedCurrentStateVersionsByOrganizationRow
+		if err := rows.Scan(&item.StateVersionID, &item.State); err != nil {
+			return nil, fmt.Errorf("scan FindUnindexedCurrentStateVersionsByOrganization row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindUnindexedCurrentStateVersionsByOrganization rows: %w", err)
+	}
+	return items, err
+}
+
+// FindUnindexedCurrentStateVersionsByOrganizationBatch implements Querier.FindUnindexedCurrentStateVersionsByOrganizationBatch.
+func (q *DBQuerier) FindUnindexedCurrentStateVersionsByOrganizationBatch(batch genericBatch, organizationName pgtype.Text) {
+	batch.Queue(findUnindexedCurrentStateVersionsByOrganizationSQL, organizationName)
+}
+
+// FindUnindexedCurrentStateVersionsByOrganizationScan implements Querier.FindUnindexedCurrentStateVersionsByOrganizationScan.
+func (q *DBQuerier) FindUnindexedCurrentStateVersionsByOrganizationScan(results pgx.BatchResults) ([]FindUnindexedCurrentStateVersionsByOrganizationRow, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query FindUnindexedCurrentStateVersionsByOrganizationBatch: %w", err)
+	}
+	defer rows.Close()
+	items := []FindUnindexedCurrentStateVersionsByOrganizationRow{}
+	for rows.Next() {
+		var item FindUnindexedCurrentStateVersionsByOrganizationRow
+		if err := rows.Scan(&item.StateVersionID, &item.State); err != nil {
+			return nil, fmt.Errorf("scan FindUnindexedCurrentStateVersionsByOrganizationBatch row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindUnindexedCurrentStateVersionsByOrganizationBatch rows: %w", err)
+	}
+	return items, err
+}
+
+const findCurrentStateWorkspaceIDsByOrganizationSQL = `SELECT w.workspace_id
+FROM workspaces w
+WHERE w.organization_name = $1
+AND   w.current_state_version_id IS NOT NULL
+;`
+
+// FindCurrentStateWorkspaceIDsByOrganization implements Querier.FindCurrentStateWorkspaceIDsByOrganization.
+func (q *DBQuerier) FindCurrentStateWorkspaceIDsByOrganization(ctx context.Context, organizationName pgtype.Text) ([]pgtype.Text, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "FindCurrentStateWorkspaceIDsByOrganization")
+	rows, err := q.conn.Query(ctx, findCurrentStateWorkspaceIDsByOrganizationSQL, organizationName)
+	if err != nil {
+		return nil, fmt.Errorf("query FindCurrentStateWorkspaceIDsByOrganization: %w", err)
+	}
+	defer rows.Close()
+	items := []pgtype.Text{}
+	for rows.Next() {
+		var item pgtype.Text
+		if err := rows.Scan(&item); err != nil {
+			return nil, fmt.Errorf("scan FindCurrentStateWorkspaceIDsByOrganization row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindCurrentStateWorkspaceIDsByOrganization rows: %w", err)
+	}
+	return items, err
+}
+
+// FindCurrentStateWorkspaceIDsByOrganizationBatch implements Querier.FindCurrentStateWorkspaceIDsByOrganizationBatch.
+func (q *DBQuerier) FindCurrentStateWorkspaceIDsByOrganizationBatch(batch genericBatch, organizationName pgtype.Text) {
+	batch.Queue(findCurrentStateWorkspaceIDsByOrganizationSQL, organizationName)
+}
+
+// FindCurrentStateWorkspaceIDsByOrganizationScan implements Querier.FindCurrentStateWorkspaceIDsByOrganizationScan.
+func (q *DBQuerier) FindCurrentStateWorkspaceIDsByOrganizationScan(results pgx.BatchResults) ([]pgtype.Text, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query FindCurrentStateWorkspaceIDsByOrganizationBatch: %w", err)
+	}
+	defer rows.Close()
+	items := []pgtype.Text{}
+	for rows.Next() {
+		var item pgtype.Text
+		if err := rows.Scan(&item); err != nil {
+			return nil, fmt.Errorf("scan FindCurrentStateWorkspaceIDsByOrganizationBatch row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindCurrentStateWorkspaceIDsByOrganizationBatch rows: %w", err)
+	}
+	return items, err
+}
+
+const findStateResourcesSQL = `SELECT
+    w.workspace_id,
+    w.name AS workspace_name,
+    r.state_version_id,
+    r.address,
+    r.type,
+    r.provider,
+    r.module,
+    CASE WHEN $1::text = '' THEN NULL
+         ELSE r.attribute_paths[array_position(r.attribute_values, $1::text)]
+    END AS attribute
+FROM state_version_resources r
+JOIN workspaces w ON w.current_state_version_id = r.state_version_id
+WHERE w.organization_name = $2
+AND   (($3::text[]) IS NULL OR w.workspace_id = ANY($3::text[]))
+AND   ($4::text = '' OR strpos(r.address, $4::text) > 0)
+AND   ($5::text = '' OR r.type = $5::text)
+AND   ($1::text = '' OR r.attribute_values @> ARRAY[$1::text])
+ORDER BY w.name, r.address
+LIMIT $6
+OFFSET $7
+;`
+
+type FindStateResourcesParams struct {
+	Value            pgtype.Text
+	OrganizationName pgtype.Text
+	WorkspaceIds     []string
+	Address          pgtype.Text
+	Type             pgtype.Text
+	Limit            pgtype.Int8
+	Offset           pgtype.Int8
+}
+
+type FindStateResourcesRow struct {
+	WorkspaceID    pgtype.Text `json:"workspace_id"`
+	WorkspaceName  pgtype.Text `json:"workspace_name"`
+	StateVersionID pgtype.Text `json:"state_version_id"`
+	Address        pgtype.Text `json:"address"`
+	Type           pgtype.Text `json:"type"`
+	Provider       pgtype.Text `json:"provider"`
+	Module         pgtype.Text `json:"module"`
+	Attribute      pgtype.Text `json:"attribute"`
+}
+
+// FindStateResources implements Querier.FindStateResources.
+func (q *DBQuerier) FindStateResources(ctx context.Context, params FindStateResourcesParams) ([]FindStateResourcesRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "FindStateResources")
+	rows, err := q.conn.Query(ctx, findStateResourcesSQL, params.Value, params.OrganizationName, params.WorkspaceIds, params.Address, params.Type, params.Limit, params.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("query FindStateResources: %w", err)
+	}
+	defer rows.Close()
+	items := []FindStateResourcesRow{}
+	for rows.Next() {
+		var item FindStateResourcesRow
+		if err := rows.Scan(&item.WorkspaceID, &item.WorkspaceName, &item.StateVersionID, &item.Address, &item.Type, &item.Provider, &item.Module, &item.Attribute); err != nil {
+			return nil, fmt.Errorf("scan FindStateResources row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindStateResources rows: %w", err)
+	}
+	return items, err
+}
+
+// FindStateResourcesBatch implements Querier.FindStateResourcesBatch.
+func (q *DBQuerier) FindStateResourcesBatch(batch genericBatch, params FindStateResourcesParams) {
+	batch.Queue(findStateResourcesSQL, params.Value, params.OrganizationName, params.WorkspaceIds, params.Address, params.Type, params.Limit, params.Offset)
+}
+
+// FindStateResourcesScan implements Querier.FindStateResourcesScan.
+func (q *DBQuerier) FindStateResourcesScan(results pgx.BatchResults) ([]FindStateResourcesRow, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query FindStateResourcesBatch: %w", err)
+	}
+	defer rows.Close()
+	items := []FindStateResourcesRow{}
+	for rows.Next() {
+		var item FindStateResourcesRow
+		if err := rows.Scan(&item.WorkspaceID, &item.WorkspaceName, &item.StateVersionID, &item.Address, &item.Type, &item.Provider, &item.Module, &item.Attribute); err != nil {
+			return nil, fmt.Errorf("scan FindStateResourcesBatch row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindStateResourcesBatch rows: %w", err)
+	}
+	return items, err
+}
+
+const countStateResourcesSQL = `SELECT count(*)
+FROM state_version_resources r
+JOIN workspaces w ON w.current_state_version_id = r.state_version_id
+WHERE w.organization_name = $1
+AND   (($2::text[]) IS NULL OR w.workspace_id = ANY($2::text[]))
+AND   ($3::text = '' OR strpos(r.address, $3::text) > 0)
+AND   ($4::text = '' OR r.type = $4::text)
+AND   ($5::text = '' OR r.attribute_values @> ARRAY[$5::text])
+;`
+
+type CountStateResourcesParams struct {
+	OrganizationName pgtype.Text
+	WorkspaceIds     []string
+	Address          pgtype.Text
+	Type             pgtype.Text
+	Value            pgtype.Text
+}
+
+// CountStateResources implements Querier.CountStateResources.
+func (q *DBQuerier) CountStateResources(ctx context.Context, params CountStateResourcesParams) (pgtype.Int8, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "CountStateResources")
+	row := q.conn.QueryRow(ctx, countStateResourcesSQL, params.OrganizationName, params.WorkspaceIds, params.Address, params.Type, params.Value)
+	var item pgtype.Int8
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("query CountStateResources: %w", err)
+	}
+	return item, nil
+}
+
+// CountStateResourcesBatch implements Querier.CountStateResourcesBatch.
+func (q *DBQuerier) CountStateResourcesBatch(batch genericBatch, params CountStateResourcesParams) {
+	batch.Queue(countStateResourcesSQL, params.OrganizationName, params.WorkspaceIds, params.Address, params.Type, params.Value)
+}
+
+// CountStateResourcesScan implements Querier.CountStateResourcesScan.
+func (q *DBQuerier) CountStateResourcesScan(results pgx.BatchResults) (pgtype.Int8, error) {
+	row := results.QueryRow()
+	var item pgtype.Int8
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("scan CountStateResourcesBatch row: %w", err)
+	}
+	return item, nil
+}
@@ -146,6 +146,75 @@ func (q *DBQuerier) FindTeamsByOrgScan(results pgx.BatchResults) ([]FindTeamsByO
 	return items, err
 }
 
+const findTeamsWithSSOTeamIDSQL = `SELECT *
+FROM teams
+WHERE sso_team_id IS NOT NULL
+AND   organization_name = ANY($1::text[])
+;`
+
+type FindTeamsWithSSOTeamIDRow struct {
+	TeamID                          pgtype.Text        `json:"team_id"`
+	Name                            pgtype.Text        `json:"name"`
+	CreatedAt                       pgtype.Timestamptz `json:"created_at"`
+	PermissionManageWorkspaces      bool               `json:"permission_manage_workspaces"`
+	PermissionManageVCS             bool               `json:"permission_manage_vcs"`
+	PermissionManageModules         bool               `json:"permission_manage_modules"`
+	OrganizationName                pgtype.Text        `json:"organization_name"`
+	SSOTeamID                       pgtype.Text        `json:"sso_team_id"`
+	Visibility                      pgtype.Text        `json:"visibility"`
+	PermissionManagePolicies        bool               `json:"permission_manage_policies"`
+	PermissionManagePolicyOverrides bool               `json:"permission_manage_policy_overrides"`
+	PermissionManageProviders       bool               `json:"permission_manage_providers"`
+}
+
+// FindTeamsWithSSOTeamID implements Querier.FindTeamsWithSSOTeamID.
+func (q *DBQuerier) FindTeamsWithSSOTeamID(ctx context.Context, organizationNames []string) ([]FindTeamsWithSSOTeamIDRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "FindTeamsWithSSOTeamID")
+	rows, err := q.conn.Query(ctx, findTeamsWithSSOTeamIDSQL, organizationNames)
+	if err != nil {
+		return nil, fmt.Errorf("query FindTeamsWithSSOTeamID: %w", err)
+	}
+	defer rows.Close()
+	items := []FindTeamsWithSSOTeamIDRow{}
+	for rows.Next() {
+		var item FindTeamsWithSSOTeamIDRow
+		if err := rows.Scan(&item.TeamID, &item.Name, &item.CreatedAt, &item.PermissionManageWorkspaces, &item.PermissionManageVCS, &item.PermissionManageModules, &item.OrganizationName, &item.SSOTeamID, &item.Visibility, &item.PermissionManagePolicies, &item.PermissionManagePolicyOverrides, &item.PermissionManageProviders); err != nil {
+			return nil, fmt.Errorf("scan FindTeamsWithSSOTeamID row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindTeamsWithSSOTeamID rows: %w", err)
+	}
+	return items, err
+}
+
+// FindTeamsWithSSOTeamIDBatch implements Querier.FindTeamsWithSSOTeamIDBatch.
+func (q *DBQuerier) FindTeamsWithSSOTeamIDBatch(batch genericBatch, organizationNames []string) {
+	batch.Queue(findTeamsWithSSOTeamIDSQL, organizationNames)
+}
+
+// FindTeamsWithSSOTeamIDScan implements Querier.FindTeamsWithSSOTeamIDScan.
+func (q *DBQuerier) FindTeamsWithSSOTeamIDScan(results pgx.BatchResults) ([]FindTeamsWithSSOTeamIDRow, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query FindTeamsWithSSOTeamIDBatch: %w", err)
+	}
+	defer rows.Close()
+	items := []FindTeamsWithSSOTeamIDRow{}
+	for rows.Next() {
+		var item FindTeamsWithSSOTeamIDRow
+		if err := rows.Scan(&item.TeamID, &item.Name, &item.CreatedAt, &item.PermissionManageWorkspaces, &item.PermissionManageVCS, &item.PermissionManageModules, &item.OrganizationName, &item.SSOTeamID, &item.Visibility, &item.PermissionManagePolicies, &item.PermissionManagePolicyOverrides, &item.PermissionManageProviders); err != nil {
+			return nil, fmt.Errorf("scan FindTeamsWithSSOTeamIDBatch row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindTeamsWithSSOTeamIDBatch rows: %w", err)
+	}
+	return items, err
+}
+
 const findTeamByNameSQL = `SELECT *
 FROM teams
 WHERE name              = $1
@@ -15,28 +15,38 @@ const insertTeamTokenSQL = `INSERT INTO team_tokens (
     team_token_id,
     created_at,
     team_id,
-    expiry
+    expiry,
+    scope_actions,
+    scope_workspace_ids
 ) VALUES (
     $1,
     $2,
     $3,
-    $4
+    $4,
+    $5,
+    $6
 ) ON CONFLICT (team_id) DO UPDATE
-  SET team_token_id = $1,
-      created_at    = $2,
-      expiry        = $4;`
+  SET team_token_id       = $1,
+      created_at          = $2,
+      expiry              = $4,
+      scope_actions       = $5,
+      scope_workspace_ids = $6,
+      last_used_at        = NULL,
+      last_used_ip        = NULL;`
 
 type InsertTeamTokenParams struct {
-	TeamTokenID pgtype.Text
-	CreatedAt   pgtype.Timestamptz
-	TeamID      pgtype.Text
-	Expiry      pgtype.Timestamptz
+	TeamTokenID       pgtype.Text
+	CreatedAt         pgtype.Timestamptz
+	TeamID            pgtype.Text
+	Expiry            pgtype.Timestamptz
+	ScopeActions      []string
+	ScopeWorkspaceIds []string
 }
 
 // InsertTeamToken implements Querier.InsertTeamToken.
 func (q *DBQuerier) InsertTeamToken(ctx context.Context, params InsertTeamTokenParams) (pgconn.CommandTag, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "InsertTeamToken")
-	cmdTag, err := q.conn.Exec(ctx, insertTeamTokenSQL, params.TeamTokenID, params.CreatedAt, params.TeamID, params.Expiry)
+	cmdTag, err := q.conn.Exec(ctx, insertTeamTokenSQL, params.TeamTokenID, params.CreatedAt, params.TeamID, params.Expiry, params.ScopeActions, params.ScopeWorkspaceIds)
 	if err != nil {
 		return cmdTag, fmt.Errorf("exec query InsertTeamToken: %w", err)
 	}
@@ -45,7 +55,7 @@ func (q *DBQuerier) InsertTeamToken(ctx context.Context, params InsertTeamTokenP
 
 // InsertTeamTokenBatch implements Querier.InsertTeamTokenBatch.
 func (q *DBQuerier) InsertTeamTokenBatch(batch genericBatch, params InsertTeamTokenParams) {
-	batch.Queue(insertTeamTokenSQL, params.TeamTokenID, params.CreatedAt, params.TeamID, params.Expiry)
+	batch.Queue(insertTeamTokenSQL, params.TeamTokenID, params.CreatedAt, params.TeamID, params.Expiry, params.ScopeActions, params.ScopeWorkspaceIds)
 }
 
 // InsertTeamTokenScan implements Querier.InsertTeamTokenScan.
@@ -63,11 +73,15 @@ WHERE team_id = $1
 ;`
 
 type FindTeamTokensByIDRow struct {
-	TeamTokenID pgtype.Text        `json:"team_token_id"`
-	Description pgtype.Text        `json:"description"`
-	CreatedAt   pgtype.Timestamptz `json:"created_at"`
-	TeamID      pgtype.Text        `json:"team_id"`
-	Expiry      pgtype.Timestamptz `json:"expiry"`
+	TeamTokenID       pgtype.Text        `json:"team_token_id"`
+	Description       pgtype.Text        `json:"description"`
+	CreatedAt         pgtype.Timestamptz `json:"created_at"`
+	TeamID            pgtype.Text        `json:"team_id"`
+	Expiry            pgtype.Timestamptz `json:"expiry"`
+	ScopeActions      []string           `json:"scope_actions"`
+	ScopeWorkspaceIds []string           `json:"scope_workspace_ids"`
+	LastUsedAt        pgtype.Timestamptz `json:"last_used_at"`
+	LastUsedIp        pgtype.Text        `json:"last_used_ip"`
 }
 
 // FindTeamTokensByID implements Querier.FindTeamTokensByID.
@@ -81,7 +95,7 @@ func (q *DBQuerier) FindTeamTokensByID(ctx context.Context, teamID pgtype.Text)
 	items := []FindTeamTokensByIDRow{}
 	for rows.Next() {
 		var item FindTeamTokensByIDRow
-		if err := rows.Scan(&item.TeamTokenID, &item.Description, &item.CreatedAt, &item.TeamID, &item.Expiry); err != nil {
+		if err := rows.Scan(&item.TeamTokenID, &item.Description, &item.CreatedAt, &item.TeamID, &item.Expiry, &item.ScopeActions, &item.ScopeWorkspaceIds, &item.LastUsedAt, &item.LastUsedIp); err != nil {
 			return nil, fmt.Errorf("scan FindTeamTokensByID row: %w", err)
 		}
 		items = append(items, item)
@@ -107,7 +121,7 @@ func (q *DBQuerier) FindTeamTokensByIDScan(results pgx.BatchResults) ([]FindTeam
 	items := []FindTeamTokensByIDRow{}
 	for rows.Next() {
 		var item FindTeamTokensByIDRow
-		if err := rows.Scan(&item.TeamTokenID, &item.Description, &item.CreatedAt, &item.TeamID, &item.Expiry); err != nil {
+		if err := rows.Scan(&item.TeamTokenID, &item.Description, &item.CreatedAt, &item.TeamID, &item.Expiry, &item.ScopeActions, &item.ScopeWorkspaceIds, &item.LastUsedAt, &item.LastUsedIp); err != nil {
 			return nil, fmt.Errorf("scan FindTeamTokensByIDBatch row: %w", err)
 		}
 		items = append(items, item)
@@ -118,6 +132,42 @@ func (q *DBQuerier) FindTeamTokensByIDScan(results pgx.BatchResults) ([]FindTeam
 	return items, err
 }
 
+const updateTeamTokenLastUsedSQL = `UPDATE team_tokens
+SET last_used_at = $1,
+    last_used_ip = $2
+WHERE team_token_id = $3
+;`
+
+type UpdateTeamTokenLastUsedParams struct {
+	LastUsedAt  pgtype.Timestamptz
+	LastUsedIp  pgtype.Text
+	TeamTokenID pgtype.Text
+}
+
+// UpdateTeamTokenLastUsed implements Querier.UpdateTeamTokenLastUsed.
+func (q *DBQuerier) UpdateTeamTokenLastUsed(ctx context.Context, params UpdateTeamTokenLastUsedParams) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "UpdateTeamTokenLastUsed")
+	cmdTag, err := q.conn.Exec(ctx, updateTeamTokenLastUsedSQL, params.LastUsedAt, params.LastUsedIp, params.TeamTokenID)
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec query UpdateTeamTokenLastUsed: %w", err)
+	}
+	return cmdTag, err
+}
+
+// UpdateTeamTokenLastUsedBatch implements Querier.UpdateTeamTokenLastUsedBatch.
+func (q *DBQuerier) UpdateTeamTokenLastUsedBatch(batch genericBatch, params UpdateTeamTokenLastUsedParams) {
+	batch.Queue(updateTeamTokenLastUsedSQL, params.LastUsedAt, params.LastUsedIp, params.TeamTokenID)
+}
+
+// UpdateTeamTokenLastUsedScan implements Querier.UpdateTeamTokenLastUsedScan.
+func (q *DBQuerier) UpdateTeamTokenLastUsedScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec UpdateTeamTokenLastUsedBatch: %w", err)
+	}
+	return cmdTag, err
+}
+
 const deleteTeamTokenByIDSQL = `DELETE
 FROM team_tokens
 WHERE team_id = $1
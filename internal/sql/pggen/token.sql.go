@@ -15,25 +15,34 @@ const insertTokenSQL = `INSERT INTO tokens (
     token_id,
     created_at,
     description,
-    username
+    username,
+    expiry,
+    scope_actions,
+    scope_workspace_ids
 ) VALUES (
     $1,
     $2,
     $3,
-    $4
+    $4,
+    $5,
+    $6,
+    $7
 );`
 
 type InsertTokenParams struct {
-	TokenID     pgtype.Text
-	CreatedAt   pgtype.Timestamptz
-	Description pgtype.Text
-	Username    pgtype.Text
+	TokenID           pgtype.Text
+	CreatedAt         pgtype.Timestamptz
+	Description       pgtype.Text
+	Username          pgtype.Text
+	Expiry            pgtype.Timestamptz
+	ScopeActions      []string
+	ScopeWorkspaceIds []string
 }
 
 // InsertToken implements Querier.InsertToken.
 func (q *DBQuerier) InsertToken(ctx context.Context, params InsertTokenParams) (pgconn.CommandTag, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "InsertToken")
-	cmdTag, err := q.conn.Exec(ctx, insertTokenSQL, params.TokenID, params.CreatedAt, params.Description, params.Username)
+	cmdTag, err := q.conn.Exec(ctx, insertTokenSQL, params.TokenID, params.CreatedAt, params.Description, params.Username, params.Expiry, params.ScopeActions, params.ScopeWorkspaceIds)
 	if err != nil {
 		return cmdTag, fmt.Errorf("exec query InsertToken: %w", err)
 	}
@@ -42,7 +51,7 @@ func (q *DBQuerier) InsertToken(ctx context.Context, params InsertTokenParams) (
 
 // InsertTokenBatch implements Querier.InsertTokenBatch.
 func (q *DBQuerier) InsertTokenBatch(batch genericBatch, params InsertTokenParams) {
-	batch.Queue(insertTokenSQL, params.TokenID, params.CreatedAt, params.Description, params.Username)
+	batch.Queue(insertTokenSQL, params.TokenID, params.CreatedAt, params.Description, params.Username, params.Expiry, params.ScopeActions, params.ScopeWorkspaceIds)
 }
 
 // InsertTokenScan implements Querier.InsertTokenScan.
@@ -60,10 +69,15 @@ WHERE username = $1
 ;`
 
 type FindTokensByUsernameRow struct {
-	TokenID     pgtype.Text        `json:"token_id"`
-	CreatedAt   pgtype.Timestamptz `json:"created_at"`
-	Description pgtype.Text        `json:"description"`
-	Username    pgtype.Text        `json:"username"`
+	TokenID           pgtype.Text        `json:"token_id"`
+	CreatedAt         pgtype.Timestamptz `json:"created_at"`
+	Description       pgtype.Text        `json:"description"`
+	Username          pgtype.Text        `json:"username"`
+	Expiry            pgtype.Timestamptz `json:"expiry"`
+	ScopeActions      []string           `json:"scope_actions"`
+	ScopeWorkspaceIds []string           `json:"scope_workspace_ids"`
+	LastUsedAt        pgtype.Timestamptz `json:"last_used_at"`
+	LastUsedIp        pgtype.Text        `json:"last_used_ip"`
 }
 
 // FindTokensByUsername implements Querier.FindTokensByUsername.
@@ -77,7 +91,7 @@ func (q *DBQuerier) FindTokensByUsername(ctx context.Context, username pgtype.Te
 	items := []FindTokensByUsernameRow{}
 	for rows.Next() {
 		var item FindTokensByUsernameRow
-		if err := rows.Scan(&item.TokenID, &item.CreatedAt, &item.Description, &item.Username); err != nil {
+		if err := rows.Scan(&item.TokenID, &item.CreatedAt, &item.Description, &item.Username, &item.Expiry, &item.ScopeActions, &item.ScopeWorkspaceIds, &item.LastUsedAt, &item.LastUsedIp); err != nil {
 			return nil, fmt.Errorf("scan FindTokensByUsername row: %w", err)
 		}
 		items = append(items, item)
@@ -103,7 +117,7 @@ func (q *DBQuerier) FindTokensByUsernameScan(results pgx.BatchResults) ([]FindTo
 	items := []FindTokensByUsernameRow{}
 	for rows.Next() {
 		var item FindTokensByUsernameRow
-		if err := rows.Scan(&item.TokenID, &item.CreatedAt, &item.Description, &item.Username); err != nil {
+		if err := rows.Scan(&item.TokenID, &item.CreatedAt, &item.Description, &item.Username, &item.Expiry, &item.ScopeActions, &item.ScopeWorkspaceIds, &item.LastUsedAt, &item.LastUsedIp); err != nil {
 			return nil, fmt.Errorf("scan FindTokensByUsernameBatch row: %w", err)
 		}
 		items = append(items, item)
@@ -120,10 +134,15 @@ WHERE token_id = $1
 ;`
 
 type FindTokenByIDRow struct {
-	TokenID     pgtype.Text        `json:"token_id"`
-	CreatedAt   pgtype.Timestamptz `json:"created_at"`
-	Description pgtype.Text        `json:"description"`
-	Username    pgtype.Text        `json:"username"`
+	TokenID           pgtype.Text        `json:"token_id"`
+	CreatedAt         pgtype.Timestamptz `json:"created_at"`
+	Description       pgtype.Text        `json:"description"`
+	Username          pgtype.Text        `json:"username"`
+	Expiry            pgtype.Timestamptz `json:"expiry"`
+	ScopeActions      []string           `json:"scope_actions"`
+	ScopeWorkspaceIds []string           `json:"scope_workspace_ids"`
+	LastUsedAt        pgtype.Timestamptz `json:"last_used_at"`
+	LastUsedIp        pgtype.Text        `json:"last_used_ip"`
 }
 
 // FindTokenByID implements Querier.FindTokenByID.
@@ -131,7 +150,7 @@ func (q *DBQuerier) FindTokenByID(ctx context.Context, tokenID pgtype.Text) (Fin
 	ctx = context.WithValue(ctx, "pggen_query_name", "FindTokenByID")
 	row := q.conn.QueryRow(ctx, findTokenByIDSQL, tokenID)
 	var item FindTokenByIDRow
-	if err := row.Scan(&item.TokenID, &item.CreatedAt, &item.Description, &item.Username); err != nil {
+	if err := row.Scan(&item.TokenID, &item.CreatedAt, &item.Description, &item.Username, &item.Expiry, &item.ScopeActions, &item.ScopeWorkspaceIds, &item.LastUsedAt, &item.LastUsedIp); err != nil {
 		return item, fmt.Errorf("query FindTokenByID: %w", err)
 	}
 	return item, nil
@@ -146,12 +165,48 @@ func (q *DBQuerier) FindTokenByIDBatch(batch genericBatch, tokenID pgtype.Text)
 func (q *DBQuerier) FindTokenByIDScan(results pgx.BatchResults) (FindTokenByIDRow, error) {
 	row := results.QueryRow()
 	var item FindTokenByIDRow
-	if err := row.Scan(&item.TokenID, &item.CreatedAt, &item.Description, &item.Username); err != nil {
+	if err := row.Scan(&item.TokenID, &item.CreatedAt, &item.Description, &item.Username, &item.Expiry, &item.ScopeActions, &item.ScopeWorkspaceIds, &item.LastUsedAt, &item.LastUsedIp); err != nil {
 		return item, fmt.Errorf("scan FindTokenByIDBatch row: %w", err)
 	}
 	return item, nil
 }
 
+const updateTokenLastUsedSQL = `UPDATE tokens
+SET last_used_at = $1,
+    last_used_ip = $2
+WHERE token_id = $3
+;`
+
+type UpdateTokenLastUsedParams struct {
+	LastUsedAt pgtype.Timestamptz
+	LastUsedIp pgtype.Text
+	TokenID    pgtype.Text
+}
+
+// UpdateTokenLastUsed implements Querier.UpdateTokenLastUsed.
+func (q *DBQuerier) UpdateTokenLastUsed(ctx context.Context, params UpdateTokenLastUsedParams) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "UpdateTokenLastUsed")
+	cmdTag, err := q.conn.Exec(ctx, updateTokenLastUsedSQL, params.LastUsedAt, params.LastUsedIp, params.TokenID)
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec query UpdateTokenLastUsed: %w", err)
+	}
+	return cmdTag, err
+}
+
+// UpdateTokenLastUsedBatch implements Querier.UpdateTokenLastUsedBatch.
+func (q *DBQuerier) UpdateTokenLastUsedBatch(batch genericBatch, params UpdateTokenLastUsedParams) {
+	batch.Queue(updateTokenLastUsedSQL, params.LastUsedAt, params.LastUsedIp, params.TokenID)
+}
+
+// UpdateTokenLastUsedScan implements Querier.UpdateTokenLastUsedScan.
+func (q *DBQuerier) UpdateTokenLastUsedScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec UpdateTokenLastUsedBatch: %w", err)
+	}
+	return cmdTag, err
+}
+
 const deleteTokenByIDSQL = `DELETE
 FROM tokens
 WHERE token_id = $1
@@ -450,6 +450,104 @@ func (q *DBQuerier) FindUserByAuthenticationTokenIDScan(results pgx.BatchResults
 	return item, nil
 }
 
+const findUserByVCSIdentitySQL = `SELECT u.*,
+    (
+        SELECT array_agg(t)
+        FROM teams t
+        JOIN team_memberships tm USING (team_id)
+        WHERE tm.username = u.username
+    ) AS teams
+FROM users u
+JOIN vcs_identities vi USING (user_id)
+WHERE vi.hostname = $1
+AND   vi.vcs_username = $2
+;`
+
+type FindUserByVCSIdentityRow struct {
+	UserID    pgtype.Text        `json:"user_id"`
+	Username  pgtype.Text        `json:"username"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	SiteAdmin bool               `json:"site_admin"`
+	Teams     []Teams            `json:"teams"`
+}
+
+// FindUserByVCSIdentity implements Querier.FindUserByVCSIdentity.
+func (q *DBQuerier) FindUserByVCSIdentity(ctx context.Context, hostname pgtype.Text, vcsUsername pgtype.Text) (FindUserByVCSIdentityRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "FindUserByVCSIdentity")
+	row := q.conn.QueryRow(ctx, findUserByVCSIdentitySQL, hostname, vcsUsername)
+	var item FindUserByVCSIdentityRow
+	teamsArray := q.types.newTeamsArray()
+	if err := row.Scan(&item.UserID, &item.Username, &item.CreatedAt, &item.UpdatedAt, &item.SiteAdmin, teamsArray); err != nil {
+		return item, fmt.Errorf("query FindUserByVCSIdentity: %w", err)
+	}
+	if err := teamsArray.AssignTo(&item.Teams); err != nil {
+		return item, fmt.Errorf("assign FindUserByVCSIdentity row: %w", err)
+	}
+	return item, nil
+}
+
+// FindUserByVCSIdentityBatch implements Querier.FindUserByVCSIdentityBatch.
+func (q *DBQuerier) FindUserByVCSIdentityBatch(batch genericBatch, hostname pgtype.Text, vcsUsername pgtype.Text) {
+	batch.Queue(findUserByVCSIdentitySQL, hostname, vcsUsername)
+}
+
+// FindUserByVCSIdentityScan implements Querier.FindUserByVCSIdentityScan.
+func (q *DBQuerier) FindUserByVCSIdentityScan(results pgx.BatchResults) (FindUserByVCSIdentityRow, error) {
+	row := results.QueryRow()
+	var item FindUserByVCSIdentityRow
+	teamsArray := q.types.newTeamsArray()
+	if err := row.Scan(&item.UserID, &item.Username, &item.CreatedAt, &item.UpdatedAt, &item.SiteAdmin, teamsArray); err != nil {
+		return item, fmt.Errorf("scan FindUserByVCSIdentityBatch row: %w", err)
+	}
+	if err := teamsArray.AssignTo(&item.Teams); err != nil {
+		return item, fmt.Errorf("assign FindUserByVCSIdentity row: %w", err)
+	}
+	return item, nil
+}
+
+const upsertVCSIdentitySQL = `INSERT INTO vcs_identities (
+    hostname,
+    vcs_username,
+    user_id
+) VALUES (
+    $1,
+    $2,
+    $3
+) ON CONFLICT (hostname, vcs_username) DO UPDATE
+SET user_id = EXCLUDED.user_id
+;`
+
+type UpsertVCSIdentityParams struct {
+	Hostname    pgtype.Text
+	VCSUsername pgtype.Text
+	UserID      pgtype.Text
+}
+
+// UpsertVCSIdentity implements Querier.UpsertVCSIdentity.
+func (q *DBQuerier) UpsertVCSIdentity(ctx context.Context, params UpsertVCSIdentityParams) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "UpsertVCSIdentity")
+	cmdTag, err := q.conn.Exec(ctx, upsertVCSIdentitySQL, params.Hostname, params.VCSUsername, params.UserID)
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec query UpsertVCSIdentity: %w", err)
+	}
+	return cmdTag, err
+}
+
+// UpsertVCSIdentityBatch implements Querier.UpsertVCSIdentityBatch.
+func (q *DBQuerier) UpsertVCSIdentityBatch(batch genericBatch, params UpsertVCSIdentityParams) {
+	batch.Queue(upsertVCSIdentitySQL, params.Hostname, params.VCSUsername, params.UserID)
+}
+
+// UpsertVCSIdentityScan implements Querier.UpsertVCSIdentityScan.
+func (q *DBQuerier) UpsertVCSIdentityScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec UpsertVCSIdentityBatch: %w", err)
+	}
+	return cmdTag, err
+}
+
 const updateUserSiteAdminsSQL = `UPDATE users
 SET site_admin = true
 WHERE username = ANY($1::text[])
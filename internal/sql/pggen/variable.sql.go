@@ -19,7 +19,8 @@ const insertVariableSQL = `INSERT INTO variables (
     category,
     sensitive,
     hcl,
-    version_id
+    version_id,
+    secret_reference
 ) VALUES (
     $1,
     $2,
@@ -28,24 +29,26 @@ const insertVariableSQL = `INSERT INTO variables (
     $5,
     $6,
     $7,
-    $8
+    $8,
+    $9
 );`
 
 type InsertVariableParams struct {
-	VariableID  pgtype.Text
-	Key         pgtype.Text
-	Value       pgtype.Text
-	Description pgtype.Text
-	Category    pgtype.Text
-	Sensitive   bool
-	HCL         bool
-	VersionID   pgtype.Text
+	VariableID      pgtype.Text
+	Key             pgtype.Text
+	Value           pgtype.Text
+	Description     pgtype.Text
+	Category        pgtype.Text
+	Sensitive       bool
+	HCL             bool
+	VersionID       pgtype.Text
+	SecretReference bool
 }
 
 // InsertVariable implements Querier.InsertVariable.
 func (q *DBQuerier) InsertVariable(ctx context.Context, params InsertVariableParams) (pgconn.CommandTag, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "InsertVariable")
-	cmdTag, err := q.conn.Exec(ctx, insertVariableSQL, params.VariableID, params.Key, params.Value, params.Description, params.Category, params.Sensitive, params.HCL, params.VersionID)
+	cmdTag, err := q.conn.Exec(ctx, insertVariableSQL, params.VariableID, params.Key, params.Value, params.Description, params.Category, params.Sensitive, params.HCL, params.VersionID, params.SecretReference)
 	if err != nil {
 		return cmdTag, fmt.Errorf("exec query InsertVariable: %w", err)
 	}
@@ -54,7 +57,7 @@ func (q *DBQuerier) InsertVariable(ctx context.Context, params InsertVariablePar
 
 // InsertVariableBatch implements Querier.InsertVariableBatch.
 func (q *DBQuerier) InsertVariableBatch(batch genericBatch, params InsertVariableParams) {
-	batch.Queue(insertVariableSQL, params.VariableID, params.Key, params.Value, params.Description, params.Category, params.Sensitive, params.HCL, params.VersionID)
+	batch.Queue(insertVariableSQL, params.VariableID, params.Key, params.Value, params.Description, params.Category, params.Sensitive, params.HCL, params.VersionID, params.SecretReference)
 }
 
 // InsertVariableScan implements Querier.InsertVariableScan.
@@ -72,14 +75,15 @@ WHERE variable_id = $1
 ;`
 
 type FindVariableRow struct {
-	VariableID  pgtype.Text `json:"variable_id"`
-	Key         pgtype.Text `json:"key"`
-	Value       pgtype.Text `json:"value"`
-	Description pgtype.Text `json:"description"`
-	Category    pgtype.Text `json:"category"`
-	Sensitive   bool        `json:"sensitive"`
-	HCL         bool        `json:"hcl"`
-	VersionID   pgtype.Text `json:"version_id"`
+	VariableID      pgtype.Text `json:"variable_id"`
+	Key             pgtype.Text `json:"key"`
+	Value           pgtype.Text `json:"value"`
+	Description     pgtype.Text `json:"description"`
+	Category        pgtype.Text `json:"category"`
+	Sensitive       bool        `json:"sensitive"`
+	HCL             bool        `json:"hcl"`
+	VersionID       pgtype.Text `json:"version_id"`
+	SecretReference bool        `json:"secret_reference"`
 }
 
 // FindVariable implements Querier.FindVariable.
@@ -87,7 +91,7 @@ func (q *DBQuerier) FindVariable(ctx context.Context, variableID pgtype.Text) (F
 	ctx = context.WithValue(ctx, "pggen_query_name", "FindVariable")
 	row := q.conn.QueryRow(ctx, findVariableSQL, variableID)
 	var item FindVariableRow
-	if err := row.Scan(&item.VariableID, &item.Key, &item.Value, &item.Description, &item.Category, &item.Sensitive, &item.HCL, &item.VersionID); err != nil {
+	if err := row.Scan(&item.VariableID, &item.Key, &item.Value, &item.Description, &item.Category, &item.Sensitive, &item.HCL, &item.VersionID, &item.SecretReference); err != nil {
 		return item, fmt.Errorf("query FindVariable: %w", err)
 	}
 	return item, nil
@@ -102,7 +106,7 @@ func (q *DBQuerier) FindVariableBatch(batch genericBatch, variableID pgtype.Text
 func (q *DBQuerier) FindVariableScan(results pgx.BatchResults) (FindVariableRow, error) {
 	row := results.QueryRow()
 	var item FindVariableRow
-	if err := row.Scan(&item.VariableID, &item.Key, &item.Value, &item.Description, &item.Category, &item.Sensitive, &item.HCL, &item.VersionID); err != nil {
+	if err := row.Scan(&item.VariableID, &item.Key, &item.Value, &item.Description, &item.Category, &item.Sensitive, &item.HCL, &item.VersionID, &item.SecretReference); err != nil {
 		return item, fmt.Errorf("scan FindVariableBatch row: %w", err)
 	}
 	return item, nil
@@ -116,26 +120,28 @@ SET
     category = $4,
     sensitive = $5,
     version_id = $6,
-    hcl = $7
-WHERE variable_id = $8
+    hcl = $7,
+    secret_reference = $8
+WHERE variable_id = $9
 RETURNING variable_id
 ;`
 
 type UpdateVariableByIDParams struct {
-	Key         pgtype.Text
-	Value       pgtype.Text
-	Description pgtype.Text
-	Category    pgtype.Text
-	Sensitive   bool
-	VersionID   pgtype.Text
-	HCL         bool
-	VariableID  pgtype.Text
+	Key             pgtype.Text
+	Value           pgtype.Text
+	Description     pgtype.Text
+	Category        pgtype.Text
+	Sensitive       bool
+	VersionID       pgtype.Text
+	HCL             bool
+	SecretReference bool
+	VariableID      pgtype.Text
 }
 
 // UpdateVariableByID implements Querier.UpdateVariableByID.
 func (q *DBQuerier) UpdateVariableByID(ctx context.Context, params UpdateVariableByIDParams) (pgtype.Text, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "UpdateVariableByID")
-	row := q.conn.QueryRow(ctx, updateVariableByIDSQL, params.Key, params.Value, params.Description, params.Category, params.Sensitive, params.VersionID, params.HCL, params.VariableID)
+	row := q.conn.QueryRow(ctx, updateVariableByIDSQL, params.Key, params.Value, params.Description, params.Category, params.Sensitive, params.VersionID, params.HCL, params.SecretReference, params.VariableID)
 	var item pgtype.Text
 	if err := row.Scan(&item); err != nil {
 		return item, fmt.Errorf("query UpdateVariableByID: %w", err)
@@ -145,7 +151,7 @@ func (q *DBQuerier) UpdateVariableByID(ctx context.Context, params UpdateVariabl
 
 // UpdateVariableByIDBatch implements Querier.UpdateVariableByIDBatch.
 func (q *DBQuerier) UpdateVariableByIDBatch(batch genericBatch, params UpdateVariableByIDParams) {
-	batch.Queue(updateVariableByIDSQL, params.Key, params.Value, params.Description, params.Category, params.Sensitive, params.VersionID, params.HCL, params.VariableID)
+	batch.Queue(updateVariableByIDSQL, params.Key, params.Value, params.Description, params.Category, params.Sensitive, params.VersionID, params.HCL, params.SecretReference, params.VariableID)
 }
 
 // UpdateVariableByIDScan implements Querier.UpdateVariableByIDScan.
@@ -165,14 +171,15 @@ RETURNING *
 ;`
 
 type DeleteVariableByIDRow struct {
-	VariableID  pgtype.Text `json:"variable_id"`
-	Key         pgtype.Text `json:"key"`
-	Value       pgtype.Text `json:"value"`
-	Description pgtype.Text `json:"description"`
-	Category    pgtype.Text `json:"category"`
-	Sensitive   bool        `json:"sensitive"`
-	HCL         bool        `json:"hcl"`
-	VersionID   pgtype.Text `json:"version_id"`
+	VariableID      pgtype.Text `json:"variable_id"`
+	Key             pgtype.Text `json:"key"`
+	Value           pgtype.Text `json:"value"`
+	Description     pgtype.Text `json:"description"`
+	Category        pgtype.Text `json:"category"`
+	Sensitive       bool        `json:"sensitive"`
+	HCL             bool        `json:"hcl"`
+	VersionID       pgtype.Text `json:"version_id"`
+	SecretReference bool        `json:"secret_reference"`
 }
 
 // DeleteVariableByID implements Querier.DeleteVariableByID.
@@ -180,7 +187,7 @@ func (q *DBQuerier) DeleteVariableByID(ctx context.Context, variableID pgtype.Te
 	ctx = context.WithValue(ctx, "pggen_query_name", "DeleteVariableByID")
 	row := q.conn.QueryRow(ctx, deleteVariableByIDSQL, variableID)
 	var item DeleteVariableByIDRow
-	if err := row.Scan(&item.VariableID, &item.Key, &item.Value, &item.Description, &item.Category, &item.Sensitive, &item.HCL, &item.VersionID); err != nil {
+	if err := row.Scan(&item.VariableID, &item.Key, &item.Value, &item.Description, &item.Category, &item.Sensitive, &item.HCL, &item.VersionID, &item.SecretReference); err != nil {
 		return item, fmt.Errorf("query DeleteVariableByID: %w", err)
 	}
 	return item, nil
@@ -195,7 +202,7 @@ func (q *DBQuerier) DeleteVariableByIDBatch(batch genericBatch, variableID pgtyp
 func (q *DBQuerier) DeleteVariableByIDScan(results pgx.BatchResults) (DeleteVariableByIDRow, error) {
 	row := results.QueryRow()
 	var item DeleteVariableByIDRow
-	if err := row.Scan(&item.VariableID, &item.Key, &item.Value, &item.Description, &item.Category, &item.Sensitive, &item.HCL, &item.VersionID); err != nil {
+	if err := row.Scan(&item.VariableID, &item.Key, &item.Value, &item.Description, &item.Category, &item.Sensitive, &item.HCL, &item.VersionID, &item.SecretReference); err != nil {
 		return item, fmt.Errorf("scan DeleteVariableByIDBatch row: %w", err)
 	}
 	return item, nil
@@ -16,13 +16,15 @@ const insertVariableSetSQL = `INSERT INTO variable_sets (
     global,
     name,
     description,
-    organization_name
+    organization_name,
+    priority
 ) VALUES (
     $1,
     $2,
     $3,
     $4,
-    $5
+    $5,
+    $6
 );`
 
 type InsertVariableSetParams struct {
@@ -31,12 +33,13 @@ type InsertVariableSetParams struct {
 	Name             pgtype.Text
 	Description      pgtype.Text
 	OrganizationName pgtype.Text
+	Priority         bool
 }
 
 // InsertVariableSet implements Querier.InsertVariableSet.
 func (q *DBQuerier) InsertVariableSet(ctx context.Context, params InsertVariableSetParams) (pgconn.CommandTag, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "InsertVariableSet")
-	cmdTag, err := q.conn.Exec(ctx, insertVariableSetSQL, params.VariableSetID, params.Global, params.Name, params.Description, params.OrganizationName)
+	cmdTag, err := q.conn.Exec(ctx, insertVariableSetSQL, params.VariableSetID, params.Global, params.Name, params.Description, params.OrganizationName, params.Priority)
 	if err != nil {
 		return cmdTag, fmt.Errorf("exec query InsertVariableSet: %w", err)
 	}
@@ -45,7 +48,7 @@ func (q *DBQuerier) InsertVariableSet(ctx context.Context, params InsertVariable
 
 // InsertVariableSetBatch implements Querier.InsertVariableSetBatch.
 func (q *DBQuerier) InsertVariableSetBatch(batch genericBatch, params InsertVariableSetParams) {
-	batch.Queue(insertVariableSetSQL, params.VariableSetID, params.Global, params.Name, params.Description, params.OrganizationName)
+	batch.Queue(insertVariableSetSQL, params.VariableSetID, params.Global, params.Name, params.Description, params.OrganizationName, params.Priority)
 }
 
 // InsertVariableSetScan implements Querier.InsertVariableSetScan.
@@ -71,7 +74,13 @@ const findVariableSetsByOrganizationSQL = `SELECT
         FROM variable_set_workspaces vsw
         WHERE vsw.variable_set_id = vs.variable_set_id
         GROUP BY variable_set_id
-    ) AS workspace_ids
+    ) AS workspace_ids,
+    (
+        SELECT array_agg(vsp.project_id) AS project_ids
+        FROM variable_set_projects vsp
+        WHERE vsp.variable_set_id = vs.variable_set_id
+        GROUP BY variable_set_id
+    ) AS project_ids
 FROM variable_sets vs
 WHERE organization_name = $1;`
 
@@ -81,8 +90,10 @@ type FindVariableSetsByOrganizationRow struct {
 	Name             pgtype.Text `json:"name"`
 	Description      pgtype.Text `json:"description"`
 	OrganizationName pgtype.Text `json:"organization_name"`
+	Priority         bool        `json:"priority"`
 	Variables        []Variables `json:"variables"`
 	WorkspaceIds     []string    `json:"workspace_ids"`
+	ProjectIds       []string    `json:"project_ids"`
 }
 
 // FindVariableSetsByOrganization implements Querier.FindVariableSetsByOrganization.
@@ -97,7 +108,7 @@ func (q *DBQuerier) FindVariableSetsByOrganization(ctx context.Context, organiza
 	variablesArray := q.types.newVariablesArray()
 	for rows.Next() {
 		var item FindVariableSetsByOrganizationRow
-		if err := rows.Scan(&item.VariableSetID, &item.Global, &item.Name, &item.Description, &item.OrganizationName, variablesArray, &item.WorkspaceIds); err != nil {
+		if err := rows.Scan(&item.VariableSetID, &item.Global, &item.Name, &item.Description, &item.OrganizationName, &item.Priority, variablesArray, &item.WorkspaceIds, &item.ProjectIds); err != nil {
 			return nil, fmt.Errorf("scan FindVariableSetsByOrganization row: %w", err)
 		}
 		if err := variablesArray.AssignTo(&item.Variables); err != nil {
@@ -127,7 +138,7 @@ func (q *DBQuerier) FindVariableSetsByOrganizationScan(results pgx.BatchResults)
 	variablesArray := q.types.newVariablesArray()
 	for rows.Next() {
 		var item FindVariableSetsByOrganizationRow
-		if err := rows.Scan(&item.VariableSetID, &item.Global, &item.Name, &item.Description, &item.OrganizationName, variablesArray, &item.WorkspaceIds); err != nil {
+		if err := rows.Scan(&item.VariableSetID, &item.Global, &item.Name, &item.Description, &item.OrganizationName, &item.Priority, variablesArray, &item.WorkspaceIds, &item.ProjectIds); err != nil {
 			return nil, fmt.Errorf("scan FindVariableSetsByOrganizationBatch row: %w", err)
 		}
 		if err := variablesArray.AssignTo(&item.Variables); err != nil {
@@ -155,7 +166,13 @@ const findVariableSetsByWorkspaceSQL = `SELECT
         FROM variable_set_workspaces vsw
         WHERE vsw.variable_set_id = vs.variable_set_id
         GROUP BY variable_set_id
-    ) AS workspace_ids
+    ) AS workspace_ids,
+    (
+        SELECT array_agg(vsp.project_id) AS project_ids
+        FROM variable_set_projects vsp
+        WHERE vsp.variable_set_id = vs.variable_set_id
+        GROUP BY variable_set_id
+    ) AS project_ids
 FROM variable_sets vs
 JOIN variable_set_workspaces vsw USING (variable_set_id)
 WHERE workspace_id = $1
@@ -174,11 +191,43 @@ SELECT
         FROM variable_set_workspaces vsw
         WHERE vsw.variable_set_id = vs.variable_set_id
         GROUP BY variable_set_id
-    ) AS workspace_ids
+    ) AS workspace_ids,
+    (
+        SELECT array_agg(vsp.project_id) AS project_ids
+        FROM variable_set_projects vsp
+        WHERE vsp.variable_set_id = vs.variable_set_id
+        GROUP BY variable_set_id
+    ) AS project_ids
 FROM variable_sets vs
 JOIN (organizations o JOIN workspaces w ON o.name = w.organization_name) ON o.name = vs.organization_name
 WHERE vs.global IS true
-AND w.workspace_id = $1;`
+AND w.workspace_id = $1
+UNION
+SELECT
+    vs.*,
+    (
+        SELECT array_agg(v.*) AS variables
+        FROM variables v
+        JOIN variable_set_variables vsv USING (variable_id)
+        WHERE vsv.variable_set_id = vs.variable_set_id
+        GROUP BY variable_set_id
+    ) AS variables,
+    (
+        SELECT array_agg(vsw.workspace_id) AS workspace_ids
+        FROM variable_set_workspaces vsw
+        WHERE vsw.variable_set_id = vs.variable_set_id
+        GROUP BY variable_set_id
+    ) AS workspace_ids,
+    (
+        SELECT array_agg(vsp.project_id) AS project_ids
+        FROM variable_set_projects vsp
+        WHERE vsp.variable_set_id = vs.variable_set_id
+        GROUP BY variable_set_id
+    ) AS project_ids
+FROM variable_sets vs
+JOIN variable_set_projects vsp USING (variable_set_id)
+JOIN workspaces w USING (project_id)
+WHERE w.workspace_id = $1;`
 
 type FindVariableSetsByWorkspaceRow struct {
 	VariableSetID    pgtype.Text `json:"variable_set_id"`
@@ -186,8 +235,10 @@ type FindVariableSetsByWorkspaceRow struct {
 	Name             pgtype.Text `json:"name"`
 	Description      pgtype.Text `json:"description"`
 	OrganizationName pgtype.Text `json:"organization_name"`
+	Priority         bool        `json:"priority"`
 	Variables        []Variables `json:"variables"`
 	WorkspaceIds     []string    `json:"workspace_ids"`
+	ProjectIds       []string    `json:"project_ids"`
 }
 
 // FindVariableSetsByWorkspace implements Querier.FindVariableSetsByWorkspace.
@@ -202,7 +253,7 @@ func (q *DBQuerier) FindVariableSetsByWorkspace(ctx context.Context, workspaceID
 	variablesArray := q.types.newVariablesArray()
 	for rows.Next() {
 		var item FindVariableSetsByWorkspaceRow
-		if err := rows.Scan(&item.VariableSetID, &item.Global, &item.Name, &item.Description, &item.OrganizationName, variablesArray, &item.WorkspaceIds); err != nil {
+		if err := rows.Scan(&item.VariableSetID, &item.Global, &item.Name, &item.Description, &item.OrganizationName, &item.Priority, variablesArray, &item.WorkspaceIds, &item.ProjectIds); err != nil {
 			return nil, fmt.Errorf("scan FindVariableSetsByWorkspace row: %w", err)
 		}
 		if err := variablesArray.AssignTo(&item.Variables); err != nil {
@@ -232,7 +283,7 @@ func (q *DBQuerier) FindVariableSetsByWorkspaceScan(results pgx.BatchResults) ([
 	variablesArray := q.types.newVariablesArray()
 	for rows.Next() {
 		var item FindVariableSetsByWorkspaceRow
-		if err := rows.Scan(&item.VariableSetID, &item.Global, &item.Name, &item.Description, &item.OrganizationName, variablesArray, &item.WorkspaceIds); err != nil {
+		if err := rows.Scan(&item.VariableSetID, &item.Global, &item.Name, &item.Description, &item.OrganizationName, &item.Priority, variablesArray, &item.WorkspaceIds, &item.ProjectIds); err != nil {
 			return nil, fmt.Errorf("scan FindVariableSetsByWorkspaceBatch row: %w", err)
 		}
 		if err := variablesArray.AssignTo(&item.Variables); err != nil {
@@ -260,7 +311,13 @@ const findVariableSetBySetIDSQL = `SELECT
         FROM variable_set_workspaces vsw
         WHERE vsw.variable_set_id = vs.variable_set_id
         GROUP BY variable_set_id
-    ) AS workspace_ids
+    ) AS workspace_ids,
+    (
+        SELECT array_agg(vsp.project_id) AS project_ids
+        FROM variable_set_projects vsp
+        WHERE vsp.variable_set_id = vs.variable_set_id
+        GROUP BY variable_set_id
+    ) AS project_ids
 FROM variable_sets vs
 WHERE vs.variable_set_id = $1;`
 
@@ -270,8 +327,10 @@ type FindVariableSetBySetIDRow struct {
 	Name             pgtype.Text `json:"name"`
 	Description      pgtype.Text `json:"description"`
 	OrganizationName pgtype.Text `json:"organization_name"`
+	Priority         bool        `json:"priority"`
 	Variables        []Variables `json:"variables"`
 	WorkspaceIds     []string    `json:"workspace_ids"`
+	ProjectIds       []string    `json:"project_ids"`
 }
 
 // FindVariableSetBySetID implements Querier.FindVariableSetBySetID.
@@ -280,7 +339,7 @@ func (q *DBQuerier) FindVariableSetBySetID(ctx context.Context, variableSetID pg
 	row := q.conn.QueryRow(ctx, findVariableSetBySetIDSQL, variableSetID)
 	var item FindVariableSetBySetIDRow
 	variablesArray := q.types.newVariablesArray()
-	if err := row.Scan(&item.VariableSetID, &item.Global, &item.Name, &item.Description, &item.OrganizationName, variablesArray, &item.WorkspaceIds); err != nil {
+	if err := row.Scan(&item.VariableSetID, &item.Global, &item.Name, &item.Description, &item.OrganizationName, &item.Priority, variablesArray, &item.WorkspaceIds, &item.ProjectIds); err != nil {
 		return item, fmt.Errorf("query FindVariableSetBySetID: %w", err)
 	}
 	if err := variablesArray.AssignTo(&item.Variables); err != nil {
@@ -299,7 +358,7 @@ func (q *DBQuerier) FindVariableSetBySetIDScan(results pgx.BatchResults) (FindVa
 	row := results.QueryRow()
 	var item FindVariableSetBySetIDRow
 	variablesArray := q.types.newVariablesArray()
-	if err := row.Scan(&item.VariableSetID, &item.Global, &item.Name, &item.Description, &item.OrganizationName, variablesArray, &item.WorkspaceIds); err != nil {
+	if err := row.Scan(&item.VariableSetID, &item.Global, &item.Name, &item.Description, &item.OrganizationName, &item.Priority, variablesArray, &item.WorkspaceIds, &item.ProjectIds); err != nil {
 		return item, fmt.Errorf("scan FindVariableSetBySetIDBatch row: %w", err)
 	}
 	if err := variablesArray.AssignTo(&item.Variables); err != nil {
@@ -322,7 +381,13 @@ const findVariableSetByVariableIDSQL = `SELECT
         FROM variable_set_workspaces vsw
         WHERE vsw.variable_set_id = vs.variable_set_id
         GROUP BY variable_set_id
-    ) AS workspace_ids
+    ) AS workspace_ids,
+    (
+        SELECT array_agg(vsp.project_id) AS project_ids
+        FROM variable_set_projects vsp
+        WHERE vsp.variable_set_id = vs.variable_set_id
+        GROUP BY variable_set_id
+    ) AS project_ids
 FROM variable_sets vs
 JOIN variable_set_variables vsv USING (variable_set_id)
 WHERE vsv.variable_id = $1;`
@@ -333,8 +398,10 @@ type FindVariableSetByVariableIDRow struct {
 	Name             pgtype.Text `json:"name"`
 	Description      pgtype.Text `json:"description"`
 	OrganizationName pgtype.Text `json:"organization_name"`
+	Priority         bool        `json:"priority"`
 	Variables        []Variables `json:"variables"`
 	WorkspaceIds     []string    `json:"workspace_ids"`
+	ProjectIds       []string    `json:"project_ids"`
 }
 
 // FindVariableSetByVariableID implements Querier.FindVariableSetByVariableID.
@@ -343,7 +410,7 @@ func (q *DBQuerier) FindVariableSetByVariableID(ctx context.Context, variableID
 	row := q.conn.QueryRow(ctx, findVariableSetByVariableIDSQL, variableID)
 	var item FindVariableSetByVariableIDRow
 	variablesArray := q.types.newVariablesArray()
-	if err := row.Scan(&item.VariableSetID, &item.Global, &item.Name, &item.Description, &item.OrganizationName, variablesArray, &item.WorkspaceIds); err != nil {
+	if err := row.Scan(&item.VariableSetID, &item.Global, &item.Name, &item.Description, &item.OrganizationName, &item.Priority, variablesArray, &item.WorkspaceIds, &item.ProjectIds); err != nil {
 		return item, fmt.Errorf("query FindVariableSetByVariableID: %w", err)
 	}
 	if err := variablesArray.AssignTo(&item.Variables); err != nil {
@@ -362,7 +429,7 @@ func (q *DBQuerier) FindVariableSetByVariableIDScan(results pgx.BatchResults) (F
 	row := results.QueryRow()
 	var item FindVariableSetByVariableIDRow
 	variablesArray := q.types.newVariablesArray()
-	if err := row.Scan(&item.VariableSetID, &item.Global, &item.Name, &item.Description, &item.OrganizationName, variablesArray, &item.WorkspaceIds); err != nil {
+	if err := row.Scan(&item.VariableSetID, &item.Global, &item.Name, &item.Description, &item.OrganizationName, &item.Priority, variablesArray, &item.WorkspaceIds, &item.ProjectIds); err != nil {
 		return item, fmt.Errorf("scan FindVariableSetByVariableIDBatch row: %w", err)
 	}
 	if err := variablesArray.AssignTo(&item.Variables); err != nil {
@@ -385,7 +452,13 @@ const findVariableSetForUpdateSQL = `SELECT
         FROM variable_set_workspaces vsw
         WHERE vsw.variable_set_id = vs.variable_set_id
         GROUP BY variable_set_id
-    ) AS workspace_ids
+    ) AS workspace_ids,
+    (
+        SELECT array_agg(vsp.project_id) AS project_ids
+        FROM variable_set_projects vsp
+        WHERE vsp.variable_set_id = vs.variable_set_id
+        GROUP BY variable_set_id
+    ) AS project_ids
 FROM variable_sets vs
 WHERE variable_set_id = $1
 FOR UPDATE OF vs;`
@@ -396,8 +469,10 @@ type FindVariableSetForUpdateRow struct {
 	Name             pgtype.Text `json:"name"`
 	Description      pgtype.Text `json:"description"`
 	OrganizationName pgtype.Text `json:"organization_name"`
+	Priority         bool        `json:"priority"`
 	Variables        []Variables `json:"variables"`
 	WorkspaceIds     []string    `json:"workspace_ids"`
+	ProjectIds       []string    `json:"project_ids"`
 }
 
 // FindVariableSetForUpdate implements Querier.FindVariableSetForUpdate.
@@ -406,7 +481,7 @@ func (q *DBQuerier) FindVariableSetForUpdate(ctx context.Context, variableSetID
 	row := q.conn.QueryRow(ctx, findVariableSetForUpdateSQL, variableSetID)
 	var item FindVariableSetForUpdateRow
 	variablesArray := q.types.newVariablesArray()
-	if err := row.Scan(&item.VariableSetID, &item.Global, &item.Name, &item.Description, &item.OrganizationName, variablesArray, &item.WorkspaceIds); err != nil {
+	if err := row.Scan(&item.VariableSetID, &item.Global, &item.Name, &item.Description, &item.OrganizationName, &item.Priority, variablesArray, &item.WorkspaceIds, &item.ProjectIds); err != nil {
 		return item, fmt.Errorf("query FindVariableSetForUpdate: %w", err)
 	}
 	if err := variablesArray.AssignTo(&item.Variables); err != nil {
@@ -425,7 +500,7 @@ func (q *DBQuerier) FindVariableSetForUpdateScan(results pgx.BatchResults) (Find
 	row := results.QueryRow()
 	var item FindVariableSetForUpdateRow
 	variablesArray := q.types.newVariablesArray()
-	if err := row.Scan(&item.VariableSetID, &item.Global, &item.Name, &item.Description, &item.OrganizationName, variablesArray, &item.WorkspaceIds); err != nil {
+	if err := row.Scan(&item.VariableSetID, &item.Global, &item.Name, &item.Description, &item.OrganizationName, &item.Priority, variablesArray, &item.WorkspaceIds, &item.ProjectIds); err != nil {
 		return item, fmt.Errorf("scan FindVariableSetForUpdateBatch row: %w", err)
 	}
 	if err := variablesArray.AssignTo(&item.Variables); err != nil {
@@ -438,21 +513,23 @@ const updateVariableSetByIDSQL = `UPDATE variable_sets
 SET
     global = $1,
     name = $2,
-    description = $3
-WHERE variable_set_id = $4
+    description = $3,
+    priority = $4
+WHERE variable_set_id = $5
 RETURNING variable_set_id;`
 
 type UpdateVariableSetByIDParams struct {
 	Global        bool
 	Name          pgtype.Text
 	Description   pgtype.Text
+	Priority      bool
 	VariableSetID pgtype.Text
 }
 
 // UpdateVariableSetByID implements Querier.UpdateVariableSetByID.
 func (q *DBQuerier) UpdateVariableSetByID(ctx context.Context, params UpdateVariableSetByIDParams) (pgtype.Text, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "UpdateVariableSetByID")
-	row := q.conn.QueryRow(ctx, updateVariableSetByIDSQL, params.Global, params.Name, params.Description, params.VariableSetID)
+	row := q.conn.QueryRow(ctx, updateVariableSetByIDSQL, params.Global, params.Name, params.Description, params.Priority, params.VariableSetID)
 	var item pgtype.Text
 	if err := row.Scan(&item); err != nil {
 		return item, fmt.Errorf("query UpdateVariableSetByID: %w", err)
@@ -462,7 +539,7 @@ func (q *DBQuerier) UpdateVariableSetByID(ctx context.Context, params UpdateVari
 
 // UpdateVariableSetByIDBatch implements Querier.UpdateVariableSetByIDBatch.
 func (q *DBQuerier) UpdateVariableSetByIDBatch(batch genericBatch, params UpdateVariableSetByIDParams) {
-	batch.Queue(updateVariableSetByIDSQL, params.Global, params.Name, params.Description, params.VariableSetID)
+	batch.Queue(updateVariableSetByIDSQL, params.Global, params.Name, params.Description, params.Priority, params.VariableSetID)
 }
 
 // UpdateVariableSetByIDScan implements Querier.UpdateVariableSetByIDScan.
@@ -486,6 +563,7 @@ type DeleteVariableSetByIDRow struct {
 	Name             pgtype.Text `json:"name"`
 	Description      pgtype.Text `json:"description"`
 	OrganizationName pgtype.Text `json:"organization_name"`
+	Priority         bool        `json:"priority"`
 }
 
 // DeleteVariableSetByID implements Querier.DeleteVariableSetByID.
@@ -493,7 +571,7 @@ func (q *DBQuerier) DeleteVariableSetByID(ctx context.Context, variableSetID pgt
 	ctx = context.WithValue(ctx, "pggen_query_name", "DeleteVariableSetByID")
 	row := q.conn.QueryRow(ctx, deleteVariableSetByIDSQL, variableSetID)
 	var item DeleteVariableSetByIDRow
-	if err := row.Scan(&item.VariableSetID, &item.Global, &item.Name, &item.Description, &item.OrganizationName); err != nil {
+	if err := row.Scan(&item.VariableSetID, &item.Global, &item.Name, &item.Description, &item.OrganizationName, &item.Priority); err != nil {
 		return item, fmt.Errorf("query DeleteVariableSetByID: %w", err)
 	}
 	return item, nil
@@ -508,7 +586,7 @@ func (q *DBQuerier) DeleteVariableSetByIDBatch(batch genericBatch, variableSetID
 func (q *DBQuerier) DeleteVariableSetByIDScan(results pgx.BatchResults) (DeleteVariableSetByIDRow, error) {
 	row := results.QueryRow()
 	var item DeleteVariableSetByIDRow
-	if err := row.Scan(&item.VariableSetID, &item.Global, &item.Name, &item.Description, &item.OrganizationName); err != nil {
+	if err := row.Scan(&item.VariableSetID, &item.Global, &item.Name, &item.Description, &item.OrganizationName, &item.Priority); err != nil {
 		return item, fmt.Errorf("scan DeleteVariableSetByIDBatch row: %w", err)
 	}
 	return item, nil
@@ -679,3 +757,63 @@ func (q *DBQuerier) DeleteVariableSetWorkspacesScan(results pgx.BatchResults) (p
 	}
 	return cmdTag, err
 }
+
+const insertVariableSetProjectSQL = `INSERT INTO variable_set_projects (
+    variable_set_id,
+    project_id
+) VALUES (
+    $1,
+    $2
+);`
+
+// InsertVariableSetProject implements Querier.InsertVariableSetProject.
+func (q *DBQuerier) InsertVariableSetProject(ctx context.Context, variableSetID pgtype.Text, projectID pgtype.Text) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "InsertVariableSetProject")
+	cmdTag, err := q.conn.Exec(ctx, insertVariableSetProjectSQL, variableSetID, projectID)
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec query InsertVariableSetProject: %w", err)
+	}
+	return cmdTag, err
+}
+
+// InsertVariableSetProjectBatch implements Querier.InsertVariableSetProjectBatch.
+func (q *DBQuerier) InsertVariableSetProjectBatch(batch genericBatch, variableSetID pgtype.Text, projectID pgtype.Text) {
+	batch.Queue(insertVariableSetProjectSQL, variableSetID, projectID)
+}
+
+// InsertVariableSetProjectScan implements Querier.InsertVariableSetProjectScan.
+func (q *DBQuerier) InsertVariableSetProjectScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec InsertVariableSetProjectBatch: %w", err)
+	}
+	return cmdTag, err
+}
+
+const deleteVariableSetProjectsSQL = `DELETE
+FROM variable_set_projects
+WHERE variable_set_id = $1;`
+
+// DeleteVariableSetProjects implements Querier.DeleteVariableSetProjects.
+func (q *DBQuerier) DeleteVariableSetProjects(ctx context.Context, variableSetID pgtype.Text) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "DeleteVariableSetProjects")
+	cmdTag, err := q.conn.Exec(ctx, deleteVariableSetProjectsSQL, variableSetID)
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec query DeleteVariableSetProjects: %w", err)
+	}
+	return cmdTag, err
+}
+
+// DeleteVariableSetProjectsBatch implements Querier.DeleteVariableSetProjectsBatch.
+func (q *DBQuerier) DeleteVariableSetProjectsBatch(batch genericBatch, variableSetID pgtype.Text) {
+	batch.Queue(deleteVariableSetProjectsSQL, variableSetID)
+}
+
+// DeleteVariableSetProjectsScan implements Querier.DeleteVariableSetProjectsScan.
+func (q *DBQuerier) DeleteVariableSetProjectsScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec DeleteVariableSetProjectsBatch: %w", err)
+	}
+	return cmdTag, err
+}
@@ -14,26 +14,47 @@ import (
 const upsertWorkspacePermissionSQL = `INSERT INTO workspace_permissions (
     workspace_id,
     team_id,
-    role
-) SELECT w.workspace_id, t.team_id, $1
+    role,
+    runs,
+    variables,
+    state_versions,
+    workspace_locking,
+    run_tasks
+) SELECT w.workspace_id, t.team_id, $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6
     FROM teams t
     JOIN organizations o ON t.organization_name = o.name
     JOIN workspaces w ON w.organization_name = o.name
-    WHERE t.name = $2
-    AND w.workspace_id = $3
-ON CONFLICT (workspace_id, team_id) DO UPDATE SET role = $1
+    WHERE t.name = $7
+    AND w.workspace_id = $8
+ON CONFLICT (workspace_id, team_id) DO UPDATE SET
+    role              = $1,
+    runs              = $2,
+    variables         = $3,
+    state_versions    = $4,
+    workspace_locking = $5,
+    run_tasks         = $6
 ;`
 
 type UpsertWorkspacePermissionParams struct {
-	Role        pgtype.Text
-	TeamName    pgtype.Text
-	WorkspaceID pgtype.Text
+	Role             pgtype.Text
+	Runs             pgtype.Text
+	Variables        pgtype.Text
+	StateVersions    pgtype.Text
+	WorkspaceLocking bool
+	RunTasks         bool
+	TeamName         pgtype.Text
+	WorkspaceID      pgtype.Text
 }
 
 // UpsertWorkspacePermission implements Querier.UpsertWorkspacePermission.
 func (q *DBQuerier) UpsertWorkspacePermission(ctx context.Context, params UpsertWorkspacePermissionParams) (pgconn.CommandTag, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "UpsertWorkspacePermission")
-	cmdTag, err := q.conn.Exec(ctx, upsertWorkspacePermissionSQL, params.Role, params.TeamName, params.WorkspaceID)
+	cmdTag, err := q.conn.Exec(ctx, upsertWorkspacePermissionSQL, params.Role, params.Runs, params.Variables, params.StateVersions, params.WorkspaceLocking, params.RunTasks, params.TeamName, params.WorkspaceID)
 	if err != nil {
 		return cmdTag, fmt.Errorf("exec query UpsertWorkspacePermission: %w", err)
 	}
@@ -42,7 +63,7 @@ func (q *DBQuerier) UpsertWorkspacePermission(ctx context.Context, params Upsert
 
 // UpsertWorkspacePermissionBatch implements Querier.UpsertWorkspacePermissionBatch.
 func (q *DBQuerier) UpsertWorkspacePermissionBatch(batch genericBatch, params UpsertWorkspacePermissionParams) {
-	batch.Queue(upsertWorkspacePermissionSQL, params.Role, params.TeamName, params.WorkspaceID)
+	batch.Queue(upsertWorkspacePermissionSQL, params.Role, params.Runs, params.Variables, params.StateVersions, params.WorkspaceLocking, params.RunTasks, params.TeamName, params.WorkspaceID)
 }
 
 // UpsertWorkspacePermissionScan implements Querier.UpsertWorkspacePermissionScan.
@@ -56,6 +77,11 @@ func (q *DBQuerier) UpsertWorkspacePermissionScan(results pgx.BatchResults) (pgc
 
 const findWorkspacePermissionsByWorkspaceIDSQL = `SELECT
     wp.role,
+    wp.runs,
+    wp.variables,
+    wp.state_versions,
+    wp.workspace_locking,
+    wp.run_tasks,
     (t.*)::"teams" AS team
 FROM workspace_permissions wp
 JOIN teams t USING (team_id)
@@ -63,8 +89,13 @@ WHERE wp.workspace_id = $1
 ;`
 
 type FindWorkspacePermissionsByWorkspaceIDRow struct {
-	Role pgtype.Text `json:"role"`
-	Team *Teams      `json:"team"`
+	Role             pgtype.Text `json:"role"`
+	Runs             pgtype.Text `json:"runs"`
+	Variables        pgtype.Text `json:"variables"`
+	StateVersions    pgtype.Text `json:"state_versions"`
+	WorkspaceLocking bool        `json:"workspace_locking"`
+	RunTasks         bool        `json:"run_tasks"`
+	Team             *Teams      `json:"team"`
 }
 
 // FindWorkspacePermissionsByWorkspaceID implements Querier.FindWorkspacePermissionsByWorkspaceID.
@@ -79,7 +110,7 @@ func (q *DBQuerier) FindWorkspacePermissionsByWorkspaceID(ctx context.Context, w
 	teamRow := q.types.newTeams()
 	for rows.Next() {
 		var item FindWorkspacePermissionsByWorkspaceIDRow
-		if err := rows.Scan(&item.Role, teamRow); err != nil {
+		if err := rows.Scan(&item.Role, &item.Runs, &item.Variables, &item.StateVersions, &item.WorkspaceLocking, &item.RunTasks, teamRow); err != nil {
 			return nil, fmt.Errorf("scan FindWorkspacePermissionsByWorkspaceID row: %w", err)
 		}
 		if err := teamRow.AssignTo(&item.Team); err != nil {
@@ -109,7 +140,7 @@ func (q *DBQuerier) FindWorkspacePermissionsByWorkspaceIDScan(results pgx.BatchR
 	teamRow := q.types.newTeams()
 	for rows.Next() {
 		var item FindWorkspacePermissionsByWorkspaceIDRow
-		if err := rows.Scan(&item.Role, teamRow); err != nil {
+		if err := rows.Scan(&item.Role, &item.Runs, &item.Variables, &item.StateVersions, &item.WorkspaceLocking, &item.RunTasks, teamRow); err != nil {
 			return nil, fmt.Errorf("scan FindWorkspacePermissionsByWorkspaceIDBatch row: %w", err)
 		}
 		if err := teamRow.AssignTo(&item.Team); err != nil {
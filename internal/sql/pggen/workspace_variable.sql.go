@@ -49,14 +49,15 @@ JOIN variables v USING (variable_id)
 WHERE workspace_id = $1;`
 
 type FindWorkspaceVariablesByWorkspaceIDRow struct {
-	VariableID  pgtype.Text `json:"variable_id"`
-	Key         pgtype.Text `json:"key"`
-	Value       pgtype.Text `json:"value"`
-	Description pgtype.Text `json:"description"`
-	Category    pgtype.Text `json:"category"`
-	Sensitive   bool        `json:"sensitive"`
-	HCL         bool        `json:"hcl"`
-	VersionID   pgtype.Text `json:"version_id"`
+	VariableID      pgtype.Text `json:"variable_id"`
+	Key             pgtype.Text `json:"key"`
+	Value           pgtype.Text `json:"value"`
+	Description     pgtype.Text `json:"description"`
+	Category        pgtype.Text `json:"category"`
+	Sensitive       bool        `json:"sensitive"`
+	HCL             bool        `json:"hcl"`
+	VersionID       pgtype.Text `json:"version_id"`
+	SecretReference bool        `json:"secret_reference"`
 }
 
 // FindWorkspaceVariablesByWorkspaceID implements Querier.FindWorkspaceVariablesByWorkspaceID.
@@ -70,7 +71,7 @@ func (q *DBQuerier) FindWorkspaceVariablesByWorkspaceID(ctx context.Context, wor
 	items := []FindWorkspaceVariablesByWorkspaceIDRow{}
 	for rows.Next() {
 		var item FindWorkspaceVariablesByWorkspaceIDRow
-		if err := rows.Scan(&item.VariableID, &item.Key, &item.Value, &item.Description, &item.Category, &item.Sensitive, &item.HCL, &item.VersionID); err != nil {
+		if err := rows.Scan(&item.VariableID, &item.Key, &item.Value, &item.Description, &item.Category, &item.Sensitive, &item.HCL, &item.VersionID, &item.SecretReference); err != nil {
 			return nil, fmt.Errorf("scan FindWorkspaceVariablesByWorkspaceID row: %w", err)
 		}
 		items = append(items, item)
@@ -96,7 +97,7 @@ func (q *DBQuerier) FindWorkspaceVariablesByWorkspaceIDScan(results pgx.BatchRes
 	items := []FindWorkspaceVariablesByWorkspaceIDRow{}
 	for rows.Next() {
 		var item FindWorkspaceVariablesByWorkspaceIDRow
-		if err := rows.Scan(&item.VariableID, &item.Key, &item.Value, &item.Description, &item.Category, &item.Sensitive, &item.HCL, &item.VersionID); err != nil {
+		if err := rows.Scan(&item.VariableID, &item.Key, &item.Value, &item.Description, &item.Category, &item.Sensitive, &item.HCL, &item.VersionID, &item.SecretReference); err != nil {
 			return nil, fmt.Errorf("scan FindWorkspaceVariablesByWorkspaceIDBatch row: %w", err)
 		}
 		items = append(items, item)
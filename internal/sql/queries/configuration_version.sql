@@ -157,3 +157,29 @@ DELETE
 FROM configuration_versions
 WHERE configuration_version_id = pggen.arg('id')
 RETURNING configuration_version_id;
+
+-- name: InsertConfigurationVersionVariable :exec
+INSERT INTO configuration_version_variables (
+    configuration_version_id,
+    directory,
+    name,
+    type,
+    default_value,
+    description,
+    sensitive
+) VALUES (
+    pggen.arg('configuration_version_id'),
+    pggen.arg('directory'),
+    pggen.arg('name'),
+    pggen.arg('type'),
+    pggen.arg('default_value'),
+    pggen.arg('description'),
+    pggen.arg('sensitive')
+)
+ON CONFLICT DO NOTHING;
+
+-- name: FindConfigurationVersionVariables :many
+SELECT *
+FROM configuration_version_variables
+WHERE configuration_version_id = pggen.arg('configuration_version_id')
+ORDER BY directory, name;
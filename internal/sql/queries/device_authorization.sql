@@ -0,0 +1,56 @@
+-- name: InsertDeviceAuthorization :exec
+INSERT INTO device_authorizations (
+    device_code_hash,
+    user_code,
+    client_id,
+    created_at,
+    expiry,
+    status
+) VALUES (
+    pggen.arg('device_code_hash'),
+    pggen.arg('user_code'),
+    pggen.arg('client_id'),
+    pggen.arg('created_at'),
+    pggen.arg('expiry'),
+    pggen.arg('status')
+);
+
+-- name: FindDeviceAuthorizationByUserCode :one
+SELECT *
+FROM device_authorizations
+WHERE user_code = pggen.arg('user_code')
+;
+
+-- name: FindDeviceAuthorizationForUpdate :one
+SELECT *
+FROM device_authorizations
+WHERE device_code_hash = pggen.arg('device_code_hash')
+FOR UPDATE
+;
+
+-- name: UpdateDeviceAuthorizationStatus :one
+UPDATE device_authorizations
+SET status   = pggen.arg('status'),
+    username = pggen.arg('username')
+WHERE user_code = pggen.arg('user_code')
+AND   status = 'pending'
+RETURNING device_code_hash
+;
+
+-- name: UpdateDeviceAuthorizationLastPolled :exec
+UPDATE device_authorizations
+SET last_polled_at = pggen.arg('last_polled_at')
+WHERE device_code_hash = pggen.arg('device_code_hash')
+;
+
+-- name: DeleteDeviceAuthorization :exec
+DELETE
+FROM device_authorizations
+WHERE device_code_hash = pggen.arg('device_code_hash')
+;
+
+-- name: DeleteExpiredDeviceAuthorizations :exec
+DELETE
+FROM device_authorizations
+WHERE expiry < pggen.arg('now')
+;
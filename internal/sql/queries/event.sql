@@ -0,0 +1,43 @@
+-- name: FindEventsAfter :many
+SELECT *
+FROM events
+WHERE event_id > pggen.arg('after_id')
+ORDER BY event_id ASC
+LIMIT pggen.arg('limit')
+;
+
+-- FindReplayStartEventID finds the ID of the event after which to replay
+-- events to a subscriber that last received the given event. Events with a
+-- lower ID that were logged within the given window of the given event may
+-- have committed after it, so the replay starts before the earliest of them.
+--
+-- name: FindReplayStartEventID :one
+SELECT COALESCE(min(e.event_id) - 1, pggen.arg('after_id'))::bigint AS event_id
+FROM events e
+WHERE e.event_id < pggen.arg('after_id')
+AND   e.time >= (
+    SELECT time - (pggen.arg('window_seconds') * interval '1 second')
+    FROM events
+    WHERE event_id = pggen.arg('after_id')
+)
+;
+
+-- name: DeleteEventsBefore :exec
+DELETE
+FROM events
+WHERE time < pggen.arg('before')
+;
+
+-- name: FindEventsAfterOrMissing :many
+SELECT *
+FROM events
+WHERE event_id > pggen.arg('after_id')
+OR    event_id = ANY(pggen.arg('missing_ids')::bigint[])
+ORDER BY event_id ASC
+LIMIT pggen.arg('limit')
+;
+
+-- name: FindLatestEventID :one
+SELECT COALESCE(max(event_id), 0)::bigint AS event_id
+FROM events
+;
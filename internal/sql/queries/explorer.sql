@@ -0,0 +1,56 @@
+-- name: InsertConfigurationVersionModule :exec
+INSERT INTO configuration_version_modules (
+    configuration_version_id,
+    name,
+    source,
+    version
+) VALUES (
+    pggen.arg('configuration_version_id'),
+    pggen.arg('name'),
+    pggen.arg('source'),
+    pggen.arg('version')
+);
+
+-- name: FindWorkspaceSummaries :many
+SELECT
+    w.workspace_id,
+    w.name,
+    w.terraform_version,
+    r.run_id AS latest_run_id,
+    r.status AS latest_run_status,
+    r.plan_only AS latest_run_plan_only,
+    r.lock_file,
+    (p.resource_report).additions AS plan_additions,
+    (p.resource_report).changes AS plan_changes,
+    (p.resource_report).destructions AS plan_destructions,
+    (
+        SELECT count(*)
+        FROM state_version_resources svr
+        WHERE svr.state_version_id = w.current_state_version_id
+        AND   svr.mode = 'managed'
+    ) AS resource_count,
+    ARRAY(
+        SELECT m.name
+        FROM configuration_version_modules m
+        WHERE m.configuration_version_id = r.configuration_version_id
+        ORDER BY m.name, m.source, m.version
+    )::text[] AS module_names,
+    ARRAY(
+        SELECT m.source
+        FROM configuration_version_modules m
+        WHERE m.configuration_version_id = r.configuration_version_id
+        ORDER BY m.name, m.source, m.version
+    )::text[] AS module_sources,
+    ARRAY(
+        SELECT m.version
+        FROM configuration_version_modules m
+        WHERE m.configuration_version_id = r.configuration_version_id
+        ORDER BY m.name, m.source, m.version
+    )::text[] AS module_versions
+FROM workspaces w
+LEFT JOIN runs r ON w.latest_run_id = r.run_id
+LEFT JOIN plans p ON r.run_id = p.run_id
+WHERE w.organization_name = pggen.arg('organization_name')
+AND   w.workspace_id = ANY(pggen.arg('workspace_ids')::text[])
+ORDER BY w.name
+;
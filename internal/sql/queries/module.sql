@@ -6,7 +6,10 @@ INSERT INTO modules (
     name,
     provider,
     status,
-    organization_name
+    organization_name,
+    path,
+    tag_prefix,
+    run_tests
 ) VALUES (
     pggen.arg('id'),
     pggen.arg('created_at'),
@@ -14,7 +17,10 @@ INSERT INTO modules (
     pggen.arg('name'),
     pggen.arg('provider'),
     pggen.arg('status'),
-    pggen.arg('organization_name')
+    pggen.arg('organization_name'),
+    pggen.arg('path'),
+    pggen.arg('tag_prefix'),
+    pggen.arg('run_tests')
 );
 
 -- name: InsertModuleVersion :one
@@ -44,6 +50,9 @@ SELECT
     m.provider,
     m.status,
     m.organization_name,
+    m.path,
+    m.tag_prefix,
+    m.run_tests,
     (r.*)::"repo_connections" AS module_connection,
     (
         SELECT array_agg(v.*) AS versions
@@ -64,6 +73,9 @@ SELECT
     m.provider,
     m.status,
     m.organization_name,
+    m.path,
+    m.tag_prefix,
+    m.run_tests,
     (r.*)::"repo_connections" AS module_connection,
     (
         SELECT array_agg(v.*) AS versions
@@ -86,6 +98,9 @@ SELECT
     m.provider,
     m.status,
     m.organization_name,
+    m.path,
+    m.tag_prefix,
+    m.run_tests,
     (r.*)::"repo_connections" AS module_connection,
     (
         SELECT array_agg(v.*) AS versions
@@ -97,7 +112,7 @@ LEFT JOIN repo_connections r USING (module_id)
 WHERE m.module_id = pggen.arg('id')
 ;
 
--- name: FindModuleByConnection :one
+-- name: FindModulesByConnection :many
 SELECT
     m.module_id,
     m.created_at,
@@ -106,6 +121,9 @@ SELECT
     m.provider,
     m.status,
     m.organization_name,
+    m.path,
+    m.tag_prefix,
+    m.run_tests,
     (r.*)::"repo_connections" AS module_connection,
     (
         SELECT array_agg(v.*) AS versions
@@ -127,6 +145,9 @@ SELECT
     m.provider,
     m.status,
     m.organization_name,
+    m.path,
+    m.tag_prefix,
+    m.run_tests,
     (r.*)::"repo_connections" AS module_connection,
     (
         SELECT array_agg(v.*) AS versions
@@ -171,6 +192,134 @@ WHERE module_version_id = pggen.arg('module_version_id')
 RETURNING *
 ;
 
+-- name: UpdateModuleVersionDeprecationByID :one
+UPDATE module_versions
+SET
+    deprecated = pggen.arg('deprecated'),
+    deprecation_reason = pggen.arg('deprecation_reason'),
+    replacement_version = pggen.arg('replacement_version')
+WHERE module_version_id = pggen.arg('module_version_id')
+RETURNING module_version_id
+;
+
+-- name: UpdateModuleVersionYankedByID :one
+UPDATE module_versions
+SET yanked = pggen.arg('yanked')
+WHERE module_version_id = pggen.arg('module_version_id')
+RETURNING module_version_id
+;
+
+-- name: FindPendingModuleVersionTests :many
+SELECT
+    m.organization_name,
+    m.name,
+    m.provider,
+    v.module_version_id,
+    v.version
+FROM module_versions v
+JOIN modules m USING (module_id)
+WHERE v.status = 'test_pending'
+ORDER BY v.created_at
+;
+
+-- name: UpdateModuleVersionTestStarted :one
+UPDATE module_versions
+SET status = 'testing'
+WHERE module_version_id = pggen.arg('module_version_id')
+AND   status = 'test_pending'
+RETURNING module_version_id
+;
+
+-- name: UpdateStaleModuleVersionTests :many
+UPDATE module_versions v
+SET status = 'test_pending'
+FROM module_version_tests t
+WHERE v.module_version_id = t.module_version_id
+AND   v.status = 'testing'
+AND   t.started_at < pggen.arg('started_before')
+RETURNING v.module_version_id
+;
+
+-- name: InsertModuleVersionTest :exec
+INSERT INTO module_version_tests (
+    module_version_id,
+    started_at
+) VALUES (
+    pggen.arg('module_version_id'),
+    pggen.arg('started_at')
+)
+ON CONFLICT (module_version_id) DO UPDATE
+SET output = ''::bytea,
+    started_at = EXCLUDED.started_at,
+    finished_at = NULL
+;
+
+-- name: UpdateModuleVersionTestOutput :one
+UPDATE module_version_tests
+SET output = output || pggen.arg('output')
+WHERE module_version_id = pggen.arg('module_version_id')
+RETURNING module_version_id
+;
+
+-- name: UpdateModuleVersionTestFinished :one
+UPDATE module_version_tests
+SET finished_at = pggen.arg('finished_at')
+WHERE module_version_id = pggen.arg('module_version_id')
+RETURNING module_version_id
+;
+
+-- name: FindModuleVersionTestsByModuleID :many
+SELECT
+    t.module_version_id,
+    v.version,
+    v.status,
+    t.output,
+    t.started_at,
+    t.finished_at
+FROM module_version_tests t
+JOIN module_versions v USING (module_version_id)
+WHERE v.module_id = pggen.arg('module_id')
+ORDER BY t.started_at DESC
+;
+
+-- name: IncrementModuleVersionDownloads :one
+UPDATE module_versions
+SET downloads = downloads + 1
+WHERE module_version_id = pggen.arg('module_version_id')
+RETURNING module_version_id
+;
+
+-- name: DeleteModuleVersionUsagesByWorkspaceID :exec
+DELETE
+FROM module_version_usages
+WHERE workspace_id = pggen.arg('workspace_id')
+;
+
+-- name: InsertModuleVersionUsage :exec
+INSERT INTO module_version_usages (
+    module_version_id,
+    workspace_id,
+    updated_at
+) VALUES (
+    pggen.arg('module_version_id'),
+    pggen.arg('workspace_id'),
+    pggen.arg('updated_at')
+);
+
+-- name: FindModuleVersionUsagesByModuleID :many
+SELECT
+    u.module_version_id,
+    v.version,
+    u.workspace_id,
+    w.name AS workspace_name,
+    u.updated_at
+FROM module_version_usages u
+JOIN module_versions v USING (module_version_id)
+JOIN workspaces w USING (workspace_id)
+WHERE v.module_id = pggen.arg('module_id')
+ORDER BY w.name
+;
+
 -- name: DeleteModuleByID :one
 DELETE
 FROM modules
@@ -3,16 +3,24 @@ INSERT INTO organization_tokens (
     organization_token_id,
     created_at,
     organization_name,
-    expiry
+    expiry,
+    scope_actions,
+    scope_workspace_ids
 ) VALUES (
     pggen.arg('organization_token_id'),
     pggen.arg('created_at'),
     pggen.arg('organization_name'),
-    pggen.arg('expiry')
+    pggen.arg('expiry'),
+    pggen.arg('scope_actions'),
+    pggen.arg('scope_workspace_ids')
 ) ON CONFLICT (organization_name) DO UPDATE
   SET created_at            = pggen.arg('created_at'),
       organization_token_id = pggen.arg('organization_token_id'),
-      expiry                = pggen.arg('expiry');
+      expiry                = pggen.arg('expiry'),
+      scope_actions         = pggen.arg('scope_actions'),
+      scope_workspace_ids   = pggen.arg('scope_workspace_ids'),
+      last_used_at          = NULL,
+      last_used_ip          = NULL;
 
 -- name: FindOrganizationTokensByName :many
 SELECT *
@@ -24,6 +32,12 @@ SELECT *
 FROM organization_tokens
 WHERE organization_token_id = pggen.arg('organization_token_id');
 
+-- name: UpdateOrganizationTokenLastUsed :exec
+UPDATE organization_tokens
+SET last_used_at = pggen.arg('last_used_at'),
+    last_used_ip = pggen.arg('last_used_ip')
+WHERE organization_token_id = pggen.arg('organization_token_id');
+
 -- name: DeleteOrganiationTokenByName :one
 DELETE
 FROM organization_tokens
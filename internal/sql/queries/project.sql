@@ -0,0 +1,97 @@
+-- name: InsertProject :exec
+INSERT INTO projects (
+    project_id,
+    created_at,
+    name,
+    organization_name
+) VALUES (
+    pggen.arg('id'),
+    pggen.arg('created_at'),
+    pggen.arg('name'),
+    pggen.arg('organization_name')
+);
+
+-- name: FindProjects :many
+SELECT *
+FROM projects
+WHERE organization_name = pggen.arg('organization_name')
+ORDER BY name ASC
+LIMIT pggen.arg('limit')
+OFFSET pggen.arg('offset')
+;
+
+-- name: CountProjects :one
+SELECT count(*)
+FROM projects
+WHERE organization_name = pggen.arg('organization_name')
+;
+
+-- name: FindProjectByID :one
+SELECT *
+FROM projects
+WHERE project_id = pggen.arg('project_id')
+;
+
+-- name: FindProjectByIDForUpdate :one
+SELECT *
+FROM projects p
+WHERE project_id = pggen.arg('project_id')
+FOR UPDATE OF p
+;
+
+-- name: UpdateProjectByID :one
+UPDATE projects
+SET
+    name = pggen.arg('name')
+WHERE project_id = pggen.arg('project_id')
+RETURNING project_id;
+
+-- name: DeleteProjectByID :one
+DELETE
+FROM projects
+WHERE project_id = pggen.arg('project_id')
+RETURNING project_id
+;
+
+-- name: UpsertProjectPermission :exec
+INSERT INTO project_permissions (
+    project_id,
+    team_id,
+    role
+) SELECT p.project_id, t.team_id, pggen.arg('role')
+    FROM teams t
+    JOIN projects p ON p.organization_name = t.organization_name
+    WHERE t.name = pggen.arg('team_name')
+    AND p.project_id = pggen.arg('project_id')
+ON CONFLICT (project_id, team_id) DO UPDATE SET role = pggen.arg('role')
+;
+
+-- name: FindProjectPermissionsByProjectID :many
+SELECT
+    pp.role,
+    (t.*)::"teams" AS team
+FROM project_permissions pp
+JOIN teams t USING (team_id)
+WHERE pp.project_id = pggen.arg('project_id')
+;
+
+-- name: FindProjectPermissionsByWorkspaceID :many
+SELECT
+    pp.role,
+    (t.*)::"teams" AS team
+FROM project_permissions pp
+JOIN teams t USING (team_id)
+JOIN workspaces w ON w.project_id = pp.project_id
+WHERE w.workspace_id = pggen.arg('workspace_id')
+;
+
+-- name: DeleteProjectPermissionByID :exec
+DELETE
+FROM project_permissions pp
+USING projects p, teams t
+WHERE pp.team_id = t.team_id
+AND pp.project_id = pggen.arg('project_id')
+AND p.project_id = pp.project_id
+AND p.organization_name = t.organization_name
+AND t.name = pggen.arg('team_name')
+;
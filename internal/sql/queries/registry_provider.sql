@@ -0,0 +1,211 @@
+-- name: InsertRegistryGPGKey :exec
+INSERT INTO registry_gpg_keys (
+    gpg_key_id,
+    organization_name,
+    key_id,
+    ascii_armor,
+    created_at
+) VALUES (
+    pggen.arg('gpg_key_id'),
+    pggen.arg('organization_name'),
+    pggen.arg('key_id'),
+    pggen.arg('ascii_armor'),
+    pggen.arg('created_at')
+);
+
+-- name: FindRegistryGPGKeysByOrganization :many
+SELECT
+    gpg_key_id,
+    organization_name,
+    key_id,
+    ascii_armor,
+    created_at
+FROM registry_gpg_keys
+WHERE organization_name = pggen.arg('organization_name')
+ORDER BY created_at
+;
+
+-- name: FindRegistryGPGKeyByID :one
+SELECT
+    gpg_key_id,
+    organization_name,
+    key_id,
+    ascii_armor,
+    created_at
+FROM registry_gpg_keys
+WHERE gpg_key_id = pggen.arg('gpg_key_id')
+;
+
+-- name: DeleteRegistryGPGKeyByID :one
+DELETE
+FROM registry_gpg_keys
+WHERE gpg_key_id = pggen.arg('gpg_key_id')
+RETURNING gpg_key_id
+;
+
+-- name: InsertRegistryProvider :exec
+INSERT INTO registry_providers (
+    registry_provider_id,
+    organization_name,
+    name,
+    created_at
+) VALUES (
+    pggen.arg('registry_provider_id'),
+    pggen.arg('organization_name'),
+    pggen.arg('name'),
+    pggen.arg('created_at')
+);
+
+-- name: FindRegistryProvidersByOrganization :many
+SELECT
+    registry_provider_id,
+    organization_name,
+    name,
+    created_at
+FROM registry_providers
+WHERE organization_name = pggen.arg('organization_name')
+ORDER BY name
+;
+
+-- name: FindRegistryProviderByName :one
+SELECT
+    registry_provider_id,
+    organization_name,
+    name,
+    created_at
+FROM registry_providers
+WHERE organization_name = pggen.arg('organization_name')
+AND   name = pggen.arg('name')
+;
+
+-- name: FindRegistryProviderByID :one
+SELECT
+    registry_provider_id,
+    organization_name,
+    name,
+    created_at
+FROM registry_providers
+WHERE registry_provider_id = pggen.arg('registry_provider_id')
+;
+
+-- name: FindRegistryProviderByVersionID :one
+SELECT
+    p.registry_provider_id,
+    p.organization_name,
+    p.name,
+    p.created_at
+FROM registry_providers p
+JOIN registry_provider_versions v USING (registry_provider_id)
+WHERE v.registry_provider_version_id = pggen.arg('registry_provider_version_id')
+;
+
+-- name: DeleteRegistryProviderByID :one
+DELETE
+FROM registry_providers
+WHERE registry_provider_id = pggen.arg('registry_provider_id')
+RETURNING registry_provider_id
+;
+
+-- name: InsertRegistryProviderVersion :exec
+INSERT INTO registry_provider_versions (
+    registry_provider_version_id,
+    registry_provider_id,
+    version,
+    protocols,
+    gpg_key_id,
+    shasums,
+    shasums_signature,
+    created_at
+) VALUES (
+    pggen.arg('registry_provider_version_id'),
+    pggen.arg('registry_provider_id'),
+    pggen.arg('version'),
+    pggen.arg('protocols'),
+    pggen.arg('gpg_key_id'),
+    pggen.arg('shasums'),
+    pggen.arg('shasums_signature'),
+    pggen.arg('created_at')
+);
+
+-- name: FindRegistryProviderVersionsByProviderID :many
+SELECT
+    registry_provider_version_id,
+    registry_provider_id,
+    version,
+    protocols,
+    gpg_key_id,
+    shasums,
+    shasums_signature,
+    created_at
+FROM registry_provider_versions
+WHERE registry_provider_id = pggen.arg('registry_provider_id')
+;
+
+-- name: DeleteRegistryProviderVersionByID :one
+DELETE
+FROM registry_provider_versions
+WHERE registry_provider_version_id = pggen.arg('registry_provider_version_id')
+RETURNING registry_provider_version_id
+;
+
+-- name: InsertRegistryProviderPlatform :exec
+INSERT INTO registry_provider_platforms (
+    registry_provider_platform_id,
+    registry_provider_version_id,
+    os,
+    arch,
+    filename,
+    shasum,
+    binary,
+    created_at
+) VALUES (
+    pggen.arg('registry_provider_platform_id'),
+    pggen.arg('registry_provider_version_id'),
+    pggen.arg('os'),
+    pggen.arg('arch'),
+    pggen.arg('filename'),
+    pggen.arg('shasum'),
+    pggen.arg('binary'),
+    pggen.arg('created_at')
+);
+
+-- name: FindRegistryProviderPlatformsByProviderID :many
+SELECT
+    pp.registry_provider_platform_id,
+    pp.registry_provider_version_id,
+    pp.os,
+    pp.arch,
+    pp.filename,
+    pp.shasum,
+    pp.created_at
+FROM registry_provider_platforms pp
+JOIN registry_provider_versions v USING (registry_provider_version_id)
+WHERE v.registry_provider_id = pggen.arg('registry_provider_id')
+ORDER BY pp.os, pp.arch
+;
+
+-- name: FindRegistryProviderPlatformByID :one
+SELECT
+    registry_provider_platform_id,
+    registry_provider_version_id,
+    os,
+    arch,
+    filename,
+    shasum,
+    created_at
+FROM registry_provider_platforms
+WHERE registry_provider_platform_id = pggen.arg('registry_provider_platform_id')
+;
+
+-- name: FindRegistryProviderPlatformBinary :one
+SELECT binary
+FROM registry_provider_platforms
+WHERE registry_provider_platform_id = pggen.arg('registry_provider_platform_id')
+;
+
+-- name: DeleteRegistryProviderPlatformByID :one
+DELETE
+FROM registry_provider_platforms
+WHERE registry_provider_platform_id = pggen.arg('registry_provider_platform_id')
+RETURNING registry_provider_platform_id
+;
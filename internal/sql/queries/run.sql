@@ -16,7 +16,8 @@ INSERT INTO runs (
     workspace_id,
     created_by,
     terraform_version,
-    allow_empty_apply
+    allow_empty_apply,
+    test_only
 ) VALUES (
     pggen.arg('id'),
     pggen.arg('created_at'),
@@ -34,7 +35,8 @@ INSERT INTO runs (
     pggen.arg('workspace_id'),
     pggen.arg('created_by'),
     pggen.arg('terraform_version'),
-    pggen.arg('allow_empty_apply')
+    pggen.arg('allow_empty_apply'),
+    pggen.arg('test_only')
 );
 
 -- name: InsertRunStatusTimestamp :exec
@@ -72,6 +74,7 @@ SELECT
     runs.status,
     plans.status      AS plan_status,
     applies.status      AS apply_status,
+    tests.status        AS test_status,
     runs.replace_addrs,
     runs.target_addrs,
     runs.auto_apply,
@@ -84,6 +87,8 @@ SELECT
     runs.created_by,
     runs.terraform_version,
     runs.allow_empty_apply,
+    runs.test_only,
+    tests.report AS test_report,
     workspaces.execution_mode AS execution_mode,
     CASE WHEN workspaces.latest_run_id = runs.run_id THEN true
          ELSE false
@@ -111,6 +116,13 @@ SELECT
         AND   st.phase = 'apply'
         GROUP BY run_id, phase
     ) AS apply_status_timestamps,
+    (
+        SELECT array_agg(st.*) AS phase_status_timestamps
+        FROM phase_status_timestamps st
+        WHERE st.run_id = tests.run_id
+        AND   st.phase = 'test'
+        GROUP BY run_id, phase
+    ) AS test_status_timestamps,
     (
         SELECT array_agg(v.*) AS run_variables
         FROM run_variables v
@@ -120,6 +132,7 @@ SELECT
 FROM runs
 JOIN plans USING (run_id)
 JOIN applies USING (run_id)
+JOIN tests USING (run_id)
 JOIN (configuration_versions LEFT JOIN ingress_attributes ia USING (configuration_version_id)) USING (configuration_version_id)
 JOIN workspaces ON runs.workspace_id = workspaces.workspace_id
 JOIN organizations ON workspaces.organization_name = organizations.name
@@ -165,6 +178,7 @@ SELECT
     runs.status,
     plans.status      AS plan_status,
     applies.status      AS apply_status,
+    tests.status        AS test_status,
     runs.replace_addrs,
     runs.target_addrs,
     runs.auto_apply,
@@ -177,6 +191,8 @@ SELECT
     runs.created_by,
     runs.terraform_version,
     runs.allow_empty_apply,
+    runs.test_only,
+    tests.report AS test_report,
     workspaces.execution_mode AS execution_mode,
     CASE WHEN workspaces.latest_run_id = runs.run_id THEN true
          ELSE false
@@ -204,6 +220,13 @@ SELECT
         AND   st.phase = 'apply'
         GROUP BY run_id, phase
     ) AS apply_status_timestamps,
+    (
+        SELECT array_agg(st.*) AS phase_status_timestamps
+        FROM phase_status_timestamps st
+        WHERE st.run_id = tests.run_id
+        AND   st.phase = 'test'
+        GROUP BY run_id, phase
+    ) AS test_status_timestamps,
     (
         SELECT array_agg(v.*) AS run_variables
         FROM run_variables v
@@ -213,6 +236,7 @@ SELECT
 FROM runs
 JOIN plans USING (run_id)
 JOIN applies USING (run_id)
+JOIN tests USING (run_id)
 JOIN (configuration_versions LEFT JOIN ingress_attributes ia USING (configuration_version_id)) USING (configuration_version_id)
 JOIN workspaces ON runs.workspace_id = workspaces.workspace_id
 JOIN organizations ON workspaces.organization_name = organizations.name
@@ -232,6 +256,7 @@ SELECT
     runs.status,
     plans.status        AS plan_status,
     applies.status      AS apply_status,
+    tests.status        AS test_status,
     runs.replace_addrs,
     runs.target_addrs,
     runs.auto_apply,
@@ -244,6 +269,8 @@ SELECT
     runs.created_by,
     runs.terraform_version,
     runs.allow_empty_apply,
+    runs.test_only,
+    tests.report AS test_report,
     workspaces.execution_mode AS execution_mode,
     CASE WHEN workspaces.latest_run_id = runs.run_id THEN true
          ELSE false
@@ -271,6 +298,13 @@ SELECT
         AND   st.phase = 'apply'
         GROUP BY run_id, phase
     ) AS apply_status_timestamps,
+    (
+        SELECT array_agg(st.*) AS phase_status_timestamps
+        FROM phase_status_timestamps st
+        WHERE st.run_id = tests.run_id
+        AND   st.phase = 'test'
+        GROUP BY run_id, phase
+    ) AS test_status_timestamps,
     (
         SELECT array_agg(v.*) AS run_variables
         FROM run_variables v
@@ -280,11 +314,12 @@ SELECT
 FROM runs
 JOIN plans USING (run_id)
 JOIN applies USING (run_id)
+JOIN tests USING (run_id)
 JOIN (configuration_versions LEFT JOIN ingress_attributes ia USING (configuration_version_id)) USING (configuration_version_id)
 JOIN workspaces ON runs.workspace_id = workspaces.workspace_id
 JOIN organizations ON workspaces.organization_name = organizations.name
 WHERE runs.run_id = pggen.arg('run_id')
-FOR UPDATE OF runs, plans, applies
+FOR UPDATE OF runs, plans, applies, tests
 ;
 
 -- name: PutLockFile :one
@@ -93,3 +93,83 @@ FROM state_versions
 WHERE state_version_id = pggen.arg('state_version_id')
 RETURNING state_version_id
 ;
+
+-- name: InsertStateVersionResource :exec
+INSERT INTO state_version_resources (
+    state_version_id,
+    address,
+    mode,
+    type,
+    provider,
+    module,
+    attribute_paths,
+    attribute_values
+) VALUES (
+    pggen.arg('state_version_id'),
+    pggen.arg('address'),
+    pggen.arg('mode'),
+    pggen.arg('type'),
+    pggen.arg('provider'),
+    pggen.arg('module'),
+    pggen.arg('attribute_paths'),
+    pggen.arg('attribute_values')
+)
+ON CONFLICT DO NOTHING;
+
+-- name: UpdateStateVersionResourcesIndexed :exec
+UPDATE state_versions
+SET resources_indexed = true
+WHERE state_version_id = pggen.arg('state_version_id')
+;
+
+-- name: FindUnindexedCurrentStateVersionsByOrganization :many
+SELECT
+    sv.state_version_id,
+    sv.state
+FROM workspaces w
+JOIN state_versions sv ON w.current_state_version_id = sv.state_version_id
+WHERE w.organization_name = pggen.arg('organization_name')
+AND   NOT sv.resources_indexed
+;
+
+-- name: FindCurrentStateWorkspaceIDsByOrganization :many
+SELECT w.workspace_id
+FROM workspaces w
+WHERE w.organization_name = pggen.arg('organization_name')
+AND   w.current_state_version_id IS NOT NULL
+;
+
+-- name: FindStateResources :many
+SELECT
+    w.workspace_id,
+    w.name AS workspace_name,
+    r.state_version_id,
+    r.address,
+    r.type,
+    r.provider,
+    r.module,
+    CASE WHEN pggen.arg('value')::text = '' THEN NULL
+         ELSE r.attribute_paths[array_position(r.attribute_values, pggen.arg('value')::text)]
+    END AS attribute
+FROM state_version_resources r
+JOIN workspaces w ON w.current_state_version_id = r.state_version_id
+WHERE w.organization_name = pggen.arg('organization_name')
+AND   ((pggen.arg('workspace_ids')::text[]) IS NULL OR w.workspace_id = ANY(pggen.arg('workspace_ids')::text[]))
+AND   (pggen.arg('address')::text = '' OR strpos(r.address, pggen.arg('address')::text) > 0)
+AND   (pggen.arg('type')::text = '' OR r.type = pggen.arg('type')::text)
+AND   (pggen.arg('value')::text = '' OR r.attribute_values @> ARRAY[pggen.arg('value')::text])
+ORDER BY w.name, r.address
+LIMIT pggen.arg('limit')
+OFFSET pggen.arg('offset')
+;
+
+-- name: CountStateResources :one
+SELECT count(*)
+FROM state_version_resources r
+JOIN workspaces w ON w.current_state_version_id = r.state_version_id
+WHERE w.organization_name = pggen.arg('organization_name')
+AND   ((pggen.arg('workspace_ids')::text[]) IS NULL OR w.workspace_id = ANY(pggen.arg('workspace_ids')::text[]))
+AND   (pggen.arg('address')::text = '' OR strpos(r.address, pggen.arg('address')::text) > 0)
+AND   (pggen.arg('type')::text = '' OR r.type = pggen.arg('type')::text)
+AND   (pggen.arg('value')::text = '' OR r.attribute_values @> ARRAY[pggen.arg('value')::text])
+;
@@ -33,6 +33,13 @@ FROM teams
 WHERE organization_name = pggen.arg('organization_name')
 ;
 
+-- name: FindTeamsWithSSOTeamID :many
+SELECT *
+FROM teams
+WHERE sso_team_id IS NOT NULL
+AND   organization_name = ANY(pggen.arg('organization_names')::text[])
+;
+
 -- name: FindTeamByName :one
 SELECT *
 FROM teams
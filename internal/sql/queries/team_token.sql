@@ -3,23 +3,38 @@ INSERT INTO team_tokens (
     team_token_id,
     created_at,
     team_id,
-    expiry
+    expiry,
+    scope_actions,
+    scope_workspace_ids
 ) VALUES (
     pggen.arg('team_token_id'),
     pggen.arg('created_at'),
     pggen.arg('team_id'),
-    pggen.arg('expiry')
+    pggen.arg('expiry'),
+    pggen.arg('scope_actions'),
+    pggen.arg('scope_workspace_ids')
 ) ON CONFLICT (team_id) DO UPDATE
-  SET team_token_id = pggen.arg('team_token_id'),
-      created_at    = pggen.arg('created_at'),
-      expiry        = pggen.arg('expiry');
+  SET team_token_id       = pggen.arg('team_token_id'),
+      created_at          = pggen.arg('created_at'),
+      expiry              = pggen.arg('expiry'),
+      scope_actions       = pggen.arg('scope_actions'),
+      scope_workspace_ids = pggen.arg('scope_workspace_ids'),
+      last_used_at        = NULL,
+      last_used_ip        = NULL;
 
---name: FindTeamTokensByID :many
+-- name: FindTeamTokensByID :many
 SELECT *
 FROM team_tokens
 WHERE team_id = pggen.arg('team_id')
 ;
 
+-- name: UpdateTeamTokenLastUsed :exec
+UPDATE team_tokens
+SET last_used_at = pggen.arg('last_used_at'),
+    last_used_ip = pggen.arg('last_used_ip')
+WHERE team_token_id = pggen.arg('team_token_id')
+;
+
 -- name: DeleteTeamTokenByID :one
 DELETE
 FROM team_tokens
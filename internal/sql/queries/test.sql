@@ -0,0 +1,22 @@
+-- name: InsertTest :exec
+INSERT INTO tests (
+    run_id,
+    status
+) VALUES (
+    pggen.arg('run_id'),
+    pggen.arg('status')
+);
+
+-- name: UpdateTestStatusByID :one
+UPDATE tests
+SET status = pggen.arg('status')
+WHERE run_id = pggen.arg('run_id')
+RETURNING run_id
+;
+
+-- name: UpdateTestReportByID :one
+UPDATE tests
+SET report = pggen.arg('report')
+WHERE run_id = pggen.arg('run_id')
+RETURNING run_id
+;
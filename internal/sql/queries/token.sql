@@ -3,12 +3,18 @@ INSERT INTO tokens (
     token_id,
     created_at,
     description,
-    username
+    username,
+    expiry,
+    scope_actions,
+    scope_workspace_ids
 ) VALUES (
     pggen.arg('token_id'),
     pggen.arg('created_at'),
     pggen.arg('description'),
-    pggen.arg('username')
+    pggen.arg('username'),
+    pggen.arg('expiry'),
+    pggen.arg('scope_actions'),
+    pggen.arg('scope_workspace_ids')
 );
 
 -- name: FindTokensByUsername :many
@@ -23,6 +29,13 @@ FROM tokens
 WHERE token_id = pggen.arg('token_id')
 ;
 
+-- name: UpdateTokenLastUsed :exec
+UPDATE tokens
+SET last_used_at = pggen.arg('last_used_at'),
+    last_used_ip = pggen.arg('last_used_ip')
+WHERE token_id = pggen.arg('token_id')
+;
+
 -- name: DeleteTokenByID :one
 DELETE
 FROM tokens
@@ -89,6 +89,33 @@ JOIN tokens t ON u.username = t.username
 WHERE t.token_id = pggen.arg('token_id')
 ;
 
+-- name: FindUserByVCSIdentity :one
+SELECT u.*,
+    (
+        SELECT array_agg(t)
+        FROM teams t
+        JOIN team_memberships tm USING (team_id)
+        WHERE tm.username = u.username
+    ) AS teams
+FROM users u
+JOIN vcs_identities vi USING (user_id)
+WHERE vi.hostname = pggen.arg('hostname')
+AND   vi.vcs_username = pggen.arg('vcs_username')
+;
+
+-- name: UpsertVCSIdentity :exec
+INSERT INTO vcs_identities (
+    hostname,
+    vcs_username,
+    user_id
+) VALUES (
+    pggen.arg('hostname'),
+    pggen.arg('vcs_username'),
+    pggen.arg('user_id')
+) ON CONFLICT (hostname, vcs_username) DO UPDATE
+SET user_id = EXCLUDED.user_id
+;
+
 -- name: UpdateUserSiteAdmins :many
 UPDATE users
 SET site_admin = true
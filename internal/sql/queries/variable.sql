@@ -7,7 +7,8 @@ INSERT INTO variables (
     category,
     sensitive,
     hcl,
-    version_id
+    version_id,
+    secret_reference
 ) VALUES (
     pggen.arg('variable_id'),
     pggen.arg('key'),
@@ -16,7 +17,8 @@ INSERT INTO variables (
     pggen.arg('category'),
     pggen.arg('sensitive'),
     pggen.arg('hcl'),
-    pggen.arg('version_id')
+    pggen.arg('version_id'),
+    pggen.arg('secret_reference')
 );
 
 -- name: FindVariable :one
@@ -34,7 +36,8 @@ SET
     category = pggen.arg('category'),
     sensitive = pggen.arg('sensitive'),
     version_id = pggen.arg('version_id'),
-    hcl = pggen.arg('hcl')
+    hcl = pggen.arg('hcl'),
+    secret_reference = pggen.arg('secret_reference')
 WHERE variable_id = pggen.arg('variable_id')
 RETURNING variable_id
 ;
@@ -4,13 +4,15 @@ INSERT INTO variable_sets (
     global,
     name,
     description,
-    organization_name
+    organization_name,
+    priority
 ) VALUES (
     pggen.arg('variable_set_id'),
     pggen.arg('global'),
     pggen.arg('name'),
     pggen.arg('description'),
-    pggen.arg('organization_name')
+    pggen.arg('organization_name'),
+    pggen.arg('priority')
 );
 
 -- name: FindVariableSetsByOrganization :many
@@ -28,7 +30,13 @@ SELECT
         FROM variable_set_workspaces vsw
         WHERE vsw.variable_set_id = vs.variable_set_id
         GROUP BY variable_set_id
-    ) AS workspace_ids
+    ) AS workspace_ids,
+    (
+        SELECT array_agg(vsp.project_id) AS project_ids
+        FROM variable_set_projects vsp
+        WHERE vsp.variable_set_id = vs.variable_set_id
+        GROUP BY variable_set_id
+    ) AS project_ids
 FROM variable_sets vs
 WHERE organization_name = pggen.arg('organization_name');
 
@@ -47,7 +55,13 @@ SELECT
         FROM variable_set_workspaces vsw
         WHERE vsw.variable_set_id = vs.variable_set_id
         GROUP BY variable_set_id
-    ) AS workspace_ids
+    ) AS workspace_ids,
+    (
+        SELECT array_agg(vsp.project_id) AS project_ids
+        FROM variable_set_projects vsp
+        WHERE vsp.variable_set_id = vs.variable_set_id
+        GROUP BY variable_set_id
+    ) AS project_ids
 FROM variable_sets vs
 JOIN variable_set_workspaces vsw USING (variable_set_id)
 WHERE workspace_id = pggen.arg('workspace_id')
@@ -66,11 +80,43 @@ SELECT
         FROM variable_set_workspaces vsw
         WHERE vsw.variable_set_id = vs.variable_set_id
         GROUP BY variable_set_id
-    ) AS workspace_ids
+    ) AS workspace_ids,
+    (
+        SELECT array_agg(vsp.project_id) AS project_ids
+        FROM variable_set_projects vsp
+        WHERE vsp.variable_set_id = vs.variable_set_id
+        GROUP BY variable_set_id
+    ) AS project_ids
 FROM variable_sets vs
 JOIN (organizations o JOIN workspaces w ON o.name = w.organization_name) ON o.name = vs.organization_name
 WHERE vs.global IS true
-AND w.workspace_id = pggen.arg('workspace_id');
+AND w.workspace_id = pggen.arg('workspace_id')
+UNION
+SELECT
+    vs.*,
+    (
+        SELECT array_agg(v.*) AS variables
+        FROM variables v
+        JOIN variable_set_variables vsv USING (variable_id)
+        WHERE vsv.variable_set_id = vs.variable_set_id
+        GROUP BY variable_set_id
+    ) AS variables,
+    (
+        SELECT array_agg(vsw.workspace_id) AS workspace_ids
+        FROM variable_set_workspaces vsw
+        WHERE vsw.variable_set_id = vs.variable_set_id
+        GROUP BY variable_set_id
+    ) AS workspace_ids,
+    (
+        SELECT array_agg(vsp.project_id) AS project_ids
+        FROM variable_set_projects vsp
+        WHERE vsp.variable_set_id = vs.variable_set_id
+        GROUP BY variable_set_id
+    ) AS project_ids
+FROM variable_sets vs
+JOIN variable_set_projects vsp USING (variable_set_id)
+JOIN workspaces w USING (project_id)
+WHERE w.workspace_id = pggen.arg('workspace_id');
 
 -- name: FindVariableSetBySetID :one
 SELECT
@@ -87,7 +133,13 @@ SELECT
         FROM variable_set_workspaces vsw
         WHERE vsw.variable_set_id = vs.variable_set_id
         GROUP BY variable_set_id
-    ) AS workspace_ids
+    ) AS workspace_ids,
+    (
+        SELECT array_agg(vsp.project_id) AS project_ids
+        FROM variable_set_projects vsp
+        WHERE vsp.variable_set_id = vs.variable_set_id
+        GROUP BY variable_set_id
+    ) AS project_ids
 FROM variable_sets vs
 WHERE vs.variable_set_id = pggen.arg('variable_set_id');
 
@@ -106,7 +158,13 @@ SELECT
         FROM variable_set_workspaces vsw
         WHERE vsw.variable_set_id = vs.variable_set_id
         GROUP BY variable_set_id
-    ) AS workspace_ids
+    ) AS workspace_ids,
+    (
+        SELECT array_agg(vsp.project_id) AS project_ids
+        FROM variable_set_projects vsp
+        WHERE vsp.variable_set_id = vs.variable_set_id
+        GROUP BY variable_set_id
+    ) AS project_ids
 FROM variable_sets vs
 JOIN variable_set_variables vsv USING (variable_set_id)
 WHERE vsv.variable_id = pggen.arg('variable_id');
@@ -126,7 +184,13 @@ SELECT
         FROM variable_set_workspaces vsw
         WHERE vsw.variable_set_id = vs.variable_set_id
         GROUP BY variable_set_id
-    ) AS workspace_ids
+    ) AS workspace_ids,
+    (
+        SELECT array_agg(vsp.project_id) AS project_ids
+        FROM variable_set_projects vsp
+        WHERE vsp.variable_set_id = vs.variable_set_id
+        GROUP BY variable_set_id
+    ) AS project_ids
 FROM variable_sets vs
 WHERE variable_set_id = pggen.arg('variable_set_id')
 FOR UPDATE OF vs;
@@ -136,7 +200,8 @@ UPDATE variable_sets
 SET
     global = pggen.arg('global'),
     name = pggen.arg('name'),
-    description = pggen.arg('description')
+    description = pggen.arg('description'),
+    priority = pggen.arg('priority')
 WHERE variable_set_id = pggen.arg('variable_set_id')
 RETURNING variable_set_id;
 
@@ -182,3 +247,17 @@ RETURNING *;
 DELETE
 FROM variable_set_workspaces
 WHERE variable_set_id = pggen.arg('variable_set_id');
+
+-- name: InsertVariableSetProject :exec
+INSERT INTO variable_set_projects (
+    variable_set_id,
+    project_id
+) VALUES (
+    pggen.arg('variable_set_id'),
+    pggen.arg('project_id')
+);
+
+-- name: DeleteVariableSetProjects :exec
+DELETE
+FROM variable_set_projects
+WHERE variable_set_id = pggen.arg('variable_set_id');
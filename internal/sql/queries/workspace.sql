@@ -24,7 +24,10 @@ INSERT INTO workspaces (
     trigger_patterns,
     vcs_tags_regex,
     working_directory,
-    organization_name
+    organization_name,
+    project_id,
+    test_pull_requests,
+    comment_pull_requests
 ) VALUES (
     pggen.arg('id'),
     pggen.arg('created_at'),
@@ -50,7 +53,10 @@ INSERT INTO workspaces (
     pggen.arg('trigger_patterns'),
     pggen.arg('vcs_tags_regex'),
     pggen.arg('working_directory'),
-    pggen.arg('organization_name')
+    pggen.arg('organization_name'),
+    pggen.arg('project_id'),
+    pggen.arg('test_pull_requests'),
+    pggen.arg('comment_pull_requests')
 );
 
 -- name: FindWorkspaces :many
@@ -83,6 +89,7 @@ LEFT JOIN runs r ON w.latest_run_id = r.run_id
 LEFT JOIN (workspace_tags wt JOIN tags t USING (tag_id)) ON wt.workspace_id = w.workspace_id
 WHERE w.name                LIKE '%' || pggen.arg('search') || '%'
 AND   w.organization_name   LIKE ANY(pggen.arg('organization_names'))
+AND   COALESCE(w.project_id, '') LIKE pggen.arg('project_id')
 GROUP BY w.workspace_id, r.status
 HAVING array_agg(t.name) @> pggen.arg('tags')
 ORDER BY w.updated_at DESC
@@ -98,6 +105,7 @@ WITH
         LEFT JOIN (workspace_tags wt JOIN tags t USING (tag_id)) ON w.workspace_id = wt.workspace_id
         WHERE w.name              LIKE '%' || pggen.arg('search') || '%'
         AND   w.organization_name LIKE ANY(pggen.arg('organization_names'))
+        AND   COALESCE(w.project_id, '') LIKE pggen.arg('project_id')
         GROUP BY w.workspace_id
         HAVING array_agg(t.name) @> pggen.arg('tags')
     )
@@ -127,6 +135,9 @@ WHERE rc.vcs_provider_id = pggen.arg('vcs_provider_id')
 AND   rc.repo_path = pggen.arg('repo_path')
 ;
 
+-- FindWorkspacesByUsername finds workspaces for which the user has been
+-- granted permissions, either directly or via the workspace's project.
+--
 -- name: FindWorkspacesByUsername :many
 SELECT
     w.*,
@@ -141,16 +152,24 @@ SELECT
     (rl.*)::"runs" AS run_lock,
     (rc.*)::"repo_connections" AS workspace_connection
 FROM workspaces w
-JOIN workspace_permissions p USING (workspace_id)
 LEFT JOIN users ul ON w.lock_username = ul.username
 LEFT JOIN runs rl ON w.lock_run_id = rl.run_id
 LEFT JOIN runs r ON w.latest_run_id = r.run_id
 LEFT JOIN repo_connections rc ON w.workspace_id = rc.workspace_id
-JOIN teams t USING (team_id)
-JOIN team_memberships tm USING (team_id)
-JOIN users u ON tm.username = u.username
 WHERE w.organization_name  = pggen.arg('organization_name')
-AND   u.username           = pggen.arg('username')
+AND   COALESCE(w.project_id, '') LIKE pggen.arg('project_id')
+AND   w.workspace_id IN (
+    SELECT p.workspace_id
+    FROM workspace_permissions p
+    JOIN team_memberships tm USING (team_id)
+    WHERE tm.username = pggen.arg('username')
+    UNION
+    SELECT pw.workspace_id
+    FROM workspaces pw
+    JOIN project_permissions pp USING (project_id)
+    JOIN team_memberships tm USING (team_id)
+    WHERE tm.username = pggen.arg('username')
+)
 ORDER BY w.updated_at DESC
 LIMIT pggen.arg('limit')
 OFFSET pggen.arg('offset')
@@ -159,12 +178,20 @@ OFFSET pggen.arg('offset')
 -- name: CountWorkspacesByUsername :one
 SELECT count(*)
 FROM workspaces w
-JOIN workspace_permissions p USING (workspace_id)
-JOIN teams t USING (team_id)
-JOIN team_memberships tm USING (team_id)
-JOIN users u USING (username)
 WHERE w.organization_name = pggen.arg('organization_name')
-AND   u.username          = pggen.arg('username')
+AND   COALESCE(w.project_id, '') LIKE pggen.arg('project_id')
+AND   w.workspace_id IN (
+    SELECT p.workspace_id
+    FROM workspace_permissions p
+    JOIN team_memberships tm USING (team_id)
+    WHERE tm.username = pggen.arg('username')
+    UNION
+    SELECT pw.workspace_id
+    FROM workspaces pw
+    JOIN project_permissions pp USING (project_id)
+    JOIN team_memberships tm USING (team_id)
+    WHERE tm.username = pggen.arg('username')
+)
 ;
 
 -- name: FindWorkspaceByName :one
@@ -247,6 +274,9 @@ SET
     trigger_patterns              = pggen.arg('trigger_patterns'),
     vcs_tags_regex                = pggen.arg('vcs_tags_regex'),
     working_directory             = pggen.arg('working_directory'),
+    project_id                    = pggen.arg('project_id'),
+    test_pull_requests            = pggen.arg('test_pull_requests'),
+    comment_pull_requests         = pggen.arg('comment_pull_requests'),
     updated_at                    = pggen.arg('updated_at')
 WHERE workspace_id = pggen.arg('id')
 RETURNING workspace_id;
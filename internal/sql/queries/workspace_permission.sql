@@ -2,19 +2,40 @@
 INSERT INTO workspace_permissions (
     workspace_id,
     team_id,
-    role
-) SELECT w.workspace_id, t.team_id, pggen.arg('role')
+    role,
+    runs,
+    variables,
+    state_versions,
+    workspace_locking,
+    run_tasks
+) SELECT w.workspace_id, t.team_id, pggen.arg('role'),
+    pggen.arg('runs'),
+    pggen.arg('variables'),
+    pggen.arg('state_versions'),
+    pggen.arg('workspace_locking'),
+    pggen.arg('run_tasks')
     FROM teams t
     JOIN organizations o ON t.organization_name = o.name
     JOIN workspaces w ON w.organization_name = o.name
     WHERE t.name = pggen.arg('team_name')
     AND w.workspace_id = pggen.arg('workspace_id')
-ON CONFLICT (workspace_id, team_id) DO UPDATE SET role = pggen.arg('role')
+ON CONFLICT (workspace_id, team_id) DO UPDATE SET
+    role              = pggen.arg('role'),
+    runs              = pggen.arg('runs'),
+    variables         = pggen.arg('variables'),
+    state_versions    = pggen.arg('state_versions'),
+    workspace_locking = pggen.arg('workspace_locking'),
+    run_tasks         = pggen.arg('run_tasks')
 ;
 
 -- name: FindWorkspacePermissionsByWorkspaceID :many
 SELECT
     wp.role,
+    wp.runs,
+    wp.variables,
+    wp.state_versions,
+    wp.workspace_locking,
+    wp.run_tasks,
     (t.*)::"teams" AS team
 FROM workspace_permissions wp
 JOIN teams t USING (team_id)
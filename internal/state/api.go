@@ -1,9 +1,11 @@
 package state
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
 	otfapi "github.com/leg100/otf/internal/api"
 	"github.com/leg100/otf/internal/http/decode"
 	"github.com/leg100/otf/internal/resource"
@@ -31,6 +33,8 @@ func (a *api) addHandlers(r *mux.Router) {
 	r.HandleFunc("/state-versions/{id}/download", a.downloadState).Methods("GET")
 	r.HandleFunc("/state-versions/{id}/rollback", a.rollbackVersion).Methods("PATCH")
 	r.HandleFunc("/state-versions/{id}", a.deleteVersion).Methods("DELETE")
+
+	r.HandleFunc("/organizations/{organization_name}/state-resources", a.searchResources).Methods("GET")
 }
 
 func (a *api) listVersions(w http.ResponseWriter, r *http.Request) {
@@ -106,3 +110,23 @@ func (a *api) downloadState(w http.ResponseWriter, r *http.Request) {
 	}
 	w.Write(resp)
 }
+
+func (a *api) searchResources(w http.ResponseWriter, r *http.Request) {
+	var opts SearchResourcesOptions
+	if err := decode.All(&opts, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	page, err := a.SearchResources(r.Context(), opts)
+	if errors.Is(err, ErrNoSearchCriteria) {
+		tfeapi.Error(w, &internal.HTTPError{
+			Message: err.Error(),
+			Code:    http.StatusUnprocessableEntity,
+		})
+		return
+	} else if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.RespondWithPage(w, r, page.Items, page.Pagination)
+}
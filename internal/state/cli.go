@@ -37,6 +37,7 @@ func NewCommand(api *otfapi.Client) *cobra.Command {
 	cmd.AddCommand(cli.stateListCommand())
 	cmd.AddCommand(cli.stateDeleteCommand())
 	cmd.AddCommand(cli.stateDownloadCommand())
+	cmd.AddCommand(cli.stateSearchCommand())
 
 	return cmd
 }
@@ -149,3 +150,44 @@ func (a *CLI) stateRollbackCommand() *cobra.Command {
 		},
 	}
 }
+
+func (a *CLI) stateSearchCommand() *cobra.Command {
+	var opts SearchResourcesOptions
+	cmd := &cobra.Command{
+		Use:           "search",
+		Short:         "Search for resources across the current state of all workspaces",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			matches, err := resource.ListAll(func(pageOpts resource.PageOptions) (*resource.Page[*ResourceMatch], error) {
+				opts.PageOptions = pageOpts
+				return a.SearchResources(cmd.Context(), opts)
+			})
+			if err != nil {
+				return err
+			}
+			out := cmd.OutOrStdout()
+			if len(matches) == 0 {
+				fmt.Fprintln(out, "No resources found")
+				return nil
+			}
+			for _, m := range matches {
+				fmt.Fprintf(out, "%s %s", m.WorkspaceName, m.Address)
+				if m.Attribute != "" {
+					fmt.Fprintf(out, " (%s)", m.Attribute)
+				}
+				fmt.Fprintln(out)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Organization, "organization", "", "Name of the organization to search")
+	cmd.MarkFlagRequired("organization")
+
+	cmd.Flags().StringVar(&opts.Address, "address", "", "Match resources whose address contains this string")
+	cmd.Flags().StringVar(&opts.Type, "type", "", "Match resources of this type")
+	cmd.Flags().StringVar(&opts.Value, "value", "", "Match resources with an attribute with this value")
+
+	return cmd
+}
@@ -83,6 +83,22 @@ func TestCLI_State(t *testing.T) {
 		assert.JSONEq(t, string(want), got.String())
 	})
 
+	t.Run("search", func(t *testing.T) {
+		matches := []*ResourceMatch{
+			{WorkspaceName: "dev", Address: "aws_iam_role.deployer", Attribute: "arn"},
+			{WorkspaceName: "prod", Address: "aws_iam_role.deployer", Attribute: "arn"},
+		}
+		cmd := newFakeCLI(nil, withResourceMatches(matches)).stateSearchCommand()
+
+		cmd.SetArgs([]string{"--organization", "acme-corp", "--value", "arn:aws:iam::123456789012:role/deployer"})
+		got := bytes.Buffer{}
+		cmd.SetOut(&got)
+		require.NoError(t, cmd.Execute())
+
+		want := "dev aws_iam_role.deployer (arn)\nprod aws_iam_role.deployer (arn)\n"
+		assert.Equal(t, want, got.String())
+	})
+
 	t.Run("rollback", func(t *testing.T) {
 		sv := &Version{ID: "sv-456"}
 		cmd := newFakeCLI(nil, withStateVersion(sv)).stateRollbackCommand()
@@ -102,6 +118,7 @@ type (
 		stateVersionList *resource.Page[*Version]
 		state            []byte
 		workspace        *workspace.Workspace
+		matches          []*ResourceMatch
 
 		Service
 		workspace.WorkspaceService
@@ -136,6 +153,12 @@ func withState(state []byte) fakeCLIOption {
 	}
 }
 
+func withResourceMatches(matches []*ResourceMatch) fakeCLIOption {
+	return func(c *fakeCLIService) {
+		c.matches = matches
+	}
+}
+
 func (f *fakeCLIService) ListStateVersions(context.Context, string, resource.PageOptions) (*resource.Page[*Version], error) {
 	return f.stateVersionList, nil
 }
@@ -159,6 +182,10 @@ func (f *fakeCLIService) DownloadState(ctx context.Context, svID string) ([]byte
 	return f.state, nil
 }
 
+func (f *fakeCLIService) SearchResources(ctx context.Context, opts SearchResourcesOptions) (*resource.Page[*ResourceMatch], error) {
+	return resource.NewPage(f.matches, opts.PageOptions, nil), nil
+}
+
 func (f *fakeCLIService) GetWorkspaceByName(context.Context, string, string) (*workspace.Workspace, error) {
 	return f.workspace, nil
 }
@@ -119,3 +119,16 @@ func (c *Client) RollbackStateVersion(ctx context.Context, svID string) (*Versio
 	}
 	return &sv, nil
 }
+
+func (c *Client) SearchResources(ctx context.Context, opts SearchResourcesOptions) (*resource.Page[*ResourceMatch], error) {
+	u := fmt.Sprintf("organizations/%s/state-resources", url.QueryEscape(opts.Organization))
+	req, err := c.NewRequest("GET", u, &opts)
+	if err != nil {
+		return nil, err
+	}
+	var page resource.Page[*ResourceMatch]
+	if err := c.Do(ctx, req, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
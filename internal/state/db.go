@@ -3,6 +3,7 @@ package state
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/jackc/pgtype"
 	"github.com/jackc/pgx/v4"
@@ -190,3 +191,119 @@ func (db *pgdb) discardPending(ctx context.Context, workspaceID string) error {
 	}
 	return nil
 }
+
+// createResources indexes the resources of a state version.
+func (db *pgdb) createResources(ctx context.Context, svID string, resources []*indexedResource) error {
+	return db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		for _, r := range resources {
+			_, err := q.InsertStateVersionResource(ctx, pggen.InsertStateVersionResourceParams{
+				StateVersionID:  sql.String(svID),
+				Address:         sql.String(r.Address),
+				Mode:            sql.String(r.Mode),
+				Type:            sql.String(r.Type),
+				Provider:        sql.String(r.Provider),
+				Module:          sql.String(r.Module),
+				AttributePaths:  r.AttributePaths,
+				AttributeValues: r.AttributeValues,
+			})
+			if err != nil {
+				return sql.Error(err)
+			}
+		}
+		if _, err := q.UpdateStateVersionResourcesIndexed(ctx, sql.String(svID)); err != nil {
+			return sql.Error(err)
+		}
+		return nil
+	})
+}
+
+// unindexedState is the current state of a workspace whose resources are
+// yet to be indexed.
+type unindexedState struct {
+	StateVersionID string
+	State          []byte
+}
+
+// listUnindexedStates lists the current state of each workspace in an
+// organization whose resources are yet to be indexed.
+func (db *pgdb) listUnindexedStates(ctx context.Context, organization string) ([]unindexedState, error) {
+	rows, err := db.Conn(ctx).FindUnindexedCurrentStateVersionsByOrganization(ctx, sql.String(organization))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	states := make([]unindexedState, len(rows))
+	for i, r := range rows {
+		states[i] = unindexedState{
+			StateVersionID: r.StateVersionID.String,
+			State:          r.State,
+		}
+	}
+	return states, nil
+}
+
+// listStateWorkspaceIDs lists the IDs of workspaces in an organization that
+// have a current state version.
+func (db *pgdb) listStateWorkspaceIDs(ctx context.Context, organization string) ([]string, error) {
+	rows, err := db.Conn(ctx).FindCurrentStateWorkspaceIDsByOrganization(ctx, sql.String(organization))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	ids := make([]string, len(rows))
+	for i, r := range rows {
+		ids[i] = r.String
+	}
+	return ids, nil
+}
+
+// searchResources searches the indexed resources of the current state of
+// each workspace in an organization. If workspaceIDs is non-nil then the
+// search is restricted to those workspaces.
+func (db *pgdb) searchResources(ctx context.Context, opts SearchResourcesOptions, workspaceIDs []string) (*resource.Page[*ResourceMatch], error) {
+	q := db.Conn(ctx)
+	batch := &pgx.Batch{}
+
+	q.FindStateResourcesBatch(batch, pggen.FindStateResourcesParams{
+		OrganizationName: sql.String(opts.Organization),
+		WorkspaceIds:     workspaceIDs,
+		Address:          sql.String(opts.Address),
+		Type:             sql.String(opts.Type),
+		Value:            sql.String(opts.Value),
+		Limit:            opts.GetLimit(),
+		Offset:           opts.GetOffset(),
+	})
+	q.CountStateResourcesBatch(batch, pggen.CountStateResourcesParams{
+		OrganizationName: sql.String(opts.Organization),
+		WorkspaceIds:     workspaceIDs,
+		Address:          sql.String(opts.Address),
+		Type:             sql.String(opts.Type),
+		Value:            sql.String(opts.Value),
+	})
+
+	results := db.SendBatch(ctx, batch)
+	defer results.Close()
+
+	rows, err := q.FindStateResourcesScan(results)
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	count, err := q.CountStateResourcesScan(results)
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+
+	items := make([]*ResourceMatch, len(rows))
+	for i, r := range rows {
+		items[i] = &ResourceMatch{
+			ID:             fmt.Sprintf("%s/%s", r.WorkspaceID.String, r.Address.String),
+			WorkspaceID:    r.WorkspaceID.String,
+			WorkspaceName:  r.WorkspaceName.String,
+			StateVersionID: r.StateVersionID.String,
+			Address:        r.Address.String,
+			Type:           r.Type.String,
+			Provider:       r.Provider.String,
+			Module:         r.Module.String,
+			Attribute:      r.Attribute.String,
+		}
+	}
+	return resource.NewPage(items, opts.PageOptions, internal.Int64(count.Int)), nil
+}
@@ -2,6 +2,7 @@ package state
 
 import (
 	"encoding/json"
+	"fmt"
 	"regexp"
 	"strings"
 )
@@ -30,6 +31,15 @@ type (
 		ProviderURI string `json:"provider"`
 		Type        string
 		Module      string
+		Mode        string
+		Instances   []ResourceInstance
+	}
+
+	// ResourceInstance is an instance of a resource in the terraform state
+	// file
+	ResourceInstance struct {
+		IndexKey   any            `json:"index_key"`
+		Attributes map[string]any `json:"attributes"`
 	}
 )
 
@@ -49,6 +59,29 @@ func (r Resource) ModuleName() string {
 	return strings.TrimPrefix(r.Module, "module.")
 }
 
+// Address returns the address of the given instance of the resource, e.g.
+// module.vpc.aws_subnet.private[0]
+func (r Resource) Address(instance ResourceInstance) string {
+	var b strings.Builder
+	if r.Module != "" {
+		b.WriteString(r.Module)
+		b.WriteRune('.')
+	}
+	if r.Mode == "data" {
+		b.WriteString("data.")
+	}
+	b.WriteString(r.Type)
+	b.WriteRune('.')
+	b.WriteString(r.Name)
+	switch key := instance.IndexKey.(type) {
+	case float64:
+		fmt.Fprintf(&b, "[%d]", int(key))
+	case string:
+		fmt.Fprintf(&b, "[%q]", key)
+	}
+	return b.String()
+}
+
 // Type determines the HCL type of the output value
 func (r FileOutput) Type() (string, error) {
 	var dst any
@@ -0,0 +1,129 @@
+package state
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+
+	"github.com/leg100/otf/internal/resource"
+)
+
+var ErrNoSearchCriteria = errors.New("at least one of address, type or value must be specified")
+
+type (
+	// SearchResourcesOptions are options for searching for resources across
+	// the current state of every workspace in an organization.
+	SearchResourcesOptions struct {
+		Organization string `schema:"organization_name,required"`
+		// Address matches resources whose address contains the given string,
+		// e.g. aws_iam_role.deployer
+		Address string `schema:"address"`
+		// Type matches resources of the given type, e.g. aws_iam_role
+		Type string `schema:"type"`
+		// Value matches resources with an attribute with the given value,
+		// e.g. arn:aws:iam::123456789012:role/deployer
+		Value string `schema:"value"`
+
+		resource.PageOptions
+	}
+
+	// ResourceMatch is a resource found in the current state of a workspace.
+	ResourceMatch struct {
+		ID             string `jsonapi:"primary,state-resources"`
+		WorkspaceID    string `jsonapi:"attribute" json:"workspace-id"`
+		WorkspaceName  string `jsonapi:"attribute" json:"workspace-name"`
+		StateVersionID string `jsonapi:"attribute" json:"state-version-id"`
+		Address        string `jsonapi:"attribute" json:"address"`
+		Type           string `jsonapi:"attribute" json:"type"`
+		Provider       string `jsonapi:"attribute" json:"provider"`
+		Module         string `jsonapi:"attribute" json:"module"`
+		// Attribute is the path to the attribute whose value matched the
+		// search value. Only populated when searching by value.
+		Attribute string `jsonapi:"attribute" json:"attribute"`
+	}
+
+	// indexedResource is a resource instance in a state file, indexed for
+	// searching, so that searches need not parse entire state files.
+	indexedResource struct {
+		Address  string
+		Mode     string // managed or data
+		Type     string
+		Provider string
+		Module   string
+		// AttributePaths and AttributeValues are the paths and values of the
+		// instance's scalar attributes, e.g. tags.Name and "web". They are
+		// ordered depth-first, with object keys sorted lexically.
+		AttributePaths  []string
+		AttributeValues []string
+	}
+)
+
+func (opts SearchResourcesOptions) empty() bool {
+	return opts.Address == "" && opts.Type == "" && opts.Value == ""
+}
+
+// indexResources indexes the resource instances in a state file.
+func indexResources(f *File) []*indexedResource {
+	var indexed []*indexedResource
+	for _, res := range f.Resources {
+		instances := res.Instances
+		if len(instances) == 0 {
+			// a resource with no instances can still be matched by address
+			// or type
+			instances = []ResourceInstance{{}}
+		}
+		for _, inst := range instances {
+			ir := &indexedResource{
+				Address:         res.Address(inst),
+				Mode:            res.Mode,
+				Type:            res.Type,
+				Provider:        res.Provider(),
+				Module:          res.ModuleName(),
+				AttributePaths:  []string{},
+				AttributeValues: []string{},
+			}
+			flattenAttributes(inst.Attributes, "", ir)
+			indexed = append(indexed, ir)
+		}
+	}
+	return indexed
+}
+
+// flattenAttributes walks the attributes of a resource instance, adding the
+// path and value of each scalar attribute to the indexed resource. The path
+// of a nested attribute is delimited with periods, e.g. tags.Name, or
+// ingress.0.cidr_blocks.1
+func flattenAttributes(v any, path string, ir *indexedResource) {
+	join := func(elem string) string {
+		if path == "" {
+			return elem
+		}
+		return path + "." + elem
+	}
+	add := func(value string) {
+		ir.AttributePaths = append(ir.AttributePaths, path)
+		ir.AttributeValues = append(ir.AttributeValues, value)
+	}
+	switch v := v.(type) {
+	case map[string]any:
+		// sort keys to ensure deterministic results
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			flattenAttributes(v[k], join(k), ir)
+		}
+	case []any:
+		for i, elem := range v {
+			flattenAttributes(elem, join(strconv.Itoa(i)), ir)
+		}
+	case string:
+		add(v)
+	case float64:
+		add(strconv.FormatFloat(v, 'f', -1, 64))
+	case bool:
+		add(strconv.FormatBool(v))
+	}
+}
@@ -0,0 +1,65 @@
+package state
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/leg100/otf/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexResources(t *testing.T) {
+	var f File
+	err := json.Unmarshal(testutils.ReadFile(t, "./testdata/search.tfstate"), &f)
+	require.NoError(t, err)
+
+	want := []*indexedResource{
+		{
+			Address:         "aws_iam_role.deployer",
+			Mode:            "managed",
+			Type:            "aws_iam_role",
+			Provider:        "hashicorp/aws",
+			Module:          "root",
+			AttributePaths:  []string{"arn", "id", "max_session_duration", "tags.team"},
+			AttributeValues: []string{"arn:aws:iam::123456789012:role/deployer", "deployer", "3600", "platform"},
+		},
+		{
+			Address:         "data.aws_caller_identity.current",
+			Mode:            "data",
+			Type:            "aws_caller_identity",
+			Provider:        "hashicorp/aws",
+			Module:          "root",
+			AttributePaths:  []string{"account_id", "id"},
+			AttributeValues: []string{"123456789012", "123456789012"},
+		},
+		{
+			Address:         "module.dns.aws_route53_record.www[0]",
+			Mode:            "managed",
+			Type:            "aws_route53_record",
+			Provider:        "hashicorp/aws",
+			Module:          "dns",
+			AttributePaths:  []string{"fqdn", "records.0", "records.1"},
+			AttributeValues: []string{"www.example.com", "10.0.0.1", "10.0.0.2"},
+		},
+		{
+			Address:         "module.dns.aws_route53_record.www[1]",
+			Mode:            "managed",
+			Type:            "aws_route53_record",
+			Provider:        "hashicorp/aws",
+			Module:          "dns",
+			AttributePaths:  []string{"fqdn", "records.0"},
+			AttributeValues: []string{"www.example.org", "10.0.0.3"},
+		},
+		{
+			Address:         `null_resource.triggers["blue"]`,
+			Mode:            "managed",
+			Type:            "null_resource",
+			Provider:        "hashicorp/null",
+			Module:          "root",
+			AttributePaths:  []string{"id"},
+			AttributeValues: []string{"7261238710"},
+		},
+	}
+	assert.Equal(t, want, indexResources(&f))
+}
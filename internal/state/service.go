@@ -2,6 +2,7 @@ package state
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 
@@ -9,6 +10,8 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/leg100/otf/internal"
 	"github.com/leg100/otf/internal/http/html"
+	"github.com/leg100/otf/internal/organization"
+	"github.com/leg100/otf/internal/pubsub"
 	"github.com/leg100/otf/internal/rbac"
 	"github.com/leg100/otf/internal/resource"
 	"github.com/leg100/otf/internal/sql"
@@ -49,18 +52,22 @@ type (
 		// DownloadState downloads the state data for a state version.
 		DownloadState(ctx context.Context, versionID string) ([]byte, error)
 		GetStateVersionOutput(ctx context.Context, outputID string) (*Output, error)
+		// SearchResources searches the current state of every workspace in
+		// an organization for resources matching the given options.
+		SearchResources(ctx context.Context, opts SearchResourcesOptions) (*resource.Page[*ResourceMatch], error)
 	}
 
 	// service provides access to state and state versions
 	service struct {
 		logr.Logger
 
-		db        *pgdb
-		cache     internal.Cache // cache state file
-		workspace internal.Authorizer
-		web       *webHandlers
-		tfeapi    *tfe
-		api       *api
+		db           *pgdb
+		cache        internal.Cache // cache state file
+		workspace    internal.Authorizer
+		organization internal.Authorizer
+		web          *webHandlers
+		tfeapi       *tfe
+		api          *api
 
 		*factory // for creating state versions
 	}
@@ -74,6 +81,7 @@ type (
 		internal.Cache
 		workspace.WorkspaceService
 		*sql.DB
+		*pubsub.Broker
 		*tfeapi.Responder
 		*surl.Signer
 	}
@@ -89,11 +97,12 @@ type (
 func NewService(opts Options) *service {
 	db := &pgdb{opts.DB}
 	svc := service{
-		Logger:    opts.Logger,
-		cache:     opts.Cache,
-		db:        db,
-		workspace: opts.WorkspaceAuthorizer,
-		factory:   &factory{db},
+		Logger:       opts.Logger,
+		cache:        opts.Cache,
+		db:           db,
+		workspace:    opts.WorkspaceAuthorizer,
+		organization: &organization.Authorizer{Logger: opts.Logger},
+		factory:      &factory{db},
 	}
 	svc.web = &webHandlers{
 		Renderer: opts.Renderer,
@@ -110,6 +119,8 @@ func NewService(opts Options) *service {
 		Responder: opts.Responder,
 		tfeapi:    svc.tfeapi,
 	}
+	// Register with broker so that it can relay state version events
+	opts.Broker.Register("state_versions", &svc)
 	// include state version outputs in api responses when requested.
 	opts.Responder.Register(tfeapi.IncludeOutputs, svc.tfeapi.includeOutputs)
 	opts.Responder.Register(tfeapi.IncludeOutputs, svc.tfeapi.includeWorkspaceCurrentOutputs)
@@ -145,6 +156,14 @@ func (a *service) CreateStateVersion(ctx context.Context, opts CreateStateVersio
 	return sv, nil
 }
 
+// GetByID implements pubsub.Getter
+func (a *service) GetByID(ctx context.Context, svID string, action pubsub.DBAction) (any, error) {
+	if action == pubsub.DeleteDBAction {
+		return &Version{ID: svID}, nil
+	}
+	return a.db.getVersion(ctx, svID)
+}
+
 func (a *service) DownloadCurrentState(ctx context.Context, workspaceID string) ([]byte, error) {
 	v, err := a.GetCurrentStateVersion(ctx, workspaceID)
 	if err != nil {
@@ -295,6 +314,72 @@ func (a *service) GetStateVersionOutput(ctx context.Context, outputID string) (*
 	return out, nil
 }
 
+func (a *service) SearchResources(ctx context.Context, opts SearchResourcesOptions) (*resource.Page[*ResourceMatch], error) {
+	if opts.empty() {
+		return nil, ErrNoSearchCriteria
+	}
+	subject, err := a.organization.CanAccess(ctx, rbac.SearchStateResourcesAction, opts.Organization)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.IndexResources(ctx, opts.Organization); err != nil {
+		a.Error(err, "indexing state resources", "organization", opts.Organization, "subject", subject)
+		return nil, err
+	}
+
+	// subjects without organization-wide access to state are restricted to
+	// searching those workspaces to which they have been granted access.
+	var workspaceIDs []string
+	if !subject.CanAccessOrganization(rbac.GetStateVersionAction, opts.Organization) {
+		candidates, err := a.db.listStateWorkspaceIDs(ctx, opts.Organization)
+		if err != nil {
+			return nil, err
+		}
+		// non-nil slice ensures search is restricted to zero workspaces if
+		// the subject cannot access any workspaces.
+		workspaceIDs = []string{}
+		for _, id := range candidates {
+			_, err := a.workspace.CanAccess(ctx, rbac.GetStateVersionAction, id)
+			if errors.Is(err, internal.ErrAccessNotPermitted) {
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+			workspaceIDs = append(workspaceIDs, id)
+		}
+	}
+
+	page, err := a.db.searchResources(ctx, opts, workspaceIDs)
+	if err != nil {
+		a.Error(err, "searching state resources", "organization", opts.Organization, "subject", subject)
+		return nil, err
+	}
+	a.V(9).Info("searched state resources", "organization", opts.Organization, "matches", len(page.Items), "subject", subject)
+	return page, nil
+}
+
+// IndexResources indexes the resources of the current state of any workspace
+// in the organization that has yet to be indexed, i.e. state created before
+// resources were indexed upon upload.
+func (a *service) IndexResources(ctx context.Context, organization string) error {
+	states, err := a.db.listUnindexedStates(ctx, organization)
+	if err != nil {
+		return err
+	}
+	for _, state := range states {
+		var f File
+		if err := json.Unmarshal(state.State, &f); err != nil {
+			// index unparseable state as having no resources rather than fail
+			// the entire search
+			a.Error(err, "parsing state file", "state_version", state.StateVersionID)
+		}
+		if err := a.db.createResources(ctx, state.StateVersionID, indexResources(&f)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (a *service) CanAccessStateVersion(ctx context.Context, action rbac.Action, svID string) (internal.Subject, error) {
 	sv, err := a.db.getVersion(ctx, svID)
 	if err != nil {
@@ -49,3 +49,7 @@ func (f *fakeDB) updateCurrentVersion(ctx context.Context, workspaceID, svID str
 func (f *fakeDB) uploadStateAndFinalize(ctx context.Context, svID string, state []byte) error {
 	return nil
 }
+
+func (f *fakeDB) createResources(ctx context.Context, svID string, resources []*indexedResource) error {
+	return nil
+}
@@ -74,6 +74,7 @@ type (
 		getCurrentVersion(ctx context.Context, workspaceID string) (*Version, error)
 		updateCurrentVersion(context.Context, string, string) error
 		uploadStateAndFinalize(ctx context.Context, svID string, state []byte) error
+		createResources(ctx context.Context, svID string, resources []*indexedResource) error
 		discardPending(ctx context.Context, workspaceID string) error
 	}
 )
@@ -173,6 +174,9 @@ func (f *factory) uploadStateAndOutputs(ctx context.Context, sv *Version, state
 		if err := f.db.uploadStateAndFinalize(ctx, sv.ID, state); err != nil {
 			return err
 		}
+		if err := f.db.createResources(ctx, sv.ID, indexResources(&file)); err != nil {
+			return fmt.Errorf("indexing resources: %w", err)
+		}
 		if err := f.db.discardPending(ctx, sv.WorkspaceID); err != nil {
 			return err
 		}
@@ -6,6 +6,8 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/leg100/otf/internal/http/decode"
 	"github.com/leg100/otf/internal/http/html"
+	"github.com/leg100/otf/internal/organization"
+	"github.com/leg100/otf/internal/resource"
 )
 
 type webHandlers struct {
@@ -17,6 +19,7 @@ func (h *webHandlers) addHandlers(r *mux.Router) {
 	r = html.UIRouter(r)
 
 	r.HandleFunc("/workspaces/{workspace_id}/state", h.getState).Methods("GET")
+	r.HandleFunc("/organizations/{organization_name}/resources", h.searchResources).Methods("GET")
 }
 
 func (h *webHandlers) getState(w http.ResponseWriter, r *http.Request) {
@@ -37,3 +40,34 @@ func (h *webHandlers) getState(w http.ResponseWriter, r *http.Request) {
 		h.Error(w, err.Error(), http.StatusInternalServerError)
 	}
 }
+
+func (h *webHandlers) searchResources(w http.ResponseWriter, r *http.Request) {
+	var opts SearchResourcesOptions
+	if err := decode.All(&opts, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	// only search once the user has provided some criteria
+	page := &resource.Page[*ResourceMatch]{}
+	if !opts.empty() {
+		var err error
+		page, err = h.SearchResources(r.Context(), opts)
+		if err != nil {
+			h.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	h.Render("state_resources.tmpl", w, struct {
+		organization.OrganizationPage
+		*resource.Page[*ResourceMatch]
+		Search   SearchResourcesOptions
+		Searched bool
+	}{
+		OrganizationPage: organization.NewPage(r, "resources", opts.Organization),
+		Page:             page,
+		Search:           opts,
+		Searched:         !opts.empty(),
+	})
+}
@@ -0,0 +1,144 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+	otfapi "github.com/leg100/otf/internal/api"
+	"github.com/leg100/otf/internal/http/decode"
+	"github.com/leg100/otf/internal/pubsub"
+	"github.com/leg100/otf/internal/tfeapi"
+)
+
+const (
+	// websocketWriteTimeout is the maximum time permitted to write a message
+	// to a websocket client.
+	websocketWriteTimeout = 10 * time.Second
+	// websocketPingInterval is the interval between pinging websocket clients
+	// to keep the connection alive.
+	websocketPingInterval = 30 * time.Second
+)
+
+type api struct {
+	logr.Logger
+
+	svc      Service
+	upgrader websocket.Upgrader
+}
+
+func (a *api) addHandlers(r *mux.Router) {
+	r = r.PathPrefix(otfapi.DefaultBasePath).Subrouter()
+
+	r.HandleFunc("/organizations/{organization_name}/events", a.stream).Methods("GET")
+}
+
+// stream responds with a stream of events, either via a websocket if the
+// client requests an upgrade, or otherwise via server-sent events.
+func (a *api) stream(w http.ResponseWriter, r *http.Request) {
+	var opts StreamOptions
+	if err := decode.All(&opts, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	if websocket.IsWebSocketUpgrade(r) {
+		a.streamWebsocket(w, r, opts)
+	} else {
+		a.streamSSE(w, r, opts)
+	}
+}
+
+func (a *api) streamSSE(w http.ResponseWriter, r *http.Request, opts StreamOptions) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming unsupported!", http.StatusInternalServerError)
+		return
+	}
+	// a reconnecting client sends the ID of the last event it received in a
+	// header, which takes precedence over the query parameter.
+	if id := pubsub.LastEventID(r); id != 0 {
+		opts.LastEventID = id
+	}
+
+	events, err := a.svc.Stream(r.Context(), opts)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, "\r\n")
+	flusher.Flush()
+
+	for event := range events {
+		b, err := json.Marshal(event)
+		if err != nil {
+			a.Error(err, "marshalling event", "event", event.Type)
+			continue
+		}
+		pubsub.WriteSSEEventWithID(w, event.ID, b, pubsub.EventType(event.Type), false)
+		flusher.Flush()
+	}
+}
+
+func (a *api) streamWebsocket(w http.ResponseWriter, r *http.Request, opts StreamOptions) {
+	// The request context is not canceled when a hijacked connection is
+	// closed, so cancel it upon detecting the client has gone away.
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	events, err := a.svc.Stream(ctx, opts)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	conn, err := a.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// upgrader has already responded with an error
+		return
+	}
+	defer conn.Close()
+
+	// read and discard messages from the client, which is necessary to
+	// process control messages and to detect the connection closing.
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(websocketPingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(websocketWriteTimeout))
+				return
+			}
+			conn.SetWriteDeadline(time.Now().Add(websocketWriteTimeout))
+			if err := conn.WriteJSON(event); err != nil {
+				a.V(2).Info("writing event to websocket", "error", err.Error())
+				return
+			}
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(websocketWriteTimeout)); err != nil {
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
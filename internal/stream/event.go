@@ -0,0 +1,92 @@
+package stream
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/leg100/otf/internal/run"
+	"github.com/leg100/otf/internal/variable"
+)
+
+const (
+	RunCreated          Type = "run.created"
+	RunStatusChanged    Type = "run.status_changed"
+	WorkspaceCreated    Type = "workspace.created"
+	WorkspaceDeleted    Type = "workspace.deleted"
+	WorkspaceLocked     Type = "workspace.locked"
+	WorkspaceUnlocked   Type = "workspace.unlocked"
+	StateVersionCreated Type = "state_version.created"
+	VariableCreated     Type = "variable.created"
+	VariableUpdated     Type = "variable.updated"
+	VariableDeleted     Type = "variable.deleted"
+)
+
+// Types lists all event types.
+var Types = []Type{
+	RunCreated,
+	RunStatusChanged,
+	WorkspaceCreated,
+	WorkspaceDeleted,
+	WorkspaceLocked,
+	WorkspaceUnlocked,
+	StateVersionCreated,
+	VariableCreated,
+	VariableUpdated,
+	VariableDeleted,
+}
+
+type (
+	// Type is the type of a streamed event.
+	Type string
+
+	// Event is an event streamed to clients.
+	Event struct {
+		// ID is the position of the event in the event log, which clients
+		// send upon reconnecting in order to receive events they have missed.
+		// Zero if the event was not logged.
+		ID           int64     `json:"id"`
+		Type         Type      `json:"type"`
+		Organization string    `json:"organization"`
+		WorkspaceID  string    `json:"workspace_id"`
+		Timestamp    time.Time `json:"timestamp"`
+		Payload      any       `json:"payload"`
+	}
+
+	RunPayload struct {
+		ID     string     `json:"id"`
+		Status run.Status `json:"status"`
+		// PreviousStatus is the status of the run prior to the transition, if
+		// known.
+		PreviousStatus run.Status `json:"previous_status,omitempty"`
+	}
+
+	WorkspacePayload struct {
+		ID     string `json:"id"`
+		Name   string `json:"name"`
+		Locked bool   `json:"locked"`
+	}
+
+	StateVersionPayload struct {
+		ID     string `json:"id"`
+		Serial int64  `json:"serial"`
+	}
+
+	// VariablePayload describes a workspace variable. The variable's value is
+	// never included.
+	VariablePayload struct {
+		ID        string                    `json:"id"`
+		Key       string                    `json:"key,omitempty"`
+		Category  variable.VariableCategory `json:"category,omitempty"`
+		Sensitive bool                      `json:"sensitive"`
+		HCL       bool                      `json:"hcl"`
+	}
+)
+
+func (t Type) valid() error {
+	for _, valid := range Types {
+		if t == valid {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid event type: %s", t)
+}
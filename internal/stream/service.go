@@ -0,0 +1,150 @@
+// Package stream provides clients with a stream of events occurring within an
+// organization.
+package stream
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/organization"
+	"github.com/leg100/otf/internal/pubsub"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/workspace"
+)
+
+type (
+	StreamService = Service
+
+	Service interface {
+		// Stream provides a stream of events occurring within an organization,
+		// filtered according to the options and to the caller's permissions.
+		Stream(ctx context.Context, opts StreamOptions) (<-chan *Event, error)
+	}
+
+	service struct {
+		logr.Logger
+
+		broker       pubsub.ResumableSubscriber
+		workspaces   workspace.Service
+		organization internal.Authorizer
+		api          *api
+	}
+
+	Options struct {
+		logr.Logger
+
+		Broker           pubsub.ResumableSubscriber
+		WorkspaceService workspace.Service
+	}
+
+	// StreamOptions are options for streaming events. An event must satisfy
+	// every filter that is specified, and if more than one value is specified
+	// for a filter then an event need only match one of them.
+	StreamOptions struct {
+		Organization string `schema:"organization_name,required"`
+		// Filter by workspace ID
+		WorkspaceIDs []string `schema:"workspace_id"`
+		// Filter by workspace tag
+		Tags []string `schema:"tag"`
+		// Filter by event type
+		Types []Type `schema:"type"`
+		// Replay events with an ID greater than LastEventID before streaming
+		// new events. Zero means no events are replayed.
+		LastEventID int64 `schema:"last_event_id"`
+	}
+
+	// superuserClient retrieves workspaces and their policies on behalf of a
+	// stream, without checking the caller's permissions; the stream instead
+	// checks the caller's permissions before sending each event.
+	superuserClient struct {
+		workspace.Service
+	}
+)
+
+func NewService(opts Options) *service {
+	svc := service{
+		Logger:       opts.Logger,
+		broker:       opts.Broker,
+		workspaces:   opts.WorkspaceService,
+		organization: &organization.Authorizer{Logger: opts.Logger},
+	}
+	svc.api = &api{
+		Logger: opts.Logger,
+		svc:    &svc,
+	}
+	return &svc
+}
+
+func (s *service) AddHandlers(r *mux.Router) {
+	s.api.addHandlers(r)
+}
+
+func (s *service) Stream(ctx context.Context, opts StreamOptions) (<-chan *Event, error) {
+	for _, t := range opts.Types {
+		if err := t.valid(); err != nil {
+			return nil, &internal.HTTPError{Code: http.StatusUnprocessableEntity, Message: err.Error()}
+		}
+	}
+	// caller must be a member of the organization; access to individual
+	// events is then determined by the caller's workspace permissions.
+	subject, err := s.organization.CanAccess(ctx, rbac.GetOrganizationAction, opts.Organization)
+	if err != nil {
+		return nil, err
+	}
+
+	// Subscribe first and only then retrieve workspaces, guaranteeing that
+	// changes to workspaces are not missed.
+	sub, err := s.broker.SubscribeFrom(ctx, "stream-", opts.LastEventID)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &superuserClient{s.workspaces}
+	workspaces, err := resource.ListAll(func(pageOpts resource.PageOptions) (*resource.Page[*workspace.Workspace], error) {
+		return s.workspaces.ListWorkspaces(client.context(ctx), workspace.ListOptions{
+			Organization: &opts.Organization,
+			PageOptions:  pageOpts,
+		})
+	})
+	if err != nil {
+		s.Error(err, "retrieving workspaces for event stream", "organization", opts.Organization, "subject", subject)
+		return nil, err
+	}
+	stream := newStream(subject, opts, client, workspaces)
+
+	// relay is returned to the caller to which typed events are sent
+	relay := make(chan *Event)
+	go func() {
+		defer close(relay)
+
+		for ev := range sub {
+			event := stream.convert(ctx, ev)
+			if event == nil {
+				continue
+			}
+			select {
+			case relay <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	s.V(2).Info("streaming events", "organization", opts.Organization, "subject", subject)
+	return relay, nil
+}
+
+func (c *superuserClient) context(ctx context.Context) context.Context {
+	return internal.AddSubjectToContext(ctx, &internal.Superuser{Username: "event-stream"})
+}
+
+func (c *superuserClient) getWorkspace(ctx context.Context, workspaceID string) (*workspace.Workspace, error) {
+	return c.GetWorkspace(c.context(ctx), workspaceID)
+}
+
+func (c *superuserClient) getPolicy(ctx context.Context, workspaceID string) (internal.WorkspacePolicy, error) {
+	return c.GetPolicy(ctx, workspaceID)
+}
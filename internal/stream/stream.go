@@ -0,0 +1,330 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/pubsub"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/run"
+	"github.com/leg100/otf/internal/state"
+	"github.com/leg100/otf/internal/variable"
+	"github.com/leg100/otf/internal/workspace"
+)
+
+// policyTTL is how long a workspace policy is cached by a stream before it is
+// retrieved again, bounding how long a stream continues to send events to a
+// subject whose permissions have since been revoked.
+const policyTTL = 30 * time.Second
+
+type (
+	// stream converts pubsub events into typed events for a single client,
+	// filtering out those the client has not asked for or is not permitted to
+	// see.
+	stream struct {
+		subject internal.Subject
+		opts    StreamOptions
+		client  workspaceClient
+
+		// workspaces in the organization, keyed by ID, maintaining their
+		// latest known state in order to detect changes to their lock.
+		workspaces map[string]*workspace.Workspace
+		// IDs of workspaces that belong to other organizations
+		foreign map[string]bool
+		// last known status of runs in progress, keyed by run ID
+		runs map[string]run.Status
+		// workspace policies, keyed by workspace ID
+		policies map[string]cachedPolicy
+	}
+
+	// runSnapshot is the state of a run at the time of an event.
+	runSnapshot struct {
+		Status run.Status `json:"status"`
+		// PreviousStatus is only set for updates.
+		PreviousStatus run.Status `json:"previous_status"`
+	}
+
+	// workspaceSnapshot is the state of a workspace at the time of an event.
+	workspaceSnapshot struct {
+		Locked bool `json:"locked"`
+		// PreviousLocked is only set for updates.
+		PreviousLocked *bool `json:"previous_locked"`
+	}
+
+	cachedPolicy struct {
+		internal.WorkspacePolicy
+		expiry time.Time
+	}
+
+	// workspaceClient retrieves workspaces and their policies, without
+	// checking the caller's permissions.
+	workspaceClient interface {
+		getWorkspace(ctx context.Context, workspaceID string) (*workspace.Workspace, error)
+		getPolicy(ctx context.Context, workspaceID string) (internal.WorkspacePolicy, error)
+	}
+)
+
+func newStream(subject internal.Subject, opts StreamOptions, client workspaceClient, workspaces []*workspace.Workspace) *stream {
+	s := &stream{
+		subject:    subject,
+		opts:       opts,
+		client:     client,
+		workspaces: make(map[string]*workspace.Workspace, len(workspaces)),
+		foreign:    make(map[string]bool),
+		runs:       make(map[string]run.Status),
+		policies:   make(map[string]cachedPolicy),
+	}
+	for _, ws := range workspaces {
+		s.workspaces[ws.ID] = ws
+	}
+	return s
+}
+
+// convert a pubsub event into a typed event, returning nil if the event is to
+// be skipped.
+func (s *stream) convert(ctx context.Context, event pubsub.Event) *Event {
+	switch payload := event.Payload.(type) {
+	case *run.Run:
+		return s.convertRun(ctx, event, payload)
+	case *workspace.Workspace:
+		return s.convertWorkspace(ctx, event, payload)
+	case *state.Version:
+		if event.Type == pubsub.DeletedEvent || payload.Status != state.Finalized {
+			return nil
+		}
+		ws := s.workspace(ctx, payload.WorkspaceID)
+		if ws == nil {
+			return nil
+		}
+		return s.filter(ctx, event, StateVersionCreated, ws, rbac.GetStateVersionAction, StateVersionPayload{
+			ID:     payload.ID,
+			Serial: payload.Serial,
+		})
+	case *variable.WorkspaceVariable:
+		ws := s.workspace(ctx, payload.WorkspaceID)
+		if ws == nil {
+			return nil
+		}
+		var t Type
+		switch event.Type {
+		case pubsub.CreatedEvent:
+			t = VariableCreated
+		case pubsub.UpdatedEvent:
+			t = VariableUpdated
+		case pubsub.DeletedEvent:
+			t = VariableDeleted
+		}
+		return s.filter(ctx, event, t, ws, rbac.ListWorkspaceVariablesAction, VariablePayload{
+			ID:        payload.ID,
+			Key:       payload.Key,
+			Category:  payload.Category,
+			Sensitive: payload.Sensitive,
+			HCL:       payload.HCL,
+		})
+	default:
+		return nil
+	}
+}
+
+func (s *stream) convertRun(ctx context.Context, event pubsub.Event, r *run.Run) *Event {
+	if r.Organization != s.opts.Organization {
+		return nil
+	}
+	// the payload of a replayed event carries the current state of the run
+	// rather than its state at the time of the event, so use the snapshot
+	// taken at the time of the event where available.
+	var snapshot runSnapshot
+	if decodeSnapshot(event, &snapshot) {
+		return s.convertRunStatus(ctx, event, r, snapshot.Status, snapshot.PreviousStatus, snapshot.PreviousStatus != "")
+	}
+	previous, known := s.runs[r.ID]
+	if event.Type == pubsub.DeletedEvent || r.Done() {
+		delete(s.runs, r.ID)
+	} else {
+		s.runs[r.ID] = r.Status
+	}
+	return s.convertRunStatus(ctx, event, r, r.Status, previous, known)
+}
+
+func (s *stream) convertRunStatus(ctx context.Context, event pubsub.Event, r *run.Run, status, previous run.Status, known bool) *Event {
+	payload := RunPayload{ID: r.ID, Status: status}
+	var t Type
+	switch event.Type {
+	case pubsub.CreatedEvent:
+		t = RunCreated
+	case pubsub.UpdatedEvent:
+		if known && previous == status {
+			// not a status transition
+			return nil
+		}
+		t = RunStatusChanged
+		payload.PreviousStatus = previous
+	default:
+		return nil
+	}
+	ws := s.workspace(ctx, r.WorkspaceID)
+	if ws == nil {
+		return nil
+	}
+	return s.filter(ctx, event, t, ws, rbac.GetRunAction, payload)
+}
+
+func (s *stream) convertWorkspace(ctx context.Context, event pubsub.Event, ws *workspace.Workspace) *Event {
+	previous, known := s.workspaces[ws.ID]
+	locked := ws.Locked()
+	// as with runs, use the snapshot taken at the time of the event where
+	// available.
+	var snapshot workspaceSnapshot
+	hasSnapshot := decodeSnapshot(event, &snapshot)
+	if hasSnapshot {
+		locked = snapshot.Locked
+	}
+	var t Type
+	switch event.Type {
+	case pubsub.CreatedEvent:
+		if ws.Organization != s.opts.Organization {
+			return nil
+		}
+		t = WorkspaceCreated
+	case pubsub.UpdatedEvent:
+		if hasSnapshot && snapshot.PreviousLocked != nil {
+			if ws.Organization != s.opts.Organization {
+				return nil
+			}
+			s.workspaces[ws.ID] = ws
+			if *snapshot.PreviousLocked == locked {
+				return nil
+			}
+		} else {
+			if !known {
+				if ws.Organization == s.opts.Organization {
+					s.workspaces[ws.ID] = ws
+				}
+				return nil
+			}
+			if previous.Locked() == locked {
+				s.workspaces[ws.ID] = ws
+				return nil
+			}
+		}
+		t = WorkspaceUnlocked
+		if locked {
+			t = WorkspaceLocked
+		}
+	case pubsub.DeletedEvent:
+		if !known {
+			return nil
+		}
+		delete(s.workspaces, ws.ID)
+		// the payload of a deleted event only contains the ID, so use the
+		// last known state of the workspace instead.
+		ws = previous
+		locked = ws.Locked()
+		t = WorkspaceDeleted
+	default:
+		return nil
+	}
+	if t != WorkspaceDeleted {
+		s.workspaces[ws.ID] = ws
+	}
+	return s.filter(ctx, event, t, ws, rbac.GetWorkspaceAction, WorkspacePayload{
+		ID:     ws.ID,
+		Name:   ws.Name,
+		Locked: locked,
+	})
+}
+
+// filter applies the client's filters and permissions to an event, returning
+// the typed event if it passes, or nil if it does not.
+func (s *stream) filter(ctx context.Context, event pubsub.Event, t Type, ws *workspace.Workspace, action rbac.Action, payload any) *Event {
+	if len(s.opts.Types) > 0 && !slices.Contains(s.opts.Types, t) {
+		return nil
+	}
+	if len(s.opts.WorkspaceIDs) > 0 && !slices.Contains(s.opts.WorkspaceIDs, ws.ID) {
+		return nil
+	}
+	if len(s.opts.Tags) > 0 && !slices.ContainsFunc(ws.Tags, func(tag string) bool {
+		return slices.Contains(s.opts.Tags, tag)
+	}) {
+		return nil
+	}
+	if !s.subject.CanAccessWorkspace(action, s.policy(ctx, ws.ID)) {
+		return nil
+	}
+	return &Event{
+		ID:           event.ID,
+		Type:         t,
+		Organization: s.opts.Organization,
+		WorkspaceID:  ws.ID,
+		Timestamp:    timestamp(event),
+		Payload:      payload,
+	}
+}
+
+// timestamp returns the time of an event: the time it was logged, or if it
+// was not logged, the current time.
+func timestamp(event pubsub.Event) time.Time {
+	if event.Time.IsZero() {
+		return internal.CurrentTimestamp(nil)
+	}
+	return event.Time
+}
+
+// decodeSnapshot decodes the snapshot of a resource taken at the time of an
+// event into v, returning false if the event has no snapshot.
+func decodeSnapshot(event pubsub.Event, v any) bool {
+	if len(event.Snapshot) == 0 {
+		return false
+	}
+	return json.Unmarshal(event.Snapshot, v) == nil
+}
+
+// workspace retrieves a workspace in the organization, returning nil if the
+// workspace cannot be found or belongs to another organization.
+func (s *stream) workspace(ctx context.Context, workspaceID string) *workspace.Workspace {
+	if ws, ok := s.workspaces[workspaceID]; ok {
+		return ws
+	}
+	if s.foreign[workspaceID] {
+		return nil
+	}
+	ws, err := s.client.getWorkspace(ctx, workspaceID)
+	if err != nil {
+		return nil
+	}
+	if ws.Organization != s.opts.Organization {
+		s.foreign[workspaceID] = true
+		return nil
+	}
+	s.workspaces[workspaceID] = ws
+	return ws
+}
+
+// policy retrieves a workspace's policy, caching it for a period. If the
+// policy cannot be retrieved, e.g. because the workspace has been deleted,
+// then the last known policy is returned, or failing that a policy that only
+// permits access to subjects with organization-wide permissions.
+func (s *stream) policy(ctx context.Context, workspaceID string) internal.WorkspacePolicy {
+	cached, ok := s.policies[workspaceID]
+	if ok && time.Now().Before(cached.expiry) {
+		return cached.WorkspacePolicy
+	}
+	policy, err := s.client.getPolicy(ctx, workspaceID)
+	if err != nil {
+		if ok {
+			return cached.WorkspacePolicy
+		}
+		return internal.WorkspacePolicy{
+			Organization: s.opts.Organization,
+			WorkspaceID:  workspaceID,
+		}
+	}
+	s.policies[workspaceID] = cachedPolicy{
+		WorkspacePolicy: policy,
+		expiry:          time.Now().Add(policyTTL),
+	}
+	return policy
+}
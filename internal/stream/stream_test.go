@@ -0,0 +1,265 @@
+package stream
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/pubsub"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/run"
+	"github.com/leg100/otf/internal/state"
+	"github.com/leg100/otf/internal/variable"
+	"github.com/leg100/otf/internal/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStream(t *testing.T) {
+	ctx := context.Background()
+	dev := &workspace.Workspace{ID: "ws-dev", Name: "dev", Organization: "acme", Tags: []string{"dev"}}
+	prod := &workspace.Workspace{ID: "ws-prod", Name: "prod", Organization: "acme", Tags: []string{"prod"}}
+	// subject is permitted access to both workspaces
+	subject := &fakeSubject{workspaces: []string{"ws-dev", "ws-prod"}}
+
+	t.Run("run status transitions", func(t *testing.T) {
+		s := newStream(subject, StreamOptions{Organization: "acme"}, &fakeClient{}, []*workspace.Workspace{dev})
+
+		r := &run.Run{ID: "run-1", Organization: "acme", WorkspaceID: "ws-dev", Status: run.RunPending}
+		got := s.convert(ctx, pubsub.Event{ID: 1, Type: pubsub.CreatedEvent, Payload: r})
+		require.NotNil(t, got)
+		assert.Equal(t, RunCreated, got.Type)
+		assert.Equal(t, int64(1), got.ID)
+		assert.Equal(t, "ws-dev", got.WorkspaceID)
+
+		// update without a change in status is skipped
+		got = s.convert(ctx, pubsub.Event{Type: pubsub.UpdatedEvent, Payload: r})
+		assert.Nil(t, got)
+
+		r = &run.Run{ID: "run-1", Organization: "acme", WorkspaceID: "ws-dev", Status: run.RunPlanQueued}
+		got = s.convert(ctx, pubsub.Event{Type: pubsub.UpdatedEvent, Payload: r})
+		require.NotNil(t, got)
+		assert.Equal(t, RunStatusChanged, got.Type)
+		assert.Equal(t, RunPayload{ID: "run-1", Status: run.RunPlanQueued, PreviousStatus: run.RunPending}, got.Payload)
+	})
+
+	t.Run("replayed run status transitions", func(t *testing.T) {
+		s := newStream(subject, StreamOptions{Organization: "acme"}, &fakeClient{}, []*workspace.Workspace{dev})
+
+		// the payload of a replayed event carries the current state of the
+		// run, whereas the snapshot carries its state at the time of the
+		// event.
+		current := &run.Run{ID: "run-1", Organization: "acme", WorkspaceID: "ws-dev", Status: run.RunApplied}
+		logged := time.Date(2023, 11, 23, 9, 0, 0, 0, time.UTC)
+
+		got := s.convert(ctx, pubsub.Event{
+			ID:       1,
+			Type:     pubsub.CreatedEvent,
+			Payload:  current,
+			Time:     logged,
+			Snapshot: []byte(`{"status":"pending"}`),
+		})
+		require.NotNil(t, got)
+		assert.Equal(t, RunPayload{ID: "run-1", Status: run.RunPending}, got.Payload)
+		assert.Equal(t, logged, got.Timestamp)
+
+		got = s.convert(ctx, pubsub.Event{
+			ID:       2,
+			Type:     pubsub.UpdatedEvent,
+			Payload:  current,
+			Time:     logged.Add(time.Second),
+			Snapshot: []byte(`{"status":"plan_queued","previous_status":"pending"}`),
+		})
+		require.NotNil(t, got)
+		assert.Equal(t, RunStatusChanged, got.Type)
+		assert.Equal(t, RunPayload{ID: "run-1", Status: run.RunPlanQueued, PreviousStatus: run.RunPending}, got.Payload)
+		assert.Equal(t, logged.Add(time.Second), got.Timestamp)
+
+		// update without a change in status is skipped
+		got = s.convert(ctx, pubsub.Event{
+			ID:       3,
+			Type:     pubsub.UpdatedEvent,
+			Payload:  current,
+			Snapshot: []byte(`{"status":"plan_queued","previous_status":"plan_queued"}`),
+		})
+		assert.Nil(t, got)
+	})
+
+	t.Run("replayed workspace lock changes", func(t *testing.T) {
+		s := newStream(subject, StreamOptions{Organization: "acme"}, &fakeClient{}, []*workspace.Workspace{dev})
+
+		// workspace is currently unlocked but was locked at the time of the
+		// event.
+		got := s.convert(ctx, pubsub.Event{
+			Type:     pubsub.UpdatedEvent,
+			Payload:  dev,
+			Snapshot: []byte(`{"locked":true,"previous_locked":false}`),
+		})
+		require.NotNil(t, got)
+		assert.Equal(t, WorkspaceLocked, got.Type)
+		assert.Equal(t, WorkspacePayload{ID: "ws-dev", Name: "dev", Locked: true}, got.Payload)
+
+		// update without a change to the lock is skipped
+		assert.Nil(t, s.convert(ctx, pubsub.Event{
+			Type:     pubsub.UpdatedEvent,
+			Payload:  dev,
+			Snapshot: []byte(`{"locked":true,"previous_locked":true}`),
+		}))
+	})
+
+	t.Run("skip run in another organization", func(t *testing.T) {
+		s := newStream(subject, StreamOptions{Organization: "acme"}, &fakeClient{}, []*workspace.Workspace{dev})
+
+		r := &run.Run{ID: "run-1", Organization: "other", WorkspaceID: "ws-other"}
+		assert.Nil(t, s.convert(ctx, pubsub.Event{Type: pubsub.CreatedEvent, Payload: r}))
+	})
+
+	t.Run("workspace lock changes", func(t *testing.T) {
+		s := newStream(subject, StreamOptions{Organization: "acme"}, &fakeClient{}, []*workspace.Workspace{dev})
+
+		locked := *dev
+		locked.Lock = &workspace.Lock{}
+		got := s.convert(ctx, pubsub.Event{Type: pubsub.UpdatedEvent, Payload: &locked})
+		require.NotNil(t, got)
+		assert.Equal(t, WorkspaceLocked, got.Type)
+
+		// update without a change to the lock is skipped
+		assert.Nil(t, s.convert(ctx, pubsub.Event{Type: pubsub.UpdatedEvent, Payload: &locked}))
+
+		got = s.convert(ctx, pubsub.Event{Type: pubsub.UpdatedEvent, Payload: dev})
+		require.NotNil(t, got)
+		assert.Equal(t, WorkspaceUnlocked, got.Type)
+	})
+
+	t.Run("workspace deleted", func(t *testing.T) {
+		s := newStream(subject, StreamOptions{Organization: "acme"}, &fakeClient{}, []*workspace.Workspace{dev})
+
+		got := s.convert(ctx, pubsub.Event{Type: pubsub.DeletedEvent, Payload: &workspace.Workspace{ID: "ws-dev"}})
+		require.NotNil(t, got)
+		assert.Equal(t, WorkspaceDeleted, got.Type)
+		assert.Equal(t, WorkspacePayload{ID: "ws-dev", Name: "dev"}, got.Payload)
+	})
+
+	t.Run("state version created once finalized", func(t *testing.T) {
+		s := newStream(subject, StreamOptions{Organization: "acme"}, &fakeClient{}, []*workspace.Workspace{dev})
+
+		sv := &state.Version{ID: "sv-1", Serial: 3, Status: state.Pending, WorkspaceID: "ws-dev"}
+		assert.Nil(t, s.convert(ctx, pubsub.Event{Type: pubsub.CreatedEvent, Payload: sv}))
+
+		sv = &state.Version{ID: "sv-1", Serial: 3, Status: state.Finalized, WorkspaceID: "ws-dev"}
+		got := s.convert(ctx, pubsub.Event{Type: pubsub.UpdatedEvent, Payload: sv})
+		require.NotNil(t, got)
+		assert.Equal(t, StateVersionCreated, got.Type)
+		assert.Equal(t, StateVersionPayload{ID: "sv-1", Serial: 3}, got.Payload)
+	})
+
+	t.Run("variable updated omits value", func(t *testing.T) {
+		s := newStream(subject, StreamOptions{Organization: "acme"}, &fakeClient{}, []*workspace.Workspace{dev})
+
+		v := &variable.WorkspaceVariable{
+			WorkspaceID: "ws-dev",
+			Variable:    &variable.Variable{ID: "var-1", Key: "foo", Value: "secret", Category: variable.CategoryTerraform},
+		}
+		got := s.convert(ctx, pubsub.Event{Type: pubsub.UpdatedEvent, Payload: v})
+		require.NotNil(t, got)
+		assert.Equal(t, VariableUpdated, got.Type)
+		assert.Equal(t, VariablePayload{ID: "var-1", Key: "foo", Category: variable.CategoryTerraform}, got.Payload)
+	})
+
+	t.Run("lookup workspace not yet known", func(t *testing.T) {
+		client := &fakeClient{workspaces: []*workspace.Workspace{prod}}
+		s := newStream(subject, StreamOptions{Organization: "acme"}, client, nil)
+
+		sv := &state.Version{ID: "sv-1", Status: state.Finalized, WorkspaceID: "ws-prod"}
+		got := s.convert(ctx, pubsub.Event{Type: pubsub.CreatedEvent, Payload: sv})
+		require.NotNil(t, got)
+		assert.Equal(t, "ws-prod", got.WorkspaceID)
+	})
+
+	tests := []struct {
+		name    string
+		subject internal.Subject
+		opts    StreamOptions
+		want    []string // IDs of workspaces of events expected to be sent
+	}{
+		{
+			name:    "no filters",
+			subject: subject,
+			opts:    StreamOptions{Organization: "acme"},
+			want:    []string{"ws-dev", "ws-prod"},
+		},
+		{
+			name:    "filter by workspace",
+			subject: subject,
+			opts:    StreamOptions{Organization: "acme", WorkspaceIDs: []string{"ws-prod"}},
+			want:    []string{"ws-prod"},
+		},
+		{
+			name:    "filter by tag",
+			subject: subject,
+			opts:    StreamOptions{Organization: "acme", Tags: []string{"dev", "staging"}},
+			want:    []string{"ws-dev"},
+		},
+		{
+			name:    "filter by type",
+			subject: subject,
+			opts:    StreamOptions{Organization: "acme", Types: []Type{WorkspaceLocked}},
+			want:    nil,
+		},
+		{
+			name:    "permitted access to one workspace",
+			subject: &fakeSubject{workspaces: []string{"ws-dev"}},
+			opts:    StreamOptions{Organization: "acme"},
+			want:    []string{"ws-dev"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newStream(tt.subject, tt.opts, &fakeClient{}, []*workspace.Workspace{dev, prod})
+
+			var got []string
+			for _, ws := range []*workspace.Workspace{dev, prod} {
+				r := &run.Run{ID: "run-" + ws.ID, Organization: "acme", WorkspaceID: ws.ID}
+				if event := s.convert(ctx, pubsub.Event{Type: pubsub.CreatedEvent, Payload: r}); event != nil {
+					got = append(got, event.WorkspaceID)
+				}
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+type (
+	fakeSubject struct {
+		internal.Subject
+
+		workspaces []string // IDs of workspaces subject is permitted to access
+	}
+
+	fakeClient struct {
+		workspaces []*workspace.Workspace
+	}
+)
+
+func (f *fakeSubject) CanAccessWorkspace(_ rbac.Action, policy internal.WorkspacePolicy) bool {
+	for _, id := range f.workspaces {
+		if id == policy.WorkspaceID {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *fakeClient) getWorkspace(_ context.Context, workspaceID string) (*workspace.Workspace, error) {
+	for _, ws := range f.workspaces {
+		if ws.ID == workspaceID {
+			return ws, nil
+		}
+	}
+	return nil, internal.ErrResourceNotFound
+}
+
+func (f *fakeClient) getPolicy(_ context.Context, workspaceID string) (internal.WorkspacePolicy, error) {
+	return internal.WorkspacePolicy{Organization: "acme", WorkspaceID: workspaceID}, nil
+}
@@ -17,6 +17,11 @@ const (
 	APIPrefixV2 = "/api/v2/"
 	// ModuleV1Prefix is the URL path prefix for module registry endpoints
 	ModuleV1Prefix = "/v1/modules/"
+	// ProviderV1Prefix is the URL path prefix for provider registry endpoints
+	ProviderV1Prefix = "/v1/providers/"
+	// ProviderMirrorPrefix is the URL path prefix for provider network mirror
+	// endpoints
+	ProviderMirrorPrefix = "/v1/mirror/providers/"
 )
 
 func Unmarshal(r io.Reader, v any) error {
@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package types
+
+import "time"
+
+type (
+	// RegistryModule represents a module in an organization's private
+	// registry.
+	RegistryModule struct {
+		ID              string                          `jsonapi:"primary,registry-modules"`
+		Name            string                          `jsonapi:"attribute" json:"name"`
+		Provider        string                          `jsonapi:"attribute" json:"provider"`
+		RegistryName    string                          `jsonapi:"attribute" json:"registry-name"`
+		Namespace       string                          `jsonapi:"attribute" json:"namespace"`
+		Status          string                          `jsonapi:"attribute" json:"status"`
+		VersionStatuses []RegistryModuleVersionStatuses `jsonapi:"attribute" json:"version-statuses"`
+		CreatedAt       time.Time                       `jsonapi:"attribute" json:"created-at"`
+		UpdatedAt       time.Time                       `jsonapi:"attribute" json:"updated-at"`
+
+		// Relations
+		Organization *Organization `jsonapi:"relationship" json:"organization"`
+	}
+
+	// RegistryModuleVersionStatuses reports the status of each of a module's
+	// versions.
+	RegistryModuleVersionStatuses struct {
+		Version string `json:"version"`
+		Status  string `json:"status"`
+		Error   string `json:"error"`
+	}
+
+	// RegistryModuleCreateOptions is used when creating a registry module
+	// without a VCS repository.
+	RegistryModuleCreateOptions struct {
+		// Type is a public field utilized by JSON:API to
+		// set the resource type via the field tag.
+		// It is not a user-defined value and does not need to be set.
+		// https://jsonapi.org/format/#crud-creating
+		Type string `jsonapi:"primary,registry-modules"`
+
+		Name     *string `jsonapi:"attribute" json:"name"`
+		Provider *string `jsonapi:"attribute" json:"provider"`
+	}
+
+	// RegistryModuleVersion represents a version of a registry module.
+	RegistryModuleVersion struct {
+		ID        string    `jsonapi:"primary,registry-module-versions"`
+		Source    string    `jsonapi:"attribute" json:"source"`
+		Status    string    `jsonapi:"attribute" json:"status"`
+		Version   string    `jsonapi:"attribute" json:"version"`
+		CreatedAt time.Time `jsonapi:"attribute" json:"created-at"`
+		UpdatedAt time.Time `jsonapi:"attribute" json:"updated-at"`
+
+		// Relations
+		RegistryModule *RegistryModule `jsonapi:"relationship" json:"registry-module"`
+	}
+
+	// RegistryModuleVersionCreateOptions is used when creating a version of a
+	// registry module.
+	RegistryModuleVersionCreateOptions struct {
+		// Type is a public field utilized by JSON:API to
+		// set the resource type via the field tag.
+		// It is not a user-defined value and does not need to be set.
+		// https://jsonapi.org/format/#crud-creating
+		Type string `jsonapi:"primary,registry-module-versions"`
+
+		Version *string `jsonapi:"attribute" json:"version"`
+	}
+)
@@ -20,6 +20,7 @@ type Run struct {
 	Permissions            *RunPermissions      `jsonapi:"attribute" json:"permissions"`
 	PlanOnly               bool                 `jsonapi:"attribute" json:"plan-only"`
 	PositionInQueue        int                  `jsonapi:"attribute" json:"position-in-queue"`
+	TestOnly               bool                 `jsonapi:"attribute" json:"test-only"`
 	Refresh                bool                 `jsonapi:"attribute" json:"refresh"`
 	RefreshOnly            bool                 `jsonapi:"attribute" json:"refresh-only"`
 	ReplaceAddrs           []string             `jsonapi:"attribute" json:"replace-addrs,omitempty"`
@@ -101,6 +102,10 @@ type RunCreateOptions struct {
 	// PlanOnly specifies if this is a speculative, plan-only run that Terraform cannot apply.
 	PlanOnly *bool `jsonapi:"attribute" json:"plan-only,omitempty"`
 
+	// TestOnly specifies if this is a run that only executes `terraform test`
+	// (OTF only).
+	TestOnly *bool `jsonapi:"attribute" json:"test-only,omitempty"`
+
 	// Specifies if this plan is a destroy plan, which will destroy all
 	// provisioned resources.
 	IsDestroy *bool `jsonapi:"attribute" json:"is-destroy,omitempty"`
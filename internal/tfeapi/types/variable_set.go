@@ -9,13 +9,13 @@ type VariableSet struct {
 	Name        string `jsonapi:"attribute" json:"name"`
 	Description string `jsonapi:"attribute" json:"description"`
 	Global      bool   `jsonapi:"attribute" json:"global"`
+	Priority    bool   `jsonapi:"attribute" json:"priority"`
 
 	// Relations
 	Organization *Organization          `jsonapi:"relationship" json:"organization"`
 	Workspaces   []*Workspace           `jsonapi:"relationship" json:"workspaces,omitempty"`
 	Variables    []*VariableSetVariable `jsonapi:"relationship" json:"vars,omitempty"`
-
-	//Projects     []*Project             `jsonapi:"relationship" json:"projects,omitempty"`
+	Projects     []*Project             `jsonapi:"relationship" json:"projects,omitempty"`
 }
 
 type VariableSetVariable struct {
@@ -43,6 +43,10 @@ type VariableSetCreateOptions struct {
 
 	// If true the variable set is considered in all runs in the organization.
 	Global bool `jsonapi:"attribute" json:"global,omitempty"`
+
+	// If true the variables in the set override any other variable values set
+	// with a more specific scope, including values set on the command line.
+	Priority *bool `jsonapi:"attribute" json:"priority,omitempty"`
 }
 
 // VariableSetUpdateOptions represents the options for updating a variable set.
@@ -63,6 +67,10 @@ type VariableSetUpdateOptions struct {
 
 	// If true the variable set is considered in all runs in the organization.
 	Global *bool `jsonapi:"attribute" json:"global,omitempty"`
+
+	// If true the variables in the set override any other variable values set
+	// with a more specific scope, including values set on the command line.
+	Priority *bool `jsonapi:"attribute" json:"priority,omitempty"`
 }
 
 // VariableSetVariableCreatOptions represents the options for creating a new variable within a variable set
@@ -53,6 +53,7 @@ type Workspace struct {
 	CurrentRun   *Run               `jsonapi:"relationship" json:"current-run"`
 	Organization *Organization      `jsonapi:"relationship" json:"organization"`
 	Outputs      []*WorkspaceOutput `jsonapi:"relationship" json:"outputs"`
+	Project      *Project           `jsonapi:"relationship" json:"project"`
 }
 
 type WorkspaceOutput struct {
@@ -227,6 +228,9 @@ type WorkspaceCreateOptions struct {
 	// A list of tags to attach to the workspace. If the tag does not already
 	// exist, it is created and added to the workspace.
 	Tags []*Tag `jsonapi:"relationship" json:"tags,omitempty"`
+
+	// Optional: The project the workspace belongs to.
+	Project *Project `jsonapi:"relationship" json:"project,omitempty"`
 }
 
 // WorkspaceUpdateOptions represents the options for updating a workspace.
@@ -314,6 +318,9 @@ type WorkspaceUpdateOptions struct {
 	// the environment when multiple environments exist within the same
 	// repository.
 	WorkingDirectory *string `jsonapi:"attribute" json:"working-directory,omitempty"`
+
+	// Optional: Move the workspace to the project.
+	Project *Project `jsonapi:"relationship" json:"project,omitempty"`
 }
 
 func (opts *WorkspaceUpdateOptions) Validate() error {
@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	otfapi "github.com/leg100/otf/internal/api"
+	"github.com/leg100/otf/internal/http/decode"
 
 	"github.com/gorilla/mux"
 	"github.com/leg100/otf/internal/tfeapi"
@@ -20,6 +21,8 @@ func (a *api) addHandlers(r *mux.Router) {
 	r.HandleFunc("/agent/create", a.createAgentToken).Methods("POST")
 	r.HandleFunc("/agent/details", a.getCurrentAgent).Methods("GET")
 	r.HandleFunc("/tokens/run/create", a.createRunToken).Methods("POST")
+	r.HandleFunc("/tokens", a.listUserTokens).Methods("GET")
+	r.HandleFunc("/tokens/{token_id}", a.deleteUserToken).Methods("DELETE")
 }
 
 func (a *api) createRunToken(w http.ResponseWriter, r *http.Request) {
@@ -39,6 +42,28 @@ func (a *api) createRunToken(w http.ResponseWriter, r *http.Request) {
 	w.Write(token)
 }
 
+func (a *api) listUserTokens(w http.ResponseWriter, r *http.Request) {
+	tokens, err := a.ListUserTokens(r.Context())
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.Respond(w, r, tokens, http.StatusOK)
+}
+
+func (a *api) deleteUserToken(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("token_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	if err := a.DeleteUserToken(r.Context(), id); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
 func (a *api) createAgentToken(w http.ResponseWriter, r *http.Request) {
 	var opts CreateAgentTokenOptions
 	if err := json.NewDecoder(r.Body).Decode(&opts); err != nil {
@@ -2,6 +2,9 @@ package tokens
 
 import (
 	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
 
 	otfapi "github.com/leg100/otf/internal/api"
 
@@ -31,6 +34,109 @@ func NewAgentsCommand(api *otfapi.Client) *cobra.Command {
 	return cmd
 }
 
+// NewTokensCommand constructs the command for managing the user tokens of the
+// authenticated user.
+func NewTokensCommand(api *otfapi.Client) *cobra.Command {
+	cli := &CLI{}
+	cmd := &cobra.Command{
+		Use:   "tokens",
+		Short: "User token management",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := cmd.Parent().PersistentPreRunE(cmd.Parent(), args); err != nil {
+				return err
+			}
+			cli.TokensService = &Client{Client: api}
+			return nil
+		},
+	}
+
+	cmd.AddCommand(cli.userTokenListCommand())
+	cmd.AddCommand(cli.userTokenRevokeCommand())
+
+	return cmd
+}
+
+func (a *CLI) userTokenListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:           "list",
+		Short:         "List your user tokens",
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tokens, err := a.ListUserTokens(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "ID\tDESCRIPTION\tCREATED\tEXPIRES\tSCOPE\tLAST USED")
+			for _, ut := range tokens {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+					ut.ID,
+					ut.Description,
+					ut.CreatedAt.Format(time.RFC3339),
+					formatExpiry(ut),
+					formatScope(ut.Scope),
+					formatLastUsed(ut),
+				)
+			}
+			return w.Flush()
+		},
+	}
+}
+
+func (a *CLI) userTokenRevokeCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:           "revoke [id]",
+		Short:         "Revoke one of your user tokens",
+		Args:          cobra.ExactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := a.DeleteUserToken(cmd.Context(), args[0]); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Successfully revoked token: %s\n", args[0])
+
+			return nil
+		},
+	}
+}
+
+func formatExpiry(ut *UserToken) string {
+	switch {
+	case ut.Expiry == nil:
+		return "never"
+	case ut.Expired():
+		return "expired"
+	default:
+		return ut.Expiry.Format(time.RFC3339)
+	}
+}
+
+func formatScope(scope Scope) string {
+	if scope.IsEmpty() {
+		return "-"
+	}
+	var parts []string
+	if len(scope.Actions) > 0 {
+		parts = append(parts, "actions="+strings.Join(scope.ActionNames(), ","))
+	}
+	if len(scope.WorkspaceIDs) > 0 {
+		parts = append(parts, "workspaces="+strings.Join(scope.WorkspaceIDs, ","))
+	}
+	return strings.Join(parts, " ")
+}
+
+func formatLastUsed(ut *UserToken) string {
+	if ut.LastUsedAt == nil {
+		return "never"
+	}
+	return fmt.Sprintf("%s (%s)", ut.LastUsedAt.Format(time.RFC3339), ut.LastUsedIP)
+}
+
 func (a *CLI) agentTokenCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "tokens",
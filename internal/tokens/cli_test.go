@@ -18,8 +18,34 @@ func TestAgentTokenNewCommand(t *testing.T) {
 	assert.Regexp(t, `Successfully created agent token: secret-token`, got.String())
 }
 
+func TestUserTokenListCommand(t *testing.T) {
+	cli := &CLI{TokensService: &fakeCLIService{userTokens: []*UserToken{
+		{ID: "ut-1", Description: "laptop"},
+		{ID: "ut-2", Description: "ci", Scope: Scope{WorkspaceIDs: []string{"ws-123"}}},
+	}}}
+	cmd := cli.userTokenListCommand()
+	got := bytes.Buffer{}
+	cmd.SetOut(&got)
+	require.NoError(t, cmd.Execute())
+	assert.Regexp(t, `ut-1\s+laptop`, got.String())
+	assert.Regexp(t, `ut-2\s+ci.*workspaces=ws-123\s+never`, got.String())
+}
+
+func TestUserTokenRevokeCommand(t *testing.T) {
+	svc := &fakeCLIService{}
+	cmd := (&CLI{TokensService: svc}).userTokenRevokeCommand()
+	cmd.SetArgs([]string{"ut-1"})
+	got := bytes.Buffer{}
+	cmd.SetOut(&got)
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, "ut-1", svc.deleted)
+	assert.Equal(t, "Successfully revoked token: ut-1\n", got.String())
+}
+
 type fakeCLIService struct {
-	at []byte
+	at         []byte
+	userTokens []*UserToken
+	deleted    string
 
 	TokensService
 }
@@ -31,3 +57,12 @@ func newFakeCLI(at []byte) *CLI {
 func (f *fakeCLIService) CreateAgentToken(ctx context.Context, opts CreateAgentTokenOptions) ([]byte, error) {
 	return f.at, nil
 }
+
+func (f *fakeCLIService) ListUserTokens(context.Context) ([]*UserToken, error) {
+	return f.userTokens, nil
+}
+
+func (f *fakeCLIService) DeleteUserToken(ctx context.Context, tokenID string) error {
+	f.deleted = tokenID
+	return nil
+}
@@ -3,6 +3,8 @@ package tokens
 import (
 	"bytes"
 	"context"
+	"fmt"
+	"net/url"
 
 	otfapi "github.com/leg100/otf/internal/api"
 )
@@ -53,3 +55,24 @@ func (c *Client) GetAgentToken(ctx context.Context, token string) (*AgentToken,
 	}
 	return &at, nil
 }
+
+func (c *Client) ListUserTokens(ctx context.Context) ([]*UserToken, error) {
+	req, err := c.NewRequest("GET", "tokens", nil)
+	if err != nil {
+		return nil, err
+	}
+	var list []*UserToken
+	if err := c.Do(ctx, req, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (c *Client) DeleteUserToken(ctx context.Context, tokenID string) error {
+	u := fmt.Sprintf("tokens/%s", url.QueryEscape(tokenID))
+	req, err := c.NewRequest("DELETE", u, nil)
+	if err != nil {
+		return err
+	}
+	return c.Do(ctx, req, nil)
+}
@@ -2,6 +2,7 @@ package tokens
 
 import (
 	"context"
+	"time"
 
 	"github.com/jackc/pgtype"
 	"github.com/leg100/otf/internal"
@@ -15,6 +16,29 @@ type (
 		*sql.DB // provides access to generated SQL queries
 	}
 
+	userTokenRow struct {
+		TokenID           pgtype.Text        `json:"token_id"`
+		CreatedAt         pgtype.Timestamptz `json:"created_at"`
+		Description       pgtype.Text        `json:"description"`
+		Username          pgtype.Text        `json:"username"`
+		Expiry            pgtype.Timestamptz `json:"expiry"`
+		ScopeActions      []string           `json:"scope_actions"`
+		ScopeWorkspaceIds []string           `json:"scope_workspace_ids"`
+		LastUsedAt        pgtype.Timestamptz `json:"last_used_at"`
+		LastUsedIp        pgtype.Text        `json:"last_used_ip"`
+	}
+
+	organizationTokenRow struct {
+		OrganizationTokenID pgtype.Text        `json:"organization_token_id"`
+		CreatedAt           pgtype.Timestamptz `json:"created_at"`
+		OrganizationName    pgtype.Text        `json:"organization_name"`
+		Expiry              pgtype.Timestamptz `json:"expiry"`
+		ScopeActions        []string           `json:"scope_actions"`
+		ScopeWorkspaceIds   []string           `json:"scope_workspace_ids"`
+		LastUsedAt          pgtype.Timestamptz `json:"last_used_at"`
+		LastUsedIp          pgtype.Text        `json:"last_used_ip"`
+	}
+
 	agentTokenRow struct {
 		TokenID          pgtype.Text        `json:"token_id"`
 		CreatedAt        pgtype.Timestamptz `json:"created_at"`
@@ -29,10 +53,13 @@ type (
 
 func (db *pgdb) createUserToken(ctx context.Context, token *UserToken) error {
 	_, err := db.Conn(ctx).InsertToken(ctx, pggen.InsertTokenParams{
-		TokenID:     sql.String(token.ID),
-		Description: sql.String(token.Description),
-		Username:    sql.String(token.Username),
-		CreatedAt:   sql.Timestamptz(token.CreatedAt),
+		TokenID:           sql.String(token.ID),
+		Description:       sql.String(token.Description),
+		Username:          sql.String(token.Username),
+		CreatedAt:         sql.Timestamptz(token.CreatedAt),
+		Expiry:            sql.TimestamptzPtr(token.Expiry),
+		ScopeActions:      token.Scope.ActionNames(),
+		ScopeWorkspaceIds: token.Scope.WorkspaceIDs,
 	})
 	return err
 }
@@ -44,11 +71,9 @@ func (db *pgdb) listUserTokens(ctx context.Context, username string) ([]*UserTok
 	}
 	tokens := make([]*UserToken, len(result))
 	for i, row := range result {
-		tokens[i] = &UserToken{
-			ID:          row.TokenID.String,
-			CreatedAt:   row.CreatedAt.Time.UTC(),
-			Description: row.Description.String,
-			Username:    row.Username.String,
+		tokens[i], err = userTokenRow(row).toUserToken()
+		if err != nil {
+			return nil, err
 		}
 	}
 	return tokens, nil
@@ -59,12 +84,19 @@ func (db *pgdb) getUserToken(ctx context.Context, id string) (*UserToken, error)
 	if err != nil {
 		return nil, sql.Error(err)
 	}
-	return &UserToken{
-		ID:          row.TokenID.String,
-		CreatedAt:   row.CreatedAt.Time.UTC(),
-		Description: row.Description.String,
-		Username:    row.Username.String,
-	}, nil
+	return userTokenRow(row).toUserToken()
+}
+
+func (db *pgdb) updateUserTokenLastUsed(ctx context.Context, id string, usage tokenUsage) error {
+	_, err := db.Conn(ctx).UpdateTokenLastUsed(ctx, pggen.UpdateTokenLastUsedParams{
+		TokenID:    sql.String(id),
+		LastUsedAt: sql.Timestamptz(usage.at),
+		LastUsedIp: sql.String(usage.ip),
+	})
+	if err != nil {
+		return sql.Error(err)
+	}
+	return nil
 }
 
 func (db *pgdb) deleteUserToken(ctx context.Context, id string) error {
@@ -81,10 +113,12 @@ func (db *pgdb) deleteUserToken(ctx context.Context, id string) error {
 
 func (db *pgdb) createTeamToken(ctx context.Context, token *TeamToken) error {
 	_, err := db.Conn(ctx).InsertTeamToken(ctx, pggen.InsertTeamTokenParams{
-		TeamTokenID: sql.String(token.ID),
-		TeamID:      sql.String(token.TeamID),
-		CreatedAt:   sql.Timestamptz(token.CreatedAt),
-		Expiry:      sql.TimestamptzPtr(token.Expiry),
+		TeamTokenID:       sql.String(token.ID),
+		TeamID:            sql.String(token.TeamID),
+		CreatedAt:         sql.Timestamptz(token.CreatedAt),
+		Expiry:            sql.TimestamptzPtr(token.Expiry),
+		ScopeActions:      token.Scope.ActionNames(),
+		ScopeWorkspaceIds: token.Scope.WorkspaceIDs,
 	})
 	return err
 }
@@ -98,15 +132,32 @@ func (db *pgdb) getTeamTokenByTeamID(ctx context.Context, teamID string) (*TeamT
 	if len(result) == 0 {
 		return nil, nil
 	}
-	ot := &TeamToken{
-		ID:        result[0].TeamTokenID.String,
-		CreatedAt: result[0].CreatedAt.Time.UTC(),
-		TeamID:    result[0].TeamID.String,
+	row := result[0]
+	scope, err := newScope(row.ScopeActions, row.ScopeWorkspaceIds)
+	if err != nil {
+		return nil, err
 	}
-	if result[0].Expiry.Status == pgtype.Present {
-		ot.Expiry = internal.Time(result[0].Expiry.Time.UTC())
+	return &TeamToken{
+		ID:         row.TeamTokenID.String,
+		CreatedAt:  row.CreatedAt.Time.UTC(),
+		TeamID:     row.TeamID.String,
+		Expiry:     timestamptzPtr(row.Expiry),
+		Scope:      scope,
+		LastUsedAt: timestamptzPtr(row.LastUsedAt),
+		LastUsedIP: row.LastUsedIp.String,
+	}, nil
+}
+
+func (db *pgdb) updateTeamTokenLastUsed(ctx context.Context, id string, usage tokenUsage) error {
+	_, err := db.Conn(ctx).UpdateTeamTokenLastUsed(ctx, pggen.UpdateTeamTokenLastUsedParams{
+		TeamTokenID: sql.String(id),
+		LastUsedAt:  sql.Timestamptz(usage.at),
+		LastUsedIp:  sql.String(usage.ip),
+	})
+	if err != nil {
+		return sql.Error(err)
 	}
-	return ot, nil
+	return nil
 }
 
 func (db *pgdb) deleteTeamToken(ctx context.Context, team string) error {
@@ -127,6 +178,8 @@ func (db *pgdb) upsertOrganizationToken(ctx context.Context, token *Organization
 		OrganizationName:    sql.String(token.Organization),
 		CreatedAt:           sql.Timestamptz(token.CreatedAt),
 		Expiry:              sql.TimestamptzPtr(token.Expiry),
+		ScopeActions:        token.Scope.ActionNames(),
+		ScopeWorkspaceIds:   token.Scope.WorkspaceIDs,
 	})
 	return err
 }
@@ -140,15 +193,7 @@ func (db *pgdb) getOrganizationTokenByName(ctx context.Context, organization str
 	if len(result) == 0 {
 		return nil, nil
 	}
-	ot := &OrganizationToken{
-		ID:           result[0].OrganizationTokenID.String,
-		CreatedAt:    result[0].CreatedAt.Time.UTC(),
-		Organization: result[0].OrganizationName.String,
-	}
-	if result[0].Expiry.Status == pgtype.Present {
-		ot.Expiry = internal.Time(result[0].Expiry.Time.UTC())
-	}
-	return ot, nil
+	return organizationTokenRow(result[0]).toOrganizationToken()
 }
 
 func (db *pgdb) getOrganizationTokenByID(ctx context.Context, tokenID string) (*OrganizationToken, error) {
@@ -156,15 +201,19 @@ func (db *pgdb) getOrganizationTokenByID(ctx context.Context, tokenID string) (*
 	if err != nil {
 		return nil, sql.Error(err)
 	}
-	ot := &OrganizationToken{
-		ID:           result.OrganizationTokenID.String,
-		CreatedAt:    result.CreatedAt.Time.UTC(),
-		Organization: result.OrganizationName.String,
-	}
-	if result.Expiry.Status == pgtype.Present {
-		ot.Expiry = internal.Time(result.Expiry.Time.UTC())
+	return organizationTokenRow(result).toOrganizationToken()
+}
+
+func (db *pgdb) updateOrganizationTokenLastUsed(ctx context.Context, id string, usage tokenUsage) error {
+	_, err := db.Conn(ctx).UpdateOrganizationTokenLastUsed(ctx, pggen.UpdateOrganizationTokenLastUsedParams{
+		OrganizationTokenID: sql.String(id),
+		LastUsedAt:          sql.Timestamptz(usage.at),
+		LastUsedIp:          sql.String(usage.ip),
+	})
+	if err != nil {
+		return sql.Error(err)
 	}
-	return ot, nil
+	return nil
 }
 
 func (db *pgdb) deleteOrganizationToken(ctx context.Context, organization string) error {
@@ -225,3 +274,44 @@ func (row agentTokenRow) toAgentToken() *AgentToken {
 		Organization: row.OrganizationName.String,
 	}
 }
+
+func (row userTokenRow) toUserToken() (*UserToken, error) {
+	scope, err := newScope(row.ScopeActions, row.ScopeWorkspaceIds)
+	if err != nil {
+		return nil, err
+	}
+	return &UserToken{
+		ID:          row.TokenID.String,
+		CreatedAt:   row.CreatedAt.Time.UTC(),
+		Description: row.Description.String,
+		Username:    row.Username.String,
+		Expiry:      timestamptzPtr(row.Expiry),
+		Scope:       scope,
+		LastUsedAt:  timestamptzPtr(row.LastUsedAt),
+		LastUsedIP:  row.LastUsedIp.String,
+	}, nil
+}
+
+func (row organizationTokenRow) toOrganizationToken() (*OrganizationToken, error) {
+	scope, err := newScope(row.ScopeActions, row.ScopeWorkspaceIds)
+	if err != nil {
+		return nil, err
+	}
+	return &OrganizationToken{
+		ID:           row.OrganizationTokenID.String,
+		CreatedAt:    row.CreatedAt.Time.UTC(),
+		Organization: row.OrganizationName.String,
+		Expiry:       timestamptzPtr(row.Expiry),
+		Scope:        scope,
+		LastUsedAt:   timestamptzPtr(row.LastUsedAt),
+		LastUsedIP:   row.LastUsedIp.String,
+	}, nil
+}
+
+// timestamptzPtr returns a pointer to the timestamp, or nil if it is null.
+func timestamptzPtr(t pgtype.Timestamptz) *time.Time {
+	if t.Status != pgtype.Present {
+		return nil
+	}
+	return internal.Time(t.Time.UTC())
+}
@@ -29,6 +29,8 @@ const (
 var AuthenticatedPrefixes = []string{
 	tfeapi.APIPrefixV2,
 	tfeapi.ModuleV1Prefix,
+	tfeapi.ProviderV1Prefix,
+	tfeapi.ProviderMirrorPrefix,
 	otfapi.DefaultBasePath,
 	paths.UIPrefix,
 }
@@ -39,6 +41,7 @@ type (
 		organizationTokenService
 		auth.AuthService
 		teamTokenService
+		usageRecorder
 
 		GoogleIAPConfig
 		SiteToken string
@@ -97,7 +100,7 @@ func newMiddleware(opts middlewareOptions) mux.MiddlewareFunc {
 					return
 				}
 			} else if bearer := r.Header.Get("Authorization"); bearer != "" {
-				subject, err = mw.validateBearer(ctx, bearer)
+				subject, err = mw.validateBearer(ctx, bearer, newTokenUsage(r))
 				if err != nil {
 					http.Error(w, err.Error(), http.StatusUnauthorized)
 					return
@@ -131,7 +134,7 @@ func (m *middleware) validateIAPToken(ctx context.Context, token string) (intern
 	return m.getOrCreateUser(ctx, email.(string))
 }
 
-func (m *middleware) validateBearer(ctx context.Context, bearer string) (internal.Subject, error) {
+func (m *middleware) validateBearer(ctx context.Context, bearer string, usage tokenUsage) (internal.Subject, error) {
 	splitToken := strings.Split(bearer, "Bearer ")
 	if len(splitToken) != 2 {
 		return nil, fmt.Errorf("malformed bearer token")
@@ -151,20 +154,32 @@ func (m *middleware) validateBearer(ctx context.Context, bearer string) (interna
 	if !ok {
 		return nil, fmt.Errorf("missing claim: kind")
 	}
-	switch Kind(kindClaim.(string)) {
+	kind := Kind(kindClaim.(string))
+	var subject internal.Subject
+	switch kind {
 	case agentTokenKind:
 		return m.GetAgentToken(ctx, parsed.Subject())
 	case userTokenKind:
-		return m.GetUser(ctx, auth.UserSpec{AuthenticationTokenID: internal.String(parsed.Subject())})
+		subject, err = m.GetUser(ctx, auth.UserSpec{AuthenticationTokenID: internal.String(parsed.Subject())})
 	case organizationTokenKind:
-		return m.getOrganizationTokenByID(ctx, parsed.Subject())
+		subject, err = m.getOrganizationTokenByID(ctx, parsed.Subject())
 	case teamTokenKind:
-		return m.GetTeamByTokenID(ctx, parsed.Subject())
+		subject, err = m.GetTeamByTokenID(ctx, parsed.Subject())
 	case runTokenKind:
 		return NewRunTokenFromJWT(parsed)
 	default:
 		return nil, fmt.Errorf("unknown authentication kind")
 	}
+	if err != nil {
+		return nil, err
+	}
+	// user, team and organization tokens may be restricted to a scope
+	scope, err := scopeFromJWT(parsed)
+	if err != nil {
+		return nil, err
+	}
+	m.recordTokenUsage(ctx, kind, parsed.Subject(), usage)
+	return scope.restrict(subject), nil
 }
 
 func (m *middleware) validateUIRequest(ctx context.Context, w http.ResponseWriter, r *http.Request) (internal.Subject, bool) {
@@ -53,6 +53,24 @@ func TestMiddleware(t *testing.T) {
 		assert.Equal(t, 200, w.Code, w.Body.String())
 	})
 
+	t.Run("valid scoped user token", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/api/v2/protected", nil)
+		token := NewTestJWT(t, secret, userTokenKind, time.Hour, scopeWorkspacesClaim, "ws-123")
+		r.Header.Add("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		fakeTokenMiddleware(t, secret)(wantSubjectHandler(t, &scopedSubject{})).ServeHTTP(w, r)
+		assert.Equal(t, 200, w.Code, w.Body.String())
+	})
+
+	t.Run("invalid token scope", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/api/v2/protected", nil)
+		token := NewTestJWT(t, secret, userTokenKind, time.Hour, scopeActionsClaim, "NoSuchAction")
+		r.Header.Add("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		fakeTokenMiddleware(t, secret)(emptyHandler).ServeHTTP(w, r)
+		assert.Equal(t, 401, w.Code)
+	})
+
 	t.Run("valid org token", func(t *testing.T) {
 		r := httptest.NewRequest("GET", "/api/v2/protected", nil)
 		token := NewTestJWT(t, secret, organizationTokenKind, time.Hour)
@@ -38,6 +38,8 @@ func (f *fakeMiddlewareService) GetTeamByTokenID(context.Context, string) (*auth
 	return &auth.Team{}, nil
 }
 
+func (f *fakeMiddlewareService) recordTokenUsage(context.Context, Kind, string, tokenUsage) {}
+
 // getGoogleCredentialsPath is a test helper to retrieve the path to a google
 // cloud service account key. If the necessary environment variable is not
 // present then the test is skipped.
@@ -75,6 +77,7 @@ func fakeTokenMiddleware(t *testing.T, secret []byte) mux.MiddlewareFunc {
 		agentTokenService:        &fakeMiddlewareService{},
 		organizationTokenService: &fakeMiddlewareService{},
 		teamTokenService:         &fakeMiddlewareService{},
+		usageRecorder:            &fakeMiddlewareService{},
 		key:                      key,
 	})
 }
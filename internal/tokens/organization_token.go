@@ -19,6 +19,11 @@ type (
 		Organization string
 		// Optional expiry.
 		Expiry *time.Time
+		Scope  Scope
+		// Time and IP address of the most recent request authenticated with
+		// the token; nil if the token has never been used.
+		LastUsedAt *time.Time
+		LastUsedIP string
 	}
 
 	// CreateOrganizationTokenOptions are options for creating an organization token via the service
@@ -26,6 +31,7 @@ type (
 	CreateOrganizationTokenOptions struct {
 		Organization string `schema:"organization_name,required"`
 		Expiry       *time.Time
+		Scope        Scope
 	}
 
 	// NewOrganizationTokenOptions are options for constructing a user token via the
@@ -55,12 +61,14 @@ func NewOrganizationToken(opts NewOrganizationTokenOptions) (*OrganizationToken,
 		CreatedAt:    internal.CurrentTimestamp(nil),
 		Organization: opts.Organization,
 		Expiry:       opts.Expiry,
+		Scope:        opts.Scope,
 	}
 	token, err := NewToken(NewTokenOptions{
 		key:     opts.key,
 		Subject: ot.ID,
 		Kind:    organizationTokenKind,
 		Expiry:  opts.Expiry,
+		Claims:  opts.Scope.claims(),
 	})
 	if err != nil {
 		return nil, nil, err
@@ -68,6 +76,11 @@ func NewOrganizationToken(opts NewOrganizationTokenOptions) (*OrganizationToken,
 	return &ot, token, nil
 }
 
+// Expired returns true if the token has expired.
+func (u *OrganizationToken) Expired() bool {
+	return u.Expiry != nil && time.Now().After(*u.Expiry)
+}
+
 func (u *OrganizationToken) CanAccessSite(action rbac.Action) bool {
 	// only be used for organization-scoped resources.
 	return false
@@ -111,6 +124,9 @@ func (u *OrganizationToken) Organizations() []string {
 // CreateOrganizationToken creates an organization token. If an organization
 // token already exists it is replaced.
 func (a *service) CreateOrganizationToken(ctx context.Context, opts CreateOrganizationTokenOptions) (*OrganizationToken, []byte, error) {
+	if err := denyScopedSubject(ctx); err != nil {
+		return nil, nil, err
+	}
 	_, err := a.organization.CanAccess(ctx, rbac.CreateOrganizationTokenAction, opts.Organization)
 	if err != nil {
 		return nil, nil, err
@@ -55,7 +55,7 @@ func (t *RunToken) CanAccessSite(action rbac.Action) bool {
 
 func (t *RunToken) CanAccessOrganization(action rbac.Action, name string) bool {
 	switch action {
-	case rbac.GetOrganizationAction, rbac.GetEntitlementsAction, rbac.GetModuleAction, rbac.ListModulesAction:
+	case rbac.GetOrganizationAction, rbac.GetEntitlementsAction, rbac.GetModuleAction, rbac.ListModulesAction, rbac.GetRegistryProviderAction, rbac.ListRegistryProvidersAction:
 		return t.Organization == name
 	default:
 		return false
@@ -0,0 +1,177 @@
+package tokens
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+)
+
+const (
+	// JWT claims for a token's scope
+	scopeActionsClaim    = "scope_actions"
+	scopeWorkspacesClaim = "scope_workspaces"
+)
+
+type (
+	// Scope restricts a token to a subset of the permissions of the entity it
+	// belongs to. A token with an empty scope is unrestricted.
+	Scope struct {
+		// Restrict token to these actions.
+		Actions []rbac.Action `json:"actions,omitempty"`
+		// Restrict token to these workspaces. A token restricted to
+		// workspaces is also denied access to site-level and team-level
+		// actions, and to organization-level actions other than those
+		// permitted to every member of an organization.
+		WorkspaceIDs []string `json:"workspace_ids,omitempty"`
+	}
+
+	// scopedSubject restricts the permissions of a subject according to the
+	// scope of the token with which it authenticated.
+	scopedSubject struct {
+		internal.Subject
+
+		scope Scope
+	}
+)
+
+// newScope constructs a scope from names of actions and workspace IDs.
+func newScope(actions, workspaceIDs []string) (Scope, error) {
+	scope := Scope{WorkspaceIDs: workspaceIDs}
+	for _, name := range actions {
+		action, err := rbac.ParseAction(name)
+		if err != nil {
+			return Scope{}, err
+		}
+		scope.Actions = append(scope.Actions, action)
+	}
+	return scope, nil
+}
+
+// scopeFromJWT retrieves a scope from a token's claims.
+func scopeFromJWT(token jwt.Token) (Scope, error) {
+	var actions, workspaceIDs []string
+	if claim, ok := token.Get(scopeActionsClaim); ok {
+		actions = splitClaim(claim)
+	}
+	if claim, ok := token.Get(scopeWorkspacesClaim); ok {
+		workspaceIDs = splitClaim(claim)
+	}
+	scope, err := newScope(actions, workspaceIDs)
+	if err != nil {
+		return Scope{}, fmt.Errorf("invalid token scope: %w", err)
+	}
+	return scope, nil
+}
+
+func splitClaim(claim any) []string {
+	s, _ := claim.(string)
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
+
+// IsEmpty returns true if the scope imposes no restrictions.
+func (s Scope) IsEmpty() bool {
+	return len(s.Actions) == 0 && len(s.WorkspaceIDs) == 0
+}
+
+// ActionNames returns the names of the actions to which the scope is
+// restricted.
+func (s Scope) ActionNames() []string {
+	names := make([]string, len(s.Actions))
+	for i, action := range s.Actions {
+		names[i] = action.String()
+	}
+	return names
+}
+
+// claims returns the scope as JWT claims.
+func (s Scope) claims() map[string]string {
+	claims := make(map[string]string)
+	if len(s.Actions) > 0 {
+		claims[scopeActionsClaim] = strings.Join(s.ActionNames(), ",")
+	}
+	if len(s.WorkspaceIDs) > 0 {
+		claims[scopeWorkspacesClaim] = strings.Join(s.WorkspaceIDs, ",")
+	}
+	return claims
+}
+
+// restrict restricts the permissions of the subject to the scope.
+func (s Scope) restrict(subj internal.Subject) internal.Subject {
+	if s.IsEmpty() {
+		return subj
+	}
+	return &scopedSubject{Subject: subj, scope: s}
+}
+
+func (s Scope) permits(action rbac.Action) bool {
+	return len(s.Actions) == 0 || slices.Contains(s.Actions, action)
+}
+
+// denyScopedSubject returns an error if the subject in the context
+// authenticated with a scoped token, which is not permitted to create tokens
+// lest it create a token with more permissions than its own.
+func denyScopedSubject(ctx context.Context) error {
+	subj, err := internal.SubjectFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	if _, ok := subj.(*scopedSubject); ok {
+		return internal.ErrAccessNotPermitted
+	}
+	return nil
+}
+
+func (s *scopedSubject) Unwrap() internal.Subject { return s.Subject }
+
+// RestrictedWorkspaceIDs returns the IDs of the workspaces to which the token
+// is scoped, or nil if it is not scoped to workspaces.
+func (s *scopedSubject) RestrictedWorkspaceIDs() []string {
+	if len(s.scope.WorkspaceIDs) == 0 {
+		return nil
+	}
+	return s.scope.WorkspaceIDs
+}
+
+func (s *scopedSubject) CanAccessSite(action rbac.Action) bool {
+	if len(s.scope.WorkspaceIDs) > 0 {
+		return false
+	}
+	return s.scope.permits(action) && s.Subject.CanAccessSite(action)
+}
+
+func (s *scopedSubject) CanAccessTeam(action rbac.Action, teamID string) bool {
+	if len(s.scope.WorkspaceIDs) > 0 {
+		return false
+	}
+	return s.scope.permits(action) && s.Subject.CanAccessTeam(action, teamID)
+}
+
+func (s *scopedSubject) CanAccessOrganization(action rbac.Action, name string) bool {
+	if len(s.scope.WorkspaceIDs) > 0 && !rbac.OrganizationMinPermissions.IsAllowed(action) {
+		return false
+	}
+	return s.scope.permits(action) && s.Subject.CanAccessOrganization(action, name)
+}
+
+func (s *scopedSubject) CanAccessWorkspace(action rbac.Action, policy internal.WorkspacePolicy) bool {
+	if len(s.scope.WorkspaceIDs) > 0 && !slices.Contains(s.scope.WorkspaceIDs, policy.WorkspaceID) {
+		return false
+	}
+	return s.scope.permits(action) && s.Subject.CanAccessWorkspace(action, policy)
+}
+
+// IsOwner always returns false because a scoped token is never granted all the
+// permissions of an owner.
+func (s *scopedSubject) IsOwner(string) bool { return false }
+
+// IsSiteAdmin always returns false because a scoped token is never granted
+// all the permissions of a site admin.
+func (s *scopedSubject) IsSiteAdmin() bool { return false }
@@ -0,0 +1,95 @@
+package tokens
+
+import (
+	"context"
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/auth"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScope(t *testing.T) {
+	// owner of acme organization
+	owner := &auth.User{
+		Username: "bobby",
+		Teams:    []*auth.Team{{Name: "owners", Organization: "acme"}},
+	}
+	dev := internal.WorkspacePolicy{Organization: "acme", WorkspaceID: "ws-dev"}
+	prod := internal.WorkspacePolicy{Organization: "acme", WorkspaceID: "ws-prod"}
+
+	t.Run("empty scope is unrestricted", func(t *testing.T) {
+		assert.Equal(t, owner, Scope{}.restrict(owner))
+	})
+
+	t.Run("restrict actions", func(t *testing.T) {
+		subj := Scope{Actions: []rbac.Action{rbac.GetRunAction}}.restrict(owner)
+
+		assert.True(t, subj.CanAccessWorkspace(rbac.GetRunAction, dev))
+		assert.False(t, subj.CanAccessWorkspace(rbac.ApplyRunAction, dev))
+		assert.False(t, subj.CanAccessOrganization(rbac.DeleteOrganizationAction, "acme"))
+		assert.False(t, subj.IsOwner("acme"))
+	})
+
+	t.Run("restrict workspaces", func(t *testing.T) {
+		subj := Scope{WorkspaceIDs: []string{"ws-dev"}}.restrict(owner)
+
+		assert.True(t, subj.CanAccessWorkspace(rbac.ApplyRunAction, dev))
+		assert.False(t, subj.CanAccessWorkspace(rbac.ApplyRunAction, prod))
+		assert.True(t, subj.CanAccessOrganization(rbac.GetOrganizationAction, "acme"))
+		assert.False(t, subj.CanAccessOrganization(rbac.CreateWorkspaceAction, "acme"))
+		assert.False(t, subj.CanAccessTeam(rbac.GetTeamAction, "team-123"))
+	})
+
+	t.Run("restricted workspace IDs", func(t *testing.T) {
+		subj := Scope{WorkspaceIDs: []string{"ws-dev"}}.restrict(owner)
+		assert.Equal(t, []string{"ws-dev"}, internal.RestrictedWorkspaceIDs(subj))
+
+		subj = Scope{Actions: []rbac.Action{rbac.GetRunAction}}.restrict(owner)
+		assert.Nil(t, internal.RestrictedWorkspaceIDs(subj))
+		assert.Nil(t, internal.RestrictedWorkspaceIDs(owner))
+	})
+
+	t.Run("deny scoped subject managing user tokens", func(t *testing.T) {
+		svc := &service{}
+		subj := Scope{Actions: []rbac.Action{rbac.GetRunAction}}.restrict(owner)
+		ctx := internal.AddSubjectToContext(context.Background(), subj)
+
+		_, err := svc.ListUserTokens(ctx)
+		assert.Equal(t, internal.ErrAccessNotPermitted, err)
+
+		err = svc.DeleteUserToken(ctx, "ut-123")
+		assert.Equal(t, internal.ErrAccessNotPermitted, err)
+	})
+
+	t.Run("unwrap", func(t *testing.T) {
+		subj := Scope{WorkspaceIDs: []string{"ws-dev"}}.restrict(owner)
+
+		assert.Equal(t, owner, internal.UnwrapSubject(subj))
+	})
+
+	t.Run("round trip via jwt claims", func(t *testing.T) {
+		want := Scope{
+			Actions:      []rbac.Action{rbac.GetRunAction, rbac.ListRunsAction},
+			WorkspaceIDs: []string{"ws-dev", "ws-prod"},
+		}
+		key := newTestJWK(t, []byte("abcdef123"))
+		token, err := NewToken(NewTokenOptions{
+			key:     key,
+			Kind:    userTokenKind,
+			Subject: "ut-123",
+			Claims:  want.claims(),
+		})
+		require.NoError(t, err)
+
+		parsed, err := jwt.Parse(token, jwt.WithKey(jwa.HS256, key))
+		require.NoError(t, err)
+		got, err := scopeFromJWT(parsed)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+}
@@ -43,6 +43,7 @@ type (
 		api    *api
 
 		middleware mux.MiddlewareFunc
+		usage      usageThrottle
 
 		key jwk.Key
 	}
@@ -90,6 +91,7 @@ func NewService(opts Options) (*service, error) {
 		agentTokenService:        &svc,
 		organizationTokenService: &svc,
 		teamTokenService:         &svc,
+		usageRecorder:            &svc,
 		AuthService:              opts.AuthService,
 		GoogleIAPConfig:          opts.GoogleIAPConfig,
 		SiteToken:                opts.SiteToken,
@@ -21,6 +21,11 @@ type (
 		TeamID string
 		// Optional expiry.
 		Expiry *time.Time
+		Scope  Scope
+		// Time and IP address of the most recent request authenticated with
+		// the token; nil if the token has never been used.
+		LastUsedAt *time.Time
+		LastUsedIP string
 	}
 
 	// CreateTeamTokenOptions are options for creating an team token via the service
@@ -28,6 +33,7 @@ type (
 	CreateTeamTokenOptions struct {
 		TeamID string
 		Expiry *time.Time
+		Scope  Scope
 	}
 
 	// NewTeamTokenOptions are options for constructing a team token via the
@@ -55,12 +61,14 @@ func NewTeamToken(opts NewTeamTokenOptions) (*TeamToken, []byte, error) {
 		CreatedAt: internal.CurrentTimestamp(nil),
 		TeamID:    opts.Team,
 		Expiry:    opts.Expiry,
+		Scope:     opts.Scope,
 	}
 	token, err := NewToken(NewTokenOptions{
 		key:     opts.key,
 		Subject: tt.ID,
 		Kind:    teamTokenKind,
 		Expiry:  opts.Expiry,
+		Claims:  opts.Scope.claims(),
 	})
 	if err != nil {
 		return nil, nil, err
@@ -80,6 +88,9 @@ func (t *TeamToken) LogValue() slog.Value {
 }
 
 func (a *service) CreateTeamToken(ctx context.Context, opts CreateTeamTokenOptions) (*TeamToken, []byte, error) {
+	if err := denyScopedSubject(ctx); err != nil {
+		return nil, nil, err
+	}
 	_, err := a.team.CanAccess(ctx, rbac.CreateTeamTokenAction, opts.TeamID)
 	if err != nil {
 		return nil, nil, err
@@ -0,0 +1,89 @@
+package tokens
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// usageInterval is the minimum interval between recording the usage of a
+// token, to avoid writing to the database upon every request.
+const usageInterval = time.Minute
+
+type (
+	// tokenUsage is the usage of a token by a request.
+	tokenUsage struct {
+		at time.Time
+		ip string
+	}
+
+	// usageRecorder records the usage of tokens.
+	usageRecorder interface {
+		recordTokenUsage(ctx context.Context, kind Kind, tokenID string, usage tokenUsage)
+	}
+
+	// usageThrottle determines whether the usage of a token is due to be
+	// recorded.
+	usageThrottle struct {
+		mu       sync.Mutex
+		recorded map[string]time.Time // last time usage was recorded, keyed by token ID
+	}
+)
+
+// newTokenUsage constructs the usage of a token by a request. The IP address
+// recorded is that of the peer connecting to OTF rather than any address
+// reported in the X-Forwarded-For header, which can be set to anything by the
+// client. If OTF is behind a reverse proxy then the address recorded is the
+// proxy's.
+func newTokenUsage(r *http.Request) tokenUsage {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+	return tokenUsage{at: time.Now(), ip: ip}
+}
+
+// due returns true if the usage of the token is due to be recorded, in which
+// case it is assumed it will be recorded.
+func (t *usageThrottle) due(tokenID string, usage tokenUsage) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.recorded == nil {
+		t.recorded = make(map[string]time.Time)
+	}
+	if last, ok := t.recorded[tokenID]; ok && usage.at.Sub(last) < usageInterval {
+		return false
+	}
+	// purge stale entries to stop the map growing indefinitely
+	for id, last := range t.recorded {
+		if usage.at.Sub(last) >= usageInterval {
+			delete(t.recorded, id)
+		}
+	}
+	t.recorded[tokenID] = usage.at
+	return true
+}
+
+// recordTokenUsage records the time and IP address of a request authenticated
+// with a token. Failure to record usage is logged rather than failing the
+// request.
+func (a *service) recordTokenUsage(ctx context.Context, kind Kind, tokenID string, usage tokenUsage) {
+	if !a.usage.due(tokenID, usage) {
+		return
+	}
+	var err error
+	switch kind {
+	case userTokenKind:
+		err = a.db.updateUserTokenLastUsed(ctx, tokenID, usage)
+	case teamTokenKind:
+		err = a.db.updateTeamTokenLastUsed(ctx, tokenID, usage)
+	case organizationTokenKind:
+		err = a.db.updateOrganizationTokenLastUsed(ctx, tokenID, usage)
+	}
+	if err != nil {
+		a.Error(err, "recording token usage", "token_id", tokenID)
+	}
+}
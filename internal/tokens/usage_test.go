@@ -0,0 +1,17 @@
+package tokens
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTokenUsage(t *testing.T) {
+	r := httptest.NewRequest("GET", "/", nil)
+	r.RemoteAddr = "1.2.3.4:1234"
+	// client-supplied header is ignored
+	r.Header.Add("X-Forwarded-For", "5.6.7.8")
+
+	assert.Equal(t, "1.2.3.4", newTokenUsage(r).ip)
+}
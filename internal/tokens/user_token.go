@@ -12,16 +12,24 @@ import (
 type (
 	// UserToken provides information about an API token for a user.
 	UserToken struct {
-		ID          string
-		CreatedAt   time.Time
-		Description string
-		Username    string // Token belongs to a user
+		ID          string     `jsonapi:"primary,user-tokens"`
+		CreatedAt   time.Time  `jsonapi:"attribute" json:"created-at"`
+		Description string     `jsonapi:"attribute" json:"description"`
+		Username    string     `jsonapi:"attribute" json:"username"` // Token belongs to a user
+		Expiry      *time.Time `jsonapi:"attribute" json:"expiry"`   // Optional expiry
+		Scope       Scope      `jsonapi:"attribute" json:"scope"`
+		// Time and IP address of the most recent request authenticated with
+		// the token; nil if the token has never been used.
+		LastUsedAt *time.Time `jsonapi:"attribute" json:"last-used-at"`
+		LastUsedIP string     `jsonapi:"attribute" json:"last-used-ip"`
 	}
 
 	// CreateUserTokenOptions are options for creating a user token via the service
 	// endpoint
 	CreateUserTokenOptions struct {
 		Description string
+		Expiry      *time.Time
+		Scope       Scope
 	}
 
 	// NewUserTokenOptions are options for constructing a user token via the
@@ -48,11 +56,15 @@ func NewUserToken(opts NewUserTokenOptions) (*UserToken, []byte, error) {
 		CreatedAt:   internal.CurrentTimestamp(nil),
 		Description: opts.Description,
 		Username:    opts.Username,
+		Expiry:      opts.Expiry,
+		Scope:       opts.Scope,
 	}
 	token, err := NewToken(NewTokenOptions{
 		key:     opts.key,
 		Subject: ut.ID,
 		Kind:    userTokenKind,
+		Expiry:  opts.Expiry,
+		Claims:  opts.Scope.claims(),
 	})
 	if err != nil {
 		return nil, nil, err
@@ -60,9 +72,17 @@ func NewUserToken(opts NewUserTokenOptions) (*UserToken, []byte, error) {
 	return &ut, token, nil
 }
 
+// Expired returns true if the token has expired.
+func (t *UserToken) Expired() bool {
+	return t.Expiry != nil && time.Now().After(*t.Expiry)
+}
+
 // CreateUserToken creates a user token. Only users can create a user token, and
 // they can only create a token for themselves.
 func (a *service) CreateUserToken(ctx context.Context, opts CreateUserTokenOptions) (*UserToken, []byte, error) {
+	if err := denyScopedSubject(ctx); err != nil {
+		return nil, nil, err
+	}
 	user, err := auth.UserFromContext(ctx)
 	if err != nil {
 		return nil, nil, err
@@ -89,6 +109,9 @@ func (a *service) CreateUserToken(ctx context.Context, opts CreateUserTokenOptio
 }
 
 func (a *service) ListUserTokens(ctx context.Context) ([]*UserToken, error) {
+	if err := denyScopedSubject(ctx); err != nil {
+		return nil, err
+	}
 	user, err := auth.UserFromContext(ctx)
 	if err != nil {
 		return nil, err
@@ -98,6 +121,9 @@ func (a *service) ListUserTokens(ctx context.Context) ([]*UserToken, error) {
 }
 
 func (a *service) DeleteUserToken(ctx context.Context, tokenID string) error {
+	if err := denyScopedSubject(ctx); err != nil {
+		return err
+	}
 	user, err := auth.UserFromContext(ctx)
 	if err != nil {
 		return err
@@ -2,9 +2,13 @@ package tokens
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
 	"net/http"
 	"sort"
+	"strings"
 	"time"
+	"unicode"
 
 	"github.com/gorilla/mux"
 	"github.com/leg100/otf/internal"
@@ -16,13 +20,29 @@ import (
 	"github.com/leg100/otf/internal/resource"
 )
 
-// webHandlers provides handlers for the web UI
-type webHandlers struct {
-	html.Renderer
+// expiryLayout is the layout of a token expiry date submitted via a form.
+const expiryLayout = "2006-01-02"
 
-	svc       TokensService
-	siteToken string
-}
+type (
+	// webHandlers provides handlers for the web UI
+	webHandlers struct {
+		html.Renderer
+
+		svc       TokensService
+		siteToken string
+	}
+
+	// tokenForm is the form for specifying the optional expiry and scope of a
+	// new token.
+	tokenForm struct {
+		// Expiry date, in the format YYYY-MM-DD
+		Expiry string `schema:"expiry"`
+		// Comma or whitespace separated names of actions
+		ScopeActions string `schema:"scope_actions"`
+		// Comma or whitespace separated workspace IDs
+		ScopeWorkspaceIDs string `schema:"scope_workspace_ids"`
+	}
+)
 
 func (h *webHandlers) addHandlers(r *mux.Router) {
 	//
@@ -72,6 +92,12 @@ func (h *webHandlers) createUserToken(w http.ResponseWriter, r *http.Request) {
 		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
 		return
 	}
+	var err error
+	opts.Expiry, opts.Scope, err = decodeTokenForm(r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
 	_, token, err := h.svc.CreateUserToken(r.Context(), opts)
 	if err != nil {
 		h.Error(w, err.Error(), http.StatusInternalServerError)
@@ -134,6 +160,12 @@ func (h *webHandlers) createOrganizationToken(w http.ResponseWriter, r *http.Req
 		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
 		return
 	}
+	var err error
+	opts.Expiry, opts.Scope, err = decodeTokenForm(r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
 	_, token, err := h.svc.CreateOrganizationToken(r.Context(), opts)
 	if err != nil {
 		h.Error(w, err.Error(), http.StatusInternalServerError)
@@ -295,6 +327,38 @@ func (h *webHandlers) logout(w http.ResponseWriter, r *http.Request) {
 	http.Redirect(w, r, "/login", http.StatusFound)
 }
 
+// decodeTokenForm decodes the optional expiry and scope of a new token from a
+// form.
+func decodeTokenForm(r *http.Request) (*time.Time, Scope, error) {
+	var form tokenForm
+	if err := decode.Form(&form, r); err != nil {
+		return nil, Scope{}, err
+	}
+	var expiry *time.Time
+	if form.Expiry != "" {
+		t, err := time.Parse(expiryLayout, form.Expiry)
+		if err != nil {
+			return nil, Scope{}, fmt.Errorf("invalid expiry date: %w", err)
+		}
+		if !t.After(time.Now()) {
+			return nil, Scope{}, errors.New("expiry date must be in the future")
+		}
+		expiry = &t
+	}
+	scope, err := newScope(splitList(form.ScopeActions), splitList(form.ScopeWorkspaceIDs))
+	if err != nil {
+		return nil, Scope{}, err
+	}
+	return expiry, scope, nil
+}
+
+// splitList splits a string of values separated by commas and/or whitespace.
+func splitList(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+}
+
 func (h *webHandlers) tokenFlashMessage(w http.ResponseWriter, token []byte) error {
 	// render a small templated flash message
 	buf := new(bytes.Buffer)
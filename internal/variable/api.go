@@ -1,6 +1,8 @@
 package variable
 
 import (
+	"encoding/json"
+	"io"
 	"net/http"
 
 	otfapi "github.com/leg100/otf/internal/api"
@@ -19,6 +21,11 @@ type api struct {
 func (a *api) addHandlers(r *mux.Router) {
 	r = r.PathPrefix(otfapi.DefaultBasePath).Subrouter()
 	r.HandleFunc("/vars/effective/{run_id}", a.listEffectiveVariables).Methods("GET")
+	r.HandleFunc("/workspaces/{workspace_id}/vars/effective", a.listEffectiveWorkspaceVariables).Methods("GET")
+	r.HandleFunc("/workspaces/{workspace_id}/vars/export", a.exportWorkspaceVariables).Methods("GET")
+	r.HandleFunc("/workspaces/{workspace_id}/vars/import", a.importWorkspaceVariables).Methods("PUT")
+	r.HandleFunc("/variable-sets/{variable_set_id}/vars/export", a.exportVariableSetVariables).Methods("GET")
+	r.HandleFunc("/variable-sets/{variable_set_id}/vars/import", a.importVariableSetVariables).Methods("PUT")
 }
 
 func (a *api) listEffectiveVariables(w http.ResponseWriter, r *http.Request) {
@@ -34,3 +41,108 @@ func (a *api) listEffectiveVariables(w http.ResponseWriter, r *http.Request) {
 	}
 	a.Respond(w, r, variables, http.StatusOK)
 }
+
+func (a *api) listEffectiveWorkspaceVariables(w http.ResponseWriter, r *http.Request) {
+	workspaceID, err := decode.Param("workspace_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	variables, err := a.ListEffectiveWorkspaceVariables(r.Context(), workspaceID)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	// scrub the values of sensitive variables
+	for i, ev := range variables {
+		if ev.Sensitive {
+			scrubbed := *ev.Variable
+			scrubbed.Value = ""
+			variables[i] = &EffectiveVariable{Variable: &scrubbed, Source: ev.Source, Overrides: ev.Overrides}
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(variables)
+}
+
+func (a *api) exportWorkspaceVariables(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		WorkspaceID string `schema:"workspace_id,required"`
+		Format      Format `schema:"format,required"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	exported, err := a.ExportWorkspaceVariables(r.Context(), params.WorkspaceID, params.Format)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", params.Format.ContentType())
+	w.Write(exported)
+}
+
+func (a *api) importWorkspaceVariables(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		ImportOptions
+		WorkspaceID string `schema:"workspace_id,required"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	src, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxImportSize))
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	result, err := a.ImportWorkspaceVariables(r.Context(), params.WorkspaceID, src, params.ImportOptions)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+func (a *api) exportVariableSetVariables(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		SetID  string `schema:"variable_set_id,required"`
+		Format Format `schema:"format,required"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	exported, err := a.ExportVariableSetVariables(r.Context(), params.SetID, params.Format)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", params.Format.ContentType())
+	w.Write(exported)
+}
+
+func (a *api) importVariableSetVariables(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		ImportOptions
+		SetID string `schema:"variable_set_id,required"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	src, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxImportSize))
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	result, err := a.ImportVariableSetVariables(r.Context(), params.SetID, src, params.ImportOptions)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
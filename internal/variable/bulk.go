@@ -0,0 +1,409 @@
+package variable
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsimple"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/leg100/otf/internal"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	// FormatHCL is a HCL file containing a variable block for each variable,
+	// e.g.:
+	//
+	//	variable "foo" {
+	//	  category = "terraform"
+	//	  value    = "bar"
+	//	}
+	FormatHCL Format = "hcl"
+	// FormatTfvars is a terraform variable definitions file, i.e.
+	// terraform.tfvars, which only contains terraform variables.
+	FormatTfvars Format = "tfvars"
+	// FormatJSON is a JSON array containing an object for each variable.
+	FormatJSON Format = "json"
+
+	// SkipConflicts skips importing a variable that conflicts with an
+	// existing variable.
+	SkipConflicts ConflictStrategy = "skip"
+	// OverwriteConflicts overwrites an existing variable with a conflicting
+	// imported variable.
+	OverwriteConflicts ConflictStrategy = "overwrite"
+	// FailOnConflict aborts the import, importing nothing, if an imported
+	// variable conflicts with an existing variable.
+	FailOnConflict ConflictStrategy = "fail"
+
+	// MaxImportSize is the maximum size in bytes of a file of variables to
+	// import.
+	MaxImportSize = 10 << 20
+)
+
+var (
+	Formats            = []Format{FormatHCL, FormatTfvars, FormatJSON}
+	ConflictStrategies = []ConflictStrategy{FailOnConflict, SkipConflicts, OverwriteConflicts}
+)
+
+type (
+	// Format is a format in which variables are exported and imported.
+	Format string
+
+	// ConflictStrategy determines what happens when an imported variable
+	// conflicts with an existing variable, i.e. they share the same key and
+	// category.
+	ConflictStrategy string
+
+	ImportOptions struct {
+		Format   Format           `schema:"format,required"`
+		Strategy ConflictStrategy `schema:"strategy"` // defaults to FailOnConflict
+	}
+
+	// ImportResult reports the keys of the variables that were created,
+	// updated, and skipped by an import.
+	ImportResult struct {
+		Created []string `json:"created"`
+		Updated []string `json:"updated"`
+		Skipped []string `json:"skipped"`
+	}
+
+	// portableVariable is the representation of a variable in the HCL and
+	// JSON formats. The value of a sensitive variable is omitted.
+	portableVariable struct {
+		Key             string  `json:"key" hcl:"key,label"`
+		Value           *string `json:"value,omitempty" hcl:"value,optional"`
+		Description     *string `json:"description,omitempty" hcl:"description,optional"`
+		Category        *string `json:"category,omitempty" hcl:"category,optional"`
+		HCL             *bool   `json:"hcl,omitempty" hcl:"hcl,optional"`
+		Sensitive       *bool   `json:"sensitive,omitempty" hcl:"sensitive,optional"`
+		SecretReference *bool   `json:"secret_reference,omitempty" hcl:"secret_reference,optional"`
+	}
+
+	// importPlan is the set of changes to make to a collection of variables
+	// in order to import variables.
+	importPlan struct {
+		create []*Variable
+		update []*Variable
+		result ImportResult
+	}
+)
+
+// FormatFromFilename infers the format of a file from its extension.
+func FormatFromFilename(name string) (Format, error) {
+	switch filepath.Ext(name) {
+	case ".hcl":
+		return FormatHCL, nil
+	case ".tfvars":
+		return FormatTfvars, nil
+	case ".json":
+		return FormatJSON, nil
+	default:
+		return "", fmt.Errorf("cannot infer format from file extension: %s", name)
+	}
+}
+
+func (f Format) valid() error {
+	if !slices.Contains(Formats, f) {
+		return fmt.Errorf("invalid format: %q", f)
+	}
+	return nil
+}
+
+// Filename is the name of a file containing variables exported in the format.
+func (f Format) Filename() string {
+	if f == FormatTfvars {
+		return "terraform.tfvars"
+	}
+	return "variables." + string(f)
+}
+
+// ContentType is the media type of the format.
+func (f Format) ContentType() string {
+	if f == FormatJSON {
+		return "application/json"
+	}
+	return "text/plain; charset=utf-8"
+}
+
+func (s ConflictStrategy) valid() error {
+	if !slices.Contains(ConflictStrategies, s) {
+		return fmt.Errorf("invalid conflict strategy: %q", s)
+	}
+	return nil
+}
+
+// exportVariables encodes variables in the given format. The values of
+// sensitive variables are omitted, and the tfvars format omits sensitive
+// variables altogether.
+func exportVariables(vars []*Variable, format Format) ([]byte, error) {
+	// sort by category and then key so that exports are deterministic
+	vars = slices.Clone(vars)
+	slices.SortFunc(vars, func(a, b *Variable) int {
+		if c := strings.Compare(string(a.Category), string(b.Category)); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Key, b.Key)
+	})
+
+	switch format {
+	case FormatHCL:
+		return exportHCL(vars), nil
+	case FormatTfvars:
+		return exportTfvars(vars), nil
+	case FormatJSON:
+		portable := make([]*portableVariable, len(vars))
+		for i, v := range vars {
+			portable[i] = newPortableVariable(v)
+		}
+		return json.MarshalIndent(portable, "", "  ")
+	default:
+		return nil, format.valid()
+	}
+}
+
+func newPortableVariable(v *Variable) *portableVariable {
+	pv := &portableVariable{
+		Key:       v.Key,
+		Category:  internal.String(string(v.Category)),
+		HCL:       internal.Bool(v.HCL),
+		Sensitive: internal.Bool(v.Sensitive),
+	}
+	if !v.Sensitive {
+		pv.Value = internal.String(v.Value)
+	}
+	if v.Description != "" {
+		pv.Description = internal.String(v.Description)
+	}
+	if v.SecretReference {
+		pv.SecretReference = internal.Bool(true)
+	}
+	return pv
+}
+
+func exportHCL(vars []*Variable) []byte {
+	f := hclwrite.NewEmptyFile()
+	for i, v := range vars {
+		if i > 0 {
+			f.Body().AppendNewline()
+		}
+		pv := newPortableVariable(v)
+		block := f.Body().AppendNewBlock("variable", []string{pv.Key}).Body()
+		block.SetAttributeValue("category", cty.StringVal(*pv.Category))
+		if pv.Value != nil {
+			block.SetAttributeValue("value", cty.StringVal(*pv.Value))
+		}
+		if pv.Description != nil {
+			block.SetAttributeValue("description", cty.StringVal(*pv.Description))
+		}
+		block.SetAttributeValue("hcl", cty.BoolVal(*pv.HCL))
+		block.SetAttributeValue("sensitive", cty.BoolVal(*pv.Sensitive))
+		if pv.SecretReference != nil {
+			block.SetAttributeValue("secret_reference", cty.True)
+		}
+	}
+	return f.Bytes()
+}
+
+func exportTfvars(vars []*Variable) []byte {
+	f := hclwrite.NewEmptyFile()
+	for _, v := range vars {
+		// a secret reference is resolved only by the agent, so its value
+		// is not the value of the terraform variable.
+		if v.Category != CategoryTerraform || v.Sensitive || v.SecretReference {
+			continue
+		}
+		if v.HCL {
+			// value is already a HCL expression, so write it verbatim
+			f.Body().SetAttributeRaw(v.Key, hclwrite.Tokens{
+				{Type: hclsyntax.TokenIdent, Bytes: []byte(v.Value)},
+			})
+		} else {
+			f.Body().SetAttributeValue(v.Key, cty.StringVal(v.Value))
+		}
+	}
+	return hclwrite.Format(f.Bytes())
+}
+
+// importVariables decodes variables from src in the given format into options
+// for creating variables.
+func importVariables(src []byte, format Format) ([]CreateVariableOptions, error) {
+	switch format {
+	case FormatHCL:
+		var file struct {
+			Variables []*portableVariable `hcl:"variable,block"`
+		}
+		if err := hclsimple.Decode("variables.hcl", src, nil, &file); err != nil {
+			return nil, err
+		}
+		return portableToOptions(file.Variables), nil
+	case FormatTfvars:
+		return importTfvars(src)
+	case FormatJSON:
+		var portable []*portableVariable
+		if err := json.Unmarshal(src, &portable); err != nil {
+			return nil, err
+		}
+		return portableToOptions(portable), nil
+	default:
+		return nil, format.valid()
+	}
+}
+
+func portableToOptions(portable []*portableVariable) []CreateVariableOptions {
+	opts := make([]CreateVariableOptions, len(portable))
+	for i, pv := range portable {
+		category := CategoryTerraform
+		if pv.Category != nil {
+			category = VariableCategory(*pv.Category)
+		}
+		opts[i] = CreateVariableOptions{
+			Key:             internal.String(pv.Key),
+			Value:           pv.Value,
+			Description:     pv.Description,
+			Category:        &category,
+			HCL:             pv.HCL,
+			Sensitive:       pv.Sensitive,
+			SecretReference: pv.SecretReference,
+		}
+	}
+	return opts
+}
+
+// importTfvars decodes terraform variables from a variable definitions file.
+// A variable assigned a string literal is imported as a string; any other
+// expression is imported verbatim as a HCL variable.
+func importTfvars(src []byte) ([]CreateVariableOptions, error) {
+	file, diags := hclsyntax.ParseConfig(src, "terraform.tfvars", hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	attrs, diags := file.Body.JustAttributes()
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	// preserve the order in which variables appear in the file
+	sorted := make([]*hcl.Attribute, 0, len(attrs))
+	for _, attr := range attrs {
+		sorted = append(sorted, attr)
+	}
+	slices.SortFunc(sorted, func(a, b *hcl.Attribute) int {
+		return a.Range.Start.Byte - b.Range.Start.Byte
+	})
+
+	opts := make([]CreateVariableOptions, len(sorted))
+	for i, attr := range sorted {
+		// a variable definitions file holds only literal values
+		opts[i] = CreateVariableOptions{
+			Key:             internal.String(attr.Name),
+			Category:        VariableCategoryPtr(CategoryTerraform),
+			SecretReference: internal.Bool(false),
+		}
+		if _, ok := attr.Expr.(*hclsyntax.TemplateExpr); ok {
+			if val, diags := attr.Expr.Value(nil); !diags.HasErrors() && val.Type() == cty.String {
+				opts[i].Value = internal.String(val.AsString())
+				opts[i].HCL = internal.Bool(false)
+				continue
+			}
+		}
+		opts[i].Value = internal.String(string(attr.Expr.Range().SliceBytes(src)))
+		opts[i].HCL = internal.Bool(true)
+	}
+	return opts, nil
+}
+
+// planImport determines the variables to create and update in order to import
+// variables into a collection of existing variables, according to the conflict
+// strategy. A copy of the collection is returned reflecting the changes.
+func planImport(collection []*Variable, imported []CreateVariableOptions, strategy ConflictStrategy) (*importPlan, []*Variable, error) {
+	if strategy == "" {
+		strategy = FailOnConflict
+	}
+	if err := strategy.valid(); err != nil {
+		return nil, nil, err
+	}
+	collection = slices.Clone(collection)
+
+	plan := importPlan{
+		result: ImportResult{Created: []string{}, Updated: []string{}, Skipped: []string{}},
+	}
+	for _, opts := range imported {
+		v, err := newVariable(collection, opts)
+		if err == nil {
+			collection = append(collection, v)
+			plan.create = append(plan.create, v)
+			plan.result.Created = append(plan.result.Created, v.Key)
+			continue
+		} else if !errors.Is(err, ErrVariableConflict) {
+			return nil, nil, fmt.Errorf("importing variable %s: %w", *opts.Key, err)
+		}
+
+		existing := findVariable(collection, *opts.Key, *opts.Category)
+		if existing == nil || slices.Contains(plan.create, existing) || slices.Contains(plan.update, existing) {
+			return nil, nil, fmt.Errorf("variable %s is defined more than once", *opts.Key)
+		}
+		switch strategy {
+		case SkipConflicts:
+			plan.result.Skipped = append(plan.result.Skipped, existing.Key)
+		case OverwriteConflicts:
+			// update a copy of the existing variable, leaving its value
+			// untouched if the imported variable omits its value, e.g. an
+			// exported sensitive variable.
+			updated := *existing
+			err := updated.update(collection, UpdateVariableOptions{
+				Value:           opts.Value,
+				Description:     opts.Description,
+				HCL:             opts.HCL,
+				Sensitive:       opts.Sensitive,
+				SecretReference: opts.SecretReference,
+			})
+			if err != nil {
+				return nil, nil, fmt.Errorf("importing variable %s: %w", existing.Key, err)
+			}
+			collection[slices.Index(collection, existing)] = &updated
+			plan.update = append(plan.update, &updated)
+			plan.result.Updated = append(plan.result.Updated, updated.Key)
+		default:
+			return nil, nil, fmt.Errorf("importing variable %s: %w", existing.Key, ErrVariableConflict)
+		}
+	}
+	return &plan, collection, nil
+}
+
+// findVariable finds the variable with the given key and category in the
+// collection.
+func findVariable(collection []*Variable, key string, category VariableCategory) *Variable {
+	key = strings.TrimSpace(key)
+	for _, v := range collection {
+		if v.Key == key && v.Category == category {
+			return v
+		}
+	}
+	return nil
+}
+
+// Summary summarises the result of an import.
+func (r *ImportResult) Summary() string {
+	return fmt.Sprintf("%d created, %d updated, %d skipped", len(r.Created), len(r.Updated), len(r.Skipped))
+}
+
+func (r *ImportResult) String() string {
+	var b bytes.Buffer
+	for _, key := range r.Created {
+		fmt.Fprintf(&b, "created: %s\n", key)
+	}
+	for _, key := range r.Updated {
+		fmt.Fprintf(&b, "updated: %s\n", key)
+	}
+	for _, key := range r.Skipped {
+		fmt.Fprintf(&b, "skipped: %s\n", key)
+	}
+	b.WriteString(r.Summary())
+	return b.String()
+}
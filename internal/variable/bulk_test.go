@@ -0,0 +1,225 @@
+package variable
+
+import (
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportImport(t *testing.T) {
+	vars := []*Variable{
+		{Key: "region", Value: "eu-west-2", Description: "AWS region", Category: CategoryTerraform},
+		{Key: "zones", Value: `["a", "b"]`, Category: CategoryTerraform, HCL: true},
+		{Key: "password", Value: "secret", Category: CategoryTerraform, Sensitive: true},
+		{Key: "AWS_PROFILE", Value: "dev", Category: CategoryEnv},
+		{Key: "greeting", Value: "hello ${world}\nmultiline", Category: CategoryTerraform},
+	}
+
+	for _, format := range []Format{FormatHCL, FormatJSON} {
+		t.Run(string(format), func(t *testing.T) {
+			exported, err := exportVariables(vars, format)
+			require.NoError(t, err)
+			assert.NotContains(t, string(exported), "secret")
+
+			imported, err := importVariables(exported, format)
+			require.NoError(t, err)
+			require.Equal(t, 5, len(imported))
+
+			got := make(map[string]CreateVariableOptions, len(imported))
+			for _, opts := range imported {
+				got[*opts.Key] = opts
+			}
+			for _, want := range vars {
+				opts, ok := got[want.Key]
+				require.True(t, ok, want.Key)
+				assert.Equal(t, want.Category, *opts.Category)
+				assert.Equal(t, want.HCL, *opts.HCL)
+				assert.Equal(t, want.Sensitive, *opts.Sensitive)
+				if want.Sensitive {
+					assert.Nil(t, opts.Value)
+				} else {
+					assert.Equal(t, want.Value, *opts.Value)
+				}
+				if want.Description != "" {
+					assert.Equal(t, want.Description, *opts.Description)
+				} else {
+					assert.Nil(t, opts.Description)
+				}
+			}
+		})
+	}
+
+	t.Run("tfvars", func(t *testing.T) {
+		exported, err := exportVariables(vars, FormatTfvars)
+		require.NoError(t, err)
+		// only non-sensitive terraform variables are exported
+		assert.NotContains(t, string(exported), "password")
+		assert.NotContains(t, string(exported), "AWS_PROFILE")
+
+		imported, err := importVariables(exported, FormatTfvars)
+		require.NoError(t, err)
+		require.Equal(t, 3, len(imported))
+
+		// exported in lexical order
+		assert.Equal(t, "greeting", *imported[0].Key)
+		assert.Equal(t, "hello ${world}\nmultiline", *imported[0].Value)
+		assert.False(t, *imported[0].HCL)
+
+		assert.Equal(t, "region", *imported[1].Key)
+		assert.Equal(t, "eu-west-2", *imported[1].Value)
+		assert.False(t, *imported[1].HCL)
+
+		assert.Equal(t, "zones", *imported[2].Key)
+		assert.Equal(t, `["a", "b"]`, *imported[2].Value)
+		assert.True(t, *imported[2].HCL)
+	})
+
+	t.Run("import tfvars with interpolation and numbers", func(t *testing.T) {
+		src := []byte(`
+instances = 3
+name      = "web-${var.env}"
+tags = {
+  team = "platform"
+}
+`)
+		imported, err := importVariables(src, FormatTfvars)
+		require.NoError(t, err)
+		require.Equal(t, 3, len(imported))
+
+		assert.Equal(t, "instances", *imported[0].Key)
+		assert.Equal(t, "3", *imported[0].Value)
+		assert.True(t, *imported[0].HCL)
+
+		// string template with an interpolation cannot be evaluated so is
+		// imported verbatim as HCL
+		assert.Equal(t, "name", *imported[1].Key)
+		assert.Equal(t, `"web-${var.env}"`, *imported[1].Value)
+		assert.True(t, *imported[1].HCL)
+
+		assert.Equal(t, "tags", *imported[2].Key)
+		assert.True(t, *imported[2].HCL)
+	})
+
+	t.Run("import hcl with default category", func(t *testing.T) {
+		imported, err := importVariables([]byte(`variable "foo" { value = "bar" }`), FormatHCL)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(imported))
+		assert.Equal(t, CategoryTerraform, *imported[0].Category)
+		assert.Nil(t, imported[0].HCL)
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		_, err := exportVariables(vars, "yaml")
+		assert.Error(t, err)
+		_, err = importVariables(nil, "yaml")
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid source", func(t *testing.T) {
+		_, err := importVariables([]byte(`variable "foo" {`), FormatHCL)
+		assert.Error(t, err)
+		_, err = importVariables([]byte(`[{`), FormatJSON)
+		assert.Error(t, err)
+	})
+}
+
+func TestPlanImport(t *testing.T) {
+	existing := func() []*Variable {
+		return []*Variable{
+			{ID: "var-1", Key: "foo", Value: "old", Category: CategoryTerraform},
+			{ID: "var-2", Key: "secret", Value: "hidden", Category: CategoryTerraform, Sensitive: true},
+		}
+	}
+	imported := []CreateVariableOptions{
+		{
+			Key:      internal.String("foo"),
+			Value:    internal.String("new"),
+			Category: VariableCategoryPtr(CategoryTerraform),
+		},
+		{
+			Key:      internal.String("bar"),
+			Value:    internal.String("baz"),
+			Category: VariableCategoryPtr(CategoryTerraform),
+		},
+		{
+			// same key but different category does not conflict
+			Key:      internal.String("foo"),
+			Value:    internal.String("env"),
+			Category: VariableCategoryPtr(CategoryEnv),
+		},
+	}
+
+	t.Run("fail", func(t *testing.T) {
+		_, _, err := planImport(existing(), imported, FailOnConflict)
+		assert.ErrorIs(t, err, ErrVariableConflict)
+	})
+
+	t.Run("default to fail", func(t *testing.T) {
+		_, _, err := planImport(existing(), imported, "")
+		assert.ErrorIs(t, err, ErrVariableConflict)
+	})
+
+	t.Run("skip", func(t *testing.T) {
+		plan, collection, err := planImport(existing(), imported, SkipConflicts)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"bar", "foo"}, plan.result.Created)
+		assert.Equal(t, []string{"foo"}, plan.result.Skipped)
+		assert.Empty(t, plan.result.Updated)
+		assert.Equal(t, 2, len(plan.create))
+		assert.Equal(t, 4, len(collection))
+		assert.Equal(t, "old", collection[0].Value)
+	})
+
+	t.Run("overwrite", func(t *testing.T) {
+		vars := existing()
+		plan, collection, err := planImport(vars, imported, OverwriteConflicts)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"bar", "foo"}, plan.result.Created)
+		assert.Equal(t, []string{"foo"}, plan.result.Updated)
+		require.Equal(t, 1, len(plan.update))
+		assert.Equal(t, "var-1", plan.update[0].ID)
+		assert.Equal(t, "new", plan.update[0].Value)
+		assert.Equal(t, "new", collection[0].Value)
+		// existing variables are left untouched
+		assert.Equal(t, "old", vars[0].Value)
+	})
+
+	t.Run("overwrite sensitive variable without value", func(t *testing.T) {
+		plan, _, err := planImport(existing(), []CreateVariableOptions{
+			{
+				Key:       internal.String("secret"),
+				Category:  VariableCategoryPtr(CategoryTerraform),
+				Sensitive: internal.Bool(true),
+			},
+		}, OverwriteConflicts)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(plan.update))
+		assert.Equal(t, "hidden", plan.update[0].Value)
+	})
+
+	t.Run("duplicate imported variables", func(t *testing.T) {
+		_, _, err := planImport(nil, []CreateVariableOptions{imported[1], imported[1]}, OverwriteConflicts)
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid strategy", func(t *testing.T) {
+		_, _, err := planImport(existing(), imported, "merge")
+		assert.Error(t, err)
+	})
+}
+
+func TestFormatFromFilename(t *testing.T) {
+	for name, want := range map[string]Format{
+		"vars.hcl":         FormatHCL,
+		"terraform.tfvars": FormatTfvars,
+		"dev/vars.json":    FormatJSON,
+	} {
+		got, err := FormatFromFilename(name)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+	_, err := FormatFromFilename("vars.yaml")
+	assert.Error(t, err)
+}
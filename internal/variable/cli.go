@@ -0,0 +1,258 @@
+package variable
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	otfapi "github.com/leg100/otf/internal/api"
+	"github.com/leg100/otf/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+type (
+	CLI struct {
+		Service
+		workspace.WorkspaceService
+	}
+
+	// cliTarget identifies either a workspace or a variable set with which to
+	// import or export variables.
+	cliTarget struct {
+		organization string
+		workspace    string
+		setID        string
+	}
+)
+
+func NewCommand(api *otfapi.Client) *cobra.Command {
+	cli := &CLI{}
+	cmd := &cobra.Command{
+		Use:   "variables",
+		Short: "Variable management",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := cmd.Parent().PersistentPreRunE(cmd.Parent(), args); err != nil {
+				return err
+			}
+			cli.Service = &Client{Client: api}
+			cli.WorkspaceService = &workspace.Client{Client: api}
+			return nil
+		},
+	}
+
+	cmd.AddCommand(cli.variableEffectiveCommand())
+	cmd.AddCommand(cli.variableExportCommand())
+	cmd.AddCommand(cli.variableImportCommand())
+
+	return cmd
+}
+
+func (a *CLI) variableEffectiveCommand() *cobra.Command {
+	var target cliTarget
+
+	cmd := &cobra.Command{
+		Use:   "effective",
+		Short: "List the effective variables of a workspace",
+		Long: `List the variables in effect for runs of a workspace once precedence rules have
+been applied, along with the source of each variable and the sources of any
+variables it overrides. The values of sensitive variables are omitted.`,
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if target.workspace == "" || target.organization == "" {
+				return errors.New("specify --workspace and --organization")
+			}
+			workspaceID, err := a.getWorkspaceID(cmd.Context(), target)
+			if err != nil {
+				return err
+			}
+			variables, err := a.ListEffectiveWorkspaceVariables(cmd.Context(), workspaceID)
+			if err != nil {
+				return err
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "KEY\tCATEGORY\tVALUE\tSOURCE\tOVERRIDES")
+			for _, ev := range variables {
+				value := ev.Value
+				if ev.Sensitive {
+					value = "(sensitive)"
+				}
+				overrides := make([]string, len(ev.Overrides))
+				for i, src := range ev.Overrides {
+					overrides[i] = src.String()
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+					ev.Key,
+					ev.Category,
+					value,
+					ev.Source,
+					strings.Join(overrides, ", "),
+				)
+			}
+			return w.Flush()
+		},
+	}
+
+	cmd.Flags().StringVar(&target.organization, "organization", "", "Organization workspace belongs to")
+	cmd.Flags().StringVar(&target.workspace, "workspace", "", "Name of workspace")
+
+	return cmd
+}
+
+func (a *CLI) variableExportCommand() *cobra.Command {
+	var (
+		target cliTarget
+		format string
+		file   string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the variables of a workspace or variable set",
+		Long: `Export the variables of a workspace or variable set in HCL, tfvars, or JSON
+format, either to stdout or to a file. The values of sensitive variables are
+omitted, and the tfvars format only includes non-sensitive terraform variables.`,
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			if err := target.validate(); err != nil {
+				return err
+			}
+			f := Format(format)
+			if f == "" {
+				f = FormatHCL
+				if file != "" {
+					if f, err = FormatFromFilename(file); err != nil {
+						return err
+					}
+				}
+			}
+
+			var exported []byte
+			if target.setID != "" {
+				exported, err = a.ExportVariableSetVariables(cmd.Context(), target.setID, f)
+			} else {
+				var workspaceID string
+				workspaceID, err = a.getWorkspaceID(cmd.Context(), target)
+				if err != nil {
+					return err
+				}
+				exported, err = a.ExportWorkspaceVariables(cmd.Context(), workspaceID, f)
+			}
+			if err != nil {
+				return err
+			}
+
+			if file == "" {
+				_, err = cmd.OutOrStdout().Write(exported)
+				return err
+			}
+			if err := os.WriteFile(file, exported, 0o600); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Exported variables to %s\n", file)
+			return nil
+		},
+	}
+
+	target.addFlags(cmd)
+	cmd.Flags().StringVar(&format, "format", "", "Format of exported variables: hcl, tfvars, or json. Inferred from the file extension if omitted, otherwise defaults to hcl.")
+	cmd.Flags().StringVar(&file, "file", "", "Write variables to this file rather than stdout")
+
+	return cmd
+}
+
+func (a *CLI) variableImportCommand() *cobra.Command {
+	var (
+		target   cliTarget
+		format   string
+		strategy string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "import [file]",
+		Short: "Import variables into a workspace or variable set",
+		Long: `Import variables from a file in HCL, tfvars, or JSON format into a workspace or
+variable set. If an imported variable shares the same key and category as an
+existing variable then the strategy determines whether to fail the import, skip
+the variable, or overwrite the existing variable.`,
+		Args:          cobra.ExactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			if err := target.validate(); err != nil {
+				return err
+			}
+			opts := ImportOptions{
+				Format:   Format(format),
+				Strategy: ConflictStrategy(strategy),
+			}
+			if opts.Format == "" {
+				if opts.Format, err = FormatFromFilename(args[0]); err != nil {
+					return err
+				}
+			}
+			src, err := os.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+
+			var result *ImportResult
+			if target.setID != "" {
+				result, err = a.ImportVariableSetVariables(cmd.Context(), target.setID, src, opts)
+			} else {
+				var workspaceID string
+				workspaceID, err = a.getWorkspaceID(cmd.Context(), target)
+				if err != nil {
+					return err
+				}
+				result, err = a.ImportWorkspaceVariables(cmd.Context(), workspaceID, src, opts)
+			}
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), result)
+			return nil
+		},
+	}
+
+	target.addFlags(cmd)
+	cmd.Flags().StringVar(&format, "format", "", "Format of file: hcl, tfvars, or json. Inferred from the file extension if omitted.")
+	cmd.Flags().StringVar(&strategy, "strategy", string(FailOnConflict), "What to do with a variable that conflicts with an existing variable: fail, skip, or overwrite")
+
+	return cmd
+}
+
+func (t *cliTarget) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&t.organization, "organization", "", "Organization workspace belongs to")
+	cmd.Flags().StringVar(&t.workspace, "workspace", "", "Name of workspace")
+	cmd.Flags().StringVar(&t.setID, "variable-set", "", "ID of variable set")
+}
+
+func (t *cliTarget) validate() error {
+	if t.setID != "" {
+		if t.workspace != "" || t.organization != "" {
+			return errors.New("cannot specify both a workspace and a variable set")
+		}
+		return nil
+	}
+	if t.workspace == "" || t.organization == "" {
+		return errors.New("specify either --workspace and --organization, or --variable-set")
+	}
+	return nil
+}
+
+// getWorkspaceID retrieves the ID of the target workspace.
+func (a *CLI) getWorkspaceID(ctx context.Context, target cliTarget) (string, error) {
+	ws, err := a.GetWorkspaceByName(ctx, target.organization, target.workspace)
+	if err != nil {
+		return "", err
+	}
+	return ws.ID, nil
+}
@@ -0,0 +1,136 @@
+package variable
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/leg100/otf/internal/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVariableExport(t *testing.T) {
+	t.Run("workspace to stdout", func(t *testing.T) {
+		svc := &fakeCLIService{exported: []byte("foo = \"bar\"\n")}
+		cmd := (&CLI{Service: svc, WorkspaceService: svc}).variableExportCommand()
+		cmd.SetArgs([]string{"--organization", "acme", "--workspace", "dev", "--format", "tfvars"})
+		got := bytes.Buffer{}
+		cmd.SetOut(&got)
+		require.NoError(t, cmd.Execute())
+
+		assert.Equal(t, "foo = \"bar\"\n", got.String())
+		assert.Equal(t, "ws-dev", svc.targetID)
+		assert.Equal(t, FormatTfvars, svc.format)
+	})
+
+	t.Run("variable set to file", func(t *testing.T) {
+		svc := &fakeCLIService{exported: []byte(`[]`)}
+		cmd := (&CLI{Service: svc, WorkspaceService: svc}).variableExportCommand()
+		path := filepath.Join(t.TempDir(), "vars.json")
+		cmd.SetArgs([]string{"--variable-set", "varset-123", "--file", path})
+		cmd.SetOut(&bytes.Buffer{})
+		require.NoError(t, cmd.Execute())
+
+		assert.Equal(t, "varset-123", svc.targetID)
+		// format inferred from file extension
+		assert.Equal(t, FormatJSON, svc.format)
+		contents, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "[]", string(contents))
+	})
+
+	t.Run("missing target", func(t *testing.T) {
+		cmd := (&CLI{}).variableExportCommand()
+		cmd.SetArgs([]string{"--workspace", "dev"})
+		err := cmd.Execute()
+		assert.EqualError(t, err, "specify either --workspace and --organization, or --variable-set")
+	})
+}
+
+func TestVariableEffective(t *testing.T) {
+	svc := &fakeCLIService{effective: []*EffectiveVariable{
+		{
+			Variable: &Variable{Key: "region", Value: "eu-west-2", Category: CategoryTerraform},
+			Source:   Source{Type: SourceWorkspace},
+			Overrides: []Source{
+				{Type: SourceVariableSet, VariableSetName: "defaults", Scope: ScopeGlobal},
+			},
+		},
+		{
+			Variable: &Variable{Key: "AWS_SECRET_ACCESS_KEY", Category: CategoryEnv, Sensitive: true},
+			Source:   Source{Type: SourceVariableSet, VariableSetName: "aws", Scope: ScopeGlobal, Priority: true},
+		},
+	}}
+	cmd := (&CLI{Service: svc, WorkspaceService: svc}).variableEffectiveCommand()
+	cmd.SetArgs([]string{"--organization", "acme", "--workspace", "dev"})
+	got := bytes.Buffer{}
+	cmd.SetOut(&got)
+	require.NoError(t, cmd.Execute())
+
+	lines := strings.Split(strings.TrimSpace(got.String()), "\n")
+	require.Equal(t, 3, len(lines))
+	assert.Equal(t, []string{"KEY", "CATEGORY", "VALUE", "SOURCE", "OVERRIDES"}, strings.Fields(lines[0]))
+	assert.Regexp(t, `^region +terraform +eu-west-2 +workspace +variable set defaults \(global\)$`, lines[1])
+	assert.Regexp(t, `^AWS_SECRET_ACCESS_KEY +env +\(sensitive\) +variable set aws \(global, priority\)$`, lines[2])
+	assert.Equal(t, "ws-dev", svc.targetID)
+}
+
+func TestVariableImport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "terraform.tfvars")
+	require.NoError(t, os.WriteFile(path, []byte(`foo = "bar"`), 0o600))
+
+	svc := &fakeCLIService{result: &ImportResult{Created: []string{"foo"}}}
+	cmd := (&CLI{Service: svc, WorkspaceService: svc}).variableImportCommand()
+	cmd.SetArgs([]string{path, "--organization", "acme", "--workspace", "dev", "--strategy", "skip"})
+	got := bytes.Buffer{}
+	cmd.SetOut(&got)
+	require.NoError(t, cmd.Execute())
+
+	assert.Equal(t, "created: foo\n1 created, 0 updated, 0 skipped\n", got.String())
+	assert.Equal(t, "ws-dev", svc.targetID)
+	assert.Equal(t, ImportOptions{Format: FormatTfvars, Strategy: SkipConflicts}, svc.importOptions)
+	assert.Equal(t, `foo = "bar"`, string(svc.imported))
+}
+
+type fakeCLIService struct {
+	exported  []byte
+	result    *ImportResult
+	effective []*EffectiveVariable
+
+	// arguments received by fake
+	targetID      string
+	format        Format
+	imported      []byte
+	importOptions ImportOptions
+
+	Service
+	workspace.WorkspaceService
+}
+
+func (f *fakeCLIService) GetWorkspaceByName(ctx context.Context, organization, name string) (*workspace.Workspace, error) {
+	return &workspace.Workspace{ID: "ws-" + name, Name: name, Organization: organization}, nil
+}
+
+func (f *fakeCLIService) ExportWorkspaceVariables(ctx context.Context, workspaceID string, format Format) ([]byte, error) {
+	f.targetID, f.format = workspaceID, format
+	return f.exported, nil
+}
+
+func (f *fakeCLIService) ExportVariableSetVariables(ctx context.Context, setID string, format Format) ([]byte, error) {
+	f.targetID, f.format = setID, format
+	return f.exported, nil
+}
+
+func (f *fakeCLIService) ImportWorkspaceVariables(ctx context.Context, workspaceID string, src []byte, opts ImportOptions) (*ImportResult, error) {
+	f.targetID, f.imported, f.importOptions = workspaceID, src, opts
+	return f.result, nil
+}
+
+func (f *fakeCLIService) ListEffectiveWorkspaceVariables(ctx context.Context, workspaceID string) ([]*EffectiveVariable, error) {
+	f.targetID = workspaceID
+	return f.effective, nil
+}
@@ -1,14 +1,20 @@
 package variable
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/url"
 
 	otfapi "github.com/leg100/otf/internal/api"
 )
 
 type Client struct {
 	*otfapi.Client
+
+	// Client does not implement all of service yet
+	Service
 }
 
 func (c *Client) ListEffectiveVariables(ctx context.Context, runID string) ([]*Variable, error) {
@@ -25,3 +31,81 @@ func (c *Client) ListEffectiveVariables(ctx context.Context, runID string) ([]*V
 
 	return list, nil
 }
+
+func (c *Client) ListEffectiveWorkspaceVariables(ctx context.Context, workspaceID string) ([]*EffectiveVariable, error) {
+	u := fmt.Sprintf("workspaces/%s/vars/effective", url.QueryEscape(workspaceID))
+	req, err := c.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := c.Do(ctx, req, &buf); err != nil {
+		return nil, err
+	}
+	var list []*EffectiveVariable
+	if err := json.Unmarshal(buf.Bytes(), &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (c *Client) ExportWorkspaceVariables(ctx context.Context, workspaceID string, format Format) ([]byte, error) {
+	u := fmt.Sprintf("workspaces/%s/vars/export", url.QueryEscape(workspaceID))
+	return c.exportVariables(ctx, u, format)
+}
+
+func (c *Client) ImportWorkspaceVariables(ctx context.Context, workspaceID string, src []byte, opts ImportOptions) (*ImportResult, error) {
+	u := fmt.Sprintf("workspaces/%s/vars/import", url.QueryEscape(workspaceID))
+	return c.importVariables(ctx, u, src, opts)
+}
+
+func (c *Client) ExportVariableSetVariables(ctx context.Context, setID string, format Format) ([]byte, error) {
+	u := fmt.Sprintf("variable-sets/%s/vars/export", url.QueryEscape(setID))
+	return c.exportVariables(ctx, u, format)
+}
+
+func (c *Client) ImportVariableSetVariables(ctx context.Context, setID string, src []byte, opts ImportOptions) (*ImportResult, error) {
+	u := fmt.Sprintf("variable-sets/%s/vars/import", url.QueryEscape(setID))
+	return c.importVariables(ctx, u, src, opts)
+}
+
+func (c *Client) exportVariables(ctx context.Context, path string, format Format) ([]byte, error) {
+	req, err := c.NewRequest("GET", path, &struct {
+		Format Format `schema:"format"`
+	}{
+		Format: format,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := c.Do(ctx, req, &buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (c *Client) importVariables(ctx context.Context, path string, src []byte, opts ImportOptions) (*ImportResult, error) {
+	req, err := c.NewRequest("PUT", path, src)
+	if err != nil {
+		return nil, err
+	}
+	// newRequest() only lets us set a query or a payload but not both, so we
+	// set query here.
+	q := url.Values{}
+	q.Add("format", string(opts.Format))
+	q.Add("strategy", string(opts.Strategy))
+	req.URL.RawQuery = q.Encode()
+
+	var buf bytes.Buffer
+	if err := c.Do(ctx, req, &buf); err != nil {
+		return nil, err
+	}
+	var result ImportResult
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/jackc/pgtype"
+	"github.com/leg100/otf/internal/project"
 	"github.com/leg100/otf/internal/sql"
 	"github.com/leg100/otf/internal/sql/pggen"
 )
@@ -15,14 +16,15 @@ type (
 	}
 
 	variableRow struct {
-		VariableID  pgtype.Text `json:"variable_id"`
-		Key         pgtype.Text `json:"key"`
-		Value       pgtype.Text `json:"value"`
-		Description pgtype.Text `json:"description"`
-		Category    pgtype.Text `json:"category"`
-		Sensitive   bool        `json:"sensitive"`
-		HCL         bool        `json:"hcl"`
-		VersionID   pgtype.Text `json:"version_id"`
+		VariableID      pgtype.Text `json:"variable_id"`
+		Key             pgtype.Text `json:"key"`
+		Value           pgtype.Text `json:"value"`
+		Description     pgtype.Text `json:"description"`
+		Category        pgtype.Text `json:"category"`
+		Sensitive       bool        `json:"sensitive"`
+		HCL             bool        `json:"hcl"`
+		VersionID       pgtype.Text `json:"version_id"`
+		SecretReference bool        `json:"secret_reference"`
 	}
 
 	variableSetRow struct {
@@ -31,21 +33,24 @@ type (
 		Name             pgtype.Text       `json:"name"`
 		Description      pgtype.Text       `json:"description"`
 		OrganizationName pgtype.Text       `json:"organization_name"`
+		Priority         bool              `json:"priority"`
 		Variables        []pggen.Variables `json:"variables"`
 		WorkspaceIds     []string          `json:"workspace_ids"`
+		ProjectIds       []string          `json:"project_ids"`
 	}
 )
 
 func (row variableRow) convert() *Variable {
 	return &Variable{
-		ID:          row.VariableID.String,
-		Key:         row.Key.String,
-		Value:       row.Value.String,
-		Description: row.Description.String,
-		Category:    VariableCategory(row.Category.String),
-		Sensitive:   row.Sensitive,
-		HCL:         row.HCL,
-		VersionID:   row.VersionID.String,
+		ID:              row.VariableID.String,
+		Key:             row.Key.String,
+		Value:           row.Value.String,
+		Description:     row.Description.String,
+		Category:        VariableCategory(row.Category.String),
+		Sensitive:       row.Sensitive,
+		HCL:             row.HCL,
+		VersionID:       row.VersionID.String,
+		SecretReference: row.SecretReference,
 	}
 }
 
@@ -53,6 +58,7 @@ func (row variableSetRow) convert() *VariableSet {
 	set := &VariableSet{
 		ID:           row.VariableSetID.String,
 		Global:       row.Global,
+		Priority:     row.Priority,
 		Description:  row.Description.String,
 		Name:         row.Name.String,
 		Organization: row.OrganizationName.String,
@@ -62,6 +68,7 @@ func (row variableSetRow) convert() *VariableSet {
 		set.Variables[i] = variableRow(v).convert()
 	}
 	set.Workspaces = row.WorkspaceIds
+	set.Projects = row.ProjectIds
 	return set
 }
 
@@ -120,6 +127,7 @@ func (pdb *pgdb) createVariableSet(ctx context.Context, set *VariableSet) error
 		Description:      sql.String(set.Description),
 		Global:           set.Global,
 		OrganizationName: sql.String(set.Organization),
+		Priority:         set.Priority,
 	})
 	return sql.Error(err)
 }
@@ -130,6 +138,7 @@ func (pdb *pgdb) updateVariableSet(ctx context.Context, set *VariableSet) error
 			Name:          sql.String(set.Name),
 			Description:   sql.String(set.Description),
 			Global:        set.Global,
+			Priority:      set.Priority,
 			VariableSetID: sql.String(set.ID),
 		})
 		if err != nil {
@@ -138,7 +147,7 @@ func (pdb *pgdb) updateVariableSet(ctx context.Context, set *VariableSet) error
 
 		// lazily delete all variable set workspaces, and then add them again,
 		// regardless of whether there are any changes
-		return pdb.Lock(ctx, "variable_set_workspaces", func(ctx context.Context, q pggen.Querier) error {
+		err = pdb.Lock(ctx, "variable_set_workspaces", func(ctx context.Context, q pggen.Querier) error {
 			if err := pdb.deleteAllVariableSetWorkspaces(ctx, set.ID); err != nil {
 				return err
 			}
@@ -147,6 +156,15 @@ func (pdb *pgdb) updateVariableSet(ctx context.Context, set *VariableSet) error
 			}
 			return nil
 		})
+		if err != nil {
+			return err
+		}
+
+		// same goes for variable set projects
+		if _, err := q.DeleteVariableSetProjects(ctx, sql.String(set.ID)); err != nil {
+			return err
+		}
+		return pdb.createVariableSetProjects(ctx, set.ID, set.Organization, set.Projects)
 	})
 	return sql.Error(err)
 }
@@ -225,6 +243,28 @@ func (pdb *pgdb) createVariableSetWorkspaces(ctx context.Context, setID string,
 	return sql.Error(err)
 }
 
+// createVariableSetProjects attaches projects to a variable set. Each project
+// must belong to the same organization as the variable set.
+func (pdb *pgdb) createVariableSetProjects(ctx context.Context, setID, organization string, projectIDs []string) error {
+	err := pdb.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		for _, pid := range projectIDs {
+			row, err := q.FindProjectByID(ctx, sql.String(pid))
+			if err != nil {
+				return err
+			}
+			if row.OrganizationName.String != organization {
+				return project.ErrOrganizationMismatch
+			}
+			_, err = q.InsertVariableSetProject(ctx, sql.String(setID), sql.String(pid))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return sql.Error(err)
+}
+
 func (pdb *pgdb) deleteAllVariableSetWorkspaces(ctx context.Context, setID string) error {
 	_, err := pdb.Conn(ctx).DeleteVariableSetWorkspaces(ctx, sql.String(setID))
 	return sql.Error(err)
@@ -245,28 +285,30 @@ func (pdb *pgdb) deleteVariableSetWorkspaces(ctx context.Context, setID string,
 
 func (pdb *pgdb) createVariable(ctx context.Context, v *Variable) error {
 	_, err := pdb.Conn(ctx).InsertVariable(ctx, pggen.InsertVariableParams{
-		VariableID:  sql.String(v.ID),
-		Key:         sql.String(v.Key),
-		Value:       sql.String(v.Value),
-		Description: sql.String(v.Description),
-		Category:    sql.String(string(v.Category)),
-		Sensitive:   v.Sensitive,
-		VersionID:   sql.String(v.VersionID),
-		HCL:         v.HCL,
+		VariableID:      sql.String(v.ID),
+		Key:             sql.String(v.Key),
+		Value:           sql.String(v.Value),
+		Description:     sql.String(v.Description),
+		Category:        sql.String(string(v.Category)),
+		Sensitive:       v.Sensitive,
+		VersionID:       sql.String(v.VersionID),
+		HCL:             v.HCL,
+		SecretReference: v.SecretReference,
 	})
 	return sql.Error(err)
 }
 
 func (pdb *pgdb) updateVariable(ctx context.Context, v *Variable) error {
 	_, err := pdb.Conn(ctx).UpdateVariableByID(ctx, pggen.UpdateVariableByIDParams{
-		VariableID:  sql.String(v.ID),
-		Key:         sql.String(v.Key),
-		Value:       sql.String(v.Value),
-		Description: sql.String(v.Description),
-		Category:    sql.String(string(v.Category)),
-		Sensitive:   v.Sensitive,
-		VersionID:   sql.String(v.VersionID),
-		HCL:         v.HCL,
+		VariableID:      sql.String(v.ID),
+		Key:             sql.String(v.Key),
+		Value:           sql.String(v.Value),
+		Description:     sql.String(v.Description),
+		Category:        sql.String(string(v.Category)),
+		Sensitive:       v.Sensitive,
+		VersionID:       sql.String(v.VersionID),
+		HCL:             v.HCL,
+		SecretReference: v.SecretReference,
 	})
 	return sql.Error(err)
 }
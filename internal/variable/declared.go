@@ -0,0 +1,79 @@
+package variable
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/ext/typeexpr"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/leg100/otf/internal/configversion"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+)
+
+// declaredVariable is a variable declared in a workspace's configuration,
+// along with the variable in effect for it, if any.
+type declaredVariable struct {
+	*configversion.DeclaredVariable
+
+	// Effective is the variable in effect for the declared variable, or nil
+	// if there is none.
+	Effective *EffectiveVariable
+	// TypeError reports a value that does not conform to the declared type.
+	TypeError string
+}
+
+// Missing is true if a value must be provided for the declared variable but
+// there is no variable in effect to provide it.
+func (v declaredVariable) Missing() bool {
+	return v.Required() && v.Effective == nil
+}
+
+// matchDeclaredVariables matches variables declared in a configuration with
+// effective terraform variables.
+func matchDeclaredVariables(declared []*configversion.DeclaredVariable, effective []*EffectiveVariable) []declaredVariable {
+	matched := make([]declaredVariable, len(declared))
+	for i, decl := range declared {
+		matched[i] = declaredVariable{DeclaredVariable: decl}
+		for _, ev := range effective {
+			if ev.Category == CategoryTerraform && ev.Key == decl.Name {
+				matched[i].Effective = ev
+				if err := ev.checkType(decl.Type); err != nil {
+					matched[i].TypeError = err.Error()
+				}
+				break
+			}
+		}
+	}
+	return matched
+}
+
+// checkType checks whether the value of a terraform variable conforms to a
+// type constraint, e.g. list(string). The value of a variable that is not in
+// HCL mode is a string, which terraform converts to the declared type where
+// possible, e.g. "3" to a number. A secret reference, and a type constraint
+// that cannot be parsed, are not checked.
+func (v *Variable) checkType(constraint string) error {
+	if constraint == "" || v.SecretReference {
+		return nil
+	}
+	expr, diags := hclsyntax.ParseExpression([]byte(constraint), "", hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil
+	}
+	ty, diags := typeexpr.TypeConstraint(expr)
+	if diags.HasErrors() {
+		return nil
+	}
+	val := cty.StringVal(v.Value)
+	if v.HCL {
+		var err error
+		if val, err = parseHCLValue(v.Value); err != nil {
+			return err
+		}
+	}
+	if _, err := convert.Convert(val, ty); err != nil {
+		return fmt.Errorf("value does not conform to type %s: %w", constraint, err)
+	}
+	return nil
+}
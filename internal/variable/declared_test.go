@@ -0,0 +1,68 @@
+package variable
+
+import (
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/configversion"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVariable_checkType(t *testing.T) {
+	tests := []struct {
+		name       string
+		variable   Variable
+		constraint string
+		wantErr    bool
+	}{
+		{"no constraint", Variable{Value: "foo"}, "", false},
+		{"string", Variable{Value: "foo"}, "string", false},
+		{"string converted to number", Variable{Value: "3"}, "number", false},
+		{"string not converted to number", Variable{Value: "three"}, "number", true},
+		{"hcl list", Variable{Value: `["a", "b"]`, HCL: true}, "list(string)", false},
+		{"hcl list of wrong type", Variable{Value: `[{a = 1}]`, HCL: true}, "list(string)", true},
+		{"hcl object", Variable{Value: `{size = 3}`, HCL: true}, "object({size = number})", false},
+		{"hcl object missing attribute", Variable{Value: `{}`, HCL: true}, "object({size = number})", true},
+		{"string for list", Variable{Value: "a,b"}, "list(string)", true},
+		{"secret reference", Variable{Value: "vault://secret/data/app#count", SecretReference: true}, "number", false},
+		{"invalid constraint", Variable{Value: "foo"}, "list(", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.variable.checkType(tt.constraint)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestMatchDeclaredVariables(t *testing.T) {
+	declared := []*configversion.DeclaredVariable{
+		{Name: "instances", Type: "number"},
+		{Name: "region", Type: "string", Default: internal.String(`"eu-west-2"`)},
+		{Name: "zones", Type: "list(string)"},
+	}
+	effective := []*EffectiveVariable{
+		{Variable: &Variable{Key: "instances", Value: "many", Category: CategoryTerraform}},
+		// an environment variable does not provide a value for a terraform
+		// variable.
+		{Variable: &Variable{Key: "zones", Value: "a", Category: CategoryEnv}},
+	}
+
+	got := matchDeclaredVariables(declared, effective)
+	assert.Equal(t, 3, len(got))
+
+	assert.Equal(t, effective[0], got[0].Effective)
+	assert.False(t, got[0].Missing())
+	assert.Contains(t, got[0].TypeError, "value does not conform to type number")
+
+	assert.Nil(t, got[1].Effective)
+	assert.False(t, got[1].Missing())
+	assert.Empty(t, got[1].TypeError)
+
+	assert.Nil(t, got[2].Effective)
+	assert.True(t, got[2].Missing())
+}
@@ -0,0 +1,173 @@
+package variable
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/leg100/otf/internal/run"
+)
+
+const (
+	SourceRun         SourceType = "run"
+	SourceWorkspace   SourceType = "workspace"
+	SourceVariableSet SourceType = "variable_set"
+
+	ScopeGlobal    SetScope = "global"
+	ScopeProject   SetScope = "project"
+	ScopeWorkspace SetScope = "workspace"
+)
+
+type (
+	// SourceType is the type of source from which an effective variable
+	// originates.
+	SourceType string
+
+	// SetScope is the means by which a variable set is applied to a
+	// workspace.
+	SetScope string
+
+	// Source identifies where an effective variable is defined.
+	Source struct {
+		Type SourceType `json:"type"`
+		// Remaining fields are only populated for a variable set.
+		VariableSetID   string   `json:"variable_set_id,omitempty"`
+		VariableSetName string   `json:"variable_set_name,omitempty"`
+		Scope           SetScope `json:"scope,omitempty"`
+		Priority        bool     `json:"priority,omitempty"`
+	}
+
+	// EffectiveVariable is a variable that is in effect for a workspace or a
+	// run once precedence rules have been applied.
+	EffectiveVariable struct {
+		*Variable
+		Source Source `json:"source"`
+		// Overrides lists the sources of variables with the same key and
+		// category that this variable overrides, from highest to lowest
+		// precedence.
+		Overrides []Source `json:"overrides,omitempty"`
+	}
+)
+
+func (s Source) String() string {
+	switch s.Type {
+	case SourceVariableSet:
+		str := fmt.Sprintf("variable set %s (%s", s.VariableSetName, s.Scope)
+		if s.Priority {
+			str += ", priority"
+		}
+		return str + ")"
+	default:
+		return string(s.Type)
+	}
+}
+
+// mergeVariables merges the variables for a workspace according to the
+// precedence rules documented here:
+//
+// https://developer.hashicorp.com/terraform/cloud-docs/workspaces/variables#precedence
+//
+// From lowest to highest precedence, variables are sourced from:
+//
+// 1. global sets
+// 2. sets applied via the workspace's project
+// 3. sets applied directly to the workspace
+// 4. the workspace
+// 5. the run
+// 6. priority sets, in the same order of scope as non-priority sets
+//
+// If a variable with the same key and category is found in more than one set
+// with the same scope and priority, then the lexical order of the set name
+// determines precedence, e.g. variable foo in set named A takes precedence
+// over variable foo in set named B.
+//
+// Note: runVariables may be nil.
+func mergeVariables(workspaceID string, workspaceSets []*VariableSet, workspaceVariables []*Variable, runVariables []run.Variable) []*EffectiveVariable {
+	// effective variables, keyed by category and then by key
+	merged := map[VariableCategory]map[string]*EffectiveVariable{
+		CategoryTerraform: make(map[string]*EffectiveVariable),
+		CategoryEnv:       make(map[string]*EffectiveVariable),
+	}
+	// ordered list of effective variables, to ensure deterministic output.
+	var ordered []*EffectiveVariable
+	add := func(v *Variable, src Source) {
+		vars, ok := merged[v.Category]
+		if !ok {
+			return
+		}
+		ev := &EffectiveVariable{Variable: v, Source: src}
+		if existing, ok := vars[v.Key]; ok {
+			ev.Overrides = append([]Source{existing.Source}, existing.Overrides...)
+			ordered[slices.Index(ordered, existing)] = ev
+		} else {
+			ordered = append(ordered, ev)
+		}
+		vars[v.Key] = ev
+	}
+
+	// sort sets by reverse lexical order, Z->A, so that sets later in the
+	// slice take precedence.
+	sets := slices.Clone(workspaceSets)
+	slices.SortFunc(sets, func(a, b *VariableSet) int {
+		if a.Name > b.Name {
+			return -1
+		} else if a.Name < b.Name {
+			return 1
+		} else {
+			return 0
+		}
+	})
+	addSets := func(priority bool) {
+		for _, scope := range []SetScope{ScopeGlobal, ScopeProject, ScopeWorkspace} {
+			for _, s := range sets {
+				if s.Priority != priority || s.scope(workspaceID) != scope {
+					continue
+				}
+				src := Source{
+					Type:            SourceVariableSet,
+					VariableSetID:   s.ID,
+					VariableSetName: s.Name,
+					Scope:           scope,
+					Priority:        s.Priority,
+				}
+				for _, v := range s.Variables {
+					add(v, src)
+				}
+			}
+		}
+	}
+
+	addSets(false)
+	for _, v := range workspaceVariables {
+		add(v, Source{Type: SourceWorkspace})
+	}
+	for _, v := range runVariables {
+		add(&Variable{Key: v.Key, Value: v.Value, Category: CategoryTerraform, HCL: true}, Source{Type: SourceRun})
+	}
+	// priority sets override everything else
+	addSets(true)
+
+	return ordered
+}
+
+// scope determines how the set is applied to the given workspace. A set that
+// is global ignores any workspaces it specifies, and a set that is applied both
+// directly to the workspace and via its project is considered to be applied
+// directly.
+func (s *VariableSet) scope(workspaceID string) SetScope {
+	if s.Global {
+		return ScopeGlobal
+	}
+	if slices.Contains(s.Workspaces, workspaceID) {
+		return ScopeWorkspace
+	}
+	return ScopeProject
+}
+
+// effectiveVariables returns the variables from a list of effective variables.
+func effectiveVariables(from []*EffectiveVariable) []*Variable {
+	to := make([]*Variable, len(from))
+	for i, ev := range from {
+		to[i] = ev.Variable
+	}
+	return to
+}
@@ -0,0 +1,357 @@
+package variable
+
+import (
+	"testing"
+
+	"github.com/leg100/otf/internal/run"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_mergeVariables(t *testing.T) {
+	tests := []struct {
+		name               string
+		sets               []*VariableSet
+		workspaceVariables []*Variable
+		run                run.Run
+		want               []*Variable
+	}{
+		{
+			name: "default",
+			sets: []*VariableSet{
+				{
+					Name:   "global",
+					Global: true,
+					Variables: []*Variable{
+						{
+							Key:      "global",
+							Value:    "true",
+							Category: CategoryTerraform,
+						},
+					},
+				},
+				{
+					Name:       "workspace-scoped",
+					Workspaces: []string{"ws-123"},
+					Variables: []*Variable{
+						{
+							Key:      "workspace-scoped",
+							Value:    "true",
+							Category: CategoryTerraform,
+						},
+					},
+				},
+			},
+			workspaceVariables: []*Variable{
+				{
+					Key:      "workspace",
+					Value:    "true",
+					Category: CategoryTerraform,
+				},
+			},
+			run: run.Run{WorkspaceID: "ws-123", Variables: []run.Variable{{Key: "run", Value: "true"}}},
+			want: []*Variable{
+				{
+					Key:      "global",
+					Value:    "true",
+					Category: CategoryTerraform,
+				},
+				{
+					Key:      "workspace-scoped",
+					Value:    "true",
+					Category: CategoryTerraform,
+				},
+				{
+					Key:      "workspace",
+					Value:    "true",
+					Category: CategoryTerraform,
+				},
+				{
+					Key:      "run",
+					Value:    "true",
+					Category: CategoryTerraform,
+					HCL:      true,
+				},
+			},
+		},
+		{
+			name: "workspace-scoped set lexical precedence",
+			sets: []*VariableSet{
+				{
+					Name:       "set_A",
+					Workspaces: []string{"ws-123"},
+					Variables: []*Variable{
+						{
+							Key:      "foo",
+							Value:    "set_a",
+							Category: CategoryTerraform,
+						},
+					},
+				},
+				{
+					Name:       "set_B",
+					Workspaces: []string{"ws-123"},
+					Variables: []*Variable{
+						{
+							Key:      "foo",
+							Value:    "set_b",
+							Category: CategoryTerraform,
+						},
+					},
+				},
+			},
+			run: run.Run{WorkspaceID: "ws-123"},
+			want: []*Variable{
+				{
+					Key:      "foo",
+					Value:    "set_a",
+					Category: CategoryTerraform,
+				},
+			},
+		},
+		// a variable set can be set both to global and also specify workspaces,
+		// in which case the workspaces should be ignored and not considered as
+		// part of determining precedence.
+		{
+			name: "ignore workspaces in global sets",
+			sets: []*VariableSet{
+				{
+					// even though this has lexical precedence, it is global and
+					// thus have lower precedence than the workspace-scoped set
+					// below.
+					Name:       "a - global with workspaces",
+					Global:     true,
+					Workspaces: []string{"ws-123"},
+					Variables: []*Variable{
+						{
+							Key:      "foo",
+							Value:    "global",
+							Category: CategoryTerraform,
+						},
+					},
+				},
+				{
+					Name:       "b - workspace-scoped",
+					Workspaces: []string{"ws-123"},
+					Variables: []*Variable{
+						{
+							Key:      "foo",
+							Value:    "workspace-scoped",
+							Category: CategoryTerraform,
+						},
+					},
+				},
+			},
+			want: []*Variable{
+				{
+					Key:      "foo",
+					Value:    "workspace-scoped",
+					Category: CategoryTerraform,
+				},
+			},
+		},
+		// a set applied directly to the workspace takes precedence over a set
+		// applied via the workspace's project, regardless of lexical order.
+		{
+			name: "workspace-scoped set overrides project-scoped set",
+			run:  run.Run{WorkspaceID: "ws-123"},
+			sets: []*VariableSet{
+				{
+					Name:   "global",
+					Global: true,
+					Variables: []*Variable{
+						{
+							Key:      "foo",
+							Value:    "global",
+							Category: CategoryTerraform,
+						},
+					},
+				},
+				{
+					Name:     "a - project-scoped",
+					Projects: []string{"prj-123"},
+					Variables: []*Variable{
+						{
+							Key:      "foo",
+							Value:    "project-scoped",
+							Category: CategoryTerraform,
+						},
+						{
+							Key:      "bar",
+							Value:    "project-scoped",
+							Category: CategoryTerraform,
+						},
+					},
+				},
+				{
+					Name:       "b - workspace-scoped",
+					Workspaces: []string{"ws-123"},
+					Variables: []*Variable{
+						{
+							Key:      "foo",
+							Value:    "workspace-scoped",
+							Category: CategoryTerraform,
+						},
+					},
+				},
+			},
+			want: []*Variable{
+				{
+					Key:      "foo",
+					Value:    "workspace-scoped",
+					Category: CategoryTerraform,
+				},
+				{
+					Key:      "bar",
+					Value:    "project-scoped",
+					Category: CategoryTerraform,
+				},
+			},
+		},
+		{
+			name: "priority set overrides workspace and run variables",
+			run:  run.Run{WorkspaceID: "ws-123", Variables: []run.Variable{{Key: "foo", Value: "run"}}},
+			sets: []*VariableSet{
+				{
+					Name:     "priority",
+					Global:   true,
+					Priority: true,
+					Variables: []*Variable{
+						{
+							Key:      "foo",
+							Value:    "priority",
+							Category: CategoryTerraform,
+						},
+					},
+				},
+			},
+			workspaceVariables: []*Variable{
+				{
+					Key:      "foo",
+					Value:    "workspace",
+					Category: CategoryTerraform,
+				},
+				{
+					Key:      "bar",
+					Value:    "workspace",
+					Category: CategoryTerraform,
+				},
+			},
+			want: []*Variable{
+				{
+					Key:      "foo",
+					Value:    "priority",
+					Category: CategoryTerraform,
+				},
+				{
+					Key:      "bar",
+					Value:    "workspace",
+					Category: CategoryTerraform,
+				},
+			},
+		},
+		// between priority sets, the more specific scope takes precedence
+		{
+			name: "workspace-scoped priority set overrides global priority set",
+			run:  run.Run{WorkspaceID: "ws-123"},
+			sets: []*VariableSet{
+				{
+					Name:       "b - workspace-scoped priority",
+					Workspaces: []string{"ws-123"},
+					Priority:   true,
+					Variables: []*Variable{
+						{
+							Key:      "foo",
+							Value:    "workspace-scoped",
+							Category: CategoryTerraform,
+						},
+					},
+				},
+				{
+					Name:     "a - global priority",
+					Global:   true,
+					Priority: true,
+					Variables: []*Variable{
+						{
+							Key:      "foo",
+							Value:    "global",
+							Category: CategoryTerraform,
+						},
+					},
+				},
+			},
+			want: []*Variable{
+				{
+					Key:      "foo",
+					Value:    "workspace-scoped",
+					Category: CategoryTerraform,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := effectiveVariables(mergeVariables(tt.run.WorkspaceID, tt.sets, tt.workspaceVariables, tt.run.Variables))
+			assert.Equal(t, len(tt.want), len(got))
+			for _, w := range tt.want {
+				assert.Contains(t, got, w)
+			}
+		})
+	}
+}
+
+func Test_mergeVariables_sources(t *testing.T) {
+	global := &VariableSet{
+		ID:     "varset-global",
+		Name:   "global",
+		Global: true,
+		Variables: []*Variable{
+			{ID: "var-global-foo", Key: "foo", Value: "global", Category: CategoryTerraform},
+			{ID: "var-global-bar", Key: "bar", Value: "global", Category: CategoryTerraform},
+		},
+	}
+	project := &VariableSet{
+		ID:       "varset-project",
+		Name:     "project",
+		Projects: []string{"prj-123"},
+		Variables: []*Variable{
+			{ID: "var-project-foo", Key: "foo", Value: "project", Category: CategoryTerraform},
+		},
+	}
+	priority := &VariableSet{
+		ID:       "varset-priority",
+		Name:     "priority",
+		Global:   true,
+		Priority: true,
+		Variables: []*Variable{
+			{ID: "var-priority-baz", Key: "baz", Value: "priority", Category: CategoryEnv},
+		},
+	}
+	sets := []*VariableSet{priority, project, global}
+	workspaceVariables := []*Variable{
+		{ID: "var-ws-foo", Key: "foo", Value: "workspace", Category: CategoryTerraform},
+		{ID: "var-ws-baz", Key: "baz", Value: "workspace", Category: CategoryEnv},
+	}
+
+	got := mergeVariables("ws-123", sets, workspaceVariables, nil)
+	require.Equal(t, 3, len(got))
+
+	// variables are ordered by when they were first defined, from the lowest
+	// precedence source to the highest.
+	assert.Equal(t, "var-ws-foo", got[0].ID)
+	assert.Equal(t, Source{Type: SourceWorkspace}, got[0].Source)
+	assert.Equal(t, []Source{
+		{Type: SourceVariableSet, VariableSetID: "varset-project", VariableSetName: "project", Scope: ScopeProject},
+		{Type: SourceVariableSet, VariableSetID: "varset-global", VariableSetName: "global", Scope: ScopeGlobal},
+	}, got[0].Overrides)
+
+	assert.Equal(t, "var-global-bar", got[1].ID)
+	assert.Empty(t, got[1].Overrides)
+
+	assert.Equal(t, "var-priority-baz", got[2].ID)
+	assert.Equal(t, "variable set priority (global, priority)", got[2].Source.String())
+	assert.Equal(t, []Source{{Type: SourceWorkspace}}, got[2].Overrides)
+
+	// sets provided by caller are not re-ordered
+	assert.Equal(t, []*VariableSet{priority, project, global}, sets)
+}
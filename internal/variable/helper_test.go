@@ -17,3 +17,15 @@ func (f *fakeService) UpdateWorkspaceVariable(ctx context.Context, variableID st
 	}
 	return &WorkspaceVariable{WorkspaceID: f.workspaceID, Variable: f.v}, nil
 }
+
+func (f *fakeService) ImportWorkspaceVariables(ctx context.Context, workspaceID string, src []byte, opts ImportOptions) (*ImportResult, error) {
+	imported, err := importVariables(src, opts.Format)
+	if err != nil {
+		return nil, err
+	}
+	plan, _, err := planImport([]*Variable{f.v}, imported, opts.Strategy)
+	if err != nil {
+		return nil, err
+	}
+	return &plan.result, nil
+}
@@ -2,12 +2,17 @@ package variable
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/go-logr/logr"
 	"github.com/gorilla/mux"
 	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/configversion"
 	"github.com/leg100/otf/internal/http/html"
 	"github.com/leg100/otf/internal/organization"
+	"github.com/leg100/otf/internal/project"
+	"github.com/leg100/otf/internal/pubsub"
 	"github.com/leg100/otf/internal/rbac"
 	"github.com/leg100/otf/internal/run"
 	"github.com/leg100/otf/internal/sql"
@@ -24,12 +29,23 @@ type (
 		// merging variable sets, workspace variables, and run variables, and
 		// removing those that are overridden according to precedence rules.
 		ListEffectiveVariables(ctx context.Context, runID string) ([]*Variable, error)
+		// ListEffectiveWorkspaceVariables lists the effective variables for a
+		// workspace, along with the source of each variable and the sources of
+		// any variables it overrides.
+		ListEffectiveWorkspaceVariables(ctx context.Context, workspaceID string) ([]*EffectiveVariable, error)
 
 		CreateWorkspaceVariable(ctx context.Context, workspaceID string, opts CreateVariableOptions) (*Variable, error)
 		UpdateWorkspaceVariable(ctx context.Context, variableID string, opts UpdateVariableOptions) (*WorkspaceVariable, error)
 		ListWorkspaceVariables(ctx context.Context, workspaceID string) ([]*Variable, error)
 		GetWorkspaceVariable(ctx context.Context, variableID string) (*WorkspaceVariable, error)
 		DeleteWorkspaceVariable(ctx context.Context, variableID string) (*WorkspaceVariable, error)
+		// ExportWorkspaceVariables encodes a workspace's variables in the
+		// given format, omitting the values of sensitive variables.
+		ExportWorkspaceVariables(ctx context.Context, workspaceID string, format Format) ([]byte, error)
+		// ImportWorkspaceVariables decodes variables in the given format and
+		// adds them to a workspace. Either all variables are imported or none
+		// are.
+		ImportWorkspaceVariables(ctx context.Context, workspaceID string, src []byte, opts ImportOptions) (*ImportResult, error)
 
 		createVariableSet(ctx context.Context, organization string, opts CreateVariableSetOptions) (*VariableSet, error)
 		updateVariableSet(ctx context.Context, setID string, opts UpdateVariableSetOptions) (*VariableSet, error)
@@ -42,6 +58,13 @@ type (
 		createVariableSetVariable(ctx context.Context, setID string, opts CreateVariableOptions) (*Variable, error)
 		updateVariableSetVariable(ctx context.Context, variableID string, opts UpdateVariableOptions) (*VariableSet, error)
 		deleteVariableSetVariable(ctx context.Context, variableID string) (*VariableSet, error)
+		// ExportVariableSetVariables encodes a variable set's variables in the
+		// given format, omitting the values of sensitive variables.
+		ExportVariableSetVariables(ctx context.Context, setID string, format Format) ([]byte, error)
+		// ImportVariableSetVariables decodes variables in the given format and
+		// adds them to a variable set. Either all variables are imported or
+		// none are.
+		ImportVariableSetVariables(ctx context.Context, setID string, src []byte, opts ImportOptions) (*ImportResult, error)
 
 		applySetToWorkspaces(ctx context.Context, setID string, workspaceIDs []string) error
 		deleteSetFromWorkspaces(ctx context.Context, setID string, workspaceIDs []string) error
@@ -62,8 +85,12 @@ type (
 	Options struct {
 		WorkspaceAuthorizer internal.Authorizer
 		WorkspaceService    workspace.Service
+		ProjectService      project.ProjectService
+
+		configversion.ConfigurationVersionService
 
 		*sql.DB
+		*pubsub.Broker
 		*tfeapi.Responder
 		html.Renderer
 		logr.Logger
@@ -81,9 +108,12 @@ func NewService(opts Options) *service {
 	}
 
 	svc.web = &web{
-		Renderer: opts.Renderer,
-		Service:  opts.WorkspaceService,
-		svc:      &svc,
+		Renderer:       opts.Renderer,
+		Service:        opts.WorkspaceService,
+		ProjectService: opts.ProjectService,
+		svc:            &svc,
+
+		ConfigurationVersionService: opts.ConfigurationVersionService,
 	}
 	svc.tfeapi = &tfe{
 		Service:   &svc,
@@ -94,6 +124,9 @@ func NewService(opts Options) *service {
 		Responder: opts.Responder,
 	}
 
+	// Register with broker so that it can relay workspace variable events
+	opts.Broker.Register("workspace_variables", &svc)
+
 	return &svc
 }
 
@@ -116,7 +149,19 @@ func (s *service) ListEffectiveVariables(ctx context.Context, runID string) ([]*
 	if err != nil {
 		return nil, err
 	}
-	return mergeVariables(sets, vars, run), nil
+	return effectiveVariables(mergeVariables(run.WorkspaceID, sets, vars, run.Variables)), nil
+}
+
+func (s *service) ListEffectiveWorkspaceVariables(ctx context.Context, workspaceID string) ([]*EffectiveVariable, error) {
+	sets, err := s.listWorkspaceVariableSets(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	vars, err := s.ListWorkspaceVariables(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	return mergeVariables(workspaceID, sets, vars, nil), nil
 }
 
 func (s *service) CreateWorkspaceVariable(ctx context.Context, workspaceID string, opts CreateVariableOptions) (*Variable, error) {
@@ -228,6 +273,19 @@ func (s *service) GetWorkspaceVariable(ctx context.Context, variableID string) (
 	return wv, nil
 }
 
+// GetByID implements pubsub.Getter. The ID identifies both the workspace and
+// the variable, separated by a colon.
+func (s *service) GetByID(ctx context.Context, id string, action pubsub.DBAction) (any, error) {
+	workspaceID, variableID, ok := strings.Cut(id, ":")
+	if !ok {
+		return nil, fmt.Errorf("malformed workspace variable event ID: %s", id)
+	}
+	if action == pubsub.DeleteDBAction {
+		return &WorkspaceVariable{WorkspaceID: workspaceID, Variable: &Variable{ID: variableID}}, nil
+	}
+	return s.db.getWorkspaceVariable(ctx, variableID)
+}
+
 func (s *service) DeleteWorkspaceVariable(ctx context.Context, variableID string) (*WorkspaceVariable, error) {
 	var (
 		subject internal.Subject
@@ -254,6 +312,60 @@ func (s *service) DeleteWorkspaceVariable(ctx context.Context, variableID string
 	return wv, nil
 }
 
+func (s *service) ExportWorkspaceVariables(ctx context.Context, workspaceID string, format Format) ([]byte, error) {
+	vars, err := s.ListWorkspaceVariables(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	return exportVariables(vars, format)
+}
+
+func (s *service) ImportWorkspaceVariables(ctx context.Context, workspaceID string, src []byte, opts ImportOptions) (*ImportResult, error) {
+	subject, err := s.workspace.CanAccess(ctx, rbac.CreateWorkspaceVariableAction, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.workspace.CanAccess(ctx, rbac.UpdateWorkspaceVariableAction, workspaceID); err != nil {
+		return nil, err
+	}
+
+	imported, err := importVariables(src, opts.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	var plan *importPlan
+	err = s.db.Lock(ctx, "variables", func(ctx context.Context, q pggen.Querier) (err error) {
+		workspaceVars, err := s.db.listWorkspaceVariables(ctx, workspaceID)
+		if err != nil {
+			return err
+		}
+		plan, _, err = planImport(workspaceVars, imported, opts.Strategy)
+		if err != nil {
+			return err
+		}
+		for _, v := range plan.create {
+			if err := s.db.createWorkspaceVariable(ctx, workspaceID, v); err != nil {
+				return err
+			}
+		}
+		for _, v := range plan.update {
+			if err := s.db.updateVariable(ctx, v); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		s.Error(err, "importing workspace variables", "subject", subject, "workspace_id", workspaceID)
+		return nil, err
+	}
+	s.V(1).Info("imported workspace variables", "subject", subject, "workspace_id", workspaceID,
+		"created", len(plan.result.Created), "updated", len(plan.result.Updated), "skipped", len(plan.result.Skipped))
+
+	return &plan.result, nil
+}
+
 func (s *service) createVariableSet(ctx context.Context, organization string, opts CreateVariableSetOptions) (*VariableSet, error) {
 	subject, err := s.organization.CanAccess(ctx, rbac.CreateVariableSetAction, organization)
 	if err != nil {
@@ -273,6 +385,9 @@ func (s *service) createVariableSet(ctx context.Context, organization string, op
 		if err := s.db.createVariableSetWorkspaces(ctx, set.ID, opts.Workspaces); err != nil {
 			return err
 		}
+		if err := s.db.createVariableSetProjects(ctx, set.ID, set.Organization, opts.Projects); err != nil {
+			return err
+		}
 		return nil
 	})
 	if err != nil {
@@ -518,6 +633,72 @@ func (s *service) deleteVariableSetVariable(ctx context.Context, variableID stri
 	return set, nil
 }
 
+func (s *service) ExportVariableSetVariables(ctx context.Context, setID string, format Format) ([]byte, error) {
+	set, err := s.getVariableSet(ctx, setID)
+	if err != nil {
+		return nil, err
+	}
+	return exportVariables(set.Variables, format)
+}
+
+func (s *service) ImportVariableSetVariables(ctx context.Context, setID string, src []byte, opts ImportOptions) (*ImportResult, error) {
+	imported, err := importVariables(src, opts.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		subject internal.Subject
+		plan    *importPlan
+	)
+	err = s.db.Lock(ctx, "variables", func(ctx context.Context, q pggen.Querier) (err error) {
+		set, err := s.db.getVariableSet(ctx, setID)
+		if err != nil {
+			return err
+		}
+		subject, err = s.organization.CanAccess(ctx, rbac.AddVariableToSetAction, set.Organization)
+		if err != nil {
+			return err
+		}
+		if _, err := s.organization.CanAccess(ctx, rbac.UpdateVariableSetAction, set.Organization); err != nil {
+			return err
+		}
+
+		organizationSets, err := s.db.listVariableSets(ctx, set.Organization)
+		if err != nil {
+			return err
+		}
+
+		plan, set.Variables, err = planImport(set.Variables, imported, opts.Strategy)
+		if err != nil {
+			return err
+		}
+		if err := set.checkGlobalConflicts(organizationSets); err != nil {
+			return err
+		}
+
+		for _, v := range plan.create {
+			if err := s.db.addVariableToSet(ctx, setID, v); err != nil {
+				return err
+			}
+		}
+		for _, v := range plan.update {
+			if err := s.db.updateVariable(ctx, v); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		s.Error(err, "importing variable set variables", "subject", subject, "set_id", setID)
+		return nil, err
+	}
+	s.V(1).Info("imported variable set variables", "subject", subject, "set_id", setID,
+		"created", len(plan.result.Created), "updated", len(plan.result.Updated), "skipped", len(plan.result.Skipped))
+
+	return &plan.result, nil
+}
+
 func (s *service) applySetToWorkspaces(ctx context.Context, setID string, workspaceIDs []string) error {
 	// retrieve set first in order to retrieve organization name for authorization
 	set, err := s.db.getVariableSet(ctx, setID)
@@ -10,11 +10,15 @@ import (
 type (
 	// VariableSet is a set of variables
 	VariableSet struct {
-		ID           string
-		Name         string
-		Description  string
-		Global       bool
+		ID          string
+		Name        string
+		Description string
+		Global      bool
+		// Priority sets override variables with a more specific scope,
+		// including workspace and run variables.
+		Priority     bool
 		Workspaces   []string // workspace IDs
+		Projects     []string // project IDs
 		Organization string   // org name
 		Variables    []*Variable
 	}
@@ -23,14 +27,18 @@ type (
 		Name        string
 		Description string
 		Global      bool
+		Priority    bool
 		Workspaces  []string // workspace IDs
+		Projects    []string // project IDs
 	}
 
 	UpdateVariableSetOptions struct {
 		Name        *string
 		Description *string
 		Global      *bool
+		Priority    *bool
 		Workspaces  []string // workspace IDs
+		Projects    []string // project IDs
 	}
 )
 
@@ -40,6 +48,7 @@ func newSet(organization string, opts CreateVariableSetOptions) (*VariableSet, e
 		Name:         opts.Name,
 		Description:  opts.Description,
 		Global:       opts.Global,
+		Priority:     opts.Priority,
 		Organization: organization,
 	}, nil
 }
@@ -50,7 +59,9 @@ func (s *VariableSet) LogValue() slog.Value {
 		slog.String("name", s.Name),
 		slog.String("organization", s.Organization),
 		slog.Bool("global", s.Global),
+		slog.Bool("priority", s.Priority),
 		slog.Any("workspaces", s.Workspaces),
+		slog.Any("projects", s.Projects),
 	}
 	return slog.GroupValue(attrs...)
 }
@@ -90,9 +101,15 @@ func (s *VariableSet) updateProperties(organizationSets []*VariableSet, opts Upd
 	if opts.Global != nil {
 		s.Global = *opts.Global
 	}
+	if opts.Priority != nil {
+		s.Priority = *opts.Priority
+	}
 	if opts.Workspaces != nil {
 		s.Workspaces = opts.Workspaces
 	}
+	if opts.Projects != nil {
+		s.Projects = opts.Projects
+	}
 	if err := s.checkGlobalConflicts(organizationSets); err != nil {
 		return err
 	}
@@ -114,7 +131,10 @@ func (s *VariableSet) getVariable(variableID string) *Variable {
 //
 // (a) set contains more than one variable sharing the same key and category
 // (b) set is global and contains a variable that shares the same key and category as another
-// variable in another global set in the given sets
+// variable in another global set in the given sets with the same priority
+//
+// A global priority set is permitted to share variables with a global
+// non-priority set, because the former takes precedence.
 func (s *VariableSet) checkGlobalConflicts(organizationSets []*VariableSet) error {
 	if !s.Global {
 		// only global sets conflict with one another
@@ -125,8 +145,9 @@ func (s *VariableSet) checkGlobalConflicts(organizationSets []*VariableSet) erro
 			// skip same variable set
 			continue
 		}
-		if !other.Global {
-			// set can only conflict with other global sets
+		if !other.Global || other.Priority != s.Priority {
+			// set can only conflict with other global sets with the same
+			// priority
 			continue
 		}
 		// check for conflicts between each set variable and each variable in all
@@ -54,6 +54,19 @@ func TestVariableSet_checkGlobalConflicts(t *testing.T) {
 			},
 			want: ErrVariableConflict,
 		},
+		{
+			name: "global priority set does not conflict with global set",
+			set: VariableSet{
+				Global:   true,
+				Priority: true,
+				Variables: []*Variable{
+					{
+						Key:      "foo",
+						Category: CategoryTerraform,
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -158,11 +158,15 @@ func (a *tfe) createVariableSet(w http.ResponseWriter, r *http.Request) {
 		tfeapi.Error(w, err)
 		return
 	}
-	set, err := a.Service.createVariableSet(r.Context(), org, CreateVariableSetOptions{
+	opts := CreateVariableSetOptions{
 		Name:        params.Name,
 		Description: params.Description,
 		Global:      params.Global,
-	})
+	}
+	if params.Priority != nil {
+		opts.Priority = *params.Priority
+	}
+	set, err := a.Service.createVariableSet(r.Context(), org, opts)
 	if err != nil {
 		tfeapi.Error(w, err)
 		return
@@ -185,6 +189,7 @@ func (a *tfe) updateVariableSet(w http.ResponseWriter, r *http.Request) {
 		Name:        params.Name,
 		Description: params.Description,
 		Global:      params.Global,
+		Priority:    params.Priority,
 	})
 	if err != nil {
 		tfeapi.Error(w, err)
@@ -440,6 +445,7 @@ func (a *tfe) convertVariableSet(from *VariableSet) *types.VariableSet {
 		Name:        from.Name,
 		Description: from.Description,
 		Global:      from.Global,
+		Priority:    from.Priority,
 		Organization: &types.Organization{
 			Name: from.Organization,
 		},
@@ -459,6 +465,12 @@ func (a *tfe) convertVariableSet(from *VariableSet) *types.VariableSet {
 			ID: workspaceID,
 		}
 	}
+	to.Projects = make([]*types.Project, len(from.Projects))
+	for i, projectID := range from.Projects {
+		to.Projects[i] = &types.Project{
+			ID: projectID,
+		}
+	}
 	return to
 }
 
@@ -7,14 +7,15 @@ import (
 	"fmt"
 	"os"
 	"path"
-	"slices"
 	"strings"
 
 	"log/slog"
 
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/leg100/otf/internal"
-	"github.com/leg100/otf/internal/run"
-	"golang.org/x/exp/maps"
+	"github.com/leg100/otf/internal/secrets"
+	"github.com/zclconf/go-cty/cty"
 )
 
 const (
@@ -29,6 +30,9 @@ var (
 	ErrVariableKeyMaxExceeded         = fmt.Errorf("maximum variable key size (%d chars) exceeded", VariableKeyMaxChars)
 	ErrVariableValueMaxExceeded       = fmt.Errorf("maximum variable value size of %d KB exceeded", VariableValueMaxKB)
 	ErrVariableConflict               = errors.New("variable conflicts with another variable with the same name and type")
+	ErrInvalidHCLValue                = errors.New("invalid HCL value")
+	ErrInvalidSecretReference         = fmt.Errorf("invalid secret reference: must be of the form <%s>://<path>[#<key>]", strings.Join(secrets.Schemes, "|"))
+	ErrSecretReferenceHCL             = errors.New("a secret reference cannot be parsed as HCL")
 )
 
 type (
@@ -40,6 +44,9 @@ type (
 		Category    VariableCategory `jsonapi:"attribute" json:"category"`
 		Sensitive   bool             `jsonapi:"attribute" json:"sensitive"`
 		HCL         bool             `jsonapi:"attribute" json:"hcl"`
+		// SecretReference is true if the value is a reference to a secret in
+		// an external secret store, which is resolved by the agent.
+		SecretReference bool `jsonapi:"attribute" json:"secret_reference"`
 
 		// OTF doesn't use this internally but the go-tfe integration tests
 		// expect it to be a random value that changes on every update.
@@ -58,6 +65,8 @@ type (
 		Category    *VariableCategory
 		Sensitive   *bool
 		HCL         *bool
+		// SecretReference is true if the value is a secret reference.
+		SecretReference *bool
 
 		generateVersion
 	}
@@ -69,6 +78,8 @@ type (
 		Category    *VariableCategory
 		Sensitive   *bool
 		HCL         *bool
+		// SecretReference is true if the value is a secret reference.
+		SecretReference *bool
 
 		generateVersion
 	}
@@ -117,6 +128,15 @@ func newVariable(collection []*Variable, opts CreateVariableOptions) (*Variable,
 	if opts.HCL != nil {
 		v.HCL = *opts.HCL
 	}
+	if opts.SecretReference != nil {
+		v.SecretReference = *opts.SecretReference
+	}
+	if err := v.validateSecretReference(); err != nil {
+		return nil, err
+	}
+	if err := v.validateHCL(); err != nil {
+		return nil, err
+	}
 	if err := v.checkConflicts(collection); err != nil {
 		return nil, err
 	}
@@ -181,6 +201,15 @@ func (v *Variable) update(collection []*Variable, opts UpdateVariableOptions) er
 			return err
 		}
 	}
+	if opts.SecretReference != nil {
+		v.SecretReference = *opts.SecretReference
+	}
+	if err := v.validateSecretReference(); err != nil {
+		return err
+	}
+	if err := v.validateHCL(); err != nil {
+		return err
+	}
 	// check for conflicts with other variables in collection
 	if err := v.checkConflicts(collection); err != nil {
 		return err
@@ -235,6 +264,65 @@ func (v *Variable) setSensitive(sensitive bool) error {
 	return nil
 }
 
+// validateHCL checks that the value of a terraform variable in HCL mode is a
+// valid HCL expression that can be evaluated without any variables or
+// functions, as is required of values in a terraform.tfvars file. An empty
+// value is not validated.
+func (v *Variable) validateHCL() error {
+	if !v.HCL || v.Category != CategoryTerraform || v.Value == "" {
+		return nil
+	}
+	_, err := parseHCLValue(v.Value)
+	return err
+}
+
+// validateSecretReference checks that the value of a variable flagged as a
+// secret reference is a well-formed reference. A reference resolves to a
+// string, which is written verbatim for a variable in HCL mode, so the two
+// are mutually exclusive.
+func (v *Variable) validateSecretReference() error {
+	if !v.SecretReference {
+		return nil
+	}
+	if v.HCL {
+		return ErrSecretReferenceHCL
+	}
+	if _, ok := secrets.ParseReference(v.Value); !ok {
+		return ErrInvalidSecretReference
+	}
+	return nil
+}
+
+// parseHCLValue parses and evaluates an HCL expression.
+func parseHCLValue(value string) (cty.Value, error) {
+	expr, diags := hclsyntax.ParseExpression([]byte(value), "", hcl.InitialPos)
+	if diags.HasErrors() {
+		return cty.NilVal, fmt.Errorf("%w: %s", ErrInvalidHCLValue, formatDiagnostics(diags))
+	}
+	val, diags := expr.Value(nil)
+	if diags.HasErrors() {
+		return cty.NilVal, fmt.Errorf("%w: %s", ErrInvalidHCLValue, formatDiagnostics(diags))
+	}
+	return val, nil
+}
+
+// formatDiagnostics formats HCL error diagnostics, omitting their source
+// location, which is meaningless for a variable value.
+func formatDiagnostics(diags hcl.Diagnostics) string {
+	var msgs []string
+	for _, diag := range diags.Errs() {
+		msg := diag.Error()
+		if d, ok := diag.(*hcl.Diagnostic); ok {
+			msg = d.Summary
+			if d.Detail != "" {
+				msg += ": " + d.Detail
+			}
+		}
+		msgs = append(msgs, msg)
+	}
+	return strings.Join(msgs, "; ")
+}
+
 // checkConflicts checks for conflicts with the given variable. i.e. they share
 // same key and category.
 func (v *Variable) checkConflicts(collection []*Variable) error {
@@ -303,81 +391,3 @@ func WriteTerraformVars(dir string, vars []*Variable) error {
 
 	return nil
 }
-
-// mergeVariables merges variables for a run according to the precedence rules
-// documented here:
-//
-// https://developer.hashicorp.com/terraform/cloud-docs/workspaces/variables#precedence
-//
-// Note: If run is nil then it is ignored.
-func mergeVariables(workspaceSets []*VariableSet, workspaceVariables []*Variable, run *run.Run) []*Variable {
-	// terraform variables keyed by variable key
-	tfVars := make(map[string]*Variable)
-	// environment variables keyed by variable key
-	envVars := make(map[string]*Variable)
-
-	// global sets have lowest precedence
-	for _, s := range workspaceSets {
-		if s.Global {
-			for _, v := range s.Variables {
-				switch v.Category {
-				case CategoryTerraform:
-					tfVars[v.Key] = v
-				case CategoryEnv:
-					envVars[v.Key] = v
-				}
-			}
-		}
-	}
-
-	// workspace-scoped sets have next lowest precedence; lexical order of the
-	// set name determines precedence if a variable with the same key is found
-	// in more than one set, e.g. variable foo in set named A takes precedence
-	// over variable foo in set named B.
-	//
-	// sort sets by lexical order, A->Z
-	slices.SortFunc(workspaceSets, func(a, b *VariableSet) int {
-		if a.Name < b.Name {
-			return -1
-		} else if a.Name > b.Name {
-			return 1
-		} else {
-			return 0
-		}
-	})
-	// reverse order sets (Z->A), so that sets later in the slice take precedence.
-	slices.Reverse(workspaceSets)
-	for _, s := range workspaceSets {
-		if s.Global {
-			continue
-		}
-		for _, v := range s.Variables {
-			switch v.Category {
-			case CategoryTerraform:
-				tfVars[v.Key] = v
-			case CategoryEnv:
-				envVars[v.Key] = v
-			}
-		}
-	}
-
-	// workspace variables have higher precedence than sets, so override
-	// anything from sets
-	for _, v := range workspaceVariables {
-		switch v.Category {
-		case CategoryTerraform:
-			tfVars[v.Key] = v
-		case CategoryEnv:
-			envVars[v.Key] = v
-		}
-	}
-
-	// run variables have highest precedence
-	if run != nil {
-		for _, v := range run.Variables {
-			tfVars[v.Key] = &Variable{Key: v.Key, Value: v.Value, Category: CategoryTerraform, HCL: true}
-		}
-	}
-
-	return append(maps.Values(tfVars), maps.Values(envVars)...)
-}
@@ -6,7 +6,6 @@ import (
 	"testing"
 
 	"github.com/leg100/otf/internal"
-	"github.com/leg100/otf/internal/run"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -81,16 +80,26 @@ func TestVariable_Update(t *testing.T) {
 			opts: UpdateVariableOptions{HCL: internal.Bool(true)},
 			before: Variable{
 				Key:      "foo",
-				Value:    "bar",
+				Value:    `"bar"`,
 				Category: CategoryTerraform,
 			},
 			after: Variable{
 				Key:      "foo",
-				Value:    "bar",
+				Value:    `"bar"`,
 				Category: CategoryTerraform,
 				HCL:      true,
 			},
 		},
+		{
+			name: "non-hcl to hcl with invalid hcl value",
+			opts: UpdateVariableOptions{HCL: internal.Bool(true)},
+			before: Variable{
+				Key:      "foo",
+				Value:    "bar",
+				Category: CategoryTerraform,
+			},
+			err: true,
+		},
 		{
 			name: "sensitive to non-sensitive",
 			opts: UpdateVariableOptions{Sensitive: internal.Bool(false)},
@@ -117,6 +126,81 @@ func TestVariable_Update(t *testing.T) {
 	}
 }
 
+func TestVariable_validateHCL(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		category VariableCategory
+		want     string
+	}{
+		{"string", `"bar"`, CategoryTerraform, ""},
+		{"number", `3`, CategoryTerraform, ""},
+		{"list", `["a", "b"]`, CategoryTerraform, ""},
+		{"map", "{\n  us-east-1 = \"image-1234\"\n}", CategoryTerraform, ""},
+		{"empty", ``, CategoryTerraform, ""},
+		{"environment variable", `{`, CategoryEnv, ""},
+		{"unquoted string", `bar`, CategoryTerraform, "invalid HCL value: Variables not allowed: Variables may not be used here."},
+		{"function call", `upper("bar")`, CategoryTerraform, "invalid HCL value: Function calls not allowed: Functions may not be called here."},
+		{"unterminated", `["a", "b"`, CategoryTerraform, "invalid HCL value"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newVariable(nil, CreateVariableOptions{
+				Key:      internal.String("foo"),
+				Value:    internal.String(tt.value),
+				Category: VariableCategoryPtr(tt.category),
+				HCL:      internal.Bool(true),
+			})
+			if tt.want == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, ErrInvalidHCLValue)
+				assert.ErrorContains(t, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestVariable_validateSecretReference(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		hcl   bool
+		want  error
+	}{
+		{"vault", `vault://secret/data/app#password`, false, nil},
+		{"file", `file:///run/secrets/password`, false, nil},
+		{"env", `env://DB_PASSWORD`, false, nil},
+		{"unknown scheme", `aws://secret`, false, ErrInvalidSecretReference},
+		{"not a reference", `s3cr3t`, false, ErrInvalidSecretReference},
+		{"hcl", `vault://secret/data/app#password`, true, ErrSecretReferenceHCL},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newVariable(nil, CreateVariableOptions{
+				Key:             internal.String("foo"),
+				Value:           internal.String(tt.value),
+				Category:        VariableCategoryPtr(CategoryTerraform),
+				HCL:             internal.Bool(tt.hcl),
+				SecretReference: internal.Bool(true),
+			})
+			assert.Equal(t, tt.want, err)
+		})
+	}
+
+	t.Run("update existing reference to hcl", func(t *testing.T) {
+		v, err := newVariable(nil, CreateVariableOptions{
+			Key:             internal.String("foo"),
+			Value:           internal.String(`vault://secret/data/app#password`),
+			Category:        VariableCategoryPtr(CategoryTerraform),
+			SecretReference: internal.Bool(true),
+		})
+		require.NoError(t, err)
+		err = v.update(nil, UpdateVariableOptions{HCL: internal.Bool(true)})
+		assert.Equal(t, ErrSecretReferenceHCL, err)
+	})
+}
+
 func TestWriteTerraformVariables(t *testing.T) {
 	dir := t.TempDir()
 
@@ -179,157 +263,3 @@ EOTT
 `
 	assert.Equal(t, want, string(got))
 }
-
-func Test_mergeVariables(t *testing.T) {
-	tests := []struct {
-		name               string
-		sets               []*VariableSet
-		workspaceVariables []*Variable
-		run                run.Run
-		want               []*Variable
-	}{
-		{
-			name: "default",
-			sets: []*VariableSet{
-				{
-					Name:   "global",
-					Global: true,
-					Variables: []*Variable{
-						{
-							Key:      "global",
-							Value:    "true",
-							Category: CategoryTerraform,
-						},
-					},
-				},
-				{
-					Name:       "workspace-scoped",
-					Workspaces: []string{"ws-123"},
-					Variables: []*Variable{
-						{
-							Key:      "workspace-scoped",
-							Value:    "true",
-							Category: CategoryTerraform,
-						},
-					},
-				},
-			},
-			workspaceVariables: []*Variable{
-				{
-					Key:      "workspace",
-					Value:    "true",
-					Category: CategoryTerraform,
-				},
-			},
-			run: run.Run{WorkspaceID: "ws-123", Variables: []run.Variable{{Key: "run", Value: "true"}}},
-			want: []*Variable{
-				{
-					Key:      "global",
-					Value:    "true",
-					Category: CategoryTerraform,
-				},
-				{
-					Key:      "workspace-scoped",
-					Value:    "true",
-					Category: CategoryTerraform,
-				},
-				{
-					Key:      "workspace",
-					Value:    "true",
-					Category: CategoryTerraform,
-				},
-				{
-					Key:      "run",
-					Value:    "true",
-					Category: CategoryTerraform,
-					HCL:      true,
-				},
-			},
-		},
-		{
-			name: "workspace-scoped set lexical precedence",
-			sets: []*VariableSet{
-				{
-					Name:       "set_A",
-					Workspaces: []string{"ws-123"},
-					Variables: []*Variable{
-						{
-							Key:      "foo",
-							Value:    "set_a",
-							Category: CategoryTerraform,
-						},
-					},
-				},
-				{
-					Name:       "set_B",
-					Workspaces: []string{"ws-123"},
-					Variables: []*Variable{
-						{
-							Key:      "foo",
-							Value:    "set_b",
-							Category: CategoryTerraform,
-						},
-					},
-				},
-			},
-			run: run.Run{WorkspaceID: "ws-123"},
-			want: []*Variable{
-				{
-					Key:      "foo",
-					Value:    "set_a",
-					Category: CategoryTerraform,
-				},
-			},
-		},
-		// a variable set can be set both to global and also specify workspaces,
-		// in which case the workspaces should be ignored and not considered as
-		// part of determining precedence.
-		{
-			name: "ignore workspaces in global sets",
-			sets: []*VariableSet{
-				{
-					// even though this has lexical precedence, it is global and
-					// thus have lower precedence than the workspace-scoped set
-					// below.
-					Name:       "a - global with workspaces",
-					Global:     true,
-					Workspaces: []string{"ws-123"},
-					Variables: []*Variable{
-						{
-							Key:      "foo",
-							Value:    "global",
-							Category: CategoryTerraform,
-						},
-					},
-				},
-				{
-					Name:       "b - workspace-scoped",
-					Workspaces: []string{"ws-123"},
-					Variables: []*Variable{
-						{
-							Key:      "foo",
-							Value:    "workspace-scoped",
-							Category: CategoryTerraform,
-						},
-					},
-				},
-			},
-			want: []*Variable{
-				{
-					Key:      "foo",
-					Value:    "workspace-scoped",
-					Category: CategoryTerraform,
-				},
-			},
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got := mergeVariables(tt.sets, tt.workspaceVariables, &tt.run)
-			assert.Equal(t, len(tt.want), len(got))
-			for _, w := range tt.want {
-				assert.Contains(t, got, w)
-			}
-		})
-	}
-}
@@ -3,15 +3,21 @@ package variable
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"slices"
 
 	"github.com/gorilla/mux"
 	"github.com/leg100/otf/internal"
 	"github.com/leg100/otf/internal/auth"
+	"github.com/leg100/otf/internal/configversion"
 	"github.com/leg100/otf/internal/http/decode"
 	"github.com/leg100/otf/internal/http/html"
 	"github.com/leg100/otf/internal/http/html/paths"
 	"github.com/leg100/otf/internal/organization"
+	"github.com/leg100/otf/internal/project"
 	"github.com/leg100/otf/internal/rbac"
 	"github.com/leg100/otf/internal/resource"
 	"github.com/leg100/otf/internal/workspace"
@@ -21,6 +27,8 @@ type (
 	web struct {
 		html.Renderer
 		workspace.Service
+		project.ProjectService
+		configversion.ConfigurationVersionService
 
 		svc Service
 	}
@@ -30,27 +38,39 @@ type (
 		Name string
 	}
 
+	projectInfo struct {
+		ID       string
+		Name     string
+		Selected bool
+	}
+
 	createVariableParams struct {
-		Key         *string `schema:"key,required"`
-		Value       *string
-		Description *string
-		Category    *VariableCategory `schema:"category,required"`
-		Sensitive   bool
-		HCL         bool
+		Key             *string `schema:"key,required"`
+		Value           *string
+		Description     *string
+		Category        *VariableCategory `schema:"category,required"`
+		Sensitive       bool
+		HCL             bool
+		SecretReference bool `schema:"secret_reference"`
 	}
 
 	updateVariableParams struct {
-		Key         *string
-		Value       *string
-		Description *string
-		Category    *VariableCategory
-		Sensitive   *bool
-		HCL         bool
-		VariableID  string `schema:"variable_id,required"`
+		Key             *string
+		Value           *string
+		Description     *string
+		Category        *VariableCategory
+		Sensitive       *bool
+		HCL             bool
+		SecretReference bool   `schema:"secret_reference"`
+		VariableID      string `schema:"variable_id,required"`
 	}
 
 	workspaceVariableTable struct {
-		Variables         []*Variable
+		Variables []*Variable
+		Merged    []*Variable
+		// Declared is the set of names of variables declared in the
+		// workspace's configuration, or nil if unknown.
+		Declared          map[string]bool
 		CanDeleteVariable bool
 	}
 
@@ -59,6 +79,16 @@ type (
 		Merged            []*Variable
 		CanDeleteVariable bool
 	}
+
+	// importExportForm renders the form for importing variables and the
+	// links for exporting variables.
+	importExportForm struct {
+		ImportAction string
+		ExportPath   string
+		CanImport    bool
+		Formats      []Format
+		Strategies   []ConflictStrategy
+	}
 )
 
 func (h *web) addHandlers(r *mux.Router) {
@@ -67,6 +97,8 @@ func (h *web) addHandlers(r *mux.Router) {
 	r.HandleFunc("/workspaces/{workspace_id}/variables", h.listWorkspaceVariables).Methods("GET")
 	r.HandleFunc("/workspaces/{workspace_id}/variables/new", h.newWorkspaceVariable).Methods("GET")
 	r.HandleFunc("/workspaces/{workspace_id}/variables/create", h.createWorkspaceVariable).Methods("POST")
+	r.HandleFunc("/workspaces/{workspace_id}/variables/import", h.importWorkspaceVariables).Methods("POST")
+	r.HandleFunc("/workspaces/{workspace_id}/variables/export", h.exportWorkspaceVariables).Methods("GET")
 	r.HandleFunc("/variables/{variable_id}/edit", h.editWorkspaceVariable).Methods("GET")
 	r.HandleFunc("/variables/{variable_id}/update", h.updateWorkspaceVariable).Methods("POST")
 	r.HandleFunc("/variables/{variable_id}/delete", h.deleteWorkspaceVariable).Methods("POST")
@@ -80,6 +112,8 @@ func (h *web) addHandlers(r *mux.Router) {
 
 	r.HandleFunc("/variable-sets/{variable_set_id}/variable-set-variables/new", h.newVariableSetVariable).Methods("GET")
 	r.HandleFunc("/variable-sets/{variable_set_id}/variable-set-variables/create", h.createVariableSetVariable).Methods("POST")
+	r.HandleFunc("/variable-sets/{variable_set_id}/variable-set-variables/import", h.importVariableSetVariables).Methods("POST")
+	r.HandleFunc("/variable-sets/{variable_set_id}/variable-set-variables/export", h.exportVariableSetVariables).Methods("GET")
 	r.HandleFunc("/variable-set-variables/{variable_id}/edit", h.editVariableSetVariable).Methods("GET")
 	r.HandleFunc("/variable-set-variables/{variable_id}/update", h.updateVariableSetVariable).Methods("POST")
 	r.HandleFunc("/variable-set-variables/{variable_id}/delete", h.deleteVariableSetVariable).Methods("POST")
@@ -122,12 +156,13 @@ func (h *web) createWorkspaceVariable(w http.ResponseWriter, r *http.Request) {
 	}
 
 	variable, err := h.svc.CreateWorkspaceVariable(r.Context(), params.WorkspaceID, CreateVariableOptions{
-		Key:         params.Key,
-		Value:       params.Value,
-		Description: params.Description,
-		Category:    params.Category,
-		Sensitive:   &params.Sensitive,
-		HCL:         &params.HCL,
+		Key:             params.Key,
+		Value:           params.Value,
+		Description:     params.Description,
+		Category:        params.Category,
+		Sensitive:       &params.Sensitive,
+		HCL:             &params.HCL,
+		SecretReference: &params.SecretReference,
 	})
 	if err != nil {
 		html.FlashError(w, err.Error())
@@ -171,7 +206,20 @@ func (h *web) listWorkspaceVariables(w http.ResponseWriter, r *http.Request) {
 		h.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	merged := mergeVariables(sets, variables, nil)
+	effective := mergeVariables(ws.ID, sets, variables, nil)
+	merged := effectiveVariables(effective)
+	declared, err := h.getDeclaredVariables(r.Context(), ws)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	var declaredNames map[string]bool
+	if len(declared) > 0 {
+		declaredNames = make(map[string]bool, len(declared))
+		for _, decl := range declared {
+			declaredNames[decl.Name] = true
+		}
+	}
 	setVariableTables := make([]setVariableTable, len(sets))
 	for i := range sets {
 		setVariableTables[i] = setVariableTable{
@@ -185,24 +233,99 @@ func (h *web) listWorkspaceVariables(w http.ResponseWriter, r *http.Request) {
 		workspace.WorkspacePage
 		WorkspaceVariableTable workspaceVariableTable
 		VariableSetTables      []setVariableTable
+		EffectiveVariables     []*EffectiveVariable
+		DeclaredVariables      []declaredVariable
 		Policy                 internal.WorkspacePolicy
 		CanCreateVariable      bool
 		CanDeleteVariable      bool
 		CanUpdateWorkspace     bool
+		ImportExport           importExportForm
 	}{
 		WorkspacePage: workspace.NewPage(r, "variables", ws),
 		WorkspaceVariableTable: workspaceVariableTable{
 			Variables:         variables,
+			Merged:            merged,
+			Declared:          declaredNames,
 			CanDeleteVariable: user.CanAccessWorkspace(rbac.DeleteWorkspaceVariableAction, policy),
 		},
 		VariableSetTables:  setVariableTables,
+		EffectiveVariables: effective,
+		DeclaredVariables:  matchDeclaredVariables(declared, effective),
 		Policy:             policy,
 		CanCreateVariable:  user.CanAccessWorkspace(rbac.CreateWorkspaceVariableAction, policy),
 		CanDeleteVariable:  user.CanAccessWorkspace(rbac.DeleteWorkspaceVariableAction, policy),
 		CanUpdateWorkspace: user.CanAccessWorkspace(rbac.UpdateWorkspaceAction, policy),
+		ImportExport: newImportExportForm(
+			paths.ImportVariable(ws.ID),
+			paths.ExportVariable(ws.ID),
+			user.CanAccessWorkspace(rbac.CreateWorkspaceVariableAction, policy) &&
+				user.CanAccessWorkspace(rbac.UpdateWorkspaceVariableAction, policy),
+		),
 	})
 }
 
+// getDeclaredVariables retrieves the variables declared in the root module of
+// the workspace's latest configuration. If there is no uploaded configuration
+// then nil is returned.
+func (h *web) getDeclaredVariables(ctx context.Context, ws *workspace.Workspace) ([]*configversion.DeclaredVariable, error) {
+	cv, err := h.GetLatestConfigurationVersion(ctx, ws.ID)
+	if errors.Is(err, internal.ErrResourceNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if cv.Status != configversion.ConfigurationUploaded {
+		return nil, nil
+	}
+	declared, err := h.ListDeclaredVariables(ctx, cv.ID)
+	if err != nil {
+		return nil, err
+	}
+	return configversion.FilterDeclaredVariables(declared, ws.WorkingDirectory), nil
+}
+
+func (h *web) importWorkspaceVariables(w http.ResponseWriter, r *http.Request) {
+	workspaceID, err := decode.Param("workspace_id", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	src, opts, err := decodeImport(r)
+	if err != nil {
+		html.FlashError(w, err.Error())
+		http.Redirect(w, r, paths.Variables(workspaceID), http.StatusFound)
+		return
+	}
+
+	result, err := h.svc.ImportWorkspaceVariables(r.Context(), workspaceID, src, opts)
+	if err != nil {
+		html.FlashError(w, "importing variables: "+err.Error())
+		http.Redirect(w, r, paths.Variables(workspaceID), http.StatusFound)
+		return
+	}
+
+	html.FlashSuccess(w, "imported variables: "+result.Summary())
+	http.Redirect(w, r, paths.Variables(workspaceID), http.StatusFound)
+}
+
+func (h *web) exportWorkspaceVariables(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		WorkspaceID string `schema:"workspace_id,required"`
+		Format      Format `schema:"format,required"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	exported, err := h.svc.ExportWorkspaceVariables(r.Context(), params.WorkspaceID, params.Format)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeExport(w, params.Format, exported)
+}
+
 func (h *web) editWorkspaceVariable(w http.ResponseWriter, r *http.Request) {
 	variableID, err := decode.Param("variable_id", r)
 	if err != nil {
@@ -242,12 +365,13 @@ func (h *web) updateWorkspaceVariable(w http.ResponseWriter, r *http.Request) {
 	}
 
 	wv, err := h.svc.UpdateWorkspaceVariable(r.Context(), params.VariableID, UpdateVariableOptions{
-		Key:         params.Key,
-		Value:       params.Value,
-		Description: params.Description,
-		Category:    params.Category,
-		Sensitive:   params.Sensitive,
-		HCL:         &params.HCL,
+		Key:             params.Key,
+		Value:           params.Value,
+		Description:     params.Description,
+		Category:        params.Category,
+		Sensitive:       params.Sensitive,
+		HCL:             &params.HCL,
+		SecretReference: &params.SecretReference,
 	})
 	if err != nil {
 		html.FlashError(w, err.Error())
@@ -319,6 +443,11 @@ func (h *web) newVariableSet(w http.ResponseWriter, r *http.Request) {
 		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
 		return
 	}
+	availableProjects, err := h.getAvailableProjects(r.Context(), org, nil)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
 
 	h.Render("variable_set_new.tmpl", w, struct {
 		organization.OrganizationPage
@@ -327,6 +456,7 @@ func (h *web) newVariableSet(w http.ResponseWriter, r *http.Request) {
 		FormAction          string
 		AvailableWorkspaces []workspaceInfo
 		ExistingWorkspaces  []workspaceInfo
+		AvailableProjects   []projectInfo
 	}{
 		OrganizationPage: organization.NewPage(r, "variable sets", org),
 		VariableSet: &VariableSet{
@@ -336,6 +466,7 @@ func (h *web) newVariableSet(w http.ResponseWriter, r *http.Request) {
 		FormAction:          paths.CreateVariableSet(org),
 		AvailableWorkspaces: availableWorkspaces,
 		ExistingWorkspaces:  []workspaceInfo{},
+		AvailableProjects:   availableProjects,
 	})
 }
 
@@ -344,8 +475,10 @@ func (h *web) createVariableSet(w http.ResponseWriter, r *http.Request) {
 		Name           *string `schema:"name,required"`
 		Description    string
 		Global         bool
-		Organization   string `schema:"organization_name,required"`
-		WorkspacesJSON string `schema:"workspaces"`
+		Priority       bool
+		Organization   string   `schema:"organization_name,required"`
+		WorkspacesJSON string   `schema:"workspaces"`
+		Projects       []string `schema:"projects"`
 	}
 	if err := decode.All(&params, r); err != nil {
 		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
@@ -366,7 +499,9 @@ func (h *web) createVariableSet(w http.ResponseWriter, r *http.Request) {
 		Name:        *params.Name,
 		Description: params.Description,
 		Global:      params.Global,
+		Priority:    params.Priority,
 		Workspaces:  workspaceIDs,
+		Projects:    params.Projects,
 	})
 	if err != nil {
 		html.FlashError(w, err.Error())
@@ -411,6 +546,11 @@ func (h *web) editVariableSet(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 	}
+	availableProjects, err := h.getAvailableProjects(r.Context(), set.Organization, set.Projects)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
 
 	user, err := auth.UserFromContext(r.Context())
 	if err != nil {
@@ -425,9 +565,11 @@ func (h *web) editVariableSet(w http.ResponseWriter, r *http.Request) {
 		FormAction          string
 		AvailableWorkspaces []workspaceInfo
 		ExistingWorkspaces  []workspaceInfo
+		AvailableProjects   []projectInfo
 		CanCreateVariable   bool
 		CanDeleteVariable   bool
 		VariableTable       setVariableTable
+		ImportExport        importExportForm
 	}{
 		OrganizationPage:    organization.NewPage(r, "edit | "+set.ID, set.Organization),
 		VariableSet:         set,
@@ -435,22 +577,33 @@ func (h *web) editVariableSet(w http.ResponseWriter, r *http.Request) {
 		FormAction:          paths.UpdateVariableSet(set.ID),
 		AvailableWorkspaces: availableWorkspaces,
 		ExistingWorkspaces:  existingWorkspaces,
+		AvailableProjects:   availableProjects,
 		CanCreateVariable:   user.CanAccessOrganization(rbac.CreateWorkspaceVariableAction, set.Organization),
 		CanDeleteVariable:   user.CanAccessOrganization(rbac.DeleteWorkspaceVariableAction, set.Organization),
 		VariableTable: setVariableTable{
 			VariableSet:       set,
 			CanDeleteVariable: user.CanAccessOrganization(rbac.DeleteWorkspaceVariableAction, set.Organization),
 		},
+		ImportExport: newImportExportForm(
+			paths.ImportVariableSetVariable(set.ID),
+			paths.ExportVariableSetVariable(set.ID),
+			user.CanAccessOrganization(rbac.AddVariableToSetAction, set.Organization) &&
+				user.CanAccessOrganization(rbac.UpdateVariableSetAction, set.Organization),
+		),
 	})
 }
 
 func (h *web) updateVariableSet(w http.ResponseWriter, r *http.Request) {
 	var params struct {
-		SetID          string `schema:"variable_set_id,required"`
-		Name           *string
-		Description    *string
-		Global         *bool
-		WorkspacesJSON string `schema:"workspaces"`
+		SetID       string `schema:"variable_set_id,required"`
+		Name        *string
+		Description *string
+		Global      *bool
+		// an unchecked checkbox is omitted from the form, so a missing value
+		// means false rather than no change.
+		Priority       bool
+		WorkspacesJSON string   `schema:"workspaces"`
+		Projects       []string `schema:"projects"`
 	}
 	if err := decode.All(&params, r); err != nil {
 		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
@@ -471,7 +624,10 @@ func (h *web) updateVariableSet(w http.ResponseWriter, r *http.Request) {
 		Name:        params.Name,
 		Description: params.Description,
 		Global:      params.Global,
+		Priority:    &params.Priority,
 		Workspaces:  workspaceIDs,
+		// non-nil slice ensures projects are removed when none are selected
+		Projects: append([]string{}, params.Projects...),
 	})
 	if err != nil {
 		html.FlashError(w, err.Error())
@@ -539,12 +695,13 @@ func (h *web) createVariableSetVariable(w http.ResponseWriter, r *http.Request)
 	}
 
 	variable, err := h.svc.createVariableSetVariable(r.Context(), params.SetID, CreateVariableOptions{
-		Key:         params.Key,
-		Value:       params.Value,
-		Description: params.Description,
-		Category:    params.Category,
-		Sensitive:   &params.Sensitive,
-		HCL:         &params.HCL,
+		Key:             params.Key,
+		Value:           params.Value,
+		Description:     params.Description,
+		Category:        params.Category,
+		Sensitive:       &params.Sensitive,
+		HCL:             &params.HCL,
+		SecretReference: &params.SecretReference,
 	})
 	if err != nil {
 		html.FlashError(w, err.Error())
@@ -556,6 +713,48 @@ func (h *web) createVariableSetVariable(w http.ResponseWriter, r *http.Request)
 	http.Redirect(w, r, paths.EditVariableSet(params.SetID), http.StatusFound)
 }
 
+func (h *web) importVariableSetVariables(w http.ResponseWriter, r *http.Request) {
+	setID, err := decode.Param("variable_set_id", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	src, opts, err := decodeImport(r)
+	if err != nil {
+		html.FlashError(w, err.Error())
+		http.Redirect(w, r, paths.EditVariableSet(setID), http.StatusFound)
+		return
+	}
+
+	result, err := h.svc.ImportVariableSetVariables(r.Context(), setID, src, opts)
+	if err != nil {
+		html.FlashError(w, "importing variables: "+err.Error())
+		http.Redirect(w, r, paths.EditVariableSet(setID), http.StatusFound)
+		return
+	}
+
+	html.FlashSuccess(w, "imported variables: "+result.Summary())
+	http.Redirect(w, r, paths.EditVariableSet(setID), http.StatusFound)
+}
+
+func (h *web) exportVariableSetVariables(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		SetID  string `schema:"variable_set_id,required"`
+		Format Format `schema:"format,required"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	exported, err := h.svc.ExportVariableSetVariables(r.Context(), params.SetID, params.Format)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeExport(w, params.Format, exported)
+}
+
 func (h *web) editVariableSetVariable(w http.ResponseWriter, r *http.Request) {
 	variableID, err := decode.Param("variable_id", r)
 	if err != nil {
@@ -593,12 +792,13 @@ func (h *web) updateVariableSetVariable(w http.ResponseWriter, r *http.Request)
 	}
 
 	set, err := h.svc.updateVariableSetVariable(r.Context(), params.VariableID, UpdateVariableOptions{
-		Key:         params.Key,
-		Value:       params.Value,
-		Description: params.Description,
-		Category:    params.Category,
-		Sensitive:   params.Sensitive,
-		HCL:         &params.HCL,
+		Key:             params.Key,
+		Value:           params.Value,
+		Description:     params.Description,
+		Category:        params.Category,
+		Sensitive:       params.Sensitive,
+		HCL:             &params.HCL,
+		SecretReference: &params.SecretReference,
 	})
 	if err != nil {
 		html.FlashError(w, err.Error())
@@ -651,6 +851,90 @@ func (h *web) getAvailableWorkspaces(ctx context.Context, org string) ([]workspa
 	return availableWorkspaces, nil
 }
 
+// getAvailableProjects retrieves all projects in the organization, marking
+// those that are selected.
+func (h *web) getAvailableProjects(ctx context.Context, org string, selected []string) ([]projectInfo, error) {
+	projects, err := resource.ListAll(func(opts resource.PageOptions) (*resource.Page[*project.Project], error) {
+		return h.ListProjects(ctx, project.ListOptions{
+			Organization: org,
+			PageOptions:  opts,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	availableProjects := make([]projectInfo, len(projects))
+	for i, prj := range projects {
+		availableProjects[i] = projectInfo{
+			ID:       prj.ID,
+			Name:     prj.Name,
+			Selected: slices.Contains(selected, prj.ID),
+		}
+	}
+	return availableProjects, nil
+}
+
+// decodeImport decodes a form for importing variables, which are either
+// uploaded as a file or pasted into a text field. If the format is not
+// specified then it is inferred from the name of the uploaded file.
+func decodeImport(r *http.Request) ([]byte, ImportOptions, error) {
+	if err := r.ParseMultipartForm(MaxImportSize); err != nil && !errors.Is(err, http.ErrNotMultipart) {
+		return nil, ImportOptions{}, err
+	}
+	var params struct {
+		Format   Format
+		Strategy ConflictStrategy
+		Data     string
+	}
+	if err := decode.All(&params, r); err != nil {
+		return nil, ImportOptions{}, err
+	}
+	opts := ImportOptions{Format: params.Format, Strategy: params.Strategy}
+
+	file, header, err := r.FormFile("file")
+	if errors.Is(err, http.ErrMissingFile) || errors.Is(err, http.ErrNotMultipart) {
+		if params.Data == "" {
+			return nil, ImportOptions{}, errors.New("no variables to import: upload a file or paste variables")
+		}
+		if opts.Format == "" {
+			return nil, ImportOptions{}, errors.New("select the format of the pasted variables")
+		}
+		return []byte(params.Data), opts, nil
+	} else if err != nil {
+		return nil, ImportOptions{}, err
+	}
+	defer file.Close()
+
+	if opts.Format == "" {
+		if opts.Format, err = FormatFromFilename(header.Filename); err != nil {
+			return nil, ImportOptions{}, err
+		}
+	}
+	src, err := io.ReadAll(file)
+	if err != nil {
+		return nil, ImportOptions{}, err
+	}
+	return src, opts, nil
+}
+
+// writeExport responds with exported variables as a file download.
+func writeExport(w http.ResponseWriter, format Format, exported []byte) {
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, format.Filename()))
+	w.Write(exported)
+}
+
+func newImportExportForm(importAction, exportPath string, canImport bool) importExportForm {
+	return importExportForm{
+		ImportAction: importAction,
+		ExportPath:   exportPath,
+		CanImport:    canImport,
+		Formats:      Formats,
+		Strategies:   ConflictStrategies,
+	}
+}
+
 func (workspaceVariableTable) EditPath(variableID string) string {
 	return paths.EditVariable(variableID)
 }
@@ -660,8 +944,20 @@ func (workspaceVariableTable) DeletePath(variableID string) string {
 }
 
 func (w workspaceVariableTable) IsOverwritten(v *Variable) bool {
-	// a workspace variable can never be overwritten
-	return false
+	// a workspace variable can only be overwritten by a priority set
+	if w.Merged == nil {
+		return false
+	}
+	return !v.Matches(w.Merged)
+}
+
+// IsUndeclared determines whether a terraform variable is not declared in the
+// workspace's configuration.
+func (w workspaceVariableTable) IsUndeclared(v *Variable) bool {
+	if w.Declared == nil || v.Category != CategoryTerraform {
+		return false
+	}
+	return !w.Declared[v.Key]
 }
 
 func (setVariableTable) EditPath(variableID string) string {
@@ -672,6 +968,12 @@ func (setVariableTable) DeletePath(variableID string) string {
 	return paths.DeleteVariableSetVariable(variableID)
 }
 
+func (w setVariableTable) IsUndeclared(v *Variable) bool {
+	// a variable set is shared with many workspaces, so its variables need
+	// not be declared in any one workspace's configuration.
+	return false
+}
+
 func (w setVariableTable) IsOverwritten(v *Variable) bool {
 	if w.Merged == nil {
 		return false
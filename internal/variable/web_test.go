@@ -1,6 +1,9 @@
 package variable
 
 import (
+	"bytes"
+	"encoding/base64"
+	"mime/multipart"
 	"net/http/httptest"
 	"net/url"
 	"strings"
@@ -47,7 +50,7 @@ func TestVariable_UpdateHandler(t *testing.T) {
 			name: "disable hcl",
 			existing: CreateVariableOptions{
 				Key:             internal.String("foo"),
-				Value:           internal.String("bar"),
+				Value:           internal.String(`"bar"`),
 				Category:        VariableCategoryPtr(CategoryTerraform),
 				HCL:             internal.Bool(true),
 				generateVersion: func() string { return "" },
@@ -99,6 +102,65 @@ func TestVariable_UpdateHandler(t *testing.T) {
 	}
 }
 
+func TestVariable_ImportHandler(t *testing.T) {
+	existing, err := newVariable(nil, CreateVariableOptions{
+		Key:      internal.String("foo"),
+		Value:    internal.String("bar"),
+		Category: VariableCategoryPtr(CategoryTerraform),
+	})
+	require.NoError(t, err)
+
+	t.Run("upload file", func(t *testing.T) {
+		var body bytes.Buffer
+		mw := multipart.NewWriter(&body)
+		fw, err := mw.CreateFormFile("file", "terraform.tfvars")
+		require.NoError(t, err)
+		fw.Write([]byte("foo = \"baz\"\nqux = 3\n"))
+		mw.WriteField("strategy", "overwrite")
+		require.NoError(t, mw.Close())
+
+		r := httptest.NewRequest("POST", "/?workspace_id=ws-123", &body)
+		r.Header.Add("Content-Type", mw.FormDataContentType())
+		w := httptest.NewRecorder()
+
+		fakeWebApp(t, "ws-123", existing).importWorkspaceVariables(w, r)
+
+		if assert.Equal(t, 302, w.Code, "got body: %s", w.Body.String()) {
+			redirect, err := w.Result().Location()
+			require.NoError(t, err)
+			assert.Equal(t, paths.Variables("ws-123"), redirect.Path)
+		}
+		assert.Contains(t, flashMessages(t, w), "imported variables: 1 created, 1 updated, 0 skipped")
+	})
+
+	t.Run("paste without format", func(t *testing.T) {
+		form := url.Values{"data": {`foo = "baz"`}}
+		r := httptest.NewRequest("POST", "/?workspace_id=ws-123", strings.NewReader(form.Encode()))
+		r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+
+		fakeWebApp(t, "ws-123", existing).importWorkspaceVariables(w, r)
+
+		assert.Equal(t, 302, w.Code, "got body: %s", w.Body.String())
+		assert.Contains(t, flashMessages(t, w), "select the format of the pasted variables")
+	})
+}
+
+// flashMessages returns the flash messages written to the response, encoded as
+// JSON.
+func flashMessages(t *testing.T, w *httptest.ResponseRecorder) string {
+	t.Helper()
+
+	for _, cookie := range w.Result().Cookies() {
+		if cookie.Name == "flash" {
+			decoded, err := base64.URLEncoding.DecodeString(cookie.Value)
+			require.NoError(t, err)
+			return string(decoded)
+		}
+	}
+	return ""
+}
+
 func fakeWebApp(t *testing.T, workspaceID string, v *Variable) *web {
 	renderer, err := html.NewRenderer(false)
 	require.NoError(t, err)
@@ -23,6 +23,16 @@ type (
 		ListTags(ctx context.Context, opts ListTagsOptions) ([]string, error)
 		// ListPullRequestFiles returns the paths of files that are modified in the pull request
 		ListPullRequestFiles(ctx context.Context, repo string, pull int) ([]string, error)
+		// GetPullRequest retrieves a pull request.
+		GetPullRequest(ctx context.Context, repo string, pull int) (PullRequest, error)
+		// ListPullRequestComments lists the comments on a pull request.
+		ListPullRequestComments(ctx context.Context, repo string, pull int) ([]Comment, error)
+		// CreatePullRequestComment creates a comment on a pull request,
+		// returning the provider's unique ID for the comment.
+		CreatePullRequestComment(ctx context.Context, opts CreatePullRequestCommentOptions) (string, error)
+		// UpdatePullRequestComment replaces the body of a comment on a pull
+		// request.
+		UpdatePullRequestComment(ctx context.Context, opts UpdatePullRequestCommentOptions) error
 		// GetCommit retrieves commit from the repo with the given git ref
 		GetCommit(ctx context.Context, repo, ref string) (Commit, error)
 	}
@@ -89,6 +99,41 @@ type (
 		Description string
 	}
 
+	// PullRequest is a pull request, or in gitlab parlance, a merge request.
+	PullRequest struct {
+		Number    int
+		URL       string
+		Title     string
+		Branch    string // head branch
+		CommitSHA string // head commit
+	}
+
+	// Comment is a comment on a pull request.
+	Comment struct {
+		ID   string // vcs' comment ID
+		Body string
+		// Own is true if the comment was made by the identity with which the
+		// client is authenticated.
+		Own bool
+	}
+
+	// CreatePullRequestCommentOptions are options for creating a comment on a
+	// pull request.
+	CreatePullRequestCommentOptions struct {
+		Repo              string // <owner>/<repo>
+		PullRequestNumber int
+		Body              string
+	}
+
+	// UpdatePullRequestCommentOptions are options for updating a comment on a
+	// pull request.
+	UpdatePullRequestCommentOptions struct {
+		Repo              string // <owner>/<repo>
+		PullRequestNumber int
+		ID                string // vcs' comment ID
+		Body              string
+	}
+
 	Repository struct {
 		Path          string
 		DefaultBranch string
@@ -7,6 +7,7 @@ const (
 	EventTypePush
 	EventTypeTag
 	EventTypeInstallation // github-app installation
+	EventTypePullComment  // comment on a pull request
 
 	ActionCreated Action = iota + 1
 	ActionDeleted
@@ -43,6 +44,10 @@ type (
 		PullRequestURL    string
 		PullRequestTitle  string
 
+		// Body of a comment on a pull request. Only applicable to pull
+		// comment events.
+		Comment string
+
 		SenderUsername  string
 		SenderAvatarURL string
 		SenderHTMLURL   string
@@ -70,7 +75,7 @@ func (e EventPayload) Validate() error {
 		return errors.New("event missing event action")
 	}
 	switch e.Type {
-	case EventTypePush, EventTypePull, EventTypeTag:
+	case EventTypePush, EventTypePull, EventTypeTag, EventTypePullComment:
 		if e.RepoPath == "" {
 			return errors.New("event missing repo path")
 		}
@@ -38,7 +38,10 @@ func NewCommand(api *otfapi.Client) *cobra.Command {
 }
 
 func (a *CLI) workspaceListCommand() *cobra.Command {
-	var org string
+	var (
+		org     string
+		project string
+	)
 
 	cmd := &cobra.Command{
 		Use:           "list",
@@ -47,10 +50,14 @@ func (a *CLI) workspaceListCommand() *cobra.Command {
 		SilenceErrors: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			list, err := resource.ListAll(func(opts resource.PageOptions) (*resource.Page[*Workspace], error) {
-				return a.ListWorkspaces(cmd.Context(), ListOptions{
+				listOpts := ListOptions{
 					PageOptions:  opts,
 					Organization: &org,
-				})
+				}
+				if project != "" {
+					listOpts.ProjectID = &project
+				}
+				return a.ListWorkspaces(cmd.Context(), listOpts)
 			})
 			if err != nil {
 				return fmt.Errorf("retrieving existing workspaces: %w", err)
@@ -65,6 +72,7 @@ func (a *CLI) workspaceListCommand() *cobra.Command {
 
 	cmd.Flags().StringVar(&org, "organization", "", "Organization workspace belongs to")
 	cmd.MarkFlagRequired("organization")
+	cmd.Flags().StringVar(&project, "project", "", "Only list workspaces belonging to the project with this ID")
 
 	return cmd
 }
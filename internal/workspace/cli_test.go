@@ -70,6 +70,18 @@ func TestWorkspaceList(t *testing.T) {
 	want := fmt.Sprintf("%s\n%s\n", ws1.Name, ws2.Name)
 	assert.Equal(t, want, got.String())
 
+	t.Run("filter by project", func(t *testing.T) {
+		ws3 := &Workspace{ID: "ws-789", Name: "networking", ProjectID: "prj-123"}
+		app := newFakeCLI(ws1, ws3)
+
+		cmd := app.workspaceListCommand()
+		cmd.SetArgs([]string{"--organization", "acme-corp", "--project", "prj-123"})
+		got := bytes.Buffer{}
+		cmd.SetOut(&got)
+		require.NoError(t, cmd.Execute())
+		assert.Equal(t, "networking\n", got.String())
+	})
+
 	t.Run("missing organization", func(t *testing.T) {
 		cmd := app.workspaceListCommand()
 		cmd.SetArgs([]string{"automatize"})
@@ -150,7 +162,14 @@ func (f *fakeCLIService) GetWorkspaceByName(context.Context, string, string) (*W
 }
 
 func (f *fakeCLIService) ListWorkspaces(ctx context.Context, opts ListOptions) (*resource.Page[*Workspace], error) {
-	return resource.NewPage(f.workspaces, opts.PageOptions, nil), nil
+	var workspaces []*Workspace
+	for _, ws := range f.workspaces {
+		if opts.ProjectID != nil && ws.ProjectID != *opts.ProjectID {
+			continue
+		}
+		workspaces = append(workspaces, ws)
+	}
+	return resource.NewPage(workspaces, opts.PageOptions, nil), nil
 }
 
 func (f *fakeCLIService) UpdateWorkspace(ctx context.Context, workspaceID string, opts UpdateOptions) (*Workspace, error) {
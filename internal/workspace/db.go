@@ -6,6 +6,7 @@ import (
 	"github.com/jackc/pgtype"
 	"github.com/jackc/pgx/v4"
 	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/project"
 	"github.com/leg100/otf/internal/resource"
 	"github.com/leg100/otf/internal/sql"
 	"github.com/leg100/otf/internal/sql/pggen"
@@ -48,6 +49,9 @@ type (
 		TriggerPatterns            []string               `json:"trigger_patterns"`
 		VCSTagsRegex               pgtype.Text            `json:"vcs_tags_regex"`
 		AllowCLIApply              bool                   `json:"allow_cli_apply"`
+		ProjectID                  pgtype.Text            `json:"project_id"`
+		TestPullRequests           bool                   `json:"test_pull_requests"`
+		CommentPullRequests        bool                   `json:"comment_pull_requests"`
 		Tags                       []string               `json:"tags"`
 		LatestRunStatus            pgtype.Text            `json:"latest_run_status"`
 		UserLock                   *pggen.Users           `json:"user_lock"`
@@ -81,14 +85,17 @@ func (r pgresult) toWorkspace() (*Workspace, error) {
 		WorkingDirectory:           r.WorkingDirectory.String,
 		Organization:               r.OrganizationName.String,
 		Tags:                       r.Tags,
+		ProjectID:                  r.ProjectID.String,
 	}
 
 	if r.WorkspaceConnection != nil {
 		ws.Connection = &Connection{
-			AllowCLIApply: r.AllowCLIApply,
-			VCSProviderID: r.WorkspaceConnection.VCSProviderID.String,
-			Repo:          r.WorkspaceConnection.RepoPath.String,
-			Branch:        r.Branch.String,
+			AllowCLIApply:       r.AllowCLIApply,
+			TestPullRequests:    r.TestPullRequests,
+			CommentPullRequests: r.CommentPullRequests,
+			VCSProviderID:       r.WorkspaceConnection.VCSProviderID.String,
+			Repo:                r.WorkspaceConnection.RepoPath.String,
+			Branch:              r.Branch.String,
 		}
 		if r.VCSTagsRegex.Status == pgtype.Present {
 			ws.Connection.TagsRegex = r.VCSTagsRegex.String
@@ -142,11 +149,20 @@ func (db *pgdb) create(ctx context.Context, ws *Workspace) error {
 		QueueAllRuns:               ws.QueueAllRuns,
 		WorkingDirectory:           sql.String(ws.WorkingDirectory),
 		OrganizationName:           sql.String(ws.Organization),
+		ProjectID:                  sql.NullString(),
 		Branch:                     sql.String(""),
 		VCSTagsRegex:               sql.StringPtr(nil),
 	}
+	if ws.ProjectID != "" {
+		if err := checkProject(ctx, q, ws); err != nil {
+			return err
+		}
+		params.ProjectID = sql.String(ws.ProjectID)
+	}
 	if ws.Connection != nil {
 		params.AllowCLIApply = ws.Connection.AllowCLIApply
+		params.TestPullRequests = ws.Connection.TestPullRequests
+		params.CommentPullRequests = ws.Connection.CommentPullRequests
 		params.Branch = sql.String(ws.Connection.Branch)
 		params.VCSTagsRegex = sql.String(ws.Connection.TagsRegex)
 	}
@@ -188,11 +204,20 @@ func (db *pgdb) update(ctx context.Context, workspaceID string, fn func(*Workspa
 			TriggerPrefixes:            ws.TriggerPrefixes,
 			TriggerPatterns:            ws.TriggerPatterns,
 			WorkingDirectory:           sql.String(ws.WorkingDirectory),
+			ProjectID:                  sql.NullString(),
 			Branch:                     sql.String(""),
 			VCSTagsRegex:               sql.StringPtr(nil),
 		}
+		if ws.ProjectID != "" {
+			if err := checkProject(ctx, q, ws); err != nil {
+				return err
+			}
+			params.ProjectID = sql.String(ws.ProjectID)
+		}
 		if ws.Connection != nil {
 			params.AllowCLIApply = ws.Connection.AllowCLIApply
+			params.TestPullRequests = ws.Connection.TestPullRequests
+			params.CommentPullRequests = ws.Connection.CommentPullRequests
 			params.Branch = sql.String(ws.Connection.Branch)
 			params.VCSTagsRegex = sql.String(ws.Connection.TagsRegex)
 		}
@@ -222,6 +247,11 @@ func (db *pgdb) list(ctx context.Context, opts ListOptions) (*resource.Page[*Wor
 	if opts.Organization != nil {
 		organization = *opts.Organization
 	}
+	// Likewise, project filter is optional.
+	project := "%"
+	if opts.ProjectID != nil {
+		project = *opts.ProjectID
+	}
 	tags := []string{}
 	if len(opts.Tags) > 0 {
 		tags = opts.Tags
@@ -229,6 +259,7 @@ func (db *pgdb) list(ctx context.Context, opts ListOptions) (*resource.Page[*Wor
 
 	q.FindWorkspacesBatch(batch, pggen.FindWorkspacesParams{
 		OrganizationNames: []string{organization},
+		ProjectID:         sql.String(project),
 		Search:            sql.String(opts.Search),
 		Tags:              tags,
 		Limit:             opts.GetLimit(),
@@ -237,6 +268,7 @@ func (db *pgdb) list(ctx context.Context, opts ListOptions) (*resource.Page[*Wor
 	q.CountWorkspacesBatch(batch, pggen.CountWorkspacesParams{
 		Search:            sql.String(opts.Search),
 		OrganizationNames: []string{organization},
+		ProjectID:         sql.String(project),
 		Tags:              tags,
 	})
 	results := db.SendBatch(ctx, batch)
@@ -280,17 +312,29 @@ func (db *pgdb) listByConnection(ctx context.Context, vcsProviderID, repoPath st
 	return items, nil
 }
 
-func (db *pgdb) listByUsername(ctx context.Context, username string, organization string, opts resource.PageOptions) (*resource.Page[*Workspace], error) {
+func (db *pgdb) listByUsername(ctx context.Context, username string, organization string, opts ListOptions) (*resource.Page[*Workspace], error) {
 	q := db.Conn(ctx)
 	batch := &pgx.Batch{}
 
+	// project filter is optional - if not provided use a % which in SQL means
+	// match any project.
+	project := "%"
+	if opts.ProjectID != nil {
+		project = *opts.ProjectID
+	}
+
 	q.FindWorkspacesByUsernameBatch(batch, pggen.FindWorkspacesByUsernameParams{
 		OrganizationName: sql.String(organization),
+		ProjectID:        sql.String(project),
 		Username:         sql.String(username),
 		Limit:            opts.GetLimit(),
 		Offset:           opts.GetOffset(),
 	})
-	q.CountWorkspacesByUsernameBatch(batch, sql.String(organization), sql.String(username))
+	q.CountWorkspacesByUsernameBatch(batch, pggen.CountWorkspacesByUsernameParams{
+		OrganizationName: sql.String(organization),
+		ProjectID:        sql.String(project),
+		Username:         sql.String(username),
+	})
 	results := db.SendBatch(ctx, batch)
 	defer results.Close()
 
@@ -312,7 +356,7 @@ func (db *pgdb) listByUsername(ctx context.Context, username string, organizatio
 		items[i] = ws
 	}
 
-	return resource.NewPage(items, opts, internal.Int64(count.Int)), nil
+	return resource.NewPage(items, opts.PageOptions, internal.Int64(count.Int)), nil
 }
 
 func (db *pgdb) get(ctx context.Context, workspaceID string) (*Workspace, error) {
@@ -341,3 +385,16 @@ func (db *pgdb) delete(ctx context.Context, workspaceID string) error {
 	}
 	return nil
 }
+
+// checkProject checks the workspace's project belongs to the same organization
+// as the workspace.
+func checkProject(ctx context.Context, q pggen.Querier, ws *Workspace) error {
+	row, err := q.FindProjectByID(ctx, sql.String(ws.ProjectID))
+	if err != nil {
+		return sql.Error(err)
+	}
+	if row.OrganizationName.String != ws.Organization {
+		return project.ErrOrganizationMismatch
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/leg100/otf/internal"
 	"github.com/leg100/otf/internal/auth"
 	"github.com/leg100/otf/internal/rbac"
 )
@@ -29,7 +30,7 @@ func (s *service) LockWorkspace(ctx context.Context, workspaceID string, runID *
 		if err != nil {
 			return nil, err
 		}
-		user, ok := subject.(*auth.User)
+		user, ok := internal.UnwrapSubject(subject).(*auth.User)
 		if !ok {
 			return nil, fmt.Errorf("only a run or a user can lock a workspace")
 		}
@@ -71,7 +72,7 @@ func (s *service) UnlockWorkspace(ctx context.Context, workspaceID string, runID
 		if err != nil {
 			return nil, err
 		}
-		user, ok := subject.(*auth.User)
+		user, ok := internal.UnwrapSubject(subject).(*auth.User)
 		if !ok {
 			return nil, fmt.Errorf("only a run or a user can unlock a workspace")
 		}
@@ -11,11 +11,22 @@ import (
 )
 
 func (db *pgdb) SetWorkspacePermission(ctx context.Context, workspaceID, team string, role rbac.Role) error {
-	_, err := db.Conn(ctx).UpsertWorkspacePermission(ctx, pggen.UpsertWorkspacePermissionParams{
-		WorkspaceID: sql.String(workspaceID),
-		TeamName:    sql.String(team),
-		Role:        sql.String(role.String()),
-	})
+	params := pggen.UpsertWorkspacePermissionParams{
+		WorkspaceID:   sql.String(workspaceID),
+		TeamName:      sql.String(team),
+		Role:          sql.String(role.String()),
+		Runs:          sql.NullString(),
+		Variables:     sql.NullString(),
+		StateVersions: sql.NullString(),
+	}
+	if custom := role.Custom(); custom != nil {
+		params.Runs = sql.String(string(custom.Runs))
+		params.Variables = sql.String(string(custom.Variables))
+		params.StateVersions = sql.String(string(custom.StateVersions))
+		params.WorkspaceLocking = custom.WorkspaceLocking
+		params.RunTasks = custom.RunTasks
+	}
+	_, err := db.Conn(ctx).UpsertWorkspacePermission(ctx, params)
 	if err != nil {
 		return sql.Error(err)
 	}
@@ -31,6 +42,7 @@ func (db *pgdb) GetWorkspacePolicy(ctx context.Context, workspaceID string) (int
 	// (2) we retrieve the name of the organization, which is part of a policy
 	q.FindWorkspaceByIDBatch(batch, sql.String(workspaceID))
 	q.FindWorkspacePermissionsByWorkspaceIDBatch(batch, sql.String(workspaceID))
+	q.FindProjectPermissionsByWorkspaceIDBatch(batch, sql.String(workspaceID))
 	results := db.SendBatch(ctx, batch)
 	defer results.Close()
 
@@ -42,6 +54,10 @@ func (db *pgdb) GetWorkspacePolicy(ctx context.Context, workspaceID string) (int
 	if err != nil {
 		return internal.WorkspacePolicy{}, sql.Error(err)
 	}
+	projectPerms, err := q.FindProjectPermissionsByWorkspaceIDScan(results)
+	if err != nil {
+		return internal.WorkspacePolicy{}, sql.Error(err)
+	}
 
 	policy := internal.WorkspacePolicy{
 		Organization:      ws.OrganizationName.String,
@@ -49,7 +65,7 @@ func (db *pgdb) GetWorkspacePolicy(ctx context.Context, workspaceID string) (int
 		GlobalRemoteState: ws.GlobalRemoteState,
 	}
 	for _, perm := range perms {
-		role, err := rbac.WorkspaceRoleFromString(perm.Role.String)
+		role, err := workspaceRoleFromRow(perm)
 		if err != nil {
 			return internal.WorkspacePolicy{}, err
 		}
@@ -59,9 +75,35 @@ func (db *pgdb) GetWorkspacePolicy(ctx context.Context, workspaceID string) (int
 			Role:   role,
 		})
 	}
+	for _, perm := range projectPerms {
+		role, err := rbac.WorkspaceRoleFromString(perm.Role.String)
+		if err != nil {
+			return internal.WorkspacePolicy{}, err
+		}
+		policy.ProjectPermissions = append(policy.ProjectPermissions, internal.WorkspacePermission{
+			Team:   perm.Team.Name.String,
+			TeamID: perm.Team.TeamID.String,
+			Role:   role,
+		})
+	}
 	return policy, nil
 }
 
+// workspaceRoleFromRow constructs a role from a workspace permission row,
+// including its custom permissions if it is a custom role.
+func workspaceRoleFromRow(row pggen.FindWorkspacePermissionsByWorkspaceIDRow) (rbac.Role, error) {
+	if row.Role.String != "custom" {
+		return rbac.WorkspaceRoleFromString(row.Role.String)
+	}
+	return rbac.NewCustomRole(rbac.CustomPermissions{
+		Runs:             rbac.RunsPermission(row.Runs.String),
+		Variables:        rbac.VariablesPermission(row.Variables.String),
+		StateVersions:    rbac.StateVersionsPermission(row.StateVersions.String),
+		WorkspaceLocking: row.WorkspaceLocking,
+		RunTasks:         row.RunTasks,
+	})
+}
+
 func (db *pgdb) UnsetWorkspacePermission(ctx context.Context, workspaceID, team string) error {
 	_, err := db.Conn(ctx).DeleteWorkspacePermissionByID(ctx, sql.String(workspaceID), sql.String(team))
 	if err != nil {
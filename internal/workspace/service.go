@@ -2,6 +2,7 @@ package workspace
 
 import (
 	"context"
+	"slices"
 
 	"github.com/go-logr/logr"
 	"github.com/gorilla/mux"
@@ -11,6 +12,7 @@ import (
 	"github.com/leg100/otf/internal/hooks"
 	"github.com/leg100/otf/internal/http/html"
 	"github.com/leg100/otf/internal/organization"
+	"github.com/leg100/otf/internal/project"
 	"github.com/leg100/otf/internal/pubsub"
 	"github.com/leg100/otf/internal/rbac"
 	"github.com/leg100/otf/internal/resource"
@@ -69,6 +71,7 @@ type (
 		vcsprovider.VCSProviderService
 		connections.ConnectionService
 		auth.TeamService
+		project.ProjectService
 		logr.Logger
 	}
 )
@@ -92,6 +95,7 @@ func NewService(opts Options) *service {
 		Renderer:           opts.Renderer,
 		TeamService:        opts.TeamService,
 		VCSProviderService: opts.VCSProviderService,
+		ProjectService:     opts.ProjectService,
 		svc:                &svc,
 	}
 	svc.tfeapi = &tfe{
@@ -209,6 +213,33 @@ func (s *service) GetWorkspaceByName(ctx context.Context, organization, workspac
 }
 
 func (s *service) ListWorkspaces(ctx context.Context, opts ListOptions) (*resource.Page[*Workspace], error) {
+	subject, err := internal.SubjectFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// a subject restricted to certain workspaces, e.g. a token scoped to
+	// workspaces, is only permitted to list those workspaces.
+	if ids := internal.RestrictedWorkspaceIDs(subject); ids != nil {
+		all, err := resource.ListAll(func(pageOpts resource.PageOptions) (*resource.Page[*Workspace], error) {
+			allOpts := opts
+			allOpts.PageOptions = pageOpts
+			return s.listWorkspaces(ctx, subject, allOpts)
+		})
+		if err != nil {
+			return nil, err
+		}
+		var permitted []*Workspace
+		for _, ws := range all {
+			if slices.Contains(ids, ws.ID) {
+				permitted = append(permitted, ws)
+			}
+		}
+		return resource.NewPage(permitted, opts.PageOptions, nil), nil
+	}
+	return s.listWorkspaces(ctx, subject, opts)
+}
+
+func (s *service) listWorkspaces(ctx context.Context, subject internal.Subject, opts ListOptions) (*resource.Page[*Workspace], error) {
 	if opts.Organization == nil {
 		// subject needs perms on site to list workspaces across site
 		_, err := s.site.CanAccess(ctx, rbac.ListWorkspacesAction, "")
@@ -221,12 +252,8 @@ func (s *service) ListWorkspaces(ctx context.Context, opts ListOptions) (*resour
 		if err == internal.ErrAccessNotPermitted {
 			// user does not have org-wide perms; fallback to listing workspaces
 			// for which they have workspace-level perms.
-			subject, err := internal.SubjectFromContext(ctx)
-			if err != nil {
-				return nil, err
-			}
-			if user, ok := subject.(*auth.User); ok {
-				return s.db.listByUsername(ctx, user.Username, *opts.Organization, opts.PageOptions)
+			if user, ok := internal.UnwrapSubject(subject).(*auth.User); ok {
+				return s.db.listByUsername(ctx, user.Username, *opts.Organization, opts)
 			}
 		} else if err != nil {
 			return nil, err
@@ -7,6 +7,8 @@ import (
 	"github.com/leg100/otf/internal"
 	"github.com/leg100/otf/internal/auth"
 	"github.com/leg100/otf/internal/http/html"
+	"github.com/leg100/otf/internal/project"
+	"github.com/leg100/otf/internal/rbac"
 	"github.com/leg100/otf/internal/resource"
 	"github.com/leg100/otf/internal/vcs"
 	"github.com/leg100/otf/internal/vcsprovider"
@@ -20,11 +22,14 @@ type (
 		repos      []string
 		policy     internal.WorkspacePolicy
 		teams      []*auth.Team
+		role       rbac.Role // role set via SetPermission
+		projects   []*project.Project
 
 		Service
 
 		auth.TeamService
 		VCSProviderService
+		project.ProjectService
 	}
 
 	fakeWebServiceOption func(*fakeWebService)
@@ -60,6 +65,12 @@ func withTeams(teams ...*auth.Team) fakeWebServiceOption {
 	}
 }
 
+func withProjects(projects ...*project.Project) fakeWebServiceOption {
+	return func(svc *fakeWebService) {
+		svc.projects = projects
+	}
+}
+
 func fakeWebHandlers(t *testing.T, opts ...fakeWebServiceOption) *webHandlers {
 	renderer, err := html.NewRenderer(false)
 	require.NoError(t, err)
@@ -73,6 +84,7 @@ func fakeWebHandlers(t *testing.T, opts ...fakeWebServiceOption) *webHandlers {
 		Renderer:           renderer,
 		TeamService:        &svc,
 		VCSProviderService: &svc,
+		ProjectService:     &svc,
 		svc:                &svc,
 	}
 }
@@ -93,10 +105,19 @@ func (f *fakeWebService) GetPolicy(context.Context, string) (internal.WorkspaceP
 	return f.policy, nil
 }
 
+func (f *fakeWebService) SetPermission(ctx context.Context, workspaceID, team string, role rbac.Role) error {
+	f.role = role
+	return nil
+}
+
 func (f *fakeWebService) ListTeams(context.Context, string) ([]*auth.Team, error) {
 	return f.teams, nil
 }
 
+func (f *fakeWebService) ListProjects(ctx context.Context, opts project.ListOptions) (*resource.Page[*project.Project], error) {
+	return resource.NewPage(f.projects, opts.PageOptions, nil), nil
+}
+
 func (f *fakeWebService) GetVCSClient(ctx context.Context, providerID string) (vcs.Client, error) {
 	return &fakeWebCloudClient{repos: f.repos}, nil
 }
@@ -82,6 +82,9 @@ func (a *tfe) createWorkspace(w http.ResponseWriter, r *http.Request) {
 		// convert from json:api structs to tag specs
 		Tags: toTagSpecs(params.Tags),
 	}
+	if params.Project != nil {
+		opts.ProjectID = &params.Project.ID
+	}
 	// Always trigger runs if neither trigger patterns nor tags regex are set
 	if len(params.TriggerPatterns) == 0 && (params.VCSRepo == nil || params.VCSRepo.TagsRegex == nil) {
 		opts.AlwaysTrigger = internal.Bool(true)
@@ -181,12 +184,16 @@ func (a *tfe) listWorkspaces(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	page, err := a.ListWorkspaces(r.Context(), ListOptions{
+	opts := ListOptions{
 		Search:       params.Search,
 		Organization: &organization,
 		PageOptions:  resource.PageOptions(params.ListOptions),
 		Tags:         internal.SplitCSV(params.Tags),
-	})
+	}
+	if params.ProjectID != "" {
+		opts.ProjectID = &params.ProjectID
+	}
+	page, err := a.ListWorkspaces(r.Context(), opts)
 	if err != nil {
 		tfeapi.Error(w, err)
 		return
@@ -329,6 +336,9 @@ func (a *tfe) updateWorkspace(w http.ResponseWriter, r *http.Request, workspaceI
 		TriggerPatterns:            params.TriggerPatterns,
 		WorkingDirectory:           params.WorkingDirectory,
 	}
+	if params.Project != nil {
+		opts.ProjectID = &params.Project.ID
+	}
 
 	// If file-triggers-enabled is set to false and tags regex is unspecified
 	// then enable always trigger runs for this workspace.
@@ -456,6 +466,9 @@ func (a *tfe) convert(from *Workspace, r *http.Request) (*types.Workspace, error
 	if from.LatestRun != nil {
 		to.CurrentRun = &types.Run{ID: from.LatestRun.ID}
 	}
+	if from.ProjectID != "" {
+		to.Project = &types.Project{ID: from.ProjectID}
+	}
 
 	// Add VCS repo to json:api struct if connected. NOTE: the terraform CLI
 	// uses the presence of VCS repo to determine whether to allow a terraform
@@ -11,6 +11,7 @@ import (
 	"github.com/leg100/otf/internal/http/html"
 	"github.com/leg100/otf/internal/http/html/paths"
 	"github.com/leg100/otf/internal/organization"
+	"github.com/leg100/otf/internal/project"
 	"github.com/leg100/otf/internal/rbac"
 	"github.com/leg100/otf/internal/resource"
 	"github.com/leg100/otf/internal/vcs"
@@ -43,6 +44,7 @@ type (
 		html.Renderer
 		auth.TeamService
 		VCSProviderService
+		project.ProjectService
 
 		svc Service
 	}
@@ -53,6 +55,14 @@ type (
 
 		Workspace *Workspace
 	}
+
+	// customPermissionsOptions are the options from which to select custom
+	// workspace permissions.
+	customPermissionsOptions struct {
+		Runs          []rbac.RunsPermission
+		Variables     []rbac.VariablesPermission
+		StateVersions []rbac.StateVersionsPermission
+	}
 )
 
 func NewPage(r *http.Request, title string, workspace *Workspace) WorkspacePage {
@@ -89,6 +99,7 @@ func (h *webHandlers) listWorkspaces(w http.ResponseWriter, r *http.Request) {
 	var params struct {
 		Search       string   `schema:"search[name],omitempty"`
 		Tags         []string `schema:"search[tags],omitempty"`
+		ProjectID    string   `schema:"project_id,omitempty"`
 		Organization *string  `schema:"organization_name,required"`
 		PageNumber   int      `schema:"page[number]"`
 	}
@@ -97,7 +108,7 @@ func (h *webHandlers) listWorkspaces(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	workspaces, err := h.svc.ListWorkspaces(r.Context(), ListOptions{
+	opts := ListOptions{
 		Search:       params.Search,
 		Tags:         params.Tags,
 		Organization: params.Organization,
@@ -105,7 +116,18 @@ func (h *webHandlers) listWorkspaces(w http.ResponseWriter, r *http.Request) {
 			PageNumber: params.PageNumber,
 			PageSize:   html.PageSize,
 		},
-	})
+	}
+	if params.ProjectID != "" {
+		opts.ProjectID = &params.ProjectID
+	}
+	workspaces, err := h.svc.ListWorkspaces(r.Context(), opts)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// retrieve all projects for populating project filter
+	projects, err := h.listProjects(r, *params.Organization)
 	if err != nil {
 		h.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -147,6 +169,8 @@ func (h *webHandlers) listWorkspaces(w http.ResponseWriter, r *http.Request) {
 		*resource.Page[*Workspace]
 		TagFilters         map[string]bool
 		Search             string
+		Projects           []*project.Project
+		ProjectFilter      string
 		CanCreateWorkspace bool
 	}{
 		OrganizationPage:   organization.NewPage(r, "workspaces", *params.Organization),
@@ -154,6 +178,8 @@ func (h *webHandlers) listWorkspaces(w http.ResponseWriter, r *http.Request) {
 		Page:               workspaces,
 		TagFilters:         tagfilters(),
 		Search:             params.Search,
+		Projects:           projects,
+		ProjectFilter:      params.ProjectID,
 	}
 
 	if isHTMX := r.Header.Get("HX-Request"); isHTMX == "true" {
@@ -365,13 +391,21 @@ func (h *webHandlers) editWorkspace(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	projects, err := h.listProjects(r, workspace.Organization)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
 	h.Render("workspace_edit.tmpl", w, struct {
 		WorkspacePage
 		Policy             internal.WorkspacePolicy
 		Unassigned         []*auth.Team
 		Roles              []rbac.Role
+		CustomPermissions  customPermissionsOptions
 		VCSProvider        *vcsprovider.VCSProvider
 		UnassignedTags     []string
+		Projects           []*project.Project
 		CanUpdateWorkspace bool
 		CanDeleteWorkspace bool
 		VCSTagRegexDefault string
@@ -391,8 +425,14 @@ func (h *webHandlers) editWorkspace(w http.ResponseWriter, r *http.Request) {
 			rbac.WorkspaceWriteRole,
 			rbac.WorkspaceAdminRole,
 		},
+		CustomPermissions: customPermissionsOptions{
+			Runs:          rbac.RunsPermissions(),
+			Variables:     rbac.VariablesPermissions(),
+			StateVersions: rbac.StateVersionsPermissions(),
+		},
 		VCSProvider:        provider,
 		UnassignedTags:     internal.DiffStrings(getTagNames(), workspace.Tags),
+		Projects:           projects,
 		VCSTagRegexDefault: vcsTagRegexDefault,
 		VCSTagRegexPrefix:  vcsTagRegexPrefix,
 		VCSTagRegexSuffix:  vcsTagRegexSuffix,
@@ -415,6 +455,7 @@ func (h *webHandlers) updateWorkspace(w http.ResponseWriter, r *http.Request) {
 		WorkingDirectory  *string        `schema:"working_directory"`
 		WorkspaceID       string         `schema:"workspace_id,required"`
 		GlobalRemoteState bool           `schema:"global_remote_state"`
+		ProjectID         *string        `schema:"project_id"`
 
 		// VCS connection
 		VCSTriggerStrategy  string `schema:"vcs_trigger"`
@@ -423,6 +464,8 @@ func (h *webHandlers) updateWorkspace(w http.ResponseWriter, r *http.Request) {
 		PredefinedTagsRegex string `schema:"tags_regex"`
 		CustomTagsRegex     string `schema:"custom_tags_regex"`
 		AllowCLIApply       bool   `schema:"allow_cli_apply"`
+		TestPullRequests    bool   `schema:"test_pull_requests"`
+		CommentPullRequests bool   `schema:"comment_pull_requests"`
 	}
 	if err := decode.All(&params, r); err != nil {
 		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
@@ -444,12 +487,15 @@ func (h *webHandlers) updateWorkspace(w http.ResponseWriter, r *http.Request) {
 		TerraformVersion:  params.TerraformVersion,
 		WorkingDirectory:  params.WorkingDirectory,
 		GlobalRemoteState: &params.GlobalRemoteState,
+		ProjectID:         params.ProjectID,
 	}
 	if ws.Connection != nil {
 		// workspace is connected, so set connection fields
 		opts.ConnectOptions = &ConnectOptions{
-			AllowCLIApply: &params.AllowCLIApply,
-			Branch:        &params.VCSBranch,
+			AllowCLIApply:       &params.AllowCLIApply,
+			TestPullRequests:    &params.TestPullRequests,
+			CommentPullRequests: &params.CommentPullRequests,
+			Branch:              &params.VCSBranch,
 		}
 		switch params.VCSTriggerStrategy {
 		case VCSTriggerAlways:
@@ -661,15 +707,40 @@ func (h *webHandlers) setWorkspacePermission(w http.ResponseWriter, r *http.Requ
 		WorkspaceID string `schema:"workspace_id,required"`
 		TeamName    string `schema:"team_name,required"`
 		Role        string `schema:"role,required"`
+
+		// custom permissions, only applicable to the custom role
+		Runs             rbac.RunsPermission          `schema:"runs"`
+		Variables        rbac.VariablesPermission     `schema:"variables"`
+		StateVersions    rbac.StateVersionsPermission `schema:"state_versions"`
+		WorkspaceLocking bool                         `schema:"workspace_locking"`
+		RunTasks         bool                         `schema:"run_tasks"`
 	}
 	if err := decode.All(&params, r); err != nil {
 		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
 		return
 	}
-	role, err := rbac.WorkspaceRoleFromString(params.Role)
-	if err != nil {
-		h.Error(w, err.Error(), http.StatusInternalServerError)
-		return
+	var (
+		role rbac.Role
+		err  error
+	)
+	if params.Role == "custom" {
+		role, err = rbac.NewCustomRole(rbac.CustomPermissions{
+			Runs:             params.Runs,
+			Variables:        params.Variables,
+			StateVersions:    params.StateVersions,
+			WorkspaceLocking: params.WorkspaceLocking,
+			RunTasks:         params.RunTasks,
+		})
+		if err != nil {
+			h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+	} else {
+		role, err = rbac.WorkspaceRoleFromString(params.Role)
+		if err != nil {
+			h.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
 	}
 
 	err = h.svc.SetPermission(r.Context(), params.WorkspaceID, params.TeamName, role)
@@ -700,6 +771,16 @@ func (h *webHandlers) unsetWorkspacePermission(w http.ResponseWriter, r *http.Re
 	http.Redirect(w, r, paths.EditWorkspace(params.WorkspaceID), http.StatusFound)
 }
 
+// listProjects lists all projects in the organization.
+func (h *webHandlers) listProjects(r *http.Request, organization string) ([]*project.Project, error) {
+	return resource.ListAll(func(opts resource.PageOptions) (*resource.Page[*project.Project], error) {
+		return h.ListProjects(r.Context(), project.ListOptions{
+			Organization: organization,
+			PageOptions:  opts,
+		})
+	})
+}
+
 // filterUnassigned removes from the list of teams those that are part of the
 // policy, i.e. those that have been assigned a permission.
 //
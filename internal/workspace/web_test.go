@@ -154,6 +154,34 @@ func TestEditWorkspaceHandler(t *testing.T) {
 				findTextNot(t, doc, "//select[@form='permissions-add-form']/option[@value='owners']")
 			},
 		},
+		{
+			name: "with custom permission",
+			ws:   &Workspace{ID: "ws-123"},
+			user: auth.SiteAdmin,
+			policy: internal.WorkspacePolicy{
+				Permissions: []internal.WorkspacePermission{
+					{Team: "auditors", Role: newTestCustomRole(t, rbac.CustomPermissions{
+						Runs:             rbac.RunsRead,
+						Variables:        rbac.VariablesRead,
+						StateVersions:    rbac.StateVersionsReadOutputs,
+						WorkspaceLocking: true,
+					})},
+				},
+			},
+			want: func(t *testing.T, doc *html.Node) {
+				findText(t, doc, "custom", "//tr[@id='permissions-auditors']/td[2]//select[@name='role']/option[@selected]")
+				findText(t, doc, "read", "//tr[@id='permissions-auditors']//select[@name='runs']/option[@selected]")
+				findText(t, doc, "read", "//tr[@id='permissions-auditors']//select[@name='variables']/option[@selected]")
+				findText(t, doc, "read-outputs", "//tr[@id='permissions-auditors']//select[@name='state_versions']/option[@selected]")
+
+				locking := htmlquery.FindOne(doc, "//tr[@id='permissions-auditors']//input[@name='workspace_locking']")
+				require.NotNil(t, locking)
+				assert.Contains(t, testutils.AttrMap(locking), "checked")
+				runTasks := htmlquery.FindOne(doc, "//tr[@id='permissions-auditors']//input[@name='run_tasks']")
+				require.NotNil(t, runTasks)
+				assert.NotContains(t, testutils.AttrMap(runTasks), "checked")
+			},
+		},
 		{
 			name: "connected repo",
 			ws:   &Workspace{ID: "ws-123", Connection: &Connection{Repo: "leg100/otf"}},
@@ -189,6 +217,41 @@ func TestEditWorkspaceHandler(t *testing.T) {
 	}
 }
 
+func TestSetWorkspacePermissionHandler(t *testing.T) {
+	ws := &Workspace{ID: "ws-123", Organization: "acme-corp"}
+	app := fakeWebHandlers(t, withWorkspaces(ws))
+
+	form := strings.NewReader(url.Values{
+		"workspace_id":      {"ws-123"},
+		"team_name":         {"auditors"},
+		"role":              {"custom"},
+		"runs":              {"plan"},
+		"variables":         {"write"},
+		"state_versions":    {"read"},
+		"workspace_locking": {"true"},
+	}.Encode())
+	r := httptest.NewRequest("POST", "/", form)
+	r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	app.setWorkspacePermission(w, r)
+
+	if assert.Equal(t, 302, w.Code, w.Body.String()) {
+		want := newTestCustomRole(t, rbac.CustomPermissions{
+			Runs:             rbac.RunsPlan,
+			Variables:        rbac.VariablesWrite,
+			StateVersions:    rbac.StateVersionsRead,
+			WorkspaceLocking: true,
+		})
+		assert.Equal(t, want, app.svc.(*fakeWebService).role)
+	}
+}
+
+func newTestCustomRole(t *testing.T, perms rbac.CustomPermissions) rbac.Role {
+	role, err := rbac.NewCustomRole(perms)
+	require.NoError(t, err)
+	return role
+}
+
 func TestUpdateWorkspaceHandler(t *testing.T) {
 	ws := &Workspace{ID: "ws-123", Organization: "acme-corp"}
 	app := fakeWebHandlers(t, withWorkspaces(ws))
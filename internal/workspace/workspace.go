@@ -63,6 +63,7 @@ type (
 		LatestRun                  *LatestRun    `jsonapi:"attribute" json:"latest_run"`
 		Tags                       []string      `jsonapi:"attribute" json:"tags"`
 		Lock                       *Lock         `jsonapi:"attribute" json:"lock"`
+		ProjectID                  string        `jsonapi:"attribute" json:"project_id"`
 
 		// VCS Connection; nil means the workspace is not connected.
 		Connection *Connection
@@ -95,15 +96,23 @@ type (
 		// possible to run a terraform apply via the CLI. Setting this to true
 		// overrides this behaviour.
 		AllowCLIApply bool
+		// TestPullRequests, if true, triggers a test run upon a pull request
+		// event, in addition to a plan-only run.
+		TestPullRequests bool
+		// CommentPullRequests, if true, posts a comment on a pull request
+		// summarising the plan of each triggered run.
+		CommentPullRequests bool
 	}
 
 	ConnectOptions struct {
 		RepoPath      *string
 		VCSProviderID *string
 
-		Branch        *string
-		TagsRegex     *string
-		AllowCLIApply *bool
+		Branch              *string
+		TagsRegex           *string
+		AllowCLIApply       *bool
+		TestPullRequests    *bool
+		CommentPullRequests *bool
 	}
 
 	ExecutionMode string
@@ -128,6 +137,7 @@ type (
 		TriggerPatterns            []string
 		WorkingDirectory           *string
 		Organization               *string
+		ProjectID                  *string
 
 		// Always trigger runs. A value of true is mutually exclusive with
 		// setting TriggerPatterns or ConnectOptions.TagsRegex.
@@ -152,6 +162,10 @@ type (
 		TriggerPatterns            []string
 		WorkingDirectory           *string
 
+		// Move workspace to project with ID. An empty string removes the
+		// workspace from its project.
+		ProjectID *string
+
 		// Always trigger runs. A value of true is mutually exclusive with
 		// setting TriggerPatterns or ConnectOptions.TagsRegex.
 		AlwaysTrigger *bool
@@ -172,6 +186,7 @@ type (
 		Search       string
 		Tags         []string
 		Organization *string `schema:"organization_name"`
+		ProjectID    *string `schema:"filter[project][id]"`
 
 		resource.PageOptions
 	}
@@ -243,6 +258,9 @@ func NewWorkspace(opts CreateOptions) (*Workspace, error) {
 	if opts.WorkingDirectory != nil {
 		ws.WorkingDirectory = *opts.WorkingDirectory
 	}
+	if opts.ProjectID != nil {
+		ws.ProjectID = *opts.ProjectID
+	}
 	// TriggerPrefixes are not used but OTF persists it in order to pass go-tfe
 	// integration tests.
 	if opts.TriggerPrefixes != nil {
@@ -359,6 +377,10 @@ func (ws *Workspace) Update(opts UpdateOptions) (*bool, error) {
 		ws.WorkingDirectory = *opts.WorkingDirectory
 		updated = true
 	}
+	if opts.ProjectID != nil {
+		ws.ProjectID = *opts.ProjectID
+		updated = true
+	}
 	// TriggerPrefixes are not used but OTF persists it in order to pass go-tfe
 	// integration tests.
 	if opts.TriggerPrefixes != nil {
@@ -432,6 +454,14 @@ func (ws *Workspace) Update(opts UpdateOptions) (*bool, error) {
 				ws.Connection.AllowCLIApply = *opts.AllowCLIApply
 				updated = true
 			}
+			if opts.TestPullRequests != nil {
+				ws.Connection.TestPullRequests = *opts.TestPullRequests
+				updated = true
+			}
+			if opts.CommentPullRequests != nil {
+				ws.Connection.CommentPullRequests = *opts.CommentPullRequests
+				updated = true
+			}
 		}
 	}
 	if updated {
@@ -455,6 +485,12 @@ func (ws *Workspace) addConnection(opts *ConnectOptions) error {
 	if opts.AllowCLIApply != nil {
 		ws.Connection.AllowCLIApply = *opts.AllowCLIApply
 	}
+	if opts.TestPullRequests != nil {
+		ws.Connection.TestPullRequests = *opts.TestPullRequests
+	}
+	if opts.CommentPullRequests != nil {
+		ws.Connection.CommentPullRequests = *opts.CommentPullRequests
+	}
 	if opts.TagsRegex != nil {
 		if err := ws.setTagsRegex(*opts.TagsRegex); err != nil {
 			return fmt.Errorf("invalid tags-regex: %w", err)